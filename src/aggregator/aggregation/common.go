@@ -22,6 +22,7 @@ package aggregation
 
 import (
 	"math"
+	"time"
 
 	"github.com/m3db/m3/src/metrics/aggregation"
 )
@@ -43,3 +44,49 @@ func isExpensive(aggTypes aggregation.Types) bool {
 	}
 	return false
 }
+
+func timeToNanos(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+func timeFromNanos(nanos int64) time.Time {
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
+
+func marshalDistinct(distinct *HyperLogLog) ([]byte, error) {
+	if distinct == nil {
+		return nil, nil
+	}
+	return distinct.MarshalBinary()
+}
+
+func unmarshalDistinct(distinct *HyperLogLog, data []byte) error {
+	if distinct == nil || len(data) == 0 {
+		return nil
+	}
+	return distinct.UnmarshalBinary(data)
+}
+
+func appendDistinct(distinct *HyperLogLog, dst []float64) []float64 {
+	if distinct == nil {
+		return dst
+	}
+	return distinct.AppendFloats(dst)
+}
+
+func mergeDistinct(distinct *HyperLogLog, values []float64) error {
+	if distinct == nil {
+		return nil
+	}
+	var sketch HyperLogLog
+	if err := sketch.UnmarshalFloats(values); err != nil {
+		return err
+	}
+	return distinct.Merge(&sketch)
+}
@@ -24,6 +24,7 @@ import (
 	"math"
 	"time"
 
+	"github.com/m3db/m3/src/aggregator/generated/proto/snapshot"
 	"github.com/m3db/m3/src/metrics/aggregation"
 )
 
@@ -37,15 +38,21 @@ type Counter struct {
 	count  int64
 	max    int64
 	min    int64
+
+	distinct *HyperLogLog
 }
 
 // NewCounter creates a new counter.
 func NewCounter(opts Options) Counter {
-	return Counter{
+	c := Counter{
 		Options: opts,
 		max:     math.MinInt64,
 		min:     math.MaxInt64,
 	}
+	if opts.HasCountDistinct {
+		c.distinct = newDefaultHyperLogLog()
+	}
+	return c
 }
 
 // Update updates the counter value.
@@ -72,6 +79,10 @@ func (c *Counter) Update(timestamp time.Time, value int64) {
 	if c.HasExpensiveAggregations {
 		c.sumSq += value * value
 	}
+
+	if c.distinct != nil {
+		c.distinct.Add(float64(value))
+	}
 }
 
 // LastAt returns the time of the last value received.
@@ -105,6 +116,32 @@ func (c *Counter) Min() int64 { return c.min }
 // Max returns the maximum counter value.
 func (c *Counter) Max() int64 { return c.max }
 
+// CountDistinct returns the estimated number of distinct counter values.
+func (c *Counter) CountDistinct() uint64 {
+	if c.distinct == nil {
+		return 0
+	}
+	return c.distinct.Count()
+}
+
+// AppendDistinct appends the sketch of distinct counter values encoded by
+// HyperLogLog.AppendFloats to dst, if distinct values are counted.
+func (c *Counter) AppendDistinct(dst []float64) []float64 {
+	return appendDistinct(c.distinct, dst)
+}
+
+// MergeDistinct merges a sketch of distinct values encoded by
+// HyperLogLog.AppendFloats into the sketch of distinct counter values.
+func (c *Counter) MergeDistinct(timestamp time.Time, values []float64) error {
+	if err := mergeDistinct(c.distinct, values); err != nil {
+		return err
+	}
+	if c.lastAt.IsZero() || timestamp.After(c.lastAt) {
+		c.lastAt = timestamp
+	}
+	return nil
+}
+
 // ValueOf returns the value for the aggregation type.
 func (c *Counter) ValueOf(aggType aggregation.Type) float64 {
 	switch aggType {
@@ -122,10 +159,42 @@ func (c *Counter) ValueOf(aggType aggregation.Type) float64 {
 		return float64(c.SumSq())
 	case aggregation.Stdev:
 		return c.Stdev()
+	case aggregation.CountDistinct:
+		return float64(c.CountDistinct())
 	default:
 		return 0
 	}
 }
 
+// ToProto converts the counter to a protobuf message in place.
+func (c *Counter) ToProto(pb *snapshot.CounterSnapshot) error {
+	distinct, err := marshalDistinct(c.distinct)
+	if err != nil {
+		return err
+	}
+	pb.LastAtNanos = timeToNanos(c.lastAt)
+	pb.Sum = c.sum
+	pb.SumSq = c.sumSq
+	pb.Count = c.count
+	pb.Max = c.max
+	pb.Min = c.min
+	pb.Distinct = distinct
+	return nil
+}
+
+// FromProto restores a newly created counter from a protobuf message.
+func (c *Counter) FromProto(pb snapshot.CounterSnapshot) error {
+	if err := unmarshalDistinct(c.distinct, pb.Distinct); err != nil {
+		return err
+	}
+	c.lastAt = timeFromNanos(pb.LastAtNanos)
+	c.sum = pb.Sum
+	c.sumSq = pb.SumSq
+	c.count = pb.Count
+	c.max = pb.Max
+	c.min = pb.Min
+	return nil
+}
+
 // Close closes the counter.
 func (c *Counter) Close() {}
@@ -24,6 +24,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/m3db/m3/src/aggregator/generated/proto/snapshot"
 	"github.com/m3db/m3/src/metrics/aggregation"
 	"github.com/m3db/m3/src/x/instrument"
 
@@ -45,6 +46,7 @@ func TestCounterDefaultAggregationType(t *testing.T) {
 func TestCounterCustomAggregationType(t *testing.T) {
 	opts := NewOptions(instrument.NewOptions())
 	opts.HasExpensiveAggregations = true
+	opts.HasCountDistinct = true
 
 	c := NewCounter(opts)
 	require.True(t, c.HasExpensiveAggregations)
@@ -70,9 +72,41 @@ func TestCounterCustomAggregationType(t *testing.T) {
 			require.Equal(t, float64(338350), v)
 		case aggregation.Stdev:
 			require.InDelta(t, 29.01149, v, 0.001)
+		case aggregation.CountDistinct:
+			require.Equal(t, float64(100), v)
 		default:
 			require.Equal(t, float64(0), v)
 			require.False(t, aggType.IsValidForCounter())
 		}
 	}
 }
+
+func TestCounterProtoRoundTrip(t *testing.T) {
+	opts := NewOptions(instrument.NewOptions())
+	opts.ResetSetData(aggregation.Types{aggregation.Max, aggregation.Stdev, aggregation.CountDistinct})
+
+	c := NewCounter(opts)
+	at := time.Unix(0, 1234)
+	for i := 1; i <= 100; i++ {
+		c.Update(at, int64(i%50))
+	}
+
+	var pb snapshot.CounterSnapshot
+	require.NoError(t, c.ToProto(&pb))
+	require.Equal(t, int64(1234), pb.LastAtNanos)
+
+	restored := NewCounter(opts)
+	require.NoError(t, restored.FromProto(pb))
+	require.Equal(t, at, restored.LastAt())
+	require.Equal(t, c.Sum(), restored.Sum())
+	require.Equal(t, c.SumSq(), restored.SumSq())
+	require.Equal(t, c.Count(), restored.Count())
+	require.Equal(t, c.Min(), restored.Min())
+	require.Equal(t, c.Max(), restored.Max())
+	require.Equal(t, uint64(50), restored.CountDistinct())
+
+	// Values added after restoring the counter are aggregated with the snapshot.
+	restored.Update(at, 100)
+	require.Equal(t, float64(100), restored.ValueOf(aggregation.Max))
+	require.Equal(t, uint64(51), restored.CountDistinct())
+}
@@ -24,6 +24,7 @@ import (
 	"math"
 	"time"
 
+	"github.com/m3db/m3/src/aggregator/generated/proto/snapshot"
 	"github.com/m3db/m3/src/metrics/aggregation"
 )
 
@@ -42,15 +43,21 @@ type Gauge struct {
 	count  int64
 	max    float64
 	min    float64
+
+	distinct *HyperLogLog
 }
 
 // NewGauge creates a new gauge.
 func NewGauge(opts Options) Gauge {
-	return Gauge{
+	g := Gauge{
 		Options: opts,
 		max:     minFloat64,
 		min:     math.MaxFloat64,
 	}
+	if opts.HasCountDistinct {
+		g.distinct = newDefaultHyperLogLog()
+	}
+	return g
 }
 
 // Update updates the gauge value.
@@ -77,6 +84,10 @@ func (g *Gauge) Update(timestamp time.Time, value float64) {
 	if g.HasExpensiveAggregations {
 		g.sumSq += value * value
 	}
+
+	if g.distinct != nil {
+		g.distinct.Add(value)
+	}
 }
 
 // LastAt returns the time of the last value received.
@@ -113,6 +124,32 @@ func (g *Gauge) Min() float64 { return g.min }
 // Max returns the maximum gauge value.
 func (g *Gauge) Max() float64 { return g.max }
 
+// CountDistinct returns the estimated number of distinct gauge values.
+func (g *Gauge) CountDistinct() uint64 {
+	if g.distinct == nil {
+		return 0
+	}
+	return g.distinct.Count()
+}
+
+// AppendDistinct appends the sketch of distinct gauge values encoded by
+// HyperLogLog.AppendFloats to dst, if distinct values are counted.
+func (g *Gauge) AppendDistinct(dst []float64) []float64 {
+	return appendDistinct(g.distinct, dst)
+}
+
+// MergeDistinct merges a sketch of distinct values encoded by
+// HyperLogLog.AppendFloats into the sketch of distinct gauge values.
+func (g *Gauge) MergeDistinct(timestamp time.Time, values []float64) error {
+	if err := mergeDistinct(g.distinct, values); err != nil {
+		return err
+	}
+	if g.lastAt.IsZero() || timestamp.After(g.lastAt) {
+		g.lastAt = timestamp
+	}
+	return nil
+}
+
 // ValueOf returns the value for the aggregation type.
 func (g *Gauge) ValueOf(aggType aggregation.Type) float64 {
 	switch aggType {
@@ -132,10 +169,44 @@ func (g *Gauge) ValueOf(aggType aggregation.Type) float64 {
 		return g.SumSq()
 	case aggregation.Stdev:
 		return g.Stdev()
+	case aggregation.CountDistinct:
+		return float64(g.CountDistinct())
 	default:
 		return 0
 	}
 }
 
+// ToProto converts the gauge to a protobuf message in place.
+func (g *Gauge) ToProto(pb *snapshot.GaugeSnapshot) error {
+	distinct, err := marshalDistinct(g.distinct)
+	if err != nil {
+		return err
+	}
+	pb.LastAtNanos = timeToNanos(g.lastAt)
+	pb.Last = g.last
+	pb.Sum = g.sum
+	pb.SumSq = g.sumSq
+	pb.Count = g.count
+	pb.Max = g.max
+	pb.Min = g.min
+	pb.Distinct = distinct
+	return nil
+}
+
+// FromProto restores a newly created gauge from a protobuf message.
+func (g *Gauge) FromProto(pb snapshot.GaugeSnapshot) error {
+	if err := unmarshalDistinct(g.distinct, pb.Distinct); err != nil {
+		return err
+	}
+	g.lastAt = timeFromNanos(pb.LastAtNanos)
+	g.last = pb.Last
+	g.sum = pb.Sum
+	g.sumSq = pb.SumSq
+	g.count = pb.Count
+	g.max = pb.Max
+	g.min = pb.Min
+	return nil
+}
+
 // Close closes the gauge.
 func (g *Gauge) Close() {}
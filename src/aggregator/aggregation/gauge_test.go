@@ -24,6 +24,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/m3db/m3/src/aggregator/generated/proto/snapshot"
 	"github.com/m3db/m3/src/metrics/aggregation"
 	"github.com/m3db/m3/src/x/instrument"
 
@@ -47,6 +48,7 @@ func TestGaugeDefaultAggregationType(t *testing.T) {
 func TestGaugeCustomAggregationType(t *testing.T) {
 	opts := NewOptions(instrument.NewOptions())
 	opts.HasExpensiveAggregations = true
+	opts.HasCountDistinct = true
 
 	g := NewGauge(opts)
 	require.True(t, g.HasExpensiveAggregations)
@@ -75,6 +77,8 @@ func TestGaugeCustomAggregationType(t *testing.T) {
 			require.Equal(t, float64(338350), v)
 		case aggregation.Stdev:
 			require.InDelta(t, 29.01149, v, 0.001)
+		case aggregation.CountDistinct:
+			require.Equal(t, float64(100), v)
 		default:
 			require.Equal(t, float64(0), v)
 			require.False(t, aggType.IsValidForGauge())
@@ -103,3 +107,47 @@ func TestGaugeLastOutOfOrderValues(t *testing.T) {
 	require.True(t, ok)
 	require.Equal(t, int64(2), counter.Value())
 }
+
+func TestGaugeProtoRoundTrip(t *testing.T) {
+	opts := NewOptions(instrument.NewOptions())
+	opts.ResetSetData(aggregation.Types{aggregation.Last, aggregation.Stdev, aggregation.CountDistinct})
+
+	g := NewGauge(opts)
+	for i := 1; i <= 100; i++ {
+		g.Update(time.Unix(0, int64(i)), float64(i))
+	}
+
+	var pb snapshot.GaugeSnapshot
+	require.NoError(t, g.ToProto(&pb))
+
+	restored := NewGauge(opts)
+	require.NoError(t, restored.FromProto(pb))
+	require.Equal(t, time.Unix(0, 100), restored.LastAt())
+	require.Equal(t, 100.0, restored.Last())
+	require.Equal(t, g.Sum(), restored.Sum())
+	require.Equal(t, g.SumSq(), restored.SumSq())
+	require.Equal(t, g.Count(), restored.Count())
+	require.Equal(t, 1.0, restored.Min())
+	require.Equal(t, 100.0, restored.Max())
+	require.Equal(t, g.CountDistinct(), restored.CountDistinct())
+	require.Equal(t, g.Stdev(), restored.Stdev())
+}
+
+func TestGaugeFromProtoEmpty(t *testing.T) {
+	opts := NewOptions(instrument.NewOptions())
+	g := NewGauge(opts)
+
+	var pb snapshot.GaugeSnapshot
+	require.NoError(t, g.ToProto(&pb))
+
+	restored := NewGauge(opts)
+	require.NoError(t, restored.FromProto(pb))
+	require.True(t, restored.LastAt().IsZero())
+	require.Equal(t, int64(0), restored.Count())
+
+	// The first value received after restoring an empty gauge is its last value.
+	restored.Update(time.Unix(0, 1), 5.0)
+	require.Equal(t, 5.0, restored.Last())
+	require.Equal(t, 5.0, restored.Min())
+	require.Equal(t, 5.0, restored.Max())
+}
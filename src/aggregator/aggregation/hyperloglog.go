@@ -0,0 +1,220 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aggregation
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+const (
+	// defaultHyperLogLogPrecision uses 4096 registers for a standard error
+	// of around 1.6% while keeping each sketch at 4kb.
+	defaultHyperLogLogPrecision = 12
+
+	minHyperLogLogPrecision = 4
+	maxHyperLogLogPrecision = 18
+
+	// hyperLogLogRankBits is the number of bits needed for a register rank,
+	// which is at most 64 - minHyperLogLogPrecision + 1.
+	hyperLogLogRankBits = 6
+)
+
+var (
+	errHyperLogLogPrecisionMismatch = errors.New("hyperloglog precision mismatch")
+	errHyperLogLogInvalidData       = errors.New("invalid hyperloglog data")
+)
+
+// HyperLogLog is a HyperLogLog sketch estimating the number of distinct
+// values added to it. Sketches with the same precision can be merged, e.g.
+// to combine the sketches built by different aggregator shards.
+type HyperLogLog struct {
+	precision uint8
+	registers []uint8
+}
+
+// NewHyperLogLog creates a new HyperLogLog sketch with 2^precision registers.
+func NewHyperLogLog(precision uint8) (*HyperLogLog, error) {
+	if precision < minHyperLogLogPrecision || precision > maxHyperLogLogPrecision {
+		return nil, fmt.Errorf("invalid hyperloglog precision %d, must be between %d and %d",
+			precision, minHyperLogLogPrecision, maxHyperLogLogPrecision)
+	}
+	return &HyperLogLog{
+		precision: precision,
+		registers: make([]uint8, 1<<precision),
+	}, nil
+}
+
+func newDefaultHyperLogLog() *HyperLogLog {
+	h, _ := NewHyperLogLog(defaultHyperLogLogPrecision)
+	return h
+}
+
+// Add adds a value to the sketch.
+func (h *HyperLogLog) Add(value float64) {
+	if value == 0 {
+		// Positive and negative zero are the same value.
+		value = 0
+	}
+	hash := mix64(math.Float64bits(value))
+	idx := hash >> (64 - h.precision)
+	rank := uint8(bits.LeadingZeros64(hash<<h.precision|1<<(h.precision-1)) + 1)
+	if rank > h.registers[idx] {
+		h.registers[idx] = rank
+	}
+}
+
+// Merge merges another sketch with the same precision into the sketch.
+func (h *HyperLogLog) Merge(other *HyperLogLog) error {
+	if h.precision != other.precision {
+		return errHyperLogLogPrecisionMismatch
+	}
+	for i, rank := range other.registers {
+		if rank > h.registers[i] {
+			h.registers[i] = rank
+		}
+	}
+	return nil
+}
+
+// Count returns the estimated number of distinct values added to the sketch.
+func (h *HyperLogLog) Count() uint64 {
+	var (
+		m     = float64(len(h.registers))
+		sum   float64
+		zeros int
+	)
+	for _, rank := range h.registers {
+		sum += 1.0 / float64(uint64(1)<<rank)
+		if rank == 0 {
+			zeros++
+		}
+	}
+
+	estimate := hyperLogLogAlpha(len(h.registers)) * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		// Use linear counting for small cardinalities.
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(estimate + 0.5)
+}
+
+// Reset resets the sketch.
+func (h *HyperLogLog) Reset() {
+	for i := range h.registers {
+		h.registers[i] = 0
+	}
+}
+
+// MarshalBinary returns the binary encoding of the sketch.
+func (h *HyperLogLog) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 1+len(h.registers))
+	data = append(data, h.precision)
+	return append(data, h.registers...), nil
+}
+
+// UnmarshalBinary decodes a binary encoded sketch into the sketch.
+func (h *HyperLogLog) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return errHyperLogLogInvalidData
+	}
+	precision := data[0]
+	if precision < minHyperLogLogPrecision || precision > maxHyperLogLogPrecision ||
+		len(data)-1 != 1<<precision {
+		return errHyperLogLogInvalidData
+	}
+	h.precision = precision
+	h.registers = append(h.registers[:0], data[1:]...)
+	return nil
+}
+
+// AppendFloats appends the sketch to dst as its precision followed by the
+// index and rank of each non-zero register packed into a single value, so
+// the sketch can be forwarded to the next aggregation stage as metric values.
+func (h *HyperLogLog) AppendFloats(dst []float64) []float64 {
+	dst = append(dst, float64(h.precision))
+	for i, rank := range h.registers {
+		if rank != 0 {
+			dst = append(dst, float64(i<<hyperLogLogRankBits|int(rank)))
+		}
+	}
+	return dst
+}
+
+// UnmarshalFloats decodes a sketch encoded by AppendFloats into the sketch.
+func (h *HyperLogLog) UnmarshalFloats(values []float64) error {
+	if len(values) == 0 {
+		return errHyperLogLogInvalidData
+	}
+	precision := values[0]
+	if precision < minHyperLogLogPrecision || precision > maxHyperLogLogPrecision ||
+		precision != math.Trunc(precision) {
+		return errHyperLogLogInvalidData
+	}
+	h.precision = uint8(precision)
+	numRegisters := 1 << h.precision
+	if cap(h.registers) < numRegisters {
+		h.registers = make([]uint8, numRegisters)
+	}
+	h.registers = h.registers[:numRegisters]
+	h.Reset()
+	for _, v := range values[1:] {
+		if v < 0 || v != math.Trunc(v) {
+			return errHyperLogLogInvalidData
+		}
+		var (
+			packed = int(v)
+			idx    = packed >> hyperLogLogRankBits
+			rank   = uint8(packed & (1<<hyperLogLogRankBits - 1))
+		)
+		if idx >= numRegisters {
+			return errHyperLogLogInvalidData
+		}
+		h.registers[idx] = rank
+	}
+	return nil
+}
+
+func hyperLogLogAlpha(m int) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	default:
+		return 0.7213 / (1 + 1.079/float64(m))
+	}
+}
+
+// mix64 is the 64-bit finalizer of MurmurHash3, which spreads the bits of
+// similar values, e.g. consecutive integers, across the whole hash.
+func mix64(k uint64) uint64 {
+	k ^= k >> 33
+	k *= 0xff51afd7ed558ccd
+	k ^= k >> 33
+	k *= 0xc4ceb9fe1a85ec53
+	k ^= k >> 33
+	return k
+}
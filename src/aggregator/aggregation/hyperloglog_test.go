@@ -0,0 +1,120 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aggregation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHyperLogLogInvalidPrecision(t *testing.T) {
+	_, err := NewHyperLogLog(minHyperLogLogPrecision - 1)
+	require.Error(t, err)
+
+	_, err = NewHyperLogLog(maxHyperLogLogPrecision + 1)
+	require.Error(t, err)
+}
+
+func TestHyperLogLogCount(t *testing.T) {
+	h := newDefaultHyperLogLog()
+	require.Equal(t, uint64(0), h.Count())
+
+	h.Add(0)
+	h.Add(math.Copysign(0, -1))
+	require.Equal(t, uint64(1), h.Count())
+
+	for _, n := range []int{10, 1000, 100000, 1000000} {
+		h.Reset()
+		for i := 0; i < n; i++ {
+			// Add each value twice to make sure duplicates are not counted.
+			h.Add(float64(i))
+			h.Add(float64(i))
+		}
+		require.InEpsilon(t, float64(n), float64(h.Count()), 0.05, "n=%d", n)
+	}
+}
+
+func TestHyperLogLogMerge(t *testing.T) {
+	var (
+		a = newDefaultHyperLogLog()
+		b = newDefaultHyperLogLog()
+	)
+	for i := 0; i < 20000; i++ {
+		a.Add(float64(i))
+	}
+	for i := 10000; i < 30000; i++ {
+		b.Add(float64(i))
+	}
+
+	require.NoError(t, a.Merge(b))
+	require.InEpsilon(t, 30000, float64(a.Count()), 0.05)
+
+	other, err := NewHyperLogLog(defaultHyperLogLogPrecision + 1)
+	require.NoError(t, err)
+	require.Equal(t, errHyperLogLogPrecisionMismatch, a.Merge(other))
+}
+
+func TestHyperLogLogMarshalRoundtrip(t *testing.T) {
+	h := newDefaultHyperLogLog()
+	for i := 0; i < 5000; i++ {
+		h.Add(float64(i) * 1.5)
+	}
+
+	data, err := h.MarshalBinary()
+	require.NoError(t, err)
+
+	var decoded HyperLogLog
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	require.Equal(t, h.Count(), decoded.Count())
+	require.NoError(t, decoded.Merge(h))
+	require.Equal(t, h.Count(), decoded.Count())
+
+	require.Equal(t, errHyperLogLogInvalidData, decoded.UnmarshalBinary(nil))
+	require.Equal(t, errHyperLogLogInvalidData, decoded.UnmarshalBinary(data[:len(data)-1]))
+}
+
+func TestHyperLogLogFloatsRoundtrip(t *testing.T) {
+	h := newDefaultHyperLogLog()
+	for i := 0; i < 5000; i++ {
+		h.Add(float64(i) * 1.5)
+	}
+
+	values := h.AppendFloats(nil)
+	require.Equal(t, float64(defaultHyperLogLogPrecision), values[0])
+
+	var decoded HyperLogLog
+	require.NoError(t, decoded.UnmarshalFloats(values))
+	require.Equal(t, h.registers, decoded.registers)
+	require.Equal(t, h.Count(), decoded.Count())
+
+	// An empty sketch is encoded as its precision alone.
+	h.Reset()
+	require.Equal(t, []float64{defaultHyperLogLogPrecision}, h.AppendFloats(nil))
+	require.NoError(t, decoded.UnmarshalFloats(h.AppendFloats(nil)))
+	require.Equal(t, uint64(0), decoded.Count())
+
+	require.Equal(t, errHyperLogLogInvalidData, decoded.UnmarshalFloats(nil))
+	require.Equal(t, errHyperLogLogInvalidData, decoded.UnmarshalFloats([]float64{2}))
+	require.Equal(t, errHyperLogLogInvalidData, decoded.UnmarshalFloats([]float64{4, 16 << hyperLogLogRankBits}))
+	require.Equal(t, errHyperLogLogInvalidData, decoded.UnmarshalFloats([]float64{4, 1.5}))
+}
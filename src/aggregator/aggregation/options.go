@@ -29,6 +29,7 @@ import (
 
 var (
 	defaultHasExpensiveAggregations = false
+	defaultHasCountDistinct         = false
 )
 
 // Options is the options for aggregations.
@@ -36,6 +37,11 @@ type Options struct {
 	// HasExpensiveAggregations means expensive (multiplication／division)
 	// aggregation types are enabled.
 	HasExpensiveAggregations bool
+	// HasCountDistinct means values are added to a HyperLogLog sketch to
+	// estimate the number of distinct values.
+	HasCountDistinct bool
+	// QuantileSketch is the data structure timers use to estimate quantiles.
+	QuantileSketch QuantileSketchType
 	// Metrics is as set of aggregation metrics.
 	Metrics Metrics
 }
@@ -95,6 +101,7 @@ func (m GaugeMetrics) IncValuesOutOfOrder() {
 func NewOptions(instrumentOpts instrument.Options) Options {
 	return Options{
 		HasExpensiveAggregations: defaultHasExpensiveAggregations,
+		HasCountDistinct:         defaultHasCountDistinct,
 		Metrics:                  NewMetrics(instrumentOpts.MetricsScope()),
 	}
 }
@@ -102,4 +109,5 @@ func NewOptions(instrumentOpts instrument.Options) Options {
 // ResetSetData resets the aggregation options.
 func (o *Options) ResetSetData(aggTypes aggregation.Types) {
 	o.HasExpensiveAggregations = isExpensive(aggTypes)
+	o.HasCountDistinct = aggTypes.Contains(aggregation.CountDistinct)
 }
@@ -187,6 +187,27 @@ func (s *stream) ResetSetData(quantiles []float64) {
 	s.compressMinRank = 0
 }
 
+func (s *stream) AppendSamples(dst []SampleData) []SampleData {
+	s.Flush()
+	for sample := s.samples.Front(); sample != nil; sample = sample.next {
+		dst = append(dst, SampleData{
+			Value:    sample.value,
+			NumRanks: sample.numRanks,
+			Delta:    sample.delta,
+		})
+	}
+	return dst
+}
+
+func (s *stream) RestoreSamples(samples []SampleData) {
+	for _, data := range samples {
+		sample := s.acquireSampleFn()
+		sample.setData(data.Value, data.NumRanks, data.Delta)
+		s.samples.PushBack(sample)
+		s.numValues += data.NumRanks
+	}
+}
+
 func (s *stream) Close() {
 	if s.closed {
 		return
@@ -97,6 +97,36 @@ func TestStreamWithThreeSamples(t *testing.T) {
 	}
 }
 
+func TestStreamRestoreSamples(t *testing.T) {
+	opts := testStreamOptions()
+	s := NewStream(testQuantiles, opts)
+	for i := 0; i < 1000; i++ {
+		s.Add(float64(i))
+	}
+	samples := s.AppendSamples(nil)
+	require.True(t, len(samples) < 1000)
+
+	restored := NewStream(testQuantiles, opts)
+	restored.RestoreSamples(samples)
+	require.Equal(t, samples, restored.AppendSamples(nil))
+	require.Equal(t, s.Min(), restored.Min())
+	require.Equal(t, s.Max(), restored.Max())
+	for _, q := range testQuantiles {
+		require.Equal(t, s.Quantile(q), restored.Quantile(q))
+	}
+
+	// Values added after restoring the samples are merged into them.
+	for i := 1000; i < 2000; i++ {
+		s.Add(float64(i))
+		restored.Add(float64(i))
+	}
+	s.Flush()
+	restored.Flush()
+	for _, q := range testQuantiles {
+		require.InDelta(t, s.Quantile(q), restored.Quantile(q), 2000*opts.Eps())
+	}
+}
+
 func TestStreamWithIncreasingSamplesNoPeriodicInsertCompressNoPeriodicFlush(t *testing.T) {
 	opts := testStreamOptions()
 	testStreamWithIncreasingSamples(t, opts)
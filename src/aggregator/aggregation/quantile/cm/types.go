@@ -31,6 +31,14 @@ type Sample struct {
 	next     *Sample // next sample
 }
 
+// SampleData is the data of a sample, used to snapshot and restore the
+// samples of a stream.
+type SampleData struct {
+	Value    float64
+	NumRanks int64
+	Delta    int64
+}
+
 // SamplePool is a pool of samples.
 type SamplePool interface {
 	// Init initializes the pool.
@@ -65,6 +73,14 @@ type Stream interface {
 
 	// ResetSetData resets the stream and sets data.
 	ResetSetData(quantiles []float64)
+
+	// AppendSamples flushes the internal buffer and appends the samples of
+	// the stream in ascending order of their values.
+	AppendSamples(dst []SampleData) []SampleData
+
+	// RestoreSamples restores the samples appended by AppendSamples into an
+	// empty stream.
+	RestoreSamples(samples []SampleData)
 }
 
 // StreamAlloc allocates a stream.
@@ -114,6 +114,10 @@ func (d *tDigest) Add(value float64) {
 	d.add(value, 1.0)
 }
 
+func (d *tDigest) AddCentroid(c Centroid) {
+	d.add(c.Mean, c.Weight)
+}
+
 func (d *tDigest) Min() float64 {
 	return d.Quantile(0.0)
 }
@@ -162,6 +162,22 @@ func TestTDigestMerge(t *testing.T) {
 	}
 }
 
+func TestTDigestAddCentroid(t *testing.T) {
+	opts := testTDigestOptions()
+	d := NewTDigest(opts)
+	d.AddCentroid(Centroid{Mean: 1.0, Weight: 3.0})
+	d.AddCentroid(Centroid{Mean: 10.0, Weight: 1.0})
+
+	require.Equal(t, 1.0, d.Min())
+	require.Equal(t, 10.0, d.Max())
+
+	var totalWeight float64
+	for _, c := range d.Merged() {
+		totalWeight += c.Weight
+	}
+	require.Equal(t, 4.0, totalWeight)
+}
+
 func TestTDigestClose(t *testing.T) {
 	opts := testTDigestOptions()
 	d := NewTDigest(opts).(*tDigest)
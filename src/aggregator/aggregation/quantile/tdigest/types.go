@@ -50,6 +50,9 @@ type TDigest interface {
 	// Add adds a value.
 	Add(value float64)
 
+	// AddCentroid adds a weighted centroid, e.g. from another t-digest.
+	AddCentroid(c Centroid)
+
 	// Min returns the minimum value.
 	Min() float64
 
@@ -0,0 +1,91 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aggregation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// QuantileSketchType is the data structure timers use to estimate quantiles.
+type QuantileSketchType int
+
+// List of supported quantile sketch types.
+const (
+	// CMQuantileSketch estimates quantiles with a CKMS stream.
+	CMQuantileSketch QuantileSketchType = iota
+
+	// TDigestQuantileSketch estimates quantiles with a t-digest, which uses
+	// less memory than a stream and can be merged across aggregation stages.
+	TDigestQuantileSketch
+
+	// DefaultQuantileSketch is the default quantile sketch type.
+	DefaultQuantileSketch = CMQuantileSketch
+)
+
+var (
+	validQuantileSketchTypes = []QuantileSketchType{
+		CMQuantileSketch,
+		TDigestQuantileSketch,
+	}
+)
+
+func (t QuantileSketchType) String() string {
+	switch t {
+	case CMQuantileSketch:
+		return "cm"
+	case TDigestQuantileSketch:
+		return "tdigest"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(t))
+	}
+}
+
+// ParseQuantileSketchType parses a quantile sketch type.
+func ParseQuantileSketchType(str string) (QuantileSketchType, error) {
+	validTypes := make([]string, 0, len(validQuantileSketchTypes))
+	for _, valid := range validQuantileSketchTypes {
+		if str == valid.String() {
+			return valid, nil
+		}
+		validTypes = append(validTypes, valid.String())
+	}
+	return DefaultQuantileSketch, fmt.Errorf("invalid quantile sketch type '%s' valid types are: %s",
+		str, strings.Join(validTypes, ", "))
+}
+
+// UnmarshalYAML unmarshals YAML object into a quantile sketch type.
+func (t *QuantileSketchType) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+	if str == "" {
+		*t = DefaultQuantileSketch
+		return nil
+	}
+	parsed, err := ParseQuantileSketchType(str)
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
@@ -24,6 +24,8 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/aggregator/aggregation/quantile/cm"
+	"github.com/m3db/m3/src/aggregator/aggregation/quantile/tdigest"
+	"github.com/m3db/m3/src/aggregator/generated/proto/snapshot"
 	"github.com/m3db/m3/src/metrics/aggregation"
 )
 
@@ -32,20 +34,39 @@ type Timer struct {
 	Options
 
 	lastAt time.Time
-	count  int64     // Number of values received.
-	sum    float64   // Sum of the values.
-	sumSq  float64   // Sum of squared values.
-	stream cm.Stream // Stream of values received.
+	count  int64           // Number of values received.
+	sum    float64         // Sum of the values.
+	sumSq  float64         // Sum of squared values.
+	stream cm.Stream       // Stream of values received, nil if using a digest.
+	digest tdigest.TDigest // Digest of values received, nil if using a stream.
+
+	distinct *HyperLogLog // Sketch of distinct values received.
 }
 
 // NewTimer creates a new timer
 func NewTimer(quantiles []float64, streamOpts cm.Options, opts Options) Timer {
 	stream := streamOpts.StreamPool().Get()
 	stream.ResetSetData(quantiles)
-	return Timer{
+	t := Timer{
 		Options: opts,
 		stream:  stream,
 	}
+	if opts.HasCountDistinct {
+		t.distinct = newDefaultHyperLogLog()
+	}
+	return t
+}
+
+// NewTDigestTimer creates a new timer that estimates quantiles with a t-digest.
+func NewTDigestTimer(digestOpts tdigest.Options, opts Options) Timer {
+	t := Timer{
+		Options: opts,
+		digest:  tdigest.NewTDigest(digestOpts),
+	}
+	if opts.HasCountDistinct {
+		t.distinct = newDefaultHyperLogLog()
+	}
+	return t
 }
 
 // Add adds a timer value.
@@ -54,6 +75,31 @@ func (t *Timer) Add(timestamp time.Time, value float64) {
 	t.addValue(value)
 }
 
+// AddDigest adds t-digest centroids encoded as consecutive mean and weight
+// pairs, e.g. a digest forwarded from a previous aggregation stage. The sum of
+// squares and distinct count of the digest are estimated from the centroid means.
+func (t *Timer) AddDigest(timestamp time.Time, centroids []float64) {
+	t.recordLastAt(timestamp)
+	for i := 0; i+1 < len(centroids); i += 2 {
+		t.addCentroid(centroids[i], centroids[i+1])
+	}
+}
+
+// AppendDigest appends the t-digest centroids of the timer encoded as
+// consecutive mean and weight pairs, timers using a stream append nothing.
+func (t *Timer) AppendDigest(dst []float64) []float64 {
+	if t.digest == nil {
+		return dst
+	}
+	for _, c := range t.digest.Merged() {
+		dst = append(dst, c.Mean, c.Weight)
+	}
+	for _, c := range t.digest.Unmerged() {
+		dst = append(dst, c.Mean, c.Weight)
+	}
+	return dst
+}
+
 // AddBatch adds a batch of timer values.
 func (t *Timer) AddBatch(timestamp time.Time, values []float64) {
 	// Record last at just once.
@@ -75,11 +121,40 @@ func (t *Timer) recordLastAt(timestamp time.Time) {
 func (t *Timer) addValue(value float64) {
 	t.count++
 	t.sum += value
-	t.stream.Add(value)
+	if t.digest != nil {
+		t.digest.Add(value)
+	} else {
+		t.stream.Add(value)
+	}
 
 	if t.HasExpensiveAggregations {
 		t.sumSq += value * value
 	}
+
+	if t.distinct != nil {
+		t.distinct.Add(value)
+	}
+}
+
+func (t *Timer) addCentroid(mean, weight float64) {
+	t.count += int64(weight)
+	t.sum += mean * weight
+	if t.digest != nil {
+		t.digest.AddCentroid(tdigest.Centroid{Mean: mean, Weight: weight})
+	} else {
+		// Streams are unweighted, so the mean is added once per unit of weight.
+		for i := 0; i < int(weight); i++ {
+			t.stream.Add(mean)
+		}
+	}
+
+	if t.HasExpensiveAggregations {
+		t.sumSq += mean * mean * weight
+	}
+
+	if t.distinct != nil {
+		t.distinct.Add(mean)
+	}
 }
 
 // LastAt returns the time of the last value received.
@@ -87,6 +162,9 @@ func (t *Timer) LastAt() time.Time { return t.lastAt }
 
 // Quantile returns the value at a given quantile.
 func (t *Timer) Quantile(q float64) float64 {
+	if t.digest != nil {
+		return t.digest.Quantile(q)
+	}
 	t.stream.Flush()
 	return t.stream.Quantile(q)
 }
@@ -96,12 +174,18 @@ func (t *Timer) Count() int64 { return t.count }
 
 // Min returns the minimum timer value.
 func (t *Timer) Min() float64 {
+	if t.digest != nil {
+		return t.digest.Min()
+	}
 	t.stream.Flush()
 	return t.stream.Min()
 }
 
 // Max returns the maximum timer value.
 func (t *Timer) Max() float64 {
+	if t.digest != nil {
+		return t.digest.Max()
+	}
 	t.stream.Flush()
 	return t.stream.Max()
 }
@@ -125,6 +209,32 @@ func (t *Timer) Stdev() float64 {
 	return stdev(t.count, t.sumSq, t.sum)
 }
 
+// CountDistinct returns the estimated number of distinct timer values.
+func (t *Timer) CountDistinct() uint64 {
+	if t.distinct == nil {
+		return 0
+	}
+	return t.distinct.Count()
+}
+
+// AppendDistinct appends the sketch of distinct timer values encoded by
+// HyperLogLog.AppendFloats to dst, if distinct values are counted.
+func (t *Timer) AppendDistinct(dst []float64) []float64 {
+	return appendDistinct(t.distinct, dst)
+}
+
+// MergeDistinct merges a sketch of distinct values encoded by
+// HyperLogLog.AppendFloats into the sketch of distinct timer values.
+func (t *Timer) MergeDistinct(timestamp time.Time, values []float64) error {
+	if err := mergeDistinct(t.distinct, values); err != nil {
+		return err
+	}
+	if t.lastAt.IsZero() || timestamp.After(t.lastAt) {
+		t.lastAt = timestamp
+	}
+	return nil
+}
+
 // ValueOf returns the value for the aggregation type.
 func (t *Timer) ValueOf(aggType aggregation.Type) float64 {
 	if q, ok := aggType.Quantile(); ok {
@@ -146,9 +256,71 @@ func (t *Timer) ValueOf(aggType aggregation.Type) float64 {
 		return t.SumSq()
 	case aggregation.Stdev:
 		return t.Stdev()
+	case aggregation.CountDistinct:
+		return float64(t.CountDistinct())
 	}
 	return 0
 }
 
+// ToProto converts the timer to a protobuf message in place. The samples of
+// timers backed by a stream are flushed before they are converted.
+func (t *Timer) ToProto(pb *snapshot.TimerSnapshot) error {
+	distinct, err := marshalDistinct(t.distinct)
+	if err != nil {
+		return err
+	}
+	pb.LastAtNanos = timeToNanos(t.lastAt)
+	pb.Count = t.count
+	pb.Sum = t.sum
+	pb.SumSq = t.sumSq
+	pb.Samples = pb.Samples[:0]
+	pb.Centroids = t.AppendDigest(pb.Centroids[:0])
+	pb.Distinct = distinct
+	if t.digest != nil {
+		return nil
+	}
+	for _, sample := range t.stream.AppendSamples(nil) {
+		pb.Samples = append(pb.Samples, snapshot.StreamSample{
+			Value:    sample.Value,
+			NumRanks: sample.NumRanks,
+			Delta:    sample.Delta,
+		})
+	}
+	return nil
+}
+
+// FromProto restores a newly created timer from a protobuf message.
+func (t *Timer) FromProto(pb snapshot.TimerSnapshot) error {
+	if err := unmarshalDistinct(t.distinct, pb.Distinct); err != nil {
+		return err
+	}
+	t.lastAt = timeFromNanos(pb.LastAtNanos)
+	t.count = pb.Count
+	t.sum = pb.Sum
+	t.sumSq = pb.SumSq
+	if t.digest != nil {
+		for i := 0; i+1 < len(pb.Centroids); i += 2 {
+			t.digest.AddCentroid(tdigest.Centroid{Mean: pb.Centroids[i], Weight: pb.Centroids[i+1]})
+		}
+		return nil
+	}
+	samples := make([]cm.SampleData, 0, len(pb.Samples))
+	for _, sample := range pb.Samples {
+		samples = append(samples, cm.SampleData{
+			Value:    sample.Value,
+			NumRanks: sample.NumRanks,
+			Delta:    sample.Delta,
+		})
+	}
+	t.stream.RestoreSamples(samples)
+	return nil
+}
+
 // Close closes the timer.
-func (t *Timer) Close() { t.stream.Close() }
+func (t *Timer) Close() {
+	if t.digest != nil {
+		t.digest.Close()
+		return
+	}
+	t.stream.Close()
+}
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/aggregator/aggregation/quantile/cm"
+	"github.com/m3db/m3/src/aggregator/aggregation/quantile/tdigest"
 	"github.com/m3db/m3/src/x/instrument"
 )
 
@@ -41,8 +42,28 @@ func getTimer() Timer {
 	return timer
 }
 
+func getTDigestTimer() Timer {
+	opts := NewOptions(instrument.NewOptions())
+	opts.ResetSetData(testAggTypes)
+
+	at := time.Now()
+	timer := NewTDigestTimer(tdigest.NewOptions(), opts)
+
+	for i := 1; i <= 100; i++ {
+		timer.Add(at, float64(i))
+	}
+	return timer
+}
+
 func BenchmarkTimerValues(b *testing.B) {
-	timer := getTimer()
+	benchmarkTimerValues(b, getTimer())
+}
+
+func BenchmarkTDigestTimerValues(b *testing.B) {
+	benchmarkTimerValues(b, getTDigestTimer())
+}
+
+func benchmarkTimerValues(b *testing.B, timer Timer) {
 	for n := 0; n < b.N; n++ {
 		timer.Sum()
 		timer.SumSq()
@@ -59,10 +80,58 @@ func BenchmarkTimerValues(b *testing.B) {
 }
 
 func BenchmarkTimerValueOf(b *testing.B) {
-	timer := getTimer()
+	benchmarkTimerValueOf(b, getTimer())
+}
+
+func BenchmarkTDigestTimerValueOf(b *testing.B) {
+	benchmarkTimerValueOf(b, getTDigestTimer())
+}
+
+func benchmarkTimerValueOf(b *testing.B, timer Timer) {
 	for n := 0; n < b.N; n++ {
 		for _, aggType := range testAggTypes {
 			timer.ValueOf(aggType)
 		}
 	}
 }
+
+func BenchmarkTimerAdd(b *testing.B) {
+	opts := NewOptions(instrument.NewOptions())
+	opts.ResetSetData(testAggTypes)
+	benchmarkTimerAdd(b, func() Timer { return NewTimer(testQuantiles, cm.NewOptions(), opts) })
+}
+
+func BenchmarkTDigestTimerAdd(b *testing.B) {
+	opts := NewOptions(instrument.NewOptions())
+	opts.ResetSetData(testAggTypes)
+	benchmarkTimerAdd(b, func() Timer { return NewTDigestTimer(tdigest.NewOptions(), opts) })
+}
+
+func benchmarkTimerAdd(b *testing.B, newTimer func() Timer) {
+	at := time.Now()
+	for n := 0; n < b.N; n++ {
+		timer := newTimer()
+		for i := 1; i <= 1000; i++ {
+			timer.Add(at, float64(i))
+		}
+		timer.Quantile(0.99)
+		timer.Close()
+	}
+}
+
+func BenchmarkTDigestTimerMergeDigest(b *testing.B) {
+	opts := NewOptions(instrument.NewOptions())
+	opts.ResetSetData(testAggTypes)
+
+	at := time.Now()
+	timer := getTDigestTimer()
+	centroids := timer.AppendDigest(nil)
+	for n := 0; n < b.N; n++ {
+		timer := NewTDigestTimer(tdigest.NewOptions(), opts)
+		for i := 0; i < 10; i++ {
+			timer.AddDigest(at, centroids)
+		}
+		timer.Quantile(0.99)
+		timer.Close()
+	}
+}
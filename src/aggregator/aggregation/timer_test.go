@@ -26,6 +26,8 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/aggregator/aggregation/quantile/cm"
+	"github.com/m3db/m3/src/aggregator/aggregation/quantile/tdigest"
+	"github.com/m3db/m3/src/aggregator/generated/proto/snapshot"
 	"github.com/m3db/m3/src/metrics/aggregation"
 	"github.com/m3db/m3/src/x/instrument"
 	"github.com/m3db/m3/src/x/pool"
@@ -170,6 +172,198 @@ func TestTimerAggregationsNotExpensive(t *testing.T) {
 	// Expensive calculations are not performed.
 	require.Equal(t, 0.0, timer.SumSq())
 
+	// Distinct values are not counted.
+	require.Equal(t, uint64(0), timer.CountDistinct())
+
 	// Closing the timer a second time should be a no op.
 	timer.Close()
 }
+
+func TestTimerCountDistinct(t *testing.T) {
+	opts := NewOptions(instrument.NewOptions())
+	opts.ResetSetData(aggregation.Types{aggregation.CountDistinct})
+
+	timer := NewTimer(testQuantiles, cm.NewOptions(), opts)
+	require.True(t, timer.HasCountDistinct)
+
+	at := time.Now()
+	for i := 0; i < 1000; i++ {
+		timer.Add(at, float64(i%100))
+	}
+
+	require.Equal(t, int64(1000), timer.Count())
+	require.Equal(t, uint64(100), timer.CountDistinct())
+	require.Equal(t, 100.0, timer.ValueOf(aggregation.CountDistinct))
+	timer.Close()
+}
+
+func TestTimerParameterizedQuantiles(t *testing.T) {
+	p75, err := aggregation.ParseType("P75")
+	require.NoError(t, err)
+	p995, err := aggregation.ParseType("P99.5")
+	require.NoError(t, err)
+	aggTypes := aggregation.Types{aggregation.P50, p75, p995}
+
+	opts := NewOptions(instrument.NewOptions())
+	opts.ResetSetData(aggTypes)
+
+	quantiles, _ := aggTypes.PooledQuantiles(nil)
+	require.Equal(t, []float64{0.5, 0.75, 0.995}, quantiles)
+
+	timer := NewTimer(quantiles, cm.NewOptions(), opts)
+	at := time.Now()
+	for i := 1; i <= 1000; i++ {
+		timer.Add(at, float64(i))
+	}
+
+	require.InEpsilon(t, 500.0, timer.ValueOf(aggregation.P50), 0.01)
+	require.InEpsilon(t, 750.0, timer.ValueOf(p75), 0.01)
+	require.InEpsilon(t, 995.0, timer.ValueOf(p995), 0.01)
+	timer.Close()
+}
+
+func TestTDigestTimerAggregations(t *testing.T) {
+	opts := NewOptions(instrument.NewOptions())
+	opts.ResetSetData(testAggTypes)
+
+	timer := NewTDigestTimer(tdigest.NewOptions(), opts)
+
+	// Assert the state of an empty timer.
+	require.Equal(t, int64(0), timer.Count())
+	require.Equal(t, 0.0, timer.Min())
+	require.Equal(t, 0.0, timer.Max())
+	require.Equal(t, 0.0, timer.Quantile(0.5))
+
+	// Add values.
+	at := time.Now()
+	for i := 1; i <= 100; i++ {
+		timer.Add(at, float64(i))
+	}
+
+	// Validate the timer values match expectations.
+	require.Equal(t, int64(100), timer.Count())
+	require.Equal(t, 5050.0, timer.Sum())
+	require.Equal(t, 338350.0, timer.SumSq())
+	require.Equal(t, 1.0, timer.Min())
+	require.Equal(t, 100.0, timer.Max())
+	require.Equal(t, 50.5, timer.Mean())
+	require.InDelta(t, 50.5, timer.Quantile(0.5), 1)
+	require.InDelta(t, 95.0, timer.ValueOf(aggregation.P95), 1)
+	require.InDelta(t, 99.0, timer.ValueOf(aggregation.P99), 1)
+
+	// Closing the timer a second time should be a no op.
+	timer.Close()
+	timer.Close()
+}
+
+func TestTDigestTimerDigestRoundTrip(t *testing.T) {
+	opts := NewOptions(instrument.NewOptions())
+	opts.ResetSetData(testAggTypes)
+
+	// Split the values across two timers, as if they were aggregated
+	// by two different aggregators in the first stage.
+	at := time.Now()
+	first := NewTDigestTimer(tdigest.NewOptions(), opts)
+	second := NewTDigestTimer(tdigest.NewOptions(), opts)
+	for i := 1; i <= 1000; i++ {
+		if i%2 == 0 {
+			first.Add(at, float64(i))
+		} else {
+			second.Add(at, float64(i))
+		}
+	}
+
+	centroids := first.AppendDigest(nil)
+	centroids = second.AppendDigest(centroids)
+	require.Equal(t, 0, len(centroids)%2)
+
+	merged := NewTDigestTimer(tdigest.NewOptions(), opts)
+	merged.AddDigest(at, centroids)
+	require.Equal(t, at, merged.LastAt())
+	require.Equal(t, int64(1000), merged.Count())
+	require.Equal(t, 500500.0, merged.Sum())
+	require.Equal(t, 1.0, merged.Min())
+	require.Equal(t, 1000.0, merged.Max())
+	require.InEpsilon(t, 500.0, merged.Quantile(0.5), 0.01)
+	require.InEpsilon(t, 990.0, merged.Quantile(0.99), 0.01)
+	merged.Close()
+	first.Close()
+	second.Close()
+}
+
+func TestTimerAddDigestToStream(t *testing.T) {
+	opts := NewOptions(instrument.NewOptions())
+	opts.ResetSetData(testAggTypes)
+
+	timer := NewTimer(testQuantiles, cm.NewOptions(), opts)
+	timer.AddDigest(time.Now(), []float64{1, 2, 3, 1})
+	require.Equal(t, int64(3), timer.Count())
+	require.Equal(t, 5.0, timer.Sum())
+	require.Equal(t, 11.0, timer.SumSq())
+	require.Equal(t, 1.0, timer.Min())
+	require.Equal(t, 3.0, timer.Max())
+
+	// Timers backed by a stream have no digest to forward.
+	require.Nil(t, timer.AppendDigest(nil))
+	timer.Close()
+}
+
+func TestTimerProtoRoundTrip(t *testing.T) {
+	opts := NewOptions(instrument.NewOptions())
+	opts.ResetSetData(append(aggregation.Types{aggregation.CountDistinct}, testAggTypes...))
+
+	timer := NewTimer(testQuantiles, cm.NewOptions(), opts)
+	at := time.Unix(0, 1234)
+	for i := 1; i <= 1000; i++ {
+		timer.Add(at, float64(i))
+	}
+
+	var pb snapshot.TimerSnapshot
+	require.NoError(t, timer.ToProto(&pb))
+	require.True(t, len(pb.Samples) > 0)
+	require.Nil(t, pb.Centroids)
+
+	restored := NewTimer(testQuantiles, cm.NewOptions(), opts)
+	require.NoError(t, restored.FromProto(pb))
+	require.Equal(t, at, restored.LastAt())
+	require.Equal(t, timer.Count(), restored.Count())
+	require.Equal(t, timer.Sum(), restored.Sum())
+	require.Equal(t, timer.SumSq(), restored.SumSq())
+	require.Equal(t, timer.CountDistinct(), restored.CountDistinct())
+	require.Equal(t, timer.Min(), restored.Min())
+	require.Equal(t, timer.Max(), restored.Max())
+	for _, q := range testQuantiles {
+		require.Equal(t, timer.Quantile(q), restored.Quantile(q))
+	}
+	timer.Close()
+	restored.Close()
+}
+
+func TestTDigestTimerProtoRoundTrip(t *testing.T) {
+	opts := NewOptions(instrument.NewOptions())
+	opts.ResetSetData(testAggTypes)
+
+	timer := NewTDigestTimer(tdigest.NewOptions(), opts)
+	at := time.Unix(0, 1234)
+	for i := 1; i <= 1000; i++ {
+		timer.Add(at, float64(i))
+	}
+
+	var pb snapshot.TimerSnapshot
+	require.NoError(t, timer.ToProto(&pb))
+	require.True(t, len(pb.Centroids) > 0)
+	require.Equal(t, 0, len(pb.Samples))
+
+	restored := NewTDigestTimer(tdigest.NewOptions(), opts)
+	require.NoError(t, restored.FromProto(pb))
+	require.Equal(t, at, restored.LastAt())
+	require.Equal(t, timer.Count(), restored.Count())
+	require.Equal(t, timer.Sum(), restored.Sum())
+	require.Equal(t, timer.SumSq(), restored.SumSq())
+	require.Equal(t, 1.0, restored.Min())
+	require.Equal(t, 1000.0, restored.Max())
+	require.InEpsilon(t, timer.Quantile(0.5), restored.Quantile(0.5), 0.01)
+	require.InEpsilon(t, timer.Quantile(0.99), restored.Quantile(0.99), 0.01)
+	timer.Close()
+	restored.Close()
+}
@@ -21,12 +21,16 @@
 package aggregator
 
 import (
+	"errors"
 	"time"
 
 	"github.com/m3db/m3/src/aggregator/aggregation"
+	"github.com/m3db/m3/src/aggregator/generated/proto/snapshot"
 	"github.com/m3db/m3/src/metrics/metric/unaggregated"
 )
 
+var errAggregationSnapshotTypeMismatch = errors.New("aggregation snapshot type mismatch")
+
 // counterAggregation is a counter aggregation.
 type counterAggregation struct {
 	aggregation.Counter
@@ -44,6 +48,35 @@ func (a *counterAggregation) AddUnion(t time.Time, mu unaggregated.MetricUnion)
 	a.Counter.Update(t, mu.CounterVal)
 }
 
+func (a *counterAggregation) AddDigest(t time.Time, centroids []float64) {
+	// The sum of the values in a centroid is its mean times its weight.
+	for i := 0; i+1 < len(centroids); i += 2 {
+		a.Counter.Update(t, int64(centroids[i]*centroids[i+1]))
+	}
+}
+
+func (a *counterAggregation) AppendDigest(dst []float64) []float64 { return dst }
+
+func (a *counterAggregation) AddDistinctSketch(t time.Time, values []float64) error {
+	return a.Counter.MergeDistinct(t, values)
+}
+
+func (a *counterAggregation) AppendDistinctSketch(dst []float64) []float64 {
+	return a.Counter.AppendDistinct(dst)
+}
+
+func (a *counterAggregation) Snapshot(pb *snapshot.AggregationSnapshot) error {
+	pb.Counter = &snapshot.CounterSnapshot{}
+	return a.Counter.ToProto(pb.Counter)
+}
+
+func (a *counterAggregation) Restore(pb snapshot.AggregationSnapshot) error {
+	if pb.Counter == nil {
+		return errAggregationSnapshotTypeMismatch
+	}
+	return a.Counter.FromProto(*pb.Counter)
+}
+
 // timerAggregation is a timer aggregation.
 type timerAggregation struct {
 	aggregation.Timer
@@ -61,6 +94,34 @@ func (a *timerAggregation) AddUnion(timestamp time.Time, mu unaggregated.MetricU
 	a.Timer.AddBatch(timestamp, mu.BatchTimerVal)
 }
 
+func (a *timerAggregation) AddDigest(timestamp time.Time, centroids []float64) {
+	a.Timer.AddDigest(timestamp, centroids)
+}
+
+func (a *timerAggregation) AppendDigest(dst []float64) []float64 {
+	return a.Timer.AppendDigest(dst)
+}
+
+func (a *timerAggregation) AddDistinctSketch(t time.Time, values []float64) error {
+	return a.Timer.MergeDistinct(t, values)
+}
+
+func (a *timerAggregation) AppendDistinctSketch(dst []float64) []float64 {
+	return a.Timer.AppendDistinct(dst)
+}
+
+func (a *timerAggregation) Snapshot(pb *snapshot.AggregationSnapshot) error {
+	pb.Timer = &snapshot.TimerSnapshot{}
+	return a.Timer.ToProto(pb.Timer)
+}
+
+func (a *timerAggregation) Restore(pb snapshot.AggregationSnapshot) error {
+	if pb.Timer == nil {
+		return errAggregationSnapshotTypeMismatch
+	}
+	return a.Timer.FromProto(*pb.Timer)
+}
+
 // gaugeAggregation is a gauge aggregation.
 type gaugeAggregation struct {
 	aggregation.Gauge
@@ -77,3 +138,31 @@ func (a *gaugeAggregation) Add(t time.Time, value float64) {
 func (a *gaugeAggregation) AddUnion(t time.Time, mu unaggregated.MetricUnion) {
 	a.Gauge.Update(t, mu.GaugeVal)
 }
+
+func (a *gaugeAggregation) AddDigest(t time.Time, centroids []float64) {
+	for i := 0; i+1 < len(centroids); i += 2 {
+		a.Gauge.Update(t, centroids[i])
+	}
+}
+
+func (a *gaugeAggregation) AppendDigest(dst []float64) []float64 { return dst }
+
+func (a *gaugeAggregation) AddDistinctSketch(t time.Time, values []float64) error {
+	return a.Gauge.MergeDistinct(t, values)
+}
+
+func (a *gaugeAggregation) AppendDistinctSketch(dst []float64) []float64 {
+	return a.Gauge.AppendDistinct(dst)
+}
+
+func (a *gaugeAggregation) Snapshot(pb *snapshot.AggregationSnapshot) error {
+	pb.Gauge = &snapshot.GaugeSnapshot{}
+	return a.Gauge.ToProto(pb.Gauge)
+}
+
+func (a *gaugeAggregation) Restore(pb snapshot.AggregationSnapshot) error {
+	if pb.Gauge == nil {
+		return errAggregationSnapshotTypeMismatch
+	}
+	return a.Gauge.FromProto(*pb.Gauge)
+}
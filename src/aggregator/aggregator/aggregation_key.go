@@ -32,6 +32,10 @@ type aggregationKey struct {
 	pipeline           applied.Pipeline
 	numForwardedTimes  int
 	idPrefixSuffixType IDPrefixSuffixType
+	// digest is true if the forwarded values are t-digest centroids.
+	digest bool
+	// distinctSketch is true if the forwarded values are a HyperLogLog sketch.
+	distinctSketch bool
 }
 
 func (k aggregationKey) Equal(other aggregationKey) bool {
@@ -39,5 +43,7 @@ func (k aggregationKey) Equal(other aggregationKey) bool {
 		k.storagePolicy == other.storagePolicy &&
 		k.pipeline.Equal(other.pipeline) &&
 		k.numForwardedTimes == other.numForwardedTimes &&
-		k.idPrefixSuffixType == other.idPrefixSuffixType
+		k.idPrefixSuffixType == other.idPrefixSuffixType &&
+		k.digest == other.digest &&
+		k.distinctSketch == other.distinctSketch
 }
@@ -105,6 +105,7 @@ type aggregator struct {
 	placementManager  PlacementManager
 	flushTimesManager FlushTimesManager
 	flushTimesChecker flushTimesChecker
+	snapshotManager   SnapshotManager
 	electionManager   ElectionManager
 	flushManager      FlushManager
 	flushHandler      handler.Handler
@@ -140,6 +141,7 @@ func NewAggregator(opts Options) Aggregator {
 		placementManager:  opts.PlacementManager(),
 		flushTimesManager: opts.FlushTimesManager(),
 		flushTimesChecker: newFlushTimesChecker(scope.SubScope("tick.shard-check")),
+		snapshotManager:   opts.SnapshotManager(),
 		electionManager:   opts.ElectionManager(),
 		flushManager:      opts.FlushManager(),
 		flushHandler:      opts.FlushHandler(),
@@ -170,6 +172,17 @@ func (agg *aggregator) Open() error {
 	if err := agg.processPlacementWithLock(stagedPlacement, placement); err != nil {
 		return err
 	}
+	if agg.snapshotManager != nil {
+		// NB: snapshots are restored before the aggregator is open so the restored
+		// aggregation windows are in place before any writes are accepted.
+		err := agg.snapshotManager.Restore(agg.shardsWithLock(), agg.flushTimesManager)
+		if err != nil {
+			agg.logger.Error("error restoring snapshots", zap.Error(err))
+		}
+		if err := agg.snapshotManager.Open(agg.shardsToSnapshot); err != nil {
+			return err
+		}
+	}
 	if agg.checkInterval > 0 {
 		agg.wg.Add(1)
 		go agg.tick()
@@ -321,6 +334,11 @@ func (agg *aggregator) Close() error {
 		return errAggregatorNotOpenOrClosed
 	}
 	close(agg.doneCh)
+	if agg.snapshotManager != nil {
+		if err := agg.snapshotManager.Close(agg.shardsWithLock()); err != nil {
+			agg.logger.Error("error taking final snapshot", zap.Error(err))
+		}
+	}
 	for _, shardID := range agg.shardIDs {
 		agg.shards[shardID].Close()
 	}
@@ -336,6 +354,21 @@ func (agg *aggregator) Close() error {
 	return nil
 }
 
+func (agg *aggregator) shardsToSnapshot() []*aggregatorShard {
+	agg.RLock()
+	defer agg.RUnlock()
+
+	return agg.shardsWithLock()
+}
+
+func (agg *aggregator) shardsWithLock() []*aggregatorShard {
+	shards := make([]*aggregatorShard, 0, len(agg.shardIDs))
+	for _, shardID := range agg.shardIDs {
+		shards = append(shards, agg.shards[shardID])
+	}
+	return shards
+}
+
 func (agg *aggregator) passWriter() (writer.Writer, error) {
 	agg.RLock()
 	defer agg.RUnlock()
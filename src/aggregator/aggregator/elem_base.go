@@ -28,6 +28,7 @@ import (
 	"time"
 
 	raggregation "github.com/m3db/m3/src/aggregator/aggregation"
+	"github.com/m3db/m3/src/aggregator/generated/proto/snapshot"
 	maggregation "github.com/m3db/m3/src/metrics/aggregation"
 	"github.com/m3db/m3/src/metrics/metric"
 	"github.com/m3db/m3/src/metrics/metric/id"
@@ -76,6 +77,20 @@ type createAggregationOptions struct {
 	initSourceSet bool
 }
 
+// forwardedValuesType describes how the values forwarded to an element are encoded.
+type forwardedValuesType int
+
+const (
+	// aggregatedForwardedValues are aggregated values added one at a time.
+	aggregatedForwardedValues forwardedValuesType = iota
+	// digestForwardedValues are t-digest centroids encoded as consecutive mean
+	// and weight pairs.
+	digestForwardedValues
+	// distinctSketchForwardedValues are a HyperLogLog sketch encoded as its
+	// precision followed by its packed non-zero registers.
+	distinctSketchForwardedValues
+)
+
 // IDPrefixSuffixType configs if the id should be added with prefix or suffix
 // after aggregation.
 type IDPrefixSuffixType int
@@ -118,6 +133,10 @@ type metricElem interface {
 		onDoneFn onForwardedAggregationDoneFn,
 	)
 
+	// SetCorrectionBuffer sets how long after its flush an aggregation window
+	// may receive late values, which are then flushed as a correction.
+	SetCorrectionBuffer(value time.Duration)
+
 	// AddUnion adds a metric value union at a given timestamp.
 	AddUnion(timestamp time.Time, mu unaggregated.MetricUnion) error
 
@@ -129,6 +148,18 @@ type metricElem interface {
 	// same aggregation, the incoming value is discarded.
 	AddUnique(timestamp time.Time, values []float64, sourceID uint32) error
 
+	// AddUniqueDigest adds t-digest centroids encoded as consecutive mean and
+	// weight pairs from a given source at a given timestamp. If previous values
+	// from the same source have already been added to the same aggregation, the
+	// incoming centroids are discarded.
+	AddUniqueDigest(timestamp time.Time, centroids []float64, sourceID uint32) error
+
+	// AddUniqueDistinctSketch adds a HyperLogLog sketch of distinct values encoded
+	// as its precision followed by its packed non-zero registers from a given source
+	// at a given timestamp. If previous values from the same source have already been
+	// added to the same aggregation, the incoming sketch is discarded.
+	AddUniqueDistinctSketch(timestamp time.Time, values []float64, sourceID uint32) error
+
 	// Consume consumes values before a given time and removes
 	// them from the element after they are consumed, returning whether
 	// the element can be collected after the consumption is completed.
@@ -141,6 +172,19 @@ type metricElem interface {
 		onForwardedFlushedFn onForwardingElemFlushedFn,
 	) bool
 
+	// Snapshot appends the aggregation windows of the element that have not
+	// been consumed to the snapshot.
+	Snapshot(pb *snapshot.ElemSnapshot) error
+
+	// Restore restores the aggregation windows in the snapshot into a newly
+	// created element, skipping the windows that are earlier than the given
+	// flushed time as they have already been flushed.
+	Restore(
+		pb snapshot.ElemSnapshot,
+		isEarlierThanFn isEarlierThanFn,
+		flushedNanos int64,
+	) error
+
 	// MarkAsTombstoned marks an element as tombstoned, which means this element
 	// will be deleted once its aggregated values have been flushed.
 	MarkAsTombstoned()
@@ -164,6 +208,9 @@ type elemBase struct {
 	parsedPipeline                  parsedPipeline
 	numForwardedTimes               int
 	idPrefixSuffixType              IDPrefixSuffixType
+	forwardsDigest                  bool
+	forwardsDistinctSketch          bool
+	correctionBuffer                time.Duration
 	writeForwardedMetricFn          writeForwardedMetricFn
 	onForwardedAggregationWrittenFn onForwardedAggregationDoneFn
 
@@ -208,6 +255,7 @@ func (e *elemBase) resetSetData(
 	e.tombstoned = false
 	e.closed = false
 	e.idPrefixSuffixType = idPrefixSuffixType
+	e.correctionBuffer = 0
 	return nil
 }
 
@@ -219,6 +267,10 @@ func (e *elemBase) SetForwardedCallbacks(
 	e.onForwardedAggregationWrittenFn = onDoneFn
 }
 
+func (e *elemBase) SetCorrectionBuffer(value time.Duration) {
+	e.correctionBuffer = value
+}
+
 func (e *elemBase) ID() id.RawID { return e.id }
 
 func (e *elemBase) ForwardedID() (id.RawID, bool) {
@@ -237,6 +289,8 @@ func (e *elemBase) ForwardedAggregationKey() (aggregationKey, bool) {
 		storagePolicy:     e.sp,
 		pipeline:          e.parsedPipeline.Remainder,
 		numForwardedTimes: e.numForwardedTimes + 1,
+		digest:            e.forwardsDigest,
+		distinctSketch:    e.forwardsDistinctSketch,
 	}, true
 }
 
@@ -305,6 +359,9 @@ func (e timerElemBase) TypeStringFor(aggTypesOpts maggregation.TypesOptions, agg
 func (e timerElemBase) ElemPool(opts Options) TimerElemPool { return opts.TimerElemPool() }
 
 func (e timerElemBase) NewAggregation(opts Options, aggOpts raggregation.Options) timerAggregation {
+	if aggOpts.QuantileSketch == raggregation.TDigestQuantileSketch {
+		return newTimerAggregation(raggregation.NewTDigestTimer(opts.DigestOptions(), aggOpts))
+	}
 	newTimer := raggregation.NewTimer(e.quantiles, opts.StreamOptions(), aggOpts)
 	return newTimerAggregation(newTimer)
 }
@@ -466,7 +523,7 @@ func newParsedPipeline(pipeline applied.Pipeline) (parsedPipeline, error) {
 
 	transformations := make([]transformation.Op, 0, transformPipeline.Len())
 	for i := 0; i < transformPipeline.Len(); i++ {
-		op, err := transformPipeline.At(i).Transformation.Type.NewOp()
+		op, err := transformPipeline.At(i).Transformation.NewOp()
 		if err != nil {
 			err := fmt.Errorf("transform could not construct op: %v", err)
 			return parsedPipeline{}, err
@@ -27,6 +27,7 @@ import (
 
 	raggregation "github.com/m3db/m3/src/aggregator/aggregation"
 	"github.com/m3db/m3/src/aggregator/aggregation/quantile/cm"
+	"github.com/m3db/m3/src/aggregator/generated/proto/snapshot"
 	maggregation "github.com/m3db/m3/src/metrics/aggregation"
 	"github.com/m3db/m3/src/metrics/metric"
 	"github.com/m3db/m3/src/metrics/metric/id"
@@ -580,6 +581,102 @@ func TestCounterElemConsumeCustomAggregationCustomPipeline(t *testing.T) {
 	require.Equal(t, 0, len(e.values))
 }
 
+func TestCounterElemConsumeParameterizedTransformations(t *testing.T) {
+	transformPipeline := applied.NewPipeline([]applied.OpUnion{
+		{
+			Type: pipeline.TransformationOpType,
+			Transformation: pipeline.TransformationOp{
+				Type: transformation.Scale,
+				Args: []float64{2},
+			},
+		},
+		{
+			Type:           pipeline.TransformationOpType,
+			Transformation: pipeline.TransformationOp{Type: transformation.Reset},
+		},
+	})
+	aggTypes := maggregation.Types{maggregation.Sum}
+	e := testCounterElem(testAlignedStarts[:1], []int64{5}, aggTypes, transformPipeline, NewOptions())
+
+	// The reset transformation emits a zero value half a resolution after the scaled value.
+	expectedLocalRes := []testLocalMetricWithMetadata{
+		{
+			idPrefix:  []byte("stats.counts."),
+			id:        testCounterID,
+			idSuffix:  expectCounterSuffix(maggregation.Sum),
+			timeNanos: testAlignedStarts[1],
+			value:     10,
+			sp:        testStoragePolicy,
+		},
+		{
+			idPrefix:  []byte("stats.counts."),
+			id:        testCounterID,
+			idSuffix:  expectCounterSuffix(maggregation.Sum),
+			timeNanos: testAlignedStarts[1] + int64(5*time.Second),
+			value:     0,
+			sp:        testStoragePolicy,
+		},
+	}
+	localFn, localRes := testFlushLocalMetricFn()
+	forwardFn, forwardRes := testFlushForwardedMetricFn()
+	onForwardedFlushedFn, _ := testOnForwardedFlushedFn()
+	require.False(t, e.Consume(testAlignedStarts[1], isStandardMetricEarlierThan, standardMetricTimestampNanos, localFn, forwardFn, onForwardedFlushedFn))
+	require.Equal(t, expectedLocalRes, *localRes)
+	require.Equal(t, 0, len(*forwardRes))
+	require.Equal(t, 0, len(e.values))
+}
+
+func TestCounterElemConsumeResetBeforeDerivative(t *testing.T) {
+	transformPipeline := applied.NewPipeline([]applied.OpUnion{
+		{
+			Type:           pipeline.TransformationOpType,
+			Transformation: pipeline.TransformationOp{Type: transformation.Reset},
+		},
+		{
+			Type:           pipeline.TransformationOpType,
+			Transformation: pipeline.TransformationOp{Type: transformation.Delta},
+		},
+	})
+	aggTypes := maggregation.Types{maggregation.Sum}
+	e := testCounterElem(testAlignedStarts[:2], []int64{5, 8}, aggTypes, transformPipeline, NewOptions())
+
+	// The zero values emitted by the reset transformation go through the delta
+	// transformation, and the delta of the next interval is computed from them.
+	expectedLocalRes := []testLocalMetricWithMetadata{
+		{
+			idPrefix:  []byte("stats.counts."),
+			id:        testCounterID,
+			idSuffix:  expectCounterSuffix(maggregation.Sum),
+			timeNanos: testAlignedStarts[1] + int64(5*time.Second),
+			value:     -5,
+			sp:        testStoragePolicy,
+		},
+		{
+			idPrefix:  []byte("stats.counts."),
+			id:        testCounterID,
+			idSuffix:  expectCounterSuffix(maggregation.Sum),
+			timeNanos: testAlignedStarts[2],
+			value:     8,
+			sp:        testStoragePolicy,
+		},
+		{
+			idPrefix:  []byte("stats.counts."),
+			id:        testCounterID,
+			idSuffix:  expectCounterSuffix(maggregation.Sum),
+			timeNanos: testAlignedStarts[2] + int64(5*time.Second),
+			value:     -8,
+			sp:        testStoragePolicy,
+		},
+	}
+	localFn, localRes := testFlushLocalMetricFn()
+	forwardFn, forwardRes := testFlushForwardedMetricFn()
+	onForwardedFlushedFn, _ := testOnForwardedFlushedFn()
+	require.False(t, e.Consume(testAlignedStarts[2], isStandardMetricEarlierThan, standardMetricTimestampNanos, localFn, forwardFn, onForwardedFlushedFn))
+	require.Equal(t, expectedLocalRes, *localRes)
+	require.Equal(t, 0, len(*forwardRes))
+	require.Equal(t, 0, len(e.values))
+}
+
 func TestCounterElemClose(t *testing.T) {
 	e := testCounterElem(testAlignedStarts[:len(testAlignedStarts)-1], testCounterVals, maggregation.DefaultTypes, applied.DefaultPipeline, NewOptions())
 	require.False(t, e.closed)
@@ -651,6 +748,121 @@ func TestCounterFindOrCreateWithSourceSet(t *testing.T) {
 	require.Equal(t, 0, len(e.cachedSourceSets))
 }
 
+func TestCounterElemConsumeCountDistinct(t *testing.T) {
+	aggTypes := maggregation.Types{maggregation.Sum, maggregation.CountDistinct}
+	e, err := NewCounterElem(testCounterID, testStoragePolicy, aggTypes, applied.DefaultPipeline, testNumForwardedTimes, WithPrefixWithSuffix, NewOptions())
+	require.NoError(t, err)
+
+	for _, v := range []int64{1, 2, 2, 3, 3, 3} {
+		require.NoError(t, e.AddUnion(testTimestamps[0], unaggregated.MetricUnion{
+			Type:       metric.CounterType,
+			ID:         testCounterID,
+			CounterVal: v,
+		}))
+	}
+
+	// Distinct counts are flushed as gauges.
+	localFn, localRes := testFlushLocalMetricFn()
+	forwardFn, forwardRes := testFlushForwardedMetricFn()
+	onForwardedFlushedFn, _ := testOnForwardedFlushedFn()
+	require.False(t, e.Consume(testAlignedStarts[1], isStandardMetricEarlierThan, standardMetricTimestampNanos, localFn, forwardFn, onForwardedFlushedFn))
+	require.Equal(t, []testLocalMetricWithMetadata{
+		{
+			idPrefix:  []byte("stats.counts."),
+			id:        testCounterID,
+			idSuffix:  expectCounterSuffix(maggregation.Sum),
+			timeNanos: testAlignedStarts[1],
+			value:     14,
+			sp:        testStoragePolicy,
+		},
+		{
+			idPrefix:  []byte("stats.gauges."),
+			id:        testCounterID,
+			idSuffix:  expectGaugeSuffix(maggregation.CountDistinct),
+			timeNanos: testAlignedStarts[1],
+			value:     3,
+			sp:        testStoragePolicy,
+		},
+	}, *localRes)
+	require.Equal(t, 0, len(*forwardRes))
+}
+
+func TestCounterElemConsumeForwardsDistinctSketch(t *testing.T) {
+	rollupPipeline := applied.NewPipeline([]applied.OpUnion{
+		{
+			Type: pipeline.RollupOpType,
+			Rollup: applied.RollupOp{
+				ID:            []byte("foo.baz"),
+				AggregationID: maggregation.MustCompressTypes(maggregation.CountDistinct),
+			},
+		},
+	})
+	aggTypes := maggregation.Types{maggregation.CountDistinct}
+
+	// Each source counts the distinct values it received, half of which were
+	// also received by the other source.
+	var forwarded [][]float64
+	for source := 0; source < 2; source++ {
+		e := MustNewCounterElem(testCounterID, testStoragePolicy, aggTypes, rollupPipeline, testNumForwardedTimes, WithPrefixWithSuffix, NewOptions())
+		require.True(t, e.forwardsDistinctSketch)
+		for v := 0; v < 1000; v++ {
+			require.NoError(t, e.AddUnion(testTimestamps[0], unaggregated.MetricUnion{
+				Type:       metric.CounterType,
+				ID:         testCounterID,
+				CounterVal: int64(source*500 + v),
+			}))
+		}
+
+		aggKey, ok := e.ForwardedAggregationKey()
+		require.True(t, ok)
+		require.True(t, aggKey.distinctSketch)
+
+		localFn, localRes := testFlushLocalMetricFn()
+		forwardFn, forwardRes := testFlushForwardedMetricFn()
+		onForwardedFlushedFn, _ := testOnForwardedFlushedFn()
+		require.False(t, e.Consume(testAlignedStarts[1], isStandardMetricEarlierThan, standardMetricTimestampNanos, localFn, forwardFn, onForwardedFlushedFn))
+		require.Equal(t, 0, len(*localRes))
+		var values []float64
+		for _, res := range *forwardRes {
+			require.Equal(t, aggKey, res.aggregationKey)
+			values = append(values, res.value)
+		}
+		forwarded = append(forwarded, values)
+	}
+
+	// The next stage merges the sketches and counts the distinct values across
+	// both sources rather than summing their estimates.
+	e := MustNewCounterElem(testCounterID, testStoragePolicy, aggTypes, applied.DefaultPipeline, testNumForwardedTimes+1, WithPrefixWithSuffix, NewOptions())
+	for source, values := range forwarded {
+		require.NoError(t, e.AddUniqueDistinctSketch(testTimestamps[0], values, uint32(source)))
+	}
+	require.Equal(t, errDuplicateForwardingSource, e.AddUniqueDistinctSketch(testTimestamps[0], forwarded[0], 0))
+	localFn, localRes := testFlushLocalMetricFn()
+	forwardFn, _ := testFlushForwardedMetricFn()
+	onForwardedFlushedFn, _ := testOnForwardedFlushedFn()
+	require.False(t, e.Consume(testAlignedStarts[1], isStandardMetricEarlierThan, standardMetricTimestampNanos, localFn, forwardFn, onForwardedFlushedFn))
+	require.Equal(t, 1, len(*localRes))
+	require.Equal(t, []byte("stats.gauges."), (*localRes)[0].idPrefix)
+	require.InEpsilon(t, 1500.0, (*localRes)[0].value, 0.05)
+
+	// Distinct counts rolled up into other aggregation types are forwarded as values.
+	sumPipeline := applied.NewPipeline([]applied.OpUnion{
+		{
+			Type: pipeline.RollupOpType,
+			Rollup: applied.RollupOp{
+				ID:            []byte("foo.baz"),
+				AggregationID: maggregation.MustCompressTypes(maggregation.Sum),
+			},
+		},
+	})
+	e = MustNewCounterElem(testCounterID, testStoragePolicy, aggTypes, sumPipeline, testNumForwardedTimes, WithPrefixWithSuffix, NewOptions())
+	require.False(t, e.forwardsDistinctSketch)
+
+	// Distinct counts transformed before being forwarded are forwarded as values.
+	e = MustNewCounterElem(testCounterID, testStoragePolicy, aggTypes, testPipeline, testNumForwardedTimes, WithPrefixWithSuffix, NewOptions())
+	require.False(t, e.forwardsDistinctSketch)
+}
+
 func TestTimerResetSetData(t *testing.T) {
 	opts := NewOptions()
 	te, err := NewTimerElem(nil, policy.EmptyStoragePolicy, maggregation.DefaultTypes, applied.DefaultPipeline, testNumForwardedTimes, NoPrefixNoSuffix, opts)
@@ -824,6 +1036,77 @@ func TestTimerElemAddUnique(t *testing.T) {
 	require.Equal(t, errElemClosed, e.AddUnique(testTimestamps[2], []float64{100}, 3))
 }
 
+func TestTimerElemAddUniqueDigest(t *testing.T) {
+	opts := NewOptions().SetTimerQuantileSketchFn(testTDigestQuantileSketchFn)
+	e, err := NewTimerElem(testBatchTimerID, testStoragePolicy, maggregation.DefaultTypes, applied.DefaultPipeline, testNumForwardedTimes, NoPrefixNoSuffix, opts)
+	require.NoError(t, err)
+
+	// Add digests from different sources.
+	require.NoError(t, e.AddUniqueDigest(testTimestamps[0], []float64{10, 2, 20, 1}, 1))
+	require.NoError(t, e.AddUniqueDigest(testTimestamps[1], []float64{30, 1}, 2))
+	require.Equal(t, 1, len(e.values))
+	timer := e.values[0].lockedAgg.aggregation
+	require.Equal(t, int64(4), timer.Count())
+	require.Equal(t, 70.0, timer.Sum())
+	require.Equal(t, 10.0, timer.Quantile(0))
+	require.Equal(t, 30.0, timer.Quantile(1))
+
+	// Adding a digest from the same source results in an error.
+	require.Equal(t, errDuplicateForwardingSource, e.AddUniqueDigest(testTimestamps[1], []float64{40, 1}, 1))
+	require.Equal(t, int64(4), e.values[0].lockedAgg.aggregation.Count())
+
+	// Adding a digest to a closed element results in an error.
+	e.closed = true
+	require.Equal(t, errElemClosed, e.AddUniqueDigest(testTimestamps[2], []float64{100, 1}, 3))
+}
+
+func TestTimerElemConsumeForwardsDigest(t *testing.T) {
+	rollupPipeline := applied.NewPipeline([]applied.OpUnion{
+		{
+			Type: pipeline.RollupOpType,
+			Rollup: applied.RollupOp{
+				ID:            []byte("foo.baz"),
+				AggregationID: maggregation.MustCompressTypes(maggregation.P99),
+			},
+		},
+	})
+	opts := NewOptions().SetTimerQuantileSketchFn(testTDigestQuantileSketchFn)
+	e := MustNewTimerElem(testBatchTimerID, testStoragePolicy, maggregation.Types{maggregation.P99}, rollupPipeline, testNumForwardedTimes, WithPrefixWithSuffix, opts)
+	require.True(t, e.forwardsDigest)
+	require.NoError(t, e.AddUnion(testTimestamps[0], testBatchTimer))
+
+	aggKey, ok := e.ForwardedAggregationKey()
+	require.True(t, ok)
+	require.True(t, aggKey.digest)
+
+	// Every centroid is forwarded as a mean and weight pair.
+	var expectedForwardedRes []testForwardedMetricWithMetadata
+	for _, v := range testBatchTimer.BatchTimerVal {
+		for _, fv := range []float64{v, 1} {
+			expectedForwardedRes = append(expectedForwardedRes, testForwardedMetricWithMetadata{
+				aggregationKey: aggKey,
+				timeNanos:      testAlignedStarts[1],
+				value:          fv,
+			})
+		}
+	}
+	localFn, localRes := testFlushLocalMetricFn()
+	forwardFn, forwardRes := testFlushForwardedMetricFn()
+	onForwardedFlushedFn, _ := testOnForwardedFlushedFn()
+	require.False(t, e.Consume(testAlignedStarts[1], isStandardMetricEarlierThan, standardMetricTimestampNanos, localFn, forwardFn, onForwardedFlushedFn))
+	verifyForwardedMetrics(t, expectedForwardedRes, *forwardRes)
+	require.Equal(t, 0, len(*localRes))
+	require.Equal(t, 0, len(e.values))
+
+	// Timers backed by a stream forward aggregated values.
+	e = MustNewTimerElem(testBatchTimerID, testStoragePolicy, maggregation.Types{maggregation.P99}, rollupPipeline, testNumForwardedTimes, WithPrefixWithSuffix, NewOptions())
+	require.False(t, e.forwardsDigest)
+
+	// Timers whose values are transformed before being forwarded forward aggregated values.
+	e = MustNewTimerElem(testBatchTimerID, testStoragePolicy, maggregation.Types{maggregation.P99}, testPipeline, testNumForwardedTimes, WithPrefixWithSuffix, opts)
+	require.False(t, e.forwardsDigest)
+}
+
 func TestTimerElemConsumeDefaultAggregationDefaultPipeline(t *testing.T) {
 	// Set up stream options.
 	streamOpts, p, numAlloc := testStreamOptions(t, len(testAlignedStarts)-1)
@@ -1683,6 +1966,225 @@ func TestGaugeFindOrCreateWithSourceSet(t *testing.T) {
 	require.Equal(t, 0, len(e.cachedSourceSets))
 }
 
+func TestCounterElemSnapshotRestore(t *testing.T) {
+	e, err := NewCounterElem(testCounterID, testStoragePolicy, maggregation.DefaultTypes, applied.DefaultPipeline, testNumForwardedTimes, NoPrefixNoSuffix, NewOptions())
+	require.NoError(t, err)
+	source1, source2 := uint32(1234), uint32(5678)
+	require.NoError(t, e.AddUnique(testTimestamps[0], []float64{345}, source1))
+	require.NoError(t, e.AddUnique(testTimestamps[1], []float64{500}, source2))
+	require.NoError(t, e.AddUnique(testTimestamps[2], []float64{278}, source1))
+
+	var pb snapshot.ElemSnapshot
+	require.NoError(t, e.Snapshot(&pb))
+	require.Equal(t, 2, len(pb.Values))
+	require.Equal(t, []uint32{source1, source2}, pb.Values[0].SourcesSeen)
+	require.Equal(t, []uint32{source1}, pb.Values[1].SourcesSeen)
+
+	// Restoring with nothing flushed restores all windows.
+	restored, err := NewCounterElem(testCounterID, testStoragePolicy, maggregation.DefaultTypes, applied.DefaultPipeline, testNumForwardedTimes, NoPrefixNoSuffix, NewOptions())
+	require.NoError(t, err)
+	require.NoError(t, restored.Restore(pb, isStandardMetricEarlierThan, 0))
+	require.Equal(t, 2, len(restored.values))
+	for i := 0; i < len(restored.values); i++ {
+		require.Equal(t, testAlignedStarts[i], restored.values[i].startAtNanos)
+		require.Equal(t, e.values[i].lockedAgg.aggregation.Sum(), restored.values[i].lockedAgg.aggregation.Sum())
+		require.Equal(t, e.values[i].lockedAgg.aggregation.Count(), restored.values[i].lockedAgg.aggregation.Count())
+	}
+	require.True(t, restored.values[0].lockedAgg.sourcesSeen.Test(uint(source1)))
+	require.True(t, restored.values[0].lockedAgg.sourcesSeen.Test(uint(source2)))
+	require.False(t, restored.values[1].lockedAgg.sourcesSeen.Test(uint(source2)))
+
+	// Adding a metric from a source already seen is rejected.
+	require.Equal(t, errDuplicateForwardingSource, restored.AddUnique(testTimestamps[0], []float64{100}, source2))
+	require.Equal(t, int64(845), restored.values[0].lockedAgg.aggregation.Sum())
+
+	// Restoring after the first window has been flushed skips that window.
+	restored, err = NewCounterElem(testCounterID, testStoragePolicy, maggregation.DefaultTypes, applied.DefaultPipeline, testNumForwardedTimes, NoPrefixNoSuffix, NewOptions())
+	require.NoError(t, err)
+	require.NoError(t, restored.Restore(pb, isStandardMetricEarlierThan, testAlignedStarts[1]))
+	require.Equal(t, 1, len(restored.values))
+	require.Equal(t, testAlignedStarts[1], restored.values[0].startAtNanos)
+	require.Equal(t, int64(278), restored.values[0].lockedAgg.aggregation.Sum())
+
+	// Snapshotting a closed element results in an error.
+	e.Close()
+	require.Equal(t, errElemClosed, e.Snapshot(&snapshot.ElemSnapshot{}))
+}
+
+func TestTimerElemSnapshotRestore(t *testing.T) {
+	opts := NewOptions()
+	e := testTimerElem(testAlignedStarts[:len(testAlignedStarts)-1], testBatchTimerVals, maggregation.DefaultTypes, applied.DefaultPipeline, opts)
+
+	var pb snapshot.ElemSnapshot
+	require.NoError(t, e.Snapshot(&pb))
+	require.Equal(t, 2, len(pb.Values))
+
+	restored := MustNewTimerElem(testBatchTimerID, testStoragePolicy, maggregation.DefaultTypes, applied.DefaultPipeline, testNumForwardedTimes, WithPrefixWithSuffix, opts)
+	require.NoError(t, restored.Restore(pb, isStandardMetricEarlierThan, 0))
+	require.Equal(t, 2, len(restored.values))
+
+	// Consuming the restored element produces the same metrics as the original.
+	localFn, localRes := testFlushLocalMetricFn()
+	forwardFn, _ := testFlushForwardedMetricFn()
+	onForwardedFlushedFn, _ := testOnForwardedFlushedFn()
+	require.False(t, e.Consume(testAlignedStarts[2], isStandardMetricEarlierThan, standardMetricTimestampNanos, localFn, forwardFn, onForwardedFlushedFn))
+	restoredLocalFn, restoredLocalRes := testFlushLocalMetricFn()
+	require.False(t, restored.Consume(testAlignedStarts[2], isStandardMetricEarlierThan, standardMetricTimestampNanos, restoredLocalFn, forwardFn, onForwardedFlushedFn))
+	require.NotEqual(t, 0, len(*localRes))
+	require.Equal(t, *localRes, *restoredLocalRes)
+}
+
+func TestGaugeElemSnapshotRestore(t *testing.T) {
+	opts := NewOptions()
+	e := testGaugeElem(testAlignedStarts[:len(testAlignedStarts)-1], testGaugeVals, maggregation.DefaultTypes, applied.DefaultPipeline, opts)
+
+	var pb snapshot.ElemSnapshot
+	require.NoError(t, e.Snapshot(&pb))
+	require.Equal(t, 2, len(pb.Values))
+
+	restored := MustNewGaugeElem(testGaugeID, testStoragePolicy, maggregation.DefaultTypes, applied.DefaultPipeline, testNumForwardedTimes, WithPrefixWithSuffix, opts)
+	require.NoError(t, restored.Restore(pb, isStandardMetricEarlierThan, testAlignedStarts[1]))
+	require.Equal(t, 1, len(restored.values))
+	require.Equal(t, testAlignedStarts[1], restored.values[0].startAtNanos)
+	require.Equal(t, e.values[1].lockedAgg.aggregation.Last(), restored.values[0].lockedAgg.aggregation.Last())
+	require.Nil(t, restored.values[0].lockedAgg.sourcesSeen)
+
+	// Restoring a snapshot of a different metric type results in an error.
+	var counterPb snapshot.ElemSnapshot
+	counterElem := testCounterElem(testAlignedStarts[:1], testCounterVals, maggregation.DefaultTypes, applied.DefaultPipeline, opts)
+	require.NoError(t, counterElem.Snapshot(&counterPb))
+	restored = MustNewGaugeElem(testGaugeID, testStoragePolicy, maggregation.DefaultTypes, applied.DefaultPipeline, testNumForwardedTimes, WithPrefixWithSuffix, opts)
+	require.Equal(t, errAggregationSnapshotTypeMismatch, restored.Restore(counterPb, isStandardMetricEarlierThan, 0))
+}
+
+func TestCounterElemCorrection(t *testing.T) {
+	aggTypes := maggregation.Types{maggregation.Sum}
+	e, err := NewCounterElem(testCounterID, testStoragePolicy, aggTypes, applied.DefaultPipeline, testNumForwardedTimes, NoPrefixNoSuffix, NewOptions())
+	require.NoError(t, err)
+	e.SetCorrectionBuffer(time.Minute)
+	forwardFn, _ := testFlushForwardedMetricFn()
+	onForwardedFlushedFn, _ := testOnForwardedFlushedFn()
+
+	require.NoError(t, e.AddValue(testTimestamps[0], 10))
+	localFn, localRes := testFlushLocalMetricFn()
+	require.False(t, e.Consume(testAlignedStarts[1], isStandardMetricEarlierThan, standardMetricTimestampNanos, localFn, forwardFn, onForwardedFlushedFn))
+	require.Equal(t, 1, len(*localRes))
+	require.Equal(t, 10.0, (*localRes)[0].value)
+	require.Equal(t, 0, len(e.flushedValues))
+
+	// Late values for a flushed window are flushed as an additive delta.
+	require.NoError(t, e.AddValue(testTimestamps[1], 5))
+	localFn, localRes = testFlushLocalMetricFn()
+	require.False(t, e.Consume(testAlignedStarts[2], isStandardMetricEarlierThan, standardMetricTimestampNanos, localFn, forwardFn, onForwardedFlushedFn))
+	require.Equal(t, 1, len(*localRes))
+	require.Equal(t, testAlignedStarts[1], (*localRes)[0].timeNanos)
+	require.Equal(t, 5.0, (*localRes)[0].value)
+}
+
+func TestGaugeElemCorrection(t *testing.T) {
+	aggTypes := maggregation.Types{maggregation.Sum}
+	e, err := NewGaugeElem(testGaugeID, testStoragePolicy, aggTypes, applied.DefaultPipeline, testNumForwardedTimes, NoPrefixNoSuffix, NewOptions())
+	require.NoError(t, err)
+	e.SetCorrectionBuffer(time.Minute)
+	forwardFn, _ := testFlushForwardedMetricFn()
+	onForwardedFlushedFn, _ := testOnForwardedFlushedFn()
+
+	require.NoError(t, e.AddValue(testTimestamps[0], 10))
+	localFn, localRes := testFlushLocalMetricFn()
+	require.False(t, e.Consume(testAlignedStarts[1], isStandardMetricEarlierThan, standardMetricTimestampNanos, localFn, forwardFn, onForwardedFlushedFn))
+	require.Equal(t, 1, len(*localRes))
+	require.Equal(t, 10.0, (*localRes)[0].value)
+	require.Equal(t, 0, len(e.values))
+	require.Equal(t, 1, len(e.flushedValues))
+
+	// Late values for a flushed window are flushed with the full recomputed value.
+	require.NoError(t, e.AddValue(testTimestamps[1], 5))
+	require.Equal(t, 1, len(e.values))
+	require.Equal(t, 0, len(e.flushedValues))
+	localFn, localRes = testFlushLocalMetricFn()
+	require.False(t, e.Consume(testAlignedStarts[2], isStandardMetricEarlierThan, standardMetricTimestampNanos, localFn, forwardFn, onForwardedFlushedFn))
+	require.Equal(t, 1, len(*localRes))
+	require.Equal(t, testAlignedStarts[1], (*localRes)[0].timeNanos)
+	require.Equal(t, 15.0, (*localRes)[0].value)
+	require.Equal(t, 1, len(e.flushedValues))
+
+	// Flushed windows are no longer retained after the correction buffer.
+	localFn, localRes = testFlushLocalMetricFn()
+	require.False(t, e.Consume(testAlignedStarts[1]+time.Minute.Nanoseconds(), isStandardMetricEarlierThan, standardMetricTimestampNanos, localFn, forwardFn, onForwardedFlushedFn))
+	require.Equal(t, 0, len(*localRes))
+	require.Equal(t, 0, len(e.flushedValues))
+
+	require.NoError(t, e.AddValue(testTimestamps[1], 5))
+	localFn, localRes = testFlushLocalMetricFn()
+	require.False(t, e.Consume(testAlignedStarts[2], isStandardMetricEarlierThan, standardMetricTimestampNanos, localFn, forwardFn, onForwardedFlushedFn))
+	require.Equal(t, 1, len(*localRes))
+	require.Equal(t, 5.0, (*localRes)[0].value)
+
+	// Closing the element closes the retained windows.
+	e.Close()
+	require.Equal(t, 0, len(e.flushedValues))
+}
+
+func TestGaugeElemCorrectionLateValueDuringConsume(t *testing.T) {
+	aggTypes := maggregation.Types{maggregation.Sum}
+	e, err := NewGaugeElem(testGaugeID, testStoragePolicy, aggTypes, applied.DefaultPipeline, testNumForwardedTimes, NoPrefixNoSuffix, NewOptions())
+	require.NoError(t, err)
+	e.SetCorrectionBuffer(time.Minute)
+	forwardFn, _ := testFlushForwardedMetricFn()
+	onForwardedFlushedFn, _ := testOnForwardedFlushedFn()
+
+	require.NoError(t, e.AddValue(testTimestamps[0], 10))
+	require.NoError(t, e.AddValue(testTimestamps[2], 20))
+
+	// A late value for a window that is consumed but not yet flushed is added
+	// to the consumed aggregation and flushed with it.
+	flushFn, localRes := testFlushLocalMetricFn()
+	localFn := func(
+		idPrefix []byte,
+		id id.RawID,
+		idSuffix []byte,
+		timeNanos int64,
+		value float64,
+		sp policy.StoragePolicy,
+	) {
+		if len(*localRes) == 0 {
+			require.NoError(t, e.AddValue(testTimestamps[2], 5))
+		}
+		flushFn(idPrefix, id, idSuffix, timeNanos, value, sp)
+	}
+	require.False(t, e.Consume(testAlignedStarts[2], isStandardMetricEarlierThan, standardMetricTimestampNanos, localFn, forwardFn, onForwardedFlushedFn))
+	require.Equal(t, []float64{10, 25}, testLocalMetricValues(*localRes))
+	require.Equal(t, 0, len(e.values))
+	require.Equal(t, 2, len(e.flushedValues))
+
+	// A late value for a flushed window reopens its aggregation.
+	require.NoError(t, e.AddValue(testTimestamps[2], 1))
+	require.Equal(t, 1, len(e.values))
+	require.Equal(t, 1, len(e.flushedValues))
+	localFn2, localRes2 := testFlushLocalMetricFn()
+	require.False(t, e.Consume(testAlignedStarts[2], isStandardMetricEarlierThan, standardMetricTimestampNanos, localFn2, forwardFn, onForwardedFlushedFn))
+	require.Equal(t, []float64{26}, testLocalMetricValues(*localRes2))
+}
+
+func TestTimerElemCorrection(t *testing.T) {
+	aggTypes := maggregation.Types{maggregation.Count, maggregation.Max}
+	e, err := NewTimerElem(testBatchTimerID, testStoragePolicy, aggTypes, applied.DefaultPipeline, testNumForwardedTimes, NoPrefixNoSuffix, NewOptions())
+	require.NoError(t, err)
+	e.SetCorrectionBuffer(time.Minute)
+	forwardFn, _ := testFlushForwardedMetricFn()
+	onForwardedFlushedFn, _ := testOnForwardedFlushedFn()
+
+	require.NoError(t, e.AddValue(testTimestamps[0], 10))
+	localFn, localRes := testFlushLocalMetricFn()
+	require.False(t, e.Consume(testAlignedStarts[1], isStandardMetricEarlierThan, standardMetricTimestampNanos, localFn, forwardFn, onForwardedFlushedFn))
+	require.Equal(t, []float64{1, 10}, testLocalMetricValues(*localRes))
+
+	require.NoError(t, e.AddValue(testTimestamps[1], 20))
+	localFn, localRes = testFlushLocalMetricFn()
+	require.False(t, e.Consume(testAlignedStarts[2], isStandardMetricEarlierThan, standardMetricTimestampNanos, localFn, forwardFn, onForwardedFlushedFn))
+	require.Equal(t, []float64{2, 20}, testLocalMetricValues(*localRes))
+}
+
 type testIndexData struct {
 	index int
 	data  []int64
@@ -1770,6 +2272,10 @@ func testOnForwardedFlushedFn() (
 	}, &result
 }
 
+func testTDigestQuantileSketchFn(policy.StoragePolicy) raggregation.QuantileSketchType {
+	return raggregation.TDigestQuantileSketch
+}
+
 func testStreamOptions(t *testing.T, size int) (cm.Options, cm.StreamPool, *int) {
 	var numAlloc int
 	p := cm.NewStreamPool(pool.NewObjectPoolOptions().SetSize(size))
@@ -1994,3 +2500,11 @@ func verifyStreamPoolSize(t *testing.T, p cm.StreamPool, expected int, numAlloc
 	p.Get()
 	require.Equal(t, 1, *numAlloc)
 }
+
+func testLocalMetricValues(metrics []testLocalMetricWithMetadata) []float64 {
+	values := make([]float64, 0, len(metrics))
+	for _, m := range metrics {
+		values = append(values, m.value)
+	}
+	return values
+}
@@ -29,6 +29,8 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/aggregator/bitset"
+	schema "github.com/m3db/m3/src/aggregator/generated/proto/flush"
+	"github.com/m3db/m3/src/aggregator/generated/proto/snapshot"
 	"github.com/m3db/m3/src/aggregator/rate"
 	"github.com/m3db/m3/src/aggregator/runtime"
 	"github.com/m3db/m3/src/metrics/aggregation"
@@ -59,6 +61,7 @@ var (
 	errTooFarInTheFuture           = xerrors.NewInvalidParamsError(errors.New("too far in the future"))
 	errTooFarInThePast             = xerrors.NewInvalidParamsError(errors.New("too far in the past"))
 	errArrivedTooLate              = xerrors.NewInvalidParamsError(errors.New("arrived too late"))
+	errInvalidMetricCategory       = errors.New("invalid metric category")
 	errTimestampFormat             = time.RFC822Z
 )
 
@@ -104,6 +107,7 @@ type timedEntryMetrics struct {
 	rateLimit             rateLimitEntryMetrics
 	tooFarInTheFuture     tally.Counter
 	tooFarInThePast       tally.Counter
+	corrections           tally.Counter
 	noPipelinesInMetadata tally.Counter
 	tombstonedMetadata    tally.Counter
 	metadataUpdates       tally.Counter
@@ -115,6 +119,7 @@ func newTimedEntryMetrics(scope tally.Scope) timedEntryMetrics {
 		rateLimit:             newRateLimitEntryMetrics(scope),
 		tooFarInTheFuture:     scope.Counter("too-far-in-the-future"),
 		tooFarInThePast:       scope.Counter("too-far-in-the-past"),
+		corrections:           scope.Counter("corrections"),
 		noPipelinesInMetadata: scope.Counter("no-pipelines-in-metadata"),
 		tombstonedMetadata:    scope.Counter("tombstoned-metadata"),
 		metadataUpdates:       scope.Counter("metadata-updates"),
@@ -326,6 +331,130 @@ func (e *Entry) TryExpire(now time.Time) bool {
 	return true
 }
 
+// Snapshot appends the aggregation windows of the entry that have not been
+// consumed to the snapshot alongside the aggregation keys they belong to.
+func (e *Entry) Snapshot(pb *snapshot.EntrySnapshot) error {
+	e.RLock()
+	defer e.RUnlock()
+
+	if e.closed {
+		return errEntryClosed
+	}
+	for _, val := range e.aggregations {
+		elem := val.elem.Value.(metricElem)
+		elemPb := snapshot.ElemSnapshot{
+			NumForwardedTimes:  int32(val.key.numForwardedTimes),
+			IdPrefixSuffixType: int32(val.key.idPrefixSuffixType),
+			Digest:             val.key.digest,
+			DistinctSketch:     val.key.distinctSketch,
+		}
+		if err := val.key.aggregationID.ToProto(&elemPb.AggregationId); err != nil {
+			return err
+		}
+		if err := val.key.storagePolicy.ToProto(&elemPb.StoragePolicy); err != nil {
+			return err
+		}
+		if err := val.key.pipeline.ToProto(&elemPb.Pipeline); err != nil {
+			return err
+		}
+		if err := elem.Snapshot(&elemPb); err != nil {
+			return err
+		}
+		if len(elemPb.Values) == 0 {
+			continue
+		}
+		pb.Id = elem.ID()
+		pb.Elems = append(pb.Elems, elemPb)
+	}
+	return nil
+}
+
+// Restore restores the aggregations of a newly created entry from a snapshot,
+// skipping the aggregation windows that have already been flushed according
+// to the flush times of the shard, which may be nil if the shard has never
+// been flushed.
+func (e *Entry) Restore(
+	category metricCategory,
+	pb snapshot.EntrySnapshot,
+	flushTimes *schema.ShardFlushTimes,
+) error {
+	var metricType metric.Type
+	if err := metricType.FromProto(pb.Type); err != nil {
+		return err
+	}
+
+	e.Lock()
+	defer e.Unlock()
+
+	if e.closed {
+		return errEntryClosed
+	}
+	for _, elemPb := range pb.Elems {
+		key := aggregationKey{
+			numForwardedTimes:  int(elemPb.NumForwardedTimes),
+			idPrefixSuffixType: IDPrefixSuffixType(elemPb.IdPrefixSuffixType),
+			digest:             elemPb.Digest,
+			distinctSketch:     elemPb.DistinctSketch,
+		}
+		if err := key.aggregationID.FromProto(elemPb.AggregationId); err != nil {
+			return err
+		}
+		if err := key.storagePolicy.FromProto(elemPb.StoragePolicy); err != nil {
+			return err
+		}
+		if err := key.pipeline.FromProto(elemPb.Pipeline); err != nil {
+			return err
+		}
+		listID, isEarlierThanFn, flushedNanos, err := restoreTargetFor(category, key, flushTimes)
+		if err != nil {
+			return err
+		}
+		aggregations, err := e.addNewAggregationKeyWithLock(metricType, pb.Id, key, listID, e.aggregations)
+		if err != nil {
+			return err
+		}
+		e.aggregations = aggregations
+		elem := e.aggregations[e.aggregations.index(key)].elem.Value.(metricElem)
+		if err := elem.Restore(elemPb, isEarlierThanFn, flushedNanos); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restoreTargetFor returns the list an aggregation of a given metric category
+// belongs to, and the function and time used to determine whether the windows
+// of the aggregation have already been flushed.
+func restoreTargetFor(
+	category metricCategory,
+	key aggregationKey,
+	flushTimes *schema.ShardFlushTimes,
+) (metricListID, isEarlierThanFn, int64, error) {
+	resolution := key.storagePolicy.Resolution().Window
+	switch category {
+	case untimedMetric:
+		listID := standardMetricListID{resolution: resolution}.toMetricListID()
+		flushedNanos := flushTimes.GetStandardByResolution()[int64(resolution)]
+		return listID, isStandardMetricEarlierThan, flushedNanos, nil
+	case forwardedMetric:
+		listID := forwardedMetricListID{
+			resolution:        resolution,
+			numForwardedTimes: key.numForwardedTimes,
+		}.toMetricListID()
+		var flushedNanos int64
+		if fbr := flushTimes.GetForwardedByResolution()[int64(resolution)]; fbr != nil {
+			flushedNanos = fbr.ByNumForwardedTimes[int32(key.numForwardedTimes)]
+		}
+		return listID, isForwardedMetricEarlierThan, flushedNanos, nil
+	case timedMetric:
+		listID := timedMetricListID{resolution: resolution}.toMetricListID()
+		flushedNanos := flushTimes.GetTimedByResolution()[int64(resolution)]
+		return listID, isStandardMetricEarlierThan, flushedNanos, nil
+	default:
+		return metricListID{}, nil, 0, errInvalidMetricCategory
+	}
+}
+
 func (e *Entry) writeBatchTimerWithMetadatas(
 	metric unaggregated.MetricUnion,
 	metadatas metadata.StagedMetadatas,
@@ -565,6 +694,11 @@ func (e *Entry) addNewAggregationKeyWithLock(
 	if err = newElem.ResetSetData(metricID, key.storagePolicy, aggTypes, key.pipeline, key.numForwardedTimes, key.idPrefixSuffixType); err != nil {
 		return nil, err
 	}
+	// Late timed metrics are added to the aggregation windows already flushed
+	// within the correction buffer of the storage policy.
+	if listID.listType == timedMetricListType {
+		newElem.SetCorrectionBuffer(e.opts.CorrectionBufferFn()(key.storagePolicy))
+	}
 	list, err := e.lists.FindOrCreate(listID)
 	if err != nil {
 		return nil, err
@@ -662,11 +796,15 @@ func (e *Entry) addTimed(
 		return errEntryClosed
 	}
 
-	// Reject datapoints that arrive too late or too early.
+	// Reject datapoints that arrive too late or too early. Late datapoints are
+	// only accepted as corrections if they are not processed by the pipelines
+	// in the staged metadatas, as the corrections are only flushed locally.
+	hasDefaultMetadatas := stagedMetadatas.IsDefault()
 	if err := e.checkTimestampForTimedMetric(
 		metric,
 		currTime.UnixNano(),
-		metadata.StoragePolicy.Resolution().Window,
+		metadata.StoragePolicy,
+		len(stagedMetadatas) == 0 || hasDefaultMetadatas,
 	); err != nil {
 		e.RUnlock()
 		timeLock.RUnlock()
@@ -675,7 +813,6 @@ func (e *Entry) addTimed(
 
 	// Only apply processing of staged metadatas if has sent staged metadatas
 	// that isn't the default staged metadatas.
-	hasDefaultMetadatas := stagedMetadatas.IsDefault()
 	if len(stagedMetadatas) > 0 && !hasDefaultMetadatas {
 		sm, err := e.activeStagedMetadataWithLock(currTime, stagedMetadatas)
 		if err != nil {
@@ -783,7 +920,8 @@ func (e *Entry) addTimed(
 func (e *Entry) checkTimestampForTimedMetric(
 	metric aggregated.Metric,
 	currNanos int64,
-	resolution time.Duration,
+	sp policy.StoragePolicy,
+	allowCorrections bool,
 ) error {
 	metricTimeNanos := metric.TimeNanos
 	timedBufferFuture := e.opts.BufferForFutureTimedMetric()
@@ -805,8 +943,18 @@ func (e *Entry) checkTimestampForTimedMetric(
 		return xerrors.NewRenamedError(errTooFarInTheFuture, err)
 	}
 	bufferPastFn := e.opts.BufferForPastTimedMetricFn()
-	timedBufferPast := bufferPastFn(resolution)
+	timedBufferPast := bufferPastFn(sp.Resolution().Window)
 	if currNanos-metricTimeNanos > timedBufferPast.Nanoseconds() {
+		// Datapoints within the correction buffer are added to the aggregation
+		// windows already flushed, which are then flushed again as corrections.
+		if allowCorrections {
+			correctionBuffer := e.opts.CorrectionBufferFn()(sp)
+			if correctionBuffer > 0 &&
+				currNanos-metricTimeNanos <= (timedBufferPast+correctionBuffer).Nanoseconds() {
+				e.metrics.timed.corrections.Inc(1)
+				return nil
+			}
+		}
 		e.metrics.timed.tooFarInThePast.Inc(1)
 		if !e.opts.VerboseErrors() {
 			// Don't return verbose errors if not enabled.
@@ -919,7 +1067,7 @@ func (e *Entry) addForwarded(
 		idPrefixSuffixType: WithPrefixWithSuffix,
 	}
 	if idx := e.aggregations.index(key); idx >= 0 {
-		err := e.addForwardedWithLock(e.aggregations[idx], metric, metadata.SourceID)
+		err := e.addForwardedWithLock(e.aggregations[idx], metric, metadata)
 		e.RUnlock()
 		timeLock.RUnlock()
 		return err
@@ -934,7 +1082,7 @@ func (e *Entry) addForwarded(
 	}
 
 	if idx := e.aggregations.index(key); idx >= 0 {
-		err := e.addForwardedWithLock(e.aggregations[idx], metric, metadata.SourceID)
+		err := e.addForwardedWithLock(e.aggregations[idx], metric, metadata)
 		e.Unlock()
 		timeLock.RUnlock()
 		return err
@@ -947,7 +1095,7 @@ func (e *Entry) addForwarded(
 		return err
 	}
 	idx := e.aggregations.index(key)
-	err := e.addForwardedWithLock(e.aggregations[idx], metric, metadata.SourceID)
+	err := e.addForwardedWithLock(e.aggregations[idx], metric, metadata)
 	e.Unlock()
 	timeLock.RUnlock()
 	return err
@@ -1019,10 +1167,21 @@ func (e *Entry) updateForwardMetadataWithLock(
 func (e *Entry) addForwardedWithLock(
 	value aggregationValue,
 	metric aggregated.ForwardedMetric,
-	sourceID uint32,
+	metadata metadata.ForwardMetadata,
 ) error {
-	timestamp := time.Unix(0, metric.TimeNanos)
-	err := value.elem.Value.(metricElem).AddUnique(timestamp, metric.Values, sourceID)
+	var (
+		timestamp = time.Unix(0, metric.TimeNanos)
+		elem      = value.elem.Value.(metricElem)
+		err       error
+	)
+	switch {
+	case metadata.Digest:
+		err = elem.AddUniqueDigest(timestamp, metric.Values, metadata.SourceID)
+	case metadata.DistinctSketch:
+		err = elem.AddUniqueDistinctSketch(timestamp, metric.Values, metadata.SourceID)
+	default:
+		err = elem.AddUnique(timestamp, metric.Values, metadata.SourceID)
+	}
 	if err == errDuplicateForwardingSource {
 		// Duplicate forwarding sources may occur during a leader re-election and is not
 		// considered an external facing error. Hence, we record it and move on.
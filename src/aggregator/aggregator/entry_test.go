@@ -1277,6 +1277,56 @@ func TestEntryAddTimedMetricTooLate(t *testing.T) {
 	}
 }
 
+func TestEntryAddTimedMetricCorrection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		correctedPolicy   = policy.NewStoragePolicy(10*time.Second, xtime.Second, time.Hour)
+		uncorrectedPolicy = policy.NewStoragePolicy(time.Minute, xtime.Minute, time.Hour)
+	)
+	timedAggregationBufferPastFn := func(
+		resolution time.Duration,
+	) time.Duration {
+		return resolution + time.Second
+	}
+	correctionBufferFn := func(sp policy.StoragePolicy) time.Duration {
+		if sp == correctedPolicy {
+			return time.Minute
+		}
+		return 0
+	}
+	e, _, now := testEntry(ctrl, testEntryOptions{})
+	e.opts = e.opts.
+		SetBufferForPastTimedMetricFn(timedAggregationBufferPastFn).
+		SetCorrectionBufferFn(correctionBufferFn)
+
+	// Late metrics within the correction buffer are accepted.
+	metric := testTimedMetric
+	metric.TimeNanos = now.UnixNano() - 40*time.Second.Nanoseconds()
+	require.NoError(t, e.AddTimed(metric, metadata.TimedMetadata{StoragePolicy: correctedPolicy}))
+	require.Equal(t, 1, len(e.aggregations))
+	elem := e.aggregations[0].elem.Value.(*CounterElem)
+	require.Equal(t, time.Minute, elem.correctionBuffer)
+	require.Equal(t, 1, len(elem.values))
+	require.Equal(t, time.Unix(0, metric.TimeNanos).Truncate(10*time.Second).UnixNano(), elem.values[0].startAtNanos)
+
+	// Late metrics beyond the correction buffer are rejected.
+	metric.TimeNanos = now.UnixNano() - 72*time.Second.Nanoseconds()
+	err := e.AddTimed(metric, metadata.TimedMetadata{StoragePolicy: correctedPolicy})
+	require.Equal(t, errTooFarInThePast, err)
+
+	// Late metrics are rejected for storage policies without a correction buffer.
+	metric.TimeNanos = now.UnixNano() - 62*time.Second.Nanoseconds()
+	err = e.AddTimed(metric, metadata.TimedMetadata{StoragePolicy: uncorrectedPolicy})
+	require.Equal(t, errTooFarInThePast, err)
+
+	// Late metrics are rejected if they are processed by staged metadatas.
+	metric.TimeNanos = now.UnixNano() - 40*time.Second.Nanoseconds()
+	err = e.AddTimedWithStagedMetadatas(metric, testStagedMetadatas)
+	require.Equal(t, errTooFarInThePast, err)
+}
+
 func TestEntryAddTimedMetricTooEarly(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1520,6 +1570,37 @@ func TestEntryAddForwardedMetricTooLate(t *testing.T) {
 	}
 }
 
+func TestEntryAddForwardedMetricTooLateWithCorrectionBuffer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	maxAllowedForwardingDelayFn := func(
+		resolution time.Duration,
+		numForwardedTimes int,
+	) time.Duration {
+		return resolution + time.Second*time.Duration(numForwardedTimes)
+	}
+	correctionBufferFn := func(sp policy.StoragePolicy) time.Duration {
+		return time.Hour
+	}
+	e, _, now := testEntry(ctrl, testEntryOptions{})
+	e.opts = e.opts.
+		SetMaxAllowedForwardingDelayFn(maxAllowedForwardingDelayFn).
+		SetCorrectionBufferFn(correctionBufferFn)
+
+	// Corrections only apply to timed metrics, forwarded metrics arriving
+	// too late are rejected regardless of the correction buffer.
+	*now = time.Unix(1264, 0)
+	metric := testForwardedMetric
+	metric.TimeNanos = 1224 * time.Second.Nanoseconds()
+	metadata := testForwardMetadata
+	metadata.StoragePolicy = policy.NewStoragePolicy(10*time.Second, xtime.Second, time.Hour)
+	metadata.NumForwardedTimes = 3
+	err := e.AddForwarded(metric, metadata)
+	require.Equal(t, errArrivedTooLate, err)
+	require.Equal(t, 0, len(e.aggregations))
+}
+
 func TestEntryAddForwarded(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
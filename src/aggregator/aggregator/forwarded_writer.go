@@ -439,6 +439,8 @@ func (agg *forwardedAggregation) onDone(key aggregationKey) error {
 				Pipeline:          key.pipeline,
 				SourceID:          agg.shard,
 				NumForwardedTimes: key.numForwardedTimes,
+				Digest:            key.digest,
+				DistinctSketch:    key.distinctSketch,
 			}
 		)
 		for _, b := range agg.byKey[idx].buckets {
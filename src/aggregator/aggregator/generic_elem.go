@@ -27,6 +27,7 @@ import (
 	"time"
 
 	raggregation "github.com/m3db/m3/src/aggregator/aggregation"
+	"github.com/m3db/m3/src/aggregator/generated/proto/snapshot"
 	maggregation "github.com/m3db/m3/src/metrics/aggregation"
 	"github.com/m3db/m3/src/metrics/metric"
 	"github.com/m3db/m3/src/metrics/metric/id"
@@ -48,6 +49,28 @@ type typeSpecificAggregation interface {
 	// AddUnion adds a new metric value union.
 	AddUnion(t time.Time, mu unaggregated.MetricUnion)
 
+	// AddDigest adds t-digest centroids encoded as consecutive mean and weight pairs.
+	AddDigest(t time.Time, centroids []float64)
+
+	// AppendDigest appends the t-digest centroids of the aggregation encoded as
+	// consecutive mean and weight pairs.
+	AppendDigest(dst []float64) []float64
+
+	// AddDistinctSketch merges a HyperLogLog sketch of distinct values encoded
+	// as its precision followed by its packed non-zero registers.
+	AddDistinctSketch(t time.Time, values []float64) error
+
+	// AppendDistinctSketch appends the HyperLogLog sketch of distinct values of
+	// the aggregation encoded as its precision followed by its packed non-zero
+	// registers.
+	AppendDistinctSketch(dst []float64) []float64
+
+	// Snapshot converts the aggregation to a snapshot in place.
+	Snapshot(pb *snapshot.AggregationSnapshot) error
+
+	// Restore restores a newly created aggregation from a snapshot.
+	Restore(pb snapshot.AggregationSnapshot) error
+
 	// ValueOf returns the value for the given aggregation type.
 	ValueOf(aggType maggregation.Type) float64
 
@@ -124,6 +147,8 @@ type GenericElem struct {
 	toConsume           []timedAggregation         // small buffer to avoid memory allocations during consumption
 	lastConsumedAtNanos int64                      // last consumed at in Unix nanoseconds
 	lastConsumedValues  []transformation.Datapoint // last consumed values
+	encodedValues       []float64                  // small buffer to avoid memory allocations when forwarding digests and sketches
+	flushedValues       []timedAggregation         // flushed aggregations retained for corrections
 }
 
 // NewGenericElem creates a new element for the given metric type.
@@ -182,6 +207,22 @@ func (e *GenericElem) ResetSetData(
 	if err := e.typeSpecificElemBase.ResetSetData(e.aggTypesOpts, aggTypes, useDefaultAggregation); err != nil {
 		return err
 	}
+	e.aggOpts.QuantileSketch = e.opts.TimerQuantileSketchFn()(sp)
+	// Timers backed by t-digests forward their digests rather than aggregated
+	// values so the next stage can merge them, unless the values are transformed
+	// before being forwarded.
+	e.forwardsDigest = e.Type() == metric.TimerType &&
+		e.aggOpts.QuantileSketch == raggregation.TDigestQuantileSketch &&
+		e.parsedPipeline.HasRollup &&
+		len(e.parsedPipeline.Transformations) == 0
+	// Distinct counts rolled up into a distinct count forward their HyperLogLog
+	// sketches so the next stage counts the distinct values across all the
+	// elements rolled up rather than summing their estimates.
+	e.forwardsDistinctSketch = !e.forwardsDigest &&
+		e.aggTypes.Contains(maggregation.CountDistinct) &&
+		e.parsedPipeline.HasRollup &&
+		len(e.parsedPipeline.Transformations) == 0 &&
+		e.parsedPipeline.Rollup.AggregationID.Contains(maggregation.CountDistinct)
 	// If the pipeline contains derivative transformations, we need to store past
 	// values in order to compute the derivatives.
 	if !e.parsedPipeline.HasDerivativeTransform {
@@ -236,6 +277,31 @@ func (e *GenericElem) AddValue(timestamp time.Time, value float64) error {
 // If previous values from the same source have already been added to the
 // same aggregation, the incoming value is discarded.
 func (e *GenericElem) AddUnique(timestamp time.Time, values []float64, sourceID uint32) error {
+	return e.addUnique(timestamp, values, sourceID, aggregatedForwardedValues)
+}
+
+// AddUniqueDigest adds t-digest centroids encoded as consecutive mean and
+// weight pairs from a given source at a given timestamp. If previous values
+// from the same source have already been added to the same aggregation, the
+// incoming centroids are discarded.
+func (e *GenericElem) AddUniqueDigest(timestamp time.Time, centroids []float64, sourceID uint32) error {
+	return e.addUnique(timestamp, centroids, sourceID, digestForwardedValues)
+}
+
+// AddUniqueDistinctSketch adds a HyperLogLog sketch of distinct values encoded
+// as its precision followed by its packed non-zero registers from a given source
+// at a given timestamp. If previous values from the same source have already been
+// added to the same aggregation, the incoming sketch is discarded.
+func (e *GenericElem) AddUniqueDistinctSketch(timestamp time.Time, values []float64, sourceID uint32) error {
+	return e.addUnique(timestamp, values, sourceID, distinctSketchForwardedValues)
+}
+
+func (e *GenericElem) addUnique(
+	timestamp time.Time,
+	values []float64,
+	sourceID uint32,
+	valuesType forwardedValuesType,
+) error {
 	alignedStart := timestamp.Truncate(e.sp.Resolution().Window).UnixNano()
 	lockedAgg, err := e.findOrCreate(alignedStart, createAggregationOptions{initSourceSet: true})
 	if err != nil {
@@ -252,11 +318,18 @@ func (e *GenericElem) AddUnique(timestamp time.Time, values []float64, sourceID
 		return errDuplicateForwardingSource
 	}
 	lockedAgg.sourcesSeen.Set(source)
-	for _, v := range values {
-		lockedAgg.aggregation.Add(timestamp, v)
+	switch valuesType {
+	case digestForwardedValues:
+		lockedAgg.aggregation.AddDigest(timestamp, values)
+	case distinctSketchForwardedValues:
+		err = lockedAgg.aggregation.AddDistinctSketch(timestamp, values)
+	default:
+		for _, v := range values {
+			lockedAgg.aggregation.Add(timestamp, v)
+		}
 	}
 	lockedAgg.Unlock()
-	return nil
+	return err
 }
 
 // Consume consumes values before a given time and removes them from the element
@@ -299,16 +372,34 @@ func (e *GenericElem) Consume(
 		e.values = e.values[:n]
 	}
 	canCollect := len(e.values) == 0 && e.tombstoned
+	// Counters flush the late values received after a window is flushed as an
+	// additive delta, whereas gauges and timers retain their flushed windows so
+	// the late values are flushed with the full recomputed value.
+	var (
+		retainFlushed      = e.correctionBuffer > 0 && e.Type() != metric.CounterType
+		correctBeforeNanos = targetNanos - e.correctionBuffer.Nanoseconds()
+	)
+	if retainFlushed {
+		// NB: the consumed aggregations are retained before the lock is released
+		// so late values for the consumed windows are added to them rather than
+		// to new aggregations for the same windows.
+		e.retainFlushedWithLock(correctBeforeNanos, isEarlierThanFn)
+	}
 	e.Unlock()
 
 	// Process the aggregations that are ready for consumption.
 	for i := range e.toConsume {
 		timeNanos := timestampNanosFn(e.toConsume[i].startAtNanos, resolution)
+		retained := retainFlushed &&
+			!isEarlierThanFn(e.toConsume[i].startAtNanos, resolution, correctBeforeNanos)
 		e.toConsume[i].lockedAgg.Lock()
 		e.processValueWithAggregationLock(timeNanos, e.toConsume[i].lockedAgg, flushLocalFn, flushForwardedFn)
-		// Closes the aggregation object after it's processed.
+		// Closes the aggregation object after it's processed unless it is retained
+		// for corrections.
 		e.toConsume[i].lockedAgg.closed = true
-		e.toConsume[i].lockedAgg.aggregation.Close()
+		if !retained {
+			e.toConsume[i].lockedAgg.aggregation.Close()
+		}
 		if e.toConsume[i].lockedAgg.sourcesSeen != nil {
 			e.cachedSourceSetsLock.Lock()
 			// This is to make sure there aren't too many cached source sets taking up
@@ -331,6 +422,72 @@ func (e *GenericElem) Consume(
 	return canCollect
 }
 
+// Snapshot appends the aggregation windows of the element that have not been
+// consumed to the snapshot.
+func (e *GenericElem) Snapshot(pb *snapshot.ElemSnapshot) error {
+	e.RLock()
+	defer e.RUnlock()
+
+	if e.closed {
+		return errElemClosed
+	}
+	for _, value := range e.values {
+		value.lockedAgg.Lock()
+		if value.lockedAgg.closed {
+			value.lockedAgg.Unlock()
+			continue
+		}
+		aggPb := snapshot.AggregationSnapshot{StartAtNanos: value.startAtNanos}
+		if sourcesSeen := value.lockedAgg.sourcesSeen; sourcesSeen != nil {
+			for i, ok := sourcesSeen.NextSet(0); ok; i, ok = sourcesSeen.NextSet(i + 1) {
+				aggPb.SourcesSeen = append(aggPb.SourcesSeen, uint32(i))
+			}
+		}
+		err := value.lockedAgg.aggregation.Snapshot(&aggPb)
+		value.lockedAgg.Unlock()
+		if err != nil {
+			return err
+		}
+		pb.Values = append(pb.Values, aggPb)
+	}
+	return nil
+}
+
+// Restore restores the aggregation windows in the snapshot into a newly created
+// element, skipping the windows that are earlier than the given flushed time
+// as they have already been flushed.
+func (e *GenericElem) Restore(
+	pb snapshot.ElemSnapshot,
+	isEarlierThanFn isEarlierThanFn,
+	flushedNanos int64,
+) error {
+	resolution := e.sp.Resolution().Window
+	for _, aggPb := range pb.Values {
+		if isEarlierThanFn(aggPb.StartAtNanos, resolution, flushedNanos) {
+			continue
+		}
+		createOpts := createAggregationOptions{initSourceSet: len(aggPb.SourcesSeen) > 0}
+		lockedAgg, err := e.findOrCreate(aggPb.StartAtNanos, createOpts)
+		if err != nil {
+			return err
+		}
+		lockedAgg.Lock()
+		if lockedAgg.closed {
+			lockedAgg.Unlock()
+			return errAggregationClosed
+		}
+		for _, source := range aggPb.SourcesSeen {
+			lockedAgg.sourcesSeen.Set(uint(source))
+		}
+		err = lockedAgg.aggregation.Restore(aggPb)
+		lockedAgg.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Close closes the element.
 func (e *GenericElem) Close() {
 	e.Lock()
@@ -354,6 +511,11 @@ func (e *GenericElem) Close() {
 		e.values[idx].Reset()
 	}
 	e.values = e.values[:0]
+	for idx := range e.flushedValues {
+		e.flushedValues[idx].lockedAgg.aggregation.Close()
+		e.flushedValues[idx].Reset()
+	}
+	e.flushedValues = e.flushedValues[:0]
 	e.toConsume = e.toConsume[:0]
 	e.lastConsumedValues = e.lastConsumedValues[:0]
 	e.typeSpecificElemBase.Close()
@@ -397,6 +559,10 @@ func (e *GenericElem) findOrCreate(
 		e.Unlock()
 		return agg, nil
 	}
+	if agg := e.pendingFlushedWithLock(alignedStart); agg != nil {
+		e.Unlock()
+		return agg, nil
+	}
 
 	// If not found, create a new aggregation.
 	numValues := len(e.values)
@@ -416,18 +582,95 @@ func (e *GenericElem) findOrCreate(
 		}
 		e.cachedSourceSetsLock.Unlock()
 	}
-	e.values[idx] = timedAggregation{
-		startAtNanos: alignedStart,
-		lockedAgg: &lockedAggregation{
+	// Late values for a flushed window are added to the flushed aggregation if
+	// it is retained for corrections.
+	agg := e.reopenFlushedWithLock(alignedStart)
+	if agg == nil {
+		agg = &lockedAggregation{
 			sourcesSeen: sourcesSeen,
 			aggregation: e.NewAggregation(e.opts, e.aggOpts),
-		},
+		}
+	} else {
+		agg.Lock()
+		agg.closed = false
+		agg.sourcesSeen = sourcesSeen
+		agg.Unlock()
+	}
+	e.values[idx] = timedAggregation{
+		startAtNanos: alignedStart,
+		lockedAgg:    agg,
 	}
-	agg := e.values[idx].lockedAgg
 	e.Unlock()
 	return agg, nil
 }
 
+// pendingFlushedWithLock returns the aggregation retained for corrections for
+// a given time that is yet to be processed by the ongoing consumption, or nil
+// if there is no such aggregation. Values added to it are flushed with it.
+func (e *GenericElem) pendingFlushedWithLock(alignedStart int64) *lockedAggregation {
+	for idx := range e.flushedValues {
+		if e.flushedValues[idx].startAtNanos != alignedStart {
+			continue
+		}
+		agg := e.flushedValues[idx].lockedAgg
+		agg.Lock()
+		pending := !agg.closed
+		agg.Unlock()
+		if pending {
+			return agg
+		}
+		return nil
+	}
+	return nil
+}
+
+// reopenFlushedWithLock removes the flushed aggregation for a given time from
+// the aggregations retained for corrections and returns it, or nil if there
+// is no such aggregation.
+func (e *GenericElem) reopenFlushedWithLock(alignedStart int64) *lockedAggregation {
+	for idx := range e.flushedValues {
+		if e.flushedValues[idx].startAtNanos != alignedStart {
+			continue
+		}
+		agg := e.flushedValues[idx].lockedAgg
+		n := len(e.flushedValues) - 1
+		e.flushedValues[idx] = e.flushedValues[n]
+		e.flushedValues[n].Reset()
+		e.flushedValues = e.flushedValues[:n]
+		return agg
+	}
+	return nil
+}
+
+// retainFlushedWithLock closes the retained aggregations that are earlier than
+// the given time as they can no longer be corrected, and retains the
+// aggregations about to be consumed that are not so late values can be added
+// to them.
+func (e *GenericElem) retainFlushedWithLock(correctBeforeNanos int64, isEarlierThanFn isEarlierThanFn) {
+	resolution := e.sp.Resolution().Window
+	n := 0
+	for i := range e.flushedValues {
+		if isEarlierThanFn(e.flushedValues[i].startAtNanos, resolution, correctBeforeNanos) {
+			e.flushedValues[i].lockedAgg.Lock()
+			e.flushedValues[i].lockedAgg.aggregation.Close()
+			e.flushedValues[i].lockedAgg.Unlock()
+			continue
+		}
+		e.flushedValues[n] = e.flushedValues[i]
+		n++
+	}
+	for i := n; i < len(e.flushedValues); i++ {
+		e.flushedValues[i].Reset()
+	}
+	e.flushedValues = e.flushedValues[:n]
+	for i := range e.toConsume {
+		if isEarlierThanFn(e.toConsume[i].startAtNanos, resolution, correctBeforeNanos) {
+			continue
+		}
+		e.flushedValues = append(e.flushedValues, e.toConsume[i])
+	}
+}
+
 // indexOfWithLock finds the smallest element index whose timestamp
 // is no smaller than the start time passed in, and true if it's an
 // exact match, false otherwise.
@@ -463,15 +706,32 @@ func (e *GenericElem) processValueWithAggregationLock(
 	flushLocalFn flushLocalMetricFn,
 	flushForwardedFn flushForwardedMetricFn,
 ) {
+	if e.forwardsDigest {
+		e.forwardDigestWithAggregationLock(timeNanos, lockedAgg, flushForwardedFn)
+		e.lastConsumedAtNanos = timeNanos
+		return
+	}
+	if e.forwardsDistinctSketch {
+		e.forwardDistinctSketchWithAggregationLock(timeNanos, lockedAgg, flushForwardedFn)
+		e.lastConsumedAtNanos = timeNanos
+		return
+	}
+
 	var (
 		transformations  = e.parsedPipeline.Transformations
 		discardNaNValues = e.opts.DiscardNaNAggregatedValues()
+		resolution       = e.sp.Resolution().Window
 	)
 	for aggTypeIdx, aggType := range e.aggTypes {
-		value := lockedAgg.aggregation.ValueOf(aggType)
+		var (
+			value      = lockedAgg.aggregation.ValueOf(aggType)
+			extraDp    transformation.Datapoint
+			hasExtraDp bool
+		)
 		for _, transformOp := range transformations {
 			unaryOp, isUnaryOp := transformOp.UnaryTransform()
 			binaryOp, isBinaryOp := transformOp.BinaryTransform()
+			unaryMultiOp, isUnaryMultiOp := transformOp.UnaryMultiOutputTransform()
 			switch {
 			case isUnaryOp:
 				curr := transformation.Datapoint{
@@ -483,8 +743,17 @@ func (e *GenericElem) processValueWithAggregationLock(
 
 				value = res.Value
 
+				if hasExtraDp {
+					extraDp = unaryOp.Evaluate(extraDp)
+				}
+
 			case isBinaryOp:
 				lastTimeNanos := e.lastConsumedAtNanos
+				if last := e.lastConsumedValues[aggTypeIdx]; last.TimeNanos > lastTimeNanos {
+					// The last value is the extra datapoint of a multi output transformation,
+					// which falls after the time the previous values were consumed at.
+					lastTimeNanos = last.TimeNanos
+				}
 				prev := transformation.Datapoint{
 					TimeNanos: lastTimeNanos,
 					Value:     e.lastConsumedValues[aggTypeIdx].Value,
@@ -508,24 +777,97 @@ func (e *GenericElem) processValueWithAggregationLock(
 
 				value = res.Value
 
+				if hasExtraDp {
+					// The extra datapoint follows the current one, so it is the value
+					// the derivative of the next interval is computed from.
+					extra := extraDp
+					extraDp = binaryOp.Evaluate(curr, extra)
+					if !math.IsNaN(extra.Value) {
+						e.lastConsumedValues[aggTypeIdx] = extra
+					}
+				}
+
+			case isUnaryMultiOp:
+				curr := transformation.Datapoint{
+					TimeNanos: timeNanos,
+					Value:     value,
+				}
+
+				var res transformation.Datapoint
+				if hasExtraDp {
+					// Only a single extra datapoint is kept, so a later multi output
+					// transformation only keeps the main outputs.
+					res, _ = unaryMultiOp.Evaluate(curr, resolution)
+					extraDp, _ = unaryMultiOp.Evaluate(extraDp, resolution)
+				} else {
+					res, extraDp = unaryMultiOp.Evaluate(curr, resolution)
+					hasExtraDp = true
+				}
+
+				value = res.Value
 			}
 		}
 
-		if discardNaNValues && math.IsNaN(value) {
+		var (
+			discardValue   = discardNaNValues && math.IsNaN(value)
+			discardExtraDp = !hasExtraDp || (discardNaNValues && math.IsNaN(extraDp.Value))
+		)
+		if discardValue && discardExtraDp {
 			continue
 		}
 
 		if !e.parsedPipeline.HasRollup {
-			switch e.idPrefixSuffixType {
-			case NoPrefixNoSuffix:
-				flushLocalFn(nil, e.id, nil, timeNanos, value, e.sp)
-			case WithPrefixWithSuffix:
-				flushLocalFn(e.FullPrefix(e.opts), e.id, e.TypeStringFor(e.aggTypesOpts, aggType), timeNanos, value, e.sp)
+			var prefix, suffix []byte
+			if e.idPrefixSuffixType == WithPrefixWithSuffix {
+				prefix, suffix = e.FullPrefix(e.opts), e.TypeStringFor(e.aggTypesOpts, aggType)
+				if aggType == maggregation.CountDistinct {
+					// Distinct counts are flushed as gauges regardless of the metric type.
+					prefix, suffix = e.opts.FullGaugePrefix(), e.aggTypesOpts.TypeStringForGauge(aggType)
+				}
 			}
-		} else {
+			if !discardValue {
+				flushLocalFn(prefix, e.id, suffix, timeNanos, value, e.sp)
+			}
+			// Multi output transformations such as reset produce an additional datapoint,
+			// which is only flushed locally since the next stage would aggregate it into
+			// the same window as the value it follows.
+			if !discardExtraDp {
+				flushLocalFn(prefix, e.id, suffix, extraDp.TimeNanos, extraDp.Value, e.sp)
+			}
+		} else if !discardValue {
 			forwardedAggregationKey, _ := e.ForwardedAggregationKey()
 			flushForwardedFn(e.writeForwardedMetricFn, forwardedAggregationKey, timeNanos, value)
 		}
 	}
 	e.lastConsumedAtNanos = timeNanos
 }
+
+// forwardDigestWithAggregationLock forwards the digest of the aggregation as
+// consecutive mean and weight pairs, which are written to the same forwarded
+// metric as the digests of the other elements rolled up into it.
+func (e *GenericElem) forwardDigestWithAggregationLock(
+	timeNanos int64,
+	lockedAgg *lockedAggregation,
+	flushForwardedFn flushForwardedMetricFn,
+) {
+	e.encodedValues = lockedAgg.aggregation.AppendDigest(e.encodedValues[:0])
+	forwardedAggregationKey, _ := e.ForwardedAggregationKey()
+	for _, v := range e.encodedValues {
+		flushForwardedFn(e.writeForwardedMetricFn, forwardedAggregationKey, timeNanos, v)
+	}
+}
+
+// forwardDistinctSketchWithAggregationLock forwards the HyperLogLog sketch of
+// distinct values of the aggregation, which is merged with the sketches of the
+// other elements rolled up into the same forwarded metric.
+func (e *GenericElem) forwardDistinctSketchWithAggregationLock(
+	timeNanos int64,
+	lockedAgg *lockedAggregation,
+	flushForwardedFn flushForwardedMetricFn,
+) {
+	e.encodedValues = lockedAgg.aggregation.AppendDistinctSketch(e.encodedValues[:0])
+	forwardedAggregationKey, _ := e.ForwardedAggregationKey()
+	for _, v := range e.encodedValues {
+		flushForwardedFn(e.writeForwardedMetricFn, forwardedAggregationKey, timeNanos, v)
+	}
+}
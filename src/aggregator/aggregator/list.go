@@ -62,6 +62,7 @@ type metricProcessingMetrics struct {
 	metricConsumeSuccess tally.Counter
 	metricConsumeErrors  tally.Counter
 	metricDiscarded      tally.Counter
+	metricCorrections    tally.Counter
 }
 
 func newMetricProcessingMetrics(scope tally.Scope) metricProcessingMetrics {
@@ -69,6 +70,7 @@ func newMetricProcessingMetrics(scope tally.Scope) metricProcessingMetrics {
 		metricConsumeSuccess: scope.Counter("metric-consume-success"),
 		metricConsumeErrors:  scope.Counter("metric-consume-errors"),
 		metricDiscarded:      scope.Counter("metric-discarded"),
+		metricCorrections:    scope.Counter("metric-corrections"),
 	}
 }
 
@@ -741,6 +743,7 @@ func newTimedMetricList(
 		log:            opts.InstrumentOptions().Logger(),
 		flushMgr:       opts.FlushManager(),
 	}
+	fl.consumeLocalMetricFn = fl.consumeLocalMetric
 	fl.flushMgr.Register(fl)
 	return fl, nil
 }
@@ -751,6 +754,23 @@ func (l *timedMetricList) ID() metricListID {
 	}.toMetricListID()
 }
 
+// consumeLocalMetric consumes a local metric, tracking the metrics of the
+// aggregation windows flushed by a previous flush, which are corrections
+// for late timed metrics.
+func (l *timedMetricList) consumeLocalMetric(
+	idPrefix []byte,
+	id metricid.RawID,
+	idSuffix []byte,
+	timeNanos int64,
+	value float64,
+	sp policy.StoragePolicy,
+) {
+	if timeNanos <= l.LastFlushedNanos() {
+		l.metrics.flushLocal.metricCorrections.Inc(1)
+	}
+	l.baseMetricList.consumeLocalMetric(idPrefix, id, idSuffix, timeNanos, value, sp)
+}
+
 func (l *timedMetricList) Close() {
 	if !l.baseMetricList.Close() {
 		return
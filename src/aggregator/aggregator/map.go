@@ -23,10 +23,13 @@ package aggregator
 import (
 	"container/list"
 	"errors"
+	"fmt"
 	"math"
 	"sync"
 	"time"
 
+	schema "github.com/m3db/m3/src/aggregator/generated/proto/flush"
+	"github.com/m3db/m3/src/aggregator/generated/proto/snapshot"
 	"github.com/m3db/m3/src/aggregator/hash"
 	"github.com/m3db/m3/src/aggregator/rate"
 	"github.com/m3db/m3/src/aggregator/runtime"
@@ -61,6 +64,34 @@ const (
 	timedMetric
 )
 
+func (c metricCategory) ToProto(pb *snapshot.MetricCategory) error {
+	switch c {
+	case untimedMetric:
+		*pb = snapshot.MetricCategory_UNTIMED
+	case forwardedMetric:
+		*pb = snapshot.MetricCategory_FORWARDED
+	case timedMetric:
+		*pb = snapshot.MetricCategory_TIMED
+	default:
+		return fmt.Errorf("unknown metric category: %v", c)
+	}
+	return nil
+}
+
+func (c *metricCategory) FromProto(pb snapshot.MetricCategory) error {
+	switch pb {
+	case snapshot.MetricCategory_UNTIMED:
+		*c = untimedMetric
+	case snapshot.MetricCategory_FORWARDED:
+		*c = forwardedMetric
+	case snapshot.MetricCategory_TIMED:
+		*c = timedMetric
+	default:
+		return fmt.Errorf("unknown metric category in proto: %v", pb)
+	}
+	return nil
+}
+
 type entryKey struct {
 	metricCategory metricCategory
 	metricType     metric.Type
@@ -214,6 +245,71 @@ func (m *metricMap) AddForwarded(
 	return err
 }
 
+// Snapshot appends the aggregation windows of the entries in the map that have
+// not been consumed to the snapshot.
+func (m *metricMap) Snapshot(pb *snapshot.ShardSnapshot) error {
+	// NB: the entry list deletion lock is held so entries are not expired and
+	// reused for different metrics while they are being snapshotted.
+	m.entryListDelLock.Lock()
+	defer m.entryListDelLock.Unlock()
+
+	var err error
+	m.forEachEntry(func(entry hashedEntry) {
+		if err != nil {
+			return
+		}
+		var entryPb snapshot.EntrySnapshot
+		if err = entry.key.metricCategory.ToProto(&entryPb.Category); err != nil {
+			return
+		}
+		if err = entry.key.metricType.ToProto(&entryPb.Type); err != nil {
+			return
+		}
+		if err = entry.entry.Snapshot(&entryPb); err != nil {
+			return
+		}
+		if len(entryPb.Elems) > 0 {
+			pb.Entries = append(pb.Entries, entryPb)
+		}
+	})
+	return err
+}
+
+// Restore restores the entries in the snapshot into the map. New entries
+// are not rate limited when they are restored.
+func (m *metricMap) Restore(
+	pb snapshot.ShardSnapshot,
+	flushTimes *schema.ShardFlushTimes,
+) error {
+	for _, entryPb := range pb.Entries {
+		key := entryKey{idHash: hash.Murmur3Hash128(entryPb.Id)}
+		if err := key.metricCategory.FromProto(entryPb.Category); err != nil {
+			return err
+		}
+		if err := key.metricType.FromProto(entryPb.Type); err != nil {
+			return err
+		}
+		m.Lock()
+		if m.closed {
+			m.Unlock()
+			return errMetricMapClosed
+		}
+		entry, found := m.lookupEntryWithLock(key)
+		if !found {
+			entry = m.createEntryWithLock(key)
+		}
+		entry.IncWriter()
+		m.Unlock()
+
+		err := entry.Restore(key.metricCategory, entryPb, flushTimes)
+		entry.DecWriter()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (m *metricMap) Tick(target time.Duration) tickResult {
 	mapTickRes := m.tick(target)
 	listsTickRes := m.metricLists.Tick()
@@ -292,17 +388,22 @@ func (m *metricMap) findOrCreate(key entryKey) (*Entry, error) {
 		m.Unlock()
 		return nil, err
 	}
-	entry = m.entryPool.Get()
+	entry = m.createEntryWithLock(key)
+	entry.IncWriter()
+	m.Unlock()
+
+	return entry, nil
+}
+
+func (m *metricMap) createEntryWithLock(key entryKey) *Entry {
+	entry := m.entryPool.Get()
 	entry.ResetSetData(m.metricLists, m.runtimeOpts, m.opts)
 	m.entries[key] = m.entryList.PushBack(hashedEntry{
 		key:   key,
 		entry: entry,
 	})
-	entry.IncWriter()
-	m.Unlock()
 	m.metrics.newEntries.Inc(1)
-
-	return entry, nil
+	return entry
 }
 
 func (m *metricMap) lookupEntryWithLock(key entryKey) (*Entry, bool) {
@@ -24,7 +24,9 @@ import (
 	"sync"
 	"time"
 
+	raggregation "github.com/m3db/m3/src/aggregator/aggregation"
 	"github.com/m3db/m3/src/aggregator/aggregation/quantile/cm"
+	"github.com/m3db/m3/src/aggregator/aggregation/quantile/tdigest"
 	"github.com/m3db/m3/src/aggregator/aggregator/handler"
 	"github.com/m3db/m3/src/aggregator/aggregator/handler/writer"
 	"github.com/m3db/m3/src/aggregator/client"
@@ -82,6 +84,16 @@ type MaxAllowedForwardingDelayFn func(resolution time.Duration, numForwardedTime
 // BufferForPastTimedMetricFn returns the buffer duration for past timed metrics.
 type BufferForPastTimedMetricFn func(resolution time.Duration) time.Duration
 
+// CorrectionBufferFn returns how long after the buffer for past timed metrics
+// late timed metrics aggregated with the given storage policy are still accepted
+// and emitted as corrections of the aggregation windows already flushed, with a
+// zero buffer disabling corrections.
+type CorrectionBufferFn func(sp policy.StoragePolicy) time.Duration
+
+// TimerQuantileSketchFn returns the quantile sketch used by timers aggregated
+// with the given storage policy.
+type TimerQuantileSketchFn func(sp policy.StoragePolicy) raggregation.QuantileSketchType
+
 // Options provide a set of base and derived options for the aggregator.
 type Options interface {
 	/// Read-write base options.
@@ -140,6 +152,20 @@ type Options interface {
 	// StreamOptions returns the stream options.
 	StreamOptions() cm.Options
 
+	// SetDigestOptions sets the t-digest options.
+	SetDigestOptions(value tdigest.Options) Options
+
+	// DigestOptions returns the t-digest options.
+	DigestOptions() tdigest.Options
+
+	// SetTimerQuantileSketchFn sets the function that determines the quantile
+	// sketch used by timers.
+	SetTimerQuantileSketchFn(value TimerQuantileSketchFn) Options
+
+	// TimerQuantileSketchFn returns the function that determines the quantile
+	// sketch used by timers.
+	TimerQuantileSketchFn() TimerQuantileSketchFn
+
 	// SetAdminClient sets the administrative client.
 	SetAdminClient(value client.AdminClient) Options
 
@@ -182,6 +208,12 @@ type Options interface {
 	// FlushTimesManager returns the flush times manager.
 	FlushTimesManager() FlushTimesManager
 
+	// SetSnapshotManager sets the snapshot manager, or nil to disable snapshots.
+	SetSnapshotManager(value SnapshotManager) Options
+
+	// SnapshotManager returns the snapshot manager.
+	SnapshotManager() SnapshotManager
+
 	// SetElectionManager sets the election manager.
 	SetElectionManager(value ElectionManager) Options
 
@@ -256,6 +288,14 @@ type Options interface {
 	// BufferForPastTimedMetricFn returns the size of the buffer for timed metrics in the past.
 	BufferForPastTimedMetricFn() BufferForPastTimedMetricFn
 
+	// SetCorrectionBufferFn sets the function that determines the size of the buffer
+	// for late timed metrics emitted as corrections.
+	SetCorrectionBufferFn(value CorrectionBufferFn) Options
+
+	// CorrectionBufferFn returns the function that determines the size of the buffer
+	// for late timed metrics emitted as corrections.
+	CorrectionBufferFn() CorrectionBufferFn
+
 	// SetBufferForFutureTimedMetric sets the size of the buffer for timed metrics in the future.
 	SetBufferForFutureTimedMetric(value time.Duration) Options
 
@@ -327,6 +367,8 @@ type options struct {
 	clockOpts                        clock.Options
 	instrumentOpts                   instrument.Options
 	streamOpts                       cm.Options
+	digestOpts                       tdigest.Options
+	timerQuantileSketchFn            TimerQuantileSketchFn
 	adminClient                      client.AdminClient
 	runtimeOptsManager               runtime.OptionsManager
 	placementManager                 PlacementManager
@@ -342,10 +384,12 @@ type options struct {
 	maxTimerBatchSizePerWrite        int
 	defaultStoragePolicies           []policy.StoragePolicy
 	flushTimesManager                FlushTimesManager
+	snapshotManager                  SnapshotManager
 	electionManager                  ElectionManager
 	resignTimeout                    time.Duration
 	maxAllowedForwardingDelayFn      MaxAllowedForwardingDelayFn
 	bufferForPastTimedMetricFn       BufferForPastTimedMetricFn
+	correctionBufferFn               CorrectionBufferFn
 	bufferForFutureTimedMetric       time.Duration
 	maxNumCachedSourceSets           int
 	discardNaNAggregatedValues       bool
@@ -378,6 +422,8 @@ func NewOptions() Options {
 		clockOpts:                        clock.NewOptions(),
 		instrumentOpts:                   instrument.NewOptions(),
 		streamOpts:                       cm.NewOptions(),
+		digestOpts:                       tdigest.NewOptions(),
+		timerQuantileSketchFn:            defaultTimerQuantileSketchFn,
 		runtimeOptsManager:               runtime.NewOptionsManager(runtime.NewOptions()),
 		shardFn:                          sharding.Murmur32Hash.MustShardFn(),
 		bufferDurationBeforeShardCutover: defaultBufferDurationBeforeShardCutover,
@@ -391,6 +437,7 @@ func NewOptions() Options {
 		resignTimeout:                    defaultResignTimeout,
 		maxAllowedForwardingDelayFn:      defaultMaxAllowedForwardingDelayFn,
 		bufferForPastTimedMetricFn:       defaultBufferForPastTimedMetricFn,
+		correctionBufferFn:               defaultCorrectionBufferFn,
 		bufferForFutureTimedMetric:       defaultTimedMetricBuffer,
 		maxNumCachedSourceSets:           defaultMaxNumCachedSourceSets,
 		discardNaNAggregatedValues:       defaultDiscardNaNAggregatedValues,
@@ -500,6 +547,26 @@ func (o *options) StreamOptions() cm.Options {
 	return o.streamOpts
 }
 
+func (o *options) SetDigestOptions(value tdigest.Options) Options {
+	opts := *o
+	opts.digestOpts = value
+	return &opts
+}
+
+func (o *options) DigestOptions() tdigest.Options {
+	return o.digestOpts
+}
+
+func (o *options) SetTimerQuantileSketchFn(value TimerQuantileSketchFn) Options {
+	opts := *o
+	opts.timerQuantileSketchFn = value
+	return &opts
+}
+
+func (o *options) TimerQuantileSketchFn() TimerQuantileSketchFn {
+	return o.timerQuantileSketchFn
+}
+
 func (o *options) SetAdminClient(value client.AdminClient) Options {
 	opts := *o
 	opts.adminClient = value
@@ -570,6 +637,16 @@ func (o *options) FlushTimesManager() FlushTimesManager {
 	return o.flushTimesManager
 }
 
+func (o *options) SetSnapshotManager(value SnapshotManager) Options {
+	opts := *o
+	opts.snapshotManager = value
+	return &opts
+}
+
+func (o *options) SnapshotManager() SnapshotManager {
+	return o.snapshotManager
+}
+
 func (o *options) SetElectionManager(value ElectionManager) Options {
 	opts := *o
 	opts.electionManager = value
@@ -690,6 +767,16 @@ func (o *options) BufferForPastTimedMetricFn() BufferForPastTimedMetricFn {
 	return o.bufferForPastTimedMetricFn
 }
 
+func (o *options) SetCorrectionBufferFn(value CorrectionBufferFn) Options {
+	opts := *o
+	opts.correctionBufferFn = value
+	return &opts
+}
+
+func (o *options) CorrectionBufferFn() CorrectionBufferFn {
+	return o.correctionBufferFn
+}
+
 func (o *options) SetBufferForFutureTimedMetric(value time.Duration) Options {
 	opts := *o
 	opts.bufferForFutureTimedMetric = value
@@ -847,6 +934,14 @@ func defaultMaxAllowedForwardingDelayFn(
 	return resolution * time.Duration(numForwardedTimes)
 }
 
+func defaultTimerQuantileSketchFn(policy.StoragePolicy) raggregation.QuantileSketchType {
+	return raggregation.DefaultQuantileSketch
+}
+
+func defaultCorrectionBufferFn(policy.StoragePolicy) time.Duration {
+	return 0
+}
+
 func defaultBufferForPastTimedMetricFn(resolution time.Duration) time.Duration {
 	return resolution + defaultTimedMetricBuffer
 }
@@ -25,11 +25,14 @@ import (
 	"testing"
 	"time"
 
+	raggregation "github.com/m3db/m3/src/aggregator/aggregation"
 	"github.com/m3db/m3/src/aggregator/aggregation/quantile/cm"
+	"github.com/m3db/m3/src/aggregator/aggregation/quantile/tdigest"
 	"github.com/m3db/m3/src/aggregator/aggregator/handler"
 	"github.com/m3db/m3/src/aggregator/aggregator/handler/writer"
 	"github.com/m3db/m3/src/aggregator/client"
 	"github.com/m3db/m3/src/aggregator/runtime"
+	"github.com/m3db/m3/src/metrics/policy"
 	"github.com/m3db/m3/src/x/clock"
 	"github.com/m3db/m3/src/x/instrument"
 
@@ -123,6 +126,23 @@ func TestSetStreamOptions(t *testing.T) {
 	require.Equal(t, value, o.StreamOptions())
 }
 
+func TestSetDigestOptions(t *testing.T) {
+	value := tdigest.NewOptions()
+	o := NewOptions().SetDigestOptions(value)
+	require.Equal(t, value, o.DigestOptions())
+}
+
+func TestSetTimerQuantileSketchFn(t *testing.T) {
+	o := NewOptions()
+	require.Equal(t, raggregation.CMQuantileSketch, o.TimerQuantileSketchFn()(testStoragePolicy))
+
+	fn := func(policy.StoragePolicy) raggregation.QuantileSketchType {
+		return raggregation.TDigestQuantileSketch
+	}
+	o = o.SetTimerQuantileSketchFn(fn)
+	require.Equal(t, raggregation.TDigestQuantileSketch, o.TimerQuantileSketchFn()(testStoragePolicy))
+}
+
 func TestSetAdminClient(t *testing.T) {
 	c, err := client.NewClient(client.NewOptions())
 	require.NoError(t, err)
@@ -186,6 +206,17 @@ func TestSetTimedAggregationBufferPastFn(t *testing.T) {
 	require.Equal(t, 2*time.Minute, fn(time.Minute))
 }
 
+func TestSetCorrectionBufferFn(t *testing.T) {
+	o := NewOptions()
+	require.Equal(t, time.Duration(0), o.CorrectionBufferFn()(testStoragePolicy))
+
+	fn := func(policy.StoragePolicy) time.Duration {
+		return time.Hour
+	}
+	o = o.SetCorrectionBufferFn(fn)
+	require.Equal(t, time.Hour, o.CorrectionBufferFn()(testStoragePolicy))
+}
+
 func TestSetTimedAggregationBufferFutureFn(t *testing.T) {
 	o := NewOptions().SetBufferForFutureTimedMetric(3 * time.Minute)
 	require.Equal(t, 3*time.Minute, o.BufferForFutureTimedMetric())
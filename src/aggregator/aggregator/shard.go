@@ -27,6 +27,8 @@ import (
 	"sync"
 	"time"
 
+	schema "github.com/m3db/m3/src/aggregator/generated/proto/flush"
+	"github.com/m3db/m3/src/aggregator/generated/proto/snapshot"
 	"github.com/m3db/m3/src/metrics/metadata"
 	"github.com/m3db/m3/src/metrics/metric/aggregated"
 	"github.com/m3db/m3/src/metrics/metric/unaggregated"
@@ -254,6 +256,34 @@ func (s *aggregatorShard) AddForwarded(
 	return nil
 }
 
+// Snapshot appends the aggregation windows of the shard that have not been
+// consumed to the snapshot.
+func (s *aggregatorShard) Snapshot(pb *snapshot.ShardSnapshot) error {
+	s.RLock()
+	defer s.RUnlock()
+
+	if s.closed {
+		return errAggregatorShardClosed
+	}
+	pb.Shard = s.shard
+	return s.metricMap.Snapshot(pb)
+}
+
+// Restore restores the aggregation windows in the snapshot that have not been
+// flushed according to the flush times of the shard.
+func (s *aggregatorShard) Restore(
+	pb snapshot.ShardSnapshot,
+	flushTimes *schema.ShardFlushTimes,
+) error {
+	s.RLock()
+	defer s.RUnlock()
+
+	if s.closed {
+		return errAggregatorShardClosed
+	}
+	return s.metricMap.Restore(pb, flushTimes)
+}
+
 func (s *aggregatorShard) Tick(target time.Duration) tickResult {
 	return s.metricMap.Tick(target)
 }
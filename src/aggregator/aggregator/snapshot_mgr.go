@@ -0,0 +1,402 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aggregator
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	schema "github.com/m3db/m3/src/aggregator/generated/proto/flush"
+	"github.com/m3db/m3/src/aggregator/generated/proto/snapshot"
+	"github.com/m3db/m3/src/x/clock"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+)
+
+const (
+	snapshotFilePrefix  = "shard-"
+	snapshotFileSuffix  = ".snapshot"
+	snapshotTmpSuffix   = ".tmp"
+	snapshotChecksumLen = 4
+)
+
+var (
+	errSnapshotManagerNotOpenOrClosed     = errors.New("snapshot manager not open or closed")
+	errSnapshotManagerAlreadyOpenOrClosed = errors.New("snapshot manager already open or closed")
+	errSnapshotChecksumMismatch           = errors.New("snapshot checksum mismatch")
+)
+
+// SnapshotManager periodically snapshots the aggregation windows of the shards
+// owned by the aggregator that have not been flushed to local disk, so they
+// can be restored when the aggregator restarts.
+type SnapshotManager interface {
+	// Restore restores the aggregation windows of the shards from their latest
+	// snapshots, skipping the windows that have already been flushed according
+	// to the flush times.
+	Restore(shards []*aggregatorShard, flushTimesManager FlushTimesManager) error
+
+	// Open starts snapshotting the shards returned by the function periodically.
+	Open(shardsFn snapshotShardsFn) error
+
+	// Close stops snapshotting the shards periodically after taking a final
+	// snapshot of the given shards.
+	Close(shards []*aggregatorShard) error
+}
+
+type snapshotShardsFn func() []*aggregatorShard
+
+type snapshotManagerState int
+
+const (
+	snapshotManagerNotOpen snapshotManagerState = iota
+	snapshotManagerOpen
+	snapshotManagerClosed
+)
+
+type snapshotManagerMetrics struct {
+	snapshot          instrument.MethodMetrics
+	shardErrors       tally.Counter
+	entriesSnapshot   tally.Counter
+	restore           instrument.MethodMetrics
+	restoreErrors     tally.Counter
+	shardsRestored    tally.Counter
+	entriesRestored   tally.Counter
+	noFlushTimes      tally.Counter
+	staleRemoveErrors tally.Counter
+}
+
+func newSnapshotManagerMetrics(scope tally.Scope) snapshotManagerMetrics {
+	snapshotScope := scope.SubScope("snapshot")
+	restoreScope := scope.SubScope("restore")
+	return snapshotManagerMetrics{
+		snapshot:          instrument.NewMethodMetrics(scope, "snapshot", 1.0),
+		shardErrors:       snapshotScope.Counter("shard-errors"),
+		entriesSnapshot:   snapshotScope.Counter("entries"),
+		restore:           instrument.NewMethodMetrics(scope, "restore", 1.0),
+		restoreErrors:     restoreScope.Counter("shard-errors"),
+		shardsRestored:    restoreScope.Counter("shards"),
+		entriesRestored:   restoreScope.Counter("entries"),
+		noFlushTimes:      restoreScope.Counter("no-flush-times"),
+		staleRemoveErrors: snapshotScope.Counter("stale-remove-errors"),
+	}
+}
+
+type snapshotManager struct {
+	sync.Mutex
+
+	nowFn                 clock.NowFn
+	logger                *zap.Logger
+	snapshotDir           string
+	snapshotInterval      time.Duration
+	flushTimesWaitTimeout time.Duration
+
+	state   snapshotManagerState
+	doneCh  chan struct{}
+	buf     []byte
+	metrics snapshotManagerMetrics
+}
+
+// NewSnapshotManager creates a new snapshot manager.
+func NewSnapshotManager(opts SnapshotManagerOptions) SnapshotManager {
+	instrumentOpts := opts.InstrumentOptions()
+	return &snapshotManager{
+		nowFn:                 opts.ClockOptions().NowFn(),
+		logger:                instrumentOpts.Logger(),
+		snapshotDir:           opts.SnapshotDir(),
+		snapshotInterval:      opts.SnapshotInterval(),
+		flushTimesWaitTimeout: opts.FlushTimesWaitTimeout(),
+		doneCh:                make(chan struct{}),
+		metrics:               newSnapshotManagerMetrics(instrumentOpts.MetricsScope()),
+	}
+}
+
+func (mgr *snapshotManager) Restore(
+	shards []*aggregatorShard,
+	flushTimesManager FlushTimesManager,
+) error {
+	var (
+		restoreStart = mgr.nowFn()
+		toRestore    = make([]*aggregatorShard, 0, len(shards))
+		snapshots    = make([]snapshot.ShardSnapshot, 0, len(shards))
+		multiErr     = xerrors.NewMultiError()
+	)
+	for _, shard := range shards {
+		pb, err := mgr.read(shard.ID())
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			mgr.metrics.restoreErrors.Inc(1)
+			multiErr = multiErr.Add(fmt.Errorf("error reading snapshot of shard %d: %v", shard.ID(), err))
+			continue
+		}
+		toRestore = append(toRestore, shard)
+		snapshots = append(snapshots, pb)
+	}
+
+	// NB: only the aggregation windows that have not been flushed are restored,
+	// which avoids emitting the same windows twice if they were flushed by this
+	// instance or its peers after the snapshots were taken.
+	var flushTimes *schema.ShardSetFlushTimes
+	if len(snapshots) > 0 {
+		flushTimes = mgr.waitForFlushTimes(flushTimesManager)
+	}
+	for i, shard := range toRestore {
+		shardFlushTimes := flushTimes.GetByShard()[shard.ID()]
+		if err := shard.Restore(snapshots[i], shardFlushTimes); err != nil {
+			mgr.metrics.restoreErrors.Inc(1)
+			multiErr = multiErr.Add(fmt.Errorf("error restoring snapshot of shard %d: %v", shard.ID(), err))
+			continue
+		}
+		mgr.metrics.shardsRestored.Inc(1)
+		mgr.metrics.entriesRestored.Inc(int64(len(snapshots[i].Entries)))
+	}
+
+	err := multiErr.FinalError()
+	if err == nil {
+		mgr.metrics.restore.ReportSuccess(mgr.nowFn().Sub(restoreStart))
+	} else {
+		mgr.metrics.restore.ReportError(mgr.nowFn().Sub(restoreStart))
+	}
+	return err
+}
+
+func (mgr *snapshotManager) Open(shardsFn snapshotShardsFn) error {
+	mgr.Lock()
+	defer mgr.Unlock()
+
+	if mgr.state != snapshotManagerNotOpen {
+		return errSnapshotManagerAlreadyOpenOrClosed
+	}
+	if err := os.MkdirAll(mgr.snapshotDir, 0755); err != nil {
+		return err
+	}
+	mgr.state = snapshotManagerOpen
+	go mgr.snapshotLoop(shardsFn)
+	return nil
+}
+
+// NB: Close does not wait for the snapshot loop to exit because the loop may be
+// waiting on the aggregator lock held by the caller. Snapshots taken by the loop
+// after the manager is closed are discarded instead.
+func (mgr *snapshotManager) Close(shards []*aggregatorShard) error {
+	mgr.Lock()
+	defer mgr.Unlock()
+
+	if mgr.state != snapshotManagerOpen {
+		return errSnapshotManagerNotOpenOrClosed
+	}
+	err := mgr.snapshotWithLock(shards)
+	mgr.state = snapshotManagerClosed
+	close(mgr.doneCh)
+	return err
+}
+
+func (mgr *snapshotManager) snapshotLoop(shardsFn snapshotShardsFn) {
+	ticker := time.NewTicker(mgr.snapshotInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-mgr.doneCh:
+			return
+		}
+		if err := mgr.snapshot(shardsFn()); err != nil && err != errSnapshotManagerNotOpenOrClosed {
+			mgr.logger.Error("error snapshotting aggregator shards", zap.Error(err))
+		}
+	}
+}
+
+func (mgr *snapshotManager) snapshot(shards []*aggregatorShard) error {
+	mgr.Lock()
+	defer mgr.Unlock()
+
+	if mgr.state != snapshotManagerOpen {
+		return errSnapshotManagerNotOpenOrClosed
+	}
+	return mgr.snapshotWithLock(shards)
+}
+
+// snapshotWithLock snapshots the given shards and removes the snapshots of the
+// shards that are no longer owned.
+func (mgr *snapshotManager) snapshotWithLock(shards []*aggregatorShard) error {
+	var (
+		snapshotStart = mgr.nowFn()
+		owned         = make(map[uint32]struct{}, len(shards))
+		multiErr      = xerrors.NewMultiError()
+	)
+	for _, shard := range shards {
+		owned[shard.ID()] = struct{}{}
+		pb := snapshot.ShardSnapshot{SnapshotNanos: snapshotStart.UnixNano()}
+		if err := shard.Snapshot(&pb); err != nil {
+			// Keep the previous snapshot of the shard if any.
+			mgr.metrics.shardErrors.Inc(1)
+			multiErr = multiErr.Add(fmt.Errorf("error snapshotting shard %d: %v", shard.ID(), err))
+			continue
+		}
+		if err := mgr.write(pb); err != nil {
+			mgr.metrics.shardErrors.Inc(1)
+			multiErr = multiErr.Add(fmt.Errorf("error writing snapshot of shard %d: %v", shard.ID(), err))
+			continue
+		}
+		mgr.metrics.entriesSnapshot.Inc(int64(len(pb.Entries)))
+	}
+	mgr.removeStale(owned)
+
+	err := multiErr.FinalError()
+	if err == nil {
+		mgr.metrics.snapshot.ReportSuccess(mgr.nowFn().Sub(snapshotStart))
+	} else {
+		mgr.metrics.snapshot.ReportError(mgr.nowFn().Sub(snapshotStart))
+	}
+	return err
+}
+
+func (mgr *snapshotManager) removeStale(owned map[uint32]struct{}) {
+	files, err := ioutil.ReadDir(mgr.snapshotDir)
+	if err != nil {
+		mgr.metrics.staleRemoveErrors.Inc(1)
+		return
+	}
+	for _, file := range files {
+		shard, ok := shardFromSnapshotFileName(file.Name())
+		if !ok {
+			continue
+		}
+		if _, exists := owned[shard]; exists {
+			continue
+		}
+		if err := os.Remove(filepath.Join(mgr.snapshotDir, file.Name())); err != nil {
+			mgr.metrics.staleRemoveErrors.Inc(1)
+		}
+	}
+}
+
+// waitForFlushTimes waits for the flush times to become available, returning
+// nil if they are not available before the timeout, e.g. if no shards have been
+// flushed yet, in which case all the windows in the snapshots are restored.
+func (mgr *snapshotManager) waitForFlushTimes(
+	flushTimesManager FlushTimesManager,
+) *schema.ShardSetFlushTimes {
+	watch, err := flushTimesManager.Watch()
+	if err != nil {
+		mgr.metrics.noFlushTimes.Inc(1)
+		mgr.logger.Warn("unable to watch flush times for restoring snapshots", zap.Error(err))
+		return nil
+	}
+	defer watch.Close()
+
+	select {
+	case <-watch.C():
+		flushTimes, _ := watch.Get().(*schema.ShardSetFlushTimes)
+		return flushTimes
+	case <-time.After(mgr.flushTimesWaitTimeout):
+		mgr.metrics.noFlushTimes.Inc(1)
+		mgr.logger.Warn("no flush times available for restoring snapshots",
+			zap.Duration("timeout", mgr.flushTimesWaitTimeout),
+		)
+		return nil
+	}
+}
+
+// The snapshot of a shard is stored in a file named after the shard containing
+// the CRC32 checksum of the marshalled snapshot followed by the snapshot itself.
+// Snapshots are written to a temporary file first which is then renamed, so a
+// crash while writing a snapshot leaves the previous snapshot intact.
+func (mgr *snapshotManager) write(pb snapshot.ShardSnapshot) error {
+	size := snapshotChecksumLen + pb.Size()
+	if cap(mgr.buf) < size {
+		mgr.buf = make([]byte, size)
+	}
+	buf := mgr.buf[:size]
+	if _, err := pb.MarshalTo(buf[snapshotChecksumLen:]); err != nil {
+		return err
+	}
+	binary.BigEndian.PutUint32(buf, crc32.ChecksumIEEE(buf[snapshotChecksumLen:]))
+
+	var (
+		path    = snapshotFilePath(mgr.snapshotDir, pb.Shard)
+		tmpPath = path + snapshotTmpSuffix
+	)
+	f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(buf); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+func (mgr *snapshotManager) read(shard uint32) (snapshot.ShardSnapshot, error) {
+	var pb snapshot.ShardSnapshot
+	data, err := ioutil.ReadFile(snapshotFilePath(mgr.snapshotDir, shard))
+	if err != nil {
+		return pb, err
+	}
+	if len(data) < snapshotChecksumLen ||
+		binary.BigEndian.Uint32(data) != crc32.ChecksumIEEE(data[snapshotChecksumLen:]) {
+		return pb, errSnapshotChecksumMismatch
+	}
+	if err := pb.Unmarshal(data[snapshotChecksumLen:]); err != nil {
+		return pb, err
+	}
+	if pb.Shard != shard {
+		return pb, fmt.Errorf("snapshot is for shard %d", pb.Shard)
+	}
+	return pb, nil
+}
+
+func snapshotFilePath(dir string, shard uint32) string {
+	return filepath.Join(dir, snapshotFilePrefix+strconv.FormatUint(uint64(shard), 10)+snapshotFileSuffix)
+}
+
+func shardFromSnapshotFileName(name string) (uint32, bool) {
+	if !strings.HasPrefix(name, snapshotFilePrefix) || !strings.HasSuffix(name, snapshotFileSuffix) {
+		return 0, false
+	}
+	shard, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(name, snapshotFilePrefix), snapshotFileSuffix), 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return uint32(shard), true
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aggregator
+
+import (
+	"time"
+
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+const (
+	defaultSnapshotInterval      = time.Minute
+	defaultFlushTimesWaitTimeout = 10 * time.Second
+)
+
+// SnapshotManagerOptions provide a set of options for snapshot manager.
+type SnapshotManagerOptions interface {
+	// SetClockOptions sets the clock options.
+	SetClockOptions(value clock.Options) SnapshotManagerOptions
+
+	// ClockOptions returns the clock options.
+	ClockOptions() clock.Options
+
+	// SetInstrumentOptions sets the instrument options.
+	SetInstrumentOptions(value instrument.Options) SnapshotManagerOptions
+
+	// InstrumentOptions returns the instrument options.
+	InstrumentOptions() instrument.Options
+
+	// SetSnapshotDir sets the directory snapshots are stored in.
+	SetSnapshotDir(value string) SnapshotManagerOptions
+
+	// SnapshotDir returns the directory snapshots are stored in.
+	SnapshotDir() string
+
+	// SetSnapshotInterval sets the interval between snapshots.
+	SetSnapshotInterval(value time.Duration) SnapshotManagerOptions
+
+	// SnapshotInterval returns the interval between snapshots.
+	SnapshotInterval() time.Duration
+
+	// SetFlushTimesWaitTimeout sets the maximum amount of time to wait for the
+	// flush times when restoring snapshots.
+	SetFlushTimesWaitTimeout(value time.Duration) SnapshotManagerOptions
+
+	// FlushTimesWaitTimeout returns the maximum amount of time to wait for the
+	// flush times when restoring snapshots.
+	FlushTimesWaitTimeout() time.Duration
+}
+
+type snapshotManagerOptions struct {
+	clockOpts             clock.Options
+	instrumentOpts        instrument.Options
+	snapshotDir           string
+	snapshotInterval      time.Duration
+	flushTimesWaitTimeout time.Duration
+}
+
+// NewSnapshotManagerOptions create a new set of snapshot manager options.
+func NewSnapshotManagerOptions() SnapshotManagerOptions {
+	return &snapshotManagerOptions{
+		clockOpts:             clock.NewOptions(),
+		instrumentOpts:        instrument.NewOptions(),
+		snapshotInterval:      defaultSnapshotInterval,
+		flushTimesWaitTimeout: defaultFlushTimesWaitTimeout,
+	}
+}
+
+func (o *snapshotManagerOptions) SetClockOptions(value clock.Options) SnapshotManagerOptions {
+	opts := *o
+	opts.clockOpts = value
+	return &opts
+}
+
+func (o *snapshotManagerOptions) ClockOptions() clock.Options {
+	return o.clockOpts
+}
+
+func (o *snapshotManagerOptions) SetInstrumentOptions(value instrument.Options) SnapshotManagerOptions {
+	opts := *o
+	opts.instrumentOpts = value
+	return &opts
+}
+
+func (o *snapshotManagerOptions) InstrumentOptions() instrument.Options {
+	return o.instrumentOpts
+}
+
+func (o *snapshotManagerOptions) SetSnapshotDir(value string) SnapshotManagerOptions {
+	opts := *o
+	opts.snapshotDir = value
+	return &opts
+}
+
+func (o *snapshotManagerOptions) SnapshotDir() string {
+	return o.snapshotDir
+}
+
+func (o *snapshotManagerOptions) SetSnapshotInterval(value time.Duration) SnapshotManagerOptions {
+	opts := *o
+	opts.snapshotInterval = value
+	return &opts
+}
+
+func (o *snapshotManagerOptions) SnapshotInterval() time.Duration {
+	return o.snapshotInterval
+}
+
+func (o *snapshotManagerOptions) SetFlushTimesWaitTimeout(value time.Duration) SnapshotManagerOptions {
+	opts := *o
+	opts.flushTimesWaitTimeout = value
+	return &opts
+}
+
+func (o *snapshotManagerOptions) FlushTimesWaitTimeout() time.Duration {
+	return o.flushTimesWaitTimeout
+}
@@ -0,0 +1,258 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aggregator
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	schema "github.com/m3db/m3/src/aggregator/generated/proto/flush"
+	"github.com/m3db/m3/src/aggregator/generated/proto/snapshot"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotManagerCloseAndRestore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	opts := testOptions(ctrl)
+
+	mgr := testSnapshotManager(dir)
+	shard := testSnapshotShard(t, opts)
+	require.NoError(t, mgr.Open(func() []*aggregatorShard { return nil }))
+	require.NoError(t, mgr.Close([]*aggregatorShard{shard}))
+	_, err = os.Stat(snapshotFilePath(dir, testShard))
+	require.NoError(t, err)
+
+	var expected snapshot.ShardSnapshot
+	require.NoError(t, shard.Snapshot(&expected))
+	require.Equal(t, 3, testNumSnapshotWindows(expected))
+
+	// Restoring without any flush times restores all the windows.
+	flushTimesManager, _ := testFlushTimesManager()
+	require.NoError(t, flushTimesManager.Open(testShardSetID))
+	defer flushTimesManager.Close()
+
+	restored := newAggregatorShard(testShard, opts)
+	require.NoError(t, testSnapshotManager(dir).Restore([]*aggregatorShard{restored}, flushTimesManager))
+	var actual snapshot.ShardSnapshot
+	require.NoError(t, restored.Snapshot(&actual))
+	require.Equal(t, 3, testNumSnapshotWindows(actual))
+	require.Equal(t, len(expected.Entries), len(actual.Entries))
+	require.Equal(t, expected.Entries[0].Id, actual.Entries[0].Id)
+	testSortSnapshotElems(expected.Entries[0].Elems)
+	testSortSnapshotElems(actual.Entries[0].Elems)
+	require.Equal(t, expected.Entries[0].Elems, actual.Entries[0].Elems)
+}
+
+func TestSnapshotManagerRestoreSkipsFlushedWindows(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	opts := testOptions(ctrl)
+
+	mgr := testSnapshotManager(dir)
+	shard := testSnapshotShard(t, opts)
+	require.NoError(t, mgr.Open(func() []*aggregatorShard { return nil }))
+	require.NoError(t, mgr.Close([]*aggregatorShard{shard}))
+
+	// The windows of the 10s resolution have been flushed after the snapshot was taken.
+	flushTimesManager, store := testFlushTimesManager()
+	_, err = store.Set(testFlushTimesKey, &schema.ShardSetFlushTimes{
+		ByShard: map[uint32]*schema.ShardFlushTimes{
+			testShard: &schema.ShardFlushTimes{
+				StandardByResolution: map[int64]int64{
+					int64(10 * time.Second): math.MaxInt64,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, flushTimesManager.Open(testShardSetID))
+	defer flushTimesManager.Close()
+
+	restored := newAggregatorShard(testShard, opts)
+	require.NoError(t, testSnapshotManager(dir).Restore([]*aggregatorShard{restored}, flushTimesManager))
+	var actual snapshot.ShardSnapshot
+	require.NoError(t, restored.Snapshot(&actual))
+	require.Equal(t, 2, testNumSnapshotWindows(actual))
+	for _, elem := range actual.Entries[0].Elems {
+		require.NotEqual(t, int64(10*time.Second), elem.StoragePolicy.Resolution.WindowSize)
+	}
+}
+
+func TestSnapshotManagerRestoreNoSnapshots(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	opts := testOptions(ctrl)
+
+	// The flush times are not needed if there are no snapshots to restore.
+	flushTimesManager, _ := testFlushTimesManager()
+	shard := newAggregatorShard(testShard, opts)
+	require.NoError(t, testSnapshotManager(dir).Restore([]*aggregatorShard{shard}, flushTimesManager))
+	require.Equal(t, 0, len(shard.metricMap.entries))
+}
+
+func TestSnapshotManagerRestoreChecksumMismatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	opts := testOptions(ctrl)
+
+	mgr := testSnapshotManager(dir)
+	require.NoError(t, mgr.Open(func() []*aggregatorShard { return nil }))
+	require.NoError(t, mgr.Close([]*aggregatorShard{testSnapshotShard(t, opts)}))
+
+	path := snapshotFilePath(dir, testShard)
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	data[len(data)-1]++
+	require.NoError(t, ioutil.WriteFile(path, data, 0644))
+
+	_, err = mgr.read(testShard)
+	require.Equal(t, errSnapshotChecksumMismatch, err)
+
+	flushTimesManager, _ := testFlushTimesManager()
+	restored := newAggregatorShard(testShard, opts)
+	require.Error(t, testSnapshotManager(dir).Restore([]*aggregatorShard{restored}, flushTimesManager))
+	require.Equal(t, 0, len(restored.metricMap.entries))
+}
+
+func TestSnapshotManagerRemovesStaleSnapshots(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	opts := testOptions(ctrl)
+
+	mgr := testSnapshotManager(dir)
+	require.NoError(t, mgr.Open(func() []*aggregatorShard { return nil }))
+	otherShard := newAggregatorShard(testShard+1, opts)
+	require.NoError(t, mgr.snapshot([]*aggregatorShard{testSnapshotShard(t, opts), otherShard}))
+	otherFile := filepath.Join(dir, "other")
+	require.NoError(t, ioutil.WriteFile(otherFile, nil, 0644))
+
+	// The snapshot of a shard no longer owned is removed.
+	require.NoError(t, mgr.Close([]*aggregatorShard{testSnapshotShard(t, opts)}))
+	_, err = os.Stat(snapshotFilePath(dir, testShard))
+	require.NoError(t, err)
+	_, err = os.Stat(snapshotFilePath(dir, testShard+1))
+	require.True(t, os.IsNotExist(err))
+	_, err = os.Stat(otherFile)
+	require.NoError(t, err)
+}
+
+func TestSnapshotManagerSnapshotsPeriodically(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	opts := testOptions(ctrl)
+
+	mgr := NewSnapshotManager(NewSnapshotManagerOptions().
+		SetSnapshotDir(dir).
+		SetSnapshotInterval(10 * time.Millisecond)).(*snapshotManager)
+	shard := testSnapshotShard(t, opts)
+	require.NoError(t, mgr.Open(func() []*aggregatorShard { return []*aggregatorShard{shard} }))
+	require.Equal(t, errSnapshotManagerAlreadyOpenOrClosed, mgr.Open(nil))
+
+	for {
+		if _, err := mgr.read(testShard); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.NoError(t, mgr.Close(nil))
+	require.Equal(t, errSnapshotManagerNotOpenOrClosed, mgr.Close(nil))
+	require.Equal(t, errSnapshotManagerNotOpenOrClosed, mgr.snapshot(nil))
+}
+
+func TestShardFromSnapshotFileName(t *testing.T) {
+	inputs := []struct {
+		name          string
+		expectedShard uint32
+		expectedOK    bool
+	}{
+		{name: "shard-12.snapshot", expectedShard: 12, expectedOK: true},
+		{name: "shard-12.snapshot.tmp", expectedOK: false},
+		{name: "shard-foo.snapshot", expectedOK: false},
+		{name: "other", expectedOK: false},
+	}
+	for _, input := range inputs {
+		shard, ok := shardFromSnapshotFileName(input.name)
+		require.Equal(t, input.expectedOK, ok)
+		require.Equal(t, input.expectedShard, shard)
+	}
+}
+
+func testSnapshotManager(dir string) *snapshotManager {
+	opts := NewSnapshotManagerOptions().
+		SetSnapshotDir(dir).
+		SetFlushTimesWaitTimeout(100 * time.Millisecond)
+	return NewSnapshotManager(opts).(*snapshotManager)
+}
+
+func testSnapshotShard(t *testing.T, opts Options) *aggregatorShard {
+	shard := newAggregatorShard(testShard, opts)
+	shard.SetWriteableRange(timeRange{cutoverNanos: 0, cutoffNanos: math.MaxInt64})
+	require.NoError(t, shard.AddUntimed(testCounter, testCustomStagedMetadatas))
+	return shard
+}
+
+func testNumSnapshotWindows(pb snapshot.ShardSnapshot) int {
+	var numWindows int
+	for _, entry := range pb.Entries {
+		for _, elem := range entry.Elems {
+			numWindows += len(elem.Values)
+		}
+	}
+	return numWindows
+}
+
+func testSortSnapshotElems(elems []snapshot.ElemSnapshot) {
+	sort.Slice(elems, func(i, j int) bool {
+		return elems[i].StoragePolicy.Resolution.WindowSize < elems[j].StoragePolicy.Resolution.WindowSize
+	})
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package processor processes aggregated metrics consumed from m3msg topics
+// by publishing them to a sink.
+package processor
+
+import (
+	"sync"
+
+	"github.com/m3db/m3/src/bridge/sink"
+	"github.com/m3db/m3/src/metrics/encoding/protobuf"
+	"github.com/m3db/m3/src/msg/consumer"
+	"github.com/m3db/m3/src/x/instrument"
+	"github.com/m3db/m3/src/x/pool"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+)
+
+// Options for the processor.
+type Options struct {
+	InstrumentOptions          instrument.Options
+	Sink                       sink.Sink
+	ProtobufDecoderPoolOptions pool.ObjectPoolOptions
+}
+
+type processorMetrics struct {
+	metricAccepted               tally.Counter
+	metricPublished              tally.Counter
+	metricPublishError           tally.Counter
+	droppedMetricDecodeError     tally.Counter
+	droppedMetricDecodeMalformed tally.Counter
+}
+
+func newProcessorMetrics(scope tally.Scope) processorMetrics {
+	messageScope := scope.SubScope("metric")
+	return processorMetrics{
+		metricAccepted:     messageScope.Counter("accepted"),
+		metricPublished:    messageScope.Counter("published"),
+		metricPublishError: messageScope.Counter("publish-error"),
+		droppedMetricDecodeError: messageScope.Tagged(map[string]string{
+			"reason": "decode-error",
+		}).Counter("dropped"),
+		droppedMetricDecodeMalformed: messageScope.Tagged(map[string]string{
+			"reason": "decode-malformed",
+		}).Counter("dropped"),
+	}
+}
+
+type processor struct {
+	sink   sink.Sink
+	pool   protobuf.AggregatedDecoderPool
+	wg     sync.WaitGroup
+	logger *zap.Logger
+	m      processorMetrics
+}
+
+// NewProcessor creates a message processor that decodes aggregated metrics
+// and publishes them to the sink. A message is only acked once the sink has
+// confirmed its metric so the producer retries any metric the sink failed
+// to publish, messages that can not be decoded are acked and dropped since
+// retrying them would never succeed.
+func NewProcessor(opts Options) consumer.MessageProcessor {
+	p := protobuf.NewAggregatedDecoderPool(opts.ProtobufDecoderPoolOptions)
+	p.Init()
+	return &processor{
+		sink:   opts.Sink,
+		pool:   p,
+		logger: opts.InstrumentOptions.Logger(),
+		m:      newProcessorMetrics(opts.InstrumentOptions.MetricsScope()),
+	}
+}
+
+func (p *processor) Process(msg consumer.Message) {
+	dec := p.pool.Get()
+	// The sink copies what it needs before Write returns, so the decoder
+	// and the bytes it references can be returned right after.
+	defer dec.Close()
+
+	if err := dec.Decode(msg.Bytes()); err != nil {
+		p.logger.Error("could not decode metric from message", zap.Error(err))
+		p.m.droppedMetricDecodeError.Inc(1)
+		msg.Ack()
+		return
+	}
+	sp, err := dec.StoragePolicy()
+	if err != nil {
+		p.logger.Error("invalid storage policy", zap.Error(err))
+		p.m.droppedMetricDecodeMalformed.Inc(1)
+		msg.Ack()
+		return
+	}
+	p.m.metricAccepted.Inc(1)
+
+	p.wg.Add(1)
+	p.sink.Write(sink.Metric{
+		ID:            dec.ID(),
+		TimeNanos:     dec.TimeNanos(),
+		Value:         dec.Value(),
+		StoragePolicy: sp,
+	}, func(err error) {
+		defer p.wg.Done()
+		if err != nil {
+			// Leave the message unacked so the producer retries it.
+			p.m.metricPublishError.Inc(1)
+			return
+		}
+		p.m.metricPublished.Inc(1)
+		msg.Ack()
+	})
+}
+
+func (p *processor) Close() { p.wg.Wait() }
@@ -0,0 +1,171 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package processor
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/bridge/sink"
+	"github.com/m3db/m3/src/metrics/encoding/protobuf"
+	"github.com/m3db/m3/src/metrics/metric"
+	"github.com/m3db/m3/src/metrics/metric/aggregated"
+	"github.com/m3db/m3/src/metrics/policy"
+	"github.com/m3db/m3/src/msg/consumer"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testID            = "foo+env=production"
+	testStoragePolicy = policy.MustParseStoragePolicy("1m:40d")
+)
+
+type write struct {
+	metric   sink.Metric
+	callback sink.Callback
+}
+
+type fakeSink struct {
+	sync.Mutex
+
+	writes []write
+}
+
+func (s *fakeSink) Write(m sink.Metric, callback sink.Callback) {
+	s.Lock()
+	defer s.Unlock()
+	m.ID = append([]byte(nil), m.ID...)
+	s.writes = append(s.writes, write{metric: m, callback: callback})
+}
+
+func (s *fakeSink) Close() error { return nil }
+
+func (s *fakeSink) written() []write {
+	s.Lock()
+	defer s.Unlock()
+	return append([]write(nil), s.writes...)
+}
+
+func newTestProcessor(s sink.Sink) consumer.MessageProcessor {
+	return NewProcessor(Options{
+		InstrumentOptions: instrument.NewOptions(),
+		Sink:              s,
+	})
+}
+
+func testMessage(t *testing.T, ctrl *gomock.Controller) *consumer.MockMessage {
+	encoder := protobuf.NewAggregatedEncoder(nil)
+	require.NoError(t, encoder.Encode(aggregated.MetricWithStoragePolicy{
+		Metric: aggregated.Metric{
+			ID:        []byte(testID),
+			TimeNanos: 1000,
+			Value:     42,
+			Type:      metric.GaugeType,
+		},
+		StoragePolicy: testStoragePolicy,
+	}, 2000))
+	msg := consumer.NewMockMessage(ctrl)
+	msg.EXPECT().Bytes().Return(encoder.Buffer().Bytes())
+	return msg
+}
+
+func TestProcessorAcksAfterSinkConfirms(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := &fakeSink{}
+	p := newTestProcessor(s)
+	msg := testMessage(t, ctrl)
+
+	// The message must not be acked before the sink confirms it.
+	p.Process(msg)
+	writes := s.written()
+	require.Equal(t, 1, len(writes))
+	require.Equal(t, sink.Metric{
+		ID:            []byte(testID),
+		TimeNanos:     1000,
+		Value:         42,
+		StoragePolicy: testStoragePolicy,
+	}, writes[0].metric)
+
+	msg.EXPECT().Ack()
+	writes[0].callback(nil)
+	p.Close()
+}
+
+func TestProcessorSinkErrorLeavesMessageUnacked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := &fakeSink{}
+	p := newTestProcessor(s)
+
+	p.Process(testMessage(t, ctrl))
+	writes := s.written()
+	require.Equal(t, 1, len(writes))
+	writes[0].callback(errors.New("sink error"))
+	p.Close()
+}
+
+func TestProcessorAcksUndecodableMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := &fakeSink{}
+	p := newTestProcessor(s)
+
+	msg := consumer.NewMockMessage(ctrl)
+	msg.EXPECT().Bytes().Return([]byte("not a metric"))
+	msg.EXPECT().Ack()
+	p.Process(msg)
+	require.Empty(t, s.written())
+	p.Close()
+}
+
+func TestProcessorCloseWaitsForSink(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := &fakeSink{}
+	p := newTestProcessor(s)
+	msg := testMessage(t, ctrl)
+	p.Process(msg)
+
+	closed := make(chan struct{})
+	go func() {
+		p.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+		require.FailNow(t, "processor closed with a pending write")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	msg.EXPECT().Ack()
+	s.written()[0].callback(nil)
+	<-closed
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package server runs the bridge which consumes aggregated metrics from an
+// m3msg topic and publishes them to Kafka.
+package server
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/m3db/m3/src/bridge/processor"
+	"github.com/m3db/m3/src/cmd/services/m3bridge/config"
+	"github.com/m3db/m3/src/msg/consumer"
+	xconfig "github.com/m3db/m3/src/x/config"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"go.uber.org/zap"
+)
+
+// NB: The consumer dedups messages as they are received, so a message the
+// sink failed to publish would be dropped as a duplicate when the producer
+// retries it.
+var errDedupNotSupported = errors.New("m3msg consumer dedup is not supported by the bridge")
+
+// RunOptions provides options for running the server
+// with backwards compatibility if only solely adding fields.
+type RunOptions struct {
+	// Config will be used to configure the application.
+	Config config.Configuration
+
+	// InterruptCh is a programmatic interrupt channel to supply to
+	// interrupt and shutdown the server.
+	InterruptCh <-chan error
+}
+
+// Run runs the server programmatically given a filename for the configuration file.
+func Run(runOpts RunOptions) {
+	cfg := runOpts.Config
+
+	logger, err := cfg.Logging.Build()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to create logger: %v", err)
+		os.Exit(1)
+	}
+	defer logger.Sync()
+
+	xconfig.WarnOnDeprecation(cfg, logger)
+
+	if size := cfg.M3Msg.Consumer.DedupWindowSize; size != nil && *size > 0 {
+		logger.Fatal("invalid m3msg consumer config", zap.Error(errDedupNotSupported))
+	}
+
+	logger.Info("creating metrics scope")
+	scope, closer, err := cfg.Metrics.NewRootScope()
+	if err != nil {
+		logger.Fatal("could not connect to metrics", zap.Error(err))
+	}
+	defer closer.Close()
+
+	instrumentOpts := instrument.NewOptions().
+		SetMetricsScope(scope).
+		SetLogger(logger)
+
+	logger.Info("creating kafka sink")
+	sink, err := cfg.Kafka.NewSink(instrumentOpts.SetMetricsScope(
+		scope.SubScope("kafka")))
+	if err != nil {
+		logger.Fatal("could not create kafka sink", zap.Error(err))
+	}
+	defer func() {
+		logger.Info("closing kafka sink")
+		if err := sink.Close(); err != nil {
+			logger.Error("error closing kafka sink", zap.Error(err))
+		}
+	}()
+
+	m3msgScope := scope.Tagged(map[string]string{"server": "m3msg"})
+	p := processor.NewProcessor(processor.Options{
+		InstrumentOptions: instrumentOpts.SetMetricsScope(m3msgScope.Tagged(
+			map[string]string{"handler": "protobuf"})),
+		Sink: sink,
+		ProtobufDecoderPoolOptions: cfg.M3Msg.ProtobufDecoderPool.NewObjectPoolOptions(
+			instrumentOpts),
+	})
+	cOpts := cfg.M3Msg.Consumer.NewOptions(instrumentOpts.SetMetricsScope(
+		m3msgScope.Tagged(map[string]string{"component": "consumer"})))
+	srv := cfg.M3Msg.Server.NewServer(consumer.NewMessageHandler(p, cOpts),
+		instrumentOpts.SetMetricsScope(m3msgScope))
+
+	logger.Info("starting m3msg server",
+		zap.String("address", cfg.M3Msg.Server.ListenAddress))
+	if err := srv.ListenAndServe(); err != nil {
+		logger.Fatal("could not start m3msg server", zap.Error(err))
+	}
+	// NB: Closing the server waits for the processor to finish outstanding
+	// writes, so it must be closed before the sink.
+	defer func() {
+		logger.Info("closing m3msg server")
+		srv.Close()
+	}()
+
+	var interruptCh <-chan error = make(chan error)
+	if runOpts.InterruptCh != nil {
+		interruptCh = runOpts.InterruptCh
+	}
+
+	var interruptErr error
+	if runOpts.InterruptCh != nil {
+		interruptErr = <-interruptCh
+	} else {
+		sigChan := make(chan os.Signal, 1)
+		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+		select {
+		case sig := <-sigChan:
+			interruptErr = fmt.Errorf("%v", sig)
+		case interruptErr = <-interruptCh:
+		}
+	}
+
+	logger.Info("interrupt", zap.String("cause", interruptErr.Error()))
+}
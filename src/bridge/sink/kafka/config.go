@@ -0,0 +1,72 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package kafka
+
+import (
+	"time"
+
+	"github.com/m3db/m3/src/bridge/sink"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+// Configuration configs the Kafka sink options.
+type Configuration struct {
+	Brokers       []string       `yaml:"brokers"`
+	Topic         string         `yaml:"topic"`
+	ClientID      *string        `yaml:"clientID"`
+	RequiredAcks  *int           `yaml:"requiredAcks"`
+	Timeout       *time.Duration `yaml:"timeout"`
+	BatchSize     *int           `yaml:"batchSize"`
+	FlushInterval *time.Duration `yaml:"flushInterval"`
+	DialTimeout   *time.Duration `yaml:"dialTimeout"`
+}
+
+// NewOptions creates Kafka sink options.
+func (c *Configuration) NewOptions(iOpts instrument.Options) Options {
+	opts := NewOptions().
+		SetBrokers(c.Brokers).
+		SetTopic(c.Topic).
+		SetInstrumentOptions(iOpts)
+	if c.ClientID != nil {
+		opts = opts.SetClientID(*c.ClientID)
+	}
+	if c.RequiredAcks != nil {
+		opts = opts.SetRequiredAcks(*c.RequiredAcks)
+	}
+	if c.Timeout != nil {
+		opts = opts.SetTimeout(*c.Timeout)
+	}
+	if c.BatchSize != nil {
+		opts = opts.SetBatchSize(*c.BatchSize)
+	}
+	if c.FlushInterval != nil {
+		opts = opts.SetFlushInterval(*c.FlushInterval)
+	}
+	if c.DialTimeout != nil {
+		opts = opts.SetDialTimeout(*c.DialTimeout)
+	}
+	return opts
+}
+
+// NewSink creates a Kafka sink.
+func (c *Configuration) NewSink(iOpts instrument.Options) (sink.Sink, error) {
+	return NewSink(c.NewOptions(iOpts))
+}
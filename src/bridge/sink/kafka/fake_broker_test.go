@@ -0,0 +1,257 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package kafka
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type producedMessage struct {
+	partition   int32
+	key         []byte
+	value       []byte
+	timestampMs int64
+}
+
+// fakeBroker is an in-process single node Kafka cluster that speaks enough
+// of the wire protocol to serve the sink.
+type fakeBroker struct {
+	sync.Mutex
+
+	t             *testing.T
+	l             net.Listener
+	topic         string
+	numPartitions int
+	// produceErrFn returns the error code to reply to a produce for a partition.
+	produceErrFn     func(partition int32) Error
+	messages         []producedMessage
+	metadataRequests int
+	produceRequests  int
+	acks             []int16
+	wg               sync.WaitGroup
+}
+
+func newFakeBroker(t *testing.T, topic string, numPartitions int) *fakeBroker {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	b := &fakeBroker{
+		t:             t,
+		l:             l,
+		topic:         topic,
+		numPartitions: numPartitions,
+	}
+	b.wg.Add(1)
+	go b.acceptLoop()
+	return b
+}
+
+func (b *fakeBroker) Addr() string {
+	return b.l.Addr().String()
+}
+
+func (b *fakeBroker) Close() {
+	b.l.Close()
+	b.wg.Wait()
+}
+
+func (b *fakeBroker) setProduceErrFn(fn func(partition int32) Error) {
+	b.Lock()
+	b.produceErrFn = fn
+	b.Unlock()
+}
+
+func (b *fakeBroker) producedMessages() []producedMessage {
+	b.Lock()
+	defer b.Unlock()
+	return append([]producedMessage(nil), b.messages...)
+}
+
+func (b *fakeBroker) numMetadataRequests() int {
+	b.Lock()
+	defer b.Unlock()
+	return b.metadataRequests
+}
+
+func (b *fakeBroker) acceptLoop() {
+	defer b.wg.Done()
+	for {
+		c, err := b.l.Accept()
+		if err != nil {
+			return
+		}
+		b.wg.Add(1)
+		go b.serve(c)
+	}
+}
+
+func (b *fakeBroker) serve(c net.Conn) {
+	defer b.wg.Done()
+	defer c.Close()
+
+	for {
+		var sizeBuf [4]byte
+		if _, err := io.ReadFull(c, sizeBuf[:]); err != nil {
+			return
+		}
+		req := make([]byte, binary.BigEndian.Uint32(sizeBuf[:]))
+		if _, err := io.ReadFull(c, req); err != nil {
+			return
+		}
+		d := decoder{buf: req}
+		apiKey := d.int16()
+		d.int16()
+		correlationID := d.int32()
+		d.string()
+		assert.NoError(b.t, d.err)
+
+		var (
+			e          encoder
+			sizeOffset = e.reserveInt32()
+		)
+		e.putInt32(correlationID)
+		switch apiKey {
+		case apiKeyMetadata:
+			b.handleMetadata(&d, &e)
+		case apiKeyProduce:
+			b.handleProduce(&d, &e)
+		default:
+			b.t.Errorf("unexpected api key %d", apiKey)
+			return
+		}
+		e.fillInt32(sizeOffset, int32(len(e.buf)-4))
+		if _, err := c.Write(e.buf); err != nil {
+			return
+		}
+	}
+}
+
+func (b *fakeBroker) handleMetadata(d *decoder, e *encoder) {
+	b.Lock()
+	b.metadataRequests++
+	b.Unlock()
+
+	host, portStr, err := net.SplitHostPort(b.Addr())
+	assert.NoError(b.t, err)
+	port, err := strconv.Atoi(portStr)
+	assert.NoError(b.t, err)
+
+	e.putInt32(1)
+	e.putInt32(0)
+	e.putString(host)
+	e.putInt32(int32(port))
+
+	numTopics := d.arrayLen()
+	e.putInt32(int32(numTopics))
+	for i := 0; i < numTopics; i++ {
+		topic := d.string()
+		if topic != b.topic {
+			e.putInt16(int16(ErrUnknownTopicOrPartition))
+			e.putString(topic)
+			e.putInt32(0)
+			continue
+		}
+		e.putInt16(int16(ErrNone))
+		e.putString(topic)
+		e.putInt32(int32(b.numPartitions))
+		for p := 0; p < b.numPartitions; p++ {
+			e.putInt16(int16(ErrNone))
+			e.putInt32(int32(p))
+			e.putInt32(0)
+			// Replicas and in sync replicas.
+			for k := 0; k < 2; k++ {
+				e.putInt32(1)
+				e.putInt32(0)
+			}
+		}
+	}
+	assert.NoError(b.t, d.err)
+}
+
+func (b *fakeBroker) handleProduce(d *decoder, e *encoder) {
+	b.Lock()
+	defer b.Unlock()
+
+	b.produceRequests++
+	b.acks = append(b.acks, d.int16())
+	d.int32()
+
+	numTopics := d.arrayLen()
+	e.putInt32(int32(numTopics))
+	for i := 0; i < numTopics; i++ {
+		topic := d.string()
+		assert.Equal(b.t, b.topic, topic)
+		e.putString(topic)
+
+		numPartitions := d.arrayLen()
+		e.putInt32(int32(numPartitions))
+		for j := 0; j < numPartitions; j++ {
+			partition := d.int32()
+			messages := b.decodeMessageSet(partition, d.next(int(d.int32())))
+			kerr := ErrNone
+			if b.produceErrFn != nil {
+				kerr = b.produceErrFn(partition)
+			}
+			if kerr == ErrNone {
+				b.messages = append(b.messages, messages...)
+			}
+			e.putInt32(partition)
+			e.putInt16(int16(kerr))
+			e.putInt64(int64(len(b.messages)))
+			e.putInt64(-1)
+		}
+	}
+	// Throttle time.
+	e.putInt32(0)
+	assert.NoError(b.t, d.err)
+}
+
+func (b *fakeBroker) decodeMessageSet(partition int32, set []byte) []producedMessage {
+	var (
+		d        = decoder{buf: set}
+		messages []producedMessage
+	)
+	for d.remaining() > 0 {
+		d.int64()
+		message := decoder{buf: d.next(int(d.int32()))}
+		crc := uint32(message.int32())
+		assert.Equal(b.t, crc32.ChecksumIEEE(message.buf[message.off:]), crc)
+		assert.Equal(b.t, messageMagic, message.int8())
+		assert.Equal(b.t, int8(0), message.int8())
+		messages = append(messages, producedMessage{
+			partition:   partition,
+			timestampMs: message.int64(),
+			key:         message.bytes(),
+			value:       message.bytes(),
+		})
+		assert.NoError(b.t, message.err)
+	}
+	assert.NoError(b.t, d.err)
+	return messages
+}
@@ -0,0 +1,292 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package kafka
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/m3db/m3/src/bridge/sink"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+const (
+	defaultClientID      = "m3bridge"
+	defaultRequiredAcks  = -1
+	defaultTimeout       = 10 * time.Second
+	defaultBatchSize     = 1000
+	defaultFlushInterval = 100 * time.Millisecond
+	defaultDialTimeout   = 5 * time.Second
+)
+
+var (
+	errNoBrokers            = errors.New("kafka: no brokers")
+	errNoTopic              = errors.New("kafka: no topic")
+	errInvalidRequiredAcks  = errors.New("kafka: required acks must be -1 or 1")
+	errInvalidTimeout       = errors.New("kafka: invalid timeout")
+	errInvalidBatchSize     = errors.New("kafka: invalid batch size")
+	errInvalidFlushInterval = errors.New("kafka: invalid flush interval")
+	errInvalidDialTimeout   = errors.New("kafka: invalid dial timeout")
+	errNoEncodeFn           = errors.New("kafka: no encode function")
+)
+
+// EncodeFn encodes a metric as the value of a Kafka message.
+type EncodeFn func(m sink.Metric) ([]byte, error)
+
+// Options configures a Kafka sink.
+type Options interface {
+	// Validate validates the options.
+	Validate() error
+
+	// SetBrokers sets the bootstrap broker addresses.
+	SetBrokers(value []string) Options
+
+	// Brokers returns the bootstrap broker addresses.
+	Brokers() []string
+
+	// SetTopic sets the topic metrics are produced to.
+	SetTopic(value string) Options
+
+	// Topic returns the topic metrics are produced to.
+	Topic() string
+
+	// SetClientID sets the client id sent with each request.
+	SetClientID(value string) Options
+
+	// ClientID returns the client id sent with each request.
+	ClientID() string
+
+	// SetRequiredAcks sets the acks required from the brokers, -1 waits
+	// for all in sync replicas and 1 waits for the leader only.
+	SetRequiredAcks(value int) Options
+
+	// RequiredAcks returns the acks required from the brokers.
+	RequiredAcks() int
+
+	// SetTimeout sets the timeout of a produce request.
+	SetTimeout(value time.Duration) Options
+
+	// Timeout returns the timeout of a produce request.
+	Timeout() time.Duration
+
+	// SetBatchSize sets the number of metrics that triggers a flush.
+	SetBatchSize(value int) Options
+
+	// BatchSize returns the number of metrics that triggers a flush.
+	BatchSize() int
+
+	// SetFlushInterval sets the interval pending metrics are flushed at.
+	SetFlushInterval(value time.Duration) Options
+
+	// FlushInterval returns the interval pending metrics are flushed at.
+	FlushInterval() time.Duration
+
+	// SetDialTimeout sets the timeout to connect to a broker.
+	SetDialTimeout(value time.Duration) Options
+
+	// DialTimeout returns the timeout to connect to a broker.
+	DialTimeout() time.Duration
+
+	// SetEncodeFn sets the function to encode metrics.
+	SetEncodeFn(value EncodeFn) Options
+
+	// EncodeFn returns the function to encode metrics.
+	EncodeFn() EncodeFn
+
+	// SetInstrumentOptions sets the instrument options.
+	SetInstrumentOptions(value instrument.Options) Options
+
+	// InstrumentOptions returns the instrument options.
+	InstrumentOptions() instrument.Options
+}
+
+type options struct {
+	brokers       []string
+	topic         string
+	clientID      string
+	requiredAcks  int
+	timeout       time.Duration
+	batchSize     int
+	flushInterval time.Duration
+	dialTimeout   time.Duration
+	encodeFn      EncodeFn
+	iOpts         instrument.Options
+}
+
+// NewOptions creates Options.
+func NewOptions() Options {
+	return &options{
+		clientID:      defaultClientID,
+		requiredAcks:  defaultRequiredAcks,
+		timeout:       defaultTimeout,
+		batchSize:     defaultBatchSize,
+		flushInterval: defaultFlushInterval,
+		dialTimeout:   defaultDialTimeout,
+		encodeFn:      encodeJSON,
+		iOpts:         instrument.NewOptions(),
+	}
+}
+
+func (o *options) Validate() error {
+	if len(o.brokers) == 0 {
+		return errNoBrokers
+	}
+	if o.topic == "" {
+		return errNoTopic
+	}
+	if o.requiredAcks != -1 && o.requiredAcks != 1 {
+		return errInvalidRequiredAcks
+	}
+	if o.timeout <= 0 {
+		return errInvalidTimeout
+	}
+	if o.batchSize <= 0 {
+		return errInvalidBatchSize
+	}
+	if o.flushInterval <= 0 {
+		return errInvalidFlushInterval
+	}
+	if o.dialTimeout <= 0 {
+		return errInvalidDialTimeout
+	}
+	if o.encodeFn == nil {
+		return errNoEncodeFn
+	}
+	return nil
+}
+
+func (o *options) SetBrokers(value []string) Options {
+	opts := *o
+	opts.brokers = value
+	return &opts
+}
+
+func (o *options) Brokers() []string {
+	return o.brokers
+}
+
+func (o *options) SetTopic(value string) Options {
+	opts := *o
+	opts.topic = value
+	return &opts
+}
+
+func (o *options) Topic() string {
+	return o.topic
+}
+
+func (o *options) SetClientID(value string) Options {
+	opts := *o
+	opts.clientID = value
+	return &opts
+}
+
+func (o *options) ClientID() string {
+	return o.clientID
+}
+
+func (o *options) SetRequiredAcks(value int) Options {
+	opts := *o
+	opts.requiredAcks = value
+	return &opts
+}
+
+func (o *options) RequiredAcks() int {
+	return o.requiredAcks
+}
+
+func (o *options) SetTimeout(value time.Duration) Options {
+	opts := *o
+	opts.timeout = value
+	return &opts
+}
+
+func (o *options) Timeout() time.Duration {
+	return o.timeout
+}
+
+func (o *options) SetBatchSize(value int) Options {
+	opts := *o
+	opts.batchSize = value
+	return &opts
+}
+
+func (o *options) BatchSize() int {
+	return o.batchSize
+}
+
+func (o *options) SetFlushInterval(value time.Duration) Options {
+	opts := *o
+	opts.flushInterval = value
+	return &opts
+}
+
+func (o *options) FlushInterval() time.Duration {
+	return o.flushInterval
+}
+
+func (o *options) SetDialTimeout(value time.Duration) Options {
+	opts := *o
+	opts.dialTimeout = value
+	return &opts
+}
+
+func (o *options) DialTimeout() time.Duration {
+	return o.dialTimeout
+}
+
+func (o *options) SetEncodeFn(value EncodeFn) Options {
+	opts := *o
+	opts.encodeFn = value
+	return &opts
+}
+
+func (o *options) EncodeFn() EncodeFn {
+	return o.encodeFn
+}
+
+func (o *options) SetInstrumentOptions(value instrument.Options) Options {
+	opts := *o
+	opts.iOpts = value
+	return &opts
+}
+
+func (o *options) InstrumentOptions() instrument.Options {
+	return o.iOpts
+}
+
+type jsonMetric struct {
+	ID            string  `json:"id"`
+	TimeNanos     int64   `json:"timestamp"`
+	Value         float64 `json:"value"`
+	StoragePolicy string  `json:"storagePolicy"`
+}
+
+// encodeJSON is the default EncodeFn.
+func encodeJSON(m sink.Metric) ([]byte, error) {
+	return json.Marshal(jsonMetric{
+		ID:            string(m.ID),
+		TimeNanos:     m.TimeNanos,
+		Value:         m.Value,
+		StoragePolicy: m.StoragePolicy.String(),
+	})
+}
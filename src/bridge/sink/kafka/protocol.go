@@ -0,0 +1,377 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package kafka
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+)
+
+// The subset of the Kafka wire protocol used by the sink, see
+// https://kafka.apache.org/protocol for the full protocol.
+const (
+	apiKeyProduce      int16 = 0
+	apiKeyMetadata     int16 = 3
+	produceAPIVersion  int16 = 2
+	metadataAPIVersion int16 = 0
+	messageMagic       int8  = 1
+)
+
+var (
+	errShortBuffer = errors.New("kafka: short buffer")
+)
+
+// Error is an error code returned by a Kafka broker.
+type Error int16
+
+// Error codes the sink reacts to.
+const (
+	ErrNone                    Error = 0
+	ErrUnknownTopicOrPartition Error = 3
+	ErrLeaderNotAvailable      Error = 5
+	ErrNotLeaderForPartition   Error = 6
+	ErrRequestTimedOut         Error = 7
+)
+
+func (e Error) Error() string {
+	switch e {
+	case ErrUnknownTopicOrPartition:
+		return "kafka: unknown topic or partition"
+	case ErrLeaderNotAvailable:
+		return "kafka: leader not available"
+	case ErrNotLeaderForPartition:
+		return "kafka: not leader for partition"
+	case ErrRequestTimedOut:
+		return "kafka: request timed out"
+	}
+	return fmt.Sprintf("kafka: error code %d", int16(e))
+}
+
+// isStaleMetadata returns true if the error means the partition leaders
+// known to the client are out of date.
+func (e Error) isStaleMetadata() bool {
+	switch e {
+	case ErrUnknownTopicOrPartition, ErrLeaderNotAvailable, ErrNotLeaderForPartition:
+		return true
+	}
+	return false
+}
+
+// encoder appends Kafka protocol primitives to a buffer.
+type encoder struct {
+	buf []byte
+}
+
+func (e *encoder) putInt8(v int8) {
+	e.buf = append(e.buf, byte(v))
+}
+
+func (e *encoder) putInt16(v int16) {
+	e.buf = append(e.buf, 0, 0)
+	binary.BigEndian.PutUint16(e.buf[len(e.buf)-2:], uint16(v))
+}
+
+func (e *encoder) putInt32(v int32) {
+	e.buf = append(e.buf, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(e.buf[len(e.buf)-4:], uint32(v))
+}
+
+func (e *encoder) putInt64(v int64) {
+	e.buf = append(e.buf, 0, 0, 0, 0, 0, 0, 0, 0)
+	binary.BigEndian.PutUint64(e.buf[len(e.buf)-8:], uint64(v))
+}
+
+func (e *encoder) putString(v string) {
+	e.putInt16(int16(len(v)))
+	e.buf = append(e.buf, v...)
+}
+
+// putBytes encodes a nullable byte array.
+func (e *encoder) putBytes(v []byte) {
+	if v == nil {
+		e.putInt32(-1)
+		return
+	}
+	e.putInt32(int32(len(v)))
+	e.buf = append(e.buf, v...)
+}
+
+// reserveInt32 reserves room for an int32 that is filled in later
+// and returns its offset.
+func (e *encoder) reserveInt32() int {
+	e.putInt32(0)
+	return len(e.buf) - 4
+}
+
+func (e *encoder) fillInt32(offset int, v int32) {
+	binary.BigEndian.PutUint32(e.buf[offset:], uint32(v))
+}
+
+// decoder reads Kafka protocol primitives from a buffer, the first error
+// encountered is kept and returned by all subsequent reads.
+type decoder struct {
+	buf []byte
+	off int
+	err error
+}
+
+func (d *decoder) remaining() int {
+	return len(d.buf) - d.off
+}
+
+func (d *decoder) next(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if n < 0 || d.remaining() < n {
+		d.err = errShortBuffer
+		return nil
+	}
+	b := d.buf[d.off : d.off+n]
+	d.off += n
+	return b
+}
+
+func (d *decoder) int8() int8 {
+	b := d.next(1)
+	if b == nil {
+		return 0
+	}
+	return int8(b[0])
+}
+
+func (d *decoder) int16() int16 {
+	b := d.next(2)
+	if b == nil {
+		return 0
+	}
+	return int16(binary.BigEndian.Uint16(b))
+}
+
+func (d *decoder) int32() int32 {
+	b := d.next(4)
+	if b == nil {
+		return 0
+	}
+	return int32(binary.BigEndian.Uint32(b))
+}
+
+func (d *decoder) int64() int64 {
+	b := d.next(8)
+	if b == nil {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(b))
+}
+
+func (d *decoder) string() string {
+	return string(d.next(int(d.int16())))
+}
+
+func (d *decoder) bytes() []byte {
+	n := d.int32()
+	if n < 0 {
+		return nil
+	}
+	return d.next(int(n))
+}
+
+// arrayLen reads an array length, bounding it by the remaining bytes
+// so a corrupt length can not cause a huge allocation.
+func (d *decoder) arrayLen() int {
+	n := int(d.int32())
+	if n < 0 {
+		return 0
+	}
+	if n > d.remaining() {
+		d.err = errShortBuffer
+		return 0
+	}
+	return n
+}
+
+type requestHeader struct {
+	apiKey        int16
+	apiVersion    int16
+	correlationID int32
+	clientID      string
+}
+
+// encodeRequest encodes a size delimited request.
+func encodeRequest(h requestHeader, bodyFn func(e *encoder)) []byte {
+	var e encoder
+	sizeOffset := e.reserveInt32()
+	e.putInt16(h.apiKey)
+	e.putInt16(h.apiVersion)
+	e.putInt32(h.correlationID)
+	e.putString(h.clientID)
+	bodyFn(&e)
+	e.fillInt32(sizeOffset, int32(len(e.buf)-4))
+	return e.buf
+}
+
+func encodeMetadataRequest(h requestHeader, topics []string) []byte {
+	h.apiKey = apiKeyMetadata
+	h.apiVersion = metadataAPIVersion
+	return encodeRequest(h, func(e *encoder) {
+		e.putInt32(int32(len(topics)))
+		for _, topic := range topics {
+			e.putString(topic)
+		}
+	})
+}
+
+type brokerMetadata struct {
+	nodeID int32
+	host   string
+	port   int32
+}
+
+type partitionMetadata struct {
+	err       Error
+	partition int32
+	leader    int32
+}
+
+type topicMetadata struct {
+	err        Error
+	name       string
+	partitions []partitionMetadata
+}
+
+type metadataResponse struct {
+	brokers []brokerMetadata
+	topics  []topicMetadata
+}
+
+// decodeMetadataResponse decodes a metadata response without its correlation id.
+func decodeMetadataResponse(b []byte) (metadataResponse, error) {
+	var (
+		d    = decoder{buf: b}
+		resp metadataResponse
+	)
+	resp.brokers = make([]brokerMetadata, d.arrayLen())
+	for i := range resp.brokers {
+		resp.brokers[i] = brokerMetadata{
+			nodeID: d.int32(),
+			host:   d.string(),
+			port:   d.int32(),
+		}
+	}
+	resp.topics = make([]topicMetadata, d.arrayLen())
+	for i := range resp.topics {
+		t := &resp.topics[i]
+		t.err = Error(d.int16())
+		t.name = d.string()
+		t.partitions = make([]partitionMetadata, d.arrayLen())
+		for j := range t.partitions {
+			t.partitions[j] = partitionMetadata{
+				err:       Error(d.int16()),
+				partition: d.int32(),
+				leader:    d.int32(),
+			}
+			// Skip the replicas and in sync replicas.
+			for k := 0; k < 2; k++ {
+				d.next(4 * d.arrayLen())
+			}
+		}
+	}
+	return resp, d.err
+}
+
+// record is a Kafka message.
+type record struct {
+	key         []byte
+	value       []byte
+	timestampMs int64
+}
+
+type produceRequest struct {
+	acks       int16
+	timeoutMs  int32
+	topic      string
+	partitions map[int32][]record
+}
+
+func encodeProduceRequest(h requestHeader, req produceRequest) []byte {
+	h.apiKey = apiKeyProduce
+	h.apiVersion = produceAPIVersion
+	return encodeRequest(h, func(e *encoder) {
+		e.putInt16(req.acks)
+		e.putInt32(req.timeoutMs)
+		// Produce to a single topic.
+		e.putInt32(1)
+		e.putString(req.topic)
+		e.putInt32(int32(len(req.partitions)))
+		for partition, records := range req.partitions {
+			e.putInt32(partition)
+			messageSetSizeOffset := e.reserveInt32()
+			for _, r := range records {
+				encodeMessage(e, r)
+			}
+			e.fillInt32(messageSetSizeOffset, int32(len(e.buf)-messageSetSizeOffset-4))
+		}
+	})
+}
+
+// encodeMessage encodes a record as a v1 message in a message set.
+func encodeMessage(e *encoder, r record) {
+	// The offset is assigned by the broker.
+	e.putInt64(0)
+	messageSizeOffset := e.reserveInt32()
+	crcOffset := e.reserveInt32()
+	e.putInt8(messageMagic)
+	// No compression.
+	e.putInt8(0)
+	e.putInt64(r.timestampMs)
+	e.putBytes(r.key)
+	e.putBytes(r.value)
+	e.fillInt32(crcOffset, int32(crc32.ChecksumIEEE(e.buf[crcOffset+4:])))
+	e.fillInt32(messageSizeOffset, int32(len(e.buf)-messageSizeOffset-4))
+}
+
+// decodeProduceResponse decodes a produce response without its correlation
+// id and returns the error of each partition of each topic.
+func decodeProduceResponse(b []byte) (map[string]map[int32]Error, error) {
+	var (
+		d    = decoder{buf: b}
+		resp = make(map[string]map[int32]Error)
+	)
+	numTopics := d.arrayLen()
+	for i := 0; i < numTopics; i++ {
+		topic := d.string()
+		numPartitions := d.arrayLen()
+		partitions := make(map[int32]Error, numPartitions)
+		for j := 0; j < numPartitions; j++ {
+			partition := d.int32()
+			partitions[partition] = Error(d.int16())
+			// Skip the base offset and log append time.
+			d.int64()
+			d.int64()
+		}
+		resp[topic] = partitions
+	}
+	// Skip the throttle time.
+	d.int32()
+	return resp, d.err
+}
@@ -0,0 +1,445 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package kafka implements a sink that produces metrics to a Kafka topic
+// speaking the Kafka wire protocol directly.
+package kafka
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/bridge/sink"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+)
+
+var (
+	errSinkClosed        = errors.New("kafka: sink is closed")
+	errNoPartitions      = errors.New("kafka: topic has no partitions")
+	errUnknownLeader     = errors.New("kafka: unknown partition leader")
+	errCorrelationID     = errors.New("kafka: mismatched correlation id")
+	errMissingPartition  = errors.New("kafka: partition missing from produce response")
+	errMetadataNoBrokers = errors.New("kafka: could not fetch metadata from any broker")
+)
+
+type sinkMetrics struct {
+	messageProduced      tally.Counter
+	messageProduceError  tally.Counter
+	encodeError          tally.Counter
+	requestError         tally.Counter
+	metadataRefresh      tally.Counter
+	metadataRefreshError tally.Counter
+	flushLatency         tally.Timer
+}
+
+func newSinkMetrics(scope tally.Scope) sinkMetrics {
+	return sinkMetrics{
+		messageProduced:      scope.Counter("message-produced"),
+		messageProduceError:  scope.Counter("message-produce-error"),
+		encodeError:          scope.Counter("encode-error"),
+		requestError:         scope.Counter("request-error"),
+		metadataRefresh:      scope.Counter("metadata-refresh"),
+		metadataRefreshError: scope.Counter("metadata-refresh-error"),
+		flushLatency:         scope.Timer("flush-latency"),
+	}
+}
+
+type pendingRecord struct {
+	record
+
+	callback sink.Callback
+}
+
+type kafkaSink struct {
+	sync.Mutex
+
+	opts    Options
+	encode  EncodeFn
+	logger  *zap.Logger
+	m       sinkMetrics
+	pending []pendingRecord
+	closed  bool
+	flushCh chan struct{}
+	closeCh chan struct{}
+	wg      sync.WaitGroup
+
+	// The fields below are only accessed by the flush goroutine.
+	correlationID int32
+	conns         map[string]*conn
+	brokers       map[int32]string
+	partitions    []partitionMetadata
+	metadataStale bool
+}
+
+// NewSink creates a sink that produces metrics to a Kafka topic. Metrics are
+// batched and produced by a single background goroutine, the callback of a
+// metric is only called without error once the brokers acked it.
+func NewSink(opts Options) (sink.Sink, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	s := &kafkaSink{
+		opts:          opts,
+		encode:        opts.EncodeFn(),
+		logger:        opts.InstrumentOptions().Logger(),
+		m:             newSinkMetrics(opts.InstrumentOptions().MetricsScope()),
+		flushCh:       make(chan struct{}, 1),
+		closeCh:       make(chan struct{}),
+		conns:         make(map[string]*conn),
+		metadataStale: true,
+	}
+	s.wg.Add(1)
+	go s.flushLoop()
+	return s, nil
+}
+
+func (s *kafkaSink) Write(m sink.Metric, callback sink.Callback) {
+	value, err := s.encode(m)
+	if err != nil {
+		s.m.encodeError.Inc(1)
+		callback(err)
+		return
+	}
+	r := pendingRecord{
+		record: record{
+			key:         append([]byte(nil), m.ID...),
+			value:       value,
+			timestampMs: m.TimeNanos / int64(time.Millisecond),
+		},
+		callback: callback,
+	}
+	s.Lock()
+	if s.closed {
+		s.Unlock()
+		callback(errSinkClosed)
+		return
+	}
+	s.pending = append(s.pending, r)
+	full := len(s.pending) >= s.opts.BatchSize()
+	s.Unlock()
+	if !full {
+		return
+	}
+	select {
+	case s.flushCh <- struct{}{}:
+	default:
+	}
+}
+
+func (s *kafkaSink) Close() error {
+	s.Lock()
+	if s.closed {
+		s.Unlock()
+		return errSinkClosed
+	}
+	s.closed = true
+	close(s.closeCh)
+	s.Unlock()
+	s.wg.Wait()
+	return nil
+}
+
+func (s *kafkaSink) flushLoop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.opts.FlushInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.flush()
+		case <-s.flushCh:
+			s.flush()
+		case <-s.closeCh:
+			s.flush()
+			for addr, c := range s.conns {
+				c.Close()
+				delete(s.conns, addr)
+			}
+			return
+		}
+	}
+}
+
+func (s *kafkaSink) flush() {
+	s.Lock()
+	pending := s.pending
+	s.pending = nil
+	s.Unlock()
+	if len(pending) == 0 {
+		return
+	}
+
+	start := time.Now()
+	defer func() { s.m.flushLatency.Record(time.Since(start)) }()
+
+	if s.metadataStale {
+		if err := s.refreshMetadata(); err != nil {
+			s.m.metadataRefreshError.Inc(1)
+			s.logger.Error("could not refresh kafka metadata", zap.Error(err))
+			s.fail(pending, err)
+			return
+		}
+	}
+
+	// Group the records by partition leader so each broker gets one request.
+	byLeader := make(map[int32]map[int32][]pendingRecord)
+	for _, r := range pending {
+		p := s.partitions[partitionForKey(r.key, len(s.partitions))]
+		if p.err != ErrNone || p.leader < 0 {
+			s.metadataStale = true
+			s.fail([]pendingRecord{r}, errUnknownLeader)
+			continue
+		}
+		byPartition, ok := byLeader[p.leader]
+		if !ok {
+			byPartition = make(map[int32][]pendingRecord)
+			byLeader[p.leader] = byPartition
+		}
+		byPartition[p.partition] = append(byPartition[p.partition], r)
+	}
+	for leader, byPartition := range byLeader {
+		s.produce(leader, byPartition)
+	}
+}
+
+func (s *kafkaSink) produce(leader int32, byPartition map[int32][]pendingRecord) {
+	addr, ok := s.brokers[leader]
+	if !ok {
+		s.metadataStale = true
+		s.failAll(byPartition, errUnknownLeader)
+		return
+	}
+
+	req := produceRequest{
+		acks:       int16(s.opts.RequiredAcks()),
+		timeoutMs:  int32(s.opts.Timeout() / time.Millisecond),
+		topic:      s.opts.Topic(),
+		partitions: make(map[int32][]record, len(byPartition)),
+	}
+	for partition, records := range byPartition {
+		rs := make([]record, 0, len(records))
+		for _, r := range records {
+			rs = append(rs, r.record)
+		}
+		req.partitions[partition] = rs
+	}
+	resp, err := s.roundTrip(addr, func(h requestHeader) []byte {
+		return encodeProduceRequest(h, req)
+	})
+	if err == nil {
+		var topics map[string]map[int32]Error
+		if topics, err = decodeProduceResponse(resp); err == nil {
+			s.complete(topics[s.opts.Topic()], byPartition)
+			return
+		}
+	}
+	s.m.requestError.Inc(1)
+	s.metadataStale = true
+	s.logger.Error("kafka produce request failed",
+		zap.String("broker", addr), zap.Error(err))
+	s.failAll(byPartition, err)
+}
+
+func (s *kafkaSink) complete(
+	errs map[int32]Error,
+	byPartition map[int32][]pendingRecord,
+) {
+	for partition, records := range byPartition {
+		kerr, ok := errs[partition]
+		if !ok {
+			s.fail(records, errMissingPartition)
+			continue
+		}
+		if kerr != ErrNone {
+			if kerr.isStaleMetadata() {
+				s.metadataStale = true
+			}
+			s.fail(records, kerr)
+			continue
+		}
+		s.m.messageProduced.Inc(int64(len(records)))
+		for _, r := range records {
+			r.callback(nil)
+		}
+	}
+}
+
+func (s *kafkaSink) fail(records []pendingRecord, err error) {
+	s.m.messageProduceError.Inc(int64(len(records)))
+	for _, r := range records {
+		r.callback(err)
+	}
+}
+
+func (s *kafkaSink) failAll(byPartition map[int32][]pendingRecord, err error) {
+	for _, records := range byPartition {
+		s.fail(records, err)
+	}
+}
+
+// refreshMetadata fetches the partition leaders of the topic from the first
+// bootstrap broker that responds.
+func (s *kafkaSink) refreshMetadata() error {
+	s.m.metadataRefresh.Inc(1)
+	topic := s.opts.Topic()
+	lastErr := errMetadataNoBrokers
+	for _, addr := range s.opts.Brokers() {
+		b, err := s.roundTrip(addr, func(h requestHeader) []byte {
+			return encodeMetadataRequest(h, []string{topic})
+		})
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		resp, err := decodeMetadataResponse(b)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return s.updateMetadata(topic, resp)
+	}
+	return lastErr
+}
+
+func (s *kafkaSink) updateMetadata(topic string, resp metadataResponse) error {
+	for _, t := range resp.topics {
+		if t.name != topic {
+			continue
+		}
+		if t.err != ErrNone {
+			return t.err
+		}
+		if len(t.partitions) == 0 {
+			return errNoPartitions
+		}
+		partitions := make([]partitionMetadata, len(t.partitions))
+		for i := range partitions {
+			partitions[i] = partitionMetadata{
+				err:       ErrLeaderNotAvailable,
+				partition: int32(i),
+				leader:    -1,
+			}
+		}
+		for _, p := range t.partitions {
+			if p.partition < 0 || int(p.partition) >= len(partitions) {
+				return fmt.Errorf("kafka: partition %d out of range", p.partition)
+			}
+			partitions[p.partition] = p
+		}
+		brokers := make(map[int32]string, len(resp.brokers))
+		for _, b := range resp.brokers {
+			brokers[b.nodeID] = net.JoinHostPort(b.host, strconv.Itoa(int(b.port)))
+		}
+		s.partitions = partitions
+		s.brokers = brokers
+		s.metadataStale = false
+		return nil
+	}
+	return ErrUnknownTopicOrPartition
+}
+
+// roundTrip sends a request to the broker and returns the response without
+// its correlation id, the connection is dropped on any error.
+func (s *kafkaSink) roundTrip(
+	addr string,
+	encodeFn func(h requestHeader) []byte,
+) ([]byte, error) {
+	c, ok := s.conns[addr]
+	if !ok {
+		var err error
+		if c, err = dial(addr, s.opts.DialTimeout()); err != nil {
+			return nil, err
+		}
+		s.conns[addr] = c
+	}
+	s.correlationID++
+	h := requestHeader{
+		correlationID: s.correlationID,
+		clientID:      s.opts.ClientID(),
+	}
+	// Give the broker the produce timeout plus as much again for the round trip.
+	resp, err := c.roundTrip(h.correlationID, encodeFn(h), 2*s.opts.Timeout())
+	if err != nil {
+		c.Close()
+		delete(s.conns, addr)
+		return nil, err
+	}
+	return resp, nil
+}
+
+// partitionForKey hashes the key to a partition so all the values of a
+// metric land on the same partition.
+func partitionForKey(key []byte, numPartitions int) int {
+	h := fnv.New32a()
+	h.Write(key)
+	return int(h.Sum32() % uint32(numPartitions))
+}
+
+type conn struct {
+	net.Conn
+}
+
+func dial(addr string, timeout time.Duration) (*conn, error) {
+	c, err := net.DialTimeout("tcp", addr, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return &conn{Conn: c}, nil
+}
+
+func (c *conn) roundTrip(
+	correlationID int32,
+	req []byte,
+	timeout time.Duration,
+) ([]byte, error) {
+	if err := c.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, err
+	}
+	if _, err := c.Write(req); err != nil {
+		return nil, err
+	}
+	var sizeBuf [4]byte
+	if _, err := io.ReadFull(c, sizeBuf[:]); err != nil {
+		return nil, err
+	}
+	size := int32(binary.BigEndian.Uint32(sizeBuf[:]))
+	if size < 4 {
+		return nil, errShortBuffer
+	}
+	resp := make([]byte, size)
+	if _, err := io.ReadFull(c, resp); err != nil {
+		return nil, err
+	}
+	if int32(binary.BigEndian.Uint32(resp)) != correlationID {
+		return nil, errCorrelationID
+	}
+	return resp[4:], nil
+}
@@ -0,0 +1,222 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package kafka
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/bridge/sink"
+	"github.com/m3db/m3/src/metrics/policy"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testTopic = "metrics"
+
+var testStoragePolicy = policy.NewStoragePolicy(10*time.Second, xtime.Second, 48*time.Hour)
+
+func testOptions(brokers ...string) Options {
+	return NewOptions().
+		SetBrokers(brokers).
+		SetTopic(testTopic).
+		SetTimeout(time.Second).
+		SetDialTimeout(time.Second).
+		SetFlushInterval(10 * time.Millisecond)
+}
+
+func testMetric(i int) sink.Metric {
+	return sink.Metric{
+		ID:            []byte(fmt.Sprintf("foo%d", i)),
+		TimeNanos:     int64(i) * int64(time.Second),
+		Value:         float64(i),
+		StoragePolicy: testStoragePolicy,
+	}
+}
+
+// writeAll writes the metrics and waits for all of their callbacks.
+func writeAll(t *testing.T, s sink.Sink, metrics []sink.Metric) []error {
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, len(metrics))
+	)
+	for i, m := range metrics {
+		i := i
+		wg.Add(1)
+		s.Write(m, func(err error) {
+			errs[i] = err
+			wg.Done()
+		})
+	}
+	wg.Wait()
+	return errs
+}
+
+func TestSinkProduce(t *testing.T) {
+	b := newFakeBroker(t, testTopic, 4)
+	defer b.Close()
+
+	s, err := NewSink(testOptions(b.Addr()).SetBatchSize(5))
+	require.NoError(t, err)
+	defer s.Close()
+
+	var metrics []sink.Metric
+	for i := 0; i < 20; i++ {
+		metrics = append(metrics, testMetric(i))
+	}
+	for _, err := range writeAll(t, s, metrics) {
+		require.NoError(t, err)
+	}
+
+	produced := b.producedMessages()
+	require.Equal(t, len(metrics), len(produced))
+	byID := make(map[string]producedMessage, len(produced))
+	for _, m := range produced {
+		byID[string(m.key)] = m
+	}
+	for _, m := range metrics {
+		p, ok := byID[string(m.ID)]
+		require.True(t, ok)
+		require.Equal(t, int32(partitionForKey(m.ID, 4)), p.partition)
+		require.Equal(t, m.TimeNanos/int64(time.Millisecond), p.timestampMs)
+
+		var decoded jsonMetric
+		require.NoError(t, json.Unmarshal(p.value, &decoded))
+		require.Equal(t, jsonMetric{
+			ID:            string(m.ID),
+			TimeNanos:     m.TimeNanos,
+			Value:         m.Value,
+			StoragePolicy: "10s:2d",
+		}, decoded)
+	}
+	require.Equal(t, 1, b.numMetadataRequests())
+
+	b.Lock()
+	for _, acks := range b.acks {
+		require.Equal(t, int16(-1), acks)
+	}
+	b.Unlock()
+}
+
+func TestSinkPartitionErrorFailsCallbackAndRefreshesMetadata(t *testing.T) {
+	b := newFakeBroker(t, testTopic, 1)
+	defer b.Close()
+
+	s, err := NewSink(testOptions(b.Addr()))
+	require.NoError(t, err)
+	defer s.Close()
+
+	b.setProduceErrFn(func(int32) Error { return ErrNotLeaderForPartition })
+	errs := writeAll(t, s, []sink.Metric{testMetric(1)})
+	require.Equal(t, ErrNotLeaderForPartition, errs[0])
+	require.Empty(t, b.producedMessages())
+
+	b.setProduceErrFn(nil)
+	errs = writeAll(t, s, []sink.Metric{testMetric(1)})
+	require.NoError(t, errs[0])
+	require.Equal(t, 1, len(b.producedMessages()))
+	require.Equal(t, 2, b.numMetadataRequests())
+}
+
+func TestSinkUnknownTopic(t *testing.T) {
+	b := newFakeBroker(t, "other", 1)
+	defer b.Close()
+
+	s, err := NewSink(testOptions(b.Addr()))
+	require.NoError(t, err)
+	defer s.Close()
+
+	errs := writeAll(t, s, []sink.Metric{testMetric(1)})
+	require.Equal(t, ErrUnknownTopicOrPartition, errs[0])
+}
+
+func TestSinkBrokerUnavailable(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	require.NoError(t, l.Close())
+
+	s, err := NewSink(testOptions(addr))
+	require.NoError(t, err)
+	defer s.Close()
+
+	errs := writeAll(t, s, []sink.Metric{testMetric(1)})
+	require.Error(t, errs[0])
+}
+
+func TestSinkCloseFlushesPending(t *testing.T) {
+	b := newFakeBroker(t, testTopic, 2)
+	defer b.Close()
+
+	s, err := NewSink(testOptions(b.Addr()).SetFlushInterval(time.Hour))
+	require.NoError(t, err)
+
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, 3)
+	)
+	for i := range errs {
+		i := i
+		wg.Add(1)
+		s.Write(testMetric(i), func(err error) {
+			errs[i] = err
+			wg.Done()
+		})
+	}
+	require.NoError(t, s.Close())
+	wg.Wait()
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	require.Equal(t, 3, len(b.producedMessages()))
+
+	require.Equal(t, errSinkClosed, s.Close())
+	errs = writeAll(t, s, []sink.Metric{testMetric(1)})
+	require.Equal(t, errSinkClosed, errs[0])
+}
+
+func TestSinkEncodeError(t *testing.T) {
+	errEncode := fmt.Errorf("encode error")
+	s, err := NewSink(testOptions("127.0.0.1:0").SetEncodeFn(func(sink.Metric) ([]byte, error) {
+		return nil, errEncode
+	}))
+	require.NoError(t, err)
+	defer s.Close()
+
+	errs := writeAll(t, s, []sink.Metric{testMetric(1)})
+	require.Equal(t, errEncode, errs[0])
+}
+
+func TestOptionsValidate(t *testing.T) {
+	opts := testOptions("127.0.0.1:9092")
+	require.NoError(t, opts.Validate())
+	require.Equal(t, errNoBrokers, opts.SetBrokers(nil).Validate())
+	require.Equal(t, errNoTopic, opts.SetTopic("").Validate())
+	require.Equal(t, errInvalidRequiredAcks, opts.SetRequiredAcks(0).Validate())
+	require.NoError(t, opts.SetRequiredAcks(1).Validate())
+	require.Equal(t, errInvalidBatchSize, opts.SetBatchSize(0).Validate())
+	require.Equal(t, errNoEncodeFn, opts.SetEncodeFn(nil).Validate())
+}
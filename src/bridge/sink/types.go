@@ -0,0 +1,50 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package sink defines the destinations metrics consumed by the bridge
+// are published to.
+package sink
+
+import (
+	"github.com/m3db/m3/src/metrics/policy"
+)
+
+// Metric is an aggregated metric consumed from a topic.
+type Metric struct {
+	ID            []byte
+	TimeNanos     int64
+	Value         float64
+	StoragePolicy policy.StoragePolicy
+}
+
+// Callback is called once a sink has confirmed a metric, or with the
+// error if the sink could not publish the metric.
+type Callback func(err error)
+
+// Sink publishes metrics to an external system.
+type Sink interface {
+	// Write publishes the metric asynchronously and calls the callback once
+	// the metric is confirmed or failed to be published. The metric is only
+	// valid until Write returns, the sink must copy anything it holds onto.
+	Write(m Metric, callback Callback)
+
+	// Close flushes the pending metrics and closes the sink.
+	Close() error
+}
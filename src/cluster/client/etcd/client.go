@@ -33,6 +33,7 @@ import (
 	"github.com/m3db/m3/src/cluster/client"
 	"github.com/m3db/m3/src/cluster/kv"
 	etcdkv "github.com/m3db/m3/src/cluster/kv/etcd"
+	filekv "github.com/m3db/m3/src/cluster/kv/file"
 	"github.com/m3db/m3/src/cluster/services"
 	etcdheartbeat "github.com/m3db/m3/src/cluster/services/heartbeat/etcd"
 	"github.com/m3db/m3/src/cluster/services/leader"
@@ -53,7 +54,10 @@ const (
 	kvPrefix = "_kv"
 )
 
-var errInvalidNamespace = errors.New("invalid namespace")
+var (
+	errInvalidNamespace = errors.New("invalid namespace")
+	errFileStoreNoEtcd  = errors.New("heartbeat and leader services require etcd, not supported with a file kv store")
+)
 
 type newClientFn func(cluster Cluster) (*clientv3.Client, error)
 
@@ -183,12 +187,36 @@ func (c *csclient) newkvOptions(
 	return kvOpts
 }
 
+// newFileStoreOptions prefixes the keys with the zone in addition to the
+// namespace and environment since all zones share the same file.
+func (c *csclient) newFileStoreOptions(opts kv.OverrideOptions) filekv.Options {
+	fileOpts := c.opts.FileStoreOptions().
+		SetInstrumentsOptions(instrument.NewOptions().
+			SetLogger(c.logger).
+			SetMetricsScope(c.kvScope)).
+		SetPrefix(opts.Zone())
+
+	if ns := opts.Namespace(); ns != "" {
+		fileOpts = fileOpts.SetPrefix(fileOpts.ApplyPrefix(ns))
+	}
+
+	if env := opts.Environment(); env != "" {
+		fileOpts = fileOpts.SetPrefix(fileOpts.ApplyPrefix(env))
+	}
+
+	return fileOpts
+}
+
 // txnGen assumes the caller has validated the options passed if they are
 // user-supplied (as opposed to constructed ourselves).
 func (c *csclient) txnGen(
 	opts kv.OverrideOptions,
 	cacheFileFn cacheFileForZoneFn,
 ) (kv.TxnStore, error) {
+	if c.opts.FileStoreOptions() != nil {
+		return c.fileTxnGen(opts)
+	}
+
 	cli, err := c.etcdClientGen(opts.Zone())
 	if err != nil {
 		return nil, err
@@ -214,9 +242,32 @@ func (c *csclient) txnGen(
 	return store, nil
 }
 
+func (c *csclient) fileTxnGen(opts kv.OverrideOptions) (kv.TxnStore, error) {
+	c.storeLock.Lock()
+	defer c.storeLock.Unlock()
+
+	key := kvStoreCacheKey(opts.Zone(), opts.Namespace(), opts.Environment())
+	store, ok := c.stores[key]
+	if ok {
+		return store, nil
+	}
+
+	store, err := filekv.NewStore(c.newFileStoreOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	c.stores[key] = store
+	return store, nil
+}
+
 func (c *csclient) heartbeatGen() services.HeartbeatGen {
 	return services.HeartbeatGen(
 		func(sid services.ServiceID) (services.HeartbeatService, error) {
+			if c.opts.FileStoreOptions() != nil {
+				return nil, errFileStoreNoEtcd
+			}
+
 			cli, err := c.etcdClientGen(sid.Zone())
 			if err != nil {
 				return nil, err
@@ -235,6 +286,10 @@ func (c *csclient) heartbeatGen() services.HeartbeatGen {
 func (c *csclient) leaderGen() services.LeaderGen {
 	return services.LeaderGen(
 		func(sid services.ServiceID, eo services.ElectionOptions) (services.LeaderService, error) {
+			if c.opts.FileStoreOptions() != nil {
+				return nil, errFileStoreNoEtcd
+			}
+
 			cli, err := c.etcdClientGen(sid.Zone())
 			if err != nil {
 				return nil, err
@@ -21,10 +21,14 @@
 package etcd
 
 import (
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/m3db/m3/src/cluster/generated/proto/commonpb"
 	"github.com/m3db/m3/src/cluster/kv"
+	filekv "github.com/m3db/m3/src/cluster/kv/file"
 	"github.com/m3db/m3/src/cluster/services"
 
 	"go.etcd.io/etcd/clientv3"
@@ -297,6 +301,59 @@ func TestReuseKVStore(t *testing.T) {
 	client.storeLock.Unlock()
 }
 
+func TestFileStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "file-kv-client")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	opts := NewOptions().
+		SetService("test_app").
+		SetZone("zone1").
+		SetEnv("env").
+		SetFileStoreOptions(filekv.NewOptions().SetFilePath(filepath.Join(dir, "kv.json")))
+	cs, err := NewConfigServiceClient(opts)
+	require.NoError(t, err)
+
+	store1, err := cs.KV()
+	require.NoError(t, err)
+
+	store2, err := cs.Txn()
+	require.NoError(t, err)
+	require.Equal(t, store1, store2)
+
+	_, err = store1.Set("key", &commonpb.StringProto{Value: "v1"})
+	require.NoError(t, err)
+
+	// Stores in other namespaces and zones do not see the key.
+	store3, err := cs.Store(kv.NewOverrideOptions().SetNamespace("ns"))
+	require.NoError(t, err)
+	_, err = store3.Get("key")
+	require.Equal(t, kv.ErrNotFound, err)
+
+	store4, err := cs.Store(kv.NewOverrideOptions().SetZone("zone2"))
+	require.NoError(t, err)
+	_, err = store4.Get("key")
+	require.Equal(t, kv.ErrNotFound, err)
+
+	// A new client on the same file sees the key.
+	cs2, err := NewConfigServiceClient(opts)
+	require.NoError(t, err)
+	store5, err := cs2.KV()
+	require.NoError(t, err)
+	value, err := store5.Get("key")
+	require.NoError(t, err)
+	require.Equal(t, 1, value.Version())
+
+	c := cs.(*csclient)
+	require.Equal(t, 0, len(c.clis))
+
+	sid := services.NewServiceID().SetName("s1").SetZone("zone1")
+	_, err = c.heartbeatGen()(sid)
+	require.Equal(t, errFileStoreNoEtcd, err)
+	_, err = c.leaderGen()(sid, services.NewElectionOptions())
+	require.Equal(t, errFileStoreNoEtcd, err)
+}
+
 func TestValidateNamespace(t *testing.T) {
 	inputs := []struct {
 		ns        string
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/cluster/client"
+	filekv "github.com/m3db/m3/src/cluster/kv/file"
 	"github.com/m3db/m3/src/cluster/services"
 	"github.com/m3db/m3/src/x/instrument"
 )
@@ -88,6 +89,24 @@ func (c *KeepAliveConfig) NewOptions() KeepAliveOptions {
 		SetKeepAliveTimeout(c.Timeout)
 }
 
+// FileStoreConfig is the config for a kv store persisted to a local file,
+// used instead of etcd to run a single process without an etcd cluster. The
+// file is locked by the process using it, so a coordinator must be embedded
+// in the database node to share its cluster state.
+type FileStoreConfig struct {
+	Path        string `yaml:"path" validate:"nonzero"`
+	MaxVersions int    `yaml:"maxVersions"`
+}
+
+// NewOptions constructs options based on the config.
+func (c *FileStoreConfig) NewOptions() filekv.Options {
+	opts := filekv.NewOptions().SetFilePath(c.Path)
+	if c.MaxVersions > 0 {
+		opts = opts.SetMaxVersions(c.MaxVersions)
+	}
+	return opts
+}
+
 // Configuration is for config service client.
 type Configuration struct {
 	Zone              string                 `yaml:"zone"`
@@ -95,6 +114,7 @@ type Configuration struct {
 	Service           string                 `yaml:"service" validate:"nonzero"`
 	CacheDir          string                 `yaml:"cacheDir"`
 	ETCDClusters      []ClusterConfig        `yaml:"etcdClusters"`
+	FileStore         *FileStoreConfig       `yaml:"fileStore"`
 	SDConfig          services.Configuration `yaml:"m3sd"`
 	WatchWithRevision int64                  `yaml:"watchWithRevision"`
 	NewDirectoryMode  *os.FileMode           `yaml:"newDirectoryMode"`
@@ -122,6 +142,11 @@ func (cfg Configuration) NewOptions() Options {
 		opts = opts.SetNewDirectoryMode(defaultDirectoryMode)
 	}
 
+	if cfg.FileStore != nil {
+		opts = opts.SetFileStoreOptions(cfg.FileStore.NewOptions().
+			SetNewDirectoryMode(opts.NewDirectoryMode()))
+	}
+
 	return opts
 }
 
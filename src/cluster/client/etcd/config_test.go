@@ -172,3 +172,31 @@ m3sd:
 		require.Equal(t, os.FileMode(0744), *cfg2.NewDirectoryMode)
 	})
 }
+
+func TestConfigFileStore(t *testing.T) {
+	const testConfig = `
+zone: z1
+service: service1
+newDirectoryMode: 0744
+fileStore:
+  path: /var/lib/m3kv/kv.json
+  maxVersions: 10
+`
+
+	var cfg Configuration
+	require.NoError(t, yaml.Unmarshal([]byte(testConfig), &cfg))
+	require.Equal(t, &FileStoreConfig{
+		Path:        "/var/lib/m3kv/kv.json",
+		MaxVersions: 10,
+	}, cfg.FileStore)
+
+	opts := cfg.NewOptions()
+	require.NoError(t, opts.Validate())
+	require.Empty(t, opts.Clusters())
+
+	fileOpts := opts.FileStoreOptions()
+	require.NotNil(t, fileOpts)
+	require.Equal(t, "/var/lib/m3kv/kv.json", fileOpts.FilePath())
+	require.Equal(t, 10, fileOpts.MaxVersions())
+	require.Equal(t, os.FileMode(0744), fileOpts.NewDirectoryMode())
+}
@@ -30,6 +30,7 @@ import (
 	"os"
 	"time"
 
+	filekv "github.com/m3db/m3/src/cluster/kv/file"
 	"github.com/m3db/m3/src/cluster/services"
 	"github.com/m3db/m3/src/x/instrument"
 	"github.com/m3db/m3/src/x/retry"
@@ -193,6 +194,7 @@ type options struct {
 	iopts             instrument.Options
 	retryOpts         retry.Options
 	newDirectoryMode  os.FileMode
+	fileStoreOpts     filekv.Options
 }
 
 func (o options) Validate() error {
@@ -200,10 +202,16 @@ func (o options) Validate() error {
 		return errors.New("invalid options, no service name set")
 	}
 
-	if len(o.clusters) == 0 {
+	if len(o.clusters) == 0 && o.fileStoreOpts == nil {
 		return errors.New("invalid options, no etcd clusters set")
 	}
 
+	if o.fileStoreOpts != nil {
+		if err := o.fileStoreOpts.Validate(); err != nil {
+			return err
+		}
+	}
+
 	if o.iopts == nil {
 		return errors.New("invalid options, no instrument options set")
 	}
@@ -313,6 +321,15 @@ func (o options) NewDirectoryMode() os.FileMode {
 	return o.newDirectoryMode
 }
 
+func (o options) FileStoreOptions() filekv.Options {
+	return o.fileStoreOpts
+}
+
+func (o options) SetFileStoreOptions(opts filekv.Options) Options {
+	o.fileStoreOpts = opts
+	return o
+}
+
 // NewCluster creates a Cluster.
 func NewCluster() Cluster {
 	return cluster{
@@ -24,6 +24,7 @@ import (
 	"testing"
 	"time"
 
+	filekv "github.com/m3db/m3/src/cluster/kv/file"
 	"github.com/m3db/m3/src/cluster/services"
 	"github.com/m3db/m3/src/x/instrument"
 
@@ -133,4 +134,10 @@ func TestValidate(t *testing.T) {
 
 	opts = opts.SetInstrumentOptions(nil)
 	assert.Error(t, opts.Validate())
+
+	opts = NewOptions().SetService("app").SetFileStoreOptions(filekv.NewOptions())
+	assert.Error(t, opts.Validate())
+
+	opts = opts.SetFileStoreOptions(filekv.NewOptions().SetFilePath("kv.json"))
+	assert.NoError(t, opts.Validate())
 }
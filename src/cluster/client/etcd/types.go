@@ -25,6 +25,7 @@ import (
 	"os"
 	"time"
 
+	filekv "github.com/m3db/m3/src/cluster/kv/file"
 	"github.com/m3db/m3/src/cluster/services"
 	"github.com/m3db/m3/src/x/instrument"
 	"github.com/m3db/m3/src/x/retry"
@@ -63,6 +64,11 @@ type Options interface {
 	SetNewDirectoryMode(fm os.FileMode) Options
 	NewDirectoryMode() os.FileMode
 
+	// FileStoreOptions returns the options of the file backed kv store used
+	// instead of etcd, nil when the client uses etcd.
+	FileStoreOptions() filekv.Options
+	SetFileStoreOptions(opts filekv.Options) Options
+
 	Validate() error
 }
 
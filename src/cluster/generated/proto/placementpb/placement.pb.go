@@ -31,6 +31,7 @@
 		Placement
 		Instance
 		Shard
+		SplitSource
 		PlacementSnapshots
 */
 package placementpb
@@ -236,6 +237,9 @@ type Shard struct {
 	// they are ready to cut over or after they are ready to cut off (e.g., for warmup purposes).
 	CutoverNanos int64 `protobuf:"varint,4,opt,name=cutover_nanos,json=cutoverNanos,proto3" json:"cutover_nanos,omitempty"`
 	CutoffNanos  int64 `protobuf:"varint,5,opt,name=cutoff_nanos,json=cutoffNanos,proto3" json:"cutoff_nanos,omitempty"`
+	// split_source is set on the shards created by splitting an existing shard
+	// when the placement is resharded, until the shard is marked available.
+	SplitSource *SplitSource `protobuf:"bytes,6,opt,name=split_source,json=splitSource" json:"split_source,omitempty"`
 }
 
 func (m *Shard) Reset()                    { *m = Shard{} }
@@ -278,6 +282,30 @@ func (m *Shard) GetCutoffNanos() int64 {
 	return 0
 }
 
+func (m *Shard) GetSplitSource() *SplitSource {
+	if m != nil {
+		return m.SplitSource
+	}
+	return nil
+}
+
+type SplitSource struct {
+	// shard_id is the id of the shard the shard was split from.
+	ShardId uint32 `protobuf:"varint,1,opt,name=shard_id,json=shardId,proto3" json:"shard_id,omitempty"`
+}
+
+func (m *SplitSource) Reset()                    { *m = SplitSource{} }
+func (m *SplitSource) String() string            { return proto.CompactTextString(m) }
+func (*SplitSource) ProtoMessage()               {}
+func (*SplitSource) Descriptor() ([]byte, []int) { return fileDescriptorPlacement, []int{3} }
+
+func (m *SplitSource) GetShardId() uint32 {
+	if m != nil {
+		return m.ShardId
+	}
+	return 0
+}
+
 type PlacementSnapshots struct {
 	Snapshots []*Placement `protobuf:"bytes,1,rep,name=snapshots" json:"snapshots,omitempty"`
 }
@@ -285,7 +313,7 @@ type PlacementSnapshots struct {
 func (m *PlacementSnapshots) Reset()                    { *m = PlacementSnapshots{} }
 func (m *PlacementSnapshots) String() string            { return proto.CompactTextString(m) }
 func (*PlacementSnapshots) ProtoMessage()               {}
-func (*PlacementSnapshots) Descriptor() ([]byte, []int) { return fileDescriptorPlacement, []int{3} }
+func (*PlacementSnapshots) Descriptor() ([]byte, []int) { return fileDescriptorPlacement, []int{4} }
 
 func (m *PlacementSnapshots) GetSnapshots() []*Placement {
 	if m != nil {
@@ -298,6 +326,7 @@ func init() {
 	proto.RegisterType((*Placement)(nil), "placementpb.Placement")
 	proto.RegisterType((*Instance)(nil), "placementpb.Instance")
 	proto.RegisterType((*Shard)(nil), "placementpb.Shard")
+	proto.RegisterType((*SplitSource)(nil), "placementpb.SplitSource")
 	proto.RegisterType((*PlacementSnapshots)(nil), "placementpb.PlacementSnapshots")
 	proto.RegisterEnum("placementpb.ShardState", ShardState_name, ShardState_value)
 }
@@ -503,6 +532,39 @@ func (m *Shard) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintPlacement(dAtA, i, uint64(m.CutoffNanos))
 	}
+	if m.SplitSource != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(m.SplitSource.Size()))
+		n2, err := m.SplitSource.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n2
+	}
+	return i, nil
+}
+
+func (m *SplitSource) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SplitSource) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ShardId != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(m.ShardId))
+	}
 	return i, nil
 }
 
@@ -642,6 +704,19 @@ func (m *Shard) Size() (n int) {
 	if m.CutoffNanos != 0 {
 		n += 1 + sovPlacement(uint64(m.CutoffNanos))
 	}
+	if m.SplitSource != nil {
+		l = m.SplitSource.Size()
+		n += 1 + l + sovPlacement(uint64(l))
+	}
+	return n
+}
+
+func (m *SplitSource) Size() (n int) {
+	var l int
+	_ = l
+	if m.ShardId != 0 {
+		n += 1 + sovPlacement(uint64(m.ShardId))
+	}
 	return n
 }
 
@@ -1376,6 +1451,108 @@ func (m *Shard) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SplitSource", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SplitSource == nil {
+				m.SplitSource = &SplitSource{}
+			}
+			if err := m.SplitSource.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPlacement(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SplitSource) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPlacement
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SplitSource: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SplitSource: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardId", wireType)
+			}
+			m.ShardId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ShardId |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPlacement(dAtA[iNdEx:])
@@ -1588,45 +1765,47 @@ func init() {
 }
 
 var fileDescriptorPlacement = []byte{
-	// 628 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x53, 0xdf, 0x6e, 0xd3, 0x3e,
-	0x14, 0x5e, 0xd2, 0xb5, 0x6b, 0x4e, 0xd7, 0xfe, 0x2a, 0x4b, 0xbf, 0x11, 0x0d, 0x51, 0x4a, 0xd1,
-	0x44, 0x35, 0x44, 0x23, 0x6d, 0x5c, 0xa0, 0xdd, 0x75, 0x68, 0x4c, 0x99, 0xca, 0x84, 0xdc, 0x69,
-	0x17, 0xdc, 0x44, 0x6e, 0xe2, 0xb6, 0x16, 0x8d, 0x1d, 0xd9, 0xce, 0xd8, 0x78, 0x03, 0xee, 0x78,
-	0x0f, 0x5e, 0x84, 0x4b, 0x1e, 0x01, 0x8d, 0x87, 0xe0, 0x16, 0xc5, 0x49, 0xfa, 0x47, 0xec, 0xee,
-	0x9c, 0xef, 0x7c, 0xf6, 0xf9, 0xfc, 0xf9, 0x1c, 0xb8, 0x98, 0x31, 0x3d, 0x4f, 0x27, 0x83, 0x50,
-	0xc4, 0x5e, 0x7c, 0x1c, 0x4d, 0xbc, 0xf8, 0xd8, 0x53, 0x32, 0xf4, 0xc2, 0x45, 0xaa, 0x34, 0x95,
-	0xde, 0x8c, 0x72, 0x2a, 0x89, 0xa6, 0x91, 0x97, 0x48, 0xa1, 0x85, 0x97, 0x2c, 0x48, 0x48, 0x63,
-	0xca, 0x75, 0x32, 0x59, 0xc5, 0x03, 0x53, 0x43, 0x8d, 0xb5, 0x62, 0xef, 0x8f, 0x0d, 0xce, 0x87,
-	0x32, 0x47, 0x6f, 0xc1, 0x61, 0x5c, 0x69, 0xc2, 0x43, 0xaa, 0x5c, 0xab, 0x5b, 0xe9, 0x37, 0x8e,
-	0x0e, 0x06, 0x6b, 0xf4, 0xc1, 0x92, 0x3a, 0xf0, 0x4b, 0xde, 0x19, 0xd7, 0xf2, 0x0e, 0xaf, 0xce,
-	0xa1, 0x03, 0x68, 0x49, 0x9a, 0x2c, 0x58, 0x48, 0x82, 0x29, 0x09, 0xb5, 0x90, 0xae, 0xdd, 0xb5,
-	0xfa, 0x4d, 0xdc, 0x2c, 0xd0, 0x77, 0x06, 0x44, 0x4f, 0x00, 0x78, 0x1a, 0x07, 0x6a, 0x4e, 0x64,
-	0xa4, 0xdc, 0x8a, 0xa1, 0x38, 0x3c, 0x8d, 0xc7, 0x06, 0xc8, 0xca, 0x4c, 0xe5, 0x55, 0x1a, 0xb9,
-	0xdb, 0x5d, 0xab, 0x5f, 0xc7, 0x0e, 0x53, 0xe3, 0x1c, 0x40, 0xcf, 0x60, 0x37, 0x4c, 0xb5, 0xb8,
-	0xa1, 0x32, 0xd0, 0x2c, 0xa6, 0x6e, 0xb5, 0x6b, 0xf5, 0x2b, 0xb8, 0x51, 0x60, 0x57, 0x2c, 0xa6,
-	0xe8, 0x29, 0x34, 0x98, 0x0a, 0x62, 0x26, 0xa5, 0x90, 0x34, 0x72, 0x6b, 0xe6, 0x0a, 0x60, 0xea,
-	0x7d, 0x81, 0xa0, 0x17, 0xd0, 0x8e, 0xc9, 0x6d, 0xde, 0x23, 0x50, 0x54, 0x07, 0x2c, 0x72, 0x77,
-	0x72, 0xa9, 0x31, 0xb9, 0x35, 0x9d, 0xc6, 0x54, 0xfb, 0xd1, 0xfe, 0x18, 0x5a, 0x9b, 0xcf, 0x45,
-	0x6d, 0xa8, 0x7c, 0xa2, 0x77, 0xae, 0xd5, 0xb5, 0xfa, 0x0e, 0xce, 0x42, 0xf4, 0x12, 0xaa, 0x37,
-	0x64, 0x91, 0x52, 0xf3, 0xd8, 0xc6, 0xd1, 0xff, 0x1b, 0xb6, 0x95, 0xa7, 0x71, 0xce, 0x39, 0xb1,
-	0xdf, 0x58, 0xbd, 0xaf, 0x36, 0xd4, 0x4b, 0x1c, 0xb5, 0xc0, 0x66, 0x51, 0x71, 0x9d, 0xcd, 0x32,
-	0x69, 0xff, 0x31, 0x25, 0x16, 0x44, 0x33, 0xc1, 0x83, 0x99, 0x14, 0x69, 0x62, 0xee, 0x75, 0x70,
-	0x6b, 0x09, 0x9f, 0x67, 0x28, 0x42, 0xb0, 0xfd, 0x45, 0x70, 0x6a, 0xfc, 0x73, 0xb0, 0x89, 0xd1,
-	0x1e, 0xd4, 0x3e, 0x53, 0x36, 0x9b, 0x6b, 0x63, 0x5b, 0x13, 0x17, 0x19, 0xda, 0x87, 0x3a, 0xe5,
-	0x51, 0x22, 0x18, 0xd7, 0xc6, 0x2f, 0x07, 0x2f, 0x73, 0x74, 0x08, 0xb5, 0xe2, 0x27, 0x6a, 0xe6,
-	0xdb, 0xd1, 0x86, 0x7e, 0xe3, 0x05, 0x2e, 0x18, 0xa8, 0x0b, 0xbb, 0x0f, 0x78, 0x06, 0x6a, 0x69,
-	0x58, 0xd6, 0x69, 0x2e, 0x94, 0xe6, 0x24, 0xa6, 0x6e, 0x3d, 0xef, 0x54, 0xe6, 0x99, 0xe2, 0x44,
-	0x48, 0xed, 0x3a, 0xe6, 0x94, 0x89, 0x7b, 0xdf, 0x2d, 0xa8, 0x9a, 0x1e, 0x6b, 0x46, 0x34, 0x8d,
-	0x11, 0xaf, 0xa0, 0xaa, 0x34, 0xd1, 0xb9, 0xad, 0xad, 0xa3, 0x47, 0xff, 0xca, 0x1a, 0x67, 0x65,
-	0x9c, 0xb3, 0xd0, 0x63, 0x70, 0x94, 0x48, 0x65, 0x48, 0x33, 0x5d, 0xb9, 0x27, 0xf5, 0x1c, 0xf0,
-	0x23, 0xf4, 0x1c, 0x9a, 0xe5, 0xcc, 0x70, 0xc2, 0x85, 0x32, 0xf6, 0x54, 0x70, 0x39, 0x48, 0x97,
-	0x19, 0x56, 0x0e, 0xd6, 0x74, 0x5a, 0x70, 0xd6, 0x06, 0x6b, 0x3a, 0x35, 0x94, 0xde, 0x05, 0xa0,
-	0xe5, 0x1e, 0x8c, 0x39, 0x49, 0xd4, 0x5c, 0x68, 0x85, 0x5e, 0x83, 0xa3, 0xca, 0xa4, 0xd8, 0x9d,
-	0xbd, 0x87, 0x77, 0x07, 0xaf, 0x88, 0x87, 0x27, 0x00, 0xab, 0x57, 0xa0, 0x36, 0xec, 0xfa, 0x97,
-	0xfe, 0x95, 0x3f, 0x1c, 0xf9, 0x1f, 0xfd, 0xcb, 0xf3, 0xf6, 0x16, 0x6a, 0x82, 0x33, 0xbc, 0x1e,
-	0xfa, 0xa3, 0xe1, 0xe9, 0xe8, 0xac, 0x6d, 0xa1, 0x06, 0xec, 0x8c, 0xce, 0x86, 0xd7, 0x59, 0xcd,
-	0x3e, 0x6d, 0xff, 0xb8, 0xef, 0x58, 0x3f, 0xef, 0x3b, 0xd6, 0xaf, 0xfb, 0x8e, 0xf5, 0xed, 0x77,
-	0x67, 0x6b, 0x52, 0x33, 0x1b, 0x7e, 0xfc, 0x37, 0x00, 0x00, 0xff, 0xff, 0x38, 0x9b, 0x13, 0x65,
-	0x2f, 0x04, 0x00, 0x00,
+	// 667 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x54, 0xcd, 0x6e, 0xd3, 0x4a,
+	0x14, 0xae, 0x93, 0x26, 0x8d, 0x8f, 0x93, 0xdc, 0x68, 0xa4, 0xdb, 0xeb, 0xdb, 0xab, 0x1b, 0x42,
+	0x50, 0x45, 0x54, 0x44, 0x22, 0xa5, 0x2c, 0x50, 0x59, 0xa5, 0xa8, 0x54, 0xae, 0x42, 0x85, 0x26,
+	0x55, 0x17, 0x6c, 0xac, 0x89, 0x3d, 0x49, 0x46, 0xc4, 0x33, 0xd6, 0xcc, 0xb8, 0xb4, 0xbc, 0x01,
+	0x3b, 0x1e, 0x8b, 0x25, 0x8f, 0x80, 0xca, 0x86, 0x37, 0x60, 0x8b, 0x3c, 0xb6, 0xf3, 0x23, 0xba,
+	0x3b, 0xe7, 0xfb, 0xbe, 0x39, 0xe7, 0xcc, 0xe7, 0x33, 0x86, 0x8b, 0x39, 0xd3, 0x8b, 0x64, 0xda,
+	0x0f, 0x44, 0x34, 0x88, 0x8e, 0xc3, 0xe9, 0x20, 0x3a, 0x1e, 0x28, 0x19, 0x0c, 0x82, 0x65, 0xa2,
+	0x34, 0x95, 0x83, 0x39, 0xe5, 0x54, 0x12, 0x4d, 0xc3, 0x41, 0x2c, 0x85, 0x16, 0x83, 0x78, 0x49,
+	0x02, 0x1a, 0x51, 0xae, 0xe3, 0xe9, 0x3a, 0xee, 0x1b, 0x0e, 0x39, 0x1b, 0x64, 0xf7, 0x57, 0x09,
+	0xec, 0x77, 0x45, 0x8e, 0x5e, 0x83, 0xcd, 0xb8, 0xd2, 0x84, 0x07, 0x54, 0xb9, 0x56, 0xa7, 0xdc,
+	0x73, 0x86, 0x87, 0xfd, 0x0d, 0x79, 0x7f, 0x25, 0xed, 0x7b, 0x85, 0xee, 0x8c, 0x6b, 0x79, 0x87,
+	0xd7, 0xe7, 0xd0, 0x21, 0x34, 0x25, 0x8d, 0x97, 0x2c, 0x20, 0xfe, 0x8c, 0x04, 0x5a, 0x48, 0xb7,
+	0xd4, 0xb1, 0x7a, 0x0d, 0xdc, 0xc8, 0xd1, 0x37, 0x06, 0x44, 0xff, 0x03, 0xf0, 0x24, 0xf2, 0xd5,
+	0x82, 0xc8, 0x50, 0xb9, 0x65, 0x23, 0xb1, 0x79, 0x12, 0x4d, 0x0c, 0x90, 0xd2, 0x4c, 0x65, 0x2c,
+	0x0d, 0xdd, 0xdd, 0x8e, 0xd5, 0xab, 0x61, 0x9b, 0xa9, 0x49, 0x06, 0xa0, 0xc7, 0x50, 0x0f, 0x12,
+	0x2d, 0x6e, 0xa8, 0xf4, 0x35, 0x8b, 0xa8, 0x5b, 0xe9, 0x58, 0xbd, 0x32, 0x76, 0x72, 0xec, 0x8a,
+	0x45, 0x14, 0x3d, 0x02, 0x87, 0x29, 0x3f, 0x62, 0x52, 0x0a, 0x49, 0x43, 0xb7, 0x6a, 0x4a, 0x00,
+	0x53, 0x6f, 0x73, 0x04, 0x3d, 0x85, 0x56, 0x44, 0x6e, 0xb3, 0x1e, 0xbe, 0xa2, 0xda, 0x67, 0xa1,
+	0xbb, 0x97, 0x8d, 0x1a, 0x91, 0x5b, 0xd3, 0x69, 0x42, 0xb5, 0x17, 0x1e, 0x4c, 0xa0, 0xb9, 0x7d,
+	0x5d, 0xd4, 0x82, 0xf2, 0x07, 0x7a, 0xe7, 0x5a, 0x1d, 0xab, 0x67, 0xe3, 0x34, 0x44, 0xcf, 0xa0,
+	0x72, 0x43, 0x96, 0x09, 0x35, 0x97, 0x75, 0x86, 0x7f, 0x6f, 0xd9, 0x56, 0x9c, 0xc6, 0x99, 0xe6,
+	0xa4, 0xf4, 0xd2, 0xea, 0x7e, 0x2e, 0x41, 0xad, 0xc0, 0x51, 0x13, 0x4a, 0x2c, 0xcc, 0xcb, 0x95,
+	0x58, 0x3a, 0xda, 0x5f, 0x4c, 0x89, 0x25, 0xd1, 0x4c, 0x70, 0x7f, 0x2e, 0x45, 0x12, 0x9b, 0xba,
+	0x36, 0x6e, 0xae, 0xe0, 0xf3, 0x14, 0x45, 0x08, 0x76, 0x3f, 0x09, 0x4e, 0x8d, 0x7f, 0x36, 0x36,
+	0x31, 0xda, 0x87, 0xea, 0x47, 0xca, 0xe6, 0x0b, 0x6d, 0x6c, 0x6b, 0xe0, 0x3c, 0x43, 0x07, 0x50,
+	0xa3, 0x3c, 0x8c, 0x05, 0xe3, 0xda, 0xf8, 0x65, 0xe3, 0x55, 0x8e, 0x8e, 0xa0, 0x9a, 0x7f, 0x89,
+	0xaa, 0xf9, 0xec, 0x68, 0x6b, 0x7e, 0xe3, 0x05, 0xce, 0x15, 0xa8, 0x03, 0xf5, 0x07, 0x3c, 0x03,
+	0xb5, 0x32, 0x2c, 0xed, 0xb4, 0x10, 0x4a, 0x73, 0x12, 0x51, 0xb7, 0x96, 0x75, 0x2a, 0xf2, 0x74,
+	0xe2, 0x58, 0x48, 0xed, 0xda, 0xe6, 0x94, 0x89, 0xbb, 0x3f, 0x2d, 0xa8, 0x98, 0x1e, 0x1b, 0x46,
+	0x34, 0x8c, 0x11, 0xcf, 0xa1, 0xa2, 0x34, 0xd1, 0x99, 0xad, 0xcd, 0xe1, 0x3f, 0x7f, 0x8e, 0x35,
+	0x49, 0x69, 0x9c, 0xa9, 0xd0, 0x7f, 0x60, 0x2b, 0x91, 0xc8, 0x80, 0xa6, 0x73, 0x65, 0x9e, 0xd4,
+	0x32, 0xc0, 0x0b, 0xd1, 0x13, 0x68, 0x14, 0x3b, 0xc3, 0x09, 0x17, 0xca, 0xd8, 0x53, 0xc6, 0xc5,
+	0x22, 0x5d, 0xa6, 0x58, 0xb1, 0x58, 0xb3, 0x59, 0xae, 0xd9, 0x58, 0xac, 0xd9, 0x2c, 0x93, 0xbc,
+	0x82, 0xba, 0x8a, 0x97, 0x4c, 0xfb, 0x59, 0x65, 0xb3, 0x59, 0xce, 0xd0, 0xdd, 0x1e, 0x2d, 0x15,
+	0x4c, 0x0c, 0x8f, 0x1d, 0xb5, 0x4e, 0xba, 0x3d, 0x70, 0x36, 0x38, 0xf4, 0x2f, 0xd4, 0x32, 0x2f,
+	0x57, 0xb7, 0xde, 0x33, 0xb9, 0x17, 0x76, 0x2f, 0x00, 0xad, 0x9e, 0xdb, 0x84, 0x93, 0x58, 0x2d,
+	0x84, 0x56, 0xe8, 0x05, 0xd8, 0xaa, 0x48, 0xf2, 0x27, 0xba, 0xff, 0xf0, 0x13, 0xc5, 0x6b, 0xe1,
+	0xd1, 0x09, 0xc0, 0xda, 0x2c, 0xd4, 0x82, 0xba, 0x77, 0xe9, 0x5d, 0x79, 0xa3, 0xb1, 0xf7, 0xde,
+	0xbb, 0x3c, 0x6f, 0xed, 0xa0, 0x06, 0xd8, 0xa3, 0xeb, 0x91, 0x37, 0x1e, 0x9d, 0x8e, 0xcf, 0x5a,
+	0x16, 0x72, 0x60, 0x6f, 0x7c, 0x36, 0xba, 0x4e, 0xb9, 0xd2, 0x69, 0xeb, 0xeb, 0x7d, 0xdb, 0xfa,
+	0x76, 0xdf, 0xb6, 0xbe, 0xdf, 0xb7, 0xad, 0x2f, 0x3f, 0xda, 0x3b, 0xd3, 0xaa, 0xf9, 0x91, 0x1c,
+	0xff, 0x1e, 0x00, 0xfc, 0xc5, 0xf1, 0x40, 0x96, 0x04, 0x00, 0x00,
 }
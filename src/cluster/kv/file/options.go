@@ -0,0 +1,176 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package file
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+const (
+	defaultMaxVersions = 100
+)
+
+var (
+	defaultNewDirectoryMode = os.FileMode(0755)
+	defaultNewFileMode      = os.FileMode(0644)
+
+	errNoFilePath          = errors.New("invalid options, no file path set")
+	errInvalidMaxVersions  = errors.New("invalid options, max versions must be positive")
+	errNoInstrumentOptions = errors.New("invalid options, no instrument options set")
+)
+
+// Options are options for the file backed kv store.
+type Options interface {
+	// FilePath is the path of the file the store is persisted to.
+	FilePath() string
+	// SetFilePath sets the FilePath.
+	SetFilePath(value string) Options
+
+	// MaxVersions is the number of versions of each key kept for History,
+	// older versions are compacted.
+	MaxVersions() int
+	// SetMaxVersions sets the MaxVersions.
+	SetMaxVersions(value int) Options
+
+	// InstrumentsOptions is the instrument options.
+	InstrumentsOptions() instrument.Options
+	// SetInstrumentsOptions sets the InstrumentsOptions.
+	SetInstrumentsOptions(iopts instrument.Options) Options
+
+	// Prefix is the prefix for each key.
+	Prefix() string
+	// SetPrefix sets the prefix.
+	SetPrefix(s string) Options
+	// ApplyPrefix applies the prefix to the key.
+	ApplyPrefix(key string) string
+
+	// NewDirectoryMode is the mode of the directory created for the file.
+	NewDirectoryMode() os.FileMode
+	// SetNewDirectoryMode sets the NewDirectoryMode.
+	SetNewDirectoryMode(fm os.FileMode) Options
+
+	// NewFileMode is the mode of the file the store is persisted to.
+	NewFileMode() os.FileMode
+	// SetNewFileMode sets the NewFileMode.
+	SetNewFileMode(fm os.FileMode) Options
+
+	// Validate validates the Options.
+	Validate() error
+}
+
+type options struct {
+	filePath         string
+	maxVersions      int
+	iopts            instrument.Options
+	prefix           string
+	newDirectoryMode os.FileMode
+	newFileMode      os.FileMode
+}
+
+// NewOptions creates a sane default Option.
+func NewOptions() Options {
+	o := options{}
+	return o.SetMaxVersions(defaultMaxVersions).
+		SetInstrumentsOptions(instrument.NewOptions()).
+		SetNewDirectoryMode(defaultNewDirectoryMode).
+		SetNewFileMode(defaultNewFileMode)
+}
+
+func (o options) Validate() error {
+	if o.filePath == "" {
+		return errNoFilePath
+	}
+
+	if o.maxVersions <= 0 {
+		return errInvalidMaxVersions
+	}
+
+	if o.iopts == nil {
+		return errNoInstrumentOptions
+	}
+
+	return nil
+}
+
+func (o options) FilePath() string {
+	return o.filePath
+}
+
+func (o options) SetFilePath(value string) Options {
+	o.filePath = value
+	return o
+}
+
+func (o options) MaxVersions() int {
+	return o.maxVersions
+}
+
+func (o options) SetMaxVersions(value int) Options {
+	o.maxVersions = value
+	return o
+}
+
+func (o options) InstrumentsOptions() instrument.Options {
+	return o.iopts
+}
+
+func (o options) SetInstrumentsOptions(iopts instrument.Options) Options {
+	o.iopts = iopts
+	return o
+}
+
+func (o options) Prefix() string {
+	return o.prefix
+}
+
+func (o options) SetPrefix(prefix string) Options {
+	o.prefix = prefix
+	return o
+}
+
+func (o options) ApplyPrefix(key string) string {
+	if o.prefix == "" {
+		return key
+	}
+	return fmt.Sprintf("%s/%s", o.prefix, key)
+}
+
+func (o options) NewDirectoryMode() os.FileMode {
+	return o.newDirectoryMode
+}
+
+func (o options) SetNewDirectoryMode(fm os.FileMode) Options {
+	o.newDirectoryMode = fm
+	return o
+}
+
+func (o options) NewFileMode() os.FileMode {
+	return o.newFileMode
+}
+
+func (o options) SetNewFileMode(fm os.FileMode) Options {
+	o.newFileMode = fm
+	return o
+}
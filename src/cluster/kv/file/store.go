@@ -0,0 +1,545 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package file implements a kv store persisted to a local file, so a single
+// process can keep its cluster state across restarts without an external etcd
+// cluster.
+package file
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/m3db/m3/src/cluster/kv"
+
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// lockFileSuffix is appended to the path of the kv file to get the path of
+	// the file locked while the kv file is in use. The kv file is replaced on
+	// every write, so a lock taken on the kv file itself would not be kept.
+	lockFileSuffix = ".lock"
+)
+
+var (
+	// ErrVersionCompacted is returned by History when a requested version is
+	// older than the versions kept for the key.
+	ErrVersionCompacted = errors.New("version has been compacted")
+
+	errInvalidHistoryVersion = errors.New("invalid version range")
+	errDuplicateKeyInTxn     = errors.New("duplicate key in transaction")
+
+	databasesLock sync.Mutex
+	databases     = make(map[string]*database)
+)
+
+// NewStore returns a kv store persisted to the file at opts.FilePath(). Every
+// write is persisted before it returns, versions follow the etcd semantics and
+// a key starts again from version 1 once deleted.
+//
+// Stores created on the same file in a process share their values and watches,
+// with the file loaded once using the options of the first store. The file
+// can not be shared by several processes, it is locked by the first process
+// to open it and opening it in another process fails. A coordinator sharing
+// the cluster state of a database node must therefore be embedded in the
+// database node.
+func NewStore(opts Options) (kv.TxnStore, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	db, err := openDatabase(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &store{db: db, opts: opts}, nil
+}
+
+func openDatabase(opts Options) (*database, error) {
+	path, err := filepath.Abs(opts.FilePath())
+	if err != nil {
+		return nil, err
+	}
+
+	databasesLock.Lock()
+	defer databasesLock.Unlock()
+
+	if db, ok := databases[path]; ok {
+		return db, nil
+	}
+
+	db, err := newDatabase(path, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	databases[path] = db
+	return db, nil
+}
+
+type store struct {
+	db   *database
+	opts Options
+}
+
+func (s *store) Get(key string) (kv.Value, error) {
+	s.db.RLock()
+	defer s.db.RUnlock()
+
+	v, ok := s.db.latestWithLock(s.opts.ApplyPrefix(key))
+	if !ok {
+		return nil, kv.ErrNotFound
+	}
+	return v, nil
+}
+
+func (s *store) Watch(key string) (kv.ValueWatch, error) {
+	newKey := s.opts.ApplyPrefix(key)
+
+	s.db.Lock()
+	watchable, ok := s.db.watchables[newKey]
+	if !ok {
+		watchable = kv.NewValueWatchable()
+		s.db.watchables[newKey] = watchable
+		if v, exists := s.db.latestWithLock(newKey); exists {
+			watchable.Update(v)
+		}
+	}
+	s.db.Unlock()
+
+	_, watch, err := watchable.Watch()
+	return watch, err
+}
+
+func (s *store) Set(key string, v proto.Message) (int, error) {
+	data, err := proto.Marshal(v)
+	if err != nil {
+		return 0, err
+	}
+
+	s.db.Lock()
+	defer s.db.Unlock()
+
+	return s.db.putWithLock(s.opts.ApplyPrefix(key), data)
+}
+
+func (s *store) SetIfNotExists(key string, v proto.Message) (int, error) {
+	data, err := proto.Marshal(v)
+	if err != nil {
+		return 0, err
+	}
+
+	newKey := s.opts.ApplyPrefix(key)
+
+	s.db.Lock()
+	defer s.db.Unlock()
+
+	if _, exists := s.db.latestWithLock(newKey); exists {
+		return 0, kv.ErrAlreadyExists
+	}
+	return s.db.putWithLock(newKey, data)
+}
+
+func (s *store) CheckAndSet(key string, version int, v proto.Message) (int, error) {
+	data, err := proto.Marshal(v)
+	if err != nil {
+		return 0, err
+	}
+
+	newKey := s.opts.ApplyPrefix(key)
+
+	s.db.Lock()
+	defer s.db.Unlock()
+
+	if s.db.versionWithLock(newKey) != version {
+		return 0, kv.ErrVersionMismatch
+	}
+	return s.db.putWithLock(newKey, data)
+}
+
+func (s *store) Delete(key string) (kv.Value, error) {
+	newKey := s.opts.ApplyPrefix(key)
+
+	s.db.Lock()
+	defer s.db.Unlock()
+
+	prev, exists := s.db.latestWithLock(newKey)
+	if !exists {
+		return nil, kv.ErrNotFound
+	}
+
+	if err := s.db.applyWithLock([]change{{key: newKey}}); err != nil {
+		return nil, err
+	}
+	return prev, nil
+}
+
+func (s *store) History(key string, from, to int) ([]kv.Value, error) {
+	if from > to || from < 0 || to < 0 {
+		return nil, errInvalidHistoryVersion
+	}
+
+	if from == to {
+		return nil, nil
+	}
+
+	s.db.RLock()
+	defer s.db.RUnlock()
+
+	values, ok := s.db.state.Values[s.opts.ApplyPrefix(key)]
+	if !ok {
+		return nil, kv.ErrNotFound
+	}
+
+	latest := values[len(values)-1].version()
+	if latest < from {
+		// No value available in the requested version range.
+		return nil, nil
+	}
+
+	numValue := to - from
+	if latest-from+1 < numValue {
+		numValue = latest - from + 1
+	}
+
+	oldest := values[0].version()
+	res := make([]kv.Value, numValue)
+	for version := from; version < from+numValue; version++ {
+		if version <= 0 {
+			continue
+		}
+		if version < oldest {
+			return nil, ErrVersionCompacted
+		}
+		res[version-from] = values[version-oldest]
+	}
+	return res, nil
+}
+
+func (s *store) Commit(conditions []kv.Condition, ops []kv.Op) (kv.Response, error) {
+	for _, condition := range conditions {
+		if condition.TargetType() != kv.TargetVersion {
+			return nil, kv.ErrUnknownTargetType
+		}
+		if condition.CompareType() != kv.CompareEqual {
+			return nil, kv.ErrUnknownCompareType
+		}
+	}
+
+	data := make([][]byte, len(ops))
+	keys := make(map[string]struct{}, len(ops))
+	for i, op := range ops {
+		if op.Type() != kv.OpSet {
+			return nil, kv.ErrUnknownOpType
+		}
+
+		opSet := op.(kv.SetOp)
+		newKey := s.opts.ApplyPrefix(opSet.Key())
+		if _, ok := keys[newKey]; ok {
+			return nil, errDuplicateKeyInTxn
+		}
+		keys[newKey] = struct{}{}
+
+		b, err := proto.Marshal(opSet.Value)
+		if err != nil {
+			return nil, err
+		}
+		data[i] = b
+	}
+
+	s.db.Lock()
+	defer s.db.Unlock()
+
+	for _, condition := range conditions {
+		version := s.db.versionWithLock(s.opts.ApplyPrefix(condition.Key()))
+		if expected, ok := condition.Value().(int); !ok || expected != version {
+			return nil, kv.ErrConditionCheckFailed
+		}
+	}
+
+	var (
+		revision    = s.db.state.Revision + 1
+		changes     = make([]change, 0, len(ops))
+		opResponses = make([]kv.OpResponse, 0, len(ops))
+	)
+	for i, op := range ops {
+		newKey := s.opts.ApplyPrefix(op.Key())
+		v := s.db.nextValueWithLock(newKey, data[i], revision)
+		changes = append(changes, change{
+			key:    newKey,
+			values: s.db.appendValueWithLock(newKey, v),
+		})
+		opResponses = append(opResponses, kv.NewOpResponse(op).SetValue(v.version()))
+	}
+
+	if err := s.db.applyWithLock(changes); err != nil {
+		return nil, err
+	}
+	return kv.NewResponse().SetResponses(opResponses), nil
+}
+
+// database holds the values of all the keys persisted to a file.
+type database struct {
+	sync.RWMutex
+
+	path       string
+	lockFile   *os.File
+	opts       Options
+	logger     *zap.Logger
+	state      persistedState
+	watchables map[string]kv.ValueWatchable
+}
+
+// persistedState is the content of the file, the values of a key are
+// ordered by version and the latest MaxVersions versions are kept.
+type persistedState struct {
+	Revision int64               `json:"revision"`
+	Values   map[string][]*value `json:"values"`
+}
+
+// change replaces the values of a key, a change without values deletes the key.
+type change struct {
+	key    string
+	values []*value
+}
+
+func newDatabase(path string, opts Options) (*database, error) {
+	db := &database{
+		path:       path,
+		opts:       opts,
+		logger:     opts.InstrumentsOptions().Logger(),
+		state:      persistedState{Values: make(map[string][]*value)},
+		watchables: make(map[string]kv.ValueWatchable),
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), opts.NewDirectoryMode()); err != nil {
+		return nil, fmt.Errorf("error creating directory for kv file %s: %v", path, err)
+	}
+
+	lockFile, err := lockFile(path + lockFileSuffix)
+	if err != nil {
+		return nil, err
+	}
+	db.lockFile = lockFile
+
+	if err := db.load(); err != nil {
+		db.close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// lockFile takes an exclusive lock on the file, creating it if needed. It
+// fails immediately if the lock is held, the lock is held until the file
+// is closed.
+func lockFile(path string) (*os.File, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0666)
+	if err != nil {
+		return nil, fmt.Errorf("error opening lock file %s: %v", path, err)
+	}
+
+	if err := unix.Flock(int(f.Fd()), unix.LOCK_EX|unix.LOCK_NB); err != nil {
+		f.Close()
+		if err == unix.EWOULDBLOCK {
+			return nil, fmt.Errorf("kv file is in use by another process, lock file %s is held", path)
+		}
+		return nil, fmt.Errorf("error locking lock file %s: %v", path, err)
+	}
+	return f, nil
+}
+
+func (d *database) load() error {
+	data, err := ioutil.ReadFile(d.path)
+	if os.IsNotExist(err) {
+		d.logger.Info("creating new kv file", zap.String("file", d.path))
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading kv file %s: %v", d.path, err)
+	}
+
+	if err := json.Unmarshal(data, &d.state); err != nil {
+		return fmt.Errorf("error decoding kv file %s: %v", d.path, err)
+	}
+	if d.state.Values == nil {
+		d.state.Values = make(map[string][]*value)
+	}
+
+	d.logger.Info("loaded kv file", zap.String("file", d.path),
+		zap.Int("keys", len(d.state.Values)),
+		zap.Int64("revision", d.state.Revision))
+	return nil
+}
+
+// close releases the lock on the kv file. Databases are kept open for the
+// lifetime of the process, so this is only used once a database fails to
+// load and by tests.
+func (d *database) close() error {
+	return d.lockFile.Close()
+}
+
+func (d *database) latestWithLock(key string) (*value, bool) {
+	values, ok := d.state.Values[key]
+	if !ok {
+		return nil, false
+	}
+	return values[len(values)-1], true
+}
+
+// versionWithLock returns the version of the key, 0 if it does not exist.
+func (d *database) versionWithLock(key string) int {
+	v, ok := d.latestWithLock(key)
+	if !ok {
+		return 0
+	}
+	return v.version()
+}
+
+func (d *database) nextValueWithLock(key string, data []byte, revision int64) *value {
+	return &value{
+		Val: data,
+		Ver: int64(d.versionWithLock(key) + 1),
+		Rev: revision,
+	}
+}
+
+// appendValueWithLock returns the values of the key with the given value
+// appended, compacting the versions beyond MaxVersions.
+func (d *database) appendValueWithLock(key string, v *value) []*value {
+	values := d.state.Values[key]
+	if n := len(values) + 1 - d.opts.MaxVersions(); n > 0 {
+		values = values[n:]
+	}
+
+	res := make([]*value, 0, len(values)+1)
+	res = append(res, values...)
+	return append(res, v)
+}
+
+func (d *database) putWithLock(key string, data []byte) (int, error) {
+	v := d.nextValueWithLock(key, data, d.state.Revision+1)
+	if err := d.applyWithLock([]change{{key: key, values: d.appendValueWithLock(key, v)}}); err != nil {
+		return 0, err
+	}
+	return v.version(), nil
+}
+
+// applyWithLock persists the changes as a new revision and only then makes
+// them visible, so a failed write leaves the store unchanged.
+func (d *database) applyWithLock(changes []change) error {
+	next := persistedState{
+		Revision: d.state.Revision + 1,
+		Values:   make(map[string][]*value, len(d.state.Values)+len(changes)),
+	}
+	for key, values := range d.state.Values {
+		next.Values[key] = values
+	}
+	for _, c := range changes {
+		if len(c.values) == 0 {
+			delete(next.Values, c.key)
+			continue
+		}
+		next.Values[c.key] = c.values
+	}
+
+	if err := d.writeWithLock(next); err != nil {
+		d.logger.Error("could not write kv file", zap.String("file", d.path), zap.Error(err))
+		return err
+	}
+
+	d.state = next
+	for _, c := range changes {
+		watchable, ok := d.watchables[c.key]
+		if !ok {
+			continue
+		}
+		if len(c.values) == 0 {
+			watchable.Update(nil)
+			continue
+		}
+		watchable.Update(c.values[len(c.values)-1])
+	}
+	return nil
+}
+
+// writeWithLock replaces the file with the state by renaming a temporary
+// file over it, so the file always holds a complete revision.
+func (d *database) writeWithLock(state persistedState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	tmpPath := d.path + ".tmp"
+	f, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, d.opts.NewFileMode())
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, d.path)
+}
+
+type value struct {
+	Val []byte `json:"value"`
+	Ver int64  `json:"version"`
+	Rev int64  `json:"revision"`
+}
+
+func (v *value) version() int {
+	return int(v.Ver)
+}
+
+func (v *value) IsNewer(other kv.Value) bool {
+	otherValue, ok := other.(*value)
+	if ok {
+		return v.Rev > otherValue.Rev
+	}
+
+	return v.Version() > other.Version()
+}
+
+func (v *value) Unmarshal(msg proto.Message) error {
+	return proto.Unmarshal(v.Val, msg)
+}
+
+func (v *value) Version() int {
+	return int(v.Ver)
+}
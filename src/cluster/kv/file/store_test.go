@@ -0,0 +1,369 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package file
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/generated/proto/kvtest"
+	"github.com/m3db/m3/src/cluster/kv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAndSet(t *testing.T) {
+	store, _, closer := testStore(t)
+	defer closer()
+
+	_, err := store.Get("foo")
+	require.Equal(t, kv.ErrNotFound, err)
+
+	version, err := store.Set("foo", genProto("bar1"))
+	require.NoError(t, err)
+	require.Equal(t, 1, version)
+
+	version, err = store.Set("foo", genProto("bar2"))
+	require.NoError(t, err)
+	require.Equal(t, 2, version)
+
+	value, err := store.Get("foo")
+	require.NoError(t, err)
+	verifyValue(t, value, "bar2", 2)
+}
+
+func TestSetIfNotExists(t *testing.T) {
+	store, _, closer := testStore(t)
+	defer closer()
+
+	version, err := store.SetIfNotExists("foo", genProto("bar1"))
+	require.NoError(t, err)
+	require.Equal(t, 1, version)
+
+	_, err = store.SetIfNotExists("foo", genProto("bar2"))
+	require.Equal(t, kv.ErrAlreadyExists, err)
+
+	value, err := store.Get("foo")
+	require.NoError(t, err)
+	verifyValue(t, value, "bar1", 1)
+}
+
+func TestCheckAndSet(t *testing.T) {
+	store, _, closer := testStore(t)
+	defer closer()
+
+	_, err := store.CheckAndSet("foo", 1, genProto("bar1"))
+	require.Equal(t, kv.ErrVersionMismatch, err)
+
+	version, err := store.CheckAndSet("foo", 0, genProto("bar1"))
+	require.NoError(t, err)
+	require.Equal(t, 1, version)
+
+	version, err = store.CheckAndSet("foo", 1, genProto("bar2"))
+	require.NoError(t, err)
+	require.Equal(t, 2, version)
+
+	_, err = store.CheckAndSet("foo", 1, genProto("bar3"))
+	require.Equal(t, kv.ErrVersionMismatch, err)
+
+	value, err := store.Get("foo")
+	require.NoError(t, err)
+	verifyValue(t, value, "bar2", 2)
+}
+
+func TestDelete(t *testing.T) {
+	store, _, closer := testStore(t)
+	defer closer()
+
+	_, err := store.Delete("foo")
+	require.Equal(t, kv.ErrNotFound, err)
+
+	_, err = store.Set("foo", genProto("bar1"))
+	require.NoError(t, err)
+	_, err = store.Set("foo", genProto("bar2"))
+	require.NoError(t, err)
+
+	prev, err := store.Delete("foo")
+	require.NoError(t, err)
+	verifyValue(t, prev, "bar2", 2)
+
+	_, err = store.Get("foo")
+	require.Equal(t, kv.ErrNotFound, err)
+
+	version, err := store.SetIfNotExists("foo", genProto("bar3"))
+	require.NoError(t, err)
+	require.Equal(t, 1, version)
+}
+
+func TestHistory(t *testing.T) {
+	_, opts, closer := testStore(t)
+	defer closer()
+
+	// Versions are compacted with the options of the first store on a file.
+	historyPath := filepath.Join(filepath.Dir(opts.FilePath()), "history.json")
+	store, err := NewStore(opts.SetFilePath(historyPath).SetMaxVersions(3))
+	require.NoError(t, err)
+
+	_, err = store.History("foo", 0, 5)
+	require.Equal(t, kv.ErrNotFound, err)
+
+	_, err = store.History("foo", 3, 1)
+	require.Equal(t, errInvalidHistoryVersion, err)
+
+	for i := 1; i <= 5; i++ {
+		_, err = store.Set("foo", genProto(fmt.Sprintf("bar%d", i)))
+		require.NoError(t, err)
+	}
+
+	res, err := store.History("foo", 3, 6)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(res))
+	for i, value := range res {
+		verifyValue(t, value, fmt.Sprintf("bar%d", i+3), i+3)
+	}
+
+	res, err = store.History("foo", 4, 10)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(res))
+
+	res, err = store.History("foo", 6, 10)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(res))
+
+	_, err = store.History("foo", 2, 4)
+	require.Equal(t, ErrVersionCompacted, err)
+}
+
+func TestWatch(t *testing.T) {
+	store, _, closer := testStore(t)
+	defer closer()
+
+	_, err := store.Set("foo", genProto("bar1"))
+	require.NoError(t, err)
+
+	w, err := store.Watch("foo")
+	require.NoError(t, err)
+	<-w.C()
+	verifyValue(t, w.Get(), "bar1", 1)
+
+	_, err = store.Set("foo", genProto("bar2"))
+	require.NoError(t, err)
+	<-w.C()
+	verifyValue(t, w.Get(), "bar2", 2)
+
+	_, err = store.Delete("foo")
+	require.NoError(t, err)
+	<-w.C()
+	require.Nil(t, w.Get())
+
+	w2, err := store.Watch("other")
+	require.NoError(t, err)
+	select {
+	case <-w2.C():
+		require.FailNow(t, "unexpected notification for a key without value")
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestTxn(t *testing.T) {
+	store, _, closer := testStore(t)
+	defer closer()
+
+	_, err := store.Set("key", genProto("bar1"))
+	require.NoError(t, err)
+
+	r, err := store.Commit(
+		[]kv.Condition{
+			kv.NewCondition().
+				SetCompareType(kv.CompareEqual).
+				SetTargetType(kv.TargetVersion).
+				SetKey("foo").
+				SetValue(0),
+			kv.NewCondition().
+				SetCompareType(kv.CompareEqual).
+				SetTargetType(kv.TargetVersion).
+				SetKey("key").
+				SetValue(1),
+		},
+		[]kv.Op{
+			kv.NewSetOp("key", genProto("bar2")),
+			kv.NewSetOp("foo", genProto("bar1")),
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(r.Responses()))
+	require.Equal(t, "key", r.Responses()[0].Key())
+	require.Equal(t, kv.OpSet, r.Responses()[0].Type())
+	require.Equal(t, 2, r.Responses()[0].Value())
+	require.Equal(t, "foo", r.Responses()[1].Key())
+	require.Equal(t, 1, r.Responses()[1].Value())
+
+	// A failed condition applies none of the ops.
+	_, err = store.Commit(
+		[]kv.Condition{
+			kv.NewCondition().
+				SetCompareType(kv.CompareEqual).
+				SetTargetType(kv.TargetVersion).
+				SetKey("key").
+				SetValue(1),
+		},
+		[]kv.Op{
+			kv.NewSetOp("foo", genProto("bar2")),
+			kv.NewSetOp("key", genProto("bar3")),
+		},
+	)
+	require.Equal(t, kv.ErrConditionCheckFailed, err)
+
+	value, err := store.Get("foo")
+	require.NoError(t, err)
+	verifyValue(t, value, "bar1", 1)
+
+	_, err = store.Commit(nil, []kv.Op{
+		kv.NewSetOp("foo", genProto("bar2")),
+		kv.NewSetOp("foo", genProto("bar3")),
+	})
+	require.Equal(t, errDuplicateKeyInTxn, err)
+
+	_, err = store.Commit(
+		[]kv.Condition{kv.NewCondition().SetTargetType(kv.TargetVersion).SetKey("foo")},
+		[]kv.Op{kv.NewSetOp("foo", genProto("bar2"))},
+	)
+	require.Equal(t, kv.ErrUnknownCompareType, err)
+}
+
+func TestPrefix(t *testing.T) {
+	_, opts, closer := testStore(t)
+	defer closer()
+
+	s1, err := NewStore(opts.SetPrefix("a"))
+	require.NoError(t, err)
+	s2, err := NewStore(opts.SetPrefix("b"))
+	require.NoError(t, err)
+	s3, err := NewStore(opts.SetPrefix("a"))
+	require.NoError(t, err)
+
+	_, err = s1.Set("foo", genProto("bar1"))
+	require.NoError(t, err)
+
+	_, err = s2.Get("foo")
+	require.Equal(t, kv.ErrNotFound, err)
+
+	value, err := s3.Get("foo")
+	require.NoError(t, err)
+	verifyValue(t, value, "bar1", 1)
+}
+
+func TestPersistence(t *testing.T) {
+	s, opts, closer := testStore(t)
+	defer closer()
+
+	_, err := s.Set("foo", genProto("bar1"))
+	require.NoError(t, err)
+	_, err = s.Set("foo", genProto("bar2"))
+	require.NoError(t, err)
+	_, err = s.Set("other", genProto("bar1"))
+	require.NoError(t, err)
+	_, err = s.Delete("other")
+	require.NoError(t, err)
+
+	path, err := filepath.Abs(opts.FilePath())
+	require.NoError(t, err)
+	require.NoError(t, s.(*store).db.close())
+	db, err := newDatabase(path, opts)
+	require.NoError(t, err)
+	defer db.close()
+	reopened := &store{db: db, opts: opts}
+
+	value, err := reopened.Get("foo")
+	require.NoError(t, err)
+	verifyValue(t, value, "bar2", 2)
+
+	_, err = reopened.Get("other")
+	require.Equal(t, kv.ErrNotFound, err)
+
+	version, err := reopened.Set("foo", genProto("bar3"))
+	require.NoError(t, err)
+	require.Equal(t, 3, version)
+
+	value, err = reopened.Get("foo")
+	require.NoError(t, err)
+	require.True(t, value.IsNewer(db.state.Values["foo"][0]))
+}
+
+func TestFileLockedByAnotherProcess(t *testing.T) {
+	dir, err := ioutil.TempDir("", "file-kv")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// The lock is held per open file, taking it on another open file fails
+	// as it would in another process.
+	path := filepath.Join(dir, "kv.json")
+	held, err := lockFile(path + lockFileSuffix)
+	require.NoError(t, err)
+
+	_, err = NewStore(NewOptions().SetFilePath(path))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "in use by another process")
+
+	require.NoError(t, held.Close())
+	s, err := NewStore(NewOptions().SetFilePath(path))
+	require.NoError(t, err)
+	require.NoError(t, s.(*store).db.close())
+}
+
+func TestValidateOptions(t *testing.T) {
+	require.Equal(t, errNoFilePath, NewOptions().Validate())
+	require.Equal(t, errInvalidMaxVersions, NewOptions().SetFilePath("kv.json").SetMaxVersions(0).Validate())
+	require.NoError(t, NewOptions().SetFilePath("kv.json").Validate())
+
+	_, err := NewStore(NewOptions())
+	require.Equal(t, errNoFilePath, err)
+}
+
+func verifyValue(t *testing.T, v kv.Value, value string, version int) {
+	var testMsg kvtest.Foo
+	err := v.Unmarshal(&testMsg)
+	require.NoError(t, err)
+	require.Equal(t, value, testMsg.Msg)
+	require.Equal(t, version, v.Version())
+}
+
+func genProto(msg string) proto.Message {
+	return &kvtest.Foo{Msg: msg}
+}
+
+func testStore(t *testing.T) (kv.TxnStore, Options, func()) {
+	dir, err := ioutil.TempDir("", "file-kv")
+	require.NoError(t, err)
+
+	opts := NewOptions().SetFilePath(filepath.Join(dir, "data", "kv.json"))
+	store, err := NewStore(opts)
+	require.NoError(t, err)
+
+	return store, opts, func() {
+		os.RemoveAll(dir)
+	}
+}
@@ -0,0 +1,333 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package history keeps a bounded history of the versions of a KV key, so
+// values overwritten in KV can be inspected and rolled back to. Every version
+// is kept under its own KV key, next to an index of the versions kept, so the
+// size of a single KV value stays bounded by the size of the values tracked.
+package history
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/generated/proto/historypb"
+	"github.com/m3db/m3/src/cluster/kv"
+
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	// KeySuffix is appended to a key to get the key holding the index of its
+	// history, the entries are kept under the index key followed by
+	// a slash and their sequence number.
+	KeySuffix = "/_history"
+)
+
+var (
+	// ErrVersionNotFound is returned when a version is not kept in the history.
+	ErrVersionNotFound = errors.New("version is not in the history")
+)
+
+// Metadata describes who made a change and why.
+type Metadata struct {
+	Operator string
+	Reason   string
+}
+
+// Entry is a version of a value kept in the history, or the deletion of
+// the key if Deleted is set. UpdatedAt is zero for the value recorded before
+// the first tracked change as it is unknown when it was written.
+type Entry struct {
+	Version   int
+	Value     []byte
+	Deleted   bool
+	UpdatedAt time.Time
+	Metadata  Metadata
+}
+
+// Unmarshal unmarshals the value of the entry.
+func (e Entry) Unmarshal(v proto.Message) error {
+	return proto.Unmarshal(e.Value, v)
+}
+
+// CurrentFn returns the value a key currently holds and its version.
+type CurrentFn func() (proto.Message, int, error)
+
+// Store keeps a bounded history of the versions of a key.
+type Store interface {
+	// AppendCurrent adds the value the key currently holds to the history if
+	// the history is empty, so the value from before the first tracked change
+	// can be rolled back to. It must be called before the key is changed, the
+	// current function returns the value and its version or kv.ErrNotFound.
+	AppendCurrent(current CurrentFn) error
+
+	// Append adds a version of the value to the history, dropping the oldest
+	// entries beyond the history limit.
+	Append(version int, value proto.Message, meta Metadata) error
+
+	// AppendDelete adds the deletion of the given version of the value to
+	// the history, dropping the oldest entries beyond the history limit.
+	AppendDelete(version int, meta Metadata) error
+
+	// Entries returns the entries kept in the history, oldest first.
+	Entries() ([]Entry, error)
+
+	// Entry returns the given version from the history. The versions of a key
+	// start over once it is deleted and set again, the most recent entry of
+	// the version is returned.
+	Entry(version int) (Entry, error)
+}
+
+type store struct {
+	store kv.Store
+	key   string
+	opts  Options
+}
+
+// NewStore creates a Store keeping the history of the key in the kv.Store.
+func NewStore(kvStore kv.Store, key string, opts Options) Store {
+	if opts == nil {
+		opts = NewOptions()
+	}
+	return &store{
+		store: kvStore,
+		key:   key + KeySuffix,
+		opts:  opts,
+	}
+}
+
+func (s *store) AppendCurrent(current CurrentFn) error {
+	h, _, err := s.history()
+	if err != nil {
+		return err
+	}
+	if len(h.Entries) > 0 {
+		return nil
+	}
+
+	value, version, err := current()
+	if err == kv.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	b, err := proto.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return s.append(&historypb.Entry{
+		Version: int64(version),
+		Value:   b,
+	}, true)
+}
+
+func (s *store) Append(version int, value proto.Message, meta Metadata) error {
+	b, err := proto.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return s.append(&historypb.Entry{
+		Version:        int64(version),
+		Value:          b,
+		UpdatedAtNanos: s.opts.NowFn()().UnixNano(),
+		Operator:       meta.Operator,
+		Reason:         meta.Reason,
+	}, false)
+}
+
+func (s *store) AppendDelete(version int, meta Metadata) error {
+	return s.append(&historypb.Entry{
+		Version:        int64(version),
+		Deleted:        true,
+		UpdatedAtNanos: s.opts.NowFn()().UnixNano(),
+		Operator:       meta.Operator,
+		Reason:         meta.Reason,
+	}, false)
+}
+
+// append reserves the next sequence number for the entry in the index and
+// then writes the entry under it, the index only keeps the metadata of the
+// entries. If onlyIfEmpty is set the entry is only added to an empty history.
+func (s *store) append(entry *historypb.Entry, onlyIfEmpty bool) error {
+	for {
+		h, historyVersion, err := s.history()
+		if err != nil {
+			return err
+		}
+		if onlyIfEmpty && len(h.Entries) > 0 {
+			return nil
+		}
+
+		entry.Sequence = h.NextSequence
+		h.NextSequence++
+
+		indexEntry := *entry
+		indexEntry.Value = nil
+
+		var dropped []*historypb.Entry
+		h.Entries, dropped = appendEntry(h.Entries, &indexEntry, s.opts.Limit())
+		if historyVersion == kv.UninitializedVersion {
+			_, err = s.store.SetIfNotExists(s.key, h)
+			if err == kv.ErrAlreadyExists {
+				continue
+			}
+		} else {
+			_, err = s.store.CheckAndSet(s.key, historyVersion, h)
+			if err == kv.ErrVersionMismatch {
+				continue
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		if !entry.Deleted {
+			if _, err := s.store.Set(s.sequenceKey(entry.Sequence), entry); err != nil {
+				return err
+			}
+		}
+		return s.deleteEntries(dropped)
+	}
+}
+
+func (s *store) Entries() ([]Entry, error) {
+	h, _, err := s.history()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(h.Entries))
+	for _, e := range h.Entries {
+		entry, err := s.entry(e)
+		if err == ErrVersionNotFound {
+			// The entry is not written yet or was dropped by a concurrent append.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (s *store) Entry(version int) (Entry, error) {
+	h, _, err := s.history()
+	if err != nil {
+		return Entry{}, err
+	}
+
+	for i := len(h.Entries) - 1; i >= 0; i-- {
+		e := h.Entries[i]
+		if int(e.Version) == version && !e.Deleted {
+			return s.entry(e)
+		}
+	}
+	return Entry{}, ErrVersionNotFound
+}
+
+// entry returns the entry described by an entry of the index.
+func (s *store) entry(indexEntry *historypb.Entry) (Entry, error) {
+	if indexEntry.Deleted {
+		return entryFromProto(indexEntry), nil
+	}
+
+	v, err := s.store.Get(s.sequenceKey(indexEntry.Sequence))
+	if err == kv.ErrNotFound {
+		return Entry{}, ErrVersionNotFound
+	}
+	if err != nil {
+		return Entry{}, err
+	}
+
+	var e historypb.Entry
+	if err := v.Unmarshal(&e); err != nil {
+		return Entry{}, err
+	}
+	return entryFromProto(&e), nil
+}
+
+func (s *store) deleteEntries(entries []*historypb.Entry) error {
+	for _, e := range entries {
+		if e.Deleted {
+			continue
+		}
+		if _, err := s.store.Delete(s.sequenceKey(e.Sequence)); err != nil && err != kv.ErrNotFound {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *store) sequenceKey(sequence int64) string {
+	return fmt.Sprintf("%s/%d", s.key, sequence)
+}
+
+func (s *store) history() (*historypb.History, int, error) {
+	v, err := s.store.Get(s.key)
+	if err == kv.ErrNotFound {
+		return &historypb.History{}, kv.UninitializedVersion, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var h historypb.History
+	if err := v.Unmarshal(&h); err != nil {
+		return nil, 0, err
+	}
+	return &h, v.Version(), nil
+}
+
+// appendEntry appends the entry to the entries, returning the entries kept
+// and the entries dropped beyond the limit.
+func appendEntry(
+	entries []*historypb.Entry,
+	entry *historypb.Entry,
+	limit int,
+) ([]*historypb.Entry, []*historypb.Entry) {
+	kept := append(entries, entry)
+	if limit > 0 && len(kept) > limit {
+		return kept[len(kept)-limit:], kept[:len(kept)-limit]
+	}
+	return kept, nil
+}
+
+func entryFromProto(e *historypb.Entry) Entry {
+	var updatedAt time.Time
+	if e.UpdatedAtNanos != 0 {
+		updatedAt = time.Unix(0, e.UpdatedAtNanos)
+	}
+	return Entry{
+		Version:   int(e.Version),
+		Value:     e.Value,
+		Deleted:   e.Deleted,
+		UpdatedAt: updatedAt,
+		Metadata: Metadata{
+			Operator: e.Operator,
+			Reason:   e.Reason,
+		},
+	}
+}
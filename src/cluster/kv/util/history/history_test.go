@@ -0,0 +1,191 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/generated/proto/commonpb"
+	"github.com/m3db/m3/src/cluster/generated/proto/historypb"
+	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/cluster/kv/mem"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreAppend(t *testing.T) {
+	now := time.Unix(100, 0)
+	opts := NewOptions().
+		SetLimit(2).
+		SetNowFn(func() time.Time { return now })
+	s := NewStore(mem.NewStore(), "key", opts)
+
+	_, err := s.Entry(1)
+	assert.Equal(t, ErrVersionNotFound, err)
+
+	meta := Metadata{Operator: "op", Reason: "test"}
+	for i := 1; i <= 3; i++ {
+		require.NoError(t, s.Append(i, &commonpb.Int64Proto{Value: int64(i)}, meta))
+	}
+
+	entries, err := s.Entries()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(entries))
+	for i, entry := range entries {
+		assert.Equal(t, i+2, entry.Version)
+		assert.Equal(t, meta, entry.Metadata)
+		assert.True(t, now.Equal(entry.UpdatedAt))
+
+		var v commonpb.Int64Proto
+		require.NoError(t, entry.Unmarshal(&v))
+		assert.Equal(t, int64(i+2), v.Value)
+	}
+
+	_, err = s.Entry(1)
+	assert.Equal(t, ErrVersionNotFound, err)
+	entry, err := s.Entry(3)
+	require.NoError(t, err)
+	assert.Equal(t, 3, entry.Version)
+}
+
+func TestStoreAppendCurrent(t *testing.T) {
+	kvStore := mem.NewStore()
+	s := NewStore(kvStore, "key", nil)
+	current := func() (proto.Message, int, error) {
+		v, err := kvStore.Get("key")
+		if err != nil {
+			return nil, 0, err
+		}
+		var value commonpb.StringProto
+		if err := v.Unmarshal(&value); err != nil {
+			return nil, 0, err
+		}
+		return &value, v.Version(), nil
+	}
+
+	// Nothing is recorded while the key is not set.
+	require.NoError(t, s.AppendCurrent(current))
+	entries, err := s.Entries()
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(entries))
+
+	_, err = kvStore.Set("key", &commonpb.StringProto{Value: "a"})
+	require.NoError(t, err)
+	require.NoError(t, s.AppendCurrent(current))
+	require.NoError(t, s.Append(2, &commonpb.StringProto{Value: "b"}, Metadata{}))
+
+	// The current value is only recorded before the first tracked change.
+	_, err = kvStore.Set("key", &commonpb.StringProto{Value: "b"})
+	require.NoError(t, err)
+	require.NoError(t, s.AppendCurrent(current))
+
+	entries, err = s.Entries()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(entries))
+	assert.Equal(t, 1, entries[0].Version)
+	assert.True(t, entries[0].UpdatedAt.IsZero())
+	assert.Equal(t, Metadata{}, entries[0].Metadata)
+
+	entry, err := s.Entry(1)
+	require.NoError(t, err)
+	var v commonpb.StringProto
+	require.NoError(t, entry.Unmarshal(&v))
+	assert.Equal(t, "a", v.Value)
+}
+
+func TestStoreAppendAfterKeyRecreated(t *testing.T) {
+	kvStore := mem.NewStore()
+	s := NewStore(kvStore, "key", nil)
+
+	meta := Metadata{Operator: "op", Reason: "delete"}
+	require.NoError(t, s.Append(1, &commonpb.StringProto{Value: "a"}, Metadata{}))
+	require.NoError(t, s.Append(2, &commonpb.StringProto{Value: "b"}, Metadata{}))
+	require.NoError(t, s.AppendDelete(2, meta))
+	require.NoError(t, s.Append(1, &commonpb.StringProto{Value: "c"}, Metadata{}))
+
+	entries, err := s.Entries()
+	require.NoError(t, err)
+	require.Equal(t, 4, len(entries))
+	assert.True(t, entries[2].Deleted)
+	assert.Equal(t, 2, entries[2].Version)
+	assert.Equal(t, meta, entries[2].Metadata)
+	assert.Nil(t, entries[2].Value)
+
+	// The most recent entry of a version is returned.
+	entry, err := s.Entry(1)
+	require.NoError(t, err)
+	var v commonpb.StringProto
+	require.NoError(t, entry.Unmarshal(&v))
+	assert.Equal(t, "c", v.Value)
+
+	// Versions from before the key was deleted can still be rolled back to.
+	entry, err = s.Entry(2)
+	require.NoError(t, err)
+	assert.False(t, entry.Deleted)
+	require.NoError(t, entry.Unmarshal(&v))
+	assert.Equal(t, "b", v.Value)
+
+	// The history is kept under its own key.
+	_, err = kvStore.Get("key")
+	assert.Equal(t, kv.ErrNotFound, err)
+	_, err = kvStore.Get("key" + KeySuffix)
+	assert.NoError(t, err)
+}
+
+func TestStoreKeepsVersionsUnderSeparateKeys(t *testing.T) {
+	kvStore := mem.NewStore()
+	s := NewStore(kvStore, "key", NewOptions().SetLimit(2))
+
+	for i := 1; i <= 3; i++ {
+		require.NoError(t, s.Append(i, &commonpb.Int64Proto{Value: int64(i)}, Metadata{}))
+	}
+
+	// The index only holds the metadata of the versions kept.
+	v, err := kvStore.Get("key" + KeySuffix)
+	require.NoError(t, err)
+	var h historypb.History
+	require.NoError(t, v.Unmarshal(&h))
+	require.Equal(t, 2, len(h.Entries))
+	for i, e := range h.Entries {
+		assert.Equal(t, int64(i+2), e.Version)
+		assert.Nil(t, e.Value)
+	}
+
+	// Every version is kept under the key of its sequence number, and dropped
+	// versions are deleted.
+	_, err = kvStore.Get("key" + KeySuffix + "/0")
+	assert.Equal(t, kv.ErrNotFound, err)
+	for i := 2; i <= 3; i++ {
+		v, err := kvStore.Get(fmt.Sprintf("key%s/%d", KeySuffix, i-1))
+		require.NoError(t, err)
+		var e historypb.Entry
+		require.NoError(t, v.Unmarshal(&e))
+		assert.Equal(t, int64(i), e.Version)
+
+		var value commonpb.Int64Proto
+		require.NoError(t, proto.Unmarshal(e.Value, &value))
+		assert.Equal(t, int64(i), value.Value)
+	}
+}
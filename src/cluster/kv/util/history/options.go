@@ -0,0 +1,80 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"time"
+
+	"github.com/m3db/m3/src/x/clock"
+)
+
+const (
+	// DefaultLimit is the default maximum number of versions kept in the history.
+	DefaultLimit = 10
+)
+
+// Options provide a set of history options.
+type Options interface {
+	// SetLimit sets the maximum number of versions kept in the history.
+	SetLimit(value int) Options
+
+	// Limit returns the maximum number of versions kept in the history.
+	Limit() int
+
+	// SetNowFn sets the function to get time now.
+	SetNowFn(value clock.NowFn) Options
+
+	// NowFn returns the function to get time now.
+	NowFn() clock.NowFn
+}
+
+type options struct {
+	limit int
+	nowFn clock.NowFn
+}
+
+// NewOptions creates a new set of history options.
+func NewOptions() Options {
+	return &options{
+		limit: DefaultLimit,
+		nowFn: time.Now,
+	}
+}
+
+func (o *options) SetLimit(value int) Options {
+	opts := *o
+	opts.limit = value
+	return &opts
+}
+
+func (o *options) Limit() int {
+	return o.limit
+}
+
+func (o *options) SetNowFn(value clock.NowFn) Options {
+	opts := *o
+	opts.nowFn = value
+	return &opts
+}
+
+func (o *options) NowFn() clock.NowFn {
+	return o.nowFn
+}
@@ -0,0 +1,279 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package runtime
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/generated/proto/commonpb"
+	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/cluster/kv/util"
+
+	"github.com/golang/protobuf/proto"
+)
+
+var (
+	errUnknownOptionType = errors.New("unknown option type")
+	errNoOptionKey       = errors.New("option has no key")
+)
+
+// OptionType is the type of the value of a runtime option.
+type OptionType int
+
+// List of supported option types.
+const (
+	UnknownOptionType OptionType = iota
+	BoolOptionType
+	Int64OptionType
+	Float64OptionType
+	StringOptionType
+	DurationOptionType
+)
+
+func (t OptionType) String() string {
+	switch t {
+	case BoolOptionType:
+		return "bool"
+	case Int64OptionType:
+		return "int64"
+	case Float64OptionType:
+		return "float64"
+	case StringOptionType:
+		return "string"
+	case DurationOptionType:
+		return "duration"
+	default:
+		return "unknown"
+	}
+}
+
+// ValidateFn validates the value of a runtime option.
+type ValidateFn func(value interface{}) error
+
+// OptionDefinition declares a runtime option kept in KV. Values are stored
+// with the commonpb protos read by the kv util watches, durations as
+// nanoseconds in an Int64Proto, and are handled as bool, int64, float64,
+// string and time.Duration respectively.
+type OptionDefinition struct {
+	// Key is the KV key holding the value of the option.
+	Key string
+
+	// Type is the type of the value of the option.
+	Type OptionType
+
+	// Description describes what the option controls.
+	Description string
+
+	// Default is the value used while the key is not set in KV.
+	Default interface{}
+
+	// ValidateFn optionally validates values of the option.
+	ValidateFn ValidateFn
+}
+
+// Validate checks the definition declares a key, a known type and a valid
+// default value.
+func (d OptionDefinition) Validate() error {
+	if d.Key == "" {
+		return errNoOptionKey
+	}
+	if err := d.ValidateValue(d.Default); err != nil {
+		return fmt.Errorf("invalid definition of option %s: %v", d.Key, err)
+	}
+	return nil
+}
+
+// ValidateValue checks the value has the type of the option and passes the
+// validation of the option.
+func (d OptionDefinition) ValidateValue(value interface{}) error {
+	var ok bool
+	switch d.Type {
+	case BoolOptionType:
+		_, ok = value.(bool)
+	case Int64OptionType:
+		_, ok = value.(int64)
+	case Float64OptionType:
+		_, ok = value.(float64)
+	case StringOptionType:
+		_, ok = value.(string)
+	case DurationOptionType:
+		_, ok = value.(time.Duration)
+	default:
+		return errUnknownOptionType
+	}
+
+	if !ok {
+		return fmt.Errorf("invalid value for option %s: expected %s, got %T", d.Key, d.Type, value)
+	}
+
+	if d.ValidateFn != nil {
+		if err := d.ValidateFn(value); err != nil {
+			return fmt.Errorf("invalid value for option %s: %v", d.Key, err)
+		}
+	}
+	return nil
+}
+
+// ParseValue converts a value decoded from JSON to the type of the option and
+// validates it. Values may also be given as strings, with durations in the
+// form accepted by time.ParseDuration or as a number of nanoseconds.
+func (d OptionDefinition) ParseValue(value interface{}) (interface{}, error) {
+	parsed, err := d.parseValue(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for option %s: %v", d.Key, err)
+	}
+	if err := d.ValidateValue(parsed); err != nil {
+		return nil, err
+	}
+	return parsed, nil
+}
+
+func (d OptionDefinition) parseValue(value interface{}) (interface{}, error) {
+	switch d.Type {
+	case BoolOptionType:
+		if v, ok := value.(string); ok {
+			return strconv.ParseBool(v)
+		}
+	case Int64OptionType:
+		switch v := value.(type) {
+		case float64:
+			return float64ToInt64(v)
+		case int:
+			return int64(v), nil
+		case string:
+			return strconv.ParseInt(v, 10, 64)
+		}
+	case Float64OptionType:
+		switch v := value.(type) {
+		case int:
+			return float64(v), nil
+		case int64:
+			return float64(v), nil
+		case string:
+			return strconv.ParseFloat(v, 64)
+		}
+	case DurationOptionType:
+		switch v := value.(type) {
+		case float64:
+			nanos, err := float64ToInt64(v)
+			return time.Duration(nanos), err
+		case int64:
+			return time.Duration(v), nil
+		case string:
+			return time.ParseDuration(v)
+		}
+	}
+	return value, nil
+}
+
+// FormatValue returns the value in a form accepted by ParseValue that is
+// readable once encoded to JSON, durations are formatted as strings.
+func (d OptionDefinition) FormatValue(value interface{}) interface{} {
+	if v, ok := value.(time.Duration); ok {
+		return v.String()
+	}
+	return value
+}
+
+// Marshal returns the proto message storing the value in KV.
+func (d OptionDefinition) Marshal(value interface{}) (proto.Message, error) {
+	if err := d.ValidateValue(value); err != nil {
+		return nil, err
+	}
+
+	switch d.Type {
+	case BoolOptionType:
+		return &commonpb.BoolProto{Value: value.(bool)}, nil
+	case Int64OptionType:
+		return &commonpb.Int64Proto{Value: value.(int64)}, nil
+	case Float64OptionType:
+		return &commonpb.Float64Proto{Value: value.(float64)}, nil
+	case StringOptionType:
+		return &commonpb.StringProto{Value: value.(string)}, nil
+	case DurationOptionType:
+		return &commonpb.Int64Proto{Value: int64(value.(time.Duration))}, nil
+	default:
+		return nil, errUnknownOptionType
+	}
+}
+
+// Unmarshal returns the value of the option stored in the KV value.
+func (d OptionDefinition) Unmarshal(v kv.Value) (interface{}, error) {
+	switch d.Type {
+	case BoolOptionType:
+		var p commonpb.BoolProto
+		err := v.Unmarshal(&p)
+		return p.Value, err
+	case Int64OptionType:
+		var p commonpb.Int64Proto
+		err := v.Unmarshal(&p)
+		return p.Value, err
+	case Float64OptionType:
+		var p commonpb.Float64Proto
+		err := v.Unmarshal(&p)
+		return p.Value, err
+	case StringOptionType:
+		var p commonpb.StringProto
+		err := v.Unmarshal(&p)
+		return p.Value, err
+	case DurationOptionType:
+		var p commonpb.Int64Proto
+		err := v.Unmarshal(&p)
+		return time.Duration(p.Value), err
+	default:
+		return nil, errUnknownOptionType
+	}
+}
+
+// Watch watches the option in the store and calls updateFn with its value on
+// every change, and with the default value once the key is deleted. Malformed
+// or invalid values are not applied. The default value overrides the default
+// of the definition so processes can fall back to their configured value. If
+// the lock is not nil updateFn is called holding it.
+func (d OptionDefinition) Watch(
+	store kv.Store,
+	defaultValue interface{},
+	updateFn func(value interface{}),
+	lock sync.Locker,
+	opts util.Options,
+) (kv.ValueWatch, error) {
+	if err := d.ValidateValue(defaultValue); err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = util.NewOptions()
+	}
+
+	return util.WatchAndUpdateGeneric(store, d.Key, d.Unmarshal, updateFn,
+		lock, defaultValue, opts.SetValidateFn(d.ValidateValue))
+}
+
+func float64ToInt64(v float64) (int64, error) {
+	if v != math.Trunc(v) || v > math.MaxInt64 || v < math.MinInt64 {
+		return 0, fmt.Errorf("%v is not an integer", v)
+	}
+	return int64(v), nil
+}
@@ -0,0 +1,234 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package runtime
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/kv/mem"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptionDefinitionValidate(t *testing.T) {
+	require.Equal(t, errNoOptionKey, OptionDefinition{Type: BoolOptionType}.Validate())
+	require.Error(t, OptionDefinition{Key: "k", Default: true}.Validate())
+	require.Error(t, OptionDefinition{Key: "k", Type: Int64OptionType, Default: 1}.Validate())
+	require.Error(t, testPositiveInt64Option().ValidateValue(int64(0)))
+	require.NoError(t, testPositiveInt64Option().Validate())
+}
+
+func TestOptionDefinitionParseValue(t *testing.T) {
+	tests := []struct {
+		def      OptionDefinition
+		input    interface{}
+		expected interface{}
+	}{
+		{testOption(BoolOptionType, false), true, true},
+		{testOption(BoolOptionType, false), "true", true},
+		{testOption(Int64OptionType, int64(0)), float64(12), int64(12)},
+		{testOption(Int64OptionType, int64(0)), "12", int64(12)},
+		{testOption(Float64OptionType, float64(0)), 1.5, 1.5},
+		{testOption(Float64OptionType, float64(0)), "1.5", 1.5},
+		{testOption(StringOptionType, ""), "foo", "foo"},
+		{testOption(DurationOptionType, time.Duration(0)), "10s", 10 * time.Second},
+		{testOption(DurationOptionType, time.Duration(0)), float64(1000), time.Microsecond},
+	}
+	for _, test := range tests {
+		value, err := test.def.ParseValue(test.input)
+		require.NoError(t, err)
+		require.Equal(t, test.expected, value)
+	}
+
+	invalid := []struct {
+		def   OptionDefinition
+		input interface{}
+	}{
+		{testOption(BoolOptionType, false), 1.0},
+		{testOption(Int64OptionType, int64(0)), 1.5},
+		{testOption(Int64OptionType, int64(0)), "foo"},
+		{testOption(StringOptionType, ""), true},
+		{testOption(DurationOptionType, time.Duration(0)), "10"},
+		{testPositiveInt64Option(), float64(-1)},
+	}
+	for _, test := range invalid {
+		_, err := test.def.ParseValue(test.input)
+		require.Error(t, err)
+	}
+}
+
+func TestOptionDefinitionMarshalRoundTrip(t *testing.T) {
+	store := mem.NewStore()
+	for i, test := range []struct {
+		def   OptionDefinition
+		value interface{}
+	}{
+		{testOption(BoolOptionType, false), true},
+		{testOption(Int64OptionType, int64(0)), int64(42)},
+		{testOption(Float64OptionType, float64(0)), 0.5},
+		{testOption(StringOptionType, ""), "foo"},
+		{testOption(DurationOptionType, time.Duration(0)), time.Minute},
+	} {
+		msg, err := test.def.Marshal(test.value)
+		require.NoError(t, err)
+
+		_, err = store.Set(test.def.Key, msg)
+		require.NoError(t, err, "test %d", i)
+
+		v, err := store.Get(test.def.Key)
+		require.NoError(t, err)
+
+		value, err := test.def.Unmarshal(v)
+		require.NoError(t, err)
+		require.Equal(t, test.value, value)
+	}
+
+	_, err := testOption(Int64OptionType, int64(0)).Marshal("foo")
+	require.Error(t, err)
+}
+
+func TestOptionDefinitionFormatValue(t *testing.T) {
+	def := testOption(DurationOptionType, time.Duration(0))
+	formatted := def.FormatValue(90 * time.Second)
+	require.Equal(t, "1m30s", formatted)
+
+	value, err := def.ParseValue(formatted)
+	require.NoError(t, err)
+	require.Equal(t, 90*time.Second, value)
+}
+
+func TestOptionDefinitionWatch(t *testing.T) {
+	var (
+		store   = mem.NewStore()
+		def     = testPositiveInt64Option()
+		updates = make(chan interface{}, 10)
+	)
+	_, err := def.Watch(store, int64(5), func(value interface{}) {
+		updates <- value
+	}, nil, nil)
+	require.NoError(t, err)
+
+	_, err = store.Set(def.Key, mustMarshal(t, def, int64(10)))
+	require.NoError(t, err)
+	waitForUpdate(t, updates, int64(10))
+
+	// Invalid values are not applied.
+	_, err = store.Set(def.Key, mustMarshal(t, testOption(Int64OptionType, int64(0)), int64(-1)))
+	require.NoError(t, err)
+	_, err = store.Set(def.Key, mustMarshal(t, def, int64(20)))
+	require.NoError(t, err)
+	for value := range waitForUpdate(t, updates, int64(20)) {
+		require.NotEqual(t, int64(-1), value)
+	}
+
+	// Deleting the key applies the default.
+	_, err = store.Delete(def.Key)
+	require.NoError(t, err)
+	waitForUpdate(t, updates, int64(5))
+
+	_, err = def.Watch(store, int64(0), func(interface{}) {}, nil, nil)
+	require.Error(t, err)
+}
+
+func TestOptionDefinitionWatchHoldsLock(t *testing.T) {
+	var (
+		store   = mem.NewStore()
+		def     = testPositiveInt64Option()
+		lock    = &testLocker{}
+		updates = make(chan interface{}, 10)
+		held    = make(chan bool, 10)
+	)
+	_, err := def.Watch(store, int64(5), func(value interface{}) {
+		held <- lock.held
+		updates <- value
+	}, lock, nil)
+	require.NoError(t, err)
+
+	_, err = store.Set(def.Key, mustMarshal(t, def, int64(10)))
+	require.NoError(t, err)
+	waitForUpdate(t, updates, int64(10))
+	close(held)
+	for isHeld := range held {
+		require.True(t, isHeld)
+	}
+}
+
+type testLocker struct {
+	sync.Mutex
+	held bool
+}
+
+func (l *testLocker) Lock() {
+	l.Mutex.Lock()
+	l.held = true
+}
+
+func (l *testLocker) Unlock() {
+	l.held = false
+	l.Mutex.Unlock()
+}
+
+// waitForUpdate waits for the expected value, returning the values updated
+// before it since watch notifications can be coalesced.
+func waitForUpdate(t *testing.T, updates <-chan interface{}, expected interface{}) map[interface{}]struct{} {
+	seen := make(map[interface{}]struct{})
+	for {
+		select {
+		case value := <-updates:
+			if value == expected {
+				return seen
+			}
+			seen[value] = struct{}{}
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "timed out waiting for update", "expected %v", expected)
+		}
+	}
+}
+
+func testOption(optionType OptionType, defaultValue interface{}) OptionDefinition {
+	return OptionDefinition{
+		Key:     "test." + optionType.String(),
+		Type:    optionType,
+		Default: defaultValue,
+	}
+}
+
+func testPositiveInt64Option() OptionDefinition {
+	def := testOption(Int64OptionType, int64(1))
+	def.Key = "test.positive"
+	def.ValidateFn = func(value interface{}) error {
+		if value.(int64) <= 0 {
+			return errors.New("must be positive")
+		}
+		return nil
+	}
+	return def
+}
+
+func mustMarshal(t *testing.T, def OptionDefinition, value interface{}) proto.Message {
+	msg, err := def.Marshal(value)
+	require.NoError(t, err)
+	return msg
+}
@@ -0,0 +1,336 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package runtime
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/cluster/kv/util/history"
+
+	"github.com/golang/protobuf/proto"
+)
+
+var (
+	// ErrUnknownOption is returned when no option is registered with a key.
+	ErrUnknownOption = errors.New("unknown runtime option")
+
+	// ErrOptionNotSet is returned when deleting an option that is not set.
+	ErrOptionNotSet = errors.New("runtime option is not set")
+)
+
+// Registry is a set of runtime option definitions.
+type Registry interface {
+	// Register adds the definitions to the registry, failing if a definition
+	// is invalid or its key is already registered.
+	Register(defs ...OptionDefinition) error
+
+	// Definition returns the definition of the option with the key.
+	Definition(key string) (OptionDefinition, bool)
+
+	// Definitions returns the registered definitions sorted by key.
+	Definitions() []OptionDefinition
+}
+
+type registry struct {
+	sync.RWMutex
+
+	defs map[string]OptionDefinition
+}
+
+// NewRegistry creates a new registry of runtime options.
+func NewRegistry() Registry {
+	return &registry{defs: make(map[string]OptionDefinition)}
+}
+
+func (r *registry) Register(defs ...OptionDefinition) error {
+	r.Lock()
+	defer r.Unlock()
+
+	for _, def := range defs {
+		if err := def.Validate(); err != nil {
+			return err
+		}
+		if _, ok := r.defs[def.Key]; ok {
+			return fmt.Errorf("option %s is already registered", def.Key)
+		}
+	}
+
+	for _, def := range defs {
+		r.defs[def.Key] = def
+	}
+	return nil
+}
+
+func (r *registry) Definition(key string) (OptionDefinition, bool) {
+	r.RLock()
+	def, ok := r.defs[key]
+	r.RUnlock()
+	return def, ok
+}
+
+func (r *registry) Definitions() []OptionDefinition {
+	r.RLock()
+	defs := make([]OptionDefinition, 0, len(r.defs))
+	for _, def := range r.defs {
+		defs = append(defs, def)
+	}
+	r.RUnlock()
+
+	sort.Slice(defs, func(i, j int) bool {
+		return defs[i].Key < defs[j].Key
+	})
+	return defs
+}
+
+// OptionValue is the current value of a runtime option.
+type OptionValue struct {
+	Definition OptionDefinition
+
+	// Value is nil when the option is not set, processes then use their
+	// configured value.
+	Value interface{}
+
+	// Version is the KV version of the value, zero when the option is not set.
+	Version int
+}
+
+// OptionChange is a change of a runtime option kept in its audit log, the
+// option is unset by the change if Deleted is set.
+type OptionChange struct {
+	Version   int
+	Value     interface{}
+	Deleted   bool
+	UpdatedAt time.Time
+	Metadata  history.Metadata
+}
+
+// OptionStore reads and updates the runtime options of a registry in KV,
+// keeping an audit log of the changes made through it.
+type OptionStore interface {
+	// Get returns the value of the option.
+	Get(key string) (OptionValue, error)
+
+	// Set validates and sets the value of the option, recording the change
+	// in its audit log.
+	Set(key string, value interface{}, meta history.Metadata) (OptionValue, error)
+
+	// Delete unsets the option so processes fall back to their configured
+	// value, recording the change in its audit log.
+	Delete(key string, meta history.Metadata) (OptionValue, error)
+
+	// Changes returns the changes kept in the audit log of the option,
+	// oldest first.
+	Changes(key string) ([]OptionChange, error)
+}
+
+type optionStore struct {
+	store       kv.Store
+	registry    Registry
+	historyOpts history.Options
+}
+
+// NewOptionStore creates an OptionStore for the options of the registry kept
+// in the kv.Store.
+func NewOptionStore(
+	store kv.Store,
+	registry Registry,
+	historyOpts history.Options,
+) OptionStore {
+	if historyOpts == nil {
+		historyOpts = history.NewOptions()
+	}
+	return &optionStore{
+		store:       store,
+		registry:    registry,
+		historyOpts: historyOpts,
+	}
+}
+
+func (s *optionStore) Get(key string) (OptionValue, error) {
+	def, ok := s.registry.Definition(key)
+	if !ok {
+		return OptionValue{}, ErrUnknownOption
+	}
+
+	v, err := s.store.Get(key)
+	if err == kv.ErrNotFound {
+		return OptionValue{Definition: def}, nil
+	}
+	if err != nil {
+		return OptionValue{}, err
+	}
+
+	value, err := def.Unmarshal(v)
+	if err != nil {
+		return OptionValue{}, err
+	}
+	return OptionValue{Definition: def, Value: value, Version: v.Version()}, nil
+}
+
+func (s *optionStore) Set(
+	key string,
+	value interface{},
+	meta history.Metadata,
+) (OptionValue, error) {
+	def, ok := s.registry.Definition(key)
+	if !ok {
+		return OptionValue{}, ErrUnknownOption
+	}
+
+	msg, err := def.Marshal(value)
+	if err != nil {
+		return OptionValue{}, err
+	}
+
+	changes := s.changes(key)
+	if err := s.appendCurrent(changes, def); err != nil {
+		return OptionValue{}, err
+	}
+
+	version, err := s.store.Set(key, msg)
+	if err != nil {
+		return OptionValue{}, err
+	}
+
+	result := OptionValue{Definition: def, Value: value, Version: version}
+	if changes == nil {
+		return result, nil
+	}
+	if err := changes.Append(version, msg, meta); err != nil {
+		return result, fmt.Errorf("option %s set to version %d but change not recorded: %v",
+			key, version, err)
+	}
+	return result, nil
+}
+
+func (s *optionStore) Delete(key string, meta history.Metadata) (OptionValue, error) {
+	def, ok := s.registry.Definition(key)
+	if !ok {
+		return OptionValue{}, ErrUnknownOption
+	}
+
+	changes := s.changes(key)
+	if err := s.appendCurrent(changes, def); err != nil {
+		return OptionValue{}, err
+	}
+
+	deleted, err := s.store.Delete(key)
+	if err == kv.ErrNotFound {
+		return OptionValue{}, ErrOptionNotSet
+	}
+	if err != nil {
+		return OptionValue{}, err
+	}
+
+	result := OptionValue{Definition: def}
+	if changes == nil {
+		return result, nil
+	}
+	if err := changes.AppendDelete(deleted.Version(), meta); err != nil {
+		return result, fmt.Errorf("option %s deleted at version %d but change not recorded: %v",
+			key, deleted.Version(), err)
+	}
+	return result, nil
+}
+
+func (s *optionStore) Changes(key string) ([]OptionChange, error) {
+	def, ok := s.registry.Definition(key)
+	if !ok {
+		return nil, ErrUnknownOption
+	}
+
+	changesStore := s.changes(key)
+	if changesStore == nil {
+		return nil, nil
+	}
+
+	entries, err := changesStore.Entries()
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]OptionChange, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Deleted {
+			changes = append(changes, OptionChange{
+				Version:   entry.Version,
+				Deleted:   true,
+				UpdatedAt: entry.UpdatedAt,
+				Metadata:  entry.Metadata,
+			})
+			continue
+		}
+
+		value, err := def.Unmarshal(entryValue{entry: entry})
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, OptionChange{
+			Version:   entry.Version,
+			Value:     value,
+			UpdatedAt: entry.UpdatedAt,
+			Metadata:  entry.Metadata,
+		})
+	}
+	return changes, nil
+}
+
+// appendCurrent keeps the value set before the first recorded change in the
+// audit log, so it can be told apart from the configured value.
+func (s *optionStore) appendCurrent(changes history.Store, def OptionDefinition) error {
+	if changes == nil {
+		return nil
+	}
+	return changes.AppendCurrent(func() (proto.Message, int, error) {
+		v, err := s.store.Get(def.Key)
+		if err != nil {
+			return nil, 0, err
+		}
+		value, err := def.Unmarshal(v)
+		if err != nil {
+			return nil, 0, err
+		}
+		msg, err := def.Marshal(value)
+		return msg, v.Version(), err
+	})
+}
+
+// changes returns the audit log of the option, nil if no changes are kept.
+func (s *optionStore) changes(key string) history.Store {
+	if s.historyOpts.Limit() <= 0 {
+		return nil
+	}
+	return history.NewStore(s.store, key, s.historyOpts)
+}
+
+// entryValue adapts a history entry to a kv.Value to unmarshal it.
+type entryValue struct {
+	entry history.Entry
+}
+
+func (v entryValue) Unmarshal(msg proto.Message) error { return v.entry.Unmarshal(msg) }
+func (v entryValue) Version() int                      { return v.entry.Version }
+func (v entryValue) IsNewer(other kv.Value) bool       { return v.Version() > other.Version() }
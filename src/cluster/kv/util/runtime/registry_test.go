@@ -0,0 +1,177 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package runtime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/kv/mem"
+	"github.com/m3db/m3/src/cluster/kv/util/history"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+	require.NoError(t, r.Register(
+		testOption(StringOptionType, "foo"),
+		testOption(BoolOptionType, false),
+	))
+
+	// Registering is all or nothing.
+	require.Error(t, r.Register(
+		testOption(Int64OptionType, int64(1)),
+		testOption(BoolOptionType, true),
+	))
+	require.Error(t, r.Register(OptionDefinition{Key: "invalid"}))
+
+	def, ok := r.Definition("test.string")
+	require.True(t, ok)
+	require.Equal(t, "foo", def.Default)
+
+	_, ok = r.Definition("test.int64")
+	require.False(t, ok)
+
+	defs := r.Definitions()
+	require.Equal(t, 2, len(defs))
+	require.Equal(t, "test.bool", defs[0].Key)
+	require.Equal(t, "test.string", defs[1].Key)
+}
+
+func TestOptionStore(t *testing.T) {
+	r := NewRegistry()
+	def := testPositiveInt64Option()
+	require.NoError(t, r.Register(def))
+
+	now := time.Unix(1000, 0)
+	s := NewOptionStore(mem.NewStore(), r, history.NewOptions().
+		SetLimit(2).
+		SetNowFn(func() time.Time { return now }))
+
+	value, err := s.Get(def.Key)
+	require.NoError(t, err)
+	require.Nil(t, value.Value)
+	require.Equal(t, 0, value.Version)
+
+	changes, err := s.Changes(def.Key)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(changes))
+
+	meta := history.Metadata{Operator: "alice", Reason: "more"}
+	for i := 1; i <= 3; i++ {
+		value, err = s.Set(def.Key, int64(i*10), meta)
+		require.NoError(t, err)
+		require.Equal(t, i, value.Version)
+	}
+
+	_, err = s.Set(def.Key, int64(-1), meta)
+	require.Error(t, err)
+
+	value, err = s.Get(def.Key)
+	require.NoError(t, err)
+	require.Equal(t, int64(30), value.Value)
+	require.Equal(t, 3, value.Version)
+
+	changes, err = s.Changes(def.Key)
+	require.NoError(t, err)
+	require.Equal(t, []OptionChange{
+		{Version: 2, Value: int64(20), UpdatedAt: now, Metadata: meta},
+		{Version: 3, Value: int64(30), UpdatedAt: now, Metadata: meta},
+	}, changes)
+
+	_, err = s.Get("unknown")
+	require.Equal(t, ErrUnknownOption, err)
+	_, err = s.Set("unknown", int64(1), meta)
+	require.Equal(t, ErrUnknownOption, err)
+	_, err = s.Changes("unknown")
+	require.Equal(t, ErrUnknownOption, err)
+	_, err = s.Delete("unknown", meta)
+	require.Equal(t, ErrUnknownOption, err)
+}
+
+func TestOptionStoreDelete(t *testing.T) {
+	r := NewRegistry()
+	def := testPositiveInt64Option()
+	require.NoError(t, r.Register(def))
+
+	now := time.Unix(1000, 0)
+	s := NewOptionStore(mem.NewStore(), r, history.NewOptions().
+		SetNowFn(func() time.Time { return now }))
+
+	meta := history.Metadata{Operator: "alice", Reason: "revert"}
+	_, err := s.Delete(def.Key, meta)
+	require.Equal(t, ErrOptionNotSet, err)
+
+	_, err = s.Set(def.Key, int64(10), meta)
+	require.NoError(t, err)
+
+	value, err := s.Delete(def.Key, meta)
+	require.NoError(t, err)
+	require.Nil(t, value.Value)
+	require.Equal(t, 0, value.Version)
+
+	value, err = s.Get(def.Key)
+	require.NoError(t, err)
+	require.Nil(t, value.Value)
+	require.Equal(t, 0, value.Version)
+
+	changes, err := s.Changes(def.Key)
+	require.NoError(t, err)
+	require.Equal(t, []OptionChange{
+		{Version: 1, Value: int64(10), UpdatedAt: now, Metadata: meta},
+		{Version: 1, Deleted: true, UpdatedAt: now, Metadata: meta},
+	}, changes)
+}
+
+func TestOptionStoreKeepsValueBeforeFirstChange(t *testing.T) {
+	r := NewRegistry()
+	def := testPositiveInt64Option()
+	require.NoError(t, r.Register(def))
+
+	// A value set before the changes were recorded.
+	kvStore := mem.NewStore()
+	msg, err := def.Marshal(int64(5))
+	require.NoError(t, err)
+	_, err = kvStore.Set(def.Key, msg)
+	require.NoError(t, err)
+
+	s := NewOptionStore(kvStore, r, nil)
+	meta := history.Metadata{Operator: "alice"}
+	_, err = s.Set(def.Key, int64(10), meta)
+	require.NoError(t, err)
+
+	changes, err := s.Changes(def.Key)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(changes))
+	require.Equal(t, OptionChange{Version: 1, Value: int64(5)}, changes[0])
+	require.Equal(t, 2, changes[1].Version)
+	require.Equal(t, int64(10), changes[1].Value)
+	require.Equal(t, meta, changes[1].Metadata)
+
+	// No changes are recorded without a history limit.
+	s = NewOptionStore(kvStore, r, history.NewOptions().SetLimit(0))
+	_, err = s.Set(def.Key, int64(20), meta)
+	require.NoError(t, err)
+	changes, err = s.Changes(def.Key)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(changes))
+}
@@ -83,6 +83,50 @@ func (a mirroredAlgorithm) AddReplica(p placement.Placement) (placement.Placemen
 	return nil, errors.New("not supported")
 }
 
+func (a mirroredAlgorithm) RemoveReplica(p placement.Placement) (placement.Placement, error) {
+	return nil, errors.New("not supported")
+}
+
+func (a mirroredAlgorithm) SplitShards(
+	p placement.Placement,
+	numShards int,
+) (placement.Placement, error) {
+	if err := a.IsCompatibleWith(p); err != nil {
+		return nil, err
+	}
+
+	// The shards are split in place, so the instances in the same shard set
+	// keep owning the same shards.
+	return a.shardedAlgo.SplitShards(p, numShards)
+}
+
+func (a mirroredAlgorithm) Balance(
+	p placement.Placement,
+	maxMoves int,
+) (placement.Placement, error) {
+	if err := a.IsCompatibleWith(p); err != nil {
+		return nil, err
+	}
+
+	p, _, err := a.MarkAllShardsAvailable(p)
+	if err != nil {
+		return nil, err
+	}
+
+	// Shard sets are balanced as a whole, so every move on the mirror
+	// placement moves the shard on all the instances of the shard set.
+	mirrorPlacement, err := mirrorFromPlacement(p)
+	if err != nil {
+		return nil, err
+	}
+
+	if mirrorPlacement, err = a.shardedAlgo.Balance(mirrorPlacement, maxMoves); err != nil {
+		return nil, err
+	}
+
+	return placementFromMirror(mirrorPlacement, p.Instances(), p.ReplicaFactor())
+}
+
 func (a mirroredAlgorithm) RemoveInstances(
 	p placement.Placement,
 	instanceIDs []string,
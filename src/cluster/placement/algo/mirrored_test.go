@@ -21,6 +21,7 @@
 package algo
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -28,6 +29,7 @@ import (
 	"github.com/m3db/m3/src/cluster/shard"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMirrorWorkflow(t *testing.T) {
@@ -787,6 +789,53 @@ func TestMirrorReplaceAndRevertAfterCutover(t *testing.T) {
 	assert.Equal(t, ssI5.NumShardsForState(shard.Initializing), i4.Shards().NumShardsForState(shard.Initializing))
 }
 
+func TestMirrorBalance(t *testing.T) {
+	var instances []placement.Instance
+	for i := 0; i < 4; i++ {
+		instances = append(instances, placement.NewInstance().
+			SetID(fmt.Sprintf("i%d", i)).
+			SetIsolationGroup(fmt.Sprintf("r%d", i)).
+			SetEndpoint(fmt.Sprintf("endpoint%d", i)).
+			SetShardSetID(uint32(i/2+1)).
+			SetWeight(1))
+	}
+
+	ids := make([]uint32, 12)
+	for i := 0; i < len(ids); i++ {
+		ids[i] = uint32(i)
+	}
+
+	a := NewAlgorithm(placement.NewOptions().SetIsMirrored(true))
+	p, err := a.InitialPlacement(instances, ids, 2)
+	require.NoError(t, err)
+	p, _, err = a.MarkAllShardsAvailable(p)
+	require.NoError(t, err)
+
+	for _, id := range []string{"i0", "i1"} {
+		instance, ok := p.Instance(id)
+		require.True(t, ok)
+		instance.SetWeight(2)
+	}
+
+	p, err = a.Balance(p, 0)
+	require.NoError(t, err)
+	require.NoError(t, placement.Validate(p))
+	p, _, err = a.MarkAllShardsAvailable(p)
+	require.NoError(t, err)
+
+	// The instances in the same shard set own the same shards.
+	for _, pair := range [][]string{{"i0", "i1"}, {"i2", "i3"}} {
+		first, ok := p.Instance(pair[0])
+		require.True(t, ok)
+		second, ok := p.Instance(pair[1])
+		require.True(t, ok)
+		assert.Equal(t, first.Shards().AllIDs(), second.Shards().AllIDs())
+	}
+	i0, ok := p.Instance("i0")
+	require.True(t, ok)
+	assert.Equal(t, 8, i0.Shards().NumShards())
+}
+
 func TestMirrorInitError(t *testing.T) {
 	i1 := placement.NewInstance().
 		SetID("i1").
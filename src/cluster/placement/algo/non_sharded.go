@@ -74,6 +74,40 @@ func (a nonShardedAlgorithm) AddReplica(p placement.Placement) (placement.Placem
 	return p.Clone().SetReplicaFactor(p.ReplicaFactor() + 1), nil
 }
 
+func (a nonShardedAlgorithm) RemoveReplica(p placement.Placement) (placement.Placement, error) {
+	if err := a.IsCompatibleWith(p); err != nil {
+		return nil, err
+	}
+
+	if p.ReplicaFactor() <= 1 {
+		return nil, errRemoveLastReplica
+	}
+
+	return p.Clone().SetReplicaFactor(p.ReplicaFactor() - 1), nil
+}
+
+func (a nonShardedAlgorithm) SplitShards(
+	p placement.Placement,
+	numShards int,
+) (placement.Placement, error) {
+	if err := a.IsCompatibleWith(p); err != nil {
+		return nil, err
+	}
+
+	return nil, errShardsOnNonShardedAlgo
+}
+
+func (a nonShardedAlgorithm) Balance(
+	p placement.Placement,
+	maxMoves int,
+) (placement.Placement, error) {
+	if err := a.IsCompatibleWith(p); err != nil {
+		return nil, err
+	}
+
+	return nil, errShardsOnNonShardedAlgo
+}
+
 func (a nonShardedAlgorithm) RemoveInstances(
 	p placement.Placement,
 	instanceIDs []string,
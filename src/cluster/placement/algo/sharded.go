@@ -90,6 +90,50 @@ func (a shardedPlacementAlgorithm) AddReplica(p placement.Placement) (placement.
 	return tryCleanupShardState(ph.generatePlacement(), a.opts)
 }
 
+func (a shardedPlacementAlgorithm) RemoveReplica(p placement.Placement) (placement.Placement, error) {
+	if err := a.IsCompatibleWith(p); err != nil {
+		return nil, err
+	}
+
+	p, err := removeReplica(p, a.opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return tryCleanupShardState(p, a.opts)
+}
+
+func (a shardedPlacementAlgorithm) SplitShards(
+	p placement.Placement,
+	numShards int,
+) (placement.Placement, error) {
+	if err := a.IsCompatibleWith(p); err != nil {
+		return nil, err
+	}
+
+	p, err := splitShards(p, numShards, a.opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return tryCleanupShardState(p, a.opts)
+}
+
+func (a shardedPlacementAlgorithm) Balance(
+	p placement.Placement,
+	maxMoves int,
+) (placement.Placement, error) {
+	if err := a.IsCompatibleWith(p); err != nil {
+		return nil, err
+	}
+
+	p = p.Clone()
+	ph := newHelper(p, p.ReplicaFactor(), a.opts)
+	ph.balance(maxMoves)
+
+	return tryCleanupShardState(ph.generatePlacement(), a.opts)
+}
+
 func (a shardedPlacementAlgorithm) RemoveInstances(
 	p placement.Placement,
 	instanceIDs []string,
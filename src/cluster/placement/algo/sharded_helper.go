@@ -25,6 +25,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sort"
 
 	"github.com/m3db/m3/src/cluster/placement"
 	"github.com/m3db/m3/src/cluster/shard"
@@ -36,6 +37,9 @@ var (
 	errAddingInstanceAlreadyExist         = errors.New("the adding instance is already in the placement")
 	errInstanceContainsNonLeavingShards   = errors.New("the adding instance contains non leaving shards")
 	errInstanceContainsInitializingShards = errors.New("the adding instance contains initializing shards")
+	errRemoveLastReplica                  = errors.New("could not remove the last replica of the placement")
+	errSplitShardsNotAvailable            = errors.New("could not split shards while shards are not all available")
+	errSplitShardsNotContiguous           = errors.New("could not split shards, shard ids are not contiguous from 0")
 )
 
 type instanceType int
@@ -72,6 +76,11 @@ type placementHelper interface {
 	// optimize rebalances the load distribution in the cluster.
 	optimize(t optimizeType) error
 
+	// balance moves at most maxMoves shards to reduce the skew between the
+	// load and the target load of the instances, it returns the number of
+	// shards moved.
+	balance(maxMoves int) int
+
 	// generatePlacement generates a placement.
 	generatePlacement() placement.Placement
 
@@ -326,6 +335,9 @@ func (ph *helper) moveShard(candidateShard shard.Shard, from, to placement.Insta
 		case shard.Unknown, shard.Initializing:
 			from.Shards().Remove(shardID)
 			newShard.SetSourceID(candidateShard.SourceID())
+			if splitSource, ok := candidateShard.SplitSource(); ok {
+				newShard.SetSplitSource(splitSource)
+			}
 		case shard.Available:
 			candidateShard.
 				SetState(shard.Leaving).
@@ -388,10 +400,14 @@ func (ph *helper) generatePlacement() placement.Placement {
 	for _, instance := range instances {
 		shards := instance.Shards()
 		for _, s := range shards.ShardsForState(shard.Unknown) {
-			shards.Add(shard.NewShard(s.ID()).
+			newShard := shard.NewShard(s.ID()).
 				SetSourceID(s.SourceID()).
 				SetState(shard.Initializing).
-				SetCutoverNanos(ph.opts.ShardCutoverNanosFn()()))
+				SetCutoverNanos(ph.opts.ShardCutoverNanosFn()())
+			if splitSource, ok := s.SplitSource(); ok {
+				newShard.SetSplitSource(splitSource)
+			}
+			shards.Add(newShard)
 		}
 		if shardSetID := instance.ShardSetID(); shardSetID >= maxShardSetID {
 			maxShardSetID = shardSetID
@@ -534,6 +550,82 @@ func (ph *helper) optimize(t optimizeType) error {
 	}
 }
 
+// balance moves shards from the instance loaded the most above its target
+// load to the instance loaded the most below it, until no move reduces the
+// skew any further or maxMoves shards have been moved. A maxMoves of zero or
+// less does not limit the number of moves.
+func (ph *helper) balance(maxMoves int) int {
+	moves := 0
+	for maxMoves <= 0 || moves < maxMoves {
+		// A shard moved through another instance counts as two moves.
+		allowIndirect := maxMoves <= 0 || maxMoves-moves >= 2
+		moved := ph.balanceOnce(allowIndirect)
+		if moved == 0 {
+			break
+		}
+		moves += moved
+	}
+	return moves
+}
+
+// balanceOnce moves one shard between the pair of instances with the largest
+// difference in load gap and returns the number of shards moved. When no
+// shard can be moved directly, for example when every shard on the overloaded
+// instance is already owned by the isolation group of the underloaded one,
+// a shard is moved through another instance in the isolation group of the
+// overloaded instance if allowIndirect is set.
+func (ph *helper) balanceOnce(allowIndirect bool) int {
+	instances := nonLeavingInstances(ph.Instances())
+	sort.Slice(instances, func(i, j int) bool {
+		gapI, gapJ := ph.loadGap(instances[i]), ph.loadGap(instances[j])
+		if gapI != gapJ {
+			return gapI > gapJ
+		}
+		return instances[i].ID() < instances[j].ID()
+	})
+
+	var candidates [][2]placement.Instance
+	for i, from := range instances {
+		for j := len(instances) - 1; j > i; j-- {
+			to := instances[j]
+			// NB: a move only reduces the skew when the gaps differ by at
+			// least 2, otherwise the two instances would just swap gaps.
+			if ph.loadGap(from)-ph.loadGap(to) < 2 {
+				break
+			}
+			if ph.moveOneShard(from, to) {
+				return 1
+			}
+			candidates = append(candidates, [2]placement.Instance{from, to})
+		}
+	}
+	if !allowIndirect {
+		return 0
+	}
+
+	for _, c := range candidates {
+		from, to := c[0], c[1]
+		for _, through := range instances {
+			if through == from || through == to ||
+				through.IsolationGroup() != from.IsolationGroup() {
+				continue
+			}
+			// The instances in the same isolation group never own the same
+			// shard, so once through gave a shard to the underloaded
+			// instance it can always take one from the overloaded instance.
+			if ph.moveOneShard(through, to) && ph.moveOneShard(from, through) {
+				return 2
+			}
+		}
+	}
+	return 0
+}
+
+// loadGap returns how many shards the instance owns above its target load.
+func (ph *helper) loadGap(instance placement.Instance) int {
+	return loadOnInstance(instance) - ph.targetLoadForInstance(instance.ID())
+}
+
 func (ph *helper) assignLoadToInstanceSafe(addingInstance placement.Instance) error {
 	return ph.assignTargetLoad(addingInstance, func(from, to placement.Instance) bool {
 		return ph.moveOneShardInState(from, to, shard.Unknown)
@@ -862,3 +954,201 @@ func markAllShardsAvailable(
 	}
 	return p, updated, nil
 }
+
+// removeReplica removes one replica of every shard in the placement. For each
+// shard the replica still initializing is removed first since it holds the
+// least data, otherwise the replica on the most loaded instance relative to
+// its weight is removed to keep the load balanced.
+func removeReplica(
+	p placement.Placement,
+	opts placement.Options,
+) (placement.Placement, error) {
+	if p.ReplicaFactor() <= 1 {
+		return nil, errRemoveLastReplica
+	}
+
+	p = p.Clone()
+	var (
+		loads         = make(map[string]int, p.NumInstances())
+		shardReplicas = make(map[uint32][]placement.Instance, p.NumShards())
+		removed       = make(map[uint32]removedReplica, p.NumShards())
+	)
+	for _, instance := range p.Instances() {
+		loads[instance.ID()] = loadOnInstance(instance)
+		for _, s := range instance.Shards().All() {
+			if s.State() == shard.Leaving {
+				continue
+			}
+			shardReplicas[s.ID()] = append(shardReplicas[s.ID()], instance)
+		}
+	}
+
+	for _, shardID := range p.Shards() {
+		var (
+			removing      placement.Instance
+			removingShard shard.Shard
+		)
+		for _, instance := range shardReplicas[shardID] {
+			s, _ := instance.Shards().Shard(shardID)
+			if removing == nil || preferRemoving(s, instance, removingShard, removing, loads) {
+				removing, removingShard = instance, s
+			}
+		}
+		if removing == nil {
+			return nil, fmt.Errorf("could not find a replica to remove for shard %d", shardID)
+		}
+
+		removing.Shards().Remove(shardID)
+		loads[removing.ID()]--
+		removed[shardID] = removedReplica{instance: removing, shard: removingShard}
+		// The shard was moving to the instance, so the leaving replica on
+		// the source instance goes away along with it.
+		if sourceID := removingShard.SourceID(); sourceID != "" {
+			if source, ok := p.Instance(sourceID); ok {
+				source.Shards().Remove(shardID)
+			}
+		}
+	}
+
+	// Removing the replicas shard by shard can leave an instance above its
+	// share when the other replicas of its last shards were on instances
+	// already below their share, so swap the removed replicas of available
+	// shards between instances until the load can't be evened out further.
+	for swapped := true; swapped; {
+		swapped = false
+		for _, shardID := range p.Shards() {
+			r, ok := removed[shardID]
+			if !ok || r.shard.State() != shard.Available {
+				continue
+			}
+			for _, instance := range shardReplicas[shardID] {
+				if instance == r.instance {
+					continue
+				}
+				s, ok := instance.Shards().Shard(shardID)
+				if !ok || s.State() != shard.Available ||
+					!preferSwappingRemoval(instance, r.instance, loads) {
+					continue
+				}
+				instance.Shards().Remove(shardID)
+				loads[instance.ID()]--
+				r.instance.Shards().Add(r.shard)
+				loads[r.instance.ID()]++
+				removed[shardID] = removedReplica{instance: instance, shard: s}
+				swapped = true
+				break
+			}
+		}
+	}
+
+	instances := make([]placement.Instance, 0, p.NumInstances())
+	for _, instance := range p.Instances() {
+		if instance.Shards().NumShards() > 0 {
+			instances = append(instances, instance)
+		}
+	}
+	return p.
+		SetInstances(instances).
+		SetReplicaFactor(p.ReplicaFactor() - 1).
+		SetCutoverNanos(opts.PlacementCutoverNanosFn()()), nil
+}
+
+type removedReplica struct {
+	instance placement.Instance
+	shard    shard.Shard
+}
+
+// preferSwappingRemoval returns true if moving the removal of a replica from
+// the other instance to the instance reduces the sum of load^2 / weight over
+// the instances, which guarantees the swaps eventually stop.
+func preferSwappingRemoval(
+	instance placement.Instance,
+	other placement.Instance,
+	loads map[string]int,
+) bool {
+	load := uint64(2*loads[instance.ID()]-1) * uint64(other.Weight())
+	otherLoad := uint64(2*loads[other.ID()]+1) * uint64(instance.Weight())
+	return load > otherLoad
+}
+
+// preferRemoving returns true if the replica of a shard on an instance should
+// be removed rather than the replica on the other instance.
+func preferRemoving(
+	s shard.Shard,
+	instance placement.Instance,
+	otherShard shard.Shard,
+	other placement.Instance,
+	loads map[string]int,
+) bool {
+	isInit, otherIsInit := s.State() == shard.Initializing, otherShard.State() == shard.Initializing
+	if isInit != otherIsInit {
+		return isInit
+	}
+
+	// Compare load / weight without dividing.
+	load := uint64(loads[instance.ID()]) * uint64(other.Weight())
+	otherLoad := uint64(loads[other.ID()]) * uint64(instance.Weight())
+	if load != otherLoad {
+		return load > otherLoad
+	}
+	return instance.ID() < other.ID()
+}
+
+// splitShards splits every shard in the placement into numShards / NumShards
+// shards. Shard s is split into the shards s + k * NumShards, which are the
+// shards the ids owned by s hash to once the number of shards is multiplied,
+// so the new shards are placed on the instances owning s and initialize from
+// the data of s.
+func splitShards(
+	p placement.Placement,
+	numShards int,
+	opts placement.Options,
+) (placement.Placement, error) {
+	curNumShards := p.NumShards()
+	if curNumShards == 0 || numShards <= curNumShards || numShards%curNumShards != 0 {
+		return nil, fmt.Errorf(
+			"could not split %d shards into %d shards, the new number of shards must be a multiple of the current number",
+			curNumShards, numShards)
+	}
+
+	isShard := make(map[uint32]struct{}, curNumShards)
+	for _, shardID := range p.Shards() {
+		if int(shardID) >= curNumShards {
+			return nil, errSplitShardsNotContiguous
+		}
+		isShard[shardID] = struct{}{}
+	}
+	if len(isShard) != curNumShards {
+		return nil, errSplitShardsNotContiguous
+	}
+
+	p = p.Clone()
+	instances := p.Instances()
+	for _, instance := range instances {
+		if !instance.IsAvailable() {
+			return nil, errSplitShardsNotAvailable
+		}
+	}
+
+	cutoverNanos := opts.ShardCutoverNanosFn()()
+	for _, instance := range instances {
+		shards := instance.Shards()
+		for _, s := range shards.All() {
+			for child := int(s.ID()) + curNumShards; child < numShards; child += curNumShards {
+				shards.Add(shard.NewShard(uint32(child)).
+					SetState(shard.Initializing).
+					SetSplitSource(s.ID()).
+					SetCutoverNanos(cutoverNanos))
+			}
+		}
+	}
+
+	shardIDs := make([]uint32, numShards)
+	for i := range shardIDs {
+		shardIDs[i] = uint32(i)
+	}
+	return p.
+		SetInstances(instances).
+		SetShards(shardIDs).
+		SetCutoverNanos(opts.PlacementCutoverNanosFn()()), nil
+}
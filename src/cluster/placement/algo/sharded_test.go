@@ -1226,6 +1226,241 @@ func TestGoodCaseWithSimpleShardStateType(t *testing.T) {
 	verifyAllShardsInAvailableState(t, p)
 }
 
+func TestRemoveReplica(t *testing.T) {
+	var instances []placement.Instance
+	for i := 0; i < 6; i++ {
+		instances = append(instances, placement.NewEmptyInstance(
+			fmt.Sprintf("i%d", i), fmt.Sprintf("r%d", i), "z1", "endpoint", 1))
+	}
+
+	numShards := 60
+	ids := make([]uint32, numShards)
+	for i := 0; i < len(ids); i++ {
+		ids[i] = uint32(i)
+	}
+
+	a := newShardedAlgorithm(placement.NewOptions())
+	p, err := a.InitialPlacement(instances, ids, 3)
+	require.NoError(t, err)
+	p, _ = mustMarkAllShardsAsAvailable(t, p, placement.NewOptions())
+
+	p, err = a.RemoveReplica(p)
+	require.NoError(t, err)
+	assert.Equal(t, 2, p.ReplicaFactor())
+	assert.Equal(t, 6, p.NumInstances())
+	validateDistribution(t, p, 1.01)
+	verifyAllShardsInAvailableState(t, p)
+
+	p, err = a.RemoveReplica(p)
+	require.NoError(t, err)
+	assert.Equal(t, 1, p.ReplicaFactor())
+	validateDistribution(t, p, 1.01)
+
+	_, err = a.RemoveReplica(p)
+	assert.Equal(t, errRemoveLastReplica, err)
+}
+
+func TestRemoveReplicaRemovesMovingShards(t *testing.T) {
+	i1 := placement.NewEmptyInstance("i1", "r1", "", "e1", 1)
+	i1.Shards().Add(shard.NewShard(0).SetState(shard.Leaving))
+	i1.Shards().Add(shard.NewShard(1).SetState(shard.Available))
+
+	i2 := placement.NewEmptyInstance("i2", "r2", "", "e2", 1)
+	i2.Shards().Add(shard.NewShard(0).SetState(shard.Initializing).SetSourceID("i1"))
+
+	i3 := placement.NewEmptyInstance("i3", "r3", "", "e3", 1)
+	i3.Shards().Add(shard.NewShard(0).SetState(shard.Available))
+	i3.Shards().Add(shard.NewShard(1).SetState(shard.Available))
+
+	p := placement.NewPlacement().
+		SetInstances([]placement.Instance{i1, i2, i3}).
+		SetShards([]uint32{0, 1}).
+		SetReplicaFactor(2).
+		SetIsSharded(true)
+	require.NoError(t, placement.Validate(p))
+
+	a := newShardedAlgorithm(placement.NewOptions())
+	p, err := a.RemoveReplica(p)
+	require.NoError(t, err)
+	require.NoError(t, placement.Validate(p))
+	assert.Equal(t, 1, p.ReplicaFactor())
+
+	// The initializing replica of shard 0 is removed along with the leaving
+	// replica it was moving from, leaving i2 with no shards.
+	_, ok := p.Instance("i2")
+	assert.False(t, ok)
+	i1, ok = p.Instance("i1")
+	require.True(t, ok)
+	assert.Equal(t, []uint32{1}, i1.Shards().AllIDs())
+	i3, ok = p.Instance("i3")
+	require.True(t, ok)
+	assert.Equal(t, []uint32{0}, i3.Shards().AllIDs())
+}
+
+func TestSplitShards(t *testing.T) {
+	var instances []placement.Instance
+	for i := 0; i < 3; i++ {
+		instances = append(instances, placement.NewEmptyInstance(
+			fmt.Sprintf("i%d", i), fmt.Sprintf("r%d", i), "z1", "endpoint", 1))
+	}
+
+	opts := placement.NewOptions().
+		SetPlacementCutoverNanosFn(timeNanosGen(1)).
+		SetShardCutoverNanosFn(timeNanosGen(2))
+	a := newShardedAlgorithm(opts)
+	p, err := a.InitialPlacement(instances, []uint32{0, 1, 2, 3}, 2)
+	require.NoError(t, err)
+
+	_, err = a.SplitShards(p, 8)
+	assert.Equal(t, errSplitShardsNotAvailable, err)
+
+	p, _ = mustMarkAllShardsAsAvailable(t, p, opts)
+	before := p.Clone()
+
+	for _, numShards := range []int{2, 4, 6} {
+		_, err = a.SplitShards(p, numShards)
+		assert.Error(t, err)
+	}
+
+	p, err = a.SplitShards(p, 12)
+	require.NoError(t, err)
+	require.NoError(t, placement.Validate(p))
+	assert.Equal(t, 12, p.NumShards())
+	assert.Equal(t, 2, p.ReplicaFactor())
+
+	for _, instance := range p.Instances() {
+		prev, ok := before.Instance(instance.ID())
+		require.True(t, ok)
+		assert.Equal(t, 3*prev.Shards().NumShards(), instance.Shards().NumShards())
+		for _, s := range prev.Shards().All() {
+			curr, ok := instance.Shards().Shard(s.ID())
+			require.True(t, ok)
+			assert.Equal(t, shard.Available, curr.State())
+
+			for _, childID := range []uint32{s.ID() + 4, s.ID() + 8} {
+				child, ok := instance.Shards().Shard(childID)
+				require.True(t, ok)
+				assert.Equal(t, shard.Initializing, child.State())
+				assert.Equal(t, "", child.SourceID())
+				assert.Equal(t, int64(2), child.CutoverNanos())
+				splitSource, isSplit := child.SplitSource()
+				assert.True(t, isSplit)
+				assert.Equal(t, s.ID(), splitSource)
+			}
+		}
+	}
+
+	p, _ = mustMarkAllShardsAsAvailable(t, p, opts)
+	require.NoError(t, placement.Validate(p))
+	for _, instance := range p.Instances() {
+		for _, s := range instance.Shards().All() {
+			_, isSplit := s.SplitSource()
+			assert.False(t, isSplit)
+		}
+	}
+}
+
+func TestSplitShardsWithStableShardStates(t *testing.T) {
+	i1 := placement.NewEmptyInstance("i1", "r1", "", "e1", 1)
+	i2 := placement.NewEmptyInstance("i2", "r2", "", "e2", 1)
+
+	opts := placement.NewOptions().SetShardStateMode(placement.StableShardStateOnly)
+	a := newShardedAlgorithm(opts)
+	p, err := a.InitialPlacement([]placement.Instance{i1, i2}, []uint32{0, 1}, 1)
+	require.NoError(t, err)
+
+	p, err = a.SplitShards(p, 4)
+	require.NoError(t, err)
+	require.NoError(t, placement.Validate(p))
+	assert.Equal(t, 4, p.NumShards())
+	verifyAllShardsInAvailableState(t, p)
+}
+
+func TestBalance(t *testing.T) {
+	var instances []placement.Instance
+	for i := 0; i < 6; i++ {
+		instances = append(instances, placement.NewEmptyInstance(
+			fmt.Sprintf("i%d", i), fmt.Sprintf("r%d", i%3), "z1", "endpoint", 1))
+	}
+
+	numShards := 60
+	ids := make([]uint32, numShards)
+	for i := 0; i < len(ids); i++ {
+		ids[i] = uint32(i)
+	}
+
+	a := newShardedAlgorithm(placement.NewOptions())
+	p, err := a.InitialPlacement(instances, ids, 2)
+	require.NoError(t, err)
+	p, _ = mustMarkAllShardsAsAvailable(t, p, placement.NewOptions())
+
+	// Balancing a balanced placement does not move any shard.
+	balanced, err := a.Balance(p, 0)
+	require.NoError(t, err)
+	for _, instance := range balanced.Instances() {
+		assert.Equal(t, 20, instance.Shards().NumShardsForState(shard.Available))
+		assert.Equal(t, 20, instance.Shards().NumShards())
+	}
+
+	i0, ok := p.Instance("i0")
+	require.True(t, ok)
+	i0.SetWeight(3)
+
+	p, err = a.Balance(p, 5)
+	require.NoError(t, err)
+	require.NoError(t, placement.Validate(p))
+	i0, ok = p.Instance("i0")
+	require.True(t, ok)
+	assert.Equal(t, 25, loadOnInstance(i0))
+	assert.Equal(t, 5, i0.Shards().NumShardsForState(shard.Initializing))
+	p, _ = mustMarkAllShardsAsAvailable(t, p, placement.NewOptions())
+
+	p, err = a.Balance(p, 0)
+	require.NoError(t, err)
+	p, _ = mustMarkAllShardsAsAvailable(t, p, placement.NewOptions())
+	validateDistribution(t, p, 1.01)
+	i0, ok = p.Instance("i0")
+	require.True(t, ok)
+	// i0 and i3 share an isolation group, which owns every shard once, so
+	// i0 ends up with 3/4 of the shards.
+	assert.Equal(t, 45, loadOnInstance(i0))
+}
+
+func TestBalanceRespectsIsolationGroups(t *testing.T) {
+	i1 := placement.NewEmptyInstance("i1", "r1", "", "e1", 1)
+	i1.Shards().Add(shard.NewShard(0).SetState(shard.Available))
+	i1.Shards().Add(shard.NewShard(1).SetState(shard.Available))
+
+	i2 := placement.NewEmptyInstance("i2", "r2", "", "e2", 1)
+	i2.Shards().Add(shard.NewShard(0).SetState(shard.Available))
+	i2.Shards().Add(shard.NewShard(1).SetState(shard.Available))
+
+	// i3 is underloaded, but shares an isolation group with i2 which
+	// already owns every shard.
+	i3 := placement.NewEmptyInstance("i3", "r2", "", "e3", 1)
+
+	p := placement.NewPlacement().
+		SetInstances([]placement.Instance{i1, i2, i3}).
+		SetShards([]uint32{0, 1}).
+		SetReplicaFactor(2).
+		SetIsSharded(true)
+
+	a := newShardedAlgorithm(placement.NewOptions())
+	p, err := a.Balance(p, 0)
+	require.NoError(t, err)
+	require.NoError(t, placement.Validate(p))
+
+	i1, ok := p.Instance("i1")
+	require.True(t, ok)
+	assert.Equal(t, 2, i1.Shards().NumShardsForState(shard.Available))
+	i3, ok = p.Instance("i3")
+	require.True(t, ok)
+	assert.Equal(t, 1, i3.Shards().NumShardsForState(shard.Initializing))
+	i2, ok = p.Instance("i2")
+	require.True(t, ok)
+	assert.Equal(t, 1, loadOnInstance(i2))
+}
+
 func verifyAllShardsInAvailableState(t *testing.T, p placement.Placement) {
 	for _, instance := range p.Instances() {
 		s := instance.Shards()
@@ -23,6 +23,7 @@ package placement
 import (
 	"time"
 
+	"github.com/m3db/m3/src/cluster/kv/util/history"
 	"github.com/m3db/m3/src/cluster/shard"
 	"github.com/m3db/m3/src/x/clock"
 	"github.com/m3db/m3/src/x/instrument"
@@ -78,6 +79,8 @@ type options struct {
 	isMirrored          bool
 	isStaged            bool
 	instanceSelector    InstanceSelector
+	historyLimit        int
+	changeMetadata      history.Metadata
 }
 
 // NewOptions returns a default Options.
@@ -242,6 +245,24 @@ func (o options) SetNowFn(fn clock.NowFn) Options {
 	return o
 }
 
+func (o options) HistoryLimit() int {
+	return o.historyLimit
+}
+
+func (o options) SetHistoryLimit(limit int) Options {
+	o.historyLimit = limit
+	return o
+}
+
+func (o options) ChangeMetadata() history.Metadata {
+	return o.changeMetadata
+}
+
+func (o options) SetChangeMetadata(meta history.Metadata) Options {
+	o.changeMetadata = meta
+	return o
+}
+
 func (o options) ValidateFnBeforeUpdate() ValidateFn {
 	return o.validateFn
 }
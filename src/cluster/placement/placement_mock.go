@@ -30,6 +30,7 @@ import (
 
 	"github.com/m3db/m3/src/cluster/generated/proto/placementpb"
 	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/cluster/kv/util/history"
 	"github.com/m3db/m3/src/cluster/shard"
 	"github.com/m3db/m3/src/x/clock"
 	"github.com/m3db/m3/src/x/instrument"
@@ -1890,6 +1891,62 @@ func (mr *MockOptionsMockRecorder) SetNowFn(fn interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNowFn", reflect.TypeOf((*MockOptions)(nil).SetNowFn), fn)
 }
 
+// HistoryLimit mocks base method
+func (m *MockOptions) HistoryLimit() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HistoryLimit")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// HistoryLimit indicates an expected call of HistoryLimit
+func (mr *MockOptionsMockRecorder) HistoryLimit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HistoryLimit", reflect.TypeOf((*MockOptions)(nil).HistoryLimit))
+}
+
+// SetHistoryLimit mocks base method
+func (m *MockOptions) SetHistoryLimit(limit int) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHistoryLimit", limit)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetHistoryLimit indicates an expected call of SetHistoryLimit
+func (mr *MockOptionsMockRecorder) SetHistoryLimit(limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHistoryLimit", reflect.TypeOf((*MockOptions)(nil).SetHistoryLimit), limit)
+}
+
+// ChangeMetadata mocks base method
+func (m *MockOptions) ChangeMetadata() history.Metadata {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeMetadata")
+	ret0, _ := ret[0].(history.Metadata)
+	return ret0
+}
+
+// ChangeMetadata indicates an expected call of ChangeMetadata
+func (mr *MockOptionsMockRecorder) ChangeMetadata() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeMetadata", reflect.TypeOf((*MockOptions)(nil).ChangeMetadata))
+}
+
+// SetChangeMetadata mocks base method
+func (m *MockOptions) SetChangeMetadata(meta history.Metadata) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetChangeMetadata", meta)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetChangeMetadata indicates an expected call of SetChangeMetadata
+func (mr *MockOptionsMockRecorder) SetChangeMetadata(meta interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChangeMetadata", reflect.TypeOf((*MockOptions)(nil).SetChangeMetadata), meta)
+}
+
 // MockStorage is a mock of Storage interface
 type MockStorage struct {
 	ctrl     *gomock.Controller
@@ -2063,6 +2120,21 @@ func (mr *MockStorageMockRecorder) PlacementForVersion(version interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlacementForVersion", reflect.TypeOf((*MockStorage)(nil).PlacementForVersion), version)
 }
 
+// History mocks base method
+func (m *MockStorage) History() ([]HistoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "History")
+	ret0, _ := ret[0].([]HistoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// History indicates an expected call of History
+func (mr *MockStorageMockRecorder) History() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockStorage)(nil).History))
+}
+
 // MockService is a mock of Service interface
 type MockService struct {
 	ctrl     *gomock.Controller
@@ -2236,6 +2308,21 @@ func (mr *MockServiceMockRecorder) PlacementForVersion(version interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlacementForVersion", reflect.TypeOf((*MockService)(nil).PlacementForVersion), version)
 }
 
+// History mocks base method
+func (m *MockService) History() ([]HistoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "History")
+	ret0, _ := ret[0].([]HistoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// History indicates an expected call of History
+func (mr *MockServiceMockRecorder) History() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockService)(nil).History))
+}
+
 // BuildInitialPlacement mocks base method
 func (m *MockService) BuildInitialPlacement(instances []Instance, numShards, rf int) (Placement, error) {
 	m.ctrl.T.Helper()
@@ -2266,6 +2353,66 @@ func (mr *MockServiceMockRecorder) AddReplica() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReplica", reflect.TypeOf((*MockService)(nil).AddReplica))
 }
 
+// RemoveReplica mocks base method
+func (m *MockService) RemoveReplica() (Placement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveReplica")
+	ret0, _ := ret[0].(Placement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveReplica indicates an expected call of RemoveReplica
+func (mr *MockServiceMockRecorder) RemoveReplica() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveReplica", reflect.TypeOf((*MockService)(nil).RemoveReplica))
+}
+
+// Balance mocks base method
+func (m *MockService) Balance(maxMoves int) (Placement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Balance", maxMoves)
+	ret0, _ := ret[0].(Placement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Balance indicates an expected call of Balance
+func (mr *MockServiceMockRecorder) Balance(maxMoves interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Balance", reflect.TypeOf((*MockService)(nil).Balance), maxMoves)
+}
+
+// SplitShards mocks base method
+func (m *MockService) SplitShards(numShards int) (Placement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SplitShards", numShards)
+	ret0, _ := ret[0].(Placement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SplitShards indicates an expected call of SplitShards
+func (mr *MockServiceMockRecorder) SplitShards(numShards interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SplitShards", reflect.TypeOf((*MockService)(nil).SplitShards), numShards)
+}
+
+// Rollback mocks base method
+func (m *MockService) Rollback(version int) (Placement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rollback", version)
+	ret0, _ := ret[0].(Placement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rollback indicates an expected call of Rollback
+func (mr *MockServiceMockRecorder) Rollback(version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockService)(nil).Rollback), version)
+}
+
 // AddInstances mocks base method
 func (m *MockService) AddInstances(candidates []Instance) (Placement, []Instance, error) {
 	m.ctrl.T.Helper()
@@ -2416,6 +2563,51 @@ func (mr *MockAlgorithmMockRecorder) AddReplica(p interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReplica", reflect.TypeOf((*MockAlgorithm)(nil).AddReplica), p)
 }
 
+// RemoveReplica mocks base method
+func (m *MockAlgorithm) RemoveReplica(p Placement) (Placement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveReplica", p)
+	ret0, _ := ret[0].(Placement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveReplica indicates an expected call of RemoveReplica
+func (mr *MockAlgorithmMockRecorder) RemoveReplica(p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveReplica", reflect.TypeOf((*MockAlgorithm)(nil).RemoveReplica), p)
+}
+
+// Balance mocks base method
+func (m *MockAlgorithm) Balance(p Placement, maxMoves int) (Placement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Balance", p, maxMoves)
+	ret0, _ := ret[0].(Placement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Balance indicates an expected call of Balance
+func (mr *MockAlgorithmMockRecorder) Balance(p, maxMoves interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Balance", reflect.TypeOf((*MockAlgorithm)(nil).Balance), p, maxMoves)
+}
+
+// SplitShards mocks base method
+func (m *MockAlgorithm) SplitShards(p Placement, numShards int) (Placement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SplitShards", p, numShards)
+	ret0, _ := ret[0].(Placement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SplitShards indicates an expected call of SplitShards
+func (mr *MockAlgorithmMockRecorder) SplitShards(p, numShards interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SplitShards", reflect.TypeOf((*MockAlgorithm)(nil).SplitShards), p, numShards)
+}
+
 // AddInstances mocks base method
 func (m *MockAlgorithm) AddInstances(p Placement, instances []Instance) (Placement, error) {
 	m.ctrl.T.Helper()
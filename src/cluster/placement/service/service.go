@@ -21,8 +21,12 @@
 package service
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 
+	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/cluster/kv/util/history"
 	"github.com/m3db/m3/src/cluster/placement"
 	"github.com/m3db/m3/src/cluster/placement/algo"
 	"github.com/m3db/m3/src/cluster/placement/selector"
@@ -31,6 +35,10 @@ import (
 	"go.uber.org/zap"
 )
 
+var (
+	errRollbackNumShardsChanged = errors.New("could not roll back to a placement with a different number of shards")
+)
+
 type placementService struct {
 	placement.Storage
 
@@ -117,6 +125,267 @@ func (ps *placementService) AddReplica() (placement.Placement, error) {
 	return ps.CheckAndSet(tempPlacement, curPlacement.Version())
 }
 
+func (ps *placementService) RemoveReplica() (placement.Placement, error) {
+	curPlacement, err := ps.Placement()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ps.opts.ValidateFnBeforeUpdate()(curPlacement); err != nil {
+		return nil, err
+	}
+
+	tempPlacement, err := ps.algo.RemoveReplica(curPlacement)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := placement.Validate(tempPlacement); err != nil {
+		return nil, err
+	}
+
+	return ps.CheckAndSet(tempPlacement, curPlacement.Version())
+}
+
+func (ps *placementService) SplitShards(numShards int) (placement.Placement, error) {
+	curPlacement, err := ps.Placement()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ps.opts.ValidateFnBeforeUpdate()(curPlacement); err != nil {
+		return nil, err
+	}
+
+	tempPlacement, err := ps.algo.SplitShards(curPlacement, numShards)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := placement.Validate(tempPlacement); err != nil {
+		return nil, err
+	}
+
+	return ps.CheckAndSet(tempPlacement, curPlacement.Version())
+}
+
+func (ps *placementService) Balance(maxMoves int) (placement.Placement, error) {
+	curPlacement, err := ps.Placement()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ps.opts.ValidateFnBeforeUpdate()(curPlacement); err != nil {
+		return nil, err
+	}
+
+	tempPlacement, err := ps.algo.Balance(curPlacement, maxMoves)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := placement.Validate(tempPlacement); err != nil {
+		return nil, err
+	}
+
+	return ps.CheckAndSet(tempPlacement, curPlacement.Version())
+}
+
+func (ps *placementService) Rollback(version int) (placement.Placement, error) {
+	entries, err := ps.History()
+	if err != nil {
+		return nil, err
+	}
+
+	// The versions start over once the placement is deleted and set again,
+	// the most recent placement at the version is rolled back to.
+	var target placement.Placement
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entry := entries[i]; !entry.Deleted && entry.Version == version {
+			target = entry.Placement
+			break
+		}
+	}
+	if target == nil {
+		return nil, history.ErrVersionNotFound
+	}
+
+	curPlacement, err := ps.Placement()
+	if err == kv.ErrNotFound {
+		// The placement has been deleted since, rolling back restores it.
+		tempPlacement := target.Clone().SetCutoverNanos(ps.opts.PlacementCutoverNanosFn()())
+		if err := placement.Validate(tempPlacement); err != nil {
+			return nil, err
+		}
+		return ps.SetIfNotExist(tempPlacement)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ps.opts.ValidateFnBeforeUpdate()(curPlacement); err != nil {
+		return nil, err
+	}
+
+	tempPlacement, err := rollbackTransition(curPlacement, target, ps.opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := placement.Validate(tempPlacement); err != nil {
+		return nil, err
+	}
+
+	return ps.CheckAndSet(tempPlacement, curPlacement.Version())
+}
+
+// rollbackTransition returns a placement moving the shards of the current
+// placement back to the instances owning them in the target placement. The
+// target is not restored verbatim since instances may have dropped the data
+// of shards they gave up since. Instances still holding the data of a shard
+// they own in the target, because the shard is available on them or leaving
+// them, get the shard as available. Other instances initialize the shard,
+// paired with an instance giving the shard up which is leaving it.
+func rollbackTransition(
+	cur placement.Placement,
+	target placement.Placement,
+	opts placement.Options,
+) (placement.Placement, error) {
+	if !cur.IsSharded() || !target.IsSharded() {
+		return target.Clone().SetCutoverNanos(opts.PlacementCutoverNanosFn()()), nil
+	}
+	if cur.NumShards() != target.NumShards() {
+		return nil, errRollbackNumShardsChanged
+	}
+
+	var (
+		instances    = make(map[string]placement.Instance, target.NumInstances())
+		ids          = make([]string, 0, target.NumInstances())
+		cutoverNanos = opts.ShardCutoverNanosFn()()
+		cutoffNanos  = opts.ShardCutoffNanosFn()()
+	)
+	addInstance := func(instance placement.Instance) {
+		if _, ok := instances[instance.ID()]; ok {
+			return
+		}
+		instances[instance.ID()] = instance.Clone().SetShards(shard.NewShards(nil))
+		ids = append(ids, instance.ID())
+	}
+	for _, instance := range target.Instances() {
+		addInstance(instance)
+	}
+	for _, instance := range cur.Instances() {
+		addInstance(instance)
+	}
+	sort.Strings(ids)
+
+	for _, shardID := range target.Shards() {
+		var (
+			owners       = make(map[string]struct{}, target.ReplicaFactor())
+			curShards    = make(map[string]shard.Shard, cur.ReplicaFactor())
+			initializing []string
+			sources      []string
+		)
+		for _, instance := range target.Instances() {
+			if s, ok := instance.Shards().Shard(shardID); ok && s.State() != shard.Leaving {
+				owners[instance.ID()] = struct{}{}
+			}
+		}
+		for _, instance := range cur.Instances() {
+			if s, ok := instance.Shards().Shard(shardID); ok {
+				curShards[instance.ID()] = s
+			}
+		}
+
+		for _, id := range ids {
+			s, hasShard := curShards[id]
+			_, isOwner := owners[id]
+			switch {
+			case isOwner && (!hasShard || s.State() == shard.Initializing):
+				initializing = append(initializing, id)
+			case isOwner:
+				// The instance still holds the data of the shard.
+				instances[id].Shards().Add(shard.NewShard(shardID).
+					SetState(shard.Available).
+					SetCutoverNanos(s.CutoverNanos()))
+			case hasShard && s.State() != shard.Initializing:
+				sources = append(sources, id)
+			}
+		}
+
+		// Initializing shards keep their source if it still gives the shard
+		// up, the others are paired with the remaining sources.
+		var (
+			sourceIDs = make(map[string]string, len(initializing))
+			paired    = make(map[string]struct{}, len(sources))
+		)
+		for _, id := range initializing {
+			s, ok := curShards[id]
+			if !ok || s.SourceID() == "" {
+				continue
+			}
+			if _, ok := paired[s.SourceID()]; ok {
+				continue
+			}
+			for _, source := range sources {
+				if source == s.SourceID() {
+					sourceIDs[id] = source
+					paired[source] = struct{}{}
+					break
+				}
+			}
+		}
+		for _, id := range initializing {
+			if _, ok := sourceIDs[id]; ok {
+				continue
+			}
+			for _, source := range sources {
+				if _, ok := paired[source]; !ok {
+					sourceIDs[id] = source
+					paired[source] = struct{}{}
+					break
+				}
+			}
+		}
+
+		for _, id := range initializing {
+			newShard, ok := curShards[id]
+			if ok {
+				newShard = newShard.Clone()
+			} else {
+				newShard = shard.NewShard(shardID).
+					SetState(shard.Initializing).
+					SetCutoverNanos(cutoverNanos)
+			}
+			// Initializing shards without a source initialize from any
+			// instance holding the data of the shard.
+			instances[id].Shards().Add(newShard.SetSourceID(sourceIDs[id]))
+		}
+		for _, id := range sources {
+			if _, ok := paired[id]; !ok {
+				// No instance initializes the shard from this instance, which
+				// happens when rolling back to fewer replicas.
+				continue
+			}
+			leavingShard := curShards[id].Clone()
+			if leavingShard.State() != shard.Leaving {
+				leavingShard = leavingShard.SetState(shard.Leaving).SetCutoffNanos(cutoffNanos)
+			}
+			instances[id].Shards().Add(leavingShard)
+		}
+	}
+
+	newInstances := make([]placement.Instance, 0, len(ids))
+	for _, id := range ids {
+		if instances[id].Shards().NumShards() > 0 {
+			newInstances = append(newInstances, instances[id])
+		}
+	}
+	return target.Clone().
+		SetInstances(newInstances).
+		SetCutoverNanos(opts.PlacementCutoverNanosFn()()), nil
+}
+
 func (ps *placementService) AddInstances(
 	candidates []placement.Instance,
 ) (placement.Placement, []placement.Instance, error) {
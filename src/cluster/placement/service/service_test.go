@@ -25,6 +25,7 @@ import (
 	"testing"
 
 	"github.com/m3db/m3/src/cluster/kv/mem"
+	"github.com/m3db/m3/src/cluster/kv/util/history"
 	"github.com/m3db/m3/src/cluster/placement"
 	"github.com/m3db/m3/src/cluster/placement/storage"
 	"github.com/m3db/m3/src/cluster/shard"
@@ -927,6 +928,298 @@ func TestValidateFnBeforeUpdate(t *testing.T) {
 	assert.Equal(t, expectErr, err)
 }
 
+func TestRemoveReplicaAndSplitShards(t *testing.T) {
+	ps := NewPlacementService(newMockStorage(), placement.NewOptions().SetValidZone("z1"))
+	_, err := ps.BuildInitialPlacement([]placement.Instance{
+		placement.NewEmptyInstance("i1", "r1", "z1", "e1", 1),
+		placement.NewEmptyInstance("i2", "r2", "z1", "e2", 1),
+		placement.NewEmptyInstance("i3", "r3", "z1", "e3", 1),
+	}, 8, 3)
+	require.NoError(t, err)
+
+	_, err = ps.SplitShards(16)
+	require.Error(t, err)
+
+	markAllInstancesAvailable(t, ps)
+
+	p, err := ps.RemoveReplica()
+	require.NoError(t, err)
+	assert.Equal(t, 2, p.ReplicaFactor())
+
+	p, err = ps.SplitShards(16)
+	require.NoError(t, err)
+	assert.Equal(t, 16, p.NumShards())
+	for _, instance := range p.Instances() {
+		assert.Equal(t, instance.Shards().NumShardsForState(shard.Available),
+			instance.Shards().NumShardsForState(shard.Initializing))
+	}
+
+	markAllInstancesAvailable(t, ps)
+	p, err = ps.Placement()
+	require.NoError(t, err)
+	require.NoError(t, placement.Validate(p))
+	assert.Equal(t, 16, p.NumShards())
+	assert.Equal(t, 2, p.ReplicaFactor())
+}
+
+func TestBalance(t *testing.T) {
+	ps := NewPlacementService(newMockStorage(), placement.NewOptions().SetValidZone("z1"))
+	_, err := ps.BuildInitialPlacement([]placement.Instance{
+		placement.NewEmptyInstance("i1", "r1", "z1", "e1", 1),
+		placement.NewEmptyInstance("i2", "r2", "z1", "e2", 1),
+	}, 12, 1)
+	require.NoError(t, err)
+	markAllInstancesAvailable(t, ps)
+
+	p, err := ps.Placement()
+	require.NoError(t, err)
+	i1, ok := p.Instance("i1")
+	require.True(t, ok)
+	_, err = ps.CheckAndSet(p.SetInstances([]placement.Instance{
+		i1.Clone().SetWeight(2),
+		mustInstance(t, p, "i2"),
+	}), p.Version())
+	require.NoError(t, err)
+
+	p, err = ps.Balance(1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, mustInstance(t, p, "i1").Shards().NumShardsForState(shard.Initializing))
+	markAllInstancesAvailable(t, ps)
+
+	p, err = ps.Balance(0)
+	require.NoError(t, err)
+	assert.Equal(t, 8, mustInstance(t, p, "i1").Shards().NumShards()-
+		mustInstance(t, p, "i1").Shards().NumShardsForState(shard.Leaving))
+	assert.Equal(t, 4, mustInstance(t, p, "i2").Shards().NumShardsForState(shard.Available))
+}
+
+func mustInstance(t *testing.T, p placement.Placement, id string) placement.Instance {
+	instance, ok := p.Instance(id)
+	require.True(t, ok)
+	return instance
+}
+
+func TestRollback(t *testing.T) {
+	opts := placement.NewOptions().
+		SetValidZone("z1").
+		SetHistoryLimit(2).
+		SetChangeMetadata(history.Metadata{Operator: "op", Reason: "test"})
+	store := mem.NewStore()
+	ps := NewPlacementService(storage.NewPlacementStorage(store, "key", opts), opts)
+
+	_, err := ps.BuildInitialPlacement([]placement.Instance{
+		placement.NewEmptyInstance("i1", "r1", "z1", "e1", 1),
+		placement.NewEmptyInstance("i2", "r2", "z1", "e2", 1),
+	}, 4, 1)
+	require.NoError(t, err)
+	markAllInstancesAvailable(t, ps)
+
+	_, _, err = ps.AddInstances([]placement.Instance{
+		placement.NewEmptyInstance("i3", "r3", "z1", "e3", 1),
+	})
+	require.NoError(t, err)
+
+	cur, err := ps.Placement()
+	require.NoError(t, err)
+	entries, err := ps.History()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(entries))
+	assert.Equal(t, cur.Version()-1, entries[0].Placement.Version())
+	assert.Equal(t, cur.Version(), entries[1].Placement.Version())
+	assert.Equal(t, history.Metadata{Operator: "op", Reason: "test"}, entries[1].Metadata)
+
+	_, err = ps.Rollback(cur.Version() - 2)
+	assert.Equal(t, history.ErrVersionNotFound, err)
+
+	p, err := ps.Rollback(cur.Version() - 1)
+	require.NoError(t, err)
+	assert.Equal(t, cur.Version()+1, p.Version())
+	assert.Equal(t, 2, p.NumInstances())
+	_, ok := p.Instance("i3")
+	assert.False(t, ok)
+
+	// Deletes are kept in the history, rolling back a deleted placement
+	// restores it.
+	require.NoError(t, ps.Delete())
+	entries, err = ps.History()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(entries))
+	assert.True(t, entries[1].Deleted)
+	assert.Nil(t, entries[1].Placement)
+	assert.Equal(t, cur.Version()+1, entries[1].Version)
+	assert.Equal(t, history.Metadata{Operator: "op", Reason: "test"}, entries[1].Metadata)
+
+	p, err = ps.Rollback(cur.Version() + 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, p.NumInstances())
+	entries, err = ps.History()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(entries))
+	assert.Equal(t, 1, entries[1].Version)
+	assert.Equal(t, 1, entries[1].Placement.Version())
+
+	// Dry runs are not kept in the history.
+	dryRunOpts := opts.SetDryrun(true)
+	ps = NewPlacementService(storage.NewPlacementStorage(store, "key", dryRunOpts), dryRunOpts)
+	_, err = ps.Rollback(1)
+	require.NoError(t, err)
+	entries, err = ps.History()
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(entries))
+}
+
+func TestRollbackToPlacementBeforeFirstChange(t *testing.T) {
+	store := mem.NewStore()
+
+	// A placement written before the history was kept.
+	opts := placement.NewOptions().SetValidZone("z1")
+	ps := NewPlacementService(storage.NewPlacementStorage(store, "key", opts), opts)
+	initial, err := ps.BuildInitialPlacement([]placement.Instance{
+		placement.NewEmptyInstance("i1", "r1", "z1", "e1", 1),
+		placement.NewEmptyInstance("i2", "r2", "z1", "e2", 1),
+	}, 4, 1)
+	require.NoError(t, err)
+
+	opts = opts.SetHistoryLimit(10)
+	ps = NewPlacementService(storage.NewPlacementStorage(store, "key", opts), opts)
+	markAllInstancesAvailable(t, ps)
+
+	entries, err := ps.History()
+	require.NoError(t, err)
+	require.Equal(t, 3, len(entries))
+	assert.Equal(t, initial.Version(), entries[0].Version)
+	assert.True(t, entries[0].UpdatedAt.IsZero())
+
+	p, err := ps.Rollback(initial.Version())
+	require.NoError(t, err)
+	assert.Equal(t, 2, p.NumInstances())
+}
+
+func TestRollbackMovedShards(t *testing.T) {
+	opts := placement.NewOptions().SetValidZone("z1").SetHistoryLimit(10)
+	ps := NewPlacementService(storage.NewPlacementStorage(mem.NewStore(), "key", opts), opts)
+
+	_, err := ps.BuildInitialPlacement([]placement.Instance{
+		placement.NewEmptyInstance("i1", "r1", "z1", "e1", 1),
+		placement.NewEmptyInstance("i2", "r2", "z1", "e2", 1),
+	}, 4, 1)
+	require.NoError(t, err)
+	markAllInstancesAvailable(t, ps)
+	target, err := ps.Placement()
+	require.NoError(t, err)
+
+	_, _, err = ps.AddInstances([]placement.Instance{
+		placement.NewEmptyInstance("i3", "r3", "z1", "e3", 1),
+	})
+	require.NoError(t, err)
+	markAllInstancesAvailable(t, ps)
+	cur, err := ps.Placement()
+	require.NoError(t, err)
+	i3, ok := cur.Instance("i3")
+	require.True(t, ok)
+	movedShards := i3.Shards().AllIDs()
+	require.True(t, len(movedShards) > 0)
+
+	p, err := ps.Rollback(target.Version())
+	require.NoError(t, err)
+
+	// The shards moved to i3 since are initialized from i3, which leaves them,
+	// rather than handed back as available to instances that dropped them.
+	i3, ok = p.Instance("i3")
+	require.True(t, ok)
+	assert.Equal(t, len(movedShards), i3.Shards().NumShardsForState(shard.Leaving))
+	assert.Equal(t, len(movedShards), i3.Shards().NumShards())
+	for _, shardID := range movedShards {
+		var owner placement.Instance
+		for _, instance := range target.Instances() {
+			if instance.Shards().Contains(shardID) {
+				owner = instance
+			}
+		}
+		require.NotNil(t, owner)
+
+		instance, ok := p.Instance(owner.ID())
+		require.True(t, ok)
+		s, ok := instance.Shards().Shard(shardID)
+		require.True(t, ok)
+		assert.Equal(t, shard.Initializing, s.State())
+		assert.Equal(t, "i3", s.SourceID())
+	}
+	for _, id := range []string{"i1", "i2"} {
+		instance, ok := p.Instance(id)
+		require.True(t, ok)
+		curInstance, ok := cur.Instance(id)
+		require.True(t, ok)
+		for _, s := range curInstance.Shards().All() {
+			rolledBack, ok := instance.Shards().Shard(s.ID())
+			require.True(t, ok)
+			assert.Equal(t, shard.Available, rolledBack.State())
+		}
+	}
+
+	// Once the shards are initialized the placement matches the target.
+	markAllInstancesAvailable(t, ps)
+	p, err = ps.Placement()
+	require.NoError(t, err)
+	require.Equal(t, 2, p.NumInstances())
+	for _, instance := range target.Instances() {
+		rolledBack, ok := p.Instance(instance.ID())
+		require.True(t, ok)
+		assert.Equal(t, instance.Shards().AllIDs(), rolledBack.Shards().AllIDs())
+		assert.True(t, rolledBack.IsAvailable())
+	}
+}
+
+func TestRollbackPendingMove(t *testing.T) {
+	opts := placement.NewOptions().SetValidZone("z1").SetHistoryLimit(10)
+	ps := NewPlacementService(storage.NewPlacementStorage(mem.NewStore(), "key", opts), opts)
+
+	_, err := ps.BuildInitialPlacement([]placement.Instance{
+		placement.NewEmptyInstance("i1", "r1", "z1", "e1", 1),
+		placement.NewEmptyInstance("i2", "r2", "z1", "e2", 1),
+	}, 4, 1)
+	require.NoError(t, err)
+	markAllInstancesAvailable(t, ps)
+	target, err := ps.Placement()
+	require.NoError(t, err)
+
+	// Rolling back a move that is still in progress keeps the shards on the
+	// instances that are leaving them and drops the initializing shards.
+	_, _, err = ps.AddInstances([]placement.Instance{
+		placement.NewEmptyInstance("i3", "r3", "z1", "e3", 1),
+	})
+	require.NoError(t, err)
+
+	p, err := ps.Rollback(target.Version())
+	require.NoError(t, err)
+	require.Equal(t, 2, p.NumInstances())
+	for _, instance := range target.Instances() {
+		rolledBack, ok := p.Instance(instance.ID())
+		require.True(t, ok)
+		assert.Equal(t, instance.Shards().AllIDs(), rolledBack.Shards().AllIDs())
+		assert.True(t, rolledBack.IsAvailable())
+	}
+}
+
+func TestRollbackNumShardsChanged(t *testing.T) {
+	opts := placement.NewOptions().SetValidZone("z1").SetHistoryLimit(10)
+	ps := NewPlacementService(storage.NewPlacementStorage(mem.NewStore(), "key", opts), opts)
+
+	_, err := ps.BuildInitialPlacement([]placement.Instance{
+		placement.NewEmptyInstance("i1", "r1", "z1", "e1", 1),
+	}, 2, 1)
+	require.NoError(t, err)
+	markAllInstancesAvailable(t, ps)
+	target, err := ps.Placement()
+	require.NoError(t, err)
+
+	_, err = ps.SplitShards(4)
+	require.NoError(t, err)
+
+	_, err = ps.Rollback(target.Version())
+	assert.Equal(t, errRollbackNumShardsChanged, err)
+}
+
 func newMockStorage() placement.Storage {
 	return storage.NewPlacementStorage(mem.NewStore(), "", nil)
 }
@@ -21,7 +21,9 @@
 package storage
 
 import (
+	"github.com/m3db/m3/src/cluster/generated/proto/placementpb"
 	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/cluster/kv/util/history"
 	"github.com/m3db/m3/src/cluster/placement"
 
 	"github.com/golang/protobuf/proto"
@@ -31,11 +33,12 @@ import (
 const errorVersionValue = 0
 
 type storage struct {
-	helper helper
-	key    string
-	store  kv.Store
-	opts   placement.Options
-	logger *zap.Logger
+	helper  helper
+	history history.Store
+	key     string
+	store   kv.Store
+	opts    placement.Options
+	logger  *zap.Logger
 }
 
 // NewPlacementStorage creates a placement.Storage.
@@ -43,12 +46,16 @@ func NewPlacementStorage(store kv.Store, key string, opts placement.Options) pla
 	if opts == nil {
 		opts = placement.NewOptions()
 	}
+	historyOpts := history.NewOptions().
+		SetLimit(opts.HistoryLimit()).
+		SetNowFn(opts.NowFn())
 	return &storage{
-		key:    key,
-		store:  store,
-		helper: newHelper(store, key, opts),
-		opts:   opts,
-		logger: opts.InstrumentOptions().Logger(),
+		key:     key,
+		store:   store,
+		helper:  newHelper(store, key, opts),
+		history: history.NewStore(store, key, historyOpts),
+		opts:    opts,
+		logger:  opts.InstrumentOptions().Logger(),
 	}
 }
 
@@ -62,7 +69,14 @@ func (s *storage) CheckAndSetProto(p proto.Message, version int) (int, error) {
 		return version + 1, nil
 	}
 
-	return s.store.CheckAndSet(s.key, version, p)
+	s.recordCurrentHistory()
+	v, err := s.store.CheckAndSet(s.key, version, p)
+	if err != nil {
+		return errorVersionValue, err
+	}
+
+	s.recordProtoHistory(p, v)
+	return v, nil
 }
 
 func (s *storage) SetProto(p proto.Message) (int, error) {
@@ -74,7 +88,15 @@ func (s *storage) SetProto(p proto.Message) (int, error) {
 		s.logger.Info("this is a dryrun, the operation is not persisted")
 		return errorVersionValue, nil
 	}
-	return s.store.Set(s.key, p)
+
+	s.recordCurrentHistory()
+	v, err := s.store.Set(s.key, p)
+	if err != nil {
+		return errorVersionValue, err
+	}
+
+	s.recordProtoHistory(p, v)
+	return v, nil
 }
 
 func (s *storage) Proto() (proto.Message, int, error) {
@@ -96,11 +118,13 @@ func (s *storage) Set(p placement.Placement) (placement.Placement, error) {
 		return p, nil
 	}
 
+	s.recordCurrentHistory()
 	v, err := s.store.Set(s.key, placementProto)
 	if err != nil {
 		return nil, err
 	}
 
+	s.recordHistory(p, v)
 	return p.Clone().SetVersion(v), nil
 }
 
@@ -119,6 +143,7 @@ func (s *storage) CheckAndSet(p placement.Placement, version int) (placement.Pla
 		return p, nil
 	}
 
+	s.recordCurrentHistory()
 	v, err := s.store.CheckAndSet(
 		s.key,
 		version,
@@ -129,6 +154,7 @@ func (s *storage) CheckAndSet(p placement.Placement, version int) (placement.Pla
 		return nil, err
 	}
 
+	s.recordHistory(p, v)
 	return p.Clone().SetVersion(v), nil
 }
 
@@ -147,6 +173,7 @@ func (s *storage) SetIfNotExist(p placement.Placement) (placement.Placement, err
 		return p, nil
 	}
 
+	s.recordCurrentHistory()
 	v, err := s.store.SetIfNotExists(
 		s.key,
 		placementProto,
@@ -156,6 +183,7 @@ func (s *storage) SetIfNotExist(p placement.Placement) (placement.Placement, err
 		return nil, err
 	}
 
+	s.recordHistory(p, v)
 	return p.Clone().SetVersion(v), nil
 }
 
@@ -165,8 +193,14 @@ func (s *storage) Delete() error {
 		return nil
 	}
 
-	_, err := s.store.Delete(s.key)
-	return err
+	s.recordCurrentHistory()
+	v, err := s.store.Delete(s.key)
+	if err != nil {
+		return err
+	}
+
+	s.recordDeleteHistory(v.Version())
+	return nil
 }
 
 func (s *storage) Placement() (placement.Placement, error) {
@@ -185,3 +219,112 @@ func (s *storage) Watch() (placement.Watch, error) {
 func (s *storage) PlacementForVersion(version int) (placement.Placement, error) {
 	return s.helper.PlacementForVersion(version)
 }
+
+func (s *storage) History() ([]placement.HistoryEntry, error) {
+	entries, err := s.history.Entries()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]placement.HistoryEntry, 0, len(entries))
+	for _, entry := range entries {
+		historyEntry := placement.HistoryEntry{
+			Version:   entry.Version,
+			Deleted:   entry.Deleted,
+			UpdatedAt: entry.UpdatedAt,
+			Metadata:  entry.Metadata,
+		}
+		if !entry.Deleted {
+			var placementProto placementpb.Placement
+			if err := entry.Unmarshal(&placementProto); err != nil {
+				return nil, err
+			}
+
+			p, err := placement.NewPlacementFromProto(&placementProto)
+			if err != nil {
+				return nil, err
+			}
+			historyEntry.Placement = p.SetVersion(entry.Version)
+		}
+		res = append(res, historyEntry)
+	}
+	return res, nil
+}
+
+// recordCurrentHistory keeps the current placement in the placement history
+// if the history is empty, so the placement from before the first change can
+// be rolled back to. Failing to record it does not prevent the change, so it
+// is logged rather than returned.
+func (s *storage) recordCurrentHistory() {
+	if s.opts.HistoryLimit() <= 0 {
+		return
+	}
+
+	current := func() (proto.Message, int, error) {
+		p, v, err := s.helper.Placement()
+		if err == errNoPlacementInTheSnapshots {
+			return nil, 0, kv.ErrNotFound
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+
+		placementProto, err := p.Proto()
+		return placementProto, v, err
+	}
+	if err := s.history.AppendCurrent(current); err != nil {
+		s.logger.Error("could not record current placement history", zap.Error(err))
+	}
+}
+
+// recordDeleteHistory keeps the deletion of the placement at the given
+// version in the placement history. The placement has already been deleted
+// at this point, so failing to record it is logged rather than returned.
+func (s *storage) recordDeleteHistory(version int) {
+	if s.opts.HistoryLimit() <= 0 {
+		return
+	}
+
+	if err := s.history.AppendDelete(version, s.opts.ChangeMetadata()); err != nil {
+		s.logger.Error("could not record placement deletion history",
+			zap.Int("version", version), zap.Error(err))
+	}
+}
+
+// recordHistory keeps the placement written at the given version in the
+// placement history. The placement has already been written at this point,
+// so failing to record it is logged rather than returned.
+func (s *storage) recordHistory(p placement.Placement, version int) {
+	if s.opts.HistoryLimit() <= 0 {
+		return
+	}
+
+	placementProto, err := p.Proto()
+	if err != nil {
+		s.logger.Error("could not record placement history",
+			zap.Int("version", version), zap.Error(err))
+		return
+	}
+	s.recordProtoHistory(placementProto, version)
+}
+
+func (s *storage) recordProtoHistory(p proto.Message, version int) {
+	if s.opts.HistoryLimit() <= 0 {
+		return
+	}
+
+	// Staged placements are written as snapshots, the placement written is
+	// the latest snapshot.
+	if snapshots, ok := p.(*placementpb.PlacementSnapshots); ok {
+		l := len(snapshots.Snapshots)
+		if l == 0 {
+			return
+		}
+		p = snapshots.Snapshots[l-1]
+	}
+
+	if err := s.history.Append(version, p, s.opts.ChangeMetadata()); err != nil {
+		s.logger.Error("could not record placement history",
+			zap.Int("version", version), zap.Error(err))
+	}
+}
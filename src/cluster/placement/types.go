@@ -25,6 +25,7 @@ import (
 
 	"github.com/m3db/m3/src/cluster/generated/proto/placementpb"
 	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/cluster/kv/util/history"
 	"github.com/m3db/m3/src/cluster/shard"
 	"github.com/m3db/m3/src/x/clock"
 	"github.com/m3db/m3/src/x/instrument"
@@ -451,6 +452,21 @@ type Options interface {
 
 	// SetNowFn sets the function to get time now.
 	SetNowFn(fn clock.NowFn) Options
+
+	// HistoryLimit returns the number of placements kept in the placement
+	// history, zero means the history is not kept.
+	HistoryLimit() int
+
+	// SetHistoryLimit sets the number of placements kept in the placement
+	// history.
+	SetHistoryLimit(limit int) Options
+
+	// ChangeMetadata returns who changes the placement and why, it is kept
+	// in the placement history along with the placements written.
+	ChangeMetadata() history.Metadata
+
+	// SetChangeMetadata sets who changes the placement and why.
+	SetChangeMetadata(meta history.Metadata) Options
 }
 
 // ShardStateMode describes the way to manage shard state in the placement.
@@ -497,6 +513,20 @@ type Storage interface {
 
 	// PlacementForVersion returns the placement of a specific version.
 	PlacementForVersion(version int) (Placement, error)
+
+	// History returns the placements kept in the placement history, oldest
+	// first.
+	History() ([]HistoryEntry, error)
+}
+
+// HistoryEntry is a placement kept in the placement history, or the deletion
+// of the placement at the version if Deleted is set.
+type HistoryEntry struct {
+	Placement Placement
+	Version   int
+	Deleted   bool
+	UpdatedAt time.Time
+	Metadata  history.Metadata
 }
 
 // Service handles the placement related operations for registered services
@@ -510,6 +540,24 @@ type Service interface {
 	// AddReplica up the replica factor by 1 in the placement.
 	AddReplica() (Placement, error)
 
+	// RemoveReplica reduces the replica factor by 1 in the placement.
+	RemoveReplica() (Placement, error)
+
+	// SplitShards splits each shard in the placement so the placement has
+	// numShards shards, numShards must be a multiple of the current number
+	// of shards.
+	SplitShards(numShards int) (Placement, error)
+
+	// Rollback moves the shards back to the instances owning them in the given
+	// version from the placement history. Shards that moved since are
+	// initialized from the instances owning them now, which leave them.
+	Rollback(version int) (Placement, error)
+
+	// Balance moves at most maxMoves shards so the number of shards on each
+	// instance is proportional to its weight, a maxMoves of zero or less does
+	// not limit the number of moves.
+	Balance(maxMoves int) (Placement, error)
+
 	// AddInstances adds instances from the candidate list to the placement.
 	AddInstances(candidates []Instance) (newPlacement Placement, addedInstances []Instance, err error)
 
@@ -544,6 +592,17 @@ type Algorithm interface {
 	// AddReplica up the replica factor by 1 in the placement.
 	AddReplica(p Placement) (Placement, error)
 
+	// RemoveReplica reduces the replica factor by 1 in the placement.
+	RemoveReplica(p Placement) (Placement, error)
+
+	// SplitShards splits each shard in the placement so the placement has
+	// numShards shards.
+	SplitShards(p Placement, numShards int) (Placement, error)
+
+	// Balance moves at most maxMoves shards so the number of shards on each
+	// instance is proportional to its weight.
+	Balance(p Placement, maxMoves int) (Placement, error)
+
 	// AddInstances adds a list of instance to the placement.
 	AddInstances(p Placement, instances []Instance) (Placement, error)
 
@@ -74,11 +74,15 @@ func NewShardFromProto(shard *placementpb.Shard) (Shard, error) {
 		return nil, err
 	}
 
-	return NewShard(shard.Id).
+	s := NewShard(shard.Id).
 		SetState(state).
 		SetSourceID(shard.SourceId).
 		SetCutoverNanos(shard.CutoverNanos).
-		SetCutoffNanos(shard.CutoffNanos), nil
+		SetCutoffNanos(shard.CutoffNanos)
+	if shard.SplitSource != nil {
+		s = s.SetSplitSource(shard.SplitSource.ShardId)
+	}
+	return s, nil
 }
 
 type shard struct {
@@ -87,6 +91,8 @@ type shard struct {
 	sourceID     string
 	cutoverNanos int64
 	cutoffNanos  int64
+	splitSource  uint32
+	isSplit      bool
 }
 
 func (s *shard) ID() uint32                        { return s.id }
@@ -95,6 +101,16 @@ func (s *shard) SetState(state State) Shard        { s.state = state; return s }
 func (s *shard) SourceID() string                  { return s.sourceID }
 func (s *shard) SetSourceID(sourceID string) Shard { s.sourceID = sourceID; return s }
 
+func (s *shard) SplitSource() (uint32, bool) {
+	return s.splitSource, s.isSplit
+}
+
+func (s *shard) SetSplitSource(sourceShardID uint32) Shard {
+	s.splitSource = sourceShardID
+	s.isSplit = true
+	return s
+}
+
 func (s *shard) CutoverNanos() int64 {
 	if s.cutoverNanos != UnInitializedValue {
 		return s.cutoverNanos
@@ -138,11 +154,15 @@ func (s *shard) SetCutoffNanos(value int64) Shard {
 }
 
 func (s *shard) Equals(other Shard) bool {
+	splitSource, isSplit := s.SplitSource()
+	otherSplitSource, otherIsSplit := other.SplitSource()
 	return s.ID() == other.ID() &&
 		s.State() == other.State() &&
 		s.SourceID() == other.SourceID() &&
 		s.CutoverNanos() == other.CutoverNanos() &&
-		s.CutoffNanos() == other.CutoffNanos()
+		s.CutoffNanos() == other.CutoffNanos() &&
+		isSplit == otherIsSplit &&
+		splitSource == otherSplitSource
 }
 
 func (s *shard) Proto() (*placementpb.Shard, error) {
@@ -151,21 +171,24 @@ func (s *shard) Proto() (*placementpb.Shard, error) {
 		return nil, err
 	}
 
+	var splitSource *placementpb.SplitSource
+	if s.isSplit {
+		splitSource = &placementpb.SplitSource{ShardId: s.splitSource}
+	}
+
 	return &placementpb.Shard{
 		Id:           s.ID(),
 		State:        ss,
 		SourceId:     s.SourceID(),
 		CutoverNanos: s.cutoverNanos,
 		CutoffNanos:  s.cutoffNanos,
+		SplitSource:  splitSource,
 	}, nil
 }
 
 func (s *shard) Clone() Shard {
-	return NewShard(s.ID()).
-		SetState(s.State()).
-		SetSourceID(s.SourceID()).
-		SetCutoverNanos(s.CutoverNanos()).
-		SetCutoffNanos(s.CutoffNanos())
+	clone := *s
+	return &clone
 }
 
 // SortableShardsByIDAsc are sortable shards by ID in ascending order
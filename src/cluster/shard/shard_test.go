@@ -209,3 +209,28 @@ func TestClone(t *testing.T) {
 	ss1.Add(NewShard(2).SetState(Leaving))
 	require.False(t, ss1.Equals(ss2))
 }
+
+func TestShardSplitSource(t *testing.T) {
+	s := NewShard(5).SetState(Initializing)
+	_, isSplit := s.SplitSource()
+	require.False(t, isSplit)
+
+	s.SetSplitSource(1)
+	splitSource, isSplit := s.SplitSource()
+	require.True(t, isSplit)
+	require.Equal(t, uint32(1), splitSource)
+	require.False(t, s.Equals(NewShard(5).SetState(Initializing)))
+	require.True(t, s.Equals(s.Clone()))
+
+	pb, err := s.Proto()
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), pb.SplitSource.ShardId)
+
+	decoded, err := NewShardFromProto(pb)
+	require.NoError(t, err)
+	require.True(t, s.Equals(decoded))
+
+	pb, err = NewShard(5).SetState(Initializing).Proto()
+	require.NoError(t, err)
+	require.Nil(t, pb.SplitSource)
+}
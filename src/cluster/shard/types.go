@@ -82,6 +82,13 @@ type Shard interface {
 	// SetSource sets the source of the shard.
 	SetSourceID(sourceID string) Shard
 
+	// SplitSource returns the ID of the shard this shard was split from when
+	// the placement was resharded, and false if the shard was not split.
+	SplitSource() (uint32, bool)
+
+	// SetSplitSource sets the ID of the shard this shard was split from.
+	SetSplitSource(sourceShardID uint32) Shard
+
 	// Equals returns whether the shard equals to another shard.
 	Equals(s Shard) bool
 
@@ -31,7 +31,9 @@ import (
 	"strings"
 	"time"
 
+	raggregation "github.com/m3db/m3/src/aggregator/aggregation"
 	"github.com/m3db/m3/src/aggregator/aggregation/quantile/cm"
+	"github.com/m3db/m3/src/aggregator/aggregation/quantile/tdigest"
 	"github.com/m3db/m3/src/aggregator/aggregator"
 	"github.com/m3db/m3/src/aggregator/aggregator/handler"
 	"github.com/m3db/m3/src/aggregator/aggregator/handler/writer"
@@ -92,6 +94,9 @@ type AggregatorConfiguration struct {
 	// Stream configuration for computing quantiles.
 	Stream streamConfiguration `yaml:"stream"`
 
+	// Digest configuration for computing quantiles with t-digests.
+	Digest digestConfiguration `yaml:"digest"`
+
 	// Client configuration.
 	Client aggclient.Configuration `yaml:"client"`
 
@@ -113,6 +118,10 @@ type AggregatorConfiguration struct {
 	// Amount of time we buffer timed metrics in the future.
 	BufferDurationForFutureTimedMetric time.Duration `yaml:"bufferDurationForFutureTimedMetric"`
 
+	// Amount of time after the buffer for past timed metrics during which late timed
+	// metrics are emitted as corrections, per storage policy.
+	CorrectionBuffers []correctionBufferConfiguration `yaml:"correctionBuffers"`
+
 	// Resign timeout.
 	ResignTimeout time.Duration `yaml:"resignTimeout"`
 
@@ -130,6 +139,9 @@ type AggregatorConfiguration struct {
 	// Flush manager.
 	FlushManager flushManagerConfiguration `yaml:"flushManager"`
 
+	// Snapshot configuration, snapshots are disabled if not set.
+	Snapshot *snapshotConfiguration `yaml:"snapshot"`
+
 	// Flushing handler configuration.
 	Flush handler.FlushHandlerConfiguration `yaml:"flush"`
 
@@ -279,6 +291,15 @@ func (c *AggregatorConfiguration) NewAggregatorOptions(
 	}
 	opts = opts.SetStreamOptions(streamOpts)
 
+	// Set digest options and the quantile sketch used by timers.
+	digestOpts, err := c.Digest.NewDigestOptions()
+	if err != nil {
+		return nil, err
+	}
+	opts = opts.
+		SetDigestOptions(digestOpts).
+		SetTimerQuantileSketchFn(c.Digest.NewTimerQuantileSketchFn())
+
 	// Set administrative client.
 	// TODO(xichen): client retry threshold likely needs to be low for faster retries.
 	iOpts = instrumentOpts.SetMetricsScope(scope.SubScope("client"))
@@ -329,6 +350,9 @@ func (c *AggregatorConfiguration) NewAggregatorOptions(
 	if c.BufferDurationForFutureTimedMetric != 0 {
 		opts = opts.SetBufferForFutureTimedMetric(c.BufferDurationForFutureTimedMetric)
 	}
+	if len(c.CorrectionBuffers) > 0 {
+		opts = opts.SetCorrectionBufferFn(correctionBufferFn(c.CorrectionBuffers))
+	}
 
 	// Set resign timeout.
 	if c.ResignTimeout != 0 {
@@ -373,6 +397,12 @@ func (c *AggregatorConfiguration) NewAggregatorOptions(
 	flushManager := aggregator.NewFlushManager(flushManagerOpts)
 	opts = opts.SetFlushManager(flushManager)
 
+	// Set snapshot manager.
+	if c.Snapshot != nil {
+		iOpts = instrumentOpts.SetMetricsScope(scope.SubScope("snapshot-manager"))
+		opts = opts.SetSnapshotManager(c.Snapshot.NewSnapshotManager(iOpts))
+	}
+
 	// Set flushing handler.
 	iOpts = instrumentOpts.SetMetricsScope(scope.SubScope("flush-handler"))
 	flushHandler, err := c.Flush.NewHandler(client, iOpts)
@@ -500,6 +530,21 @@ func bufferForPastTimedMetricFn(buffer time.Duration) aggregator.BufferForPastTi
 	}
 }
 
+type correctionBufferConfiguration struct {
+	StoragePolicy policy.StoragePolicy `yaml:"storagePolicy"`
+	Buffer        time.Duration        `yaml:"buffer"`
+}
+
+func correctionBufferFn(configs []correctionBufferConfiguration) aggregator.CorrectionBufferFn {
+	buffers := make(map[policy.StoragePolicy]time.Duration, len(configs))
+	for _, config := range configs {
+		buffers[config.StoragePolicy] = config.Buffer
+	}
+	return func(sp policy.StoragePolicy) time.Duration {
+		return buffers[sp]
+	}
+}
+
 // streamConfiguration contains configuration for quantile-related metric streams.
 type streamConfiguration struct {
 	// Error epsilon for quantile computation.
@@ -563,6 +608,57 @@ func (c *streamConfiguration) NewStreamOptions(instrumentOpts instrument.Options
 	return opts, nil
 }
 
+// digestConfiguration contains configuration for t-digest backed timers.
+type digestConfiguration struct {
+	// Compression of the digest, higher values trade memory for accuracy.
+	Compression *float64 `yaml:"compression"`
+
+	// Number of significant decimal digits kept for centroid means.
+	Precision *int `yaml:"precision"`
+
+	// Default quantile sketch used by timers.
+	Sketch raggregation.QuantileSketchType `yaml:"sketch"`
+
+	// Per storage policy overrides of the quantile sketch used by timers.
+	StoragePolicies []storagePolicySketchConfiguration `yaml:"storagePolicies"`
+}
+
+// storagePolicySketchConfiguration overrides the quantile sketch for a storage policy.
+type storagePolicySketchConfiguration struct {
+	StoragePolicy policy.StoragePolicy            `yaml:"storagePolicy"`
+	Sketch        raggregation.QuantileSketchType `yaml:"sketch"`
+}
+
+func (c *digestConfiguration) NewDigestOptions() (tdigest.Options, error) {
+	opts := tdigest.NewOptions()
+	if c.Compression != nil {
+		opts = opts.SetCompression(*c.Compression)
+	}
+	if c.Precision != nil {
+		opts = opts.SetPrecision(*c.Precision)
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
+func (c *digestConfiguration) NewTimerQuantileSketchFn() aggregator.TimerQuantileSketchFn {
+	var (
+		defaultSketch = c.Sketch
+		overrides     = make(map[policy.StoragePolicy]raggregation.QuantileSketchType, len(c.StoragePolicies))
+	)
+	for _, override := range c.StoragePolicies {
+		overrides[override.StoragePolicy] = override.Sketch
+	}
+	return func(sp policy.StoragePolicy) raggregation.QuantileSketchType {
+		if sketch, exists := overrides[sp]; exists {
+			return sketch
+		}
+		return defaultSketch
+	}
+}
+
 type placementManagerConfiguration struct {
 	KVConfig         kv.OverrideConfiguration       `yaml:"kvConfig"`
 	PlacementWatcher placement.WatcherConfiguration `yaml:"placementWatcher"`
@@ -836,6 +932,32 @@ func (c flushManagerConfiguration) NewFlushManagerOptions(
 
 // jitterBucket determines the max jitter percent for lists whose flush
 // intervals are no more than the bucket flush interval.
+type snapshotConfiguration struct {
+	// Directory where the snapshots are stored.
+	Dir string `yaml:"dir" validate:"nonzero"`
+
+	// How often the shards are snapshotted.
+	Interval time.Duration `yaml:"interval"`
+
+	// How long to wait for the flush times when restoring snapshots.
+	FlushTimesWaitTimeout time.Duration `yaml:"flushTimesWaitTimeout"`
+}
+
+func (c snapshotConfiguration) NewSnapshotManager(
+	instrumentOpts instrument.Options,
+) aggregator.SnapshotManager {
+	opts := aggregator.NewSnapshotManagerOptions().
+		SetInstrumentOptions(instrumentOpts).
+		SetSnapshotDir(c.Dir)
+	if c.Interval != 0 {
+		opts = opts.SetSnapshotInterval(c.Interval)
+	}
+	if c.FlushTimesWaitTimeout != 0 {
+		opts = opts.SetFlushTimesWaitTimeout(c.FlushTimesWaitTimeout)
+	}
+	return aggregator.NewSnapshotManager(opts)
+}
+
 type jitterBucket struct {
 	FlushInterval    time.Duration `yaml:"flushInterval" validate:"nonzero"`
 	MaxJitterPercent float64       `yaml:"maxJitterPercent" validate:"min=0.0,max=1.0"`
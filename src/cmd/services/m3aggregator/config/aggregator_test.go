@@ -24,6 +24,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/m3db/m3/src/aggregator/aggregation"
+	"github.com/m3db/m3/src/metrics/policy"
+	"github.com/m3db/m3/src/x/instrument"
+
 	"github.com/stretchr/testify/require"
 	yaml "gopkg.in/yaml.v2"
 )
@@ -118,3 +122,61 @@ func TestMaxAllowedForwardingDelayFnJitterDisabled(t *testing.T) {
 		require.Equal(t, input.expected, fn(input.resolution, input.numForwardedTimes))
 	}
 }
+
+func TestDigestConfiguration(t *testing.T) {
+	config := `
+compression: 50
+precision: 3
+sketch: cm
+storagePolicies:
+  - storagePolicy: 1m:40d
+    sketch: tdigest`
+
+	var cfg digestConfiguration
+	require.NoError(t, yaml.Unmarshal([]byte(config), &cfg))
+
+	opts, err := cfg.NewDigestOptions()
+	require.NoError(t, err)
+	require.Equal(t, 50.0, opts.Compression())
+	require.Equal(t, 3, opts.Precision())
+
+	sketchFn := cfg.NewTimerQuantileSketchFn()
+	require.Equal(t, aggregation.CMQuantileSketch,
+		sketchFn(policy.MustParseStoragePolicy("10s:2d")))
+	require.Equal(t, aggregation.TDigestQuantileSketch,
+		sketchFn(policy.MustParseStoragePolicy("1m:40d")))
+}
+
+func TestSnapshotConfiguration(t *testing.T) {
+	config := `
+snapshot:
+  dir: /var/lib/m3aggregator/snapshots
+  interval: 30s
+  flushTimesWaitTimeout: 5s`
+
+	var cfg AggregatorConfiguration
+	require.NoError(t, yaml.Unmarshal([]byte(config), &cfg))
+	require.NotNil(t, cfg.Snapshot)
+	require.Equal(t, "/var/lib/m3aggregator/snapshots", cfg.Snapshot.Dir)
+	require.Equal(t, 30*time.Second, cfg.Snapshot.Interval)
+	require.Equal(t, 5*time.Second, cfg.Snapshot.FlushTimesWaitTimeout)
+	require.NotNil(t, cfg.Snapshot.NewSnapshotManager(instrument.NewOptions()))
+
+	// Snapshots are disabled by default.
+	cfg = AggregatorConfiguration{}
+	require.NoError(t, yaml.Unmarshal([]byte(`verboseErrors: true`), &cfg))
+	require.Nil(t, cfg.Snapshot)
+}
+
+func TestCorrectionBuffersConfiguration(t *testing.T) {
+	config := `
+correctionBuffers:
+  - storagePolicy: 1m:40d
+    buffer: 1h`
+
+	var cfg AggregatorConfiguration
+	require.NoError(t, yaml.Unmarshal([]byte(config), &cfg))
+	fn := correctionBufferFn(cfg.CorrectionBuffers)
+	require.Equal(t, time.Hour, fn(policy.MustParseStoragePolicy("1m:40d")))
+	require.Equal(t, time.Duration(0), fn(policy.MustParseStoragePolicy("10s:2d")))
+}
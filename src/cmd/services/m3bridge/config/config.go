@@ -0,0 +1,51 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"github.com/m3db/m3/src/bridge/sink/kafka"
+	"github.com/m3db/m3/src/msg/consumer"
+	"github.com/m3db/m3/src/x/instrument"
+	"github.com/m3db/m3/src/x/pool"
+	"github.com/m3db/m3/src/x/server"
+
+	"go.uber.org/zap"
+)
+
+// Configuration is configuration for the bridge.
+type Configuration struct {
+	Metrics instrument.MetricsConfiguration `yaml:"metrics"`
+	Logging zap.Config                      `yaml:"logging"`
+	M3Msg   M3MsgConfiguration              `yaml:"m3msg"`
+	Kafka   kafka.Configuration             `yaml:"kafka"`
+}
+
+// M3MsgConfiguration configs the m3msg server metrics are consumed from.
+type M3MsgConfiguration struct {
+	// Server configs the server.
+	Server server.Configuration `yaml:"server"`
+
+	// Consumer configs the consumer.
+	Consumer consumer.Configuration `yaml:"consumer"`
+
+	// ProtobufDecoderPool configs the protobuf decoder pool.
+	ProtobufDecoderPool pool.ObjectPoolConfiguration `yaml:"protobufDecoderPool"`
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package downsample
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/m3db/m3/src/metrics/rules"
+	"github.com/m3db/m3/src/metrics/rules/view"
+	"github.com/m3db/m3/src/x/serialize"
+)
+
+// DryRunRules matches series, each given as a set of tags, against the rules
+// configured before and after a proposed change to the downsampling rules
+// without applying either of them. A nil rules configuration is treated as
+// having no rules. Auto mapping rules derived from aggregated namespaces are
+// not part of the results.
+func (o DownsamplerOptions) DryRunRules(
+	before, after *RulesConfiguration,
+	series []map[string]string,
+) (view.DryRunMatches, error) {
+	nowNanos := o.ClockOptions.NowFn()().UnixNano()
+	updateMetadata := rules.NewRuleSetUpdateHelper(0).
+		NewUpdateMetadata(nowNanos, "dry-run")
+
+	beforeRuleSet, err := newDryRunRuleSet(before, updateMetadata)
+	if err != nil {
+		return view.DryRunMatches{}, err
+	}
+	afterRuleSet, err := newDryRunRuleSet(after, updateMetadata)
+	if err != nil {
+		return view.DryRunMatches{}, err
+	}
+
+	pools := o.newAggregatorPools()
+	tagEncoder := pools.tagEncoderPool.Get()
+	defer tagEncoder.Finalize()
+
+	ids := make([][]byte, 0, len(series))
+	for _, tags := range series {
+		id, err := encodeDryRunTags(tagEncoder, tags)
+		if err != nil {
+			return view.DryRunMatches{}, err
+		}
+		ids = append(ids, id)
+	}
+
+	return rules.DryRun(beforeRuleSet, afterRuleSet, ids, rules.DryRunOptions{
+		RuleSetOptions: o.newAggregatorRulesOptions(pools),
+		TimeNanos:      nowNanos,
+		IDStringFn: func(id []byte) string {
+			return encodedTagsString(id, pools.metricTagsIteratorPool)
+		},
+	})
+}
+
+func newDryRunRuleSet(
+	cfg *RulesConfiguration,
+	updateMetadata rules.UpdateMetadata,
+) (rules.RuleSet, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	return cfg.newRuleSet(updateMetadata)
+}
+
+func encodeDryRunTags(
+	tagEncoder serialize.TagEncoder,
+	tagsByName map[string]string,
+) ([]byte, error) {
+	tags := newTags()
+	for name, value := range tagsByName {
+		tags.append([]byte(name), []byte(value))
+	}
+
+	id, err := encodeTags(tagEncoder, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	// Copy the encoded tags since the encoder is reused for each series.
+	return append([]byte(nil), id...), nil
+}
+
+// encodeTags sorts and encodes the tags, the returned ID is only valid
+// until the tag encoder is next reset.
+func encodeTags(tagEncoder serialize.TagEncoder, tags *tags) ([]byte, error) {
+	sort.Sort(tags)
+
+	tagEncoder.Reset()
+	if err := tagEncoder.Encode(tags); err != nil {
+		return nil, err
+	}
+	data, ok := tagEncoder.Data()
+	if !ok {
+		return nil, fmt.Errorf("unable to encode tags: names=%v, values=%v",
+			tags.names, tags.values)
+	}
+	return data.Bytes(), nil
+}
+
+// encodedTagsString returns the encoded tags of a metric ID in the
+// same format as tags are logged by the downsampler.
+func encodedTagsString(
+	id []byte,
+	iterPool serialize.MetricTagsIteratorPool,
+) string {
+	iter := iterPool.Get()
+	iter.Reset(id)
+	defer iter.Close()
+
+	var str strings.Builder
+	str.WriteString("{")
+	for i := 0; iter.Next(); i++ {
+		if i > 0 {
+			str.WriteString(",")
+		}
+		name, value := iter.Current()
+		str.Write(name)
+		str.WriteString("=\"")
+		str.Write(value)
+		str.WriteString("\"")
+	}
+	str.WriteString("}")
+	return str.String()
+}
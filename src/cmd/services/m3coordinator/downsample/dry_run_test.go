@@ -0,0 +1,197 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package downsample
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/metrics/aggregation"
+	"github.com/m3db/m3/src/metrics/policy"
+	"github.com/m3db/m3/src/metrics/rules/view"
+	"github.com/m3db/m3/src/metrics/transformation"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/pool"
+	"github.com/m3db/m3/src/x/serialize"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownsamplerOptionsDryRunRules(t *testing.T) {
+	var (
+		res    = time.Minute
+		ret    = 48 * time.Hour
+		sp     = policy.NewStoragePolicy(res, xtime.Minute, ret)
+		before = &RulesConfiguration{
+			MappingRules: []MappingRuleConfiguration{
+				{
+					Filter:       "app:nginx*",
+					Aggregations: []aggregation.Type{aggregation.Max},
+					StoragePolicies: []StoragePolicyConfiguration{
+						{Resolution: res, Retention: ret},
+					},
+				},
+			},
+		}
+		after = &RulesConfiguration{
+			MappingRules: []MappingRuleConfiguration{
+				{
+					Filter: "app:nginx*",
+					Drop:   true,
+				},
+			},
+			RollupRules: []RollupRuleConfiguration{
+				{
+					Filter: "__name__:http_requests app:*",
+					Transforms: []TransformConfiguration{
+						{
+							Rollup: &RollupOperationConfiguration{
+								MetricName:   "http_requests_by_app",
+								GroupBy:      []string{"app"},
+								Aggregations: []aggregation.Type{aggregation.Sum},
+							},
+						},
+					},
+					StoragePolicies: []StoragePolicyConfiguration{
+						{Resolution: res, Retention: ret},
+					},
+				},
+				{
+					Filter: "__name__:http_requests app:*",
+					Transforms: []TransformConfiguration{
+						{
+							Transform: &TransformOperationConfiguration{
+								Type: transformation.PerSecond,
+							},
+						},
+						{
+							Rollup: &RollupOperationConfiguration{
+								MetricName:   "http_requests_rate",
+								GroupBy:      []string{"app"},
+								Aggregations: []aggregation.Type{aggregation.Sum},
+							},
+						},
+					},
+					StoragePolicies: []StoragePolicyConfiguration{
+						{Resolution: res, Retention: ret},
+					},
+				},
+			},
+		}
+	)
+
+	result, err := newTestDryRunDownsamplerOptions().DryRunRules(before, after,
+		[]map[string]string{
+			{"__name__": "http_requests", "app": "nginx_edge", "status_code": "500"},
+			{"__name__": "http_requests", "app": "envoy"},
+			{"__name__": "cpu", "host": "a"},
+		})
+	require.NoError(t, err)
+	require.Equal(t, 3, len(result.Matches))
+
+	// The drop policy takes precedence over the rollup pipeline that keeps
+	// the existing ID, however rollups to new IDs are still produced.
+	nginx := result.Matches[0]
+	require.Equal(t,
+		`{__name__="http_requests",app="nginx_edge",status_code="500"}`, nginx.ID)
+	require.True(t, nginx.Changed)
+	require.Equal(t, view.MetricMatch{
+		Pipelines: view.PipelineMatches{
+			{
+				AggregationID:   aggregation.MustCompressTypes(aggregation.Max),
+				StoragePolicies: policy.StoragePolicies{sp},
+			},
+		},
+	}, nginx.Before)
+	require.True(t, nginx.After.Dropped)
+	require.Equal(t, view.PipelineMatches{{DropPolicy: policy.DropMust}},
+		nginx.After.Pipelines)
+	require.Equal(t, []view.RollupMatch{
+		{
+			ID: `{__name__="http_requests_by_app",__rollup__="true",app="nginx_edge"}`,
+			Pipelines: view.PipelineMatches{
+				{
+					AggregationID:   aggregation.MustCompressTypes(aggregation.Sum),
+					StoragePolicies: policy.StoragePolicies{sp},
+				},
+			},
+		},
+	}, nginx.After.Rollups)
+
+	envoy := result.Matches[1]
+	require.True(t, envoy.Changed)
+	require.Equal(t, view.MetricMatch{}, envoy.Before)
+	require.False(t, envoy.After.Dropped)
+	require.Equal(t, view.PipelineMatches{
+		{
+			StoragePolicies: policy.StoragePolicies{sp},
+			Pipeline: []string{
+				"transformation(PerSecond)",
+				`rollup({__name__="http_requests_rate",__rollup__="true",app="envoy"}, Sum)`,
+			},
+		},
+	}, envoy.After.Pipelines)
+	require.Equal(t, 1, len(envoy.After.Rollups))
+
+	cpu := result.Matches[2]
+	require.Equal(t, `{__name__="cpu",host="a"}`, cpu.ID)
+	require.False(t, cpu.Changed)
+	require.Equal(t, view.MetricMatch{}, cpu.After)
+}
+
+func TestDownsamplerOptionsDryRunRulesNilRules(t *testing.T) {
+	result, err := newTestDryRunDownsamplerOptions().DryRunRules(nil, &RulesConfiguration{
+		MappingRules: []MappingRuleConfiguration{
+			{Filter: "app:*", Drop: true},
+		},
+	}, []map[string]string{{"__name__": "cpu", "app": "a"}})
+	require.NoError(t, err)
+	require.Equal(t, []view.DryRunMatch{
+		{
+			ID: `{__name__="cpu",app="a"}`,
+			After: view.MetricMatch{
+				Dropped:   true,
+				Pipelines: view.PipelineMatches{{DropPolicy: policy.DropMust}},
+			},
+			Changed: true,
+		},
+	}, result.Matches)
+}
+
+func TestDownsamplerOptionsDryRunRulesInvalidRule(t *testing.T) {
+	_, err := newTestDryRunDownsamplerOptions().DryRunRules(nil, &RulesConfiguration{
+		MappingRules: []MappingRuleConfiguration{
+			{Filter: "app:re:(abc", Drop: true},
+		},
+	}, []map[string]string{{"__name__": "cpu", "app": "a"}})
+	require.Error(t, err)
+}
+
+func newTestDryRunDownsamplerOptions() DownsamplerOptions {
+	return DownsamplerOptions{
+		ClockOptions:          clock.NewOptions(),
+		TagEncoderOptions:     serialize.NewTagEncoderOptions(),
+		TagDecoderOptions:     serialize.NewTagDecoderOptions(serialize.TagDecoderOptionsConfig{}),
+		TagEncoderPoolOptions: pool.NewObjectPoolOptions().SetSize(2),
+		TagDecoderPoolOptions: pool.NewObjectPoolOptions().SetSize(2),
+	}
+}
@@ -93,6 +93,7 @@ var (
 	errNoTagEncoderPoolOptions = errors.New("dynamic downsampling enabled with tag encoder pool options not set")
 	errNoTagDecoderPoolOptions = errors.New("dynamic downsampling enabled with tag decoder pool options not set")
 	errRollupRuleNoTransforms  = errors.New("rollup rule has no transforms set")
+	errRollupGroupByExcludeBy  = errors.New("rollup operation has both group by and exclude by set")
 )
 
 // DownsamplerOptions is a set of required downsampler options.
@@ -225,11 +226,46 @@ type RulesConfiguration struct {
 	RollupRules []RollupRuleConfiguration `yaml:"rollupRules"`
 }
 
+// newRuleSet creates a ruleset in the default in-memory namespace
+// containing the configured rules.
+func (c RulesConfiguration) newRuleSet(
+	updateMetadata rules.UpdateMetadata,
+) (rules.MutableRuleSet, error) {
+	rs := rules.NewEmptyRuleSet(defaultConfigInMemoryNamespace,
+		updateMetadata)
+	for _, mappingRule := range c.MappingRules {
+		rule, err := mappingRule.Rule()
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = rs.AddMappingRule(rule, updateMetadata)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, rollupRule := range c.RollupRules {
+		rule, err := rollupRule.Rule()
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = rs.AddRollupRule(rule, updateMetadata)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return rs, nil
+}
+
 // MappingRuleConfiguration is a mapping rule configuration.
 type MappingRuleConfiguration struct {
 	// Filter is a string separated filter of label name to label value
-	// glob patterns to filter the mapping rule to.
-	// e.g. "app:*nginx* foo:bar baz:qux*qaz*"
+	// glob patterns to filter the mapping rule to. Values prefixed with "re:"
+	// are fully anchored regular expressions instead of glob patterns.
+	// e.g. "app:*nginx* foo:bar baz:qux*qaz* pod:re:api-[0-9a-f]{8}-.*"
 	Filter string `yaml:"filter"`
 
 	// Aggregations is the aggregations to apply to the set of metrics.
@@ -256,6 +292,7 @@ type MappingRuleConfiguration struct {
 	// - "P99"
 	// - "P999"
 	// - "P9999"
+	// - "CountDistinct"
 	Aggregations []aggregation.Type `yaml:"aggregations"`
 
 	// StoragePolicies are retention/resolution storage policies at which to
@@ -340,8 +377,9 @@ func (p StoragePolicyConfigurations) StoragePolicies() (policy.StoragePolicies,
 // RollupRuleConfiguration is a rollup rule configuration.
 type RollupRuleConfiguration struct {
 	// Filter is a space separated filter of label name to label value glob
-	// patterns to which to filter the mapping rule.
-	// e.g. "app:*nginx* foo:bar baz:qux*qaz*"
+	// patterns to which to filter the mapping rule. Values prefixed with "re:"
+	// are fully anchored regular expressions instead of glob patterns.
+	// e.g. "app:*nginx* foo:bar baz:qux*qaz* pod:re:api-[0-9a-f]{8}-.*"
 	Filter string `yaml:"filter"`
 
 	// Transforms are a set of of rollup rule transforms.
@@ -382,11 +420,19 @@ func (r RollupRuleConfiguration) Rule() (view.RollupRule, error) {
 			if err != nil {
 				return view.RollupRule{}, err
 			}
+			rollupType, tags := pipelinepb.RollupOp_GROUP_BY, cfg.GroupBy
+			if len(cfg.ExcludeBy) > 0 {
+				if len(cfg.GroupBy) > 0 {
+					return view.RollupRule{}, errRollupGroupByExcludeBy
+				}
+				rollupType, tags = pipelinepb.RollupOp_EXCLUDE_BY, cfg.ExcludeBy
+			}
 			op, err := pipeline.NewOpUnionFromProto(pipelinepb.PipelineOp{
 				Type: pipelinepb.PipelineOp_ROLLUP,
 				Rollup: &pipelinepb.RollupOp{
+					Type:             rollupType,
 					NewName:          cfg.MetricName,
-					Tags:             cfg.GroupBy,
+					Tags:             tags,
 					AggregationTypes: aggregationTypes,
 				},
 			})
@@ -417,10 +463,14 @@ func (r RollupRuleConfiguration) Rule() (view.RollupRule, error) {
 			if err != nil {
 				return view.RollupRule{}, err
 			}
+			if err := cfg.Type.ValidateArgs(cfg.Args); err != nil {
+				return view.RollupRule{}, err
+			}
 			op, err := pipeline.NewOpUnionFromProto(pipelinepb.PipelineOp{
 				Type: pipelinepb.PipelineOp_TRANSFORMATION,
 				Transformation: &pipelinepb.TransformationOp{
 					Type: transformType,
+					Args: cfg.Args,
 				},
 			})
 			if err != nil {
@@ -469,6 +519,11 @@ type RollupOperationConfiguration struct {
 	// new metric name produced by the rollup operation.
 	GroupBy []string `yaml:"groupBy"`
 
+	// ExcludeBy is a set of labels to exclude, all other labels remain on
+	// the new metric name produced by the rollup operation. Only one of
+	// GroupBy or ExcludeBy may be set.
+	ExcludeBy []string `yaml:"excludeBy"`
+
 	// Aggregations is a set of aggregate operations to perform.
 	Aggregations []aggregation.Type `yaml:"aggregations"`
 }
@@ -483,6 +538,10 @@ type AggregateOperationConfiguration struct {
 type TransformOperationConfiguration struct {
 	// Type is a transformation operation type.
 	Type transformation.Type `yaml:"type"`
+
+	// Args are the arguments of parameterized transformation types, such as
+	// the factor of a scale transformation or the bounds of a clamp transformation.
+	Args []float64 `yaml:"args"`
 }
 
 // AggregationTypes is a set of aggregation types.
@@ -621,30 +680,9 @@ func (cfg Configuration) newAggregator(o DownsamplerOptions) (agg, error) {
 		}
 
 		// Create the ruleset in the default namespace.
-		rs := rules.NewEmptyRuleSet(defaultConfigInMemoryNamespace,
-			updateMetadata)
-		for _, mappingRule := range cfg.Rules.MappingRules {
-			rule, err := mappingRule.Rule()
-			if err != nil {
-				return agg{}, err
-			}
-
-			_, err = rs.AddMappingRule(rule, updateMetadata)
-			if err != nil {
-				return agg{}, err
-			}
-		}
-
-		for _, rollupRule := range cfg.Rules.RollupRules {
-			rule, err := rollupRule.Rule()
-			if err != nil {
-				return agg{}, err
-			}
-
-			_, err = rs.AddRollupRule(rule, updateMetadata)
-			if err != nil {
-				return agg{}, err
-			}
+		rs, err := cfg.Rules.newRuleSet(updateMetadata)
+		if err != nil {
+			return agg{}, err
 		}
 
 		if err := rulesStore.WriteAll(ruleNamespaces, rs); err != nil {
@@ -0,0 +1,89 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package downsample
+
+import (
+	"github.com/m3db/m3/src/metrics/filters"
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/serialize"
+)
+
+// RuleFilter matches series against the filter of a mapping or rollup rule
+// the same way the downsampler matches the series it receives.
+type RuleFilter struct {
+	filter     filters.Filter
+	tagEncoder serialize.TagEncoder
+	tags       *tags
+}
+
+// NewRuleFilter returns a new rule filter for a mapping or rollup rule
+// filter, the rule filter is not safe for concurrent use and must be
+// closed once it is no longer required.
+func (o DownsamplerOptions) NewRuleFilter(filter string) (*RuleFilter, error) {
+	values, err := filters.ParseTagFilterValueMap(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	pools := o.newAggregatorPools()
+	tagsFilter, err := filters.NewTagsFilter(values, filters.Conjunction,
+		o.newAggregatorRulesOptions(pools).TagsFilterOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	return &RuleFilter{
+		filter:     tagsFilter,
+		tagEncoder: pools.tagEncoderPool.Get(),
+		tags:       newTags(),
+	}, nil
+}
+
+// Matches returns whether a series with the given tags matches the filter.
+func (f *RuleFilter) Matches(it ident.TagIterator) (bool, error) {
+	f.tags.names = f.tags.names[:0]
+	f.tags.values = f.tags.values[:0]
+	f.tags.idx = -1
+
+	it = it.Duplicate()
+	defer it.Close()
+
+	for it.Next() {
+		tag := it.Current()
+		// Copy the tag bytes since the iterator may reuse them.
+		f.tags.append(append([]byte(nil), tag.Name.Bytes()...),
+			append([]byte(nil), tag.Value.Bytes()...))
+	}
+	if err := it.Err(); err != nil {
+		return false, err
+	}
+
+	id, err := encodeTags(f.tagEncoder, f.tags)
+	if err != nil {
+		return false, err
+	}
+	return f.filter.Matches(id), nil
+}
+
+// Close releases the resources held by the rule filter.
+func (f *RuleFilter) Close() {
+	f.tagEncoder.Finalize()
+}
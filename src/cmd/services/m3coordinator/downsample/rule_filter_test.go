@@ -0,0 +1,89 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package downsample
+
+import (
+	"testing"
+
+	"github.com/m3db/m3/src/x/ident"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuleFilterMatches(t *testing.T) {
+	filter, err := newTestDryRunDownsamplerOptions().
+		NewRuleFilter("__name__:http_* app:re:nginx|envoy status:!5*")
+	require.NoError(t, err)
+	defer filter.Close()
+
+	tests := []struct {
+		tags     map[string]string
+		expected bool
+	}{
+		{
+			tags:     map[string]string{"__name__": "http_requests", "app": "nginx", "status": "200"},
+			expected: true,
+		},
+		{
+			tags:     map[string]string{"app": "envoy", "__name__": "http_errors", "status": "404"},
+			expected: true,
+		},
+		{
+			tags:     map[string]string{"__name__": "http_requests", "app": "nginx", "status": "500"},
+			expected: false,
+		},
+		{
+			tags:     map[string]string{"__name__": "http_requests", "app": "nginx-proxy", "status": "200"},
+			expected: false,
+		},
+		{
+			tags:     map[string]string{"__name__": "http_requests", "app": "nginx"},
+			expected: false,
+		},
+		{
+			tags:     map[string]string{"__name__": "grpc_requests", "app": "nginx", "status": "200"},
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		matched, err := filter.Matches(ident.NewTagsIterator(ident.NewTags(
+			testRuleFilterTags(test.tags)...)))
+		require.NoError(t, err)
+		require.Equal(t, test.expected, matched, "tags: %v", test.tags)
+	}
+}
+
+func TestNewRuleFilterInvalidFilter(t *testing.T) {
+	_, err := newTestDryRunDownsamplerOptions().NewRuleFilter("app:re:(abc")
+	require.Error(t, err)
+
+	_, err = newTestDryRunDownsamplerOptions().NewRuleFilter("app")
+	require.Error(t, err)
+}
+
+func testRuleFilterTags(tagsByName map[string]string) []ident.Tag {
+	tags := make([]ident.Tag, 0, len(tagsByName))
+	for name, value := range tagsByName {
+		tags = append(tags, ident.StringTag(name, value))
+	}
+	return tags
+}
@@ -626,6 +626,7 @@ func TestConfiguration(t *testing.T) {
           keepAlive: null
           tls: null
           autoSyncInterval: 0s
+        fileStore: null
         m3sd:
           initTimeout: null
         watchWithRevision: 0
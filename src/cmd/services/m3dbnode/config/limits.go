@@ -30,7 +30,9 @@ type Limits struct {
 	MaxOutstandingWriteRequests int `yaml:"maxOutstandingWriteRequests" validate:"min=0"`
 	// MaxOutstandingReadRequests controls the maximum number of outstanding read requests that
 	// the server will allow before it begins rejecting requests. Just like MaxOutstandingWriteRequests
-	// this value is independent of the number of time series being read.
+	// this value is independent of the number of time series being read. It can
+	// be changed at runtime through the m3db.node.max-outstanding-read-requests
+	// KV key.
 	MaxOutstandingReadRequests int `yaml:"maxOutstandingReadRequests" validate:"min=0"`
 
 	// MaxOutstandingRepairedBytes controls the maximum number of bytes that can be loaded into memory
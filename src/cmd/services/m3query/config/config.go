@@ -26,12 +26,15 @@ import (
 	"time"
 
 	etcdclient "github.com/m3db/m3/src/cluster/client/etcd"
+	"github.com/m3db/m3/src/cluster/kv/util/history"
 	"github.com/m3db/m3/src/cmd/services/m3coordinator/downsample"
 	ingestm3msg "github.com/m3db/m3/src/cmd/services/m3coordinator/ingest/m3msg"
 	"github.com/m3db/m3/src/cmd/services/m3coordinator/server/m3msg"
 	"github.com/m3db/m3/src/metrics/aggregation"
+	"github.com/m3db/m3/src/query/alerting"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
 	"github.com/m3db/m3/src/query/graphite/graphite"
+	"github.com/m3db/m3/src/query/metadata"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/storage/m3"
@@ -131,6 +134,9 @@ type Configuration struct {
 	// Carbon is the carbon configuration.
 	Carbon *CarbonConfiguration `yaml:"carbon"`
 
+	// InfluxDB is the InfluxDB compatible endpoints configuration.
+	InfluxDB InfluxDBConfiguration `yaml:"influxdb"`
+
 	// Query is the query configuration.
 	Query QueryConfiguration `yaml:"query"`
 
@@ -143,6 +149,19 @@ type Configuration struct {
 	// ResultOptions are the results options for query.
 	ResultOptions ResultOptions `yaml:"resultOptions"`
 
+	// Alerting is the configuration for evaluating Prometheus-format
+	// alerting rules, if not set no rules are evaluated.
+	Alerting *alerting.Configuration `yaml:"alerting"`
+
+	// Metadata is the configuration for storing Prometheus metric metadata
+	// received via remote write, if not set metadata is dropped.
+	Metadata *metadata.Configuration `yaml:"metadata"`
+
+	// HistoryLimit is the number of previous placements, namespace registries
+	// and runtime option values kept in their history by the cluster
+	// management endpoints, zero keeps no history.
+	HistoryLimit *int `yaml:"historyLimit"`
+
 	// Experimental is the configuration for the experimental API group.
 	Experimental ExperimentalAPIConfiguration `yaml:"experimental"`
 
@@ -325,6 +344,15 @@ type CarbonIngesterConfiguration struct {
 	Rules           []CarbonIngesterRuleConfiguration `yaml:"rules"`
 }
 
+// InfluxDBConfiguration is the configuration for the InfluxDB compatible
+// endpoints.
+type InfluxDBConfiguration struct {
+	// StringFieldsAsAnnotations attaches the string fields of each written
+	// point, JSON encoded, as an annotation to the series of its numeric
+	// fields. If not set string fields are dropped.
+	StringFieldsAsAnnotations bool `yaml:"stringFieldsAsAnnotations"`
+}
+
 // LookbackDurationOrDefault validates the LookbackDuration
 func (c Configuration) LookbackDurationOrDefault() (time.Duration, error) {
 	if c.LookbackDuration == nil {
@@ -339,6 +367,20 @@ func (c Configuration) LookbackDurationOrDefault() (time.Duration, error) {
 	return v, nil
 }
 
+// HistoryLimitOrDefault validates the HistoryLimit
+func (c Configuration) HistoryLimitOrDefault() (int, error) {
+	if c.HistoryLimit == nil {
+		return history.DefaultLimit, nil
+	}
+
+	v := *c.HistoryLimit
+	if v < 0 {
+		return 0, errors.New("historyLimit must be >= 0")
+	}
+
+	return v, nil
+}
+
 // ListenAddressOrDefault returns the specified carbon ingester listen address if provided, or the
 // default value if not.
 func (c *CarbonIngesterConfiguration) ListenAddressOrDefault() string {
@@ -461,6 +503,32 @@ type RemoteConfiguration struct {
 	//
 	// NB: defaults to warning on error.
 	ErrorBehavior *storage.ErrorBehavior `yaml:"errorBehavior"`
+	// Required marks the remote zone as required, failing queries if it
+	// fails, or as best-effort, returning partial results with warnings if
+	// it fails. If set it overrides ErrorBehavior.
+	Required *bool `yaml:"required"`
+	// Timeout is the deadline for each request to the remote zone, if not
+	// set requests are bounded only by the query timeout.
+	Timeout time.Duration `yaml:"timeout"`
+	// HedgeDelay, if set, is how long to wait for a coordinator in the
+	// remote zone to respond before also sending the request to another
+	// coordinator in the zone, using the first successful response.
+	HedgeDelay time.Duration `yaml:"hedgeDelay"`
+	// CircuitBreaker, if set, skips the remote zone after consecutive
+	// failures.
+	CircuitBreaker *CircuitBreakerConfiguration `yaml:"circuitBreaker"`
+}
+
+// CircuitBreakerConfiguration is the configuration for a circuit breaker
+// that stops sending requests to a remote zone which is persistently
+// failing.
+type CircuitBreakerConfiguration struct {
+	// FailureThreshold is the number of consecutive failures after which
+	// the circuit opens and requests are skipped.
+	FailureThreshold int `yaml:"failureThreshold"`
+	// OpenDuration is how long requests are skipped for before a single
+	// request is let through to probe whether the remote zone recovered.
+	OpenDuration time.Duration `yaml:"openDuration"`
 }
 
 // RPCConfiguration is the RPC configuration for the coordinator for
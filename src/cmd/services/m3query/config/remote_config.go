@@ -21,6 +21,8 @@
 package config
 
 import (
+	"time"
+
 	"github.com/m3db/m3/src/query/storage"
 )
 
@@ -32,6 +34,14 @@ type Remote struct {
 	Name string
 	// Addresses are the remote addresses for this client.
 	Addresses []string
+	// Timeout is the deadline for each request to this remote, zero if
+	// requests are bounded only by the query timeout.
+	Timeout time.Duration
+	// HedgeDelay is the delay after which requests are hedged to another
+	// address, zero if requests are not hedged.
+	HedgeDelay time.Duration
+	// CircuitBreaker is the circuit breaker configuration, nil if disabled.
+	CircuitBreaker *CircuitBreakerConfiguration
 }
 
 func makeRemote(
@@ -47,6 +57,20 @@ func makeRemote(
 	return Remote{Name: name, Addresses: addresses, ErrorBehavior: global}
 }
 
+// errorBehavior returns the error behavior override for the remote, a
+// required remote fails queries and a best-effort one warns.
+func (c RemoteConfiguration) errorBehavior() *storage.ErrorBehavior {
+	if c.Required == nil {
+		return c.ErrorBehavior
+	}
+
+	behavior := storage.BehaviorWarn
+	if *c.Required {
+		behavior = storage.BehaviorFail
+	}
+	return &behavior
+}
+
 // RemoteOptions are the options for RPC configurations.
 type RemoteOptions interface {
 	// ServeEnabled describes if this RPC should serve rpc requests.
@@ -95,8 +119,12 @@ func RemoteOptionsFromConfig(cfg *RPCConfiguration) RemoteOptions {
 	}
 
 	for _, remote := range cfg.Remotes {
-		remotes = append(remotes, makeRemote(remote.Name,
-			remote.RemoteListenAddresses, defaultBehavior, remote.ErrorBehavior))
+		r := makeRemote(remote.Name, remote.RemoteListenAddresses,
+			defaultBehavior, remote.errorBehavior())
+		r.Timeout = remote.Timeout
+		r.HedgeDelay = remote.HedgeDelay
+		r.CircuitBreaker = remote.CircuitBreaker
+		remotes = append(remotes, r)
 	}
 
 	return &remoteOptions{
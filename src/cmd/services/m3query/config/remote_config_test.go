@@ -22,6 +22,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/m3db/m3/src/query/storage"
 
@@ -177,6 +178,44 @@ remotes:
 			},
 		},
 	},
+	{
+		name: "required and best-effort",
+		cfg: `
+errorBehavior: "fail"
+remotes:
+ - name: "foo"
+   remoteListenAddresses: ["abc","def"]
+   required: false
+   timeout: 5s
+   hedgeDelay: 100ms
+   circuitBreaker:
+     failureThreshold: 3
+     openDuration: 1m
+ - name: "bar"
+   remoteListenAddresses: ["ghi"]
+   errorBehavior: "warn"
+   required: true
+`,
+		listenEnabled: true,
+		remotes: []Remote{
+			Remote{
+				ErrorBehavior: storage.BehaviorWarn,
+				Name:          "foo",
+				Addresses:     []string{"abc", "def"},
+				Timeout:       5 * time.Second,
+				HedgeDelay:    100 * time.Millisecond,
+				CircuitBreaker: &CircuitBreakerConfiguration{
+					FailureThreshold: 3,
+					OpenDuration:     time.Minute,
+				},
+			},
+			Remote{
+				ErrorBehavior: storage.BehaviorFail,
+				Name:          "bar",
+				Addresses:     []string{"ghi"},
+			},
+		},
+	},
 	{
 		name: "mixed disabled",
 		cfg: `
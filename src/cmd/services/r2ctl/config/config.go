@@ -30,6 +30,8 @@ import (
 	r2store "github.com/m3db/m3/src/ctl/service/r2/store"
 	r2kv "github.com/m3db/m3/src/ctl/service/r2/store/kv"
 	"github.com/m3db/m3/src/ctl/service/r2/store/stub"
+	"github.com/m3db/m3/src/metrics/filters"
+	"github.com/m3db/m3/src/metrics/metric/id/m3"
 	"github.com/m3db/m3/src/metrics/rules"
 	ruleskv "github.com/m3db/m3/src/metrics/rules/store/kv"
 	"github.com/m3db/m3/src/metrics/rules/validator"
@@ -37,6 +39,10 @@ import (
 	"github.com/m3db/m3/src/x/log"
 )
 
+const (
+	defaultNameTagKey = "name"
+)
+
 var (
 	errKVConfigRequired = errors.New("must provide kv configuration if not using stub store")
 )
@@ -100,6 +106,10 @@ type kvStoreConfig struct {
 
 	// Validation configuration.
 	Validation *validator.Configuration `yaml:"validation"`
+
+	// NameTagKey is the tag rule filters use to match the metric name
+	// when dry running rulesets against metric IDs.
+	NameTagKey string `yaml:"nameTagKey"`
 }
 
 // NewStore creates a new KV backed R2 store.
@@ -131,6 +141,27 @@ func (c kvStoreConfig) NewStore(instrumentOpts instrument.Options) (r2store.Stor
 	r2StoreOpts := r2kv.NewStoreOptions().
 		SetInstrumentOptions(instrumentOpts).
 		SetRuleUpdatePropagationDelay(c.PropagationDelay).
-		SetValidator(validator)
+		SetValidator(validator).
+		SetRuleSetOptions(c.newRuleSetOptions())
 	return r2kv.NewStore(rulesStore, r2StoreOpts), nil
 }
+
+// newRuleSetOptions creates the options to match m3 metric IDs against rulesets.
+func (c kvStoreConfig) newRuleSetOptions() rules.Options {
+	nameTagKey := c.NameTagKey
+	if nameTagKey == "" {
+		nameTagKey = defaultNameTagKey
+	}
+	tagsFilterOpts := filters.TagsFilterOptions{
+		NameTagKey:          []byte(nameTagKey),
+		NameAndTagsFn:       m3.NameAndTags,
+		SortedTagIteratorFn: m3.NewSortedTagIterator,
+	}
+	isRollupIDFn := func(name []byte, tags []byte) bool {
+		return m3.IsRollupID(name, tags, nil)
+	}
+	return rules.NewOptions().
+		SetTagsFilterOptions(tagsFilterOpts).
+		SetNewRollupIDFn(m3.NewRollupID).
+		SetIsRollupIDFn(isRollupIDFn)
+}
@@ -28,6 +28,7 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/m3db/m3/src/metrics/rules/view"
 	"github.com/m3db/m3/src/metrics/rules/view/changes"
 
 	validator "gopkg.in/go-playground/validator.v9"
@@ -88,3 +89,11 @@ type updateRuleSetRequest struct {
 	RuleSetChanges changes.RuleSetChanges `json:"rulesetChanges"`
 	RuleSetVersion int                    `json:"rulesetVersion"`
 }
+
+// dryRunRuleSetRequest carries either a proposed ruleset or a set of changes
+// against the current ruleset, alongside the metric IDs to match.
+type dryRunRuleSetRequest struct {
+	RuleSet        *view.RuleSet           `json:"ruleset,omitempty"`
+	RuleSetChanges *changes.RuleSetChanges `json:"rulesetChanges,omitempty"`
+	IDs            []string                `json:"ids" validate:"required"`
+}
@@ -93,6 +93,43 @@ func updateRuleSet(s *service, r *http.Request) (data interface{}, err error) {
 	return s.store.UpdateRuleSet(req.RuleSetChanges, req.RuleSetVersion, uOpts)
 }
 
+func dryRunRuleSet(s *service, r *http.Request) (data interface{}, err error) {
+	vars := mux.Vars(r)
+	var req dryRunRuleSetRequest
+	if err := parseRequest(&req, r.Body); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case req.RuleSet != nil && req.RuleSetChanges != nil:
+		return nil, NewBadInputError(
+			"invalid request: only one of ruleset and ruleset changes can be specified",
+		)
+	case req.RuleSet != nil:
+		if vars[namespaceIDVar] != req.RuleSet.Namespace {
+			return nil, NewBadInputError(fmt.Sprintf(
+				"namespaceID param %s and ruleset namespaceID %s do not match",
+				vars[namespaceIDVar],
+				req.RuleSet.Namespace,
+			))
+		}
+		return s.store.DryRunRuleSet(*req.RuleSet, req.IDs)
+	case req.RuleSetChanges != nil:
+		if vars[namespaceIDVar] != req.RuleSetChanges.Namespace {
+			return nil, NewBadInputError(fmt.Sprintf(
+				"namespaceID param %s and ruleset changes namespaceID %s do not match",
+				vars[namespaceIDVar],
+				req.RuleSetChanges.Namespace,
+			))
+		}
+		return s.store.DryRunRuleSetChanges(*req.RuleSetChanges, req.IDs)
+	default:
+		return nil, NewBadInputError(
+			"invalid request: one of ruleset and ruleset changes must be specified",
+		)
+	}
+}
+
 func deleteNamespace(s *service, r *http.Request) (data interface{}, err error) {
 	vars := mux.Vars(r)
 	namespaceID := vars[namespaceIDVar]
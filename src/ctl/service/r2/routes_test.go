@@ -294,6 +294,122 @@ func TestUpdateRuleSetEmptyRequest(t *testing.T) {
 	println(err.Error())
 }
 
+func TestDryRunRuleSetChanges(t *testing.T) {
+	namespaceID := "testNamespace"
+	body := &dryRunRuleSetRequest{
+		RuleSetChanges: &changes.RuleSetChanges{Namespace: namespaceID},
+		IDs:            []string{"foo+bar=baz"},
+	}
+	req := newTestDryRunRequest(t, namespaceID, body)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	expected := view.DryRunMatches{
+		Matches: []view.DryRunMatch{
+			{ID: "foo+bar=baz", After: view.MetricMatch{Dropped: true}, Changed: true},
+		},
+	}
+	storeMock := store.NewMockStore(ctrl)
+	storeMock.EXPECT().
+		DryRunRuleSetChanges(*body.RuleSetChanges, body.IDs).
+		Return(expected, nil)
+
+	resp, err := dryRunRuleSet(newTestService(storeMock), req)
+	require.NoError(t, err)
+	require.Equal(t, expected, resp)
+}
+
+func TestDryRunRuleSet(t *testing.T) {
+	namespaceID := "testNamespace"
+	body := &dryRunRuleSetRequest{
+		RuleSet: &view.RuleSet{Namespace: namespaceID},
+		IDs:     []string{"foo+bar=baz"},
+	}
+	req := newTestDryRunRequest(t, namespaceID, body)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	storeMock := store.NewMockStore(ctrl)
+	storeMock.EXPECT().
+		DryRunRuleSet(*body.RuleSet, body.IDs).
+		Return(view.DryRunMatches{}, nil)
+
+	resp, err := dryRunRuleSet(newTestService(storeMock), req)
+	require.NoError(t, err)
+	require.Equal(t, view.DryRunMatches{}, resp)
+}
+
+func TestDryRunRuleSetBadRequest(t *testing.T) {
+	namespaceID := "testNamespace"
+	tests := []struct {
+		name string
+		body *dryRunRuleSetRequest
+	}{
+		{
+			name: "no ids",
+			body: &dryRunRuleSetRequest{
+				RuleSet: &view.RuleSet{Namespace: namespaceID},
+			},
+		},
+		{
+			name: "no ruleset or changes",
+			body: &dryRunRuleSetRequest{IDs: []string{"foo"}},
+		},
+		{
+			name: "both ruleset and changes",
+			body: &dryRunRuleSetRequest{
+				RuleSet:        &view.RuleSet{Namespace: namespaceID},
+				RuleSetChanges: &changes.RuleSetChanges{Namespace: namespaceID},
+				IDs:            []string{"foo"},
+			},
+		},
+		{
+			name: "ruleset namespace mismatch",
+			body: &dryRunRuleSetRequest{
+				RuleSet: &view.RuleSet{Namespace: "other"},
+				IDs:     []string{"foo"},
+			},
+		},
+		{
+			name: "ruleset changes namespace mismatch",
+			body: &dryRunRuleSetRequest{
+				RuleSetChanges: &changes.RuleSetChanges{Namespace: "other"},
+				IDs:            []string{"foo"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			storeMock := store.NewMockStore(ctrl)
+
+			req := newTestDryRunRequest(t, namespaceID, test.body)
+			resp, err := dryRunRuleSet(newTestService(storeMock), req)
+			require.Nil(t, resp)
+			require.Error(t, err)
+			require.IsType(t, NewBadInputError(""), err)
+		})
+	}
+}
+
+func newTestDryRunRequest(
+	t *testing.T,
+	namespaceID string,
+	body *dryRunRuleSetRequest,
+) *http.Request {
+	bodyBytes, err := json.Marshal(body)
+	require.NoError(t, err)
+	req, err := http.NewRequest(
+		http.MethodPost,
+		fmt.Sprintf("/namespaces/%s/ruleset/dry-run", namespaceID),
+		bytes.NewBuffer(bodyBytes),
+	)
+	require.NoError(t, err)
+	return mux.SetURLVars(req, map[string]string{"namespaceID": namespaceID})
+}
+
 func newTestService(store store.Store) *service {
 	if store == nil {
 		store = newMockStore()
@@ -412,6 +528,14 @@ func (s mockStore) UpdateRuleSet(rsChanges changes.RuleSetChanges, version int,
 	return view.RuleSet{}, nil
 }
 
+func (s mockStore) DryRunRuleSet(rs view.RuleSet, ids []string) (view.DryRunMatches, error) {
+	return view.DryRunMatches{}, nil
+}
+
+func (s mockStore) DryRunRuleSetChanges(rsChanges changes.RuleSetChanges, ids []string) (view.DryRunMatches, error) {
+	return view.DryRunMatches{}, nil
+}
+
 func (s mockStore) CreateNamespace(namespaceID string, uOpts store.UpdateOptions) (view.Namespace, error) {
 	return view.Namespace{}, nil
 }
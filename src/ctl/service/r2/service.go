@@ -49,6 +49,7 @@ var (
 	namespacePrefix     = fmt.Sprintf("%s/{%s}", namespacePath, namespaceIDVar)
 	validateRuleSetPath = fmt.Sprintf("%s/{%s}/ruleset/validate", namespacePath, namespaceIDVar)
 	updateRuleSetPath   = fmt.Sprintf("%s/{%s}/ruleset/update", namespacePath, namespaceIDVar)
+	dryRunRuleSetPath   = fmt.Sprintf("%s/{%s}/ruleset/dry-run", namespacePath, namespaceIDVar)
 
 	mappingRuleRoot        = fmt.Sprintf("%s/%s", namespacePrefix, mappingRulePrefix)
 	mappingRuleWithIDPath  = fmt.Sprintf("%s/{%s}", mappingRuleRoot, ruleIDVar)
@@ -78,6 +79,7 @@ type serviceMetrics struct {
 	deleteRollupRule        instrument.MethodMetrics
 	fetchRollupRuleHistory  instrument.MethodMetrics
 	updateRuleSet           instrument.MethodMetrics
+	dryRunRuleSet           instrument.MethodMetrics
 }
 
 func newServiceMetrics(scope tally.Scope, samplingRate float64) serviceMetrics {
@@ -98,12 +100,15 @@ func newServiceMetrics(scope tally.Scope, samplingRate float64) serviceMetrics {
 		deleteRollupRule:        instrument.NewMethodMetrics(scope, "deleteRollupRule", samplingRate),
 		fetchRollupRuleHistory:  instrument.NewMethodMetrics(scope, "fetchRollupRuleHistory", samplingRate),
 		updateRuleSet:           instrument.NewMethodMetrics(scope, "updateRuleSet", samplingRate),
+		dryRunRuleSet:           instrument.NewMethodMetrics(scope, "dryRunRuleSet", samplingRate),
 	}
 }
 
 var authorizationRegistry = map[route]auth.AuthorizationType{
 	// This validation route should only require read access.
 	{path: validateRuleSetPath, method: http.MethodPost}: auth.ReadOnlyAuthorization,
+	// Dry runs never persist changes so should only require read access.
+	{path: dryRunRuleSetPath, method: http.MethodPost}: auth.ReadOnlyAuthorization,
 }
 
 func defaultAuthorizationTypeForHTTPMethod(method string) (auth.AuthorizationType, error) {
@@ -174,6 +179,7 @@ func (s *service) RegisterHandlers(router *mux.Router) error {
 		{route: route{path: namespacePrefix, method: http.MethodDelete}, handler: s.deleteNamespace},
 		{route: route{path: validateRuleSetPath, method: http.MethodPost}, handler: s.validateRuleSet},
 		{route: route{path: updateRuleSetPath, method: http.MethodPost}, handler: s.updateRuleSet},
+		{route: route{path: dryRunRuleSetPath, method: http.MethodPost}, handler: s.dryRunRuleSet},
 
 		// Mapping Rule actions.
 		{route: route{path: mappingRuleRoot, method: http.MethodPost}, handler: s.createMappingRule},
@@ -284,6 +290,14 @@ func (s *service) updateRuleSet(w http.ResponseWriter, r *http.Request) error {
 	return s.sendResponse(w, http.StatusOK, data)
 }
 
+func (s *service) dryRunRuleSet(w http.ResponseWriter, r *http.Request) error {
+	data, err := s.handleRoute(dryRunRuleSet, r, s.metrics.dryRunRuleSet)
+	if err != nil {
+		return err
+	}
+	return s.sendResponse(w, http.StatusOK, data)
+}
+
 func (s *service) deleteNamespace(w http.ResponseWriter, r *http.Request) error {
 	data, err := s.handleRoute(deleteNamespace, r, s.metrics.deleteNamespace)
 	if err != nil {
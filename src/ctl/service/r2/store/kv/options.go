@@ -57,6 +57,14 @@ type StoreOptions interface {
 
 	// ValidatprOptions returns the validator for the store.
 	Validator() rules.Validator
+
+	// SetRuleSetOptions sets the ruleset options used to match metric IDs
+	// when dry running rulesets.
+	SetRuleSetOptions(value rules.Options) StoreOptions
+
+	// RuleSetOptions returns the ruleset options used to match metric IDs
+	// when dry running rulesets.
+	RuleSetOptions() rules.Options
 }
 
 type storeOptions struct {
@@ -64,6 +72,7 @@ type storeOptions struct {
 	instrumentOpts             instrument.Options
 	ruleUpdatePropagationDelay time.Duration
 	validator                  rules.Validator
+	ruleSetOpts                rules.Options
 }
 
 // NewStoreOptions creates a new set of store options.
@@ -114,3 +123,13 @@ func (o *storeOptions) SetValidator(value rules.Validator) StoreOptions {
 func (o *storeOptions) Validator() rules.Validator {
 	return o.validator
 }
+
+func (o *storeOptions) SetRuleSetOptions(value rules.Options) StoreOptions {
+	opts := *o
+	opts.ruleSetOpts = value
+	return &opts
+}
+
+func (o *storeOptions) RuleSetOptions() rules.Options {
+	return o.ruleSetOpts
+}
@@ -41,7 +41,10 @@ type store struct {
 	updateHelper rules.RuleSetUpdateHelper
 }
 
-var errNilValidator = errors.New("no validator set on StoreOptions so validation is not applicable")
+var (
+	errNilValidator      = errors.New("no validator set on StoreOptions so validation is not applicable")
+	errNilRuleSetOptions = errors.New("no ruleset options set on StoreOptions so dry run is not applicable")
+)
 
 // NewStore returns a new service that knows how to talk to a kv backed r2 store.
 func NewStore(rs rules.Store, opts StoreOptions) r2store.Store {
@@ -123,6 +126,68 @@ func (s *store) UpdateRuleSet(
 	return s.FetchRuleSetSnapshot(rsChanges.Namespace)
 }
 
+func (s *store) DryRunRuleSet(
+	rsv view.RuleSet,
+	ids []string,
+) (view.DryRunMatches, error) {
+	rs, err := s.ruleStore.ReadRuleSet(rsv.Namespace)
+	if err != nil {
+		return view.DryRunMatches{}, handleUpstreamError(err)
+	}
+
+	nowNanos := s.nowFn().UnixNano()
+	proposed, err := rules.NewRuleSetFromView(rsv, s.updateHelper.NewUpdateMetadata(nowNanos, ""))
+	if err != nil {
+		return view.DryRunMatches{}, handleUpstreamError(err)
+	}
+	return s.dryRun(rs, proposed, ids, nowNanos)
+}
+
+func (s *store) DryRunRuleSetChanges(
+	rsChanges changes.RuleSetChanges,
+	ids []string,
+) (view.DryRunMatches, error) {
+	rs, err := s.ruleStore.ReadRuleSet(rsChanges.Namespace)
+	if err != nil {
+		return view.DryRunMatches{}, handleUpstreamError(err)
+	}
+
+	nowNanos := s.nowFn().UnixNano()
+	proposed := rs.ToMutableRuleSet().Clone()
+	err = proposed.ApplyRuleSetChanges(rsChanges, s.updateHelper.NewUpdateMetadata(nowNanos, ""))
+	if err != nil {
+		return view.DryRunMatches{}, handleUpstreamError(err)
+	}
+	return s.dryRun(rs, proposed, ids, nowNanos)
+}
+
+// dryRun matches the metric IDs once the proposed changes have propagated so
+// that both rulesets are compared with all of their rules in effect.
+func (s *store) dryRun(
+	current, proposed rules.RuleSet,
+	ids []string,
+	nowNanos int64,
+) (view.DryRunMatches, error) {
+	ruleSetOpts := s.opts.RuleSetOptions()
+	// If no ruleset options are set, then metric IDs cannot be matched.
+	if ruleSetOpts == nil {
+		return view.DryRunMatches{}, errNilRuleSetOptions
+	}
+
+	metricIDs := make([][]byte, 0, len(ids))
+	for _, id := range ids {
+		metricIDs = append(metricIDs, []byte(id))
+	}
+	res, err := rules.DryRun(current, proposed, metricIDs, rules.DryRunOptions{
+		RuleSetOptions: ruleSetOpts,
+		TimeNanos:      nowNanos + int64(s.opts.RuleUpdatePropagationDelay()),
+	})
+	if err != nil {
+		return view.DryRunMatches{}, handleUpstreamError(err)
+	}
+	return res, nil
+}
+
 func (s *store) CreateNamespace(
 	namespaceID string,
 	uOpts r2store.UpdateOptions,
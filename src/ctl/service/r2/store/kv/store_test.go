@@ -28,6 +28,8 @@ import (
 	r2store "github.com/m3db/m3/src/ctl/service/r2/store"
 	"github.com/m3db/m3/src/metrics/aggregation"
 	merrors "github.com/m3db/m3/src/metrics/errors"
+	"github.com/m3db/m3/src/metrics/filters"
+	"github.com/m3db/m3/src/metrics/metric/id/m3"
 	"github.com/m3db/m3/src/metrics/pipeline"
 	"github.com/m3db/m3/src/metrics/policy"
 	"github.com/m3db/m3/src/metrics/rules"
@@ -250,6 +252,93 @@ func TestUpdateRuleSetWriteFailure(t *testing.T) {
 	require.IsType(t, r2.NewConflictError(""), err)
 }
 
+func TestDryRunRuleSetChanges(t *testing.T) {
+	helper := rules.NewRuleSetUpdateHelper(time.Minute)
+	initialRuleSet, err := newEmptyTestRuleSet(1, helper.NewUpdateMetadata(100, "validUser"))
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockedStore := rules.NewMockStore(ctrl)
+	mockedStore.EXPECT().ReadRuleSet("testNamespace").Return(initialRuleSet, nil)
+
+	storeOpts := NewStoreOptions().
+		SetClockOptions(clock.NewOptions().SetNowFn(func() time.Time {
+			return time.Unix(0, 200)
+		})).
+		SetRuleSetOptions(testM3RuleSetOptions())
+	rulesStore := NewStore(mockedStore, storeOpts)
+	res, err := rulesStore.DryRunRuleSetChanges(changes.RuleSetChanges{
+		Namespace: "testNamespace",
+		MappingRuleChanges: []changes.MappingRuleChange{
+			{
+				Op: changes.AddOp,
+				RuleData: &view.MappingRule{
+					Name:       "dropNginx",
+					Filter:     "app:nginx",
+					DropPolicy: policy.DropMust,
+				},
+			},
+		},
+	}, []string{"m3+foo+app=nginx", "m3+foo+app=envoy"})
+	require.NoError(t, err)
+	require.Equal(t, []view.DryRunMatch{
+		{
+			ID: "m3+foo+app=nginx",
+			After: view.MetricMatch{
+				Dropped:   true,
+				Pipelines: view.PipelineMatches{{DropPolicy: policy.DropMust}},
+			},
+			Changed: true,
+		},
+		{
+			ID: "m3+foo+app=envoy",
+		},
+	}, res.Matches)
+}
+
+func TestDryRunRuleSet(t *testing.T) {
+	helper := rules.NewRuleSetUpdateHelper(time.Minute)
+	initialRuleSet, err := testRuleSet(1, helper.NewUpdateMetadata(100, "validUser"))
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockedStore := rules.NewMockStore(ctrl)
+	mockedStore.EXPECT().ReadRuleSet("testNamespace").Return(initialRuleSet, nil)
+
+	storeOpts := NewStoreOptions().
+		SetClockOptions(clock.NewOptions().SetNowFn(func() time.Time {
+			return time.Unix(0, 200)
+		})).
+		SetRuleSetOptions(testM3RuleSetOptions())
+	rulesStore := NewStore(mockedStore, storeOpts)
+	res, err := rulesStore.DryRunRuleSet(view.RuleSet{
+		Namespace: "testNamespace",
+	}, []string{"m3+foo+app=nginx"})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(res.Matches))
+	require.True(t, res.Matches[0].Changed)
+	require.Equal(t, view.MetricMatch{}, res.Matches[0].After)
+}
+
+func TestDryRunRuleSetNoRuleSetOptions(t *testing.T) {
+	helper := rules.NewRuleSetUpdateHelper(time.Minute)
+	initialRuleSet, err := newEmptyTestRuleSet(1, helper.NewUpdateMetadata(100, "validUser"))
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockedStore := rules.NewMockStore(ctrl)
+	mockedStore.EXPECT().ReadRuleSet("testNamespace").Return(initialRuleSet, nil)
+
+	rulesStore := NewStore(mockedStore, NewStoreOptions())
+	_, err = rulesStore.DryRunRuleSet(view.RuleSet{
+		Namespace: "testNamespace",
+	}, []string{"m3+foo+app=nginx"})
+	require.Equal(t, errNilRuleSetOptions, err)
+}
+
 func newTestRuleSetChanges(mrs view.MappingRules, rrs view.RollupRules) changes.RuleSetChanges {
 	mrChanges := make([]changes.MappingRuleChange, 0, len(mrs))
 	for uuid := range mrs {
@@ -360,3 +449,13 @@ func newEmptyTestRuleSet(version int, meta rules.UpdateMetadata) (rules.RuleSet,
 
 	return ruleSet, nil
 }
+
+func testM3RuleSetOptions() rules.Options {
+	return rules.NewOptions().
+		SetTagsFilterOptions(filters.TagsFilterOptions{
+			NameTagKey:          []byte("name"),
+			NameAndTagsFn:       m3.NameAndTags,
+			SortedTagIteratorFn: m3.NewSortedTagIterator,
+		}).
+		SetNewRollupIDFn(m3.NewRollupID)
+}
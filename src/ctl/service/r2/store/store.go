@@ -45,6 +45,14 @@ type Store interface {
 	// UpdateRuleSet updates a ruleset with a given namespace.
 	UpdateRuleSet(rsChanges changes.RuleSetChanges, version int, uOpts UpdateOptions) (view.RuleSet, error)
 
+	// DryRunRuleSet matches metric IDs against a namespace's current ruleset and
+	// the given ruleset without persisting any changes.
+	DryRunRuleSet(rs view.RuleSet, ids []string) (view.DryRunMatches, error)
+
+	// DryRunRuleSetChanges matches metric IDs against a namespace's current ruleset
+	// before and after applying the given changes without persisting any changes.
+	DryRunRuleSetChanges(rsChanges changes.RuleSetChanges, ids []string) (view.DryRunMatches, error)
+
 	// FetchMappingRule fetches the mapping rule for the given namespace ID and rule ID.
 	FetchMappingRule(namespaceID, mappingRuleID string) (view.MappingRule, error)
 
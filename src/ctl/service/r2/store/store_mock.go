@@ -155,6 +155,36 @@ func (mr *MockStoreMockRecorder) DeleteRollupRule(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRollupRule", reflect.TypeOf((*MockStore)(nil).DeleteRollupRule), arg0, arg1, arg2)
 }
 
+// DryRunRuleSet mocks base method
+func (m *MockStore) DryRunRuleSet(arg0 view.RuleSet, arg1 []string) (view.DryRunMatches, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DryRunRuleSet", arg0, arg1)
+	ret0, _ := ret[0].(view.DryRunMatches)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DryRunRuleSet indicates an expected call of DryRunRuleSet
+func (mr *MockStoreMockRecorder) DryRunRuleSet(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DryRunRuleSet", reflect.TypeOf((*MockStore)(nil).DryRunRuleSet), arg0, arg1)
+}
+
+// DryRunRuleSetChanges mocks base method
+func (m *MockStore) DryRunRuleSetChanges(arg0 changes.RuleSetChanges, arg1 []string) (view.DryRunMatches, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DryRunRuleSetChanges", arg0, arg1)
+	ret0, _ := ret[0].(view.DryRunMatches)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DryRunRuleSetChanges indicates an expected call of DryRunRuleSetChanges
+func (mr *MockStoreMockRecorder) DryRunRuleSetChanges(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DryRunRuleSetChanges", reflect.TypeOf((*MockStore)(nil).DryRunRuleSetChanges), arg0, arg1)
+}
+
 // FetchMappingRule mocks base method
 func (m *MockStore) FetchMappingRule(arg0, arg1 string) (view.MappingRule, error) {
 	m.ctrl.T.Helper()
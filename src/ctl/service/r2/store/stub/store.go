@@ -394,6 +394,19 @@ func (s *store) UpdateRuleSet(
 	return view.RuleSet{}, errNotImplemented
 }
 
+// This function is not supported. Use mocks package.
+func (s *store) DryRunRuleSet(rs view.RuleSet, ids []string) (view.DryRunMatches, error) {
+	return view.DryRunMatches{}, errNotImplemented
+}
+
+// This function is not supported. Use mocks package.
+func (s *store) DryRunRuleSetChanges(
+	rsChanges changes.RuleSetChanges,
+	ids []string,
+) (view.DryRunMatches, error) {
+	return view.DryRunMatches{}, errNotImplemented
+}
+
 func (s *store) DeleteNamespace(namespaceID string, uOpts r2store.UpdateOptions) error {
 	switch namespaceID {
 	case s.data.ErrorNamespace:
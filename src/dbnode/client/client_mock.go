@@ -667,6 +667,18 @@ func (mr *MockPeerBlockMetadataIterMockRecorder) Err() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Err", reflect.TypeOf((*MockPeerBlockMetadataIter)(nil).Err))
 }
 
+// Close mocks base method
+func (m *MockPeerBlockMetadataIter) Close() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Close")
+}
+
+// Close indicates an expected call of Close
+func (mr *MockPeerBlockMetadataIterMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockPeerBlockMetadataIter)(nil).Close))
+}
+
 // MockPeerBlocksIter is a mock of PeerBlocksIter interface
 type MockPeerBlocksIter struct {
 	ctrl     *gomock.Controller
@@ -26,10 +26,10 @@ import (
 	"sort"
 	"time"
 
-	"github.com/m3db/m3/src/cluster/shard"
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
 	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/sharding"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/topology"
 	xerrors "github.com/m3db/m3/src/x/errors"
@@ -165,8 +165,9 @@ func (accum *fetchTaggedResultAccumulator) accumulatedResult(
 			continue // already been marked done, don't need to do anything for this shard
 		}
 
-		if hs.State() != shard.Available {
-			// Currently, we only accept responses from shard's which are available
+		if !sharding.IsServing(hostShardSet.ShardSet(), hs.ID()) {
+			// Currently, we only accept responses from shard's which are available,
+			// or initializing from an available shard they were split from
 			// NB: as a possible enhancement, we could accept a response from
 			// a shard that's not available if we tracked response pairs from
 			// a LEAVING+INITIALIZING shard; this would help during node replaces.
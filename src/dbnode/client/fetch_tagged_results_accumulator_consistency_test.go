@@ -176,6 +176,65 @@ func TestFetchTaggedResultsAccumulatorShardAvailabilityIsEnforced(t *testing.T)
 	}.run()
 }
 
+func TestFetchTaggedResultsAccumulatorSplitShardsServedBySplitSource(t *testing.T) {
+	// rf=3, 2 shards split into 4 shards; shards 2 and 3 initialize from
+	// shards 0 and 1 on every host, except on testhost2 which is still
+	// initializing the split sources as well
+	splitShards := func(sourceState shard.State) []shard.Shard {
+		return []shard.Shard{
+			shard.NewShard(0).SetState(sourceState),
+			shard.NewShard(1).SetState(sourceState),
+			shard.NewShard(2).SetState(shard.Initializing).SetSplitSource(0),
+			shard.NewShard(3).SetState(shard.Initializing).SetSplitSource(1),
+		}
+	}
+	topoMap := tu.MustNewTopologyMap(3, map[string][]shard.Shard{
+		"testhost0": splitShards(shard.Available),
+		"testhost1": splitShards(shard.Available),
+		"testhost2": splitShards(shard.Initializing),
+	})
+
+	// responses for the split shards count while their split sources are available
+	testFetchStateWorkflow{
+		t:       t,
+		topoMap: topoMap,
+		level:   topology.ReadConsistencyLevelMajority,
+		steps: []testFetchStateWorklowStep{
+			testFetchStateWorklowStep{
+				hostname:          "testhost0",
+				fetchTaggedResult: &testFetchTaggedSuccessResponse,
+			},
+			testFetchStateWorklowStep{
+				hostname:          "testhost1",
+				fetchTaggedResult: &testFetchTaggedSuccessResponse,
+				expectedDone:      true,
+			},
+		},
+	}.run()
+
+	// responses for the split shards do not count once the split sources are not
+	testFetchStateWorkflow{
+		t:       t,
+		topoMap: topoMap,
+		level:   topology.ReadConsistencyLevelMajority,
+		steps: []testFetchStateWorklowStep{
+			testFetchStateWorklowStep{
+				hostname:          "testhost0",
+				fetchTaggedResult: &testFetchTaggedSuccessResponse,
+			},
+			testFetchStateWorklowStep{
+				hostname:          "testhost2",
+				fetchTaggedResult: &testFetchTaggedSuccessResponse,
+			},
+			testFetchStateWorklowStep{
+				hostname:          "testhost1",
+				fetchTaggedResult: &testFetchTaggedSuccessResponse,
+				expectedDone:      true,
+			},
+		},
+	}.run()
+}
+
 func TestFetchTaggedResultsAccumulatorAnyResponseShouldTerminateConsistencyLevelOneComplexTopo(t *testing.T) {
 	// rf=3, 30 shards total; 2 identical hosts, one additional host with a subset of all shards
 	topoMap := tu.MustNewTopologyMap(3, map[string][]shard.Shard{
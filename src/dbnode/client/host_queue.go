@@ -58,6 +58,7 @@ type queue struct {
 	writeTaggedBatchRawV2RequestElementArrayPool writeTaggedBatchRawV2RequestElementArrayPool
 	fetchBatchRawV2RequestPool                   fetchBatchRawV2RequestPool
 	fetchBatchRawV2RequestElementArrayPool       fetchBatchRawV2RequestElementArrayPool
+	writeRequestTimeoutFn                        writeRequestTimeoutFn
 	workerPool                                   xsync.PooledWorkerPool
 	size                                         int
 	ops                                          []op
@@ -124,6 +125,11 @@ func newHostQueue(
 	opArrayPool := newOpArrayPool(opArrayPoolOpts, opArrayPoolCapacity)
 	opArrayPool.Init()
 
+	writeRequestTimeoutFn := hostQueueOpts.writeRequestTimeoutFn
+	if writeRequestTimeoutFn == nil {
+		writeRequestTimeoutFn = opts.WriteRequestTimeout
+	}
+
 	return &queue{
 		opts:                                   opts,
 		nowFn:                                  opts.ClockOptions().NowFn(),
@@ -139,6 +145,7 @@ func newHostQueue(
 		writeTaggedBatchRawV2RequestElementArrayPool: hostQueueOpts.writeTaggedBatchRawV2RequestElementArrayPool,
 		fetchBatchRawV2RequestPool:                   hostQueueOpts.fetchBatchRawV2RequestPool,
 		fetchBatchRawV2RequestElementArrayPool:       hostQueueOpts.fetchBatchRawV2RequestElementArrayPool,
+		writeRequestTimeoutFn:                        writeRequestTimeoutFn,
 		workerPool:                                   workerPool,
 		size:                                         size,
 		ops:                                          opArrayPool.Get(),
@@ -543,7 +550,7 @@ func (q *queue) asyncTaggedWrite(
 			return
 		}
 
-		ctx, _ := thrift.NewContext(q.opts.WriteRequestTimeout())
+		ctx, _ := thrift.NewContext(q.writeRequestTimeoutFn())
 		err = client.WriteTaggedBatchRaw(ctx, req)
 		if err == nil {
 			// All succeeded
@@ -603,7 +610,7 @@ func (q *queue) asyncTaggedWriteV2(
 			return
 		}
 
-		ctx, _ := thrift.NewContext(q.opts.WriteRequestTimeout())
+		ctx, _ := thrift.NewContext(q.writeRequestTimeoutFn())
 		err = client.WriteTaggedBatchRawV2(ctx, req)
 		if err == nil {
 			// All succeeded
@@ -668,7 +675,7 @@ func (q *queue) asyncWrite(
 			return
 		}
 
-		ctx, _ := thrift.NewContext(q.opts.WriteRequestTimeout())
+		ctx, _ := thrift.NewContext(q.writeRequestTimeoutFn())
 		err = client.WriteBatchRaw(ctx, req)
 		if err == nil {
 			// All succeeded
@@ -727,7 +734,7 @@ func (q *queue) asyncWriteV2(
 			return
 		}
 
-		ctx, _ := thrift.NewContext(q.opts.WriteRequestTimeout())
+		ctx, _ := thrift.NewContext(q.writeRequestTimeoutFn())
 		err = client.WriteBatchRawV2(ctx, req)
 		if err == nil {
 			// All succeeded.
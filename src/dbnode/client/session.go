@@ -113,6 +113,10 @@ var (
 	errUnableToEncodeTags = errors.New("unable to include tags")
 	// errEnqueueChIsClosed is returned when attempting to use a closed enqueuCh.
 	errEnqueueChIsClosed = errors.New("error enqueueCh is cosed")
+	// errBlocksMetadataStreamClosed is raised when streaming blocks metadata
+	// from peers is stopped because the metadata iterator was closed.
+	errBlocksMetadataStreamClosed = xerrors.NewNonRetryableError(
+		errors.New("blocks metadata stream closed"))
 )
 
 // sessionState is volatile state that is protected by a
@@ -136,6 +140,9 @@ type sessionState struct {
 }
 
 type session struct {
+	// NB: writeRequestTimeoutNanos is accessed atomically and kept first
+	// for 64 bit alignment.
+	writeRequestTimeoutNanos         int64
 	state                            sessionState
 	opts                             Options
 	runtimeOptsListenerCloser        xclose.Closer
@@ -240,9 +247,12 @@ type hostQueueOpts struct {
 	writeTaggedBatchRawV2RequestElementArrayPool writeTaggedBatchRawV2RequestElementArrayPool
 	fetchBatchRawV2RequestPool                   fetchBatchRawV2RequestPool
 	fetchBatchRawV2RequestElementArrayPool       fetchBatchRawV2RequestElementArrayPool
+	writeRequestTimeoutFn                        writeRequestTimeoutFn
 	opts                                         Options
 }
 
+type writeRequestTimeoutFn func() time.Duration
+
 type newHostQueueFn func(
 	host topology.Host,
 	hostQueueOpts hostQueueOpts,
@@ -290,6 +300,7 @@ func newSession(opts Options) (clientSession, error) {
 		},
 		metrics: newSessionMetrics(scope),
 	}
+	s.setWriteRequestTimeout(opts.WriteRequestTimeout())
 	s.reattemptStreamBlocksFromPeersFn = s.streamBlocksReattemptFromPeers
 	s.pickBestPeerFn = s.streamBlocksPickBestPeer
 	writeAttemptPoolOpts := pool.NewObjectPoolOptions().
@@ -372,6 +383,22 @@ func (s *session) SetRuntimeOptions(value runtime.Options) {
 	s.state.readLevel = value.ClientReadConsistencyLevel()
 	s.state.writeLevel = value.ClientWriteConsistencyLevel()
 	s.state.Unlock()
+
+	timeout := s.opts.WriteRequestTimeout()
+	if value := value.ClientWriteRequestTimeout(); value > 0 {
+		timeout = value
+	}
+	s.setWriteRequestTimeout(timeout)
+}
+
+func (s *session) setWriteRequestTimeout(value time.Duration) {
+	atomic.StoreInt64(&s.writeRequestTimeoutNanos, int64(value))
+}
+
+// writeRequestTimeout returns the timeout of write requests made to the
+// hosts, which can be changed by the runtime options.
+func (s *session) writeRequestTimeout() time.Duration {
+	return time.Duration(atomic.LoadInt64(&s.writeRequestTimeoutNanos))
 }
 
 func (s *session) ShardID(id ident.ID) (uint32, error) {
@@ -965,6 +992,7 @@ func (s *session) newHostQueue(host topology.Host, topoMap topology.Map) (hostQu
 		writeTaggedBatchRawV2RequestElementArrayPool: writeTaggedBatchRawV2RequestElementArrayPool,
 		fetchBatchRawV2RequestPool:                   fetchBatchRawV2RequestPool,
 		fetchBatchRawV2RequestElementArrayPool:       fetchBatchRawV2RequestElementArrayPool,
+		writeRequestTimeoutFn:                        s.writeRequestTimeout,
 		opts:                                         s.opts,
 	})
 	if err != nil {
@@ -2006,18 +2034,19 @@ func (s *session) fetchBlocksMetadataFromPeers(
 	var (
 		metadataCh = make(chan receivedBlockMetadata,
 			blocksMetadataChannelInitialCapacity)
-		errCh = make(chan error, 1)
-		meta  = resultTypeMetadata
-		m     = s.newPeerMetadataStreamingProgressMetrics(shard, meta)
+		errCh  = make(chan error, 1)
+		doneCh = make(chan struct{})
+		meta   = resultTypeMetadata
+		m      = s.newPeerMetadataStreamingProgressMetrics(shard, meta)
 	)
 	go func() {
 		errCh <- s.streamBlocksMetadataFromPeers(namespace, shard,
-			peers, start, end, level, metadataCh, resultOpts, m)
+			peers, start, end, level, metadataCh, doneCh, resultOpts, m)
 		close(metadataCh)
 		close(errCh)
 	}()
 
-	iter := newMetadataIter(metadataCh, errCh,
+	iter := newMetadataIter(metadataCh, errCh, doneCh,
 		s.pools.tagDecoder.Get(), s.pools.id)
 	return iter, nil
 }
@@ -2072,7 +2101,7 @@ func (s *session) FetchBootstrapBlocksFromPeers(
 	errCh := make(chan error, 1)
 	go func() {
 		errCh <- s.streamBlocksMetadataFromPeers(nsMetadata.ID(), shard,
-			peers, start, end, level, metadataCh, opts, progress)
+			peers, start, end, level, metadataCh, nil, opts, progress)
 		close(metadataCh)
 	}()
 
@@ -2187,6 +2216,7 @@ func (s *session) streamBlocksMetadataFromPeers(
 	start, end time.Time,
 	level runtimeReadConsistencyLevel,
 	metadataCh chan<- receivedBlockMetadata,
+	doneCh <-chan struct{},
 	resultOpts result.Options,
 	progress *streamFromPeersMetrics,
 ) error {
@@ -2262,7 +2292,7 @@ func (s *session) streamBlocksMetadataFromPeers(
 			for condition() {
 				var err error
 				currPageToken, err = s.streamBlocksMetadataFromPeer(namespace, shardID,
-					peer, start, end, currPageToken, metadataCh, resultOpts, progress)
+					peer, start, end, currPageToken, metadataCh, doneCh, resultOpts, progress)
 				// Set error or success if err is nil
 				errs.setError(idx, err)
 
@@ -2317,6 +2347,7 @@ func (s *session) streamBlocksMetadataFromPeer(
 	start, end time.Time,
 	startPageToken pageToken,
 	metadataCh chan<- receivedBlockMetadata,
+	doneCh <-chan struct{},
 	resultOpts result.Options,
 	progress *streamFromPeersMetrics,
 ) (pageToken, error) {
@@ -2343,6 +2374,16 @@ func (s *session) streamBlocksMetadataFromPeer(
 		}
 	}()
 
+	// Stop streaming once done, a nil doneCh never stops streaming.
+	enqueue := func(m receivedBlockMetadata) error {
+		select {
+		case metadataCh <- m:
+			return nil
+		case <-doneCh:
+			return errBlocksMetadataStreamClosed
+		}
+	}
+
 	// Declare before loop to avoid redeclaring each iteration
 	attemptFn := func(client rpc.TChanNode) error {
 		tctx, _ := thrift.NewContext(s.streamBlocksMetadataBatchTimeout)
@@ -2407,13 +2448,15 @@ func (s *session) streamBlocksMetadataFromPeer(
 					zap.Error(err),
 				)
 				// Enqueue with a zeroed checksum which triggers a fanout fetch
-				metadataCh <- receivedBlockMetadata{
+				if err := enqueue(receivedBlockMetadata{
 					peer:        peer,
 					id:          clonedID,
 					encodedTags: encodedTags,
 					block: blockMetadata{
 						start: blockStart,
 					},
+				}); err != nil {
+					return err
 				}
 				continue
 			}
@@ -2437,7 +2480,7 @@ func (s *session) streamBlocksMetadataFromPeer(
 				}
 			}
 
-			metadataCh <- receivedBlockMetadata{
+			if err := enqueue(receivedBlockMetadata{
 				peer:        peer,
 				id:          clonedID,
 				encodedTags: encodedTags,
@@ -2447,6 +2490,8 @@ func (s *session) streamBlocksMetadataFromPeer(
 					checksum: pChecksum,
 					lastRead: lastRead,
 				},
+			}); err != nil {
+				return err
 			}
 			// Only used for logs
 			metadataCountByBlock[xtime.ToUnixNano(blockStart)]++
@@ -2465,6 +2510,11 @@ func (s *session) streamBlocksMetadataFromPeer(
 	}
 
 	for moreResults {
+		select {
+		case <-doneCh:
+			return startPageToken, errBlocksMetadataStreamClosed
+		default:
+		}
 		if err := s.streamBlocksRetrier.Attempt(fetchFn); err != nil {
 			return startPageToken, err
 		}
@@ -3996,23 +4046,27 @@ func newTimesByRPCBlocks(values []*rpc.Block) []time.Time {
 type metadataIter struct {
 	inputCh    <-chan receivedBlockMetadata
 	errCh      <-chan error
+	doneCh     chan struct{}
 	host       topology.Host
 	metadata   block.Metadata
 	tagDecoder serialize.TagDecoder
 	idPool     ident.Pool
 	done       bool
+	closed     bool
 	err        error
 }
 
 func newMetadataIter(
 	inputCh <-chan receivedBlockMetadata,
 	errCh <-chan error,
+	doneCh chan struct{},
 	tagDecoder serialize.TagDecoder,
 	idPool ident.Pool,
 ) PeerBlockMetadataIter {
 	return &metadataIter{
 		inputCh:    inputCh,
 		errCh:      errCh,
+		doneCh:     doneCh,
 		tagDecoder: tagDecoder,
 		idPool:     idPool,
 	}
@@ -4048,6 +4102,15 @@ func (it *metadataIter) Err() error {
 	return it.err
 }
 
+func (it *metadataIter) Close() {
+	if it.closed {
+		return
+	}
+	it.closed = true
+	it.done = true
+	close(it.doneCh)
+}
+
 type idAndBlockStart struct {
 	id         ident.ID
 	blockStart int64
@@ -95,7 +95,7 @@ func TestPeerBlockMetadataIter(t *testing.T) {
 	}()
 
 	var actual []testHostBlock
-	it := newMetadataIter(inputCh, errCh,
+	it := newMetadataIter(inputCh, errCh, make(chan struct{}),
 		testTagDecodingPool.Get(), testIDPool)
 	for it.Next() {
 		host, curr := it.Current()
@@ -137,6 +137,47 @@ func TestPeerBlockMetadataIter(t *testing.T) {
 	}
 }
 
+func TestPeerBlockMetadataIterClose(t *testing.T) {
+	var (
+		inputCh = make(chan receivedBlockMetadata)
+		errCh   = make(chan error, 1)
+		doneCh  = make(chan struct{})
+	)
+
+	opts := newHostQueueTestOptions()
+	peer := newTestHostQueue(opts)
+
+	// Streams metadata until the iterator is closed.
+	go func() {
+		defer close(errCh)
+		defer close(inputCh)
+		for {
+			select {
+			case inputCh <- receivedBlockMetadata{
+				peer:  peer,
+				id:    ident.StringID("foo"),
+				block: blockMetadata{start: time.Now()},
+			}:
+			case <-doneCh:
+				errCh <- errBlocksMetadataStreamClosed
+				return
+			}
+		}
+	}()
+
+	it := newMetadataIter(inputCh, errCh, doneCh,
+		testTagDecodingPool.Get(), testIDPool)
+	require.True(t, it.Next())
+	it.Close()
+	require.False(t, it.Next())
+	require.NoError(t, it.Err())
+
+	// The stream stops once the iterator is closed.
+	for range inputCh {
+	}
+	require.Equal(t, errBlocksMetadataStreamClosed, <-errCh)
+}
+
 func mustEncodeTags(t *testing.T, tags ident.Tags) checked.Bytes {
 	encoder := testTagEncodingPool.Get()
 	err := encoder.Encode(ident.NewTagsIterator(tags))
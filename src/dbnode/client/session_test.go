@@ -25,9 +25,11 @@ import (
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/m3db/m3/src/cluster/shard"
 	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/dbnode/sharding"
 	"github.com/m3db/m3/src/dbnode/topology"
 	"github.com/m3db/m3/src/dbnode/x/xpool"
@@ -176,6 +178,23 @@ func TestSessionShardID(t *testing.T) {
 	assert.NoError(t, s.Close())
 }
 
+func TestSessionSetRuntimeOptionsWriteRequestTimeout(t *testing.T) {
+	opts := newSessionTestOptions().SetWriteRequestTimeout(5 * time.Second)
+	s, err := newSession(opts)
+	require.NoError(t, err)
+
+	session := s.(*session)
+	require.Equal(t, 5*time.Second, session.writeRequestTimeout())
+
+	session.SetRuntimeOptions(runtime.NewOptions().
+		SetClientWriteRequestTimeout(time.Second))
+	require.Equal(t, time.Second, session.writeRequestTimeout())
+
+	// Unsetting the runtime option falls back to the configured timeout.
+	session.SetRuntimeOptions(runtime.NewOptions())
+	require.Equal(t, 5*time.Second, session.writeRequestTimeout())
+}
+
 func TestSessionClusterConnectConsistencyLevelAll(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
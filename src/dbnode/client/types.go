@@ -168,6 +168,10 @@ type PeerBlockMetadataIter interface {
 
 	// Err returns any error encountered
 	Err() error
+
+	// Close stops streaming the metadata from the peers if it has not
+	// completed yet, the iterator returns no more items once closed.
+	Close()
 }
 
 // PeerBlocksIter iterates over a collection of blocks from peers.
@@ -25,6 +25,7 @@ import (
 	"sync"
 
 	"github.com/m3db/m3/src/cluster/shard"
+	"github.com/m3db/m3/src/dbnode/sharding"
 	"github.com/m3db/m3/src/dbnode/topology"
 	"github.com/m3db/m3/src/x/serialize"
 	xerrors "github.com/m3db/m3/src/x/errors"
@@ -122,8 +123,10 @@ func (w *writeState) completionFn(result interface{}, err error) {
 	} else if shardState, err := hostShardSet.ShardSet().LookupStateByID(w.op.ShardID()); err != nil {
 		errStr := "missing shard %d in host %s"
 		wErr = xerrors.NewRetryableError(fmt.Errorf(errStr, w.op.ShardID(), hostID))
-	} else if shardState != shard.Available {
-		// NB(bl): only count writes to available shards towards success
+	} else if !sharding.IsServing(hostShardSet.ShardSet(), w.op.ShardID()) {
+		// NB(bl): only count writes to available shards towards success, or
+		// to shards initializing from an available shard they were split from
+		// since the split source serves the shard until it is available.
 		var errStr string
 		switch shardState {
 		case shard.Initializing:
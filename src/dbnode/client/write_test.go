@@ -65,6 +65,35 @@ func TestWriteToLeavingShards(t *testing.T) {
 	testWriteSuccess(t, shard.Leaving, false)
 }
 
+func testWriteToSplitShardSuccess(t *testing.T, sourceState shard.State, success bool) {
+	var writeWg sync.WaitGroup
+
+	wState, s, host := writeTestSetup(t, &writeWg)
+	setShardStates(t, s, host, sourceState)
+	setSplitShard(t, s, host, 0, 1)
+	wState.completionFn(host, nil)
+
+	if success {
+		assert.Equal(t, int32(1), wState.success)
+	} else {
+		assert.Equal(t, int32(0), wState.success)
+	}
+
+	writeTestTeardown(wState, &writeWg)
+}
+
+func TestWriteToSplitShardsWithAvailableSource(t *testing.T) {
+	testWriteToSplitShardSuccess(t, shard.Available, true)
+}
+
+func TestWriteToSplitShardsWithInitializingSource(t *testing.T) {
+	testWriteToSplitShardSuccess(t, shard.Initializing, false)
+}
+
+func TestWriteToSplitShardsWithLeavingSource(t *testing.T) {
+	testWriteToSplitShardSuccess(t, shard.Leaving, false)
+}
+
 // retryability test
 
 type errTestFn func(error) bool
@@ -150,6 +179,19 @@ func setShardStates(t *testing.T, s *session, host topology.Host, state shard.St
 	}
 }
 
+// setSplitShard marks the shard as initializing from the split source shard,
+// as it is after the shards of the placement have been split.
+func setSplitShard(t *testing.T, s *session, host topology.Host, shardID, splitSource uint32) {
+	s.state.RLock()
+	hostShardSet, ok := s.state.topoMap.LookupHostShardSet(host.ID())
+	s.state.RUnlock()
+	require.True(t, ok)
+
+	hostShard, err := hostShardSet.ShardSet().LookupShard(shardID)
+	require.NoError(t, err)
+	hostShard.SetState(shard.Initializing).SetSplitSource(splitSource)
+}
+
 type fakeHost struct{ id string }
 
 func (f fakeHost) ID() string      { return f.id }
@@ -44,4 +44,40 @@ const (
 	// ClientWriteConsistencyLevel is the KV config key for the runtime
 	// configuration specifying the client write consistency level
 	ClientWriteConsistencyLevel = "m3db.client.write-consistency-level"
+
+	// TickMinimumIntervalKey is the KV config key for the runtime
+	// configuration specifying the minimum interval between ticks.
+	TickMinimumIntervalKey = "m3db.node.tick-minimum-interval"
+
+	// TickSeriesBatchSizeKey is the KV config key for the runtime
+	// configuration specifying the number of series ticked per batch.
+	TickSeriesBatchSizeKey = "m3db.node.tick-series-batch-size"
+
+	// TickPerSeriesSleepDurationKey is the KV config key for the runtime
+	// configuration specifying the sleep duration per series ticked.
+	TickPerSeriesSleepDurationKey = "m3db.node.tick-per-series-sleep-duration"
+
+	// MaxWiredBlocksKey is the KV config key for the runtime configuration
+	// specifying the maximum number of blocks kept wired by the block cache.
+	MaxWiredBlocksKey = "m3db.node.max-wired-blocks"
+
+	// WriteNewSeriesBackoffDurationKey is the KV config key for the runtime
+	// configuration specifying the backoff applied to writes of new series.
+	WriteNewSeriesBackoffDurationKey = "m3db.node.write-new-series-backoff-duration"
+
+	// IndexDefaultQueryTimeoutKey is the KV config key for the runtime
+	// configuration specifying the timeout of index queries.
+	IndexDefaultQueryTimeoutKey = "m3db.node.index-default-query-timeout"
+
+	// IndexMaxQueryLimitKey is the KV config key for the runtime
+	// configuration specifying the maximum number of results of index queries.
+	IndexMaxQueryLimitKey = "m3db.node.index-max-query-limit"
+
+	// MaxOutstandingReadRequestsKey is the KV config key for the runtime
+	// configuration specifying the maximum number of outstanding read requests.
+	MaxOutstandingReadRequestsKey = "m3db.node.max-outstanding-read-requests"
+
+	// ClientWriteRequestTimeoutKey is the KV config key for the runtime
+	// configuration specifying the client write request timeout.
+	ClientWriteRequestTimeoutKey = "m3db.client.write-request-timeout"
 )
@@ -0,0 +1,277 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package kvconfig
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/kv/util/runtime"
+	m3dbruntime "github.com/m3db/m3/src/dbnode/runtime"
+	"github.com/m3db/m3/src/dbnode/topology"
+)
+
+var (
+	errMustBePositive     = errors.New("must be positive")
+	errCannotBeNegative   = errors.New("cannot be negative")
+	errUnknownConsistency = errors.New("unknown consistency level")
+)
+
+// NodeRuntimeOption is a runtime option of the database nodes, which apply
+// it to their runtime options whenever its KV key changes.
+type NodeRuntimeOption struct {
+	runtime.OptionDefinition
+
+	// Value returns the value of the option in the runtime options.
+	Value func(opts m3dbruntime.Options) interface{}
+
+	// Apply returns the runtime options with the value of the option set.
+	Apply func(opts m3dbruntime.Options, value interface{}) m3dbruntime.Options
+}
+
+// NodeRuntimeOptions returns the runtime options of the database nodes that
+// are applied from KV, defaulting to the default runtime options. Nodes fall
+// back to their configured value when a key is not set.
+func NodeRuntimeOptions() []NodeRuntimeOption {
+	options := []NodeRuntimeOption{
+		{
+			OptionDefinition: runtime.OptionDefinition{
+				Key:         TickMinimumIntervalKey,
+				Type:        runtime.DurationOptionType,
+				Description: "Minimum interval between ticks of the database.",
+				ValidateFn:  nonNegativeDuration,
+			},
+			Value: func(opts m3dbruntime.Options) interface{} {
+				return opts.TickMinimumInterval()
+			},
+			Apply: func(opts m3dbruntime.Options, value interface{}) m3dbruntime.Options {
+				return opts.SetTickMinimumInterval(value.(time.Duration))
+			},
+		},
+		{
+			OptionDefinition: runtime.OptionDefinition{
+				Key:         TickSeriesBatchSizeKey,
+				Type:        runtime.Int64OptionType,
+				Description: "Number of series ticked in a batch before sleeping.",
+				ValidateFn:  positiveInt64,
+			},
+			Value: func(opts m3dbruntime.Options) interface{} {
+				return int64(opts.TickSeriesBatchSize())
+			},
+			Apply: func(opts m3dbruntime.Options, value interface{}) m3dbruntime.Options {
+				return opts.SetTickSeriesBatchSize(int(value.(int64)))
+			},
+		},
+		{
+			OptionDefinition: runtime.OptionDefinition{
+				Key:         TickPerSeriesSleepDurationKey,
+				Type:        runtime.DurationOptionType,
+				Description: "Time slept per series ticked, after each batch of series.",
+				ValidateFn:  positiveDuration,
+			},
+			Value: func(opts m3dbruntime.Options) interface{} {
+				return opts.TickPerSeriesSleepDuration()
+			},
+			Apply: func(opts m3dbruntime.Options, value interface{}) m3dbruntime.Options {
+				return opts.SetTickPerSeriesSleepDuration(value.(time.Duration))
+			},
+		},
+		{
+			OptionDefinition: runtime.OptionDefinition{
+				Key:         MaxWiredBlocksKey,
+				Type:        runtime.Int64OptionType,
+				Description: "Maximum number of blocks kept wired by the LRU block cache.",
+				ValidateFn:  nonNegativeInt64,
+			},
+			Value: func(opts m3dbruntime.Options) interface{} {
+				return int64(opts.MaxWiredBlocks())
+			},
+			Apply: func(opts m3dbruntime.Options, value interface{}) m3dbruntime.Options {
+				return opts.SetMaxWiredBlocks(uint(value.(int64)))
+			},
+		},
+		{
+			OptionDefinition: runtime.OptionDefinition{
+				Key:         WriteNewSeriesBackoffDurationKey,
+				Type:        runtime.DurationOptionType,
+				Description: "Backoff applied to writes of new series, zero for no backoff.",
+				ValidateFn:  nonNegativeDuration,
+			},
+			Value: func(opts m3dbruntime.Options) interface{} {
+				return opts.WriteNewSeriesBackoffDuration()
+			},
+			Apply: func(opts m3dbruntime.Options, value interface{}) m3dbruntime.Options {
+				return opts.SetWriteNewSeriesBackoffDuration(value.(time.Duration))
+			},
+		},
+		{
+			OptionDefinition: runtime.OptionDefinition{
+				Key:         IndexDefaultQueryTimeoutKey,
+				Type:        runtime.DurationOptionType,
+				Description: "Timeout of index queries which do not set one, zero for no timeout.",
+				ValidateFn:  nonNegativeDuration,
+			},
+			Value: func(opts m3dbruntime.Options) interface{} {
+				return opts.IndexDefaultQueryTimeout()
+			},
+			Apply: func(opts m3dbruntime.Options, value interface{}) m3dbruntime.Options {
+				return opts.SetIndexDefaultQueryTimeout(value.(time.Duration))
+			},
+		},
+		{
+			OptionDefinition: runtime.OptionDefinition{
+				Key:         IndexMaxQueryLimitKey,
+				Type:        runtime.Int64OptionType,
+				Description: "Maximum number of results returned by an index query, zero for no maximum.",
+				ValidateFn:  nonNegativeInt64,
+			},
+			Value: func(opts m3dbruntime.Options) interface{} {
+				return opts.IndexMaxQueryLimit()
+			},
+			Apply: func(opts m3dbruntime.Options, value interface{}) m3dbruntime.Options {
+				return opts.SetIndexMaxQueryLimit(value.(int64))
+			},
+		},
+		{
+			OptionDefinition: runtime.OptionDefinition{
+				Key:         MaxOutstandingReadRequestsKey,
+				Type:        runtime.Int64OptionType,
+				Description: "Maximum number of read requests served concurrently, zero for no limit.",
+				ValidateFn:  nonNegativeInt64,
+			},
+			Value: func(opts m3dbruntime.Options) interface{} {
+				return int64(opts.MaxOutstandingReadRequests())
+			},
+			Apply: func(opts m3dbruntime.Options, value interface{}) m3dbruntime.Options {
+				return opts.SetMaxOutstandingReadRequests(int(value.(int64)))
+			},
+		},
+		{
+			OptionDefinition: runtime.OptionDefinition{
+				Key:         ClientWriteRequestTimeoutKey,
+				Type:        runtime.DurationOptionType,
+				Description: "Timeout of write requests made by the clients of the node, zero for the configured timeout.",
+				ValidateFn:  nonNegativeDuration,
+			},
+			Value: func(opts m3dbruntime.Options) interface{} {
+				return opts.ClientWriteRequestTimeout()
+			},
+			Apply: func(opts m3dbruntime.Options, value interface{}) m3dbruntime.Options {
+				return opts.SetClientWriteRequestTimeout(value.(time.Duration))
+			},
+		},
+	}
+
+	defaults := m3dbruntime.NewOptions()
+	for i := range options {
+		options[i].Default = options[i].Value(defaults)
+	}
+	return options
+}
+
+// RuntimeOptionDefinitions returns the definitions of the database runtime
+// options kept in KV, including the cluster new series insert limit and the
+// client consistency levels which nodes watch separately.
+func RuntimeOptionDefinitions() []runtime.OptionDefinition {
+	defaults := m3dbruntime.NewOptions()
+	defs := []runtime.OptionDefinition{
+		{
+			Key:         ClusterNewSeriesInsertLimitKey,
+			Type:        runtime.Int64OptionType,
+			Description: "Limit of new series inserted per second across the cluster, zero for no limit.",
+			Default:     int64(0),
+			ValidateFn:  nonNegativeInt64,
+		},
+		{
+			Key:         ClientBootstrapConsistencyLevel,
+			Type:        runtime.StringOptionType,
+			Description: "Consistency level of the client used to bootstrap from peers.",
+			Default:     defaults.ClientBootstrapConsistencyLevel().String(),
+			ValidateFn:  validReadConsistencyLevel,
+		},
+		{
+			Key:         ClientReadConsistencyLevel,
+			Type:        runtime.StringOptionType,
+			Description: "Consistency level of the client used to read from peers.",
+			Default:     defaults.ClientReadConsistencyLevel().String(),
+			ValidateFn:  validReadConsistencyLevel,
+		},
+		{
+			Key:         ClientWriteConsistencyLevel,
+			Type:        runtime.StringOptionType,
+			Description: "Consistency level of the client used to write to peers.",
+			Default:     defaults.ClientWriteConsistencyLevel().String(),
+			ValidateFn:  validConsistencyLevel,
+		},
+	}
+
+	for _, option := range NodeRuntimeOptions() {
+		defs = append(defs, option.OptionDefinition)
+	}
+	return defs
+}
+
+func positiveInt64(value interface{}) error {
+	if value.(int64) <= 0 {
+		return errMustBePositive
+	}
+	return nil
+}
+
+func nonNegativeInt64(value interface{}) error {
+	if value.(int64) < 0 {
+		return errCannotBeNegative
+	}
+	return nil
+}
+
+func positiveDuration(value interface{}) error {
+	if value.(time.Duration) <= 0 {
+		return errMustBePositive
+	}
+	return nil
+}
+
+func nonNegativeDuration(value interface{}) error {
+	if value.(time.Duration) < 0 {
+		return errCannotBeNegative
+	}
+	return nil
+}
+
+func validReadConsistencyLevel(value interface{}) error {
+	for _, level := range topology.ValidReadConsistencyLevels() {
+		if level.String() == value.(string) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%v: %s", errUnknownConsistency, value)
+}
+
+func validConsistencyLevel(value interface{}) error {
+	for _, level := range topology.ValidConsistencyLevels() {
+		if level.String() == value.(string) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%v: %s", errUnknownConsistency, value)
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package kvconfig
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/kv/util/runtime"
+	m3dbruntime "github.com/m3db/m3/src/dbnode/runtime"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuntimeOptionDefinitionsRegister(t *testing.T) {
+	r := runtime.NewRegistry()
+	require.NoError(t, r.Register(RuntimeOptionDefinitions()...))
+	require.Equal(t, len(RuntimeOptionDefinitions()), len(r.Definitions()))
+
+	def, ok := r.Definition(ClientWriteConsistencyLevel)
+	require.True(t, ok)
+	_, err := def.ParseValue("all")
+	require.NoError(t, err)
+	_, err = def.ParseValue("unknown")
+	require.Error(t, err)
+}
+
+func TestNodeRuntimeOptionsApply(t *testing.T) {
+	values := map[string]interface{}{
+		TickMinimumIntervalKey:           time.Minute,
+		TickSeriesBatchSizeKey:           int64(1024),
+		TickPerSeriesSleepDurationKey:    time.Millisecond,
+		MaxWiredBlocksKey:                int64(1000),
+		WriteNewSeriesBackoffDurationKey: time.Second,
+		IndexDefaultQueryTimeoutKey:      30 * time.Second,
+		IndexMaxQueryLimitKey:            int64(10000),
+		MaxOutstandingReadRequestsKey:    int64(512),
+		ClientWriteRequestTimeoutKey:     5 * time.Second,
+	}
+
+	opts := m3dbruntime.NewOptions()
+	for _, option := range NodeRuntimeOptions() {
+		require.Equal(t, option.Default, option.Value(opts))
+
+		value, ok := values[option.Key]
+		require.True(t, ok, "no test value for %s", option.Key)
+		require.NoError(t, option.ValidateValue(value))
+		opts = option.Apply(opts, value)
+	}
+	require.NoError(t, opts.Validate())
+
+	for _, option := range NodeRuntimeOptions() {
+		require.Equal(t, values[option.Key], option.Value(opts))
+	}
+}
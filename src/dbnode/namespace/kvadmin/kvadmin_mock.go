@@ -155,3 +155,33 @@ func (mr *MockNamespaceMetadataAdminServiceMockRecorder) ResetSchema(name interf
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetSchema", reflect.TypeOf((*MockNamespaceMetadataAdminService)(nil).ResetSchema), name)
 }
+
+// History mocks base method
+func (m *MockNamespaceMetadataAdminService) History() ([]RegistryHistoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "History")
+	ret0, _ := ret[0].([]RegistryHistoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// History indicates an expected call of History
+func (mr *MockNamespaceMetadataAdminServiceMockRecorder) History() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockNamespaceMetadataAdminService)(nil).History))
+}
+
+// Rollback mocks base method
+func (m *MockNamespaceMetadataAdminService) Rollback(version int) (*namespace.Registry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rollback", version)
+	ret0, _ := ret[0].(*namespace.Registry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rollback indicates an expected call of Rollback
+func (mr *MockNamespaceMetadataAdminServiceMockRecorder) Rollback(version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockNamespaceMetadataAdminService)(nil).Rollback), version)
+}
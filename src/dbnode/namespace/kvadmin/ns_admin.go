@@ -25,11 +25,14 @@ import (
 	"fmt"
 
 	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/cluster/kv/util/history"
 	nsproto "github.com/m3db/m3/src/dbnode/generated/proto/namespace"
 	"github.com/m3db/m3/src/dbnode/namespace"
 	xerrors "github.com/m3db/m3/src/x/errors"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/satori/go.uuid"
+	"go.uber.org/zap"
 )
 
 var (
@@ -39,9 +42,34 @@ var (
 )
 
 type adminService struct {
-	store kv.Store
-	key   string
-	idGen func() string
+	store         kv.Store
+	key           string
+	idGen         func() string
+	history       history.Store
+	recordHistory bool
+	changeMeta    history.Metadata
+	logger        *zap.Logger
+}
+
+// AdminServiceOption is an option for the namespace admin service.
+type AdminServiceOption func(*adminService)
+
+// WithHistory keeps the previous namespace registries written by the admin
+// service in the registry history, along with the metadata of the change.
+func WithHistory(opts history.Options, meta history.Metadata) AdminServiceOption {
+	return func(as *adminService) {
+		as.history = history.NewStore(as.store, as.key, opts)
+		as.recordHistory = opts.Limit() > 0
+		as.changeMeta = meta
+	}
+}
+
+// WithLogger sets the logger used to report registries that could not be
+// recorded in the registry history.
+func WithLogger(logger *zap.Logger) AdminServiceOption {
+	return func(as *adminService) {
+		as.logger = logger
+	}
 }
 
 const (
@@ -49,7 +77,12 @@ const (
 	M3DBNodeNamespacesKey = "m3db.node.namespaces"
 )
 
-func NewAdminService(store kv.Store, key string, idGen func() string) NamespaceMetadataAdminService {
+func NewAdminService(
+	store kv.Store,
+	key string,
+	idGen func() string,
+	opts ...AdminServiceOption,
+) NamespaceMetadataAdminService {
 	if idGen == nil {
 		idGen = func() string {
 			return uuid.NewV4().String()
@@ -58,11 +91,17 @@ func NewAdminService(store kv.Store, key string, idGen func() string) NamespaceM
 	if len(key) == 0 {
 		key = M3DBNodeNamespacesKey
 	}
-	return &adminService{
-		store: store,
-		key:   key,
-		idGen: idGen,
+	as := &adminService{
+		store:   store,
+		key:     key,
+		idGen:   idGen,
+		history: history.NewStore(store, key, history.NewOptions()),
+		logger:  zap.NewNop(),
+	}
+	for _, opt := range opts {
+		opt(as)
 	}
+	return as
 }
 
 func (as *adminService) GetAll() (*nsproto.Registry, error) {
@@ -94,12 +133,14 @@ func (as *adminService) Add(name string, options *nsproto.NamespaceOptions) erro
 	}
 	currentRegistry, currentVersion, err := as.currentRegistry()
 	if err == kv.ErrNotFound {
-		_, err = as.store.SetIfNotExists(as.key, &nsproto.Registry{
+		newRegistry := &nsproto.Registry{
 			Namespaces: map[string]*nsproto.NamespaceOptions{name: options},
-		})
+		}
+		version, err := as.store.SetIfNotExists(as.key, newRegistry)
 		if err != nil {
 			return xerrors.Wrapf(err, "failed to add namespace %v", name)
 		}
+		as.appendHistory(version, newRegistry)
 		return nil
 	}
 	if err != nil {
@@ -119,10 +160,13 @@ func (as *adminService) Add(name string, options *nsproto.NamespaceOptions) erro
 		return err
 	}
 
-	_, err = as.store.CheckAndSet(as.key, currentVersion, namespace.ToProto(newMap))
+	newRegistry := namespace.ToProto(newMap)
+	as.appendCurrentHistory()
+	version, err := as.store.CheckAndSet(as.key, currentVersion, newRegistry)
 	if err != nil {
 		return xerrors.Wrapf(err, "failed to add namespace %v", name)
 	}
+	as.appendHistory(version, newRegistry)
 	return nil
 }
 
@@ -141,10 +185,12 @@ func (as *adminService) Set(name string, options *nsproto.NamespaceOptions) erro
 
 	currentRegistry.Namespaces[name] = options
 
-	_, err = as.store.CheckAndSet(as.key, currentVersion, currentRegistry)
+	as.appendCurrentHistory()
+	version, err := as.store.CheckAndSet(as.key, currentVersion, currentRegistry)
 	if err != nil {
 		return xerrors.Wrapf(err, "failed to update namespace %v", name)
 	}
+	as.appendHistory(version, currentRegistry)
 	return nil
 }
 
@@ -176,10 +222,12 @@ func (as *adminService) ResetSchema(name string) error {
 	// Clear schema options in place.
 	targetMeta.SchemaOptions = nil
 
-	_, err = as.store.CheckAndSet(as.key, currentVersion, currentRegistry)
+	as.appendCurrentHistory()
+	version, err := as.store.CheckAndSet(as.key, currentVersion, currentRegistry)
 	if err != nil {
 		return xerrors.Wrapf(err, "failed to reset schema for namespace %s", name)
 	}
+	as.appendHistory(version, currentRegistry)
 	return nil
 }
 
@@ -213,13 +261,106 @@ func (as *adminService) DeploySchema(name, protoFileName, msgName string, protos
 	// Update schema options in place.
 	targetMeta.SchemaOptions = schemaOpt
 
-	_, err = as.store.CheckAndSet(as.key, currentVersion, currentRegistry)
+	as.appendCurrentHistory()
+	version, err := as.store.CheckAndSet(as.key, currentVersion, currentRegistry)
 	if err != nil {
 		return "", xerrors.Wrapf(err, "failed to deploy schema from %s with version %s to namespace %s", protoFileName, deployID, name)
 	}
+	as.appendHistory(version, currentRegistry)
 	return deployID, nil
 }
 
+func (as *adminService) History() ([]RegistryHistoryEntry, error) {
+	entries, err := as.history.Entries()
+	if err != nil {
+		return nil, xerrors.Wrapf(err, "failed to load namespace registry history for %s", as.key)
+	}
+
+	res := make([]RegistryHistoryEntry, 0, len(entries))
+	for _, entry := range entries {
+		historyEntry := RegistryHistoryEntry{
+			Version:   entry.Version,
+			Deleted:   entry.Deleted,
+			UpdatedAt: entry.UpdatedAt,
+			Metadata:  entry.Metadata,
+		}
+		if !entry.Deleted {
+			var registry nsproto.Registry
+			if err := entry.Unmarshal(&registry); err != nil {
+				return nil, fmt.Errorf("unable to parse registry version %d, err: %v", entry.Version, err)
+			}
+			historyEntry.Registry = &registry
+		}
+		res = append(res, historyEntry)
+	}
+	return res, nil
+}
+
+func (as *adminService) Rollback(version int) (*nsproto.Registry, error) {
+	entry, err := as.history.Entry(version)
+	if err != nil {
+		return nil, err
+	}
+
+	var registry nsproto.Registry
+	if err := entry.Unmarshal(&registry); err != nil {
+		return nil, fmt.Errorf("unable to parse registry version %d, err: %v", version, err)
+	}
+	if _, err := namespace.FromProto(registry); err != nil {
+		return nil, xerrors.Wrapf(err, "invalid namespace registry at version %d", version)
+	}
+
+	_, currentVersion, err := as.currentRegistry()
+	if err == kv.ErrNotFound {
+		newVersion, err := as.store.SetIfNotExists(as.key, &registry)
+		if err != nil {
+			return nil, xerrors.Wrapf(err, "failed to roll back namespace registry to version %d", version)
+		}
+		as.appendHistory(newVersion, &registry)
+		return &registry, nil
+	}
+	if err != nil {
+		return nil, xerrors.Wrapf(err, "failed to load namespace registry at %s", as.key)
+	}
+
+	newVersion, err := as.store.CheckAndSet(as.key, currentVersion, &registry)
+	if err != nil {
+		return nil, xerrors.Wrapf(err, "failed to roll back namespace registry to version %d", version)
+	}
+	as.appendHistory(newVersion, &registry)
+	return &registry, nil
+}
+
+// appendCurrentHistory keeps the current registry in the registry history
+// if the history is empty, so the registry from before the first change made
+// by the admin service can be rolled back to. Failing to record it does not
+// prevent the change, so it is logged rather than returned.
+func (as *adminService) appendCurrentHistory() {
+	if !as.recordHistory {
+		return
+	}
+	current := func() (proto.Message, int, error) {
+		return as.currentRegistry()
+	}
+	if err := as.history.AppendCurrent(current); err != nil {
+		as.logger.Error("could not record current namespace registry history",
+			zap.String("key", as.key), zap.Error(err))
+	}
+}
+
+// appendHistory keeps the registry written at the given version in the
+// registry history. The registry has already been written at this point,
+// so failing to record it is logged rather than returned.
+func (as *adminService) appendHistory(version int, registry *nsproto.Registry) {
+	if !as.recordHistory {
+		return
+	}
+	if err := as.history.Append(version, registry, as.changeMeta); err != nil {
+		as.logger.Error("could not record namespace registry history",
+			zap.String("key", as.key), zap.Int("version", version), zap.Error(err))
+	}
+}
+
 func (as *adminService) currentRegistry() (*nsproto.Registry, int, error) {
 	value, err := as.store.Get(as.key)
 	if err != nil {
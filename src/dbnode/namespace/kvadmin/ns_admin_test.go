@@ -25,6 +25,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/cluster/kv/util/history"
 	nsproto "github.com/m3db/m3/src/dbnode/generated/proto/namespace"
 	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/x/ident"
@@ -185,3 +186,77 @@ func TestAdminService_Crud(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, nsReg.Namespaces, 1)
 }
+
+func TestAdminService_HistoryAndRollback(t *testing.T) {
+	store := mem.NewStore()
+	var nsRegKey = "nsRegKey"
+	meta := history.Metadata{Operator: "alice", Reason: "test"}
+	as := NewAdminService(store, nsRegKey, func() string {return "first"},
+		WithHistory(history.NewOptions().SetLimit(2), meta))
+	require.NotNil(t, as)
+
+	opts := namespace.OptionsToProto(namespace.NewOptions())
+	require.NoError(t, as.Add("ns1", opts))
+	require.NoError(t, as.Add("ns2", opts))
+	require.NoError(t, as.Add("ns3", opts))
+
+	// Only the last two registries are kept.
+	entries, err := as.History()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, 2, entries[0].Version)
+	require.Len(t, entries[0].Registry.Namespaces, 2)
+	require.Equal(t, 3, entries[1].Version)
+	require.Len(t, entries[1].Registry.Namespaces, 3)
+	require.Equal(t, meta, entries[1].Metadata)
+
+	_, err = as.Rollback(1)
+	require.Equal(t, history.ErrVersionNotFound, err)
+
+	reg, err := as.Rollback(2)
+	require.NoError(t, err)
+	require.Len(t, reg.Namespaces, 2)
+
+	nsReg, err := as.GetAll()
+	require.NoError(t, err)
+	require.Len(t, nsReg.Namespaces, 2)
+	_, err = as.Get("ns3")
+	require.Equal(t, ErrNamespaceNotFound, err)
+
+	entries, err = as.History()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, 4, entries[1].Version)
+
+	// Registries written without the history option are not recorded.
+	as = NewAdminService(store, nsRegKey, nil)
+	require.NoError(t, as.Add("ns4", opts))
+	entries, err = as.History()
+	require.NoError(t, err)
+	require.Equal(t, 4, entries[len(entries)-1].Version)
+}
+
+func TestAdminService_HistoryKeepsRegistryBeforeFirstChange(t *testing.T) {
+	store := mem.NewStore()
+	var nsRegKey = "nsRegKey"
+	opts := namespace.OptionsToProto(namespace.NewOptions())
+
+	// A registry written before the history was kept.
+	as := NewAdminService(store, nsRegKey, nil)
+	require.NoError(t, as.Add("ns1", opts))
+
+	as = NewAdminService(store, nsRegKey, nil,
+		WithHistory(history.NewOptions(), history.Metadata{Operator: "alice"}))
+	require.NoError(t, as.Add("ns2", opts))
+
+	entries, err := as.History()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, 1, entries[0].Version)
+	require.Len(t, entries[0].Registry.Namespaces, 1)
+	require.Equal(t, history.Metadata{}, entries[0].Metadata)
+
+	reg, err := as.Rollback(1)
+	require.NoError(t, err)
+	require.Len(t, reg.Namespaces, 1)
+}
@@ -21,6 +21,9 @@
 package kvadmin
 
 import (
+	"time"
+
+	"github.com/m3db/m3/src/cluster/kv/util/history"
 	nsproto "github.com/m3db/m3/src/dbnode/generated/proto/namespace"
 )
 
@@ -48,4 +51,22 @@ type NamespaceMetadataAdminService interface {
 
 	// ResetSchema reset schema for the specified namespace.
 	ResetSchema(name string) error
+
+	// History returns the namespace registries kept in the registry history,
+	// oldest first.
+	History() ([]RegistryHistoryEntry, error)
+
+	// Rollback sets the namespace registry back to the given version from the
+	// registry history.
+	Rollback(version int) (*nsproto.Registry, error)
+}
+
+// RegistryHistoryEntry is a namespace registry kept in the registry history,
+// or the deletion of the registry at the version if Deleted is set.
+type RegistryHistoryEntry struct {
+	Registry  *nsproto.Registry
+	Version   int
+	Deleted   bool
+	UpdatedAt time.Time
+	Metadata  history.Metadata
 }
@@ -33,6 +33,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift"
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/convert"
 	tterrors "github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/errors"
+	"github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/dbnode/storage"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/index"
@@ -208,7 +209,8 @@ func (s *serviceState) DBForReadRPCWithLimit() (
 	if s.db == nil {
 		return nil, false, false
 	}
-	if s.numOutstandingReadRPCs >= s.maxOutstandingReadRPCs {
+	if s.maxOutstandingReadRPCs > 0 &&
+		s.numOutstandingReadRPCs >= s.maxOutstandingReadRPCs {
 		return nil, true, false
 	}
 
@@ -223,6 +225,12 @@ func (s *serviceState) DecNumOutstandingReadRPCs() {
 	s.Unlock()
 }
 
+func (s *serviceState) SetMaxOutstandingReadRPCs(value int) {
+	s.Lock()
+	s.maxOutstandingReadRPCs = value
+	s.Unlock()
+}
+
 type pools struct {
 	id                      ident.Pool
 	tagEncoder              serialize.TagEncoderPool
@@ -246,6 +254,10 @@ type Service interface {
 
 	// Only safe to be called one time once the service has started.
 	SetDatabase(db storage.Database) error
+
+	// SetRuntimeOptions applies the runtime options of the node, such as the
+	// maximum number of outstanding read requests.
+	SetRuntimeOptions(value runtime.Options)
 }
 
 // NewService creates a new node TChannel Thrift service
@@ -1952,6 +1964,10 @@ func (s *service) SetDatabase(db storage.Database) error {
 	return nil
 }
 
+func (s *service) SetRuntimeOptions(value runtime.Options) {
+	s.state.SetMaxOutstandingReadRPCs(value.MaxOutstandingReadRequests())
+}
+
 func (s *service) startWriteRPCWithDB() (storage.Database, error) {
 	if s.state.maxOutstandingWriteRPCs == 0 {
 		// No limitations on number of outstanding requests.
@@ -1984,11 +2000,8 @@ func (s *service) writeRPCCompleted() {
 }
 
 func (s *service) startReadRPCWithDB() (storage.Database, error) {
-	if s.state.maxOutstandingReadRPCs == 0 {
-		// No limitations on number of outstanding requests.
-		return s.startRPCWithDB()
-	}
-
+	// NB: outstanding read RPCs are always tracked, even without a limit, since
+	// the limit can be set at runtime while read RPCs are outstanding.
 	db, dbIsInitialized, requestDoesNotExceedLimit := s.state.DBForReadRPCWithLimit()
 	if !dbIsInitialized {
 		return nil, convert.ToRPCError(errDatabaseIsNotInitializedYet)
@@ -1998,6 +2011,7 @@ func (s *service) startReadRPCWithDB() (storage.Database, error) {
 		return nil, convert.ToRPCError(errServerIsOverloaded)
 	}
 	if db.IsOverloaded() {
+		s.state.DecNumOutstandingReadRPCs()
 		s.metrics.overloadRejected.Inc(1)
 		return nil, convert.ToRPCError(errServerIsOverloaded)
 	}
@@ -2006,11 +2020,6 @@ func (s *service) startReadRPCWithDB() (storage.Database, error) {
 }
 
 func (s *service) readRPCCompleted() {
-	if s.state.maxOutstandingReadRPCs == 0 {
-		// Nothing to do since we're not tracking the number outstanding RPCs.
-		return
-	}
-
 	s.state.DecNumOutstandingReadRPCs()
 }
 
@@ -953,6 +953,37 @@ func TestServiceFetchBatchRawOverMaxOutstandingRequests(t *testing.T) {
 	require.Equal(t, 0, service.state.numOutstandingReadRPCs)
 }
 
+func TestServiceSetRuntimeOptionsMaxOutstandingReadRequests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().IsOverloaded().Return(false).Times(3)
+
+	service := NewService(mockDB, testTChannelThriftOptions).(*service)
+
+	// Read RPCs outstanding before the limit is set count towards it.
+	_, err := service.startReadRPCWithDB()
+	require.NoError(t, err)
+
+	service.SetRuntimeOptions(runtime.NewOptions().SetMaxOutstandingReadRequests(1))
+	_, err = service.startReadRPCWithDB()
+	require.Equal(t, tterrors.NewInternalError(errServerIsOverloaded), err)
+
+	service.readRPCCompleted()
+	_, err = service.startReadRPCWithDB()
+	require.NoError(t, err)
+
+	service.SetRuntimeOptions(runtime.NewOptions())
+	_, err = service.startReadRPCWithDB()
+	require.NoError(t, err)
+
+	service.readRPCCompleted()
+	service.readRPCCompleted()
+	require.Equal(t, 0, service.state.numOutstandingReadRPCs)
+}
+
 func TestServiceFetchBatchRawUnknownError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
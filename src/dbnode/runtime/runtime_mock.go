@@ -408,6 +408,90 @@ func (mr *MockOptionsMockRecorder) IndexDefaultQueryTimeout() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexDefaultQueryTimeout", reflect.TypeOf((*MockOptions)(nil).IndexDefaultQueryTimeout))
 }
 
+// SetIndexMaxQueryLimit mocks base method
+func (m *MockOptions) SetIndexMaxQueryLimit(value int64) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetIndexMaxQueryLimit", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetIndexMaxQueryLimit indicates an expected call of SetIndexMaxQueryLimit
+func (mr *MockOptionsMockRecorder) SetIndexMaxQueryLimit(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetIndexMaxQueryLimit", reflect.TypeOf((*MockOptions)(nil).SetIndexMaxQueryLimit), value)
+}
+
+// IndexMaxQueryLimit mocks base method
+func (m *MockOptions) IndexMaxQueryLimit() int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IndexMaxQueryLimit")
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// IndexMaxQueryLimit indicates an expected call of IndexMaxQueryLimit
+func (mr *MockOptionsMockRecorder) IndexMaxQueryLimit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexMaxQueryLimit", reflect.TypeOf((*MockOptions)(nil).IndexMaxQueryLimit))
+}
+
+// SetMaxOutstandingReadRequests mocks base method
+func (m *MockOptions) SetMaxOutstandingReadRequests(value int) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMaxOutstandingReadRequests", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetMaxOutstandingReadRequests indicates an expected call of SetMaxOutstandingReadRequests
+func (mr *MockOptionsMockRecorder) SetMaxOutstandingReadRequests(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMaxOutstandingReadRequests", reflect.TypeOf((*MockOptions)(nil).SetMaxOutstandingReadRequests), value)
+}
+
+// MaxOutstandingReadRequests mocks base method
+func (m *MockOptions) MaxOutstandingReadRequests() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaxOutstandingReadRequests")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// MaxOutstandingReadRequests indicates an expected call of MaxOutstandingReadRequests
+func (mr *MockOptionsMockRecorder) MaxOutstandingReadRequests() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaxOutstandingReadRequests", reflect.TypeOf((*MockOptions)(nil).MaxOutstandingReadRequests))
+}
+
+// SetClientWriteRequestTimeout mocks base method
+func (m *MockOptions) SetClientWriteRequestTimeout(value time.Duration) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetClientWriteRequestTimeout", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetClientWriteRequestTimeout indicates an expected call of SetClientWriteRequestTimeout
+func (mr *MockOptionsMockRecorder) SetClientWriteRequestTimeout(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetClientWriteRequestTimeout", reflect.TypeOf((*MockOptions)(nil).SetClientWriteRequestTimeout), value)
+}
+
+// ClientWriteRequestTimeout mocks base method
+func (m *MockOptions) ClientWriteRequestTimeout() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClientWriteRequestTimeout")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// ClientWriteRequestTimeout indicates an expected call of ClientWriteRequestTimeout
+func (mr *MockOptionsMockRecorder) ClientWriteRequestTimeout() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClientWriteRequestTimeout", reflect.TypeOf((*MockOptions)(nil).ClientWriteRequestTimeout))
+}
+
 // MockOptionsManager is a mock of OptionsManager interface
 type MockOptionsManager struct {
 	ctrl     *gomock.Controller
@@ -60,6 +60,12 @@ var (
 		"tick series batch size must be positive")
 	errTickPerSeriesSleepDurationMustBePositive = errors.New(
 		"tick per series sleep duration must be positive")
+	errIndexMaxQueryLimitIsNegative = errors.New(
+		"index max query limit cannot be negative")
+	errMaxOutstandingReadRequestsIsNegative = errors.New(
+		"max outstanding read requests cannot be negative")
+	errClientWriteRequestTimeoutIsNegative = errors.New(
+		"client write request timeout cannot be negative")
 )
 
 type options struct {
@@ -75,6 +81,9 @@ type options struct {
 	clientReadConsistencyLevel           topology.ReadConsistencyLevel
 	clientWriteConsistencyLevel          topology.ConsistencyLevel
 	indexDefaultQueryTimeout             time.Duration
+	indexMaxQueryLimit                   int64
+	maxOutstandingReadRequests           int
+	clientWriteRequestTimeout            time.Duration
 }
 
 // NewOptions creates a new set of runtime options with defaults
@@ -117,6 +126,22 @@ func (o *options) Validate() error {
 
 	// tickMinimumInterval can be zero if user desires
 
+	// indexMaxQueryLimit can be zero to specify no maximum
+	if o.indexMaxQueryLimit < 0 {
+		return errIndexMaxQueryLimitIsNegative
+	}
+
+	// maxOutstandingReadRequests can be zero to specify no limit
+	if o.maxOutstandingReadRequests < 0 {
+		return errMaxOutstandingReadRequestsIsNegative
+	}
+
+	// clientWriteRequestTimeout can be zero to use the client's configured
+	// write request timeout
+	if o.clientWriteRequestTimeout < 0 {
+		return errClientWriteRequestTimeoutIsNegative
+	}
+
 	return nil
 }
 
@@ -239,3 +264,33 @@ func (o *options) SetIndexDefaultQueryTimeout(value time.Duration) Options {
 func (o *options) IndexDefaultQueryTimeout() time.Duration {
 	return o.indexDefaultQueryTimeout
 }
+
+func (o *options) SetIndexMaxQueryLimit(value int64) Options {
+	opts := *o
+	opts.indexMaxQueryLimit = value
+	return &opts
+}
+
+func (o *options) IndexMaxQueryLimit() int64 {
+	return o.indexMaxQueryLimit
+}
+
+func (o *options) SetMaxOutstandingReadRequests(value int) Options {
+	opts := *o
+	opts.maxOutstandingReadRequests = value
+	return &opts
+}
+
+func (o *options) MaxOutstandingReadRequests() int {
+	return o.maxOutstandingReadRequests
+}
+
+func (o *options) SetClientWriteRequestTimeout(value time.Duration) Options {
+	opts := *o
+	opts.clientWriteRequestTimeout = value
+	return &opts
+}
+
+func (o *options) ClientWriteRequestTimeout() time.Duration {
+	return o.clientWriteRequestTimeout
+}
@@ -22,6 +22,7 @@ package runtime
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -30,3 +31,30 @@ func TestRuntimeOptionsDefaultsIsValid(t *testing.T) {
 	v := NewOptions()
 	assert.NoError(t, v.Validate())
 }
+
+func TestRuntimeOptionsIndexMaxQueryLimit(t *testing.T) {
+	v := NewOptions().SetIndexMaxQueryLimit(100)
+	assert.Equal(t, int64(100), v.IndexMaxQueryLimit())
+	assert.NoError(t, v.Validate())
+
+	v = v.SetIndexMaxQueryLimit(-1)
+	assert.Equal(t, errIndexMaxQueryLimitIsNegative, v.Validate())
+}
+
+func TestRuntimeOptionsMaxOutstandingReadRequests(t *testing.T) {
+	v := NewOptions().SetMaxOutstandingReadRequests(100)
+	assert.Equal(t, 100, v.MaxOutstandingReadRequests())
+	assert.NoError(t, v.Validate())
+
+	v = v.SetMaxOutstandingReadRequests(-1)
+	assert.Equal(t, errMaxOutstandingReadRequestsIsNegative, v.Validate())
+}
+
+func TestRuntimeOptionsClientWriteRequestTimeout(t *testing.T) {
+	v := NewOptions().SetClientWriteRequestTimeout(time.Second)
+	assert.Equal(t, time.Second, v.ClientWriteRequestTimeout())
+	assert.NoError(t, v.Validate())
+
+	v = v.SetClientWriteRequestTimeout(-time.Second)
+	assert.Equal(t, errClientWriteRequestTimeoutIsNegative, v.Validate())
+}
@@ -168,6 +168,34 @@ type Options interface {
 	// IndexDefaultQueryTimeout is the hard timeout value to use if none is
 	// specified for a specific query, zero specifies to use no timeout at all.
 	IndexDefaultQueryTimeout() time.Duration
+
+	// SetIndexMaxQueryLimit sets the maximum number of results returned by an
+	// index query, queries asking for more or for no limit are capped to it,
+	// zero specifies no maximum.
+	SetIndexMaxQueryLimit(value int64) Options
+
+	// IndexMaxQueryLimit returns the maximum number of results returned by an
+	// index query, zero specifies no maximum.
+	IndexMaxQueryLimit() int64
+
+	// SetMaxOutstandingReadRequests sets the maximum number of read requests
+	// served concurrently by the node, requests over it are rejected as the
+	// node being overloaded, zero specifies no limit.
+	SetMaxOutstandingReadRequests(value int) Options
+
+	// MaxOutstandingReadRequests returns the maximum number of read requests
+	// served concurrently by the node, zero specifies no limit.
+	MaxOutstandingReadRequests() int
+
+	// SetClientWriteRequestTimeout sets the timeout of write requests made by
+	// the clients used by the node, zero specifies to use the write request
+	// timeout the client was configured with.
+	SetClientWriteRequestTimeout(value time.Duration) Options
+
+	// ClientWriteRequestTimeout returns the timeout of write requests made by
+	// the clients used by the node, zero specifies to use the write request
+	// timeout the client was configured with.
+	ClientWriteRequestTimeout() time.Duration
 }
 
 // OptionsManager updates and supplies runtime options.
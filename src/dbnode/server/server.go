@@ -433,12 +433,20 @@ func Run(runOpts RunOptions) {
 			SetTickMinimumInterval(tick.MinimumInterval)
 	}
 
+	runtimeOpts = runtimeOpts.
+		SetMaxOutstandingReadRequests(cfg.Limits.MaxOutstandingReadRequests)
+
 	runtimeOptsMgr := m3dbruntime.NewOptionsManager()
 	if err := runtimeOptsMgr.Update(runtimeOpts); err != nil {
 		logger.Fatal("could not set initial runtime options", zap.Error(err))
 	}
 	defer runtimeOptsMgr.Close()
 
+	// The runtime options are updated from several KV watches, each reading
+	// the current options and updating them with its change, so the updates
+	// are serialized to not lose each other's changes.
+	runtimeOptsLock := &sync.Mutex{}
+
 	opts = opts.SetRuntimeOptionsManager(runtimeOptsMgr)
 
 	policy := cfg.PoolingPolicy
@@ -620,6 +628,7 @@ func Run(runOpts RunOptions) {
 		// SetDatabase() once we've initialized it.
 		service = ttnode.NewService(nil, ttopts)
 	)
+	runtimeOptsMgr.RegisterListener(service)
 	if cfg.TChannel != nil {
 		tchannelOpts.MaxIdleTime = cfg.TChannel.MaxIdleTime
 		tchannelOpts.IdleCheckInterval = cfg.TChannel.IdleCheckInterval
@@ -720,7 +729,7 @@ func Run(runOpts RunOptions) {
 	origin := topology.NewHost(hostID, "")
 	m3dbClient, err := newAdminClient(
 		cfg.Client, iopts, tchannelOpts, syncCfg.TopologyInitializer,
-		runtimeOptsMgr, origin, protoEnabled, schemaRegistry,
+		runtimeOptsMgr, runtimeOptsLock, origin, protoEnabled, schemaRegistry,
 		syncCfg.KVStore, logger, runOpts.CustomOptions)
 
 	if err != nil {
@@ -757,7 +766,7 @@ func Run(runOpts RunOptions) {
 			clientCfg := *cluster.Client
 			clusterClient, err := newAdminClient(
 				clientCfg, iopts, tchannelOpts, topologyInitializer,
-				runtimeOptsMgr, origin, protoEnabled, schemaRegistry,
+				runtimeOptsMgr, runtimeOptsLock, origin, protoEnabled, schemaRegistry,
 				syncCfg.KVStore, logger, runOpts.CustomOptions)
 			if err != nil {
 				logger.Fatal(
@@ -831,6 +840,8 @@ func Run(runOpts RunOptions) {
 		}
 	}()
 
+	kvWatchRuntimeOptions(syncCfg.KVStore, logger, runtimeOptsMgr, runtimeOptsLock)
+
 	kvWatchBootstrappers(syncCfg.KVStore, logger, timeout, cfg.Bootstrap.Bootstrappers,
 		func(bootstrappers []string) {
 			if len(bootstrappers) == 0 {
@@ -915,7 +926,7 @@ func Run(runOpts RunOptions) {
 
 		// Only set the write new series limit after bootstrapping
 		kvWatchNewSeriesLimitPerShard(syncCfg.KVStore, logger, topo,
-			runtimeOptsMgr, cfg.WriteNewSeriesLimitPerSecond)
+			runtimeOptsMgr, runtimeOptsLock, cfg.WriteNewSeriesLimitPerSecond)
 	}()
 
 	// Wait for process interrupt.
@@ -980,6 +991,7 @@ func kvWatchNewSeriesLimitPerShard(
 	logger *zap.Logger,
 	topo topology.Topology,
 	runtimeOptsMgr m3dbruntime.OptionsManager,
+	runtimeOptsLock sync.Locker,
 	defaultClusterNewSeriesLimit int,
 ) {
 	var initClusterLimit int
@@ -1000,7 +1012,7 @@ func kvWatchNewSeriesLimitPerShard(
 		initClusterLimit = defaultClusterNewSeriesLimit
 	}
 
-	err = setNewSeriesLimitPerShardOnChange(topo, runtimeOptsMgr, initClusterLimit)
+	err = setNewSeriesLimitPerShardOnChange(topo, runtimeOptsMgr, runtimeOptsLock, initClusterLimit)
 	if err != nil {
 		logger.Warn("unable to set cluster new series insert limit", zap.Error(err))
 	}
@@ -1023,7 +1035,7 @@ func kvWatchNewSeriesLimitPerShard(
 				value = int(protoValue.Value)
 			}
 
-			err = setNewSeriesLimitPerShardOnChange(topo, runtimeOptsMgr, value)
+			err = setNewSeriesLimitPerShardOnChange(topo, runtimeOptsMgr, runtimeOptsLock, value)
 			if err != nil {
 				logger.Warn("unable to set cluster new series insert limit", zap.Error(err))
 				continue
@@ -1037,15 +1049,23 @@ func kvWatchClientConsistencyLevels(
 	logger *zap.Logger,
 	clientOpts client.AdminOptions,
 	runtimeOptsMgr m3dbruntime.OptionsManager,
+	runtimeOptsLock sync.Locker,
 ) {
+	update := func(applyFn func(m3dbruntime.Options) m3dbruntime.Options) error {
+		runtimeOptsLock.Lock()
+		defer runtimeOptsLock.Unlock()
+		return runtimeOptsMgr.Update(applyFn(runtimeOptsMgr.Get()))
+	}
+
 	setReadConsistencyLevel := func(
 		v string,
 		applyFn func(topology.ReadConsistencyLevel, m3dbruntime.Options) m3dbruntime.Options,
 	) error {
 		for _, level := range topology.ValidReadConsistencyLevels() {
 			if level.String() == v {
-				runtimeOpts := applyFn(level, runtimeOptsMgr.Get())
-				return runtimeOptsMgr.Update(runtimeOpts)
+				return update(func(opts m3dbruntime.Options) m3dbruntime.Options {
+					return applyFn(level, opts)
+				})
 			}
 		}
 		return fmt.Errorf("invalid read consistency level set: %s", v)
@@ -1057,8 +1077,9 @@ func kvWatchClientConsistencyLevels(
 	) error {
 		for _, level := range topology.ValidConsistencyLevels() {
 			if level.String() == v {
-				runtimeOpts := applyFn(level, runtimeOptsMgr.Get())
-				return runtimeOptsMgr.Update(runtimeOpts)
+				return update(func(opts m3dbruntime.Options) m3dbruntime.Options {
+					return applyFn(level, opts)
+				})
 			}
 		}
 		return fmt.Errorf("invalid consistency level set: %s", v)
@@ -1073,8 +1094,9 @@ func kvWatchClientConsistencyLevels(
 				})
 		},
 		func() error {
-			return runtimeOptsMgr.Update(runtimeOptsMgr.Get().
-				SetClientBootstrapConsistencyLevel(clientOpts.BootstrapConsistencyLevel()))
+			return update(func(opts m3dbruntime.Options) m3dbruntime.Options {
+				return opts.SetClientBootstrapConsistencyLevel(clientOpts.BootstrapConsistencyLevel())
+			})
 		})
 
 	kvWatchStringValue(store, logger,
@@ -1086,8 +1108,9 @@ func kvWatchClientConsistencyLevels(
 				})
 		},
 		func() error {
-			return runtimeOptsMgr.Update(runtimeOptsMgr.Get().
-				SetClientReadConsistencyLevel(clientOpts.ReadConsistencyLevel()))
+			return update(func(opts m3dbruntime.Options) m3dbruntime.Options {
+				return opts.SetClientReadConsistencyLevel(clientOpts.ReadConsistencyLevel())
+			})
 		})
 
 	kvWatchStringValue(store, logger,
@@ -1099,8 +1122,9 @@ func kvWatchClientConsistencyLevels(
 				})
 		},
 		func() error {
-			return runtimeOptsMgr.Update(runtimeOptsMgr.Get().
-				SetClientWriteConsistencyLevel(clientOpts.WriteConsistencyLevel()))
+			return update(func(opts m3dbruntime.Options) m3dbruntime.Options {
+				return opts.SetClientWriteConsistencyLevel(clientOpts.WriteConsistencyLevel())
+			})
 		})
 }
 
@@ -1162,9 +1186,13 @@ func kvWatchStringValue(
 func setNewSeriesLimitPerShardOnChange(
 	topo topology.Topology,
 	runtimeOptsMgr m3dbruntime.OptionsManager,
+	runtimeOptsLock sync.Locker,
 	clusterLimit int,
 ) error {
 	perPlacedShardLimit := clusterLimitToPlacedShardLimit(topo, clusterLimit)
+
+	runtimeOptsLock.Lock()
+	defer runtimeOptsLock.Unlock()
 	runtimeOpts := runtimeOptsMgr.Get()
 	if runtimeOpts.WriteNewSeriesLimitPerShardPerSecond() == perPlacedShardLimit {
 		// Not changed, no need to set the value and trigger a runtime options update
@@ -1191,6 +1219,33 @@ func clusterLimitToPlacedShardLimit(topo topology.Topology, clusterLimit int) in
 	return nodeLimit
 }
 
+// kvWatchRuntimeOptions applies the node runtime options set in KV, falling back
+// to the configured runtime options when a key is not set. Updates are applied
+// holding the runtime options lock.
+func kvWatchRuntimeOptions(
+	store kv.Store,
+	logger *zap.Logger,
+	runtimeOptsMgr m3dbruntime.OptionsManager,
+	runtimeOptsLock sync.Locker,
+) {
+	configured := runtimeOptsMgr.Get()
+	watchOpts := util.NewOptions().SetLogger(logger)
+	for _, option := range kvconfig.NodeRuntimeOptions() {
+		option := option
+		_, err := option.Watch(store, option.Value(configured), func(value interface{}) {
+			runtimeOpts := option.Apply(runtimeOptsMgr.Get(), value)
+			if err := runtimeOptsMgr.Update(runtimeOpts); err != nil {
+				logger.Warn("could not update runtime option",
+					zap.String("key", option.Key), zap.Error(err))
+			}
+		}, runtimeOptsLock, watchOpts)
+		if err != nil {
+			logger.Error("could not watch runtime option",
+				zap.String("key", option.Key), zap.Error(err))
+		}
+	}
+}
+
 // this function will block for at most waitTimeout to try to get an initial value
 // before we kick off the bootstrap
 func kvWatchBootstrappers(
@@ -1579,6 +1634,7 @@ func newAdminClient(
 	tchannelOpts *tchannel.ChannelOptions,
 	topologyInitializer topology.Initializer,
 	runtimeOptsMgr m3dbruntime.OptionsManager,
+	runtimeOptsLock sync.Locker,
 	origin topology.Host,
 	protoEnabled bool,
 	schemaRegistry namespace.SchemaRegistry,
@@ -1633,7 +1689,7 @@ func newAdminClient(
 	// Kick off runtime options manager KV watches.
 	clientAdminOpts := m3dbClient.Options().(client.AdminOptions)
 	kvWatchClientConsistencyLevels(kvStore, logger,
-		clientAdminOpts, runtimeOptsMgr)
+		clientAdminOpts, runtimeOptsMgr, runtimeOptsLock)
 	return m3dbClient, nil
 }
 
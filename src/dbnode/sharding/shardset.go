@@ -85,6 +85,14 @@ func (s *shardSet) LookupStateByID(shardID uint32) (shard.State, error) {
 	return hostShard.State(), nil
 }
 
+func (s *shardSet) LookupShard(shardID uint32) (shard.Shard, error) {
+	hostShard, ok := s.shardMap[shardID]
+	if !ok {
+		return nil, ErrInvalidShardID
+	}
+	return hostShard, nil
+}
+
 func (s *shardSet) All() []shard.Shard {
 	return s.shards[:]
 }
@@ -146,6 +154,43 @@ func validateShards(shards []shard.Shard) error {
 	return nil
 }
 
+// NewSplitShardFilter returns a filter that matches the IDs hashed to the
+// given shard. A shard created by splitting a shard set inherits the series
+// of its split source shard, which holds the series of all of its children,
+// so the series fetched from the source are filtered down with it. This
+// relies on the shard count after the split being a multiple of the shard
+// count before it, so that every ID of the child was hashed to the source.
+func NewSplitShardFilter(fn HashFn, shardID uint32) func(id ident.ID) bool {
+	return func(id ident.ID) bool {
+		return fn(id) == shardID
+	}
+}
+
+// IsServing returns whether the shard with the given ID serves reads and
+// writes in the shard set. Available shards do, and so do shards that are
+// initializing from the shard they were split from while that split source is
+// available in the shard set, since the split source holds the data of the
+// shard until it has been initialized and the shard receives its new writes.
+func IsServing(shardSet ShardSet, shardID uint32) bool {
+	s, err := shardSet.LookupShard(shardID)
+	if err != nil {
+		return false
+	}
+	switch s.State() {
+	case shard.Available:
+		return true
+	case shard.Initializing:
+		splitSource, isSplit := s.SplitSource()
+		if !isSplit {
+			return false
+		}
+		state, err := shardSet.LookupStateByID(splitSource)
+		return err == nil && state == shard.Available
+	default:
+		return false
+	}
+}
+
 // DefaultHashFn generates a HashFn based on murmur32
 func DefaultHashFn(length int) HashFn {
 	return NewHashFn(length, 0)
@@ -21,6 +21,7 @@
 package sharding
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/m3db/m3/src/cluster/shard"
@@ -75,3 +76,54 @@ func TestLookupShardState(t *testing.T) {
 	require.Equal(t, ErrInvalidShardID, err)
 	require.Equal(t, noState, shardTwoState)
 }
+
+func TestShardSetLookupShard(t *testing.T) {
+	ss, err := NewShardSet(NewShards([]uint32{1}, shard.Available), DefaultHashFn(2))
+	require.NoError(t, err)
+
+	s, err := ss.LookupShard(1)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), s.ID())
+
+	_, err = ss.LookupShard(2)
+	require.Equal(t, ErrInvalidShardID, err)
+}
+
+func TestIsServing(t *testing.T) {
+	var (
+		source = shard.NewShard(1).SetState(shard.Available)
+		child  = shard.NewShard(3).SetState(shard.Initializing).SetSplitSource(1)
+		other  = shard.NewShard(0).SetState(shard.Initializing)
+	)
+	ss, err := NewShardSet([]shard.Shard{other, source, child}, DefaultHashFn(4))
+	require.NoError(t, err)
+
+	require.True(t, IsServing(ss, 1))
+	require.False(t, IsServing(ss, 0))
+	require.False(t, IsServing(ss, 2))
+
+	// A split shard is served with its split source while it initializes.
+	require.True(t, IsServing(ss, 3))
+	source.SetState(shard.Leaving)
+	require.False(t, IsServing(ss, 3))
+	source.SetState(shard.Available)
+
+	child.SetState(shard.Available)
+	require.True(t, IsServing(ss, 3))
+	child.SetState(shard.Leaving)
+	require.False(t, IsServing(ss, 3))
+}
+
+func TestSplitShardFilter(t *testing.T) {
+	var (
+		sourceFn = DefaultHashFn(4)
+		splitFn  = DefaultHashFn(16)
+	)
+	for i := 0; i < 1000; i++ {
+		id := ident.StringID(fmt.Sprintf("foo.%d", i))
+		child := splitFn(id)
+		require.Equal(t, sourceFn(id), child%4)
+		require.True(t, NewSplitShardFilter(splitFn, child)(id))
+		require.False(t, NewSplitShardFilter(splitFn, (child+4)%16)(id))
+	}
+}
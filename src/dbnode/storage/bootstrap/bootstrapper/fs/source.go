@@ -36,6 +36,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/storage/index/convert"
 	"github.com/m3db/m3/src/dbnode/storage/series"
+	"github.com/m3db/m3/src/dbnode/topology"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/m3ninx/doc"
 	"github.com/m3db/m3/src/m3ninx/index/segment"
@@ -117,7 +118,7 @@ func (s *fileSystemSource) AvailableData(
 	shardsTimeRanges result.ShardTimeRanges,
 	runOpts bootstrap.RunOptions,
 ) (result.ShardTimeRanges, error) {
-	return s.availability(md, shardsTimeRanges)
+	return s.availability(md, shardsTimeRanges, runOpts.InitialTopologyState())
 }
 
 func (s *fileSystemSource) AvailableIndex(
@@ -125,7 +126,7 @@ func (s *fileSystemSource) AvailableIndex(
 	shardsTimeRanges result.ShardTimeRanges,
 	runOpts bootstrap.RunOptions,
 ) (result.ShardTimeRanges, error) {
-	return s.availability(md, shardsTimeRanges)
+	return s.availability(md, shardsTimeRanges, runOpts.InitialTopologyState())
 }
 
 func (s *fileSystemSource) Read(
@@ -209,10 +210,11 @@ func (s *fileSystemSource) Read(
 func (s *fileSystemSource) availability(
 	md namespace.Metadata,
 	shardsTimeRanges result.ShardTimeRanges,
+	topoState *topology.StateSnapshot,
 ) (result.ShardTimeRanges, error) {
 	result := result.NewShardTimeRangesFromSize(shardsTimeRanges.Len())
 	for shard, ranges := range shardsTimeRanges.Iter() {
-		result.Set(shard, s.shardAvailability(md.ID(), shard, ranges))
+		result.Set(shard, s.shardAvailability(md.ID(), shard, ranges, topoState))
 	}
 	return result, nil
 }
@@ -221,6 +223,23 @@ func (s *fileSystemSource) shardAvailability(
 	namespace ident.ID,
 	shard uint32,
 	targetRangesForShard xtime.Ranges,
+	topoState *topology.StateSnapshot,
+) xtime.Ranges {
+	tr := s.filesetAvailability(namespace, shard, targetRangesForShard)
+	if source, ok := bootstrapper.SplitSource(topoState, shard); ok {
+		// The blocks flushed before the shard was split from its split source
+		// are read from the filesets of the split source.
+		remaining := targetRangesForShard.Clone()
+		remaining.RemoveRanges(tr)
+		tr.AddRanges(s.filesetAvailability(namespace, source, remaining))
+	}
+	return tr
+}
+
+func (s *fileSystemSource) filesetAvailability(
+	namespace ident.ID,
+	shard uint32,
+	targetRangesForShard xtime.Ranges,
 ) xtime.Ranges {
 	if targetRangesForShard.IsEmpty() {
 		return xtime.NewRanges()
@@ -352,6 +371,7 @@ func (s *fileSystemSource) loadShardReadersDataIntoShardResult(
 				timeRange = r.Range()
 				start     = timeRange.Start
 				blockSize = ns.Options().RetentionOptions().BlockSize()
+				filterFn  = bootstrapper.ReaderFilter(runOpts.InitialTopologyState(), shard, r)
 				err       error
 			)
 			switch run {
@@ -369,10 +389,10 @@ func (s *fileSystemSource) loadShardReadersDataIntoShardResult(
 				switch run {
 				case bootstrapDataRunType:
 					err = s.readNextEntryAndRecordBlock(nsCtx, accumulator, shard, r,
-						runResult, start, blockSize, blockPool, seriesCachePolicy)
+						filterFn, runResult, start, blockSize, blockPool, seriesCachePolicy)
 				case bootstrapIndexRunType:
 					// We can just read the entry and index if performing an index run.
-					batch, err = s.readNextEntryAndMaybeIndex(r, batch, builder)
+					batch, err = s.readNextEntryAndMaybeIndex(r, filterFn, batch, builder)
 					if err != nil {
 						s.log.Error("readNextEntryAndMaybeIndex failed",
 							zap.String("error", err.Error()),
@@ -582,6 +602,7 @@ func (s *fileSystemSource) readNextEntryAndRecordBlock(
 	accumulator bootstrap.NamespaceDataAccumulator,
 	shardID uint32,
 	r fs.DataFileSetReader,
+	filterFn func(id ident.ID) bool,
 	runResult *runResult,
 	blockStart time.Time,
 	blockSize time.Duration,
@@ -616,6 +637,11 @@ func (s *fileSystemSource) readNextEntryAndRecordBlock(
 		return fmt.Errorf("error reading data file: %v", err)
 	}
 
+	if filterFn != nil && !filterFn(id) {
+		// Ignore the series of the other shards split from the same source.
+		return nil
+	}
+
 	ref, owned, err := accumulator.CheckoutSeriesWithLock(shardID, id, tagsIter)
 	if err != nil {
 		if !owned {
@@ -636,6 +662,7 @@ func (s *fileSystemSource) readNextEntryAndRecordBlock(
 
 func (s *fileSystemSource) readNextEntryAndMaybeIndex(
 	r fs.DataFileSetReader,
+	filterFn func(id ident.ID) bool,
 	batch []doc.Document,
 	builder *result.IndexBuilder,
 ) ([]doc.Document, error) {
@@ -645,6 +672,13 @@ func (s *fileSystemSource) readNextEntryAndMaybeIndex(
 		return batch, err
 	}
 
+	if filterFn != nil && !filterFn(id) {
+		// Ignore the series of the other shards split from the same source.
+		id.Finalize()
+		tagsIter.Close()
+		return batch, nil
+	}
+
 	d, err := convert.FromMetricIter(id, tagsIter)
 	// Finalize the ID and tags.
 	id.Finalize()
@@ -693,7 +727,8 @@ func (s *fileSystemSource) read(
 		if seriesCachePolicy != series.CacheAll {
 			// Unless we're caching all series (or all series metadata) in memory, we
 			// return just the availability of the files we have.
-			return s.bootstrapDataRunResultFromAvailability(md, shardsTimeRanges), nil
+			return s.bootstrapDataRunResultFromAvailability(md, shardsTimeRanges,
+				runOpts.InitialTopologyState()), nil
 		}
 	}
 
@@ -748,6 +783,7 @@ func (s *fileSystemSource) newReader() (fs.DataFileSetReader, error) {
 func (s *fileSystemSource) bootstrapDataRunResultFromAvailability(
 	md namespace.Metadata,
 	shardsTimeRanges result.ShardTimeRanges,
+	topoState *topology.StateSnapshot,
 ) *runResult {
 	runResult := newRunResult()
 	unfulfilled := runResult.data.Unfulfilled()
@@ -755,7 +791,7 @@ func (s *fileSystemSource) bootstrapDataRunResultFromAvailability(
 		if ranges.IsEmpty() {
 			continue
 		}
-		availability := s.shardAvailability(md.ID(), shard, ranges)
+		availability := s.shardAvailability(md.ID(), shard, ranges, topoState)
 		remaining := ranges.Clone()
 		remaining.RemoveRanges(availability)
 		if !remaining.IsEmpty() {
@@ -30,15 +30,18 @@ import (
 	"testing"
 	"time"
 
+	"github.com/m3db/m3/src/cluster/shard"
 	"github.com/m3db/m3/src/dbnode/digest"
 	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/retention"
+	"github.com/m3db/m3/src/dbnode/sharding"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/storage/index/compaction"
 	"github.com/m3db/m3/src/dbnode/storage/series"
+	tu "github.com/m3db/m3/src/dbnode/topology/testutil"
 	"github.com/m3db/m3/src/m3ninx/index/segment/fst"
 	"github.com/m3db/m3/src/x/checked"
 	"github.com/m3db/m3/src/x/context"
@@ -857,3 +860,64 @@ func TestReadTags(t *testing.T) {
 	require.Equal(t, tags, reader.Tags)
 	tester.EnsureNoWrites()
 }
+
+func TestReadSplitShardFromSplitSource(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	// Shard 4 was split from shard 0 when growing from 4 to 8 shards.
+	hashFn := sharding.DefaultHashFn(8)
+	var childID, sourceID string
+	for i := 0; childID == "" || sourceID == ""; i++ {
+		id := fmt.Sprintf("foo.%d", i)
+		switch hashFn(ident.StringID(id)) {
+		case 4:
+			childID = id
+		case 0:
+			sourceID = id
+		}
+	}
+
+	writeTSDBFiles(t, dir, testNs1ID, 0, testStart, []testSeries{
+		{childID, nil, []byte{1, 2, 3}},
+		{sourceID, nil, []byte{4, 5, 6}},
+	})
+
+	topoState := tu.NewStateSnapshot(1, tu.HostShardStates{
+		tu.SelfID: []shard.Shard{
+			shard.NewShard(0).SetState(shard.Available),
+			shard.NewShard(4).SetState(shard.Initializing).SetSplitSource(0),
+		},
+	})
+	topoState.HashFn = hashFn
+	runOpts := testDefaultRunOpts.SetInitialTopologyState(topoState)
+
+	src, err := newFileSystemSource(newTestOptions(t, dir))
+	require.NoError(t, err)
+
+	nsMD := testNsMetadata(t)
+	blockRanges := xtime.NewRanges(xtime.Range{
+		Start: testStart,
+		End:   testStart.Add(testBlockSize),
+	})
+	ranges := result.NewShardTimeRanges().Set(4, blockRanges)
+
+	// The split shard is available from the filesets of its split source.
+	res, err := src.AvailableData(nsMD, ranges, runOpts)
+	require.NoError(t, err)
+	tr, ok := res.Get(4)
+	require.True(t, ok)
+	validateTimeRanges(t, tr, blockRanges)
+
+	tester := bootstrap.BuildNamespacesTester(t, runOpts, ranges, nsMD)
+	defer tester.Finish()
+
+	// Only the series of the split shard are read from its split source.
+	tester.TestReadWith(src)
+	tester.TestUnfulfilledForNamespaceIsEmpty(nsMD)
+	readers := tester.EnsureDumpReadersForNamespace(nsMD)
+	require.Equal(t, 1, len(readers))
+	_, found := readers[childID]
+	require.True(t, found)
+	tester.EnsureNoWrites()
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/sharding"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/bootstrapper"
@@ -278,9 +279,24 @@ func (s *peersSource) readData(
 			opts, persistenceWorkerDoneCh, persistenceQueue, persistFlush, result, &resultLock)
 	}
 
+	var (
+		initialTopologyState = opts.InitialTopologyState()
+		splitShardsBySource  = make(map[uint32]map[uint32]xtime.Ranges)
+	)
 	workers := xsync.NewWorkerPool(concurrency)
 	workers.Init()
 	for shard, ranges := range shardsTimeRanges.Iter() {
+		if source, ok := bootstrapper.SplitSource(initialTopologyState, shard); ok {
+			// The data of a split shard lives in its split source on the peers,
+			// which is fetched once for all of the shards split from it.
+			splitShards, ok := splitShardsBySource[source]
+			if !ok {
+				splitShards = make(map[uint32]xtime.Ranges)
+				splitShardsBySource[source] = splitShards
+			}
+			splitShards[shard] = ranges
+			continue
+		}
 		shard, ranges := shard, ranges
 		wg.Add(1)
 		workers.Go(func() {
@@ -290,6 +306,17 @@ func (s *peersSource) readData(
 				persistenceQueue, shardRetrieverMgr, blockSize)
 		})
 	}
+	for source, splitShards := range splitShardsBySource {
+		source, splitShards := source, splitShards
+		wg.Add(1)
+		workers.Go(func() {
+			defer wg.Done()
+			s.fetchSplitBootstrapBlocksFromPeers(source, splitShards,
+				initialTopologyState.HashFn, nsMetadata, session, accumulator,
+				resultOpts, result, &resultLock, shouldPersist, persistenceQueue,
+				shardRetrieverMgr, blockSize)
+		})
+	}
 
 	wg.Wait()
 	close(persistenceQueue)
@@ -361,15 +388,11 @@ func (s *peersSource) fetchBootstrapBlocksFromPeers(
 	blockSize time.Duration,
 ) {
 	it := ranges.Iter()
-	tagsIter := ident.NewTagsIterator(ident.Tags{})
-	unfulfill := func(r xtime.Range) {
-		lock.Lock()
-		unfulfilled := bootstrapResult.Unfulfilled()
-		unfulfilled.AddRanges(result.NewShardTimeRanges().Set(shard, xtime.NewRanges(r)))
-		lock.Unlock()
-	}
 	for it.Next() {
 		currRange := it.Value()
+		unfulfill := func() {
+			s.unfulfill(shard, currRange, bootstrapResult, lock)
+		}
 
 		for blockStart := currRange.Start; blockStart.Before(currRange.End); blockStart = blockStart.Add(blockSize) {
 			blockEnd := blockStart.Add(blockSize)
@@ -379,52 +402,157 @@ func (s *peersSource) fetchBootstrapBlocksFromPeers(
 
 			if err != nil {
 				// No result to add for this bootstrap.
-				unfulfill(currRange)
+				unfulfill()
 				continue
 			}
 
-			if shouldPersist {
-				persistenceQueue <- persistenceFlush{
-					nsMetadata:        nsMetadata,
-					shard:             shard,
-					shardRetrieverMgr: shardRetrieverMgr,
-					shardResult:       shardResult,
-					timeRange:         xtime.Range{Start: blockStart, End: blockEnd},
+			s.loadBootstrapBlocks(shard, shardResult,
+				xtime.Range{Start: blockStart, End: blockEnd}, unfulfill, nsMetadata,
+				accumulator, shouldPersist, persistenceQueue, shardRetrieverMgr)
+		}
+	}
+}
+
+// fetchSplitBootstrapBlocksFromPeers fetches the bootstrap blocks of the shards
+// split from the same source shard. The data of the shards lives in the source
+// shard on the peers, whose blocks are fetched once and split across the shards
+// by hashing the series IDs rather than fetching the whole source shard for
+// every shard split from it.
+func (s *peersSource) fetchSplitBootstrapBlocksFromPeers(
+	sourceShard uint32,
+	shardsTimeRanges map[uint32]xtime.Ranges,
+	hashFn sharding.HashFn,
+	nsMetadata namespace.Metadata,
+	session client.AdminSession,
+	accumulator bootstrap.NamespaceDataAccumulator,
+	bopts result.Options,
+	bootstrapResult result.DataBootstrapResult,
+	lock *sync.Mutex,
+	shouldPersist bool,
+	persistenceQueue chan persistenceFlush,
+	shardRetrieverMgr block.DatabaseShardBlockRetrieverManager,
+	blockSize time.Duration,
+) {
+	allRanges := xtime.NewRanges()
+	for _, ranges := range shardsTimeRanges {
+		allRanges.AddRanges(ranges)
+	}
+
+	it := allRanges.Iter()
+	for it.Next() {
+		currRange := it.Value()
+
+		for blockStart := currRange.Start; blockStart.Before(currRange.End); blockStart = blockStart.Add(blockSize) {
+			var (
+				blockRange   = xtime.Range{Start: blockStart, End: blockStart.Add(blockSize)}
+				shardResults = make(map[uint32]result.ShardResult)
+			)
+			for shard, ranges := range shardsTimeRanges {
+				if ranges.Overlaps(blockRange) {
+					shardResults[shard] = result.NewShardResult(0, bopts)
+				}
+			}
+
+			sourceResult, err := session.FetchBootstrapBlocksFromPeers(
+				nsMetadata, sourceShard, blockRange.Start, blockRange.End, bopts)
+			s.logFetchBootstrapBlocksFromPeersOutcome(sourceShard, sourceResult, err)
+
+			if err != nil {
+				// No result to add for this bootstrap.
+				for shard := range shardResults {
+					s.unfulfill(shard, blockRange, bootstrapResult, lock)
 				}
 				continue
 			}
 
-			// If not waiting to flush, add straight away to bootstrap result.
-			for _, elem := range shardResult.AllSeries().Iter() {
+			// The series hashed to the source shard or to shards split to
+			// other hosts are not bootstrapped by this fetch.
+			for _, elem := range sourceResult.AllSeries().Iter() {
 				entry := elem.Value()
-				tagsIter.Reset(entry.Tags)
-				ref, owned, err := accumulator.CheckoutSeriesWithLock(shard, entry.ID, tagsIter)
-				if err != nil {
-					if !owned {
-						// Only if we own this shard do we care consider this an
-						// error in bootstrapping.
-						continue
-					}
-					unfulfill(currRange)
-					s.log.Error("could not checkout series", zap.Error(err))
-					continue
+				if shardResult, ok := shardResults[hashFn(entry.ID)]; ok {
+					shardResult.AddSeries(entry.ID, entry.Tags, entry.Blocks)
 				}
+			}
 
-				for _, block := range entry.Blocks.AllBlocks() {
-					if err := ref.Series.LoadBlock(block, series.WarmWrite); err != nil {
-						unfulfill(currRange)
-						s.log.Error("could not load series block", zap.Error(err))
-					}
+			for shard, shardResult := range shardResults {
+				shard := shard
+				unfulfill := func() {
+					s.unfulfill(shard, blockRange, bootstrapResult, lock)
 				}
+				s.loadBootstrapBlocks(shard, shardResult, blockRange, unfulfill,
+					nsMetadata, accumulator, shouldPersist, persistenceQueue,
+					shardRetrieverMgr)
+			}
+		}
+	}
+}
 
-				// Safe to finalize these IDs and Tags, shard result no longer used.
-				entry.ID.Finalize()
-				entry.Tags.Finalize()
+// loadBootstrapBlocks adds the blocks fetched for a shard to the bootstrap
+// result, or pushes a flush of them into the persistenceQueue if persisting.
+func (s *peersSource) loadBootstrapBlocks(
+	shard uint32,
+	shardResult result.ShardResult,
+	blockRange xtime.Range,
+	unfulfill func(),
+	nsMetadata namespace.Metadata,
+	accumulator bootstrap.NamespaceDataAccumulator,
+	shouldPersist bool,
+	persistenceQueue chan persistenceFlush,
+	shardRetrieverMgr block.DatabaseShardBlockRetrieverManager,
+) {
+	if shouldPersist {
+		persistenceQueue <- persistenceFlush{
+			nsMetadata:        nsMetadata,
+			shard:             shard,
+			shardRetrieverMgr: shardRetrieverMgr,
+			shardResult:       shardResult,
+			timeRange:         blockRange,
+		}
+		return
+	}
+
+	// If not waiting to flush, add straight away to bootstrap result.
+	tagsIter := ident.NewTagsIterator(ident.Tags{})
+	for _, elem := range shardResult.AllSeries().Iter() {
+		entry := elem.Value()
+		tagsIter.Reset(entry.Tags)
+		ref, owned, err := accumulator.CheckoutSeriesWithLock(shard, entry.ID, tagsIter)
+		if err != nil {
+			if !owned {
+				// Only if we own this shard do we care consider this an
+				// error in bootstrapping.
+				continue
 			}
+			unfulfill()
+			s.log.Error("could not checkout series", zap.Error(err))
+			continue
 		}
+
+		for _, block := range entry.Blocks.AllBlocks() {
+			if err := ref.Series.LoadBlock(block, series.WarmWrite); err != nil {
+				unfulfill()
+				s.log.Error("could not load series block", zap.Error(err))
+			}
+		}
+
+		// Safe to finalize these IDs and Tags, shard result no longer used.
+		entry.ID.Finalize()
+		entry.Tags.Finalize()
 	}
 }
 
+func (s *peersSource) unfulfill(
+	shard uint32,
+	r xtime.Range,
+	bootstrapResult result.DataBootstrapResult,
+	lock *sync.Mutex,
+) {
+	lock.Lock()
+	unfulfilled := bootstrapResult.Unfulfilled()
+	unfulfilled.AddRanges(result.NewShardTimeRanges().Set(shard, xtime.NewRanges(r)))
+	lock.Unlock()
+}
+
 func (s *peersSource) logFetchBootstrapBlocksFromPeersOutcome(
 	shard uint32,
 	shardResult result.ShardResult,
@@ -683,6 +811,7 @@ func (s *peersSource) readIndex(
 		remainingRanges, timesWithErrors := s.processReaders(
 			ns,
 			r,
+			opts.InitialTopologyState(),
 			timeWindowReaders,
 			readerPool,
 			idxOpts,
@@ -696,6 +825,7 @@ func (s *peersSource) readIndex(
 
 func (s *peersSource) readNextEntryAndMaybeIndex(
 	r fs.DataFileSetReader,
+	filterFn func(id ident.ID) bool,
 	batch []doc.Document,
 ) ([]doc.Document, error) {
 	// If performing index run, then simply read the metadata and add to segment.
@@ -704,6 +834,13 @@ func (s *peersSource) readNextEntryAndMaybeIndex(
 		return batch, err
 	}
 
+	if filterFn != nil && !filterFn(id) {
+		// Ignore the series of the other shards split from the same source.
+		id.Finalize()
+		tagsIter.Close()
+		return batch, nil
+	}
+
 	d, err := convert.FromMetricIter(id, tagsIter)
 	// Finalize the ID and tags.
 	id.Finalize()
@@ -724,6 +861,7 @@ func (s *peersSource) readNextEntryAndMaybeIndex(
 func (s *peersSource) processReaders(
 	ns namespace.Metadata,
 	r result.IndexBootstrapResult,
+	topoState *topology.StateSnapshot,
 	timeWindowReaders bootstrapper.TimeWindowReaders,
 	readerPool *bootstrapper.ReaderPool,
 	idxOpts namespace.IndexOptions,
@@ -746,13 +884,14 @@ func (s *peersSource) processReaders(
 			var (
 				timeRange = reader.Range()
 				start     = timeRange.Start
+				filterFn  = bootstrapper.ReaderFilter(topoState, shard, reader)
 				err       error
 			)
 
 			r.IndexResults().AddBlockIfNotExists(start, idxOpts)
 			numEntries := reader.Entries()
 			for i := 0; err == nil && i < numEntries; i++ {
-				batch, err = s.readNextEntryAndMaybeIndex(reader, batch)
+				batch, err = s.readNextEntryAndMaybeIndex(reader, filterFn, batch)
 				totalEntries++
 			}
 
@@ -962,6 +1101,10 @@ func (s *peersSource) peerAvailability(
 			shardPeers = &shardPeerAvailability{}
 			peerAvailabilityByShard[shardID] = shardPeers
 		}
+		if source, ok := bootstrapper.SplitSource(initialTopologyState, shardIDUint); ok {
+			// A split shard is bootstrapped from the peers of its split source.
+			shardID = topology.ShardID(source)
+		}
 		hostShardStates, ok := initialTopologyState.ShardStates[shardID]
 		if !ok {
 			// This shard was not part of the topology when the bootstrapping
@@ -26,11 +26,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/m3db/m3/src/cluster/shard"
 	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	m3dbruntime "github.com/m3db/m3/src/dbnode/runtime"
+	"github.com/m3db/m3/src/dbnode/sharding"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
@@ -38,6 +40,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/index/compaction"
 	"github.com/m3db/m3/src/dbnode/storage/series"
 	"github.com/m3db/m3/src/dbnode/topology"
+	tu "github.com/m3db/m3/src/dbnode/topology/testutil"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/dbnode/x/xio"
 	"github.com/m3db/m3/src/m3ninx/index/segment/fst"
@@ -252,6 +255,84 @@ func TestPeersSourceReturnsUnfulfilled(t *testing.T) {
 	tester.EnsureNoWrites()
 }
 
+func TestPeersSourceSplitShardFetchesFromSplitSource(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newTestDefaultOpts(t, ctrl)
+	nsMetadata := testNamespaceMetadata(t)
+	ropts := nsMetadata.Options().RetentionOptions()
+
+	start := time.Now().Add(-ropts.RetentionPeriod()).Truncate(ropts.BlockSize())
+	end := start.Add(ropts.BlockSize())
+
+	// Shards 4 and 8 were split from shard 0 when growing from 4 to 12 shards.
+	hashFn := sharding.DefaultHashFn(12)
+	idsByShard := make(map[uint32]ident.ID)
+	for i := 0; len(idsByShard) < 3; i++ {
+		id := ident.StringID(fmt.Sprintf("foo.%d", i))
+		if shard := hashFn(id); shard%4 == 0 {
+			idsByShard[shard] = id
+		}
+	}
+
+	sourceResult := result.NewShardResult(0, opts.ResultOptions())
+	for _, id := range idsByShard {
+		fooBlock := block.NewDatabaseBlock(start, ropts.BlockSize(), ts.Segment{}, testBlockOpts, namespace.Context{})
+		sourceResult.AddBlock(id, ident.NewTags(ident.StringTag("foo", "oof")), fooBlock)
+	}
+
+	// The source shard is fetched once for both of the shards split from it.
+	mockAdminSession := client.NewMockAdminSession(ctrl)
+	mockAdminSession.EXPECT().
+		FetchBootstrapBlocksFromPeers(namespace.NewMetadataMatcher(nsMetadata),
+			uint32(0), start, end, gomock.Any()).
+		Return(sourceResult, nil)
+
+	peerMetaIter := client.NewMockPeerBlockMetadataIter(ctrl)
+	peerMetaIter.EXPECT().Next().Return(false).AnyTimes()
+	peerMetaIter.EXPECT().Err().Return(nil).AnyTimes()
+	mockAdminSession.EXPECT().
+		FetchBootstrapBlocksMetadataFromPeers(gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(peerMetaIter, nil).AnyTimes()
+
+	mockAdminClient := client.NewMockAdminClient(ctrl)
+	mockAdminClient.EXPECT().DefaultAdminSession().Return(mockAdminSession, nil).AnyTimes()
+
+	opts = opts.SetAdminClient(mockAdminClient)
+
+	src, err := newPeersSource(opts)
+	require.NoError(t, err)
+
+	ranges := xtime.NewRanges(xtime.Range{Start: start, End: end})
+	target := result.NewShardTimeRanges().Set(4, ranges).Set(8, ranges)
+
+	topoState := tu.NewStateSnapshot(1, tu.HostShardStates{
+		tu.SelfID: []shard.Shard{
+			shard.NewShard(0).SetState(shard.Available),
+			shard.NewShard(4).SetState(shard.Initializing).SetSplitSource(0),
+			shard.NewShard(8).SetState(shard.Initializing).SetSplitSource(0),
+		},
+	})
+	topoState.HashFn = hashFn
+	runOpts := testDefaultRunOpts.SetInitialTopologyState(topoState)
+	tester := bootstrap.BuildNamespacesTester(t, runOpts, target, nsMetadata)
+	defer tester.Finish()
+	tester.TestReadWith(src)
+	tester.TestUnfulfilledForNamespaceIsEmpty(nsMetadata)
+	vals := tester.DumpLoadedBlocks()
+	series, found := vals[nsMetadata.ID().String()]
+	require.True(t, found)
+
+	assert.Equal(t, 2, len(series))
+	for _, shard := range []uint32{4, 8} {
+		_, found = series[idsByShard[shard].String()]
+		require.True(t, found)
+	}
+	tester.EnsureNoWrites()
+}
+
 func TestPeersSourceRunWithPersist(t *testing.T) {
 	for _, cachePolicy := range []series.CachePolicy{
 		series.CacheRecentlyRead,
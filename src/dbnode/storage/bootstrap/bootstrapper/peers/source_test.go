@@ -66,6 +66,14 @@ func TestPeersSourceAvailableDataAndIndex(t *testing.T) {
 	shardTimeRangesToBootstrapOneExtra := shardTimeRangesToBootstrap.Copy()
 	shardTimeRangesToBootstrapOneExtra.Set(100, bootstrapRanges)
 
+	splitShardTimeRangesToBootstrap := result.NewShardTimeRanges()
+	splitShards := tu.ShardsRange(0, numShards-1, shard.Available)
+	for i := numShards; i < 2*numShards; i++ {
+		splitShardTimeRangesToBootstrap.Set(i, bootstrapRanges)
+		splitShards = append(splitShards,
+			shard.NewShard(i).SetState(shard.Initializing).SetSplitSource(i-numShards))
+	}
+
 	testCases := []struct {
 		title                             string
 		topoState                         *topology.StateSnapshot
@@ -117,6 +125,17 @@ func TestPeersSourceAvailableDataAndIndex(t *testing.T) {
 			shardsTimeRangesToBootstrap:       shardTimeRangesToBootstrapOneExtra,
 			expectedAvailableShardsTimeRanges: shardTimeRangesToBootstrap,
 		},
+		{
+			title: "Returns success for split shards if consistency can be met on the split source",
+			topoState: tu.NewStateSnapshot(2, tu.HostShardStates{
+				tu.SelfID:  splitShards,
+				notSelfID1: splitShards,
+				notSelfID2: splitShards,
+			}),
+			bootstrapReadConsistency:          topology.ReadConsistencyLevelMajority,
+			shardsTimeRangesToBootstrap:       splitShardTimeRangesToBootstrap,
+			expectedAvailableShardsTimeRanges: splitShardTimeRangesToBootstrap,
+		},
 		{
 			title: "Returns empty if consistency can not be met",
 			topoState: tu.NewStateSnapshot(2, tu.HostShardStates{
@@ -43,7 +43,9 @@ type TimeWindowReaders struct {
 // ShardID is the shard #.
 type ShardID uint32
 
-// ShardReaders are the fileset readers for a shard.
+// ShardReaders are the fileset readers for a shard. The readers of a shard
+// split from another shard may read the filesets of its split source, which
+// hold the series of other shards too and must be filtered by the caller.
 type ShardReaders struct {
 	Readers []fs.DataFileSetReader
 }
@@ -97,6 +99,19 @@ func enqueueReadersGroupedByBlockSize(
 		readers := make(map[ShardID]ShardReaders, group.Ranges.Len())
 		for shard, tr := range group.Ranges.Iter() {
 			shardReaders := newShardReaders(ns, fsOpts, readerPool, shard, tr, logger)
+			if source, ok := SplitSource(runOpts.InitialTopologyState(), shard); ok {
+				// A shard split from another shard has no filesets of its own for
+				// the blocks flushed before the split, those are read from the
+				// filesets of its split source which hold the series of the shard.
+				remaining := tr.Clone()
+				for _, r := range shardReaders.Readers {
+					remaining.RemoveRange(r.Range())
+				}
+				if !remaining.IsEmpty() {
+					sourceReaders := newShardReaders(ns, fsOpts, readerPool, source, remaining, logger)
+					shardReaders.Readers = append(shardReaders.Readers, sourceReaders.Readers...)
+				}
+			}
 			readers[ShardID(shard)] = shardReaders
 		}
 		readersCh <- newTimeWindowReaders(group.Ranges, readers)
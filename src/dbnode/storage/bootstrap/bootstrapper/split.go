@@ -0,0 +1,60 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package bootstrapper
+
+import (
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/sharding"
+	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3/src/x/ident"
+)
+
+// SplitSource returns the shard the given shard was split from if the origin
+// is initializing the shard from a shard split.
+func SplitSource(state *topology.StateSnapshot, shard uint32) (uint32, bool) {
+	if state == nil {
+		return 0, false
+	}
+	hostShardStates, ok := state.ShardStates[topology.ShardID(shard)]
+	if !ok {
+		return 0, false
+	}
+	hostShardState, ok := hostShardStates[topology.HostID(state.Origin.ID())]
+	if !ok || !hostShardState.IsSplit {
+		return 0, false
+	}
+	return uint32(hostShardState.SplitSource), true
+}
+
+// ReaderFilter returns the filter for the series read by a fileset reader of
+// the given shard, or nil if all of the series read belong to the shard. The
+// reader of a shard split from another shard may read the fileset of its
+// split source, which holds the series of all the shards split from it.
+func ReaderFilter(
+	state *topology.StateSnapshot,
+	shard uint32,
+	r fs.DataFileSetReader,
+) func(id ident.ID) bool {
+	if _, ok := SplitSource(state, shard); !ok || r.Status().Shard == shard {
+		return nil
+	}
+	return sharding.NewSplitShardFilter(state.HashFn, shard)
+}
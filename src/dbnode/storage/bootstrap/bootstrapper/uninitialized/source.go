@@ -97,12 +97,19 @@ func (s *uninitializedTopologySource) availability(
 		// by the corresponding node that is leaving. I.E if numInitializing > 0
 		// BUT numLeaving >= numInitializing then it is still not a new namespace.
 		// See the TestUnitializedSourceAvailableDataAndAvailableIndex test for more details.
+		// A shard split from another shard is initializing on every host without
+		// a leaving counterpart, but its data lives in its split source so it is
+		// never new either.
 		var (
 			numAvailable    = 0
 			numInitializing = 0
 			numLeaving      = 0
+			isSplit         = false
 		)
 		for _, hostState := range hostShardStates {
+			if hostState.IsSplit {
+				isSplit = true
+			}
 			shardState := hostState.ShardState
 			switch shardState {
 			case shard.Initializing:
@@ -124,7 +131,7 @@ func (s *uninitializedTopologySource) availability(
 		// a bootstrapper if users want to change the replication factor dynamically, which is fine
 		// because otherwise you'd have to wait for one entire retention period for the replicaiton
 		// factor to actually increase correctly.
-		shardHasNeverBeenCompletelyInitialized := numInitializing-numLeaving > 0
+		shardHasNeverBeenCompletelyInitialized := !isSplit && numInitializing-numLeaving > 0
 		if shardHasNeverBeenCompletelyInitialized {
 			if tr, ok := shardsTimeRanges.Get(shardIDUint); ok {
 				availableShardTimeRanges.Set(shardIDUint, tr)
@@ -163,6 +163,29 @@ func TestUnitializedTopologySourceAvailableDataAndAvailableIndex(t *testing.T) {
 			shardsTimeRangesToBootstrap:       shardTimeRangesToBootstrap,
 			expectedAvailableShardsTimeRanges: result.NewShardTimeRanges(),
 		},
+		// Snould return that it can't bootstrap anything because the shards
+		// were split from shards that hold their data.
+		{
+			title: "Single node - Split shard initializing",
+			topoState: tu.NewStateSnapshot(1, tu.HostShardStates{
+				tu.SelfID: splitShards(tu.ShardsRange(0, numShards, shard.Initializing), 2),
+			}),
+			shardsTimeRangesToBootstrap:       shardTimeRangesToBootstrap,
+			expectedAvailableShardsTimeRanges: result.NewShardTimeRanges(),
+		},
+		// Snould return that it can't bootstrap anything because the shards
+		// were split from shards that hold their data, even though the split
+		// shards are initializing on every node.
+		{
+			title: "Multi node - Split shard initializing",
+			topoState: tu.NewStateSnapshot(2, tu.HostShardStates{
+				tu.SelfID:  splitShards(tu.ShardsRange(0, numShards, shard.Initializing), 2),
+				notSelfID1: splitShards(tu.ShardsRange(0, numShards, shard.Initializing), 2),
+				notSelfID2: splitShards(tu.ShardsRange(0, numShards, shard.Initializing), 2),
+			}),
+			shardsTimeRangesToBootstrap:       shardTimeRangesToBootstrap,
+			expectedAvailableShardsTimeRanges: result.NewShardTimeRanges(),
+		},
 		// Snould return that it can't bootstrap anything because we don't
 		// know how to interpret the unknown host.
 		{
@@ -217,3 +240,11 @@ func TestUnitializedTopologySourceAvailableDataAndAvailableIndex(t *testing.T) {
 		})
 	}
 }
+
+// splitShards marks the shards as split from the first numSourceShards shards.
+func splitShards(shards []shard.Shard, numSourceShards uint32) []shard.Shard {
+	for _, s := range shards {
+		s.SetSplitSource(s.ID() % numSourceShards)
+	}
+	return shards
+}
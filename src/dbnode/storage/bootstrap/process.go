@@ -118,6 +118,7 @@ func (b *bootstrapProcessProvider) newInitialTopologyState() (*topology.StateSna
 			Origin:           b.processOpts.Origin(),
 			MajorityReplicas: topoMap.MajorityReplicas(),
 			ShardStates:      topology.ShardStates{},
+			HashFn:           topoMap.ShardSet().HashFn(),
 		}
 	)
 
@@ -131,9 +132,12 @@ func (b *bootstrapProcessProvider) newInitialTopologyState() (*topology.StateSna
 			}
 
 			hostID := topology.HostID(hostShardSet.Host().ID())
+			splitSource, isSplit := currShard.SplitSource()
 			existing[hostID] = topology.HostShardState{
-				Host:       hostShardSet.Host(),
-				ShardState: currShard.State(),
+				Host:        hostShardSet.Host(),
+				ShardState:  currShard.State(),
+				SplitSource: topology.ShardID(splitSource),
+				IsSplit:     isSplit,
 			}
 		}
 	}
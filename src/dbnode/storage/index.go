@@ -374,6 +374,7 @@ func newNamespaceIndexWithOptions(
 func (i *nsIndex) SetRuntimeOptions(value runtime.Options) {
 	i.state.Lock()
 	i.state.runtimeOpts.defaultQueryTimeout = value.IndexDefaultQueryTimeout()
+	i.state.runtimeOpts.maxQueryLimit = value.IndexMaxQueryLimit()
 	i.state.Unlock()
 }
 
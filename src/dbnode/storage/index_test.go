@@ -32,6 +32,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/retention"
+	"github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/m3ninx/idx"
@@ -71,6 +72,30 @@ func TestNamespaceIndexCleanupExpiredFilesets(t *testing.T) {
 	require.NoError(t, idx.CleanupExpiredFileSets(now))
 }
 
+func TestNamespaceIndexRuntimeOptionsMaxQueryLimit(t *testing.T) {
+	md := testNamespaceMetadata(time.Hour, time.Hour*8)
+	nsIdx, err := newNamespaceIndex(md, testShardSet, DefaultTestOptions())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, nsIdx.Close())
+	}()
+
+	idx := nsIdx.(*nsIndex)
+	idx.SetRuntimeOptions(runtime.NewOptions().SetIndexMaxQueryLimit(10))
+
+	idx.state.RLock()
+	defer idx.state.RUnlock()
+
+	opts := idx.overriddenOptsForQueryWithRLock(index.QueryOptions{})
+	require.Equal(t, 10, opts.Limit)
+
+	opts = idx.overriddenOptsForQueryWithRLock(index.QueryOptions{Limit: 100})
+	require.Equal(t, 10, opts.Limit)
+
+	opts = idx.overriddenOptsForQueryWithRLock(index.QueryOptions{Limit: 5})
+	require.Equal(t, 5, opts.Limit)
+}
+
 func TestNamespaceIndexCleanupDuplicateFilesets(t *testing.T) {
 	md := testNamespaceMetadata(time.Hour, time.Hour*8)
 	nsIdx, err := newNamespaceIndex(md, testShardSet, DefaultTestOptions())
@@ -794,16 +794,59 @@ func (n *dbNamespace) ReadEncoded(
 	start, end time.Time,
 ) ([][]xio.BlockReader, error) {
 	callStart := n.nowFn()
-	shard, nsCtx, err := n.readableShardFor(id)
+	shard, splitSource, nsCtx, err := n.readableShardOrSplitSourceFor(id)
 	if err != nil {
 		n.metrics.read.ReportError(n.nowFn().Sub(callStart))
 		return nil, err
 	}
 	res, err := shard.ReadEncoded(ctx, id, start, end, nsCtx)
+	if err == nil && splitSource != nil {
+		var sourceRes [][]xio.BlockReader
+		sourceRes, err = splitSource.ReadEncoded(ctx, id, start, end, nsCtx)
+		res = mergeBlockReadersByStart(sourceRes, res)
+	}
 	n.metrics.read.ReportSuccessOrError(err, n.nowFn().Sub(callStart))
 	return res, err
 }
 
+// mergeBlockReadersByStart merges two sets of block readers for a series that
+// are each ordered by block start, block readers for the same block start are
+// placed in the same slice so that they are merged when read.
+func mergeBlockReadersByStart(a, b [][]xio.BlockReader) [][]xio.BlockReader {
+	if len(a) == 0 {
+		return b
+	}
+	if len(b) == 0 {
+		return a
+	}
+	start := func(readers []xio.BlockReader) time.Time {
+		if len(readers) == 0 {
+			return time.Time{}
+		}
+		return readers[0].Start
+	}
+	merged := make([][]xio.BlockReader, 0, len(a)+len(b))
+	for len(a) > 0 && len(b) > 0 {
+		aStart, bStart := start(a[0]), start(b[0])
+		switch {
+		case aStart.Before(bStart):
+			merged = append(merged, a[0])
+			a = a[1:]
+		case bStart.Before(aStart):
+			merged = append(merged, b[0])
+			b = b[1:]
+		default:
+			readers := make([]xio.BlockReader, 0, len(a[0])+len(b[0]))
+			readers = append(readers, a[0]...)
+			readers = append(readers, b[0]...)
+			merged = append(merged, readers)
+			a, b = a[1:], b[1:]
+		}
+	}
+	merged = append(merged, a...)
+	return append(merged, b...)
+}
+
 func (n *dbNamespace) FetchBlocks(
 	ctx context.Context,
 	shardID uint32,
@@ -1398,13 +1441,46 @@ func (n *dbNamespace) shardFor(id ident.ID) (databaseShard, namespace.Context, e
 	return shard, nsCtx, err
 }
 
-func (n *dbNamespace) readableShardFor(id ident.ID) (databaseShard, namespace.Context, error) {
+// readableShardOrSplitSourceFor returns the readable shard for an ID. If the
+// shard is not bootstrapped yet but was split from a shard that is, the shard
+// is returned alongside its split source. The split source holds the data
+// of the shard until it has been bootstrapped, while the shard holds the
+// writes it received since the split, so reads are served from both.
+func (n *dbNamespace) readableShardOrSplitSourceFor(
+	id ident.ID,
+) (databaseShard, databaseShard, namespace.Context, error) {
 	n.RLock()
+	defer n.RUnlock()
+
 	nsCtx := n.nsContextWithRLock()
 	shardID := n.shardSet.Lookup(id)
-	shard, err := n.readableShardAtWithRLock(shardID)
-	n.RUnlock()
-	return shard, nsCtx, err
+	shard, _, err := n.shardAtWithRLock(shardID)
+	if err != nil {
+		return nil, nil, nsCtx, err
+	}
+	if shard.IsBootstrapped() {
+		return shard, nil, nsCtx, nil
+	}
+	if splitSource, ok := n.bootstrappedSplitSourceAtWithRLock(shardID); ok {
+		return shard, splitSource, nsCtx, nil
+	}
+	return nil, nil, nsCtx, xerrors.NewRetryableError(errShardNotBootstrappedToRead)
+}
+
+func (n *dbNamespace) bootstrappedSplitSourceAtWithRLock(shardID uint32) (databaseShard, bool) {
+	s, err := n.shardSet.LookupShard(shardID)
+	if err != nil {
+		return nil, false
+	}
+	splitSourceID, isSplit := s.SplitSource()
+	if !isSplit {
+		return nil, false
+	}
+	splitSource, _, err := n.shardAtWithRLock(splitSourceID)
+	if err != nil || !splitSource.IsBootstrapped() {
+		return nil, false
+	}
+	return splitSource, true
 }
 
 func (n *dbNamespace) readableShardAt(shardID uint32) (databaseShard, namespace.Context, error) {
@@ -41,6 +41,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/tracepoint"
 	"github.com/m3db/m3/src/dbnode/ts"
 	xmetrics "github.com/m3db/m3/src/dbnode/x/metrics"
+	"github.com/m3db/m3/src/dbnode/x/xio"
 	xidx "github.com/m3db/m3/src/m3ninx/idx"
 	"github.com/m3db/m3/src/x/context"
 	xerrors "github.com/m3db/m3/src/x/errors"
@@ -280,6 +281,76 @@ func TestNamespaceReadEncodedShardOwned(t *testing.T) {
 	require.Equal(t, errShardNotBootstrappedToRead, xerrors.GetInnerRetryableError(err))
 }
 
+func TestNamespaceReadEncodedSplitShard(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+
+	id := ident.StringID("foo")
+	start := time.Now().Truncate(time.Hour)
+	end := start.Add(3 * time.Hour)
+
+	ns, closer := newTestNamespace(t)
+	defer closer()
+
+	// Shard 1 was split from shard 0 and is initializing from it.
+	shardSet, err := sharding.NewShardSet([]shard.Shard{
+		shard.NewShard(0).SetState(shard.Available),
+		shard.NewShard(1).SetState(shard.Initializing).SetSplitSource(0),
+	}, func(ident.ID) uint32 { return 1 })
+	require.NoError(t, err)
+	ns.shardSet = shardSet
+
+	source := NewMockdatabaseShard(ctrl)
+	child := NewMockdatabaseShard(ctrl)
+	ns.shards[0] = source
+	ns.shards[1] = child
+
+	blockReader := func(start time.Time) xio.BlockReader {
+		return xio.BlockReader{Start: start, BlockSize: time.Hour}
+	}
+	var (
+		sourceReaders = [][]xio.BlockReader{
+			{blockReader(start)},
+			{blockReader(start.Add(time.Hour))},
+		}
+		childReaders = [][]xio.BlockReader{
+			{blockReader(start.Add(time.Hour))},
+			{blockReader(start.Add(2 * time.Hour))},
+		}
+	)
+
+	// Reads are served from the split source while the shard bootstraps.
+	child.EXPECT().IsBootstrapped().Return(false)
+	source.EXPECT().IsBootstrapped().Return(true)
+	child.EXPECT().ReadEncoded(ctx, id, start, end, gomock.Any()).Return(childReaders, nil)
+	source.EXPECT().ReadEncoded(ctx, id, start, end, gomock.Any()).Return(sourceReaders, nil)
+	res, err := ns.ReadEncoded(ctx, id, start, end)
+	require.NoError(t, err)
+	require.Equal(t, [][]xio.BlockReader{
+		{blockReader(start)},
+		{blockReader(start.Add(time.Hour)), blockReader(start.Add(time.Hour))},
+		{blockReader(start.Add(2 * time.Hour))},
+	}, res)
+
+	// The shard is not readable if the split source is not bootstrapped either.
+	child.EXPECT().IsBootstrapped().Return(false)
+	source.EXPECT().IsBootstrapped().Return(false)
+	_, err = ns.ReadEncoded(ctx, id, start, end)
+	require.Error(t, err)
+	require.True(t, xerrors.IsRetryableError(err))
+	require.Equal(t, errShardNotBootstrappedToRead, xerrors.GetInnerRetryableError(err))
+
+	// Only the shard is read from once it has been bootstrapped.
+	child.EXPECT().IsBootstrapped().Return(true)
+	child.EXPECT().ReadEncoded(ctx, id, start, end, gomock.Any()).Return(childReaders, nil)
+	res, err = ns.ReadEncoded(ctx, id, start, end)
+	require.NoError(t, err)
+	require.Equal(t, childReaders, res)
+}
+
 func TestNamespaceFetchBlocksShardNotOwned(t *testing.T) {
 	ctx := context.NewContext()
 	defer ctx.Close()
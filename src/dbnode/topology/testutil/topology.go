@@ -127,9 +127,12 @@ func NewStateSnapshot(numMajorityReplicas int, hostShardStates HostShardStates)
 				hostShardStates = make(map[topology.HostID]topology.HostShardState)
 			}
 
+			splitSource, isSplit := shard.SplitSource()
 			hostShardStates[topology.HostID(host)] = topology.HostShardState{
-				Host:       topology.NewHost(host, host+"address"),
-				ShardState: shard.State(),
+				Host:        topology.NewHost(host, host+"address"),
+				ShardState:  shard.State(),
+				SplitSource: topology.ShardID(splitSource),
+				IsSplit:     isSplit,
 			}
 			topoState.ShardStates[topology.ShardID(shard.ID())] = hostShardStates
 		}
@@ -228,6 +228,9 @@ type StateSnapshot struct {
 	Origin           Host
 	MajorityReplicas int
 	ShardStates      ShardStates
+	// HashFn is the hash function of the shard set, used to find the series
+	// of a shard split from another shard.
+	HashFn sharding.HashFn
 }
 
 // ShardStates maps shard IDs to the state of each of the hosts that own
@@ -238,6 +241,10 @@ type ShardStates map[ShardID]map[HostID]HostShardState
 type HostShardState struct {
 	Host       Host
 	ShardState shard.State
+	// SplitSource is the shard this shard was split from, only valid
+	// when IsSplit is set.
+	SplitSource ShardID
+	IsSplit     bool
 }
 
 // HostID is the string representation of a host ID.
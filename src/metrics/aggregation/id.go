@@ -28,13 +28,25 @@ import (
 )
 
 const (
-	// IDLen is the length of the ID.
-	// The IDLen will be 1 when maxTypeID <= 63.
-	IDLen = (maxTypeID)/64 + 1
+	// IDLen is the length of the ID, the enumerated aggregation types are
+	// stored as a bitset in the leading words and the last word holds the
+	// parameterized quantile types.
+	// The IDLen will be 2 when maxTypeID <= 63.
+	IDLen = typesIDLen + 1
+
+	// typesIDLen is the number of words used by the enumerated types bitset.
+	typesIDLen = (maxTypeID)/64 + 1
 
 	// ID uses an array of int64 to represent aggregation types.
 	idBitShift = 6
 	idBitMask  = 63
+
+	// The quantiles word packs up to maxQuantilesPerID parameterized
+	// quantiles as 16 bit basis points in ascending order, unused
+	// slots are zero.
+	maxQuantilesPerID = 4
+	quantileBits      = 16
+	quantileMask      = 1<<quantileBits - 1
 )
 
 var (
@@ -76,6 +88,15 @@ func (id ID) Contains(aggType Type) bool {
 	if !aggType.IsValid() {
 		return false
 	}
+	if bp, ok := aggType.parameterizedQuantile(); ok {
+		quantiles := id[typesIDLen]
+		for i := 0; i < maxQuantilesPerID; i++ {
+			if int((quantiles>>(uint(i)*quantileBits))&quantileMask) == bp {
+				return true
+			}
+		}
+		return false
+	}
 	idx := int(aggType) >> idBitShift   // aggType / 64
 	offset := uint(aggType) & idBitMask // aggType % 64
 	return (id[idx] & (1 << offset)) > 0
@@ -142,19 +163,21 @@ func (id *ID) UnmarshalYAML(unmarshal func(interface{}) error) error {
 
 // ToProto converts the aggregation id to a protobuf message in place.
 func (id ID) ToProto(pb *aggregationpb.AggregationID) error {
-	if IDLen != 1 {
-		return fmt.Errorf("id length %d cannot be represented by a single integer", IDLen)
+	if typesIDLen != 1 {
+		return fmt.Errorf("id length %d cannot be represented by a single integer", typesIDLen)
 	}
 	pb.Id = id[0]
+	pb.Quantiles = id[typesIDLen]
 	return nil
 }
 
 // FromProto converts the protobuf message to an aggregation id in place.
 func (id *ID) FromProto(pb aggregationpb.AggregationID) error {
-	if IDLen != 1 {
-		return fmt.Errorf("id length %d cannot be represented by a single integer", IDLen)
+	if typesIDLen != 1 {
+		return fmt.Errorf("id length %d cannot be represented by a single integer", typesIDLen)
 	}
 	(*id)[0] = pb.Id
+	(*id)[typesIDLen] = pb.Quantiles
 	return nil
 }
 
@@ -22,6 +22,7 @@ package aggregation
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/willf/bitset"
 )
@@ -47,7 +48,8 @@ type IDDecompressor interface {
 }
 
 type idCompressor struct {
-	bs *bitset.BitSet
+	bs        *bitset.BitSet
+	quantiles []int
 }
 
 // NewIDCompressor returns a new IDCompressor.
@@ -55,29 +57,52 @@ func NewIDCompressor() IDCompressor {
 	// NB(cw): If we start to support more than 64 types, the library will
 	// expand the underlying word list itself.
 	return &idCompressor{
-		bs: bitset.New(maxTypeID),
+		bs:        bitset.New(maxTypeID),
+		quantiles: make([]int, 0, maxQuantilesPerID),
 	}
 }
 
 func (c *idCompressor) Compress(aggTypes Types) (ID, error) {
 	c.bs.ClearAll()
+	c.quantiles = c.quantiles[:0]
 	for _, aggType := range aggTypes {
 		if !aggType.IsValid() {
 			return DefaultID, fmt.Errorf("could not compress invalid Type %v", aggType)
 		}
+		if bp, ok := aggType.parameterizedQuantile(); ok {
+			c.addQuantile(bp)
+			continue
+		}
 		c.bs.Set(uint(aggType.ID()))
 	}
+	if len(c.quantiles) > maxQuantilesPerID {
+		return DefaultID, fmt.Errorf("could not compress %d parameterized quantiles, at most %d are supported",
+			len(c.quantiles), maxQuantilesPerID)
+	}
 
 	codes := c.bs.Bytes()
 	var id ID
-	// NB(cw) it's guaranteed that len(id) == len(codes) == IDLen, we need to copy
+	// NB(cw) it's guaranteed that len(codes) == typesIDLen, we need to copy
 	// the words in bitset out because the bitset contains a slice internally.
-	for i := 0; i < IDLen; i++ {
+	for i := 0; i < typesIDLen; i++ {
 		id[i] = codes[i]
 	}
+	sort.Ints(c.quantiles)
+	for i, bp := range c.quantiles {
+		id[typesIDLen] |= uint64(bp) << (uint(i) * quantileBits)
+	}
 	return id, nil
 }
 
+func (c *idCompressor) addQuantile(bp int) {
+	for _, existing := range c.quantiles {
+		if existing == bp {
+			return
+		}
+	}
+	c.quantiles = append(c.quantiles, bp)
+}
+
 func (c *idCompressor) MustCompress(aggTypes Types) ID {
 	id, err := c.Compress(aggTypes)
 	if err != nil {
@@ -111,9 +136,9 @@ func (d *idDecompressor) Decompress(id ID) (Types, error) {
 	if id.IsDefault() {
 		return DefaultTypes, nil
 	}
-	// NB(cw) it's guaranteed that len(c.buf) == len(id) == IDLen, we need to copy
+	// NB(cw) it's guaranteed that len(c.buf) == typesIDLen, we need to copy
 	// the words from id into a slice to be used in bitset.
-	for i := range id {
+	for i := 0; i < typesIDLen; i++ {
 		d.buf[i] = id[i]
 	}
 
@@ -133,6 +158,19 @@ func (d *idDecompressor) Decompress(id ID) (Types, error) {
 		res = append(res, aggType)
 	}
 
+	quantiles := id[typesIDLen]
+	for i := 0; i < maxQuantilesPerID; i++ {
+		bp := int((quantiles >> (uint(i) * quantileBits)) & quantileMask)
+		if bp == 0 {
+			continue
+		}
+		aggType := Type(quantileTypeOffset + bp)
+		if !aggType.IsParameterizedQuantile() {
+			return DefaultTypes, fmt.Errorf("invalid parameterized quantile: %d basis points", bp)
+		}
+		res = append(res, aggType)
+	}
+
 	return res, nil
 }
 
@@ -42,6 +42,17 @@ func TestIDCompressRoundTrip(t *testing.T) {
 		{[]Type{1, 5, 9, 3, 2}, []Type{1, 2, 3, 5, 9}, false},
 		// 50 is an unknown aggregation type.
 		{[]Type{10, 50}, DefaultTypes, true},
+		{
+			[]Type{quantileTypeOffset + 9950, Max, quantileTypeOffset + 7500, quantileTypeOffset + 9950},
+			[]Type{Max, quantileTypeOffset + 7500, quantileTypeOffset + 9950},
+			false,
+		},
+		{
+			[]Type{quantileTypeOffset + 1, quantileTypeOffset + 2, quantileTypeOffset + 3,
+				quantileTypeOffset + 4, quantileTypeOffset + 5},
+			DefaultTypes,
+			true,
+		},
 	}
 
 	p := NewTypesPool(pool.NewObjectPoolOptions().SetSize(1))
@@ -66,12 +77,18 @@ func TestIDDecompressError(t *testing.T) {
 	_, err := decompressor.Decompress([IDLen]uint64{1})
 	require.Error(t, err)
 
-	max, err := compressor.Compress([]Type{Last, Min, Max, Mean, Median, Count, Sum, SumSq, Stdev, P95, P99, P999, P9999})
+	max, err := compressor.Compress([]Type{Last, Min, Max, Mean, Median, Count, Sum, SumSq, Stdev, P95, P99, P999, P9999, CountDistinct})
 	require.NoError(t, err)
 
 	max[0] = max[0] << 1
 	_, err = decompressor.Decompress(max)
 	require.Error(t, err)
+
+	// Quantiles with an enumerated type are not valid parameterized quantiles.
+	var id ID
+	id[typesIDLen] = 9900
+	_, err = decompressor.Decompress(id)
+	require.Error(t, err)
 }
 
 func TestIDMustDecompress(t *testing.T) {
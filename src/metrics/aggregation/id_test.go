@@ -35,6 +35,10 @@ import (
 var (
 	testID      = ID{6}
 	testIDProto = aggregationpb.AggregationID{Id: 6}
+
+	// testQuantilesID contains Last, Min, P75 and P99.5.
+	testQuantilesID      = ID{6, 9950<<16 | 7500}
+	testQuantilesIDProto = aggregationpb.AggregationID{Id: 6, Quantiles: 9950<<16 | 7500}
 )
 
 func TestIDToProto(t *testing.T) {
@@ -59,6 +63,25 @@ func TestIDRoundTrip(t *testing.T) {
 	require.Equal(t, testID, res)
 }
 
+func TestIDQuantilesRoundTrip(t *testing.T) {
+	var (
+		pb  aggregationpb.AggregationID
+		res ID
+	)
+	require.NoError(t, testQuantilesID.ToProto(&pb))
+	require.Equal(t, testQuantilesIDProto, pb)
+	require.NoError(t, res.FromProto(pb))
+	require.Equal(t, testQuantilesID, res)
+
+	b, err := json.Marshal(testQuantilesID)
+	require.NoError(t, err)
+	require.Equal(t, `["Last","Min","P75","P99.5"]`, string(b))
+
+	var fromJSON ID
+	require.NoError(t, json.Unmarshal(b, &fromJSON))
+	require.Equal(t, testQuantilesID, fromJSON)
+}
+
 func TestIDMarshalJSON(t *testing.T) {
 	inputs := []struct {
 		id       ID
@@ -22,6 +22,8 @@ package aggregation
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 
 	"github.com/m3db/m3/src/metrics/generated/proto/aggregationpb"
@@ -53,6 +55,7 @@ const (
 	P99
 	P999
 	P9999
+	CountDistinct
 
 	nextTypeID = iota
 )
@@ -65,6 +68,15 @@ const (
 	// maxTypeID == len(ValidTypes).
 	maxTypeID = nextTypeID - 1
 
+	// quantileTypeOffset is the offset of parameterized quantile types, a
+	// parameterized quantile type is the offset plus its quantile expressed
+	// in basis points, e.g. P99.5 is quantileTypeOffset + 9950. The offset
+	// leaves the enumerated types and their proto values untouched.
+	quantileTypeOffset = 1 << 16
+
+	// quantileBasisPoints is the number of basis points in a quantile of 1.
+	quantileBasisPoints = 10000
+
 	typesSeparator = ","
 )
 
@@ -98,9 +110,30 @@ var (
 		P99:    emptyStruct,
 		P999:   emptyStruct,
 		P9999:  emptyStruct,
+
+		CountDistinct: emptyStruct,
 	}
 
 	typeStringMap map[string]Type
+
+	// quantileTypes maps the quantiles of the enumerated quantile types in
+	// basis points to their types, so parameterized quantiles that have an
+	// enumerated equivalent resolve to the enumerated type.
+	quantileTypes = map[int]Type{
+		1000: P10,
+		2000: P20,
+		3000: P30,
+		4000: P40,
+		5000: P50,
+		6000: P60,
+		7000: P70,
+		8000: P80,
+		9000: P90,
+		9500: P95,
+		9900: P99,
+		9990: P999,
+		9999: P9999,
+	}
 )
 
 // Type defines an aggregation function.
@@ -115,6 +148,22 @@ func NewTypeFromProto(input aggregationpb.AggregationType) (Type, error) {
 	return aggType, nil
 }
 
+// NewQuantileType returns the aggregation type for a quantile, the quantile
+// must be in (0, 1) with a precision of at most four decimal places. Quantiles
+// with an enumerated type such as P99 return the enumerated type, otherwise a
+// parameterized quantile type is returned which is only valid for timers.
+func NewQuantileType(q float64) (Type, error) {
+	bp := math.Round(q * quantileBasisPoints)
+	if bp <= 0 || bp >= quantileBasisPoints ||
+		math.Abs(q*quantileBasisPoints-bp) > 1e-6 {
+		return UnknownType, fmt.Errorf("invalid quantile %v: must be in (0, 1) with at most four decimal places", q)
+	}
+	if aggType, ok := quantileTypes[int(bp)]; ok {
+		return aggType, nil
+	}
+	return Type(quantileTypeOffset + int(bp)), nil
+}
+
 // ID returns the id of the Type.
 func (a Type) ID() int {
 	return int(a)
@@ -122,14 +171,47 @@ func (a Type) ID() int {
 
 // IsValid checks if an Type is valid.
 func (a Type) IsValid() bool {
-	_, ok := ValidTypes[a]
+	if _, ok := ValidTypes[a]; ok {
+		return true
+	}
+	_, ok := a.parameterizedQuantile()
+	return ok
+}
+
+// IsParameterizedQuantile returns true if the Type is a parameterized
+// quantile type rather than one of the enumerated types.
+func (a Type) IsParameterizedQuantile() bool {
+	_, ok := a.parameterizedQuantile()
 	return ok
 }
 
+// parameterizedQuantile returns the quantile in basis points of a
+// parameterized quantile type.
+func (a Type) parameterizedQuantile() (int, bool) {
+	bp := int(a) - quantileTypeOffset
+	if bp <= 0 || bp >= quantileBasisPoints {
+		return 0, false
+	}
+	if _, ok := quantileTypes[bp]; ok {
+		// Quantiles with an enumerated type must use the enumerated type.
+		return 0, false
+	}
+	return bp, true
+}
+
+// String returns the string representation of the Type, parameterized
+// quantile types are formatted as a percentile, e.g. P99.5.
+func (a Type) String() string {
+	if bp, ok := a.parameterizedQuantile(); ok {
+		return "P" + strconv.FormatFloat(float64(bp)/(quantileBasisPoints/100), 'f', -1, 64)
+	}
+	return a.enumString()
+}
+
 // IsValidForGauge if an Type is valid for Gauge.
 func (a Type) IsValidForGauge() bool {
 	switch a {
-	case Last, Min, Max, Mean, Count, Sum, SumSq, Stdev:
+	case Last, Min, Max, Mean, Count, Sum, SumSq, Stdev, CountDistinct:
 		return true
 	default:
 		return false
@@ -139,7 +221,7 @@ func (a Type) IsValidForGauge() bool {
 // IsValidForCounter if an Type is valid for Counter.
 func (a Type) IsValidForCounter() bool {
 	switch a {
-	case Min, Max, Mean, Count, Sum, SumSq, Stdev:
+	case Min, Max, Mean, Count, Sum, SumSq, Stdev, CountDistinct:
 		return true
 	default:
 		return false
@@ -186,6 +268,9 @@ func (a Type) Quantile() (float64, bool) {
 	case P9999:
 		return 0.9999, true
 	default:
+		if bp, ok := a.parameterizedQuantile(); ok {
+			return float64(bp) / quantileBasisPoints, true
+		}
 		return 0, false
 	}
 }
@@ -193,6 +278,11 @@ func (a Type) Quantile() (float64, bool) {
 // Proto returns the proto of the aggregation type.
 func (a Type) Proto() (aggregationpb.AggregationType, error) {
 	s := aggregationpb.AggregationType(a)
+	if a.IsParameterizedQuantile() {
+		// Parameterized quantile types are carried as values outside of
+		// the enumerated range of the proto enum.
+		return s, nil
+	}
 	if err := validateProtoType(s); err != nil {
 		return aggregationpb.AggregationType_UNKNOWN, err
 	}
@@ -260,8 +350,24 @@ func ParseType(str string) (Type, error) {
 	}
 
 	if !exactMatch && !looseMatch {
+		return parseQuantileType(str)
+	}
+	return aggType, nil
+}
+
+// parseQuantileType parses a percentile such as P99.5 into a quantile type.
+func parseQuantileType(str string) (Type, error) {
+	if len(str) < 2 || (str[0] != 'P' && str[0] != 'p') {
 		return UnknownType, fmt.Errorf("invalid aggregation type: %s", str)
 	}
+	percentile, err := strconv.ParseFloat(str[1:], 64)
+	if err != nil || math.IsNaN(percentile) {
+		return UnknownType, fmt.Errorf("invalid aggregation type: %s", str)
+	}
+	aggType, err := NewQuantileType(percentile / 100)
+	if err != nil {
+		return UnknownType, fmt.Errorf("invalid aggregation type %s: %v", str, err)
+	}
 	return aggType, nil
 }
 
@@ -18,17 +18,18 @@
 // OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
 // THE SOFTWARE.
 
-// generated by stringer -type=Type; DO NOT EDIT
+// generated by stringer -type=Type, with String renamed to enumString so that
+// parameterized quantile types can be formatted in type.go; DO NOT EDIT
 
 package aggregation
 
 import "fmt"
 
-const _Type_name = "UnknownTypeLastMinMaxMeanMedianCountSumSumSqStdevP10P20P30P40P50P60P70P80P90P95P99P999P9999"
+const _Type_name = "UnknownTypeLastMinMaxMeanMedianCountSumSumSqStdevP10P20P30P40P50P60P70P80P90P95P99P999P9999CountDistinct"
 
-var _Type_index = [...]uint8{0, 11, 15, 18, 21, 25, 31, 36, 39, 44, 49, 52, 55, 58, 61, 64, 67, 70, 73, 76, 79, 82, 86, 91}
+var _Type_index = [...]uint8{0, 11, 15, 18, 21, 25, 31, 36, 39, 44, 49, 52, 55, 58, 61, 64, 67, 70, 73, 76, 79, 82, 86, 91, 104}
 
-func (i Type) String() string {
+func (i Type) enumString() string {
 	if i < 0 || i >= Type(len(_Type_index)-1) {
 		return fmt.Sprintf("Type(%d)", i)
 	}
@@ -34,12 +34,13 @@ import (
 
 func TestTypeIsValid(t *testing.T) {
 	require.True(t, P9999.IsValid())
-	require.False(t, Type(int(P9999)+1).IsValid())
+	require.True(t, CountDistinct.IsValid())
+	require.False(t, Type(int(CountDistinct)+1).IsValid())
 }
 
 func TestTypeMaxID(t *testing.T) {
-	require.Equal(t, maxTypeID, P9999.ID())
-	require.Equal(t, P9999, Type(maxTypeID))
+	require.Equal(t, maxTypeID, CountDistinct.ID())
+	require.Equal(t, CountDistinct, Type(maxTypeID))
 	require.Equal(t, maxTypeID, len(ValidTypes))
 }
 
@@ -308,6 +309,64 @@ func TestQuantiles(t *testing.T) {
 	require.True(t, ok)
 }
 
+func TestNewQuantileType(t *testing.T) {
+	inputs := []struct {
+		quantile  float64
+		expected  string
+		expectErr bool
+	}{
+		{quantile: 0.99, expected: "P99"},
+		{quantile: 0.9999, expected: "P9999"},
+		{quantile: 0.75, expected: "P75"},
+		{quantile: 0.995, expected: "P99.5"},
+		{quantile: 0.0001, expected: "P0.01"},
+		{quantile: 0, expectErr: true},
+		{quantile: 1, expectErr: true},
+		{quantile: -0.5, expectErr: true},
+		{quantile: 0.99999, expectErr: true},
+	}
+	for _, input := range inputs {
+		aggType, err := NewQuantileType(input.quantile)
+		if input.expectErr {
+			require.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		require.True(t, aggType.IsValid())
+		require.Equal(t, input.expected, aggType.String())
+
+		q, ok := aggType.Quantile()
+		require.True(t, ok)
+		require.Equal(t, input.quantile, q)
+	}
+}
+
+func TestParameterizedQuantileType(t *testing.T) {
+	aggType, err := ParseType("P99.5")
+	require.NoError(t, err)
+	require.True(t, aggType.IsParameterizedQuantile())
+	require.True(t, aggType.IsValidForTimer())
+	require.False(t, aggType.IsValidForCounter())
+	require.False(t, aggType.IsValidForGauge())
+
+	pb, err := aggType.Proto()
+	require.NoError(t, err)
+	fromProto, err := NewTypeFromProto(pb)
+	require.NoError(t, err)
+	require.Equal(t, aggType, fromProto)
+
+	// Quantiles with an enumerated type resolve to the enumerated type.
+	aggType, err = ParseType("p99.90")
+	require.NoError(t, err)
+	require.Equal(t, P999, aggType)
+	require.False(t, Type(quantileTypeOffset+9990).IsValid())
+
+	for _, str := range []string{"P0", "P100", "P99.999", "Pfoo", "P"} {
+		_, err := ParseType(str)
+		require.Error(t, err, str)
+	}
+}
+
 func TestIDContains(t *testing.T) {
 	require.True(t, MustCompressTypes(P99).Contains(P99))
 	require.True(t, MustCompressTypes(P99, P95).Contains(P99))
@@ -318,6 +377,12 @@ func TestIDContains(t *testing.T) {
 	require.False(t, MustCompressTypes(Sum, Last, P999).Contains(P9999))
 	require.False(t, MustCompressTypes().Contains(P99))
 	require.False(t, MustCompressTypes(P99, P95).Contains(P9999))
+
+	p75, p995 := Type(quantileTypeOffset+7500), Type(quantileTypeOffset+9950)
+	require.True(t, MustCompressTypes(Sum, p995).Contains(p995))
+	require.True(t, MustCompressTypes(p75, p995).Contains(p75))
+	require.False(t, MustCompressTypes(Sum, p995).Contains(p75))
+	require.False(t, MustCompressTypes(Sum, p995).Contains(P99))
 }
 
 func TestCompressedTypesIsDefault(t *testing.T) {
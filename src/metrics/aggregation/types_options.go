@@ -22,8 +22,10 @@ package aggregation
 
 import (
 	"bytes"
+	"math"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/m3db/m3/src/metrics/metric"
 	"github.com/m3db/m3/src/x/pool"
@@ -151,6 +153,8 @@ var (
 		Count:  []byte("count"),
 		Stdev:  []byte("stdev"),
 		Median: []byte("median"),
+
+		CountDistinct: []byte("count_distinct"),
 	}
 )
 
@@ -165,10 +169,11 @@ type options struct {
 	aggTypesPool                   TypesPool
 	quantilesPool                  pool.FloatsPool
 
-	counterTypeStrings [][]byte
-	timerTypeStrings   [][]byte
-	gaugeTypeStrings   [][]byte
-	quantiles          []float64
+	counterTypeStrings       [][]byte
+	timerTypeStrings         [][]byte
+	gaugeTypeStrings         [][]byte
+	timerQuantileTypeStrings *quantileTypeStrings
+	quantiles                []float64
 }
 
 // NewTypesOptions returns a default TypesOptions.
@@ -295,14 +300,25 @@ func (o *options) QuantilesPool() pool.FloatsPool {
 }
 
 func (o *options) TypeStringForCounter(aggType Type) []byte {
+	if aggType.IsParameterizedQuantile() {
+		// Parameterized quantile types are only valid for timers.
+		return nil
+	}
 	return o.counterTypeStrings[aggType.ID()]
 }
 
 func (o *options) TypeStringForTimer(aggType Type) []byte {
+	if aggType.IsParameterizedQuantile() {
+		return o.timerQuantileTypeStrings.typeString(aggType)
+	}
 	return o.timerTypeStrings[aggType.ID()]
 }
 
 func (o *options) TypeStringForGauge(aggType Type) []byte {
+	if aggType.IsParameterizedQuantile() {
+		// Parameterized quantile types are only valid for timers.
+		return nil
+	}
 	return o.gaugeTypeStrings[aggType.ID()]
 }
 
@@ -352,6 +368,7 @@ func (o *options) computeCounterTypeStrings() {
 
 func (o *options) computeTimerTypeStrings() {
 	o.timerTypeStrings = o.computeTypeStrings(o.timerTypeStringTransformFn)
+	o.timerQuantileTypeStrings = newQuantileTypeStrings(o.quantileTypeStringFn, o.timerTypeStringTransformFn)
 }
 
 func (o *options) computeGaugeTypeStrings() {
@@ -388,12 +405,55 @@ func typeFor(value []byte, typeStrings [][]byte) Type {
 	return UnknownType
 }
 
+// quantileTypeStrings lazily computes and caches the type strings of
+// parameterized quantile types, which are too many to compute upfront.
+type quantileTypeStrings struct {
+	sync.RWMutex
+
+	quantileTypeStringFn QuantileTypeStringFn
+	transformFn          TypeStringTransformFn
+	typeStrings          map[Type][]byte
+}
+
+func newQuantileTypeStrings(
+	quantileTypeStringFn QuantileTypeStringFn,
+	transformFn TypeStringTransformFn,
+) *quantileTypeStrings {
+	return &quantileTypeStrings{
+		quantileTypeStringFn: quantileTypeStringFn,
+		transformFn:          transformFn,
+		typeStrings:          make(map[Type][]byte),
+	}
+}
+
+func (s *quantileTypeStrings) typeString(aggType Type) []byte {
+	s.RLock()
+	typeString, exists := s.typeStrings[aggType]
+	s.RUnlock()
+	if exists {
+		return typeString
+	}
+
+	q, _ := aggType.Quantile()
+	typeString = s.transformFn(s.quantileTypeStringFn(q))
+	s.Lock()
+	s.typeStrings[aggType] = typeString
+	s.Unlock()
+	return typeString
+}
+
 // By default we use e.g. "p50", "p95", "p99" for the 50th/95th/99th percentile.
+// Fractional percentiles below 10 are zero padded so that every type string is
+// unique, e.g. "p099" for the 9.9th percentile rather than "p99".
 func defaultQuantileTypeStringFn(quantile float64) []byte {
-	str := strconv.FormatFloat(quantile*100, 'f', -1, 64)
+	// Round to drop floating point noise, e.g. 0.995 * 100 is not 99.5.
+	str := strconv.FormatFloat(math.Round(quantile*1e6)/1e4, 'f', -1, 64)
 	idx := strings.Index(str, ".")
 	if idx != -1 {
 		str = str[:idx] + str[idx+1:]
+		if idx == 1 {
+			str = "0" + str
+		}
 	}
 	return []byte("p" + str)
 }
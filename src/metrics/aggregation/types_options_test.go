@@ -421,6 +421,18 @@ func TestOptionQuantileTypeString(t *testing.T) {
 			quantile: 0.123,
 			b:        []byte("p123"),
 		},
+		{
+			quantile: 0.995,
+			b:        []byte("p995"),
+		},
+		{
+			quantile: 0.099,
+			b:        []byte("p099"),
+		},
+		{
+			quantile: 0.0005,
+			b:        []byte("p0005"),
+		},
 	}
 
 	for _, c := range cases {
@@ -428,6 +440,23 @@ func TestOptionQuantileTypeString(t *testing.T) {
 	}
 }
 
+func TestOptionsTypeStringForParameterizedQuantile(t *testing.T) {
+	p995, err := NewQuantileType(0.995)
+	require.NoError(t, err)
+	p75, err := NewQuantileType(0.75)
+	require.NoError(t, err)
+
+	o := NewTypesOptions()
+	require.Equal(t, []byte("p995"), o.TypeStringForTimer(p995))
+	require.Equal(t, []byte("p75"), o.TypeStringForTimer(p75))
+	require.Nil(t, o.TypeStringForCounter(p995))
+	require.Nil(t, o.TypeStringForGauge(p995))
+
+	o = o.SetTimerTypeStringTransformFn(SuffixTransform)
+	require.Equal(t, []byte(".p995"), o.TypeStringForTimer(p995))
+	require.Equal(t, []byte(".p995"), o.TypeStringForTimer(p995))
+}
+
 func TestSetQuantilesPool(t *testing.T) {
 	p := pool.NewFloatsPool(nil, nil)
 	o := NewTypesOptions().SetQuantilesPool(p)
@@ -472,6 +501,8 @@ func typeStrings(overrides map[Type][]byte) [][]byte {
 		P99:    []byte("p99"),
 		P999:   []byte("p999"),
 		P9999:  []byte("p9999"),
+
+		CountDistinct: []byte("count_distinct"),
 	}
 	res := make([][]byte, maxTypeID+1)
 	for t, bstr := range defaultTypeStrings {
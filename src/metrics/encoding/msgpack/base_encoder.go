@@ -105,7 +105,7 @@ func (enc *baseEncoder) encodeCompressedAggregationTypes(aggTypes aggregation.ID
 		return
 	}
 
-	if aggregation.IDLen == 1 {
+	if isShortAggregationID(aggTypes) {
 		enc.encodeNumObjectFields(numFieldsForType(shortAggregationID))
 		enc.encodeObjectType(shortAggregationID)
 		enc.encodeVarintFn(int64(aggTypes[0]))
@@ -113,6 +113,7 @@ func (enc *baseEncoder) encodeCompressedAggregationTypes(aggTypes aggregation.ID
 	}
 
 	// NB(cw): Only reachable after we start to support more than 63 aggregation types
+	// or the id contains parameterized quantile types.
 	enc.encodeNumObjectFields(numFieldsForType(longAggregationID))
 	enc.encodeObjectType(longAggregationID)
 	enc.encodeArrayLen(aggregation.IDLen)
@@ -121,6 +122,18 @@ func (enc *baseEncoder) encodeCompressedAggregationTypes(aggTypes aggregation.ID
 	}
 }
 
+// isShortAggregationID returns true if the aggregation id can be represented
+// by its first word, which keeps the encoding of ids without parameterized
+// quantile types unchanged.
+func isShortAggregationID(aggTypes aggregation.ID) bool {
+	for i := 1; i < len(aggTypes); i++ {
+		if aggTypes[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
 func (enc *baseEncoder) encodeStoragePolicyInternal(p policy.StoragePolicy) {
 	enc.encodeNumObjectFields(numFieldsForType(storagePolicyType))
 	enc.encodeResolution(p.Resolution())
@@ -37,6 +37,7 @@ func TestAggregationTypesRoundTrip(t *testing.T) {
 		aggregation.ID{5},
 		aggregation.ID{100},
 		aggregation.ID{12345},
+		aggregation.ID{8, 9950},
 	}
 
 	for _, input := range inputs {
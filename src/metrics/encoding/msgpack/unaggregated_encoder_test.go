@@ -244,14 +244,14 @@ func expectedResultsForCompressedAggregationTypes(compressed aggregation.ID) []i
 		return append(results, numFieldsForType(defaultAggregationID), int64(defaultAggregationID))
 	}
 
-	if len(compressed) == 1 {
+	if isShortAggregationID(compressed) {
 		return append(results, numFieldsForType(shortAggregationID), int64(shortAggregationID), int64(compressed[0]))
 	}
 
 	results = append(results, numFieldsForType(longAggregationID), int64(longAggregationID), int64(len(compressed)))
 
 	for _, code := range compressed {
-		results = append(results, code)
+		results = append(results, int64(code))
 	}
 
 	return results
@@ -176,6 +176,8 @@ func resetForwardMetadata(pb *metricpb.ForwardMetadata) {
 	pb.Pipeline.Ops = pb.Pipeline.Ops[:0]
 	pb.SourceId = 0
 	pb.NumForwardedTimes = 0
+	pb.Digest = false
+	pb.DistinctSketch = false
 }
 
 func resetTimedMetadata(pb *metricpb.TimedMetadata) {
@@ -24,6 +24,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"regexp"
 )
 
 var (
@@ -61,8 +62,14 @@ const (
 	invalidNestedChars   = "?[{"
 )
 
+// RegexpPrefix is the prefix of filter patterns that are regular expressions
+// rather than glob patterns, e.g. "re:api-[0-9a-f]{8}-.*".
+// NB: a prefix is required so that existing glob patterns are unaffected.
+const RegexpPrefix = "re:"
+
 var (
 	multiRangeSplit = []byte(",")
+	regexpPrefix    = []byte(RegexpPrefix)
 )
 
 // FilterValue contains the filter pattern and a boolean flag indicating
@@ -99,9 +106,12 @@ func NewFilterFromFilterValue(fv FilterValue) (Filter, error) {
 }
 
 // NewFilter supports startsWith, endsWith, contains and a single wildcard
-// along with negation and glob matching support.
-// NOTE: Currently only supports ASCII matching and has zero compatibility
-// with UTF8 so you should make sure all matches are done against ASCII only.
+// along with negation and glob matching support. Patterns prefixed with
+// RegexpPrefix are instead fully anchored regular expressions, in the same
+// way as Prometheus label matchers.
+// NOTE: Currently glob matching only supports ASCII matching and has zero
+// compatibility with UTF8 so you should make sure all matches are done
+// against ASCII only.
 func NewFilter(pattern []byte) (Filter, error) {
 	// TODO(martinm): Provide more detailed error messages.
 	if len(pattern) == 0 {
@@ -109,7 +119,7 @@ func NewFilter(pattern []byte) (Filter, error) {
 	}
 
 	if pattern[0] != negationChar {
-		return newWildcardFilter(pattern)
+		return newPatternFilter(pattern)
 	}
 
 	if len(pattern) == 1 {
@@ -117,7 +127,7 @@ func NewFilter(pattern []byte) (Filter, error) {
 		return nil, errInvalidFilterPattern
 	}
 
-	filter, err := newWildcardFilter(pattern[1:])
+	filter, err := newPatternFilter(pattern[1:])
 	if err != nil {
 		return nil, err
 	}
@@ -125,6 +135,15 @@ func NewFilter(pattern []byte) (Filter, error) {
 	return newNegationFilter(filter), nil
 }
 
+// newPatternFilter creates a regular expression filter if the pattern has
+// the regular expression prefix and a wildcard filter otherwise.
+func newPatternFilter(pattern []byte) (Filter, error) {
+	if bytes.HasPrefix(pattern, regexpPrefix) {
+		return newRegexpFilter(pattern[len(regexpPrefix):])
+	}
+	return newWildcardFilter(pattern)
+}
+
 // newWildcardFilter creates a filter that segments the pattern based
 // on wildcards, creating a rangeFilter for each segment.
 func newWildcardFilter(pattern []byte) (Filter, error) {
@@ -287,6 +306,30 @@ func (f *containsFilter) Matches(val []byte) bool {
 	return bytes.Contains(val, f.pattern)
 }
 
+// regexpFilter is a filter that matches a fully anchored regular expression.
+type regexpFilter struct {
+	pattern []byte
+	re      *regexp.Regexp
+}
+
+func newRegexpFilter(pattern []byte) (Filter, error) {
+	re, err := regexp.Compile("^(?:" + string(pattern) + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression %s: %v",
+			string(pattern), err)
+	}
+
+	return newImmutableFilter(&regexpFilter{pattern: pattern, re: re}), nil
+}
+
+func (f *regexpFilter) String() string {
+	return "Regexp(\"" + string(f.pattern) + "\")"
+}
+
+func (f *regexpFilter) Matches(val []byte) bool {
+	return f.re.Match(val)
+}
+
 // negationFilter is a filter that matches the opposite of the provided filter.
 type negationFilter struct {
 	filter Filter
@@ -290,6 +290,46 @@ func TestNegationFilter(t *testing.T) {
 	}
 }
 
+func TestRegexpFilter(t *testing.T) {
+	filters := genAndValidateFilters(t, []testPattern{
+		testPattern{pattern: "re:api-[0-9a-f]{4}-.*", expectedStr: "Regexp(\"api-[0-9a-f]{4}-.*\")"},
+		testPattern{pattern: "!re:foo|bar", expectedStr: "Not(Regexp(\"foo|bar\"))"},
+		testPattern{pattern: "re:a:b", expectedStr: "Regexp(\"a:b\")"},
+		testPattern{pattern: "re*", expectedStr: "StartsWith(Equals(\"re\"))"},
+	})
+
+	inputs := []testInput{
+		newTestInput("api-12ab-xyz", true, true, false, false),
+		newTestInput("api-12ab-", true, true, false, false),
+		newTestInput("xapi-12ab-xyz", false, true, false, false),
+		newTestInput("api-12zz-xyz", false, true, false, false),
+		newTestInput("foo", false, false, false, false),
+		newTestInput("foobar", false, true, false, false),
+		newTestInput("a:b", false, true, true, false),
+		newTestInput("re:foo", false, true, false, true),
+	}
+
+	for _, input := range inputs {
+		for i, expectedMatch := range input.matches {
+			require.Equal(t, expectedMatch, filters[i].Matches(input.val),
+				fmt.Sprintf("input: %s, pattern: %s", input.val, filters[i].String()))
+		}
+	}
+}
+
+func TestBadRegexpPatterns(t *testing.T) {
+	patterns := []string{
+		"re:abc[sdf",
+		"re:(foo",
+		"!re:*foo",
+	}
+
+	for _, pattern := range patterns {
+		_, err := NewFilter([]byte(pattern))
+		require.Error(t, err, fmt.Sprintf("pattern: %s", pattern))
+	}
+}
+
 func TestBadPatterns(t *testing.T) {
 	patterns := []string{
 		"!", // negation of nothing is everything, so user should use *.
@@ -57,6 +57,8 @@ type tagFilterSeparator struct {
 type TagFilterValueMap map[string]FilterValue
 
 // ParseTagFilterValueMap parses the input string and creates a tag filter value map.
+// NB: as tag filters are space separated, regular expression patterns
+// cannot contain spaces, use \s or [ ] instead.
 func ParseTagFilterValueMap(str string) (TagFilterValueMap, error) {
 	trimmed := strings.TrimSpace(str)
 	tagPairs := strings.Split(trimmed, tagFilterListSeparator)
@@ -83,7 +85,12 @@ func ParseTagFilterValueMap(str string) (TagFilterValueMap, error) {
 func parseTagFilter(str string) ([]string, tagFilterSeparator, error) {
 	// TODO(xichen): support negation of glob patterns.
 	for _, separator := range validFilterSeparators {
-		items := strings.Split(str, separator.str)
+		items := strings.SplitN(str, separator.str, 2)
+		if len(items) == 2 && strings.Contains(items[1], separator.str) &&
+			!isRegexpPattern(items[1]) {
+			// NB: only regular expression patterns may contain the separator.
+			continue
+		}
 		if len(items) == 2 {
 			if items[0] == "" {
 				return nil, unknownFilterSeparator, fmt.Errorf("invalid filter %s: empty tag name", str)
@@ -97,6 +104,13 @@ func parseTagFilter(str string) ([]string, tagFilterSeparator, error) {
 	return nil, unknownFilterSeparator, fmt.Errorf("invalid filter %s: expecting tag pattern pairs", str)
 }
 
+func isRegexpPattern(pattern string) bool {
+	if len(pattern) > 0 && pattern[0] == negationChar {
+		pattern = pattern[1:]
+	}
+	return strings.HasPrefix(pattern, RegexpPrefix)
+}
+
 // tagFilter is a filter associated with a given tag.
 type tagFilter struct {
 	name        []byte
@@ -56,6 +56,14 @@ func TestParseTagFilterValueMap(t *testing.T) {
 				"tagName4": FilterValue{Pattern: "tagValue4", Negate: false},
 			},
 		},
+		{
+			str: "tagName1:re:api-[0-9a-f]{8}-.* tagName2:!re:a:b|c tagName3:tagValue3",
+			expected: TagFilterValueMap{
+				"tagName1": FilterValue{Pattern: "re:api-[0-9a-f]{8}-.*", Negate: false},
+				"tagName2": FilterValue{Pattern: "!re:a:b|c", Negate: false},
+				"tagName3": FilterValue{Pattern: "tagValue3", Negate: false},
+			},
+		},
 	}
 
 	for _, input := range inputs {
@@ -72,6 +80,7 @@ func TestParseTagFilterValueMapErrors(t *testing.T) {
 		"tagName1:tagValue1  tagName2:tagValue2 tagName1:tagValue3",
 		"tagName:",
 		":tagValue",
+		"tagName:tagValue:tagValue",
 	}
 
 	for _, input := range inputs {
@@ -249,6 +258,10 @@ func TestValidateTagsFilterError(t *testing.T) {
 			str: "tagName1:abcsdf tagName2:*con[tT]ains*",
 			err: "tags filter tagName1:abcsdf tagName2:*con[tT]ains* contains invalid filter pattern *con[tT]ains* for tag tagName2",
 		},
+		{
+			str: "tagName1:re:(abc",
+			err: "tags filter tagName1:re:(abc contains invalid filter pattern re:(abc for tag tagName1",
+		},
 	}
 
 	for _, input := range inputs {
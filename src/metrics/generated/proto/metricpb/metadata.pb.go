@@ -148,6 +148,12 @@ type ForwardMetadata struct {
 	Pipeline          pipelinepb.AppliedPipeline  `protobuf:"bytes,3,opt,name=pipeline" json:"pipeline"`
 	SourceId          uint32                      `protobuf:"varint,4,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
 	NumForwardedTimes int32                       `protobuf:"varint,5,opt,name=num_forwarded_times,json=numForwardedTimes,proto3" json:"num_forwarded_times,omitempty"`
+	// digest is true if the forwarded values are t-digest centroids encoded
+	// as consecutive mean and weight pairs rather than individual values.
+	Digest bool `protobuf:"varint,6,opt,name=digest,proto3" json:"digest,omitempty"`
+	// distinct_sketch is true if the forwarded values are a HyperLogLog sketch
+	// encoded as its precision followed by its packed non-zero registers.
+	DistinctSketch bool `protobuf:"varint,7,opt,name=distinct_sketch,json=distinctSketch,proto3" json:"distinct_sketch,omitempty"`
 }
 
 func (m *ForwardMetadata) Reset()                    { *m = ForwardMetadata{} }
@@ -190,6 +196,20 @@ func (m *ForwardMetadata) GetNumForwardedTimes() int32 {
 	return 0
 }
 
+func (m *ForwardMetadata) GetDigest() bool {
+	if m != nil {
+		return m.Digest
+	}
+	return false
+}
+
+func (m *ForwardMetadata) GetDistinctSketch() bool {
+	if m != nil {
+		return m.DistinctSketch
+	}
+	return false
+}
+
 type TimedMetadata struct {
 	AggregationId aggregationpb.AggregationID `protobuf:"bytes,1,opt,name=aggregation_id,json=aggregationId" json:"aggregation_id"`
 	StoragePolicy policypb.StoragePolicy      `protobuf:"bytes,2,opt,name=storage_policy,json=storagePolicy" json:"storage_policy"`
@@ -423,6 +443,26 @@ func (m *ForwardMetadata) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintMetadata(dAtA, i, uint64(m.NumForwardedTimes))
 	}
+	if m.Digest {
+		dAtA[i] = 0x30
+		i++
+		if m.Digest {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.DistinctSketch {
+		dAtA[i] = 0x38
+		i++
+		if m.DistinctSketch {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -541,6 +581,12 @@ func (m *ForwardMetadata) Size() (n int) {
 	if m.NumForwardedTimes != 0 {
 		n += 1 + sovMetadata(uint64(m.NumForwardedTimes))
 	}
+	if m.Digest {
+		n += 2
+	}
+	if m.DistinctSketch {
+		n += 2
+	}
 	return n
 }
 
@@ -1165,6 +1211,46 @@ func (m *ForwardMetadata) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Digest", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetadata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Digest = bool(v != 0)
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DistinctSketch", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetadata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DistinctSketch = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMetadata(dAtA[iNdEx:])
@@ -1406,40 +1492,42 @@ func init() {
 }
 
 var fileDescriptorMetadata = []byte{
-	// 551 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x54, 0xd1, 0x8a, 0xd3, 0x40,
-	0x14, 0xdd, 0x69, 0x77, 0x25, 0x9d, 0xda, 0x76, 0x8d, 0x82, 0xa1, 0x2b, 0xb5, 0xc4, 0x97, 0xbe,
-	0x38, 0x81, 0x56, 0xf1, 0x45, 0x85, 0x5d, 0x4a, 0xd9, 0x0a, 0xae, 0x4b, 0xd6, 0x27, 0x5f, 0x42,
-	0x92, 0x99, 0x8d, 0x03, 0x4d, 0x26, 0xcc, 0x4c, 0x94, 0x7e, 0x83, 0x2f, 0xfe, 0x80, 0xe0, 0x17,
-	0xf8, 0x1d, 0xfb, 0xe8, 0x17, 0x88, 0xd4, 0x1f, 0x91, 0x24, 0x33, 0x49, 0xba, 0x2f, 0x52, 0x45,
-	0xd8, 0xb7, 0x7b, 0xef, 0xdc, 0x7b, 0x38, 0xe7, 0x70, 0x18, 0xb8, 0x88, 0xa8, 0x7c, 0x9f, 0x05,
-	0x28, 0x64, 0xb1, 0x13, 0xcf, 0x70, 0xe0, 0xc4, 0x33, 0x47, 0xf0, 0xd0, 0x89, 0x89, 0xe4, 0x34,
-	0x14, 0x4e, 0x44, 0x12, 0xc2, 0x7d, 0x49, 0xb0, 0x93, 0x72, 0x26, 0x99, 0x9a, 0xa7, 0x41, 0x5e,
-	0xf8, 0xd8, 0x97, 0x3e, 0x2a, 0xe6, 0xa6, 0xa1, 0x1f, 0x86, 0x8f, 0x1b, 0x88, 0x11, 0x8b, 0x58,
-	0x79, 0x18, 0x64, 0x97, 0x45, 0x57, 0xa2, 0xe4, 0x55, 0x79, 0x38, 0x3c, 0xdb, 0x91, 0x80, 0x1f,
-	0x45, 0x9c, 0x44, 0xbe, 0xa4, 0x2c, 0x49, 0x83, 0x66, 0xa7, 0xf0, 0xe6, 0x3b, 0xe2, 0xa5, 0x6c,
-	0x45, 0xc3, 0x75, 0x1a, 0xa8, 0x42, 0xa1, 0x9c, 0xee, 0x8a, 0x42, 0x53, 0xb2, 0xa2, 0x09, 0xc9,
-	0x71, 0x54, 0x59, 0x22, 0xd9, 0x5f, 0x5a, 0xf0, 0xf0, 0x5c, 0x8d, 0x5e, 0x2b, 0xcf, 0xcc, 0x25,
-	0xec, 0x37, 0x98, 0x7b, 0x14, 0x5b, 0x60, 0x0c, 0x26, 0xdd, 0xe9, 0x03, 0xb4, 0x25, 0x0f, 0x1d,
-	0xd7, 0xdd, 0x72, 0x7e, 0xb2, 0x7f, 0xf5, 0xe3, 0xe1, 0x9e, 0xdb, 0x6b, 0xac, 0x2c, 0xb1, 0x79,
-	0x0a, 0x0f, 0x85, 0x64, 0xdc, 0x8f, 0x88, 0x57, 0x28, 0xa0, 0x44, 0x58, 0xad, 0x71, 0x7b, 0xd2,
-	0x9d, 0xde, 0x47, 0x5a, 0x1b, 0xba, 0x28, 0x37, 0xce, 0x8b, 0x5e, 0xe1, 0x0c, 0x44, 0x63, 0x48,
-	0x89, 0x30, 0x5f, 0x40, 0x43, 0x73, 0xb7, 0xda, 0x05, 0x9d, 0x23, 0x54, 0xeb, 0x42, 0xc7, 0x69,
-	0xba, 0xa2, 0x04, 0x6b, 0x2d, 0x0a, 0xa5, 0x3a, 0x31, 0x9f, 0xc2, 0x2e, 0xe6, 0x2c, 0x2d, 0x59,
-	0xac, 0xad, 0xfd, 0x31, 0x98, 0xf4, 0xa7, 0xf7, 0x6a, 0x0e, 0x73, 0xce, 0xd2, 0x92, 0x80, 0x0b,
-	0x71, 0x55, 0xdb, 0xaf, 0xa0, 0x51, 0xd9, 0xf2, 0x12, 0x76, 0x34, 0x9c, 0xb0, 0x40, 0x21, 0x62,
-	0x88, 0x74, 0xb0, 0xd0, 0x75, 0x17, 0x15, 0x83, 0xfa, 0xc4, 0xfe, 0x04, 0x60, 0xff, 0x42, 0xfa,
-	0x11, 0xc1, 0x15, 0xe4, 0x23, 0xd8, 0x0b, 0x33, 0xc9, 0x3e, 0x10, 0xee, 0x25, 0x7e, 0xc2, 0x44,
-	0x61, 0x74, 0xdb, 0xbd, 0xad, 0x86, 0x67, 0xf9, 0xcc, 0x1c, 0x41, 0x28, 0x59, 0x1c, 0x08, 0xc9,
-	0x12, 0x82, 0xad, 0xd6, 0x18, 0x4c, 0x0c, 0xb7, 0x31, 0x31, 0x9f, 0x40, 0x43, 0xc7, 0x5d, 0x39,
-	0x63, 0xd6, 0xb4, 0xae, 0xd1, 0xa9, 0x36, 0xed, 0x37, 0x70, 0xb0, 0x4d, 0x46, 0x98, 0xcf, 0x61,
-	0x47, 0x3f, 0x6b, 0x81, 0x56, 0x8d, 0xb4, 0xbd, 0xad, 0xe5, 0x55, 0x07, 0xf6, 0xb7, 0x16, 0x1c,
-	0x2c, 0x18, 0xff, 0xe8, 0x73, 0xfc, 0x3f, 0x92, 0x34, 0x87, 0xfd, 0xad, 0x24, 0xad, 0x0b, 0x27,
-	0xfe, 0x98, 0xa3, 0x5e, 0x33, 0x47, 0xeb, 0x7f, 0x4d, 0xd1, 0x11, 0xec, 0x08, 0x96, 0xf1, 0x90,
-	0xe4, 0x52, 0xf2, 0x0c, 0xf5, 0x5c, 0xa3, 0x1c, 0x2c, 0xb1, 0x89, 0xe0, 0xdd, 0x24, 0x8b, 0xbd,
-	0xcb, 0xd2, 0x03, 0x82, 0x3d, 0x49, 0x63, 0x22, 0xac, 0x83, 0x31, 0x98, 0x1c, 0xb8, 0x77, 0x92,
-	0x2c, 0x5e, 0xe8, 0x97, 0xb7, 0xf9, 0x83, 0xfd, 0x15, 0xc0, 0x5e, 0x5e, 0xdd, 0x5c, 0xbb, 0x4e,
-	0x96, 0x57, 0x9b, 0x11, 0xf8, 0xbe, 0x19, 0x81, 0x9f, 0x9b, 0x11, 0xf8, 0xfc, 0x6b, 0xb4, 0xf7,
-	0xee, 0xd9, 0x5f, 0xfe, 0xc8, 0xc1, 0xad, 0xa2, 0x9f, 0xfd, 0x0e, 0x00, 0x00, 0xff, 0xff, 0x29,
-	0x6f, 0x13, 0xd0, 0xd3, 0x05, 0x00, 0x00,
+	// 586 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x54, 0xcd, 0x6e, 0xd3, 0x4c,
+	0x14, 0xad, 0xd3, 0x9f, 0xcf, 0x9d, 0x7e, 0x49, 0xca, 0x80, 0xc0, 0x4a, 0x51, 0x88, 0xcc, 0x82,
+	0x6c, 0xb0, 0xa5, 0x04, 0xc4, 0x06, 0x90, 0x5a, 0x45, 0x51, 0x83, 0x44, 0xa9, 0x1c, 0x56, 0x6c,
+	0x2c, 0xdb, 0x33, 0x75, 0x47, 0xc4, 0x1e, 0x6b, 0x66, 0x0c, 0xca, 0x33, 0xb0, 0xe1, 0x05, 0x90,
+	0x78, 0x9c, 0x2e, 0x79, 0x02, 0x84, 0xc2, 0x03, 0xf0, 0x0a, 0xc8, 0xe3, 0x19, 0xdb, 0xe9, 0x06,
+	0x05, 0x84, 0xc4, 0xee, 0xde, 0x33, 0xf7, 0x1e, 0x9d, 0x73, 0x7d, 0x64, 0x30, 0x8d, 0x89, 0xb8,
+	0xcc, 0x43, 0x27, 0xa2, 0x89, 0x9b, 0x8c, 0x51, 0xe8, 0x26, 0x63, 0x97, 0xb3, 0xc8, 0x4d, 0xb0,
+	0x60, 0x24, 0xe2, 0x6e, 0x8c, 0x53, 0xcc, 0x02, 0x81, 0x91, 0x9b, 0x31, 0x2a, 0xa8, 0xc2, 0xb3,
+	0xb0, 0x28, 0x02, 0x14, 0x88, 0xc0, 0x91, 0x38, 0x34, 0xf5, 0x43, 0xef, 0x61, 0x83, 0x31, 0xa6,
+	0x31, 0x2d, 0x17, 0xc3, 0xfc, 0x42, 0x76, 0x25, 0x4b, 0x51, 0x95, 0x8b, 0xbd, 0xb3, 0x0d, 0x05,
+	0x04, 0x71, 0xcc, 0x70, 0x1c, 0x08, 0x42, 0xd3, 0x2c, 0x6c, 0x76, 0x8a, 0x6f, 0xb2, 0x21, 0x5f,
+	0x46, 0x17, 0x24, 0x5a, 0x66, 0xa1, 0x2a, 0x14, 0xcb, 0xe9, 0xa6, 0x2c, 0x24, 0xc3, 0x0b, 0x92,
+	0xe2, 0x2c, 0xac, 0xca, 0x92, 0xc9, 0xfe, 0xd4, 0x02, 0x87, 0xe7, 0x0a, 0x7a, 0xa9, 0x6e, 0x06,
+	0x67, 0xa0, 0xd3, 0x50, 0xee, 0x13, 0x64, 0x19, 0x03, 0x63, 0x78, 0x30, 0xba, 0xeb, 0xac, 0xd9,
+	0x73, 0x8e, 0xeb, 0x6e, 0x36, 0x39, 0xd9, 0xb9, 0xfa, 0x7a, 0x6f, 0xcb, 0x6b, 0x37, 0x46, 0x66,
+	0x08, 0x9e, 0x82, 0x43, 0x2e, 0x28, 0x0b, 0x62, 0xec, 0x4b, 0x07, 0x04, 0x73, 0xab, 0x35, 0xd8,
+	0x1e, 0x1e, 0x8c, 0xee, 0x38, 0xda, 0x9b, 0x33, 0x2f, 0x27, 0xce, 0x65, 0xaf, 0x78, 0xba, 0xbc,
+	0x01, 0x12, 0xcc, 0xe1, 0x33, 0x60, 0x6a, 0xed, 0xd6, 0xb6, 0x94, 0x73, 0xe4, 0xd4, 0xbe, 0x9c,
+	0xe3, 0x2c, 0x5b, 0x10, 0x8c, 0xb4, 0x17, 0xc5, 0x52, 0xad, 0xc0, 0xc7, 0xe0, 0x00, 0x31, 0x9a,
+	0x95, 0x2a, 0x96, 0xd6, 0xce, 0xc0, 0x18, 0x76, 0x46, 0xb7, 0x6a, 0x0d, 0x13, 0x46, 0xb3, 0x52,
+	0x80, 0x07, 0x50, 0x55, 0xdb, 0x2f, 0x80, 0x59, 0x9d, 0xe5, 0x39, 0xd8, 0xd7, 0x74, 0xdc, 0x32,
+	0xa4, 0x89, 0x9e, 0xa3, 0x83, 0xe5, 0x5c, 0xbf, 0xa2, 0x52, 0x50, 0xaf, 0xd8, 0x1f, 0x0c, 0xd0,
+	0x99, 0x8b, 0x20, 0xc6, 0xa8, 0xa2, 0xbc, 0x0f, 0xda, 0x51, 0x2e, 0xe8, 0x3b, 0xcc, 0xfc, 0x34,
+	0x48, 0x29, 0x97, 0x87, 0xde, 0xf6, 0xfe, 0x57, 0xe0, 0x59, 0x81, 0xc1, 0x3e, 0x00, 0x82, 0x26,
+	0x21, 0x17, 0x34, 0xc5, 0xc8, 0x6a, 0x0d, 0x8c, 0xa1, 0xe9, 0x35, 0x10, 0xf8, 0x08, 0x98, 0x3a,
+	0xee, 0xea, 0x32, 0xb0, 0x96, 0x75, 0x4d, 0x4e, 0x35, 0x69, 0xbf, 0x02, 0xdd, 0x75, 0x31, 0x1c,
+	0x3e, 0x05, 0xfb, 0xfa, 0x59, 0x1b, 0xb4, 0x6a, 0xa6, 0xf5, 0x69, 0x6d, 0xaf, 0x5a, 0xb0, 0x7f,
+	0xb4, 0x40, 0x77, 0x4a, 0xd9, 0xfb, 0x80, 0xa1, 0xbf, 0x91, 0xa4, 0x09, 0xe8, 0xac, 0x25, 0x69,
+	0x29, 0x2f, 0xf1, 0xcb, 0x1c, 0xb5, 0x9b, 0x39, 0x5a, 0xfe, 0x69, 0x8a, 0x8e, 0xc0, 0x3e, 0xa7,
+	0x39, 0x8b, 0x70, 0x61, 0xa5, 0xc8, 0x50, 0xdb, 0x33, 0x4b, 0x60, 0x86, 0xa0, 0x03, 0x6e, 0xa6,
+	0x79, 0xe2, 0x5f, 0x94, 0x37, 0xc0, 0xc8, 0x17, 0x24, 0xc1, 0xdc, 0xda, 0x1d, 0x18, 0xc3, 0x5d,
+	0xef, 0x46, 0x9a, 0x27, 0x53, 0xfd, 0xf2, 0xba, 0x78, 0x80, 0xb7, 0xc1, 0x1e, 0x22, 0x31, 0xe6,
+	0xc2, 0xda, 0x93, 0xdf, 0x54, 0x75, 0xf0, 0x01, 0xe8, 0x22, 0xc2, 0x05, 0x49, 0x23, 0xe1, 0xf3,
+	0xb7, 0x58, 0x44, 0x97, 0xd6, 0x7f, 0x72, 0xa0, 0xa3, 0xe1, 0xb9, 0x44, 0xed, 0xcf, 0x06, 0x68,
+	0x17, 0x54, 0xff, 0xee, 0xbd, 0x4f, 0x66, 0x57, 0xab, 0xbe, 0xf1, 0x65, 0xd5, 0x37, 0xbe, 0xad,
+	0xfa, 0xc6, 0xc7, 0xef, 0xfd, 0xad, 0x37, 0x4f, 0x7e, 0xf3, 0x97, 0x1e, 0xee, 0xc9, 0x7e, 0xfc,
+	0x73, 0x00, 0x8e, 0xf0, 0x54, 0xa8, 0x14, 0x06, 0x00, 0x00,
 }
@@ -46,6 +46,8 @@ import _ "github.com/gogo/protobuf/gogoproto"
 import aggregationpb "github.com/m3db/m3/src/metrics/generated/proto/aggregationpb"
 import transformationpb "github.com/m3db/m3/src/metrics/generated/proto/transformationpb"
 
+import binary "encoding/binary"
+
 import io "io"
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -59,6 +61,27 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
+type RollupOp_Type int32
+
+const (
+	RollupOp_GROUP_BY   RollupOp_Type = 0
+	RollupOp_EXCLUDE_BY RollupOp_Type = 1
+)
+
+var RollupOp_Type_name = map[int32]string{
+	0: "GROUP_BY",
+	1: "EXCLUDE_BY",
+}
+var RollupOp_Type_value = map[string]int32{
+	"GROUP_BY":   0,
+	"EXCLUDE_BY": 1,
+}
+
+func (x RollupOp_Type) String() string {
+	return proto.EnumName(RollupOp_Type_name, int32(x))
+}
+func (RollupOp_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptorPipeline, []int{2, 0} }
+
 type PipelineOp_Type int32
 
 const (
@@ -130,6 +153,9 @@ func (m *AggregationOp) GetType() aggregationpb.AggregationType {
 
 type TransformationOp struct {
 	Type transformationpb.TransformationType `protobuf:"varint,1,opt,name=type,proto3,enum=transformationpb.TransformationType" json:"type,omitempty"`
+	// Arguments of parameterized transformations, e.g. the factor of a scale
+	// transformation or the bounds of a clamp transformation.
+	Args []float64 `protobuf:"fixed64,2,rep,packed,name=args" json:"args,omitempty"`
 }
 
 func (m *TransformationOp) Reset()                    { *m = TransformationOp{} }
@@ -144,10 +170,18 @@ func (m *TransformationOp) GetType() transformationpb.TransformationType {
 	return transformationpb.TransformationType_UNKNOWN
 }
 
+func (m *TransformationOp) GetArgs() []float64 {
+	if m != nil {
+		return m.Args
+	}
+	return nil
+}
+
 type RollupOp struct {
 	NewName          string                          `protobuf:"bytes,1,opt,name=new_name,json=newName,proto3" json:"new_name,omitempty"`
 	Tags             []string                        `protobuf:"bytes,2,rep,name=tags" json:"tags,omitempty"`
 	AggregationTypes []aggregationpb.AggregationType `protobuf:"varint,3,rep,packed,name=aggregation_types,json=aggregationTypes,enum=aggregationpb.AggregationType" json:"aggregation_types,omitempty"`
+	Type             RollupOp_Type                   `protobuf:"varint,4,opt,name=type,proto3,enum=pipelinepb.RollupOp_Type" json:"type,omitempty"`
 }
 
 func (m *RollupOp) Reset()                    { *m = RollupOp{} }
@@ -176,6 +210,13 @@ func (m *RollupOp) GetAggregationTypes() []aggregationpb.AggregationType {
 	return nil
 }
 
+func (m *RollupOp) GetType() RollupOp_Type {
+	if m != nil {
+		return m.Type
+	}
+	return RollupOp_GROUP_BY
+}
+
 type PipelineOp struct {
 	Type           PipelineOp_Type   `protobuf:"varint,1,opt,name=type,proto3,enum=pipelinepb.PipelineOp_Type" json:"type,omitempty"`
 	Aggregation    *AggregationOp    `protobuf:"bytes,2,opt,name=aggregation" json:"aggregation,omitempty"`
@@ -319,6 +360,7 @@ func init() {
 	proto.RegisterType((*AppliedRollupOp)(nil), "pipelinepb.AppliedRollupOp")
 	proto.RegisterType((*AppliedPipelineOp)(nil), "pipelinepb.AppliedPipelineOp")
 	proto.RegisterType((*AppliedPipeline)(nil), "pipelinepb.AppliedPipeline")
+	proto.RegisterEnum("pipelinepb.RollupOp_Type", RollupOp_Type_name, RollupOp_Type_value)
 	proto.RegisterEnum("pipelinepb.PipelineOp_Type", PipelineOp_Type_name, PipelineOp_Type_value)
 	proto.RegisterEnum("pipelinepb.AppliedPipelineOp_Type", AppliedPipelineOp_Type_name, AppliedPipelineOp_Type_value)
 }
@@ -365,6 +407,16 @@ func (m *TransformationOp) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintPipeline(dAtA, i, uint64(m.Type))
 	}
+	if len(m.Args) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPipeline(dAtA, i, uint64(len(m.Args)*8))
+		for _, num := range m.Args {
+			f1 := math.Float64bits(float64(num))
+			binary.LittleEndian.PutUint64(dAtA[i:], uint64(f1))
+			i += 8
+		}
+	}
 	return i, nil
 }
 
@@ -405,21 +457,26 @@ func (m *RollupOp) MarshalTo(dAtA []byte) (int, error) {
 		}
 	}
 	if len(m.AggregationTypes) > 0 {
-		dAtA2 := make([]byte, len(m.AggregationTypes)*10)
-		var j1 int
+		dAtA3 := make([]byte, len(m.AggregationTypes)*10)
+		var j2 int
 		for _, num := range m.AggregationTypes {
 			for num >= 1<<7 {
-				dAtA2[j1] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA3[j2] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j1++
+				j2++
 			}
-			dAtA2[j1] = uint8(num)
-			j1++
+			dAtA3[j2] = uint8(num)
+			j2++
 		}
 		dAtA[i] = 0x1a
 		i++
-		i = encodeVarintPipeline(dAtA, i, uint64(j1))
-		i += copy(dAtA[i:], dAtA2[:j1])
+		i = encodeVarintPipeline(dAtA, i, uint64(j2))
+		i += copy(dAtA[i:], dAtA3[:j2])
+	}
+	if m.Type != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintPipeline(dAtA, i, uint64(m.Type))
 	}
 	return i, nil
 }
@@ -448,31 +505,31 @@ func (m *PipelineOp) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintPipeline(dAtA, i, uint64(m.Aggregation.Size()))
-		n3, err := m.Aggregation.MarshalTo(dAtA[i:])
+		n4, err := m.Aggregation.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n3
+		i += n4
 	}
 	if m.Transformation != nil {
 		dAtA[i] = 0x1a
 		i++
 		i = encodeVarintPipeline(dAtA, i, uint64(m.Transformation.Size()))
-		n4, err := m.Transformation.MarshalTo(dAtA[i:])
+		n5, err := m.Transformation.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n4
+		i += n5
 	}
 	if m.Rollup != nil {
 		dAtA[i] = 0x22
 		i++
 		i = encodeVarintPipeline(dAtA, i, uint64(m.Rollup.Size()))
-		n5, err := m.Rollup.MarshalTo(dAtA[i:])
+		n6, err := m.Rollup.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n5
+		i += n6
 	}
 	return i, nil
 }
@@ -531,11 +588,11 @@ func (m *AppliedRollupOp) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x12
 	i++
 	i = encodeVarintPipeline(dAtA, i, uint64(m.AggregationId.Size()))
-	n6, err := m.AggregationId.MarshalTo(dAtA[i:])
+	n7, err := m.AggregationId.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n6
+	i += n7
 	return i, nil
 }
 
@@ -563,21 +620,21 @@ func (m *AppliedPipelineOp) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintPipeline(dAtA, i, uint64(m.Transformation.Size()))
-		n7, err := m.Transformation.MarshalTo(dAtA[i:])
+		n8, err := m.Transformation.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n7
+		i += n8
 	}
 	if m.Rollup != nil {
 		dAtA[i] = 0x1a
 		i++
 		i = encodeVarintPipeline(dAtA, i, uint64(m.Rollup.Size()))
-		n8, err := m.Rollup.MarshalTo(dAtA[i:])
+		n9, err := m.Rollup.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n8
+		i += n9
 	}
 	return i, nil
 }
@@ -636,6 +693,9 @@ func (m *TransformationOp) Size() (n int) {
 	if m.Type != 0 {
 		n += 1 + sovPipeline(uint64(m.Type))
 	}
+	if len(m.Args) > 0 {
+		n += 1 + sovPipeline(uint64(len(m.Args)*8)) + len(m.Args)*8
+	}
 	return n
 }
 
@@ -659,6 +719,9 @@ func (m *RollupOp) Size() (n int) {
 		}
 		n += 1 + sovPipeline(uint64(l)) + l
 	}
+	if m.Type != 0 {
+		n += 1 + sovPipeline(uint64(m.Type))
+	}
 	return n
 }
 
@@ -866,6 +929,52 @@ func (m *TransformationOp) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 2:
+			if wireType == 1 {
+				var v uint64
+				if (iNdEx + 8) > l {
+					return io.ErrUnexpectedEOF
+				}
+				v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+				iNdEx += 8
+				v2 := float64(math.Float64frombits(v))
+				m.Args = append(m.Args, v2)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPipeline
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPipeline
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					if (iNdEx + 8) > l {
+						return io.ErrUnexpectedEOF
+					}
+					v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+					iNdEx += 8
+					v2 := float64(math.Float64frombits(v))
+					m.Args = append(m.Args, v2)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Args", wireType)
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPipeline(dAtA[iNdEx:])
@@ -1036,6 +1145,25 @@ func (m *RollupOp) Unmarshal(dAtA []byte) error {
 			} else {
 				return fmt.Errorf("proto: wrong wireType = %d for field AggregationTypes", wireType)
 			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPipeline
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= (RollupOp_Type(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPipeline(dAtA[iNdEx:])
@@ -1743,42 +1871,45 @@ func init() {
 }
 
 var fileDescriptorPipeline = []byte{
-	// 587 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x94, 0x4d, 0x6e, 0xd3, 0x40,
-	0x1c, 0xc5, 0x3b, 0x76, 0x94, 0xa6, 0xff, 0xd0, 0x34, 0x1d, 0x21, 0xe4, 0x7e, 0x10, 0x22, 0x8b,
-	0x45, 0x16, 0x60, 0x4b, 0x89, 0x40, 0x7c, 0xac, 0x52, 0x02, 0x69, 0x94, 0x60, 0x57, 0x43, 0x2a,
-	0x24, 0x36, 0x95, 0x1d, 0x4f, 0x8d, 0xa5, 0xd8, 0x1e, 0xd9, 0x8e, 0xaa, 0x5e, 0x80, 0x35, 0x87,
-	0xe1, 0x10, 0x5d, 0x72, 0x02, 0x84, 0xc2, 0x31, 0xd8, 0xa0, 0xd8, 0x4e, 0x32, 0x93, 0x04, 0x44,
-	0xbb, 0x1b, 0x8f, 0xdf, 0x7b, 0xf3, 0x9f, 0xf7, 0xb3, 0x0c, 0xa7, 0xae, 0x97, 0x7c, 0x9e, 0xd8,
-	0xda, 0x28, 0xf4, 0x75, 0xbf, 0xe5, 0xd8, 0xba, 0xdf, 0xd2, 0xe3, 0x68, 0xa4, 0xfb, 0x34, 0x89,
-	0xbc, 0x51, 0xac, 0xbb, 0x34, 0xa0, 0x91, 0x95, 0x50, 0x47, 0x67, 0x51, 0x98, 0x84, 0x3a, 0xf3,
-	0x18, 0x1d, 0x7b, 0x01, 0x65, 0xf6, 0x62, 0xa9, 0xa5, 0x6f, 0x30, 0x2c, 0x5f, 0x1d, 0x3e, 0xe5,
-	0x52, 0xdd, 0xd0, 0x0d, 0x33, 0xb3, 0x3d, 0xb9, 0x4c, 0x9f, 0xb2, 0xa4, 0xd9, 0x2a, 0xb3, 0x1e,
-	0x1a, 0xb7, 0x1c, 0xc2, 0x72, 0xdd, 0x88, 0xba, 0x56, 0xe2, 0x85, 0x01, 0xb3, 0xf9, 0xa7, 0x3c,
-	0x6f, 0x78, 0xcb, 0xbc, 0x24, 0xb2, 0x82, 0xf8, 0x32, 0x8c, 0xfc, 0x79, 0xa4, 0xb8, 0x91, 0xa5,
-	0xaa, 0x6f, 0x60, 0xb7, 0xbd, 0x3c, 0xca, 0x64, 0xb8, 0x09, 0x85, 0xe4, 0x9a, 0x51, 0x05, 0xd5,
-	0x51, 0xa3, 0xd2, 0xac, 0x69, 0xc2, 0x58, 0x1a, 0xa7, 0x1d, 0x5e, 0x33, 0x4a, 0x52, 0xad, 0x3a,
-	0x80, 0xea, 0x50, 0x08, 0x37, 0x19, 0x7e, 0x21, 0xe4, 0x3c, 0xd6, 0x56, 0xc7, 0xd1, 0x44, 0x07,
-	0x97, 0xf6, 0x05, 0x41, 0x89, 0x84, 0xe3, 0xf1, 0x84, 0x99, 0x0c, 0x1f, 0x40, 0x29, 0xa0, 0x57,
-	0x17, 0x81, 0xe5, 0x67, 0x51, 0x3b, 0x64, 0x3b, 0xa0, 0x57, 0x86, 0xe5, 0x53, 0x8c, 0xa1, 0x90,
-	0x58, 0x6e, 0xac, 0x48, 0x75, 0xb9, 0xb1, 0x43, 0xd2, 0x35, 0xee, 0xc3, 0x3e, 0x37, 0xf0, 0xc5,
-	0x2c, 0x2f, 0x56, 0xe4, 0xba, 0xfc, 0x1f, 0x57, 0xa9, 0x5a, 0xe2, 0x46, 0xac, 0x7e, 0x93, 0x00,
-	0xce, 0x72, 0xfe, 0x26, 0xc3, 0xba, 0x70, 0xa3, 0x23, 0x6d, 0xf9, 0x69, 0x68, 0x4b, 0x95, 0xb6,
-	0xbc, 0x08, 0x7e, 0x0d, 0x65, 0x2e, 0x53, 0x91, 0xea, 0xa8, 0x51, 0x6e, 0x1e, 0xf0, 0x3e, 0xa1,
-	0x7a, 0xc2, 0xab, 0x71, 0x07, 0x2a, 0x62, 0x65, 0x8a, 0x9c, 0xfa, 0x8f, 0x79, 0xff, 0x6a, 0xeb,
-	0x64, 0xc5, 0x83, 0x9f, 0x40, 0x31, 0x4a, 0xab, 0x54, 0x0a, 0xa9, 0xfb, 0x3e, 0xef, 0x9e, 0x97,
-	0x4c, 0x72, 0x8d, 0xda, 0x81, 0xc2, 0x6c, 0x7c, 0x5c, 0x86, 0xed, 0x73, 0xa3, 0x6f, 0x98, 0x1f,
-	0x8d, 0xea, 0x16, 0xde, 0x83, 0x72, 0xbb, 0xdb, 0x25, 0x6f, 0xbb, 0xed, 0x61, 0xcf, 0x34, 0xaa,
-	0x08, 0x63, 0xa8, 0x0c, 0x49, 0xdb, 0xf8, 0xf0, 0xce, 0x24, 0xef, 0xb3, 0x3d, 0x09, 0x03, 0x14,
-	0x89, 0x39, 0x18, 0x9c, 0x9f, 0x55, 0x65, 0xf5, 0x15, 0x94, 0xe6, 0x7d, 0x60, 0x0d, 0xe4, 0x90,
-	0xc5, 0x0a, 0xaa, 0xcb, 0x8d, 0x72, 0xf3, 0xc1, 0xe6, 0xca, 0x4e, 0x0a, 0x37, 0x3f, 0x1e, 0x6d,
-	0x91, 0x99, 0x50, 0x1d, 0xc3, 0x5e, 0x9b, 0xb1, 0xb1, 0x47, 0x9d, 0xc5, 0x17, 0x50, 0x01, 0xc9,
-	0x73, 0xd2, 0xd2, 0xef, 0x11, 0xc9, 0x73, 0x70, 0x0f, 0x2a, 0x3c, 0x62, 0xcf, 0xc9, 0x8b, 0x3d,
-	0xfe, 0x3b, 0xdf, 0x5e, 0x27, 0x3f, 0x63, 0x97, 0x93, 0xf4, 0x1c, 0xf5, 0x37, 0x82, 0xfd, 0xfc,
-	0x38, 0x8e, 0xf3, 0x73, 0x81, 0xb3, 0x2a, 0xf0, 0x5a, 0x15, 0xf3, 0xb8, 0xd7, 0x89, 0x49, 0x77,
-	0x20, 0xd6, 0x5a, 0x10, 0xcb, 0x78, 0x1f, 0x6d, 0x38, 0x7f, 0x0d, 0x5c, 0x6b, 0x13, 0xb8, 0x75,
-	0x4e, 0x88, 0xe3, 0x24, 0xa9, 0xa7, 0x8b, 0xae, 0x17, 0xb8, 0x9e, 0xf1, 0xb8, 0x1e, 0xfe, 0xf3,
-	0xe6, 0x1c, 0xb5, 0x93, 0xfe, 0xcd, 0xb4, 0x86, 0xbe, 0x4f, 0x6b, 0xe8, 0xe7, 0xb4, 0x86, 0xbe,
-	0xfe, 0xaa, 0x6d, 0x7d, 0x7a, 0x79, 0xe7, 0x3f, 0xb0, 0x5d, 0x4c, 0x77, 0x5a, 0x7f, 0x02, 0x00,
-	0x00, 0xff, 0xff, 0x26, 0x29, 0x00, 0xcc, 0xc5, 0x05, 0x00, 0x00,
+	// 630 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x94, 0xcd, 0x4e, 0xdb, 0x40,
+	0x10, 0xc7, 0xb3, 0x76, 0x04, 0x61, 0x02, 0x21, 0xac, 0xaa, 0x2a, 0x7c, 0x34, 0x8d, 0x2c, 0x0e,
+	0x39, 0x14, 0x5b, 0x4a, 0xd4, 0xaa, 0x1f, 0xa7, 0x40, 0x68, 0x88, 0xa0, 0x36, 0xda, 0x26, 0xea,
+	0xc7, 0x85, 0xda, 0x78, 0x71, 0x2d, 0xc5, 0xf6, 0xca, 0x36, 0x42, 0xbc, 0x45, 0x1f, 0xa6, 0x0f,
+	0xc1, 0xb1, 0xf7, 0x4a, 0x55, 0x45, 0x1f, 0xa3, 0x97, 0x2a, 0x6b, 0x43, 0x76, 0x93, 0xb4, 0x2a,
+	0xdc, 0x76, 0xd7, 0x33, 0xff, 0x99, 0xf9, 0xff, 0x46, 0x86, 0x03, 0xcf, 0x4f, 0x3f, 0x9f, 0x3b,
+	0xfa, 0x69, 0x14, 0x18, 0x41, 0xdb, 0x75, 0x8c, 0xa0, 0x6d, 0x24, 0xf1, 0xa9, 0x11, 0xd0, 0x34,
+	0xf6, 0x4f, 0x13, 0xc3, 0xa3, 0x21, 0x8d, 0xed, 0x94, 0xba, 0x06, 0x8b, 0xa3, 0x34, 0x32, 0x98,
+	0xcf, 0xe8, 0xc8, 0x0f, 0x29, 0x73, 0x6e, 0x8f, 0x3a, 0xff, 0x82, 0x61, 0xf2, 0x69, 0x63, 0x47,
+	0x50, 0xf5, 0x22, 0x2f, 0xca, 0x92, 0x9d, 0xf3, 0x33, 0x7e, 0xcb, 0x94, 0xc6, 0xa7, 0x2c, 0x75,
+	0xc3, 0xbc, 0x63, 0x13, 0xb6, 0xe7, 0xc5, 0xd4, 0xb3, 0x53, 0x3f, 0x0a, 0x99, 0x23, 0xde, 0x72,
+	0xbd, 0xc1, 0x1d, 0xf5, 0xd2, 0xd8, 0x0e, 0x93, 0xb3, 0x28, 0x0e, 0x6e, 0x24, 0xe5, 0x87, 0x4c,
+	0x55, 0xdb, 0x83, 0x95, 0xce, 0xa4, 0x94, 0xc5, 0x70, 0x0b, 0x8a, 0xe9, 0x25, 0xa3, 0x35, 0xd4,
+	0x40, 0xcd, 0x4a, 0xab, 0xae, 0x4b, 0x6d, 0xe9, 0x42, 0xec, 0xe0, 0x92, 0x51, 0xc2, 0x63, 0xb5,
+	0x4f, 0x50, 0x1d, 0x48, 0xe2, 0x16, 0xc3, 0xcf, 0x25, 0x9d, 0x6d, 0x7d, 0xba, 0x1d, 0x5d, 0xce,
+	0x98, 0xa8, 0x61, 0x0c, 0x45, 0x3b, 0xf6, 0x92, 0x9a, 0xd2, 0x50, 0x9b, 0x88, 0xf0, 0xb3, 0xf6,
+	0x1d, 0x41, 0x89, 0x44, 0xa3, 0xd1, 0x39, 0xb3, 0x18, 0x5e, 0x87, 0x52, 0x48, 0x2f, 0x4e, 0x42,
+	0x3b, 0xc8, 0xe4, 0x97, 0xc8, 0x62, 0x48, 0x2f, 0x4c, 0x3b, 0xe0, 0xb9, 0xa9, 0x9d, 0xe7, 0x2e,
+	0x11, 0x7e, 0xc6, 0x87, 0xb0, 0x26, 0x0c, 0x71, 0x32, 0xae, 0x91, 0xd4, 0xd4, 0x86, 0xfa, 0x1f,
+	0xe3, 0x55, 0x6d, 0xf9, 0x21, 0xc1, 0x3b, 0xf9, 0x58, 0x45, 0x3e, 0xd6, 0xba, 0x3e, 0xd9, 0x0f,
+	0xfd, 0xa6, 0x3f, 0x5d, 0x70, 0x66, 0x1b, 0x8a, 0xe3, 0x1b, 0x5e, 0x86, 0x52, 0x8f, 0x58, 0xc3,
+	0xe3, 0x93, 0xdd, 0x0f, 0xd5, 0x02, 0xae, 0x00, 0xec, 0xbf, 0xdf, 0x3b, 0x1a, 0x76, 0xf7, 0xc7,
+	0x77, 0xa4, 0x7d, 0x55, 0x00, 0x8e, 0x73, 0x21, 0x8b, 0x61, 0x43, 0xb2, 0x6e, 0x53, 0xac, 0x31,
+	0x89, 0x12, 0xaa, 0xe0, 0x57, 0x50, 0x16, 0x1a, 0xad, 0x29, 0x0d, 0xd4, 0x2c, 0xcb, 0xbd, 0x49,
+	0x8c, 0x89, 0x18, 0x8d, 0xbb, 0x50, 0x91, 0xd9, 0xd4, 0x54, 0x9e, 0xbf, 0x25, 0xe6, 0x4f, 0xe3,
+	0x25, 0x53, 0x39, 0xf8, 0x09, 0x2c, 0xc4, 0x7c, 0x7e, 0xee, 0x4c, 0xb9, 0xf5, 0x60, 0x9e, 0x33,
+	0x24, 0x8f, 0xd1, 0xba, 0xb9, 0x2d, 0x65, 0x58, 0x1c, 0x9a, 0x87, 0xa6, 0xf5, 0xce, 0xac, 0x16,
+	0xf0, 0x2a, 0x94, 0x3b, 0xbd, 0x1e, 0xd9, 0xef, 0x75, 0x06, 0x7d, 0xcb, 0xac, 0x22, 0x8c, 0xa1,
+	0x32, 0x20, 0x1d, 0xf3, 0xed, 0x6b, 0x8b, 0xbc, 0xc9, 0xde, 0x14, 0x0c, 0xb0, 0x40, 0xac, 0xa3,
+	0xa3, 0xe1, 0x71, 0x55, 0xd5, 0x5e, 0x42, 0xe9, 0xc6, 0x0f, 0xac, 0x83, 0x1a, 0xb1, 0xa4, 0x86,
+	0x1a, 0x6a, 0xb3, 0xdc, 0x7a, 0x38, 0xdf, 0xb2, 0xdd, 0xe2, 0xd5, 0x8f, 0xc7, 0x05, 0x32, 0x0e,
+	0xd4, 0x46, 0xb0, 0xda, 0x61, 0x6c, 0xe4, 0x53, 0xf7, 0x76, 0xad, 0x2a, 0xa0, 0xf8, 0x2e, 0x37,
+	0x7d, 0x99, 0x28, 0xbe, 0x8b, 0xfb, 0x50, 0x11, 0xf7, 0xc6, 0x77, 0x73, 0x63, 0xb7, 0xfe, 0xbe,
+	0x34, 0xfd, 0x6e, 0x5e, 0x63, 0x45, 0x08, 0xe9, 0xbb, 0xda, 0x6f, 0x04, 0x6b, 0x79, 0x39, 0x81,
+	0xf3, 0x33, 0x89, 0xb3, 0x26, 0xf1, 0x9a, 0x0e, 0x16, 0x71, 0xcf, 0x12, 0x53, 0xee, 0x41, 0xac,
+	0x7d, 0x4b, 0x2c, 0xe3, 0xbd, 0x39, 0xa7, 0xfe, 0x0c, 0xb8, 0xf6, 0x3c, 0x70, 0xb3, 0x9c, 0x90,
+	0xc0, 0x49, 0xd1, 0x0e, 0x60, 0x75, 0x6a, 0x1e, 0xfc, 0x54, 0xc4, 0xf5, 0xe8, 0x9f, 0x93, 0x0b,
+	0xd4, 0x76, 0x0f, 0xaf, 0xae, 0xeb, 0xe8, 0xdb, 0x75, 0x1d, 0xfd, 0xbc, 0xae, 0xa3, 0x2f, 0xbf,
+	0xea, 0x85, 0x8f, 0x2f, 0xee, 0xfd, 0xab, 0x77, 0x16, 0xf8, 0x4b, 0xfb, 0xcf, 0x00, 0x90, 0x02,
+	0xe3, 0xa3, 0x2e, 0x06, 0x00, 0x00,
 }
@@ -49,11 +49,16 @@ const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 type TransformationType int32
 
 const (
-	TransformationType_UNKNOWN   TransformationType = 0
-	TransformationType_ABSOLUTE  TransformationType = 1
-	TransformationType_PERSECOND TransformationType = 2
-	TransformationType_INCREASE  TransformationType = 3
-	TransformationType_ADD       TransformationType = 4
+	TransformationType_UNKNOWN              TransformationType = 0
+	TransformationType_ABSOLUTE             TransformationType = 1
+	TransformationType_PERSECOND            TransformationType = 2
+	TransformationType_INCREASE             TransformationType = 3
+	TransformationType_ADD                  TransformationType = 4
+	TransformationType_DELTA                TransformationType = 5
+	TransformationType_PERSECOND_WITH_RESET TransformationType = 6
+	TransformationType_RESET                TransformationType = 7
+	TransformationType_SCALE                TransformationType = 8
+	TransformationType_CLAMP                TransformationType = 9
 )
 
 var TransformationType_name = map[int32]string{
@@ -62,13 +67,23 @@ var TransformationType_name = map[int32]string{
 	2: "PERSECOND",
 	3: "INCREASE",
 	4: "ADD",
+	5: "DELTA",
+	6: "PERSECOND_WITH_RESET",
+	7: "RESET",
+	8: "SCALE",
+	9: "CLAMP",
 }
 var TransformationType_value = map[string]int32{
-	"UNKNOWN":   0,
-	"ABSOLUTE":  1,
-	"PERSECOND": 2,
-	"INCREASE":  3,
-	"ADD":       4,
+	"UNKNOWN":              0,
+	"ABSOLUTE":             1,
+	"PERSECOND":            2,
+	"INCREASE":             3,
+	"ADD":                  4,
+	"DELTA":                5,
+	"PERSECOND_WITH_RESET": 6,
+	"RESET":                7,
+	"SCALE":                8,
+	"CLAMP":                9,
 }
 
 func (x TransformationType) String() string {
@@ -87,18 +102,21 @@ func init() {
 }
 
 var fileDescriptorTransformation = []byte{
-	// 201 bytes of a gzipped FileDescriptorProto
+	// 251 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x0a, 0x49, 0xcf, 0x2c, 0xc9,
 	0x28, 0x4d, 0xd2, 0x4b, 0xce, 0xcf, 0xd5, 0xcf, 0x35, 0x4e, 0x49, 0xd2, 0xcf, 0x35, 0xd6, 0x2f,
 	0x2e, 0x4a, 0xd6, 0xcf, 0x4d, 0x2d, 0x29, 0xca, 0x4c, 0x2e, 0xd6, 0x4f, 0x4f, 0xcd, 0x4b, 0x2d,
 	0x4a, 0x2c, 0x49, 0x4d, 0xd1, 0x2f, 0x28, 0xca, 0x2f, 0xc9, 0xd7, 0x2f, 0x29, 0x4a, 0xcc, 0x2b,
 	0x4e, 0xcb, 0x2f, 0xca, 0x4d, 0x2c, 0xc9, 0xcc, 0xcf, 0x2b, 0x48, 0x42, 0x13, 0xd0, 0x03, 0xab,
-	0x12, 0x12, 0x40, 0x57, 0xa6, 0x15, 0xca, 0x25, 0x14, 0x82, 0x22, 0x16, 0x52, 0x59, 0x90, 0x2a,
-	0xc4, 0xcd, 0xc5, 0x1e, 0xea, 0xe7, 0xed, 0xe7, 0x1f, 0xee, 0x27, 0xc0, 0x20, 0xc4, 0xc3, 0xc5,
-	0xe1, 0xe8, 0x14, 0xec, 0xef, 0x13, 0x1a, 0xe2, 0x2a, 0xc0, 0x28, 0xc4, 0xcb, 0xc5, 0x19, 0xe0,
-	0x1a, 0x14, 0xec, 0xea, 0xec, 0xef, 0xe7, 0x22, 0xc0, 0x04, 0x92, 0xf4, 0xf4, 0x73, 0x0e, 0x72,
-	0x75, 0x0c, 0x76, 0x15, 0x60, 0x16, 0x62, 0xe7, 0x62, 0x76, 0x74, 0x71, 0x11, 0x60, 0x71, 0x0a,
-	0x3c, 0xf1, 0x48, 0x8e, 0xf1, 0xc2, 0x23, 0x39, 0xc6, 0x07, 0x8f, 0xe4, 0x18, 0x27, 0x3c, 0x96,
-	0x63, 0x88, 0xb2, 0xa7, 0xd0, 0x43, 0x49, 0x6c, 0x60, 0x71, 0x63, 0x40, 0x00, 0x00, 0x00, 0xff,
-	0xff, 0xf3, 0x67, 0xfb, 0xf2, 0x1a, 0x01, 0x00, 0x00,
+	0x12, 0x12, 0x40, 0x57, 0xa6, 0x35, 0x9b, 0x91, 0x4b, 0x28, 0x04, 0x45, 0x30, 0xa4, 0xb2, 0x20,
+	0x55, 0x88, 0x9b, 0x8b, 0x3d, 0xd4, 0xcf, 0xdb, 0xcf, 0x3f, 0xdc, 0x4f, 0x80, 0x41, 0x88, 0x87,
+	0x8b, 0xc3, 0xd1, 0x29, 0xd8, 0xdf, 0x27, 0x34, 0xc4, 0x55, 0x80, 0x51, 0x88, 0x97, 0x8b, 0x33,
+	0xc0, 0x35, 0x28, 0xd8, 0xd5, 0xd9, 0xdf, 0xcf, 0x45, 0x80, 0x09, 0x24, 0xe9, 0xe9, 0xe7, 0x1c,
+	0xe4, 0xea, 0x18, 0xec, 0x2a, 0xc0, 0x2c, 0xc4, 0xce, 0xc5, 0xec, 0xe8, 0xe2, 0x22, 0xc0, 0x22,
+	0xc4, 0xc9, 0xc5, 0xea, 0xe2, 0xea, 0x13, 0xe2, 0x28, 0xc0, 0x2a, 0x24, 0xc1, 0x25, 0x02, 0xd7,
+	0x10, 0x1f, 0xee, 0x19, 0xe2, 0x11, 0x1f, 0xe4, 0x1a, 0xec, 0x1a, 0x22, 0xc0, 0x06, 0x52, 0x04,
+	0x61, 0xb2, 0x83, 0x98, 0xc1, 0xce, 0x8e, 0x3e, 0xae, 0x02, 0x1c, 0x20, 0xa6, 0xb3, 0x8f, 0xa3,
+	0x6f, 0x80, 0x00, 0xa7, 0x53, 0xe0, 0x89, 0x47, 0x72, 0x8c, 0x17, 0x1e, 0xc9, 0x31, 0x3e, 0x78,
+	0x24, 0xc7, 0x38, 0xe1, 0xb1, 0x1c, 0x43, 0x94, 0x3d, 0x85, 0xe1, 0x92, 0xc4, 0x06, 0x16, 0x37,
+	0x06, 0x0c, 0x00, 0x8d, 0xeb, 0x64, 0xf5, 0x61, 0x01, 0x00, 0x00,
 }
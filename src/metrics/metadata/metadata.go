@@ -311,6 +311,14 @@ type ForwardMetadata struct {
 
 	// Number of times this metric has been forwarded.
 	NumForwardedTimes int
+
+	// Whether the metric values are t-digest centroids encoded as consecutive
+	// mean and weight pairs rather than individual values.
+	Digest bool
+
+	// Whether the metric values are a HyperLogLog sketch of distinct values
+	// encoded as its precision followed by its packed non-zero registers.
+	DistinctSketch bool
 }
 
 // ToProto converts the forward metadata to a protobuf message in place.
@@ -326,6 +334,8 @@ func (m ForwardMetadata) ToProto(pb *metricpb.ForwardMetadata) error {
 	}
 	pb.SourceId = m.SourceID
 	pb.NumForwardedTimes = int32(m.NumForwardedTimes)
+	pb.Digest = m.Digest
+	pb.DistinctSketch = m.DistinctSketch
 	return nil
 }
 
@@ -342,6 +352,8 @@ func (m *ForwardMetadata) FromProto(pb metricpb.ForwardMetadata) error {
 	}
 	m.SourceID = pb.SourceId
 	m.NumForwardedTimes = int(pb.NumForwardedTimes)
+	m.Digest = pb.Digest
+	m.DistinctSketch = pb.DistinctSketch
 	return nil
 }
 
@@ -77,6 +77,7 @@ var (
 		}),
 		SourceID:          897,
 		NumForwardedTimes: 2,
+		Digest:            true,
 	}
 	testSmallPipelineMetadata = PipelineMetadata{
 		AggregationID: aggregation.DefaultID,
@@ -291,6 +292,7 @@ var (
 		},
 		SourceId:          897,
 		NumForwardedTimes: 2,
+		Digest:            true,
 	}
 	testBadForwardMetadataProto    = metricpb.ForwardMetadata{}
 	testSmallPipelineMetadataProto = metricpb.PipelineMetadata{
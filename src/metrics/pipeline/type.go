@@ -26,6 +26,8 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/m3db/m3/src/metrics/aggregation"
 	"github.com/m3db/m3/src/metrics/generated/proto/pipelinepb"
@@ -39,6 +41,7 @@ var (
 	errNilRollupOpProto         = errors.New("nil rollup op proto message")
 	errNilPipelineProto         = errors.New("nil pipeline proto message")
 	errNoOpInUnionMarshaler     = errors.New("no operation in union JSON value")
+	errInvalidTransformationOp  = errors.New("invalid transformation operation")
 )
 
 // OpType defines the type of an operation.
@@ -109,6 +112,9 @@ func (op *AggregationOp) UnmarshalText(data []byte) error {
 type TransformationOp struct {
 	// Type of transformation performed.
 	Type transformation.Type
+
+	// Arguments of parameterized transformations.
+	Args []float64
 }
 
 // NewTransformationOpFromProto creates a new transformation op from proto.
@@ -122,14 +128,30 @@ func NewTransformationOpFromProto(pb *pipelinepb.TransformationOp) (Transformati
 
 // Equal determines whether two transformation operations are equal.
 func (op TransformationOp) Equal(other TransformationOp) bool {
-	return op.Type == other.Type
+	if op.Type != other.Type || len(op.Args) != len(other.Args) {
+		return false
+	}
+	for i := range op.Args {
+		if op.Args[i] != other.Args[i] {
+			return false
+		}
+	}
+	return true
 }
 
 // Clone clones the transformation operation.
 func (op TransformationOp) Clone() TransformationOp {
+	if len(op.Args) > 0 {
+		op.Args = append([]float64(nil), op.Args...)
+	}
 	return op
 }
 
+// NewOp constructs the transformation operation with its arguments.
+func (op TransformationOp) NewOp() (transformation.Op, error) {
+	return op.Type.NewOp(op.Args...)
+}
+
 // Proto returns the proto message for the given transformation op.
 func (op TransformationOp) Proto() (*pipelinepb.TransformationOp, error) {
 	var pbOp pipelinepb.TransformationOp
@@ -140,12 +162,29 @@ func (op TransformationOp) Proto() (*pipelinepb.TransformationOp, error) {
 }
 
 func (op TransformationOp) String() string {
-	return op.Type.String()
+	if len(op.Args) == 0 {
+		return op.Type.String()
+	}
+	var b bytes.Buffer
+	b.WriteString(op.Type.String())
+	b.WriteString("(")
+	for i, arg := range op.Args {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString(strconv.FormatFloat(arg, 'f', -1, 64))
+	}
+	b.WriteString(")")
+	return b.String()
 }
 
 // ToProto converts the transformation op to a protobuf message in place.
 func (op TransformationOp) ToProto(pb *pipelinepb.TransformationOp) error {
-	return op.Type.ToProto(&pb.Type)
+	if err := op.Type.ToProto(&pb.Type); err != nil {
+		return err
+	}
+	pb.Args = op.Args
+	return nil
 }
 
 // FromProto converts the protobuf message to a transformation in place.
@@ -153,24 +192,136 @@ func (op *TransformationOp) FromProto(pb *pipelinepb.TransformationOp) error {
 	if pb == nil {
 		return errNilTransformationOpProto
 	}
-	return op.Type.FromProto(pb.Type)
+	if err := op.Type.FromProto(pb.Type); err != nil {
+		return err
+	}
+	op.Args = pb.Args
+	return nil
 }
 
-// UnmarshalText extracts this type from its textual representation.
+// UnmarshalText extracts this type from its textual representation, which is
+// the transformation type optionally followed by its arguments, e.g. `Scale(0.001)`.
 func (op *TransformationOp) UnmarshalText(text []byte) error {
-	return op.Type.UnmarshalText(text)
+	var (
+		str    = string(text)
+		name   = str
+		parsed TransformationOp
+	)
+	if argsIdx := strings.IndexByte(str, '('); argsIdx >= 0 {
+		if !strings.HasSuffix(str, ")") {
+			return fmt.Errorf("%v: %s", errInvalidTransformationOp, str)
+		}
+		name = str[:argsIdx]
+		for _, arg := range strings.Split(str[argsIdx+1:len(str)-1], ",") {
+			v, err := strconv.ParseFloat(strings.TrimSpace(arg), 64)
+			if err != nil {
+				return fmt.Errorf("%v: %s", errInvalidTransformationOp, str)
+			}
+			parsed.Args = append(parsed.Args, v)
+		}
+	}
+	if err := parsed.Type.UnmarshalText([]byte(name)); err != nil {
+		return err
+	}
+	if err := parsed.Type.ValidateArgs(parsed.Args); err != nil {
+		return err
+	}
+	*op = parsed
+	return nil
 }
 
 // MarshalText serializes this type to its textual representation.
 func (op TransformationOp) MarshalText() (text []byte, err error) {
-	return op.Type.MarshalText()
+	if !op.Type.IsValid() {
+		return nil, fmt.Errorf("invalid transformation type %s", op.Type.String())
+	}
+	return []byte(op.String()), nil
+}
+
+// RollupType defines how the tags of a rollup operation are interpreted.
+type RollupType int
+
+// List of supported rollup types.
+const (
+	// GroupByRollupType rolls up along the rollup tags, dropping all other tags.
+	GroupByRollupType RollupType = iota
+	// ExcludeByRollupType rolls up along all tags except the rollup tags.
+	ExcludeByRollupType
+)
+
+var (
+	validRollupTypes = map[RollupType]string{
+		GroupByRollupType:   "GroupBy",
+		ExcludeByRollupType: "ExcludeBy",
+	}
+)
+
+// NewRollupTypeFromProto creates a new rollup type from proto.
+func NewRollupTypeFromProto(pb pipelinepb.RollupOp_Type) (RollupType, error) {
+	switch pb {
+	case pipelinepb.RollupOp_GROUP_BY:
+		return GroupByRollupType, nil
+	case pipelinepb.RollupOp_EXCLUDE_BY:
+		return ExcludeByRollupType, nil
+	default:
+		return GroupByRollupType, fmt.Errorf("unknown rollup type in proto: %v", pb)
+	}
+}
+
+// IsValid checks if the rollup type is valid.
+func (t RollupType) IsValid() bool {
+	_, exists := validRollupTypes[t]
+	return exists
+}
+
+// Proto returns the proto representation of the rollup type.
+func (t RollupType) Proto() (pipelinepb.RollupOp_Type, error) {
+	switch t {
+	case GroupByRollupType:
+		return pipelinepb.RollupOp_GROUP_BY, nil
+	case ExcludeByRollupType:
+		return pipelinepb.RollupOp_EXCLUDE_BY, nil
+	default:
+		return pipelinepb.RollupOp_GROUP_BY, fmt.Errorf("unknown rollup type: %v", t)
+	}
+}
+
+func (t RollupType) String() string {
+	if str, exists := validRollupTypes[t]; exists {
+		return str
+	}
+	return fmt.Sprintf("RollupType(%d)", int(t))
+}
+
+// MarshalText returns the text encoding of a rollup type.
+func (t RollupType) MarshalText() ([]byte, error) {
+	if !t.IsValid() {
+		return nil, fmt.Errorf("invalid rollup type: %v", t)
+	}
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText unmarshals text-encoded data into a rollup type.
+func (t *RollupType) UnmarshalText(data []byte) error {
+	str := string(data)
+	for rollupType, rollupTypeStr := range validRollupTypes {
+		if str == rollupTypeStr {
+			*t = rollupType
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid rollup type: %s", str)
 }
 
 // RollupOp is a rollup operation.
 type RollupOp struct {
+	// Type of the rollup, which determines whether the rollup is performed
+	// along the rollup tags or along all tags except the rollup tags.
+	Type RollupType
 	// New metric name generated as a result of the rollup.
 	NewName []byte
-	// Dimensions along which the rollup is performed.
+	// Dimensions along which the rollup is performed for group by rollups,
+	// or dimensions excluded from the rollup for exclude by rollups.
 	Tags [][]byte
 	// Types of aggregation performed within each unique dimension combination.
 	AggregationID aggregation.ID
@@ -183,6 +334,10 @@ func NewRollupOpFromProto(pb *pipelinepb.RollupOp) (RollupOp, error) {
 	if pb == nil {
 		return rollup, errNilRollupOpProto
 	}
+	rollupType, err := NewRollupTypeFromProto(pb.Type)
+	if err != nil {
+		return rollup, err
+	}
 	aggregationID, err := aggregation.NewIDFromProto(pb.AggregationTypes)
 	if err != nil {
 		return rollup, err
@@ -191,6 +346,7 @@ func NewRollupOpFromProto(pb *pipelinepb.RollupOp) (RollupOp, error) {
 	copy(tags, pb.Tags)
 	sort.Strings(tags)
 	return RollupOp{
+		Type:          rollupType,
 		NewName:       []byte(pb.NewName),
 		Tags:          xbytes.ArraysFromStringArray(tags),
 		AggregationID: aggregationID,
@@ -198,8 +354,11 @@ func NewRollupOpFromProto(pb *pipelinepb.RollupOp) (RollupOp, error) {
 }
 
 // SameTransform returns true if the two rollup operations have the same rollup transformation
-// (i.e., same new rollup metric name and same set of rollup tags).
+// (i.e., same rollup type, same new rollup metric name and same set of rollup tags).
 func (op RollupOp) SameTransform(other RollupOp) bool {
+	if op.Type != other.Type {
+		return false
+	}
 	if !bytes.Equal(op.NewName, other.NewName) {
 		return false
 	}
@@ -232,6 +391,7 @@ func (op RollupOp) Clone() RollupOp {
 	newName := make([]byte, len(op.NewName))
 	copy(newName, op.NewName)
 	return RollupOp{
+		Type:          op.Type,
 		NewName:       newName,
 		Tags:          xbytes.ArrayCopy(op.Tags),
 		AggregationID: op.AggregationID,
@@ -240,6 +400,10 @@ func (op RollupOp) Clone() RollupOp {
 
 // Proto returns the proto message for the given rollup op.
 func (op RollupOp) Proto() (*pipelinepb.RollupOp, error) {
+	pbRollupType, err := op.Type.Proto()
+	if err != nil {
+		return nil, err
+	}
 	aggTypes, err := op.AggregationID.Types()
 	if err != nil {
 		return nil, err
@@ -252,6 +416,7 @@ func (op RollupOp) Proto() (*pipelinepb.RollupOp, error) {
 		NewName:          string(op.NewName),
 		Tags:             xbytes.ArraysToStringArray(op.Tags),
 		AggregationTypes: pbAggTypes,
+		Type:             pbRollupType,
 	}, nil
 }
 
@@ -259,7 +424,11 @@ func (op RollupOp) String() string {
 	var b bytes.Buffer
 	b.WriteString("{")
 	fmt.Fprintf(&b, "name: %s, ", op.NewName)
-	b.WriteString("tags: [")
+	if op.Type == ExcludeByRollupType {
+		b.WriteString("excludeTags: [")
+	} else {
+		b.WriteString("tags: [")
+	}
 	for i, t := range op.Tags {
 		fmt.Fprintf(&b, "%s", t)
 		if i < len(op.Tags)-1 {
@@ -303,6 +472,7 @@ func (op RollupOp) MarshalYAML() (interface{}, error) {
 }
 
 type rollupMarshaler struct {
+	Type          RollupType     `json:"type,omitempty" yaml:"type,omitempty"`
 	NewName       string         `json:"newName" yaml:"newName"`
 	Tags          []string       `json:"tags" yaml:"tags"`
 	AggregationID aggregation.ID `json:"aggregation,omitempty" yaml:"aggregation"`
@@ -310,6 +480,7 @@ type rollupMarshaler struct {
 
 func newRollupMarshaler(op RollupOp) rollupMarshaler {
 	return rollupMarshaler{
+		Type:          op.Type,
 		NewName:       string(op.NewName),
 		Tags:          xbytes.ArraysToStringArray(op.Tags),
 		AggregationID: op.AggregationID,
@@ -318,6 +489,7 @@ func newRollupMarshaler(op RollupOp) rollupMarshaler {
 
 func (m rollupMarshaler) RollupOp() RollupOp {
 	return RollupOp{
+		Type:          m.Type,
 		NewName:       []byte(m.NewName),
 		Tags:          xbytes.ArraysFromStringArray(m.Tags),
 		AggregationID: m.AggregationID,
@@ -45,6 +45,14 @@ var (
 	testBadTransformationOpProto = pipelinepb.TransformationOp{
 		Type: transformationpb.TransformationType_UNKNOWN,
 	}
+	testClampTransformationOp = TransformationOp{
+		Type: transformation.Clamp,
+		Args: []float64{0, 100.5},
+	}
+	testClampTransformationOpProto = pipelinepb.TransformationOp{
+		Type: transformationpb.TransformationType_CLAMP,
+		Args: []float64{0, 100.5},
+	}
 )
 
 func TestAggregationOpEqual(t *testing.T) {
@@ -121,13 +129,28 @@ func TestTransformationOpEqual(t *testing.T) {
 		expected bool
 	}{
 		{
-			a1:       TransformationOp{transformation.Absolute},
-			a2:       TransformationOp{transformation.Absolute},
+			a1:       TransformationOp{Type: transformation.Absolute},
+			a2:       TransformationOp{Type: transformation.Absolute},
+			expected: true,
+		},
+		{
+			a1:       TransformationOp{Type: transformation.Absolute},
+			a2:       TransformationOp{Type: transformation.PerSecond},
+			expected: false,
+		},
+		{
+			a1:       TransformationOp{Type: transformation.Scale, Args: []float64{2}},
+			a2:       TransformationOp{Type: transformation.Scale, Args: []float64{2}},
 			expected: true,
 		},
 		{
-			a1:       TransformationOp{transformation.Absolute},
-			a2:       TransformationOp{transformation.PerSecond},
+			a1:       TransformationOp{Type: transformation.Scale, Args: []float64{2}},
+			a2:       TransformationOp{Type: transformation.Scale, Args: []float64{3}},
+			expected: false,
+		},
+		{
+			a1:       TransformationOp{Type: transformation.Scale, Args: []float64{2}},
+			a2:       TransformationOp{Type: transformation.Scale},
 			expected: false,
 		},
 	}
@@ -139,11 +162,66 @@ func TestTransformationOpEqual(t *testing.T) {
 }
 
 func TestTransformationOpClone(t *testing.T) {
-	source := TransformationOp{transformation.Absolute}
+	source := TransformationOp{Type: transformation.Absolute}
 	clone := source.Clone()
 	require.Equal(t, source, clone)
 	clone.Type = transformation.PerSecond
 	require.Equal(t, transformation.Absolute, source.Type)
+
+	source = TransformationOp{Type: transformation.Scale, Args: []float64{2}}
+	clone = source.Clone()
+	require.Equal(t, source, clone)
+	clone.Args[0] = 3
+	require.Equal(t, []float64{2}, source.Args)
+}
+
+func TestTransformationOpMarshalling(t *testing.T) {
+	examples := []TransformationOp{
+		{Type: transformation.PerSecond},
+		{Type: transformation.Scale, Args: []float64{0.001}},
+		testClampTransformationOp,
+	}
+
+	t.Run("roundtrips", func(t *testing.T) {
+		testmarshal.TestMarshalersRoundtrip(t, examples, []testmarshal.Marshaler{testmarshal.JSONMarshaler, testmarshal.YAMLMarshaler, testmarshal.TextMarshaler})
+	})
+
+	t.Run("marshals", func(t *testing.T) {
+		cases := []struct {
+			Example TransformationOp
+			Text    string
+		}{
+			{Example: TransformationOp{Type: transformation.PerSecond}, Text: "PerSecond"},
+			{Example: TransformationOp{Type: transformation.Scale, Args: []float64{0.001}}, Text: "Scale(0.001)"},
+			{Example: testClampTransformationOp, Text: "Clamp(0,100.5)"},
+		}
+		for _, tc := range cases {
+			testmarshal.Require(t, testmarshal.AssertUnmarshals(t, testmarshal.TextMarshaler, tc.Example, []byte(tc.Text)))
+			testmarshal.Require(t, testmarshal.AssertMarshals(t, testmarshal.TextMarshaler, tc.Example, []byte(tc.Text)))
+		}
+	})
+
+	t.Run("unmarshals with spaces", func(t *testing.T) {
+		var op TransformationOp
+		require.NoError(t, op.UnmarshalText([]byte("Clamp(0, 100.5)")))
+		require.Equal(t, testClampTransformationOp, op)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, text := range []string{
+			"Scale",
+			"Scale()",
+			"Scale(foo)",
+			"Scale(1",
+			"Clamp(1)",
+			"Clamp(2,1)",
+			"Absolute(1)",
+			"Unknown(1)",
+		} {
+			var op TransformationOp
+			require.Error(t, op.UnmarshalText([]byte(text)), text)
+		}
+	})
 }
 
 func TestPipelineString(t *testing.T) {
@@ -213,6 +291,16 @@ func TestTransformationOpFromProtoBadProto(t *testing.T) {
 	require.Error(t, res.FromProto(&testBadTransformationOpProto))
 }
 
+func TestTransformationOpWithArgsProto(t *testing.T) {
+	var pb pipelinepb.TransformationOp
+	require.NoError(t, testClampTransformationOp.ToProto(&pb))
+	require.Equal(t, testClampTransformationOpProto, pb)
+
+	var res TransformationOp
+	require.NoError(t, res.FromProto(&pb))
+	require.Equal(t, testClampTransformationOp, res)
+}
+
 func TestTransformationOpRoundTrip(t *testing.T) {
 	var (
 		pb  pipelinepb.TransformationOp
@@ -260,12 +348,61 @@ func TestRollupOpSameTransform(t *testing.T) {
 			op:     RollupOp{NewName: b("baz"), Tags: bs("bar2", "bar1")},
 			result: false,
 		},
+		{
+			op:     RollupOp{Type: ExcludeByRollupType, NewName: b("foo"), Tags: bs("bar1", "bar2")},
+			result: false,
+		},
 	}
 	for _, input := range inputs {
 		require.Equal(t, input.result, rollupOp.SameTransform(input.op))
 	}
 }
 
+func TestRollupOpProtoRoundTrip(t *testing.T) {
+	ops := []RollupOp{
+		{
+			NewName:       b("foo"),
+			Tags:          bs("bar1", "bar2"),
+			AggregationID: aggregation.MustCompressTypes(aggregation.Sum),
+		},
+		{
+			Type:          ExcludeByRollupType,
+			NewName:       b("foo"),
+			Tags:          bs("pod"),
+			AggregationID: aggregation.MustCompressTypes(aggregation.Sum),
+		},
+	}
+	for _, op := range ops {
+		pb, err := op.Proto()
+		require.NoError(t, err)
+		res, err := NewRollupOpFromProto(pb)
+		require.NoError(t, err)
+		require.Equal(t, op, res)
+	}
+
+	pb, err := ops[1].Proto()
+	require.NoError(t, err)
+	require.Equal(t, pipelinepb.RollupOp_EXCLUDE_BY, pb.Type)
+}
+
+func TestRollupOpProtoBadRollupType(t *testing.T) {
+	_, err := RollupOp{Type: RollupType(100)}.Proto()
+	require.Error(t, err)
+
+	_, err = NewRollupOpFromProto(&pipelinepb.RollupOp{Type: pipelinepb.RollupOp_Type(100)})
+	require.Error(t, err)
+}
+
+func TestRollupOpString(t *testing.T) {
+	op := RollupOp{
+		Type:          ExcludeByRollupType,
+		NewName:       b("foo"),
+		Tags:          bs("pod", "instance"),
+		AggregationID: aggregation.MustCompressTypes(aggregation.Sum),
+	}
+	require.Equal(t, "{name: foo, excludeTags: [pod, instance], aggregation: Sum}", op.String())
+}
+
 func TestOpUnionMarshalJSON(t *testing.T) {
 	inputs := []struct {
 		op       OpUnion
@@ -307,6 +444,18 @@ func TestOpUnionMarshalJSON(t *testing.T) {
 			},
 			expected: `{"rollup":{"newName":"testRollup","tags":["tag1","tag2"],"aggregation":null}}`,
 		},
+		{
+			op: OpUnion{
+				Type: RollupOpType,
+				Rollup: RollupOp{
+					Type:          ExcludeByRollupType,
+					NewName:       b("testRollup"),
+					Tags:          bs("tag1"),
+					AggregationID: aggregation.DefaultID,
+				},
+			},
+			expected: `{"rollup":{"type":"ExcludeBy","newName":"testRollup","tags":["tag1"],"aggregation":null}}`,
+		},
 	}
 
 	for _, input := range inputs {
@@ -348,6 +497,15 @@ func TestOpUnionMarshalRoundtrip(t *testing.T) {
 				AggregationID: aggregation.DefaultID,
 			},
 		},
+		{
+			Type: RollupOpType,
+			Rollup: RollupOp{
+				Type:          ExcludeByRollupType,
+				NewName:       b("testRollup"),
+				Tags:          bs("tag1"),
+				AggregationID: aggregation.DefaultID,
+			},
+		},
 	}
 
 	testmarshal.TestMarshalersRoundtrip(t, ops, []testmarshal.Marshaler{testmarshal.JSONMarshaler, testmarshal.YAMLMarshaler})
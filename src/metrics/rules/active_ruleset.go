@@ -329,6 +329,8 @@ func (as *activeRuleSet) toRollupResults(
 			numSteps      = pipeline.Len()
 			firstOp       = pipeline.At(0)
 			toApply       mpipeline.Pipeline
+
+			previousRollupOps []mpipeline.RollupOp
 		)
 		switch firstOp.Type {
 		case mpipeline.AggregationOpType:
@@ -347,8 +349,7 @@ func (as *activeRuleSet) toRollupResults(
 			var matched bool
 			rollupID, matched = as.matchRollupTarget(
 				sortedTagPairBytes,
-				firstOp.Rollup.NewName,
-				firstOp.Rollup.Tags,
+				firstOp.Rollup,
 				tagPairs,
 				matchRollupTargetOptions{generateRollupID: true},
 			)
@@ -358,13 +359,14 @@ func (as *activeRuleSet) toRollupResults(
 			}
 			aggregationID = firstOp.Rollup.AggregationID
 			toApply = pipeline.SubPipeline(1, numSteps)
+			previousRollupOps = []mpipeline.RollupOp{firstOp.Rollup}
 		default:
 			err = fmt.Errorf("target %v operation 0 has unknown type: %v", target, firstOp.Type)
 			multiErr = multiErr.Add(err)
 			continue
 		}
 		tagPairs = tagPairs[:0]
-		applied, err := as.applyIDToPipeline(sortedTagPairBytes, toApply, tagPairs)
+		applied, err := as.applyIDToPipeline(sortedTagPairBytes, toApply, previousRollupOps, tagPairs)
 		if err != nil {
 			err = fmt.Errorf("failed to apply id %s to pipeline %v: %v", id, toApply, err)
 			multiErr = multiErr.Add(err)
@@ -397,15 +399,21 @@ func (as *activeRuleSet) toRollupResults(
 
 // matchRollupTarget matches an incoming metric ID against a rollup target,
 // returns the new rollup ID if the metric ID contains the full list of rollup
-// tags, and nil otherwise.
+// tags, and nil otherwise. Exclude by rollup targets match any metric ID when
+// generating a rollup ID, see matchExcludeByRollupTarget.
 func (as *activeRuleSet) matchRollupTarget(
 	sortedTagPairBytes []byte,
-	newName []byte,
-	rollupTags [][]byte,
+	rollupOp mpipeline.RollupOp,
 	tagPairs []metricID.TagPair, // buffer for reuse to generate rollup ID across calls
 	opts matchRollupTargetOptions,
 ) ([]byte, bool) {
+	if rollupOp.Type == mpipeline.ExcludeByRollupType {
+		return as.matchExcludeByRollupTarget(sortedTagPairBytes, rollupOp, tagPairs, opts)
+	}
+
 	var (
+		newName       = rollupOp.NewName
+		rollupTags    = rollupOp.Tags
 		sortedTagIter = as.tagsFilterOpts.SortedTagIteratorFn(sortedTagPairBytes)
 		hasMoreTags   = sortedTagIter.Next()
 		currTagIdx    = 0
@@ -440,9 +448,77 @@ func (as *activeRuleSet) matchRollupTarget(
 	return as.newRollupIDFn(newName, tagPairs), true
 }
 
+// matchExcludeByRollupTarget matches a metric ID against an exclude by rollup
+// target. When generating a rollup ID, the metric ID always matches and the new
+// rollup ID retains all tags except the name tag, the excluded tags and any tags
+// dropped by the previous rollup operations in the pipeline. Otherwise the metric
+// ID is a rollup ID and matches if it contains none of the excluded tags.
+func (as *activeRuleSet) matchExcludeByRollupTarget(
+	sortedTagPairBytes []byte,
+	rollupOp mpipeline.RollupOp,
+	tagPairs []metricID.TagPair, // buffer for reuse to generate rollup ID across calls
+	opts matchRollupTargetOptions,
+) ([]byte, bool) {
+	var (
+		nameTag       = as.tagsFilterOpts.NameTagKey
+		sortedTagIter = as.tagsFilterOpts.SortedTagIteratorFn(sortedTagPairBytes)
+	)
+	for sortedTagIter.Next() {
+		tagName, tagVal := sortedTagIter.Current()
+		if containsTag(rollupOp.Tags, tagName) {
+			if !opts.generateRollupID {
+				sortedTagIter.Close()
+				return nil, false
+			}
+			continue
+		}
+		if !opts.generateRollupID {
+			continue
+		}
+		if len(nameTag) > 0 && bytes.Equal(tagName, nameTag) {
+			continue
+		}
+		if !retainedByRollupOps(tagName, opts.previousRollupOps) {
+			continue
+		}
+		tagPairs = append(tagPairs, metricID.TagPair{Name: tagName, Value: tagVal})
+	}
+	sortedTagIter.Close()
+
+	if !opts.generateRollupID {
+		return nil, true
+	}
+	return as.newRollupIDFn(rollupOp.NewName, tagPairs), true
+}
+
+// retainedByRollupOps returns true if the tag is retained by all of the given
+// rollup operations.
+func retainedByRollupOps(tagName []byte, rollupOps []mpipeline.RollupOp) bool {
+	for _, rollupOp := range rollupOps {
+		contains := containsTag(rollupOp.Tags, tagName)
+		if rollupOp.Type == mpipeline.ExcludeByRollupType && contains {
+			return false
+		}
+		if rollupOp.Type == mpipeline.GroupByRollupType && !contains {
+			return false
+		}
+	}
+	return true
+}
+
+func containsTag(tags [][]byte, tagName []byte) bool {
+	for _, tag := range tags {
+		if bytes.Equal(tag, tagName) {
+			return true
+		}
+	}
+	return false
+}
+
 func (as *activeRuleSet) applyIDToPipeline(
 	sortedTagPairBytes []byte,
 	pipeline mpipeline.Pipeline,
+	previousRollupOps []mpipeline.RollupOp, // rollup operations already applied to the ID
 	tagPairs []metricID.TagPair, // buffer for reuse across calls
 ) (applied.Pipeline, error) {
 	operations := make([]applied.OpUnion, 0, pipeline.Len())
@@ -460,15 +536,18 @@ func (as *activeRuleSet) applyIDToPipeline(
 			var matched bool
 			rollupID, matched := as.matchRollupTarget(
 				sortedTagPairBytes,
-				rollupOp.NewName,
-				rollupOp.Tags,
+				rollupOp,
 				tagPairs,
-				matchRollupTargetOptions{generateRollupID: true},
+				matchRollupTargetOptions{
+					generateRollupID:  true,
+					previousRollupOps: previousRollupOps,
+				},
 			)
 			if !matched {
 				err := fmt.Errorf("existing tag pairs %s do not contain all rollup tags %s", sortedTagPairBytes, rollupOp.Tags)
 				return applied.Pipeline{}, err
 			}
+			previousRollupOps = append(previousRollupOps, rollupOp)
 			opUnion = applied.OpUnion{
 				Type:   mpipeline.RollupOpType,
 				Rollup: applied.RollupOp{ID: rollupID, AggregationID: rollupOp.AggregationID},
@@ -557,8 +636,7 @@ func (as *activeRuleSet) reverseMappingsForRollupID(
 				}
 				if _, matched := as.matchRollupTarget(
 					sortedTagPairBytes,
-					rollupOp.NewName,
-					rollupOp.Tags,
+					rollupOp,
 					nil,
 					matchRollupTargetOptions{generateRollupID: false},
 				); !matched {
@@ -727,6 +805,9 @@ func (a int64Asc) Less(i, j int) bool { return a[i] < a[j] }
 
 type matchRollupTargetOptions struct {
 	generateRollupID bool
+	// previousRollupOps are the rollup operations preceding the rollup target
+	// in its pipeline, which determine the tags retained by exclude by rollups.
+	previousRollupOps []mpipeline.RollupOp
 }
 
 type ruleMatchResults struct {
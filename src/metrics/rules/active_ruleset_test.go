@@ -3135,6 +3135,147 @@ func TestActiveRuleSetReverseMatchWithRollupRulesForRollupID(t *testing.T) {
 	}
 }
 
+func TestActiveRuleSetForwardMatchWithExcludeByRollupRules(t *testing.T) {
+	as := newActiveRuleSet(
+		0,
+		nil,
+		testExcludeByRollupRules(t),
+		testTagsFilterOptions(),
+		mockNewID,
+		nil,
+	)
+	res := as.ForwardMatch(b("name=foo,rtagName1=rtagValue1,rtagName2=rtagValue2,rtagName3=rtagValue3"), 25000, 25001)
+	require.Equal(t, 2, res.NumNewRollupIDs())
+
+	// The first target excludes rtagName3 and the name tag is never retained.
+	rollup := res.ForNewRollupIDsAt(0, 0)
+	require.Equal(t, "rName5|rtagName1=rtagValue1,rtagName2=rtagValue2", string(rollup.ID))
+	require.Equal(t, 1, len(rollup.Metadatas))
+	require.Equal(t, 1, len(rollup.Metadatas[0].Pipelines))
+	require.True(t, rollup.Metadatas[0].Pipelines[0].Pipeline.IsEmpty())
+
+	// The second target excludes rtagName2 from the tags retained by the
+	// preceding group by rollup, rather than from the original tags.
+	rollup = res.ForNewRollupIDsAt(1, 0)
+	require.Equal(t, "rName6|rtagName1=rtagValue1,rtagName2=rtagValue2", string(rollup.ID))
+	require.Equal(t, 1, len(rollup.Metadatas))
+	require.Equal(t, 1, len(rollup.Metadatas[0].Pipelines))
+	expected := applied.NewPipeline([]applied.OpUnion{
+		{
+			Type: pipeline.RollupOpType,
+			Rollup: applied.RollupOp{
+				ID:            b("rName7|rtagName1=rtagValue1"),
+				AggregationID: aggregation.MustCompressTypes(aggregation.Sum),
+			},
+		},
+	})
+	require.True(t, expected.Equal(rollup.Metadatas[0].Pipelines[0].Pipeline))
+}
+
+func TestActiveRuleSetReverseMatchWithExcludeByRollupRulesForRollupID(t *testing.T) {
+	as := newActiveRuleSet(
+		0,
+		nil,
+		testExcludeByRollupRules(t),
+		testTagsFilterOptions(),
+		mockNewID,
+		func([]byte, []byte) bool { return true },
+	)
+	aggTypesOpts := aggregation.NewTypesOptions()
+
+	res := as.ReverseMatch(b("rName5|rtagName1=rtagValue1,rtagName2=rtagValue2"), 25000, 25001,
+		metric.CounterType, aggregation.Sum, false, aggTypesOpts)
+	expected := metadata.StagedMetadatas{
+		{
+			CutoverNanos: 10000,
+			Tombstoned:   false,
+			Metadata: metadata.Metadata{
+				Pipelines: []metadata.PipelineMetadata{
+					{
+						AggregationID: aggregation.MustCompressTypes(aggregation.Sum),
+						StoragePolicies: policy.StoragePolicies{
+							policy.NewStoragePolicy(10*time.Second, xtime.Second, 24*time.Hour),
+						},
+					},
+				},
+			},
+		},
+	}
+	require.Equal(t, expected, res.ForExistingIDAt(0))
+
+	// Rollup IDs containing an excluded tag were not produced by the rollup target.
+	res = as.ReverseMatch(b("rName5|rtagName1=rtagValue1,rtagName3=rtagValue3"), 25000, 25001,
+		metric.CounterType, aggregation.Sum, false, aggTypesOpts)
+	require.Nil(t, res.ForExistingIDAt(0))
+}
+
+func testExcludeByRollupRules(t *testing.T) []*rollupRule {
+	filter, err := filters.NewTagsFilter(
+		filters.TagFilterValueMap{
+			"rtagName1": filters.FilterValue{Pattern: "rtagValue1"},
+		},
+		filters.Conjunction,
+		testTagsFilterOptions(),
+	)
+	require.NoError(t, err)
+
+	return []*rollupRule{
+		&rollupRule{
+			uuid: "excludeByRollupRule",
+			snapshots: []*rollupRuleSnapshot{
+				&rollupRuleSnapshot{
+					name:         "excludeByRollupRule.snapshot1",
+					tombstoned:   false,
+					cutoverNanos: 10000,
+					filter:       filter,
+					targets: []rollupTarget{
+						{
+							Pipeline: pipeline.NewPipeline([]pipeline.OpUnion{
+								{
+									Type: pipeline.RollupOpType,
+									Rollup: pipeline.RollupOp{
+										Type:          pipeline.ExcludeByRollupType,
+										NewName:       b("rName5"),
+										Tags:          bs("rtagName3"),
+										AggregationID: aggregation.MustCompressTypes(aggregation.Sum),
+									},
+								},
+							}),
+							StoragePolicies: policy.StoragePolicies{
+								policy.NewStoragePolicy(10*time.Second, xtime.Second, 24*time.Hour),
+							},
+						},
+						{
+							Pipeline: pipeline.NewPipeline([]pipeline.OpUnion{
+								{
+									Type: pipeline.RollupOpType,
+									Rollup: pipeline.RollupOp{
+										NewName:       b("rName6"),
+										Tags:          bs("rtagName1", "rtagName2"),
+										AggregationID: aggregation.DefaultID,
+									},
+								},
+								{
+									Type: pipeline.RollupOpType,
+									Rollup: pipeline.RollupOp{
+										Type:          pipeline.ExcludeByRollupType,
+										NewName:       b("rName7"),
+										Tags:          bs("rtagName2"),
+										AggregationID: aggregation.MustCompressTypes(aggregation.Sum),
+									},
+								},
+							}),
+							StoragePolicies: policy.StoragePolicies{
+								policy.NewStoragePolicy(time.Minute, xtime.Minute, 48*time.Hour),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func testMappingRules(t *testing.T) []*mappingRule {
 	filter1, err := filters.NewTagsFilter(
 		filters.TagFilterValueMap{"mtagName1": filters.FilterValue{Pattern: "mtagValue1"}},
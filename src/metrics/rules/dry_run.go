@@ -0,0 +1,181 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"fmt"
+
+	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/metrics/generated/proto/rulepb"
+	"github.com/m3db/m3/src/metrics/metadata"
+	"github.com/m3db/m3/src/metrics/pipeline"
+	"github.com/m3db/m3/src/metrics/pipeline/applied"
+	"github.com/m3db/m3/src/metrics/rules/view"
+)
+
+// DryRunOptions provide the options for a ruleset dry run.
+type DryRunOptions struct {
+	// RuleSetOptions determine how metric IDs are parsed by rule filters
+	// and how new rollup IDs are generated.
+	RuleSetOptions Options
+
+	// TimeNanos is the time at which rules are matched.
+	TimeNanos int64
+
+	// IDStringFn returns the printable form of a metric ID, the ID is
+	// used as-is if not set.
+	IDStringFn func(id []byte) string
+}
+
+// NewRuleSetFromView creates a new mutable ruleset containing the rules of a
+// ruleset view, with every rule taking effect at the time of the update metadata.
+func NewRuleSetFromView(rsv view.RuleSet, meta UpdateMetadata) (MutableRuleSet, error) {
+	rs := NewEmptyRuleSet(rsv.Namespace, meta)
+	for _, mrv := range rsv.MappingRules {
+		if _, err := rs.AddMappingRule(mrv, meta); err != nil {
+			return nil, err
+		}
+	}
+	for _, rrv := range rsv.RollupRules {
+		if _, err := rs.AddRollupRule(rrv, meta); err != nil {
+			return nil, err
+		}
+	}
+	return rs, nil
+}
+
+// DryRun forward matches each metric ID against the rules active in a ruleset
+// before and after a proposed change, and returns both results alongside whether
+// they differ. A nil ruleset is treated as an empty ruleset.
+func DryRun(
+	before, after RuleSet,
+	ids [][]byte,
+	opts DryRunOptions,
+) (view.DryRunMatches, error) {
+	if opts.RuleSetOptions == nil {
+		opts.RuleSetOptions = NewOptions()
+	}
+	if opts.IDStringFn == nil {
+		opts.IDStringFn = func(id []byte) string { return string(id) }
+	}
+	beforeMatcher, err := newDryRunMatcher(before, opts)
+	if err != nil {
+		return view.DryRunMatches{}, err
+	}
+	afterMatcher, err := newDryRunMatcher(after, opts)
+	if err != nil {
+		return view.DryRunMatches{}, err
+	}
+
+	matches := make([]view.DryRunMatch, 0, len(ids))
+	for _, id := range ids {
+		var (
+			beforeMatch = newMetricMatch(beforeMatcher, id, opts)
+			afterMatch  = newMetricMatch(afterMatcher, id, opts)
+		)
+		matches = append(matches, view.DryRunMatch{
+			ID:      opts.IDStringFn(id),
+			Before:  beforeMatch,
+			After:   afterMatch,
+			Changed: !beforeMatch.Equal(afterMatch),
+		})
+	}
+	return view.DryRunMatches{Matches: matches}, nil
+}
+
+// newDryRunMatcher rebuilds the ruleset with the dry run ruleset options since
+// rulesets created from views or read with different options may not be able
+// to parse the metric IDs being matched.
+func newDryRunMatcher(rs RuleSet, opts DryRunOptions) (Matcher, error) {
+	var (
+		version = kv.UninitializedVersion
+		pb      = &rulepb.RuleSet{}
+	)
+	if rs != nil {
+		var err error
+		if pb, err = rs.Proto(); err != nil {
+			return nil, err
+		}
+		version = rs.Version()
+	}
+	matchable, err := NewRuleSetFromProto(version, pb, opts.RuleSetOptions)
+	if err != nil {
+		return nil, err
+	}
+	return matchable.ActiveSet(opts.TimeNanos), nil
+}
+
+func newMetricMatch(m Matcher, id []byte, opts DryRunOptions) view.MetricMatch {
+	var (
+		res   = m.ForwardMatch(id, opts.TimeNanos, opts.TimeNanos+1)
+		match view.MetricMatch
+	)
+	if existing := res.ForExistingIDAt(opts.TimeNanos); len(existing) > 0 && !existing[0].Tombstoned {
+		match.Dropped = existing[0].IsDropPolicyApplied()
+		match.Pipelines = newPipelineMatches(existing[0].Pipelines, opts)
+	}
+	for i := 0; i < res.NumNewRollupIDs(); i++ {
+		rollup := res.ForNewRollupIDsAt(i, opts.TimeNanos)
+		if len(rollup.Metadatas) == 0 || rollup.Metadatas[0].Tombstoned {
+			continue
+		}
+		match.Rollups = append(match.Rollups, view.RollupMatch{
+			ID:        opts.IDStringFn(rollup.ID),
+			Pipelines: newPipelineMatches(rollup.Metadatas[0].Pipelines, opts),
+		})
+	}
+	return match
+}
+
+func newPipelineMatches(
+	pipelines metadata.PipelineMetadatas,
+	opts DryRunOptions,
+) view.PipelineMatches {
+	var matches view.PipelineMatches
+	for _, p := range pipelines {
+		if p.IsDefault() {
+			continue
+		}
+		var ops []string
+		for i := 0; i < p.Pipeline.Len(); i++ {
+			ops = append(ops, pipelineOpString(p.Pipeline.At(i), opts))
+		}
+		matches = append(matches, view.PipelineMatch{
+			AggregationID:   p.AggregationID,
+			StoragePolicies: p.StoragePolicies,
+			DropPolicy:      p.DropPolicy,
+			Pipeline:        ops,
+		})
+	}
+	return matches
+}
+
+func pipelineOpString(op applied.OpUnion, opts DryRunOptions) string {
+	switch op.Type {
+	case pipeline.TransformationOpType:
+		return fmt.Sprintf("transformation(%s)", op.Transformation.String())
+	case pipeline.RollupOpType:
+		return fmt.Sprintf("rollup(%s, %s)", opts.IDStringFn(op.Rollup.ID),
+			op.Rollup.AggregationID.String())
+	default:
+		return op.String()
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/metrics/aggregation"
+	"github.com/m3db/m3/src/metrics/pipeline"
+	"github.com/m3db/m3/src/metrics/policy"
+	"github.com/m3db/m3/src/metrics/rules/view"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRun(t *testing.T) {
+	var (
+		nowNanos = time.Now().UnixNano()
+		meta     = NewRuleSetUpdateHelper(0).NewUpdateMetadata(nowNanos, testUser)
+		sp       = policy.NewStoragePolicy(time.Minute, xtime.Minute, 48*time.Hour)
+	)
+	before, err := NewRuleSetFromView(view.RuleSet{
+		Namespace: "ns",
+		MappingRules: []view.MappingRule{
+			{
+				Name:            "keep",
+				Filter:          "app:*",
+				StoragePolicies: policy.StoragePolicies{sp},
+			},
+		},
+	}, meta)
+	require.NoError(t, err)
+
+	after, err := NewRuleSetFromView(view.RuleSet{
+		Namespace: "ns",
+		MappingRules: []view.MappingRule{
+			{
+				Name:            "keep",
+				Filter:          "app:nginx",
+				StoragePolicies: policy.StoragePolicies{sp},
+			},
+			{
+				Name:       "drop",
+				Filter:     "app:debug",
+				DropPolicy: policy.DropMust,
+			},
+		},
+		RollupRules: []view.RollupRule{
+			{
+				Name:   "rollup",
+				Filter: "app:nginx",
+				Targets: []view.RollupTarget{
+					{
+						Pipeline: pipeline.NewPipeline([]pipeline.OpUnion{
+							{
+								Type: pipeline.RollupOpType,
+								Rollup: pipeline.RollupOp{
+									NewName:       b("rollup"),
+									Tags:          bs("app"),
+									AggregationID: aggregation.MustCompressTypes(aggregation.Sum),
+								},
+							},
+						}),
+						StoragePolicies: policy.StoragePolicies{sp},
+					},
+				},
+			},
+		},
+	}, meta)
+	require.NoError(t, err)
+
+	res, err := DryRun(before, after, bs(
+		"requests|app=nginx,host=a",
+		"requests|app=debug",
+		"requests|host=b",
+	), DryRunOptions{
+		RuleSetOptions: testRuleSetOptions(),
+		TimeNanos:      nowNanos,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, len(res.Matches))
+
+	nginx := res.Matches[0]
+	require.Equal(t, "requests|app=nginx,host=a", nginx.ID)
+	require.True(t, nginx.Changed)
+	require.Equal(t, view.PipelineMatches{{StoragePolicies: policy.StoragePolicies{sp}}},
+		nginx.Before.Pipelines)
+	require.Empty(t, nginx.Before.Rollups)
+	require.Equal(t, nginx.Before.Pipelines, nginx.After.Pipelines)
+	require.Equal(t, []view.RollupMatch{
+		{
+			ID: "rollup|app=nginx",
+			Pipelines: view.PipelineMatches{
+				{
+					AggregationID:   aggregation.MustCompressTypes(aggregation.Sum),
+					StoragePolicies: policy.StoragePolicies{sp},
+				},
+			},
+		},
+	}, nginx.After.Rollups)
+
+	debug := res.Matches[1]
+	require.True(t, debug.Changed)
+	require.False(t, debug.Before.Dropped)
+	require.True(t, debug.After.Dropped)
+	require.Equal(t, view.PipelineMatches{{DropPolicy: policy.DropMust}},
+		debug.After.Pipelines)
+
+	unmatched := res.Matches[2]
+	require.False(t, unmatched.Changed)
+	require.Empty(t, unmatched.Before.Pipelines)
+	require.Empty(t, unmatched.After.Pipelines)
+}
+
+func TestDryRunNilRuleSet(t *testing.T) {
+	var (
+		nowNanos = time.Now().UnixNano()
+		meta     = NewRuleSetUpdateHelper(0).NewUpdateMetadata(nowNanos, testUser)
+	)
+	after, err := NewRuleSetFromView(view.RuleSet{
+		Namespace: "ns",
+		MappingRules: []view.MappingRule{
+			{
+				Name:       "drop",
+				Filter:     "app:*",
+				DropPolicy: policy.DropMust,
+			},
+		},
+	}, meta)
+	require.NoError(t, err)
+
+	res, err := DryRun(nil, after, bs("requests|app=nginx"), DryRunOptions{
+		RuleSetOptions: testRuleSetOptions(),
+		TimeNanos:      nowNanos,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []view.DryRunMatch{
+		{
+			ID: "requests|app=nginx",
+			After: view.MetricMatch{
+				Dropped:   true,
+				Pipelines: view.PipelineMatches{{DropPolicy: policy.DropMust}},
+			},
+			Changed: true,
+		},
+	}, res.Matches)
+}
+
+func TestNewRuleSetFromViewInvalidRule(t *testing.T) {
+	meta := NewRuleSetUpdateHelper(0).NewUpdateMetadata(time.Now().UnixNano(), testUser)
+	_, err := NewRuleSetFromView(view.RuleSet{
+		Namespace: "ns",
+		MappingRules: []view.MappingRule{
+			{Name: "invalid", Filter: "tag1:abc[def"},
+		},
+	}, meta)
+	require.Error(t, err)
+}
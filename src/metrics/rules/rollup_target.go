@@ -59,9 +59,11 @@ func newRollupTargetFromV1Proto(pb *rulepb.RollupTarget) (rollupTarget, error) {
 	tags := make([]string, len(pb.Tags))
 	copy(tags, pb.Tags)
 	sort.Strings(tags)
+	// NB: v1 rollup targets only support group by rollups.
 	rollupOp := pipeline.OpUnion{
 		Type: pipeline.RollupOpType,
 		Rollup: pipeline.RollupOp{
+			Type:          pipeline.GroupByRollupType,
 			NewName:       []byte(pb.Name),
 			Tags:          bytes.ArraysFromStringArray(tags),
 			AggregationID: aggregationID,
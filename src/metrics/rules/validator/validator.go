@@ -42,6 +42,7 @@ var (
 	errMoreThanOneAggregationOpInPipeline = errors.New("more than one aggregation operation in pipeline")
 	errAggregationOpNotFirstInPipeline    = errors.New("aggregation operation is not the first operation in pipeline")
 	errNoRollupOpInPipeline               = errors.New("no rollup operation in pipeline")
+	errNoExcludeByRollupTags              = errors.New("no rollup tags to exclude")
 )
 
 type validator struct {
@@ -283,6 +284,8 @@ func (v *validator) validateStoragePolicies(
 // * The pipeline can contain arbitrary number of transformation operations. However,
 //   the transformation derivative order computed from the list of transformations must
 //   be no more than the maximum transformation derivative order that is supported.
+//   Transformations that output an additional datapoint, such as reset, must be the last
+//   operation in the pipeline.
 // * The pipeline must contain at least one rollup operation and at most `n` rollup operations,
 //   where `n` is the maximum supported number of rollup levels.
 func (v *validator) validatePipeline(pipeline mpipeline.Pipeline, types []metric.Type) error {
@@ -293,7 +296,7 @@ func (v *validator) validatePipeline(pipeline mpipeline.Pipeline, types []metric
 		numAggregationOps             int
 		transformationDerivativeOrder int
 		numRollupOps                  int
-		previousRollupTags            map[string]struct{}
+		previousRollupTags            *retainedRollupTags
 		numPipelineOps                = pipeline.Len()
 	)
 	for i := 0; i < numPipelineOps; i++ {
@@ -321,6 +324,9 @@ func (v *validator) validatePipeline(pipeline mpipeline.Pipeline, types []metric
 			if err := validateTransformationOp(transformOp); err != nil {
 				return fmt.Errorf("invalid transformation operation at index %d: %v", i, err)
 			}
+			if transformOp.Type.IsUnaryMultiOutputTransform() && i != numPipelineOps-1 {
+				return fmt.Errorf("transformation operation %v at index %d is not last in pipeline", transformOp, i)
+			}
 		case mpipeline.RollupOpType:
 			// We only care about the derivative order of transformation operations in between
 			// two consecutive rollup operations and as such we reset the derivative order when
@@ -333,10 +339,7 @@ func (v *validator) validatePipeline(pipeline mpipeline.Pipeline, types []metric
 			if err := v.validateRollupOp(pipelineOp.Rollup, i, types, previousRollupTags); err != nil {
 				return fmt.Errorf("invalid rollup operation at index %d: %v", i, err)
 			}
-			previousRollupTags = make(map[string]struct{}, len(pipelineOp.Rollup.Tags))
-			for _, tag := range pipelineOp.Rollup.Tags {
-				previousRollupTags[string(tag)] = struct{}{}
-			}
+			previousRollupTags = previousRollupTags.apply(pipelineOp.Rollup)
 		default:
 			return fmt.Errorf("operation at index %d has invalid type: %v", i, pipelineOp.Type)
 		}
@@ -362,22 +365,27 @@ func validateTransformationOp(transformationOp mpipeline.TransformationOp) error
 	if !transformationOp.Type.IsValid() {
 		return fmt.Errorf("invalid transformation type: %v", transformationOp.Type)
 	}
-	return nil
+	return transformationOp.Type.ValidateArgs(transformationOp.Args)
 }
 
 func (v *validator) validateRollupOp(
 	rollupOp mpipeline.RollupOp,
 	opIdxInPipeline int,
 	types []metric.Type,
-	previousRollupTags map[string]struct{},
+	previousRollupTags *retainedRollupTags,
 ) error {
+	// Validate that the rollup type is valid.
+	if !rollupOp.Type.IsValid() {
+		return fmt.Errorf("invalid rollup type: %v", rollupOp.Type)
+	}
+
 	// Validate that the rollup metric name is valid.
 	if err := v.validateRollupMetricName(rollupOp.NewName); err != nil {
 		return fmt.Errorf("invalid rollup metric name '%s': %v", rollupOp.NewName, err)
 	}
 
 	// Validate that the rollup tags are valid.
-	if err := v.validateRollupTags(rollupOp.Tags, previousRollupTags); err != nil {
+	if err := v.validateRollupTags(rollupOp.Type, rollupOp.Tags, previousRollupTags); err != nil {
 		return fmt.Errorf("invalid rollup tags %v: %v", rollupOp.Tags, err)
 	}
 
@@ -404,8 +412,9 @@ func (v *validator) validateRollupMetricName(metricName []byte) error {
 }
 
 func (v *validator) validateRollupTags(
+	rollupType mpipeline.RollupType,
 	tags [][]byte,
-	previousRollupTags map[string]struct{},
+	previousRollupTags *retainedRollupTags,
 ) error {
 	// Validating that all tag names have valid characters.
 	for _, tag := range tags {
@@ -424,18 +433,22 @@ func (v *validator) validateRollupTags(
 		rollupTags[tagStr] = struct{}{}
 	}
 
+	if rollupType == mpipeline.ExcludeByRollupType {
+		return v.validateExcludeByRollupTags(tags, rollupTags, previousRollupTags)
+	}
+
 	// Validate that the set of rollup tags are a strict subset of those in
 	// previous rollup operations.
 	// NB: `previousRollupTags` is nil for the first rollup operation.
 	if previousRollupTags != nil {
 		var numSeenTags int
 		for _, tag := range tags {
-			if _, exists := previousRollupTags[string(tag)]; !exists {
+			if !previousRollupTags.retains(string(tag)) {
 				return fmt.Errorf("tag %s not found in previous rollup operations", tag)
 			}
 			numSeenTags++
 		}
-		if numSeenTags == len(previousRollupTags) {
+		if numRetained, ok := previousRollupTags.numRetained(); ok && numSeenTags == numRetained {
 			return fmt.Errorf("same set of %d rollup tags in consecutive rollup operations", numSeenTags)
 		}
 	}
@@ -454,6 +467,38 @@ func (v *validator) validateRollupTags(
 	return nil
 }
 
+func (v *validator) validateExcludeByRollupTags(
+	tags [][]byte,
+	rollupTags map[string]struct{},
+	previousRollupTags *retainedRollupTags,
+) error {
+	// Validate that an exclude by rollup excludes at least one tag, since
+	// otherwise the rollup retains all tags.
+	if len(tags) == 0 {
+		return errNoExcludeByRollupTags
+	}
+
+	// Validate that the excluded tags are retained by previous rollup
+	// operations, since otherwise excluding them has no effect.
+	// NB: `previousRollupTags` is nil for the first rollup operation.
+	if previousRollupTags != nil {
+		for _, tag := range tags {
+			if !previousRollupTags.retains(string(tag)) {
+				return fmt.Errorf("tag %s not found in previous rollup operations", tag)
+			}
+		}
+	}
+
+	// Validating the list of excluded tags do not contain any required tags.
+	for _, requiredTag := range v.opts.RequiredRollupTags() {
+		if _, exists := rollupTags[requiredTag]; exists {
+			return fmt.Errorf("excluded required rollup tag: '%s'", requiredTag)
+		}
+	}
+
+	return nil
+}
+
 func validateNoDuplicateRollupIDIn(pipelines []mpipeline.Pipeline) error {
 	rollupOps := make([]mpipeline.RollupOp, 0, len(pipelines))
 	for _, pipeline := range pipelines {
@@ -475,6 +520,66 @@ func validateNoDuplicateRollupIDIn(pipelines []mpipeline.Pipeline) error {
 	return nil
 }
 
+// retainedRollupTags tracks the tags retained by the rollup operations in a pipeline.
+type retainedRollupTags struct {
+	// groupByTags are the tags of the last group by rollup operation, or nil
+	// if there is no group by rollup operation.
+	groupByTags map[string]struct{}
+	// excludedTags are the tags excluded after the last group by rollup operation.
+	excludedTags map[string]struct{}
+}
+
+// apply returns the tags retained after applying the rollup operation.
+// NB: `t` is nil before the first rollup operation.
+func (t *retainedRollupTags) apply(rollupOp mpipeline.RollupOp) *retainedRollupTags {
+	if rollupOp.Type != mpipeline.ExcludeByRollupType {
+		groupByTags := make(map[string]struct{}, len(rollupOp.Tags))
+		for _, tag := range rollupOp.Tags {
+			groupByTags[string(tag)] = struct{}{}
+		}
+		return &retainedRollupTags{groupByTags: groupByTags}
+	}
+
+	res := &retainedRollupTags{excludedTags: make(map[string]struct{}, len(rollupOp.Tags))}
+	if t != nil {
+		res.groupByTags = t.groupByTags
+		for tag := range t.excludedTags {
+			res.excludedTags[tag] = struct{}{}
+		}
+	}
+	for _, tag := range rollupOp.Tags {
+		res.excludedTags[string(tag)] = struct{}{}
+	}
+	return res
+}
+
+// retains returns true if the tag is retained by the rollup operations.
+func (t *retainedRollupTags) retains(tag string) bool {
+	if _, excluded := t.excludedTags[tag]; excluded {
+		return false
+	}
+	if t.groupByTags == nil {
+		return true
+	}
+	_, exists := t.groupByTags[tag]
+	return exists
+}
+
+// numRetained returns the number of tags retained by the rollup operations,
+// and false if it is unbounded because there is no group by rollup operation.
+func (t *retainedRollupTags) numRetained() (int, bool) {
+	if t.groupByTags == nil {
+		return 0, false
+	}
+	var n int
+	for tag := range t.groupByTags {
+		if _, excluded := t.excludedTags[tag]; !excluded {
+			n++
+		}
+	}
+	return n, true
+}
+
 func (v *validator) wrapError(err error) error {
 	if err == nil {
 		return nil
@@ -38,6 +38,7 @@ import (
 	"github.com/m3db/m3/src/metrics/rules/validator/namespace/kv"
 	"github.com/m3db/m3/src/metrics/rules/view"
 	"github.com/m3db/m3/src/metrics/transformation"
+	xbytes "github.com/m3db/m3/src/metrics/x/bytes"
 
 	"github.com/fortytw2/leaktest"
 	"github.com/stretchr/testify/assert"
@@ -145,6 +146,19 @@ func TestValidatorValidateMappingRuleInvalidFilterExpr(t *testing.T) {
 	require.Error(t, validator.ValidateSnapshot(view))
 }
 
+func TestValidatorValidateMappingRuleInvalidFilterRegexp(t *testing.T) {
+	view := view.RuleSet{
+		MappingRules: []view.MappingRule{
+			{
+				Name:   "snapshot1",
+				Filter: "randomTag:re:api-[0-9a-f",
+			},
+		},
+	}
+	validator := NewValidator(testValidatorOptions())
+	require.Error(t, validator.ValidateSnapshot(view))
+}
+
 func TestValidatorValidateMappingRuleInvalidFilterTagName(t *testing.T) {
 	invalidChars := []rune{'$'}
 	view := view.RuleSet{
@@ -338,6 +352,21 @@ func TestValidatorValidateMappingRule(t *testing.T) {
 	require.NoError(t, validator.ValidateSnapshot(view))
 }
 
+func TestValidatorValidateMappingRuleFilterRegexp(t *testing.T) {
+	view := view.RuleSet{
+		MappingRules: []view.MappingRule{
+			{
+				Name:            "snapshot1",
+				Filter:          "randomTag:re:api-[0-9a-f]{8}-.* " + testTypeTag + ":" + testCounterType,
+				StoragePolicies: testStoragePolicies(),
+			},
+		},
+	}
+
+	validator := NewValidator(testValidatorOptions())
+	require.NoError(t, validator.ValidateSnapshot(view))
+}
+
 func TestValidatorValidateDuplicateRollupRules(t *testing.T) {
 	view := view.RuleSet{
 		RollupRules: []view.RollupRule{
@@ -722,6 +751,115 @@ func TestValidatorValidateRollupRulePipelineInvalidTransformationType(t *testing
 	require.True(t, strings.Contains(err.Error(), "invalid transformation operation at index 0"))
 }
 
+func TestValidatorValidateRollupRulePipelineInvalidTransformationArgs(t *testing.T) {
+	view := view.RuleSet{
+		RollupRules: []view.RollupRule{
+			{
+				Name:   "snapshot1",
+				Filter: testTypeTag + ":" + testCounterType,
+				Targets: []view.RollupTarget{
+					{
+						Pipeline: pipeline.NewPipeline([]pipeline.OpUnion{
+							{
+								Type:           pipeline.TransformationOpType,
+								Transformation: pipeline.TransformationOp{Type: transformation.Clamp, Args: []float64{100, 0}},
+							},
+							{
+								Type: pipeline.RollupOpType,
+								Rollup: pipeline.RollupOp{
+									NewName:       []byte("rName1"),
+									Tags:          [][]byte{[]byte("rtagName1"), []byte("rtagName2")},
+									AggregationID: aggregation.DefaultID,
+								},
+							},
+						}),
+						StoragePolicies: testStoragePolicies(),
+					},
+				},
+			},
+		},
+	}
+	validator := NewValidator(testValidatorOptions())
+	err := validator.ValidateSnapshot(view)
+	require.Error(t, err)
+	require.True(t, strings.Contains(err.Error(), "invalid transformation operation at index 0"))
+}
+
+func TestValidatorValidateRollupRulePipelineParameterizedTransformations(t *testing.T) {
+	view := view.RuleSet{
+		RollupRules: []view.RollupRule{
+			{
+				Name:   "snapshot1",
+				Filter: testTypeTag + ":" + testCounterType,
+				Targets: []view.RollupTarget{
+					{
+						Pipeline: pipeline.NewPipeline([]pipeline.OpUnion{
+							{
+								Type:           pipeline.TransformationOpType,
+								Transformation: pipeline.TransformationOp{Type: transformation.Scale, Args: []float64{0.001}},
+							},
+							{
+								Type:           pipeline.TransformationOpType,
+								Transformation: pipeline.TransformationOp{Type: transformation.Clamp, Args: []float64{0, 100}},
+							},
+							{
+								Type: pipeline.RollupOpType,
+								Rollup: pipeline.RollupOp{
+									NewName:       []byte("rName1"),
+									Tags:          [][]byte{[]byte("rtagName1"), []byte("rtagName2")},
+									AggregationID: aggregation.DefaultID,
+								},
+							},
+							{
+								Type:           pipeline.TransformationOpType,
+								Transformation: pipeline.TransformationOp{Type: transformation.Reset},
+							},
+						}),
+						StoragePolicies: testStoragePolicies(),
+					},
+				},
+			},
+		},
+	}
+	validator := NewValidator(testValidatorOptions())
+	err := validator.ValidateSnapshot(view)
+	require.NoError(t, err)
+}
+
+func TestValidatorValidateRollupRulePipelineResetTransformationNotLast(t *testing.T) {
+	view := view.RuleSet{
+		RollupRules: []view.RollupRule{
+			{
+				Name:   "snapshot1",
+				Filter: testTypeTag + ":" + testCounterType,
+				Targets: []view.RollupTarget{
+					{
+						Pipeline: pipeline.NewPipeline([]pipeline.OpUnion{
+							{
+								Type:           pipeline.TransformationOpType,
+								Transformation: pipeline.TransformationOp{Type: transformation.Reset},
+							},
+							{
+								Type: pipeline.RollupOpType,
+								Rollup: pipeline.RollupOp{
+									NewName:       []byte("rName1"),
+									Tags:          [][]byte{[]byte("rtagName1"), []byte("rtagName2")},
+									AggregationID: aggregation.DefaultID,
+								},
+							},
+						}),
+						StoragePolicies: testStoragePolicies(),
+					},
+				},
+			},
+		},
+	}
+	validator := NewValidator(testValidatorOptions())
+	err := validator.ValidateSnapshot(view)
+	require.Error(t, err)
+	require.True(t, strings.Contains(err.Error(), "transformation operation Reset at index 0 is not last in pipeline"))
+}
+
 func TestValidatorValidateRollupRulePipelineNoRollupOp(t *testing.T) {
 	view := view.RuleSet{
 		RollupRules: []view.RollupRule{
@@ -906,6 +1044,120 @@ func TestValidatorValidateRollupRulePipelineMultiLevelRollup(t *testing.T) {
 	require.NoError(t, validator.ValidateSnapshot(view))
 }
 
+func TestValidatorValidateRollupRulePipelineExcludeByRollup(t *testing.T) {
+	groupBy := func(name string, tags ...string) pipeline.OpUnion {
+		return pipeline.OpUnion{
+			Type: pipeline.RollupOpType,
+			Rollup: pipeline.RollupOp{
+				NewName:       []byte(name),
+				Tags:          xbytes.ArraysFromStringArray(tags),
+				AggregationID: aggregation.DefaultID,
+			},
+		}
+	}
+	excludeBy := func(name string, tags ...string) pipeline.OpUnion {
+		op := groupBy(name, tags...)
+		op.Rollup.Type = pipeline.ExcludeByRollupType
+		return op
+	}
+	inputs := []struct {
+		ops []pipeline.OpUnion
+		err string
+	}{
+		{
+			ops: []pipeline.OpUnion{excludeBy("rName1", "rtagName1")},
+		},
+		{
+			ops: []pipeline.OpUnion{excludeBy("rName1")},
+			err: "no rollup tags to exclude",
+		},
+		{
+			ops: []pipeline.OpUnion{excludeBy("rName1", "rtagName1", "rtagName1")},
+			err: "duplicate rollup tag: 'rtagName1'",
+		},
+		{
+			ops: []pipeline.OpUnion{excludeBy("rName1", "requiredTag")},
+			err: "excluded required rollup tag: 'requiredTag'",
+		},
+		{
+			ops: []pipeline.OpUnion{
+				groupBy("rName1", "requiredTag", "rtagName1", "rtagName2"),
+				excludeBy("rName2", "rtagName2"),
+				groupBy("rName3", "requiredTag"),
+			},
+		},
+		{
+			ops: []pipeline.OpUnion{
+				groupBy("rName1", "requiredTag", "rtagName1"),
+				excludeBy("rName2", "rtagName2"),
+			},
+			err: "tag rtagName2 not found in previous rollup operations",
+		},
+		{
+			ops: []pipeline.OpUnion{
+				excludeBy("rName1", "rtagName1"),
+				excludeBy("rName2", "rtagName1"),
+			},
+			err: "tag rtagName1 not found in previous rollup operations",
+		},
+		{
+			ops: []pipeline.OpUnion{
+				excludeBy("rName1", "rtagName2"),
+				groupBy("rName2", "requiredTag", "rtagName2"),
+			},
+			err: "tag rtagName2 not found in previous rollup operations",
+		},
+		{
+			ops: []pipeline.OpUnion{
+				groupBy("rName1", "requiredTag", "rtagName1", "rtagName2"),
+				excludeBy("rName2", "rtagName2"),
+				groupBy("rName3", "requiredTag", "rtagName1"),
+			},
+			err: "same set of 2 rollup tags in consecutive rollup operations",
+		},
+		{
+			ops: []pipeline.OpUnion{
+				{
+					Type: pipeline.RollupOpType,
+					Rollup: pipeline.RollupOp{
+						Type:          pipeline.RollupType(100),
+						NewName:       []byte("rName1"),
+						AggregationID: aggregation.DefaultID,
+					},
+				},
+			},
+			err: "invalid rollup type",
+		},
+	}
+
+	for _, input := range inputs {
+		view := view.RuleSet{
+			RollupRules: []view.RollupRule{
+				{
+					Name:   "snapshot1",
+					Filter: testTypeTag + ":" + testCounterType,
+					Targets: []view.RollupTarget{
+						{
+							Pipeline:        pipeline.NewPipeline(input.ops),
+							StoragePolicies: testStoragePolicies(),
+						},
+					},
+				},
+			},
+		}
+		validator := NewValidator(testValidatorOptions().
+			SetMaxRollupLevels(3).
+			SetRequiredRollupTags([]string{"requiredTag"}))
+		err := validator.ValidateSnapshot(view)
+		if input.err == "" {
+			require.NoError(t, err)
+			continue
+		}
+		require.Error(t, err)
+		require.True(t, strings.Contains(err.Error(), input.err), err.Error())
+	}
+}
+
 func TestValidatorValidateRollupRuleRollupOpDuplicateRollupTag(t *testing.T) {
 	view := view.RuleSet{
 		RollupRules: []view.RollupRule{
@@ -0,0 +1,114 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package view
+
+import (
+	"github.com/m3db/m3/src/metrics/aggregation"
+	"github.com/m3db/m3/src/metrics/policy"
+)
+
+// PipelineMatch is a single pipeline a metric is aggregated through as a
+// result of matching a ruleset.
+type PipelineMatch struct {
+	AggregationID   aggregation.ID         `json:"aggregation"`
+	StoragePolicies policy.StoragePolicies `json:"storagePolicies"`
+	DropPolicy      policy.DropPolicy      `json:"dropPolicy"`
+	Pipeline        []string               `json:"pipeline,omitempty"`
+}
+
+// Equal determines whether two pipeline matches are equal.
+func (m PipelineMatch) Equal(other PipelineMatch) bool {
+	if !m.AggregationID.Equal(other.AggregationID) ||
+		!m.StoragePolicies.Equal(other.StoragePolicies) ||
+		m.DropPolicy != other.DropPolicy ||
+		len(m.Pipeline) != len(other.Pipeline) {
+		return false
+	}
+	for i := range m.Pipeline {
+		if m.Pipeline[i] != other.Pipeline[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// PipelineMatches is a list of pipeline matches.
+type PipelineMatches []PipelineMatch
+
+// Equal determines whether two lists of pipeline matches are equal.
+func (m PipelineMatches) Equal(other PipelineMatches) bool {
+	if len(m) != len(other) {
+		return false
+	}
+	for i := range m {
+		if !m[i].Equal(other[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// RollupMatch is a new rollup metric produced as a result of matching a ruleset.
+type RollupMatch struct {
+	ID        string          `json:"id"`
+	Pipelines PipelineMatches `json:"pipelines"`
+}
+
+// Equal determines whether two rollup matches are equal.
+func (m RollupMatch) Equal(other RollupMatch) bool {
+	return m.ID == other.ID && m.Pipelines.Equal(other.Pipelines)
+}
+
+// MetricMatch is the result of matching a metric ID against a ruleset.
+type MetricMatch struct {
+	Dropped   bool            `json:"dropped"`
+	Pipelines PipelineMatches `json:"pipelines"`
+	Rollups   []RollupMatch   `json:"rollups"`
+}
+
+// Equal determines whether two metric matches are equal.
+func (m MetricMatch) Equal(other MetricMatch) bool {
+	if m.Dropped != other.Dropped ||
+		!m.Pipelines.Equal(other.Pipelines) ||
+		len(m.Rollups) != len(other.Rollups) {
+		return false
+	}
+	for i := range m.Rollups {
+		if !m.Rollups[i].Equal(other.Rollups[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// DryRunMatch is the result of matching a metric ID against a ruleset
+// before and after a proposed change.
+type DryRunMatch struct {
+	ID      string      `json:"id"`
+	Before  MetricMatch `json:"before"`
+	After   MetricMatch `json:"after"`
+	Changed bool        `json:"changed"`
+}
+
+// DryRunMatches is the result of a ruleset dry run.
+type DryRunMatches struct {
+	Matches []DryRunMatch `json:"matches"`
+}
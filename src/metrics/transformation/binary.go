@@ -34,6 +34,9 @@ var (
 	// taking reference to it each time when converting to iface).
 	transformPerSecondFn = BinaryTransformFn(perSecond)
 	transformIncreaseFn  = BinaryTransformFn(increase)
+
+	transformDeltaFn              = BinaryTransformFn(delta)
+	transformPerSecondWithResetFn = BinaryTransformFn(perSecondWithReset)
 )
 
 func transformPerSecond() BinaryTransform {
@@ -78,3 +81,43 @@ func increase(prev, curr Datapoint) Datapoint {
 	}
 	return Datapoint{TimeNanos: curr.TimeNanos, Value: diff}
 }
+
+func transformDelta() BinaryTransform {
+	return transformDeltaFn
+}
+
+// delta computes the difference between consecutive datapoints, unlike increase
+// it does not treat a decrease in value as a reset and may return negative values.
+// Note:
+// * It skips NaN values.
+// * It assumes the timestamps are monotonically increasing. If not, an empty
+//   datapoint is returned.
+func delta(prev, curr Datapoint) Datapoint {
+	if prev.TimeNanos >= curr.TimeNanos || math.IsNaN(prev.Value) || math.IsNaN(curr.Value) {
+		return emptyDatapoint
+	}
+	return Datapoint{TimeNanos: curr.TimeNanos, Value: curr.Value - prev.Value}
+}
+
+func transformPerSecondWithReset() BinaryTransform {
+	return transformPerSecondWithResetFn
+}
+
+// perSecondWithReset computes the derivative between consecutive datapoints like
+// perSecond, except a decrease in value is treated as a counter reset, in which
+// case the current value is the increase since the reset.
+// Note:
+// * It skips NaN values.
+// * It assumes the timestamps are monotonically increasing. If not, an empty
+//   datapoint is returned.
+func perSecondWithReset(prev, curr Datapoint) Datapoint {
+	if prev.TimeNanos >= curr.TimeNanos || math.IsNaN(prev.Value) || math.IsNaN(curr.Value) {
+		return emptyDatapoint
+	}
+	diff := curr.Value - prev.Value
+	if diff < 0 {
+		diff = curr.Value
+	}
+	rate := diff * float64(nanosPerSecond) / float64(curr.TimeNanos-prev.TimeNanos)
+	return Datapoint{TimeNanos: curr.TimeNanos, Value: rate}
+}
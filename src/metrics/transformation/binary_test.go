@@ -79,3 +79,79 @@ func TestPerSecond(t *testing.T) {
 		}
 	}
 }
+
+func TestDelta(t *testing.T) {
+	inputs := []struct {
+		prev        Datapoint
+		curr        Datapoint
+		expectedNaN bool
+		expected    Datapoint
+	}{
+		{
+			prev:     Datapoint{TimeNanos: time.Unix(1230, 0).UnixNano(), Value: 25},
+			curr:     Datapoint{TimeNanos: time.Unix(1240, 0).UnixNano(), Value: 30},
+			expected: Datapoint{TimeNanos: time.Unix(1240, 0).UnixNano(), Value: 5},
+		},
+		{
+			prev:     Datapoint{TimeNanos: time.Unix(1230, 0).UnixNano(), Value: 30},
+			curr:     Datapoint{TimeNanos: time.Unix(1240, 0).UnixNano(), Value: 20},
+			expected: Datapoint{TimeNanos: time.Unix(1240, 0).UnixNano(), Value: -10},
+		},
+		{
+			prev:        Datapoint{TimeNanos: time.Unix(1230, 0).UnixNano(), Value: 25},
+			curr:        Datapoint{TimeNanos: time.Unix(1230, 0).UnixNano(), Value: 20},
+			expectedNaN: true,
+		},
+		{
+			prev:        Datapoint{TimeNanos: time.Unix(1230, 0).UnixNano(), Value: math.NaN()},
+			curr:        Datapoint{TimeNanos: time.Unix(1240, 0).UnixNano(), Value: 20},
+			expectedNaN: true,
+		},
+	}
+
+	for _, input := range inputs {
+		if input.expectedNaN {
+			require.True(t, delta(input.prev, input.curr).IsEmpty())
+		} else {
+			require.Equal(t, input.expected, delta(input.prev, input.curr))
+		}
+	}
+}
+
+func TestPerSecondWithReset(t *testing.T) {
+	inputs := []struct {
+		prev        Datapoint
+		curr        Datapoint
+		expectedNaN bool
+		expected    Datapoint
+	}{
+		{
+			prev:     Datapoint{TimeNanos: time.Unix(1230, 0).UnixNano(), Value: 25},
+			curr:     Datapoint{TimeNanos: time.Unix(1240, 0).UnixNano(), Value: 30},
+			expected: Datapoint{TimeNanos: time.Unix(1240, 0).UnixNano(), Value: 0.5},
+		},
+		{
+			prev:     Datapoint{TimeNanos: time.Unix(1230, 0).UnixNano(), Value: 30},
+			curr:     Datapoint{TimeNanos: time.Unix(1240, 0).UnixNano(), Value: 20},
+			expected: Datapoint{TimeNanos: time.Unix(1240, 0).UnixNano(), Value: 2},
+		},
+		{
+			prev:        Datapoint{TimeNanos: time.Unix(1230, 0).UnixNano(), Value: 25},
+			curr:        Datapoint{TimeNanos: time.Unix(1230, 0).UnixNano(), Value: 30},
+			expectedNaN: true,
+		},
+		{
+			prev:        Datapoint{TimeNanos: time.Unix(1230, 0).UnixNano(), Value: 20},
+			curr:        Datapoint{TimeNanos: time.Unix(1240, 0).UnixNano(), Value: math.NaN()},
+			expectedNaN: true,
+		},
+	}
+
+	for _, input := range inputs {
+		if input.expectedNaN {
+			require.True(t, perSecondWithReset(input.prev, input.curr).IsEmpty())
+		} else {
+			require.Equal(t, input.expected, perSecondWithReset(input.prev, input.curr))
+		}
+	}
+}
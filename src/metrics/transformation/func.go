@@ -20,7 +20,10 @@
 
 package transformation
 
-import "math"
+import (
+	"math"
+	"time"
+)
 
 var (
 	emptyDatapoint = Datapoint{Value: math.NaN()}
@@ -66,3 +69,18 @@ type BinaryTransformFn func(prev, curr Datapoint) Datapoint
 func (fn BinaryTransformFn) Evaluate(prev, curr Datapoint) Datapoint {
 	return fn(prev, curr)
 }
+
+// UnaryMultiOutputTransform is a unary transformation that takes a single
+// datapoint as input and transforms it into a datapoint as output, along
+// with an additional datapoint computed from the resolution of the input.
+type UnaryMultiOutputTransform interface {
+	Evaluate(dp Datapoint, resolution time.Duration) (Datapoint, Datapoint)
+}
+
+// UnaryMultiOutputTransformFn implements UnaryMultiOutputTransform as a function.
+type UnaryMultiOutputTransformFn func(dp Datapoint, resolution time.Duration) (Datapoint, Datapoint)
+
+// Evaluate implements UnaryMultiOutputTransform as a function.
+func (fn UnaryMultiOutputTransformFn) Evaluate(dp Datapoint, resolution time.Duration) (Datapoint, Datapoint) {
+	return fn(dp, resolution)
+}
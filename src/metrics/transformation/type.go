@@ -23,6 +23,7 @@ package transformation
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/m3db/m3/src/metrics/generated/proto/transformationpb"
 )
@@ -37,16 +38,24 @@ const (
 	PerSecond
 	Increase
 	Add
+	Delta
+	PerSecondWithReset
+	Reset
+	Scale
+	Clamp
 )
 
 // IsValid checks if the transformation type is valid.
 func (t Type) IsValid() bool {
-	return t.IsUnaryTransform() || t.IsBinaryTransform()
+	return t.IsUnaryTransform() || t.IsBinaryTransform() || t.IsUnaryMultiOutputTransform()
 }
 
 // IsUnaryTransform returns whether this is a unary transformation.
 func (t Type) IsUnaryTransform() bool {
-	_, exists := unaryTransforms[t]
+	if _, exists := unaryTransforms[t]; exists {
+		return true
+	}
+	_, exists := parameterizedUnaryTransforms[t]
 	return exists
 }
 
@@ -56,19 +65,53 @@ func (t Type) IsBinaryTransform() bool {
 	return exists
 }
 
+// IsUnaryMultiOutputTransform returns whether this is a unary transformation
+// that outputs an additional datapoint.
+func (t Type) IsUnaryMultiOutputTransform() bool {
+	_, exists := unaryMultiOutputTransforms[t]
+	return exists
+}
+
+// NumArgs returns the number of arguments taken by the transformation type.
+func (t Type) NumArgs() int {
+	return typeNumArgs[t]
+}
+
+// ValidateArgs checks if the arguments are valid for the transformation type.
+func (t Type) ValidateArgs(args []float64) error {
+	if len(args) != t.NumArgs() {
+		return fmt.Errorf("%v transformation takes %d arguments, %d given", t, t.NumArgs(), len(args))
+	}
+	for _, arg := range args {
+		if math.IsNaN(arg) {
+			return fmt.Errorf("%v transformation argument is not a number", t)
+		}
+	}
+	if t == Clamp && args[0] > args[1] {
+		return fmt.Errorf("%v transformation min %v is larger than max %v", t, args[0], args[1])
+	}
+	return nil
+}
+
 // NewOp returns a constructed operation that is allocated once and can be
-// reused.
-func (t Type) NewOp() (Op, error) {
+// reused. Parameterized transformations take their arguments in order.
+func (t Type) NewOp(args ...float64) (Op, error) {
+	if err := t.ValidateArgs(args); err != nil {
+		return Op{}, err
+	}
 	var (
-		err    error
-		unary  UnaryTransform
-		binary BinaryTransform
+		err        error
+		unary      UnaryTransform
+		binary     BinaryTransform
+		unaryMulti UnaryMultiOutputTransform
 	)
 	switch {
 	case t.IsUnaryTransform():
-		unary, err = t.UnaryTransform()
+		unary, err = t.UnaryTransform(args...)
 	case t.IsBinaryTransform():
 		binary, err = t.BinaryTransform()
+	case t.IsUnaryMultiOutputTransform():
+		unaryMulti, err = t.UnaryMultiOutputTransform()
 	default:
 		err = fmt.Errorf("unknown transformation type: %v", t)
 	}
@@ -76,26 +119,35 @@ func (t Type) NewOp() (Op, error) {
 		return Op{}, err
 	}
 	return Op{
-		opType: t,
-		unary:  unary,
-		binary: binary,
+		opType:     t,
+		unary:      unary,
+		binary:     binary,
+		unaryMulti: unaryMulti,
 	}, nil
 }
 
 // UnaryTransform returns the unary transformation function associated with
-// the transformation type if applicable, or an error otherwise.
-func (t Type) UnaryTransform() (UnaryTransform, error) {
-	tf, exists := unaryTransforms[t]
-	if !exists {
-		return nil, fmt.Errorf("%v is not a unary transfomration", t)
+// the transformation type and arguments if applicable, or an error otherwise.
+func (t Type) UnaryTransform(args ...float64) (UnaryTransform, error) {
+	if tf, exists := unaryTransforms[t]; exists {
+		if err := t.ValidateArgs(args); err != nil {
+			return nil, err
+		}
+		return tf(), nil
 	}
-	return tf(), nil
+	if tf, exists := parameterizedUnaryTransforms[t]; exists {
+		if err := t.ValidateArgs(args); err != nil {
+			return nil, err
+		}
+		return tf(args), nil
+	}
+	return nil, fmt.Errorf("%v is not a unary transfomration", t)
 }
 
 // MustUnaryTransform returns the unary transformation function associated with
-// the transformation type if applicable, or panics otherwise.
-func (t Type) MustUnaryTransform() UnaryTransform {
-	tf, err := t.UnaryTransform()
+// the transformation type and arguments if applicable, or panics otherwise.
+func (t Type) MustUnaryTransform(args ...float64) UnaryTransform {
+	tf, err := t.UnaryTransform(args...)
 	if err != nil {
 		panic(err)
 	}
@@ -122,6 +174,16 @@ func (t Type) MustBinaryTransform() BinaryTransform {
 	return tf
 }
 
+// UnaryMultiOutputTransform returns the unary multi output transformation function
+// associated with the transformation type if applicable, or an error otherwise.
+func (t Type) UnaryMultiOutputTransform() (UnaryMultiOutputTransform, error) {
+	tf, exists := unaryMultiOutputTransforms[t]
+	if !exists {
+		return nil, fmt.Errorf("%v is not a unary multi output transfomration", t)
+	}
+	return tf(), nil
+}
+
 // ToProto converts the transformation type to a protobuf message in place.
 func (t Type) ToProto(pb *transformationpb.TransformationType) error {
 	switch t {
@@ -133,6 +195,16 @@ func (t Type) ToProto(pb *transformationpb.TransformationType) error {
 		*pb = transformationpb.TransformationType_INCREASE
 	case Add:
 		*pb = transformationpb.TransformationType_ADD
+	case Delta:
+		*pb = transformationpb.TransformationType_DELTA
+	case PerSecondWithReset:
+		*pb = transformationpb.TransformationType_PERSECOND_WITH_RESET
+	case Reset:
+		*pb = transformationpb.TransformationType_RESET
+	case Scale:
+		*pb = transformationpb.TransformationType_SCALE
+	case Clamp:
+		*pb = transformationpb.TransformationType_CLAMP
 	default:
 		return fmt.Errorf("unknown transformation type: %v", t)
 	}
@@ -150,6 +222,16 @@ func (t *Type) FromProto(pb transformationpb.TransformationType) error {
 		*t = Increase
 	case transformationpb.TransformationType_ADD:
 		*t = Add
+	case transformationpb.TransformationType_DELTA:
+		*t = Delta
+	case transformationpb.TransformationType_PERSECOND_WITH_RESET:
+		*t = PerSecondWithReset
+	case transformationpb.TransformationType_RESET:
+		*t = Reset
+	case transformationpb.TransformationType_SCALE:
+		*t = Scale
+	case transformationpb.TransformationType_CLAMP:
+		*t = Clamp
 	default:
 		return fmt.Errorf("unknown transformation type in proto: %v", pb)
 	}
@@ -201,9 +283,10 @@ func ParseType(str string) (Type, error) {
 type Op struct {
 	opType Type
 
-	// might have either unary or binary
-	unary  UnaryTransform
-	binary BinaryTransform
+	// might have either unary, binary or unary multi output
+	unary      UnaryTransform
+	binary     BinaryTransform
+	unaryMulti UnaryMultiOutputTransform
 }
 
 // Type returns the op type.
@@ -227,14 +310,36 @@ func (o Op) BinaryTransform() (BinaryTransform, bool) {
 	return o.binary, true
 }
 
+// UnaryMultiOutputTransform returns the active unary multi output transform
+// if op is unary multi output transform.
+func (o Op) UnaryMultiOutputTransform() (UnaryMultiOutputTransform, bool) {
+	if !o.Type().IsUnaryMultiOutputTransform() {
+		return nil, false
+	}
+	return o.unaryMulti, true
+}
+
 var (
 	unaryTransforms = map[Type]func() UnaryTransform{
 		Absolute: transformAbsolute,
 		Add:      transformAdd,
 	}
+	parameterizedUnaryTransforms = map[Type]func(args []float64) UnaryTransform{
+		Scale: transformScale,
+		Clamp: transformClamp,
+	}
 	binaryTransforms = map[Type]func() BinaryTransform{
-		PerSecond: transformPerSecond,
-		Increase:  transformIncrease,
+		PerSecond:          transformPerSecond,
+		Increase:           transformIncrease,
+		Delta:              transformDelta,
+		PerSecondWithReset: transformPerSecondWithReset,
+	}
+	unaryMultiOutputTransforms = map[Type]func() UnaryMultiOutputTransform{
+		Reset: transformReset,
+	}
+	typeNumArgs = map[Type]int{
+		Scale: 1,
+		Clamp: 2,
 	}
 	typeStringMap map[string]Type
 )
@@ -244,7 +349,13 @@ func init() {
 	for t := range unaryTransforms {
 		typeStringMap[t.String()] = t
 	}
+	for t := range parameterizedUnaryTransforms {
+		typeStringMap[t.String()] = t
+	}
 	for t := range binaryTransforms {
 		typeStringMap[t.String()] = t
 	}
+	for t := range unaryMultiOutputTransforms {
+		typeStringMap[t.String()] = t
+	}
 }
@@ -33,11 +33,16 @@ func _() {
 	_ = x[PerSecond-2]
 	_ = x[Increase-3]
 	_ = x[Add-4]
+	_ = x[Delta-5]
+	_ = x[PerSecondWithReset-6]
+	_ = x[Reset-7]
+	_ = x[Scale-8]
+	_ = x[Clamp-9]
 }
 
-const _Type_name = "UnknownTypeAbsolutePerSecondIncreaseAdd"
+const _Type_name = "UnknownTypeAbsolutePerSecondIncreaseAddDeltaPerSecondWithResetResetScaleClamp"
 
-var _Type_index = [...]uint8{0, 11, 19, 28, 36, 39}
+var _Type_index = [...]uint8{0, 11, 19, 28, 36, 39, 44, 62, 67, 72, 77}
 
 func (i Type) String() string {
 	if i < 0 || i >= Type(len(_Type_index)-1) {
@@ -22,6 +22,7 @@ package transformation
 
 import (
 	"encoding/json"
+	"math"
 	"testing"
 
 	"github.com/m3db/m3/src/metrics/generated/proto/transformationpb"
@@ -174,6 +175,112 @@ func TestMustBinaryTransformPanics(t *testing.T) {
 	}
 }
 
+func TestIsUnaryMultiOutputTransform(t *testing.T) {
+	inputs := []struct {
+		typ      Type
+		expected bool
+	}{
+		{typ: Reset, expected: true},
+		{typ: Absolute, expected: false},
+		{typ: PerSecond, expected: false},
+		{typ: Type(10000), expected: false},
+	}
+
+	for _, input := range inputs {
+		require.Equal(t, input.expected, input.typ.IsUnaryMultiOutputTransform())
+	}
+}
+
+func TestUnaryTransformWithArgs(t *testing.T) {
+	tf, err := Scale.UnaryTransform(2)
+	require.NoError(t, err)
+	require.Equal(t, Datapoint{Value: 6}, tf.Evaluate(Datapoint{Value: 3}))
+
+	tf, err = Clamp.UnaryTransform(0, 1)
+	require.NoError(t, err)
+	require.Equal(t, Datapoint{Value: 1}, tf.Evaluate(Datapoint{Value: 3}))
+
+	_, err = Scale.UnaryTransform()
+	require.Error(t, err)
+	_, err = Absolute.UnaryTransform(2)
+	require.Error(t, err)
+	require.Panics(t, func() { Clamp.MustUnaryTransform(1) })
+}
+
+func TestValidateArgs(t *testing.T) {
+	inputs := []struct {
+		typ     Type
+		args    []float64
+		isValid bool
+	}{
+		{typ: Absolute, args: nil, isValid: true},
+		{typ: Absolute, args: []float64{1}, isValid: false},
+		{typ: PerSecond, args: nil, isValid: true},
+		{typ: Reset, args: nil, isValid: true},
+		{typ: Scale, args: []float64{0.5}, isValid: true},
+		{typ: Scale, args: nil, isValid: false},
+		{typ: Scale, args: []float64{math.NaN()}, isValid: false},
+		{typ: Clamp, args: []float64{0, 100}, isValid: true},
+		{typ: Clamp, args: []float64{5, 5}, isValid: true},
+		{typ: Clamp, args: []float64{100, 0}, isValid: false},
+		{typ: Clamp, args: []float64{0}, isValid: false},
+	}
+
+	for _, input := range inputs {
+		err := input.typ.ValidateArgs(input.args)
+		require.Equal(t, input.isValid, err == nil, "%v %v", input.typ, input.args)
+	}
+}
+
+func TestNewOp(t *testing.T) {
+	op, err := Scale.NewOp(10)
+	require.NoError(t, err)
+	unary, ok := op.UnaryTransform()
+	require.True(t, ok)
+	require.Equal(t, Datapoint{Value: 20}, unary.Evaluate(Datapoint{Value: 2}))
+	_, ok = op.BinaryTransform()
+	require.False(t, ok)
+
+	op, err = Delta.NewOp()
+	require.NoError(t, err)
+	binary, ok := op.BinaryTransform()
+	require.True(t, ok)
+	require.Equal(t, Datapoint{TimeNanos: 2, Value: -1}, binary.Evaluate(Datapoint{TimeNanos: 1, Value: 3}, Datapoint{TimeNanos: 2, Value: 2}))
+
+	op, err = Reset.NewOp()
+	require.NoError(t, err)
+	unaryMulti, ok := op.UnaryMultiOutputTransform()
+	require.True(t, ok)
+	res, extra := unaryMulti.Evaluate(Datapoint{TimeNanos: 10, Value: 2}, 5)
+	require.Equal(t, Datapoint{TimeNanos: 10, Value: 2}, res)
+	require.Equal(t, Datapoint{TimeNanos: 12, Value: 0}, extra)
+	_, ok = op.UnaryTransform()
+	require.False(t, ok)
+
+	_, err = Clamp.NewOp(1)
+	require.Error(t, err)
+	_, err = PerSecond.NewOp(1)
+	require.Error(t, err)
+	_, err = UnknownType.NewOp()
+	require.Error(t, err)
+}
+
+func TestTypeRoundTripProtoAllTypes(t *testing.T) {
+	for _, typ := range []Type{Absolute, PerSecond, Increase, Add, Delta, PerSecondWithReset, Reset, Scale, Clamp} {
+		var (
+			pb  transformationpb.TransformationType
+			res Type
+		)
+		require.NoError(t, typ.ToProto(&pb))
+		require.NoError(t, res.FromProto(pb))
+		require.Equal(t, typ, res)
+
+		parsed, err := ParseType(typ.String())
+		require.NoError(t, err)
+		require.Equal(t, typ, parsed)
+	}
+}
+
 func TestTypeString(t *testing.T) {
 	inputs := []struct {
 		typ      Type
@@ -182,6 +289,8 @@ func TestTypeString(t *testing.T) {
 		{typ: UnknownType, expected: "UnknownType"},
 		{typ: Absolute, expected: "Absolute"},
 		{typ: PerSecond, expected: "PerSecond"},
+		{typ: PerSecondWithReset, expected: "PerSecondWithReset"},
+		{typ: Clamp, expected: "Clamp"},
 		{typ: Type(1000), expected: "Type(1000)"},
 	}
 
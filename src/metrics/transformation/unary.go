@@ -52,3 +52,23 @@ func transformAdd() UnaryTransform {
 		return Datapoint{TimeNanos: dp.TimeNanos, Value: curr}
 	})
 }
+
+// transformScale multiplies datapoint values by the factor given as the only
+// argument, useful for converting units.
+func transformScale(args []float64) UnaryTransform {
+	factor := args[0]
+	return UnaryTransformFn(func(dp Datapoint) Datapoint {
+		return Datapoint{TimeNanos: dp.TimeNanos, Value: dp.Value * factor}
+	})
+}
+
+// transformClamp limits datapoint values to the range between the min and max
+// given as arguments.
+// Note:
+// * NaN values are returned as is.
+func transformClamp(args []float64) UnaryTransform {
+	min, max := args[0], args[1]
+	return UnaryTransformFn(func(dp Datapoint) Datapoint {
+		return Datapoint{TimeNanos: dp.TimeNanos, Value: math.Max(min, math.Min(max, dp.Value))}
+	})
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package transformation
+
+import "time"
+
+var (
+	// allows to use a single transform fn ref (instead of
+	// taking reference to it each time when converting to iface).
+	transformResetFn = UnaryMultiOutputTransformFn(reset)
+)
+
+func transformReset() UnaryMultiOutputTransform {
+	return transformResetFn
+}
+
+// reset returns the datapoint as is along with a zero datapoint half a resolution
+// later, useful for sparse counters that should drop back to zero when no values
+// are received. The zero datapoint falls strictly inside the next interval so it
+// never overwrites the value of the next interval.
+func reset(dp Datapoint, resolution time.Duration) (Datapoint, Datapoint) {
+	return dp, Datapoint{TimeNanos: dp.TimeNanos + resolution.Nanoseconds()/2, Value: 0}
+}
@@ -21,6 +21,7 @@
 package transformation
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -49,3 +50,36 @@ func TestAbsolute(t *testing.T) {
 		require.Equal(t, input.expected, absolute(input.dp))
 	}
 }
+
+func TestScale(t *testing.T) {
+	scale := transformScale([]float64{0.001})
+	require.Equal(t, Datapoint{TimeNanos: 1234, Value: 1.5}, scale.Evaluate(Datapoint{TimeNanos: 1234, Value: 1500}))
+	require.Equal(t, Datapoint{TimeNanos: 1234, Value: -0.2}, scale.Evaluate(Datapoint{TimeNanos: 1234, Value: -200}))
+	require.True(t, scale.Evaluate(Datapoint{TimeNanos: 1234, Value: math.NaN()}).IsEmpty())
+}
+
+func TestClamp(t *testing.T) {
+	inputs := []struct {
+		dp       Datapoint
+		expected Datapoint
+	}{
+		{
+			dp:       Datapoint{TimeNanos: 1234, Value: -5},
+			expected: Datapoint{TimeNanos: 1234, Value: 0},
+		},
+		{
+			dp:       Datapoint{TimeNanos: 1234, Value: 42},
+			expected: Datapoint{TimeNanos: 1234, Value: 42},
+		},
+		{
+			dp:       Datapoint{TimeNanos: 1234, Value: 150},
+			expected: Datapoint{TimeNanos: 1234, Value: 100},
+		},
+	}
+
+	clamp := transformClamp([]float64{0, 100})
+	for _, input := range inputs {
+		require.Equal(t, input.expected, clamp.Evaluate(input.dp))
+	}
+	require.True(t, clamp.Evaluate(Datapoint{TimeNanos: 1234, Value: math.NaN()}).IsEmpty())
+}
@@ -39,6 +39,7 @@ ackFlushInterval: 100ms
 ackBufferSize: 100
 connectionWriteBufferSize: 200
 connectionReadBufferSize: 300
+dedupWindowSize: 1024
 encoder:
   maxMessageSize: 100
   bytesPool:
@@ -61,6 +62,7 @@ decoder:
 	require.Equal(t, 100, opts.AckBufferSize())
 	require.Equal(t, 200, opts.ConnectionWriteBufferSize())
 	require.Equal(t, 300, opts.ConnectionReadBufferSize())
+	require.Equal(t, 1024, opts.DedupWindowSize())
 	require.Equal(t, 100, opts.EncoderOptions().MaxMessageSize())
 	require.NotNil(t, opts.EncoderOptions().BytesPool())
 	require.Equal(t, 200, opts.DecoderOptions().MaxMessageSize())
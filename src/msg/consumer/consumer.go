@@ -73,6 +73,7 @@ type metrics struct {
 	ackSent            tally.Counter
 	ackEncodeError     tally.Counter
 	ackWriteError      tally.Counter
+	messageDuplicate   tally.Counter
 }
 
 func newConsumerMetrics(scope tally.Scope) metrics {
@@ -82,6 +83,7 @@ func newConsumerMetrics(scope tally.Scope) metrics {
 		ackSent:            scope.Counter("ack-sent"),
 		ackEncodeError:     scope.Counter("ack-encode-error"),
 		ackWriteError:      scope.Counter("ack-write-error"),
+		messageDuplicate:   scope.Counter("message-duplicate"),
 	}
 }
 
@@ -133,6 +135,14 @@ func (c *consumer) Init() {
 }
 
 func (c *consumer) Message() (Message, error) {
+	m, err := c.message()
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *consumer) message() (*message, error) {
 	m := c.mPool.Get()
 	m.reset(c)
 	if err := c.decoder.Decode(m); err != nil {
@@ -223,6 +233,7 @@ type message struct {
 
 	mPool *messagePool
 	c     *consumer
+	dedup *deduper
 }
 
 func newMessage(p *messagePool) *message {
@@ -234,6 +245,11 @@ func (m *message) Bytes() []byte {
 }
 
 func (m *message) Ack() {
+	if m.dedup != nil {
+		// NB: Sequences are only marked once acked since the producer retries
+		// messages that are never acked and those retries must be processed.
+		m.dedup.markAcked(&m.Message)
+	}
 	m.c.tryAck(m.Metadata)
 	if m.mPool != nil {
 		m.mPool.Put(m)
@@ -242,6 +258,7 @@ func (m *message) Ack() {
 
 func (m *message) reset(c *consumer) {
 	m.c = c
+	m.dedup = nil
 	resetProto(&m.Message)
 }
 
@@ -249,4 +266,6 @@ func resetProto(m *msgpb.Message) {
 	m.Metadata.Id = 0
 	m.Metadata.Shard = 0
 	m.Value = m.Value[:0]
+	m.ProducerId = 0
+	m.Sequence = 0
 }
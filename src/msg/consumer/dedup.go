@@ -0,0 +1,169 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package consumer
+
+import (
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/msg/generated/proto/msgpb"
+)
+
+const (
+	// dedupWindowExpiry is how long the dedup window of a producer shard is
+	// kept after its last message, which bounds the windows kept for producer
+	// instances that have gone away.
+	dedupWindowExpiry = 10 * time.Minute
+)
+
+type dedupKey struct {
+	producerID uint64
+	shard      uint64
+}
+
+// dedupWindow tracks the most recent sequence numbers seen on a producer shard
+// in a bitmap indexed by the sequence number modulo the window size.
+type dedupWindow struct {
+	highest       uint64
+	seen          []uint64
+	lastSeenNanos int64
+}
+
+// deduper detects messages replayed by producers based on the sequence
+// numbers they assign to messages per shard.
+type deduper struct {
+	sync.Mutex
+
+	size            uint64
+	nowFn           func() time.Time
+	windows         map[dedupKey]*dedupWindow
+	lastExpiryNanos int64
+}
+
+func newDeduper(size int) *deduper {
+	return &deduper{
+		size:    uint64(size),
+		nowFn:   time.Now,
+		windows: make(map[dedupKey]*dedupWindow),
+	}
+}
+
+// isDuplicate returns true if the message has been acked before. Messages
+// without a producer assigned sequence are never considered duplicates,
+// and neither are messages older than the window since there is no way
+// to tell whether they have been acked. Messages that were delivered but
+// never acked are not duplicates either so that the retries of the producer
+// still reach the processor.
+func (d *deduper) isDuplicate(pb *msgpb.Message) bool {
+	if pb.ProducerId == 0 || pb.Sequence == 0 {
+		return false
+	}
+	key := dedupKey{producerID: pb.ProducerId, shard: pb.Metadata.Shard}
+	nowNanos := d.nowFn().UnixNano()
+
+	d.Lock()
+	defer d.Unlock()
+
+	d.expireWithLock(nowNanos)
+	w, ok := d.windows[key]
+	if !ok {
+		return false
+	}
+	w.lastSeenNanos = nowNanos
+	if pb.Sequence > w.highest || w.highest-pb.Sequence >= d.size {
+		return false
+	}
+	return d.isSetWithLock(w, pb.Sequence)
+}
+
+// markAcked marks the sequence of the message as acked so later deliveries
+// of the message are detected as duplicates.
+func (d *deduper) markAcked(pb *msgpb.Message) {
+	if pb.ProducerId == 0 || pb.Sequence == 0 {
+		return
+	}
+	key := dedupKey{producerID: pb.ProducerId, shard: pb.Metadata.Shard}
+	nowNanos := d.nowFn().UnixNano()
+
+	d.Lock()
+	defer d.Unlock()
+
+	d.expireWithLock(nowNanos)
+	w, ok := d.windows[key]
+	if !ok {
+		w = &dedupWindow{
+			highest: pb.Sequence,
+			seen:    make([]uint64, (d.size+63)/64),
+		}
+		d.windows[key] = w
+	}
+	w.lastSeenNanos = nowNanos
+	d.markWithLock(w, pb.Sequence)
+}
+
+func (d *deduper) markWithLock(w *dedupWindow, seq uint64) {
+	if seq > w.highest {
+		if seq-w.highest >= d.size {
+			for i := range w.seen {
+				w.seen[i] = 0
+			}
+		} else {
+			for s := w.highest + 1; s < seq; s++ {
+				d.clearWithLock(w, s)
+			}
+		}
+		w.highest = seq
+		d.setWithLock(w, seq)
+		return
+	}
+	if w.highest-seq >= d.size {
+		return
+	}
+	d.setWithLock(w, seq)
+}
+
+func (d *deduper) setWithLock(w *dedupWindow, seq uint64) {
+	idx := seq % d.size
+	w.seen[idx/64] |= 1 << (idx % 64)
+}
+
+func (d *deduper) clearWithLock(w *dedupWindow, seq uint64) {
+	idx := seq % d.size
+	w.seen[idx/64] &^= 1 << (idx % 64)
+}
+
+func (d *deduper) isSetWithLock(w *dedupWindow, seq uint64) bool {
+	idx := seq % d.size
+	return w.seen[idx/64]&(1<<(idx%64)) != 0
+}
+
+func (d *deduper) expireWithLock(nowNanos int64) {
+	expiryNanos := int64(dedupWindowExpiry)
+	if nowNanos-d.lastExpiryNanos < expiryNanos {
+		return
+	}
+	d.lastExpiryNanos = nowNanos
+	for key, w := range d.windows {
+		if nowNanos-w.lastSeenNanos >= expiryNanos {
+			delete(d.windows, key)
+		}
+	}
+}
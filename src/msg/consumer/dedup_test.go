@@ -0,0 +1,102 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package consumer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/msg/generated/proto/msgpb"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeduper(t *testing.T) {
+	d := newDeduper(8)
+	newMsg := func(producerID, shard, seq uint64) *msgpb.Message {
+		return &msgpb.Message{
+			Metadata:   msgpb.Metadata{Shard: shard},
+			ProducerId: producerID,
+			Sequence:   seq,
+		}
+	}
+	// ackOnce acks the message unless it is a duplicate, as the processor
+	// would after a successful delivery.
+	ackOnce := func(msg *msgpb.Message) bool {
+		if d.isDuplicate(msg) {
+			return true
+		}
+		d.markAcked(msg)
+		return false
+	}
+
+	// Messages are only duplicates once they have been acked.
+	require.False(t, d.isDuplicate(newMsg(1, 0, 5)))
+	require.False(t, d.isDuplicate(newMsg(1, 0, 5)))
+
+	require.False(t, ackOnce(newMsg(1, 0, 5)))
+	require.True(t, ackOnce(newMsg(1, 0, 5)))
+
+	// Sequences are tracked per producer and shard.
+	require.False(t, ackOnce(newMsg(2, 0, 5)))
+	require.False(t, ackOnce(newMsg(1, 1, 5)))
+
+	// Out of order sequences within the window.
+	require.False(t, ackOnce(newMsg(1, 0, 7)))
+	require.False(t, ackOnce(newMsg(1, 0, 6)))
+	require.True(t, ackOnce(newMsg(1, 0, 6)))
+	require.True(t, ackOnce(newMsg(1, 0, 7)))
+
+	// Sequences skipped by an acked sequence are not duplicates until acked.
+	require.False(t, ackOnce(newMsg(1, 0, 9)))
+	require.False(t, d.isDuplicate(newMsg(1, 0, 8)))
+
+	// Sequences are forgotten once they fall out of the window.
+	require.False(t, ackOnce(newMsg(1, 0, 13)))
+	require.False(t, ackOnce(newMsg(1, 0, 5)))
+	require.True(t, ackOnce(newMsg(1, 0, 6)))
+	require.False(t, ackOnce(newMsg(1, 0, 100)))
+	require.False(t, ackOnce(newMsg(1, 0, 99)))
+	require.False(t, ackOnce(newMsg(1, 0, 13)))
+
+	// Messages without a producer assigned sequence are never duplicates.
+	require.False(t, ackOnce(newMsg(0, 0, 1)))
+	require.False(t, ackOnce(newMsg(0, 0, 1)))
+	require.False(t, ackOnce(newMsg(1, 0, 0)))
+	require.False(t, ackOnce(newMsg(1, 0, 0)))
+}
+
+func TestDeduperExpiry(t *testing.T) {
+	now := time.Unix(0, 0).Add(dedupWindowExpiry)
+	d := newDeduper(8)
+	d.nowFn = func() time.Time { return now }
+
+	msg := &msgpb.Message{ProducerId: 1, Sequence: 1}
+	require.False(t, d.isDuplicate(msg))
+	d.markAcked(msg)
+	require.True(t, d.isDuplicate(msg))
+	require.Equal(t, 1, len(d.windows))
+
+	now = now.Add(dedupWindowExpiry)
+	d.markAcked(&msgpb.Message{ProducerId: 2, Sequence: 1})
+	require.Equal(t, 1, len(d.windows))
+	require.False(t, d.isDuplicate(msg))
+}
@@ -60,6 +60,7 @@ type messageHandler struct {
 	opts  Options
 	mPool *messagePool
 	mp    MessageProcessor
+	dedup *deduper
 	m     metrics
 }
 
@@ -67,10 +68,17 @@ type messageHandler struct {
 func NewMessageHandler(mp MessageProcessor, opts Options) server.Handler {
 	mPool := newMessagePool(opts.MessagePoolOptions())
 	mPool.Init()
+	var dedup *deduper
+	if size := opts.DedupWindowSize(); size > 0 {
+		// NB: The deduper is shared across connections since producers
+		// retry messages on new connections after reconnecting.
+		dedup = newDeduper(size)
+	}
 	return &messageHandler{
 		mp:    mp,
 		opts:  opts,
 		mPool: mPool,
+		dedup: dedup,
 		m:     newConsumerMetrics(opts.InstrumentOptions().MetricsScope()),
 	}
 }
@@ -80,13 +88,22 @@ func (h *messageHandler) Handle(conn net.Conn) {
 	c.Init()
 	var (
 		msgErr error
-		msg    Message
+		msg    *message
 	)
 	for {
-		msg, msgErr = c.Message()
+		msg, msgErr = c.message()
 		if msgErr != nil {
 			break
 		}
+		if h.dedup != nil {
+			if h.dedup.isDuplicate(&msg.Message) {
+				// Ack the duplicate so the producer stops retrying it.
+				h.m.messageDuplicate.Inc(1)
+				msg.Ack()
+				continue
+			}
+			msg.dedup = h.dedup
+		}
 		h.mp.Process(msg)
 	}
 	if msgErr != nil && msgErr != io.EOF {
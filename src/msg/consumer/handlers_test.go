@@ -88,6 +88,136 @@ func TestServerWithMessageFn(t *testing.T) {
 	s.Close()
 }
 
+func TestServerWithMessageFnDedup(t *testing.T) {
+	defer leaktest.Check(t)()
+
+	var (
+		data []string
+		wg   sync.WaitGroup
+	)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p := NewMockMessageProcessor(ctrl)
+	p.EXPECT().Process(gomock.Any()).Do(
+		func(m Message) {
+			data = append(data, string(m.Bytes()))
+			m.Ack()
+			wg.Done()
+		},
+	).Times(2)
+	opts := testOptions().SetAckBufferSize(100).SetDedupWindowSize(16)
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := server.NewServer("a", NewMessageHandler(p, opts), server.NewOptions())
+	s.Serve(l)
+
+	conn, err := net.Dial("tcp", l.Addr().String())
+	require.NoError(t, err)
+
+	msg1 := testMsg1
+	msg1.ProducerId = 1
+	msg1.Sequence = 1
+	msg2 := testMsg2
+	msg2.Metadata.Shard = msg1.Metadata.Shard
+	msg2.ProducerId = 1
+	msg2.Sequence = 2
+
+	wg.Add(1)
+	require.NoError(t, produce(conn, &msg1))
+	// The replayed message is acked without being processed.
+	require.NoError(t, produce(conn, &msg1))
+	wg.Add(1)
+	require.NoError(t, produce(conn, &msg2))
+
+	wg.Wait()
+	require.Equal(t, []string{string(msg1.Value), string(msg2.Value)}, data)
+
+	var ack msgpb.Ack
+	testDecoder := proto.NewDecoder(conn, opts.DecoderOptions())
+	err = testDecoder.Decode(&ack)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(ack.Metadata))
+	require.Equal(t, msg1.Metadata, ack.Metadata[0])
+	require.Equal(t, msg1.Metadata, ack.Metadata[1])
+	require.Equal(t, msg2.Metadata, ack.Metadata[2])
+
+	p.EXPECT().Close()
+	s.Close()
+}
+
+func TestServerWithMessageFnDedupUnacked(t *testing.T) {
+	defer leaktest.Check(t)()
+
+	var (
+		data []string
+		wg   sync.WaitGroup
+	)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p := NewMockMessageProcessor(ctrl)
+	gomock.InOrder(
+		// The first delivery fails and is left unacked.
+		p.EXPECT().Process(gomock.Any()).Do(
+			func(m Message) {
+				data = append(data, string(m.Bytes()))
+				wg.Done()
+			},
+		),
+		p.EXPECT().Process(gomock.Any()).Do(
+			func(m Message) {
+				data = append(data, string(m.Bytes()))
+				m.Ack()
+				wg.Done()
+			},
+		),
+	)
+	opts := testOptions().SetAckBufferSize(100).SetDedupWindowSize(16)
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := server.NewServer("a", NewMessageHandler(p, opts), server.NewOptions())
+	s.Serve(l)
+
+	conn, err := net.Dial("tcp", l.Addr().String())
+	require.NoError(t, err)
+
+	msg := testMsg1
+	msg.ProducerId = 1
+	msg.Sequence = 1
+
+	wg.Add(1)
+	require.NoError(t, produce(conn, &msg))
+	wg.Wait()
+
+	// The retry of the unacked message reaches the processor.
+	wg.Add(1)
+	require.NoError(t, produce(conn, &msg))
+	wg.Wait()
+
+	// Only the retry after it was acked is a duplicate.
+	require.NoError(t, produce(conn, &msg))
+	require.Equal(t, []string{string(msg.Value), string(msg.Value)}, data)
+
+	var (
+		acked       []msgpb.Metadata
+		testDecoder = proto.NewDecoder(conn, opts.DecoderOptions())
+	)
+	for len(acked) < 2 {
+		var ack msgpb.Ack
+		require.NoError(t, testDecoder.Decode(&ack))
+		acked = append(acked, ack.Metadata...)
+	}
+	require.Equal(t, []msgpb.Metadata{msg.Metadata, msg.Metadata}, acked)
+
+	p.EXPECT().Close()
+	s.Close()
+}
+
 func TestServerWithConsumeFn(t *testing.T) {
 	defer leaktest.Check(t)()
 
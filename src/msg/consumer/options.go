@@ -42,6 +42,7 @@ type options struct {
 	ackBufferSize    int
 	writeBufferSize  int
 	readBufferSize   int
+	dedupWindowSize  int
 	iOpts            instrument.Options
 }
 
@@ -129,6 +130,16 @@ func (opts *options) SetConnectionReadBufferSize(value int) Options {
 	return &o
 }
 
+func (opts *options) DedupWindowSize() int {
+	return opts.dedupWindowSize
+}
+
+func (opts *options) SetDedupWindowSize(value int) Options {
+	o := *opts
+	o.dedupWindowSize = value
+	return &o
+}
+
 func (opts *options) InstrumentOptions() instrument.Options {
 	return opts.iOpts
 }
@@ -106,6 +106,16 @@ type Options interface {
 	// SetConnectionWriteBufferSize sets the buffer size.
 	SetConnectionReadBufferSize(value int) Options
 
+	// DedupWindowSize returns the number of most recent sequence numbers tracked
+	// per producer instance and shard by message handlers to drop messages
+	// replayed by producers, zero disables deduplication.
+	DedupWindowSize() int
+
+	// SetDedupWindowSize sets the number of most recent sequence numbers tracked
+	// per producer instance and shard by message handlers to drop messages
+	// replayed by producers, zero disables deduplication.
+	SetDedupWindowSize(value int) Options
+
 	// InstrumentOptions returns the instrument options.
 	InstrumentOptions() instrument.Options
 
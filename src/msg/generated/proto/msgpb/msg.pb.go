@@ -79,6 +79,11 @@ func (m *Metadata) GetId() uint64 {
 type Message struct {
 	Metadata Metadata `protobuf:"bytes,1,opt,name=metadata" json:"metadata"`
 	Value    []byte   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// producer_id identifies the producer instance that sent the message.
+	ProducerId uint64 `protobuf:"varint,3,opt,name=producer_id,json=producerId,proto3" json:"producer_id,omitempty"`
+	// sequence is assigned by the producer per shard and increases for
+	// every message produced on the shard, zero means unassigned.
+	Sequence uint64 `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"`
 }
 
 func (m *Message) Reset()                    { *m = Message{} }
@@ -100,6 +105,20 @@ func (m *Message) GetValue() []byte {
 	return nil
 }
 
+func (m *Message) GetProducerId() uint64 {
+	if m != nil {
+		return m.ProducerId
+	}
+	return 0
+}
+
+func (m *Message) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
 type Ack struct {
 	Metadata []Metadata `protobuf:"bytes,1,rep,name=metadata" json:"metadata"`
 }
@@ -178,6 +197,16 @@ func (m *Message) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintMsg(dAtA, i, uint64(len(m.Value)))
 		i += copy(dAtA[i:], m.Value)
 	}
+	if m.ProducerId != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintMsg(dAtA, i, uint64(m.ProducerId))
+	}
+	if m.Sequence != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintMsg(dAtA, i, uint64(m.Sequence))
+	}
 	return i, nil
 }
 
@@ -241,6 +270,12 @@ func (m *Message) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovMsg(uint64(l))
 	}
+	if m.ProducerId != 0 {
+		n += 1 + sovMsg(uint64(m.ProducerId))
+	}
+	if m.Sequence != 0 {
+		n += 1 + sovMsg(uint64(m.Sequence))
+	}
 	return n
 }
 
@@ -447,6 +482,44 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 				m.Value = []byte{}
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProducerId", wireType)
+			}
+			m.ProducerId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ProducerId |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sequence", wireType)
+			}
+			m.Sequence = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMsg
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Sequence |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMsg(dAtA[iNdEx:])
@@ -659,20 +732,22 @@ func init() {
 }
 
 var fileDescriptorMsg = []byte{
-	// 232 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xb2, 0x4a, 0xcf, 0x2c, 0xc9,
-	0x28, 0x4d, 0xd2, 0x4b, 0xce, 0xcf, 0xd5, 0xcf, 0x35, 0x4e, 0x49, 0xd2, 0xcf, 0x35, 0xd6, 0x2f,
-	0x2e, 0x4a, 0xd6, 0xcf, 0x2d, 0x4e, 0xd7, 0x4f, 0x4f, 0xcd, 0x4b, 0x2d, 0x4a, 0x2c, 0x49, 0x4d,
-	0xd1, 0x2f, 0x28, 0xca, 0x2f, 0xc9, 0x07, 0x89, 0x15, 0x24, 0x81, 0x48, 0x3d, 0x30, 0x5f, 0x88,
-	0x15, 0x2c, 0x20, 0xa5, 0x8b, 0x64, 0x44, 0x7a, 0x7e, 0x7a, 0x3e, 0x44, 0x75, 0x52, 0x69, 0x1a,
-	0x98, 0x07, 0xd1, 0x0a, 0x62, 0x41, 0x74, 0x29, 0x19, 0x70, 0x71, 0xf8, 0xa6, 0x96, 0x24, 0xa6,
-	0x24, 0x96, 0x24, 0x0a, 0x89, 0x70, 0xb1, 0x16, 0x67, 0x24, 0x16, 0xa5, 0x48, 0x30, 0x2a, 0x30,
-	0x6a, 0xb0, 0x04, 0x41, 0x38, 0x42, 0x7c, 0x5c, 0x4c, 0x99, 0x29, 0x12, 0x4c, 0x60, 0x21, 0xa6,
-	0xcc, 0x14, 0xa5, 0x20, 0x2e, 0x76, 0xdf, 0xd4, 0xe2, 0xe2, 0xc4, 0xf4, 0x54, 0x21, 0x43, 0x2e,
-	0x8e, 0x5c, 0xa8, 0x66, 0xb0, 0x1e, 0x6e, 0x23, 0x7e, 0x3d, 0xb0, 0x2b, 0xf4, 0x60, 0x66, 0x3a,
-	0xb1, 0x9c, 0xb8, 0x27, 0xcf, 0x10, 0x04, 0x57, 0x06, 0xb2, 0xa3, 0x2c, 0x31, 0xa7, 0x34, 0x15,
-	0x6c, 0x20, 0x4f, 0x10, 0x84, 0xa3, 0x64, 0xc1, 0xc5, 0xec, 0x98, 0x9c, 0x8d, 0x66, 0x1e, 0x33,
-	0x11, 0xe6, 0x39, 0x09, 0x9c, 0x78, 0x24, 0xc7, 0x78, 0xe1, 0x91, 0x1c, 0xe3, 0x83, 0x47, 0x72,
-	0x8c, 0x13, 0x1e, 0xcb, 0x31, 0x24, 0xb1, 0x81, 0x3d, 0x66, 0x0c, 0x08, 0x00, 0x00, 0xff, 0xff,
-	0x31, 0xd7, 0x86, 0x7e, 0x4c, 0x01, 0x00, 0x00,
+	// 271 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x90, 0x31, 0x4e, 0xc3, 0x30,
+	0x14, 0x86, 0xeb, 0x26, 0x85, 0xc8, 0x45, 0x80, 0x2c, 0x86, 0xa8, 0x43, 0x8a, 0x32, 0xb1, 0x10,
+	0x03, 0x59, 0x10, 0x1b, 0xdd, 0x18, 0xba, 0xe4, 0x02, 0xc8, 0x89, 0x1f, 0x6e, 0x04, 0xae, 0x83,
+	0x9d, 0x70, 0x0d, 0x38, 0x56, 0x47, 0x4e, 0x80, 0x50, 0xb8, 0x08, 0xca, 0x33, 0x45, 0x15, 0x13,
+	0x8b, 0xe5, 0xef, 0xb7, 0xff, 0xff, 0xfd, 0x7a, 0xf4, 0x46, 0xd5, 0xed, 0xaa, 0x2b, 0xb3, 0xca,
+	0x68, 0xae, 0x73, 0x59, 0x72, 0x9d, 0x73, 0x67, 0x2b, 0xae, 0x9d, 0xe2, 0x0a, 0xd6, 0x60, 0x45,
+	0x0b, 0x92, 0x37, 0xd6, 0xb4, 0x66, 0xd0, 0x9a, 0x72, 0x38, 0x33, 0x64, 0x36, 0x41, 0x61, 0x76,
+	0xbe, 0x13, 0xa1, 0x8c, 0x32, 0xfe, 0x77, 0xd9, 0x3d, 0x20, 0x79, 0xeb, 0x70, 0xf3, 0xae, 0xf4,
+	0x82, 0x46, 0x4b, 0x68, 0x85, 0x14, 0xad, 0x60, 0x27, 0x74, 0xe2, 0x56, 0xc2, 0xca, 0x98, 0x9c,
+	0x92, 0xb3, 0xb0, 0xf0, 0xc0, 0x0e, 0xe9, 0xb8, 0x96, 0xf1, 0x18, 0xa5, 0x71, 0x2d, 0xd3, 0x57,
+	0x42, 0xf7, 0x97, 0xe0, 0x9c, 0x50, 0xc0, 0x2e, 0x69, 0xa4, 0x7f, 0xdc, 0x68, 0x9a, 0x5e, 0x1d,
+	0x65, 0x58, 0x23, 0xdb, 0x86, 0x2e, 0xc2, 0xcd, 0xc7, 0x7c, 0x54, 0x44, 0x7a, 0x67, 0xc8, 0x8b,
+	0x78, 0xea, 0x00, 0x13, 0x0f, 0x0a, 0x0f, 0x6c, 0x4e, 0xa7, 0x8d, 0x35, 0xb2, 0xab, 0xc0, 0xde,
+	0xd7, 0x32, 0x0e, 0x70, 0x1a, 0xdd, 0x4a, 0x77, 0x92, 0xcd, 0x68, 0xe4, 0xe0, 0xb9, 0x83, 0x75,
+	0x05, 0x71, 0x88, 0xaf, 0xbf, 0x9c, 0x5e, 0xd3, 0xe0, 0xb6, 0x7a, 0xfc, 0x53, 0x26, 0xf8, 0x47,
+	0x99, 0xc5, 0xf1, 0xa6, 0x4f, 0xc8, 0x7b, 0x9f, 0x90, 0xcf, 0x3e, 0x21, 0x6f, 0x5f, 0xc9, 0xa8,
+	0xdc, 0xc3, 0xb5, 0xe4, 0xdf, 0x03, 0x00, 0xcc, 0x30, 0x84, 0xc7, 0x8a, 0x01, 0x00, 0x00,
 }
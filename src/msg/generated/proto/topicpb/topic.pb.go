@@ -54,20 +54,23 @@ const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 type ConsumptionType int32
 
 const (
-	ConsumptionType_UNKNOWN    ConsumptionType = 0
-	ConsumptionType_SHARED     ConsumptionType = 1
-	ConsumptionType_REPLICATED ConsumptionType = 2
+	ConsumptionType_UNKNOWN        ConsumptionType = 0
+	ConsumptionType_SHARED         ConsumptionType = 1
+	ConsumptionType_REPLICATED     ConsumptionType = 2
+	ConsumptionType_CONSUMER_GROUP ConsumptionType = 3
 )
 
 var ConsumptionType_name = map[int32]string{
 	0: "UNKNOWN",
 	1: "SHARED",
 	2: "REPLICATED",
+	3: "CONSUMER_GROUP",
 }
 var ConsumptionType_value = map[string]int32{
-	"UNKNOWN":    0,
-	"SHARED":     1,
-	"REPLICATED": 2,
+	"UNKNOWN":        0,
+	"SHARED":         1,
+	"REPLICATED":     2,
+	"CONSUMER_GROUP": 3,
 }
 
 func (x ConsumptionType) String() string {
@@ -864,30 +867,30 @@ func init() {
 }
 
 var fileDescriptorTopic = []byte{
-	// 385 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0x4f, 0x8e, 0xd3, 0x30,
-	0x14, 0x87, 0xc7, 0x53, 0x98, 0x51, 0x5e, 0x44, 0x9b, 0xf1, 0x2a, 0xab, 0x28, 0xea, 0x2a, 0x9a,
-	0x45, 0x22, 0xa6, 0x3b, 0x16, 0x48, 0x43, 0x1b, 0x89, 0x0a, 0x94, 0x41, 0x6e, 0x46, 0x2c, 0xa3,
-	0xfc, 0xf1, 0x64, 0x22, 0xd5, 0x76, 0x64, 0xbb, 0x95, 0xca, 0x19, 0x58, 0x70, 0x19, 0xee, 0xc0,
-	0x92, 0x23, 0xa0, 0x72, 0x11, 0x14, 0xd7, 0x14, 0x0a, 0xb3, 0xca, 0xd3, 0x97, 0x9f, 0xdf, 0xfb,
-	0xfc, 0x0c, 0xaf, 0xdb, 0x4e, 0x3f, 0x6e, 0xaa, 0xb8, 0x16, 0x2c, 0x61, 0xb3, 0xa6, 0x4a, 0xd8,
-	0x2c, 0x51, 0xb2, 0x4e, 0x98, 0x6a, 0x93, 0x96, 0x72, 0x2a, 0x4b, 0x4d, 0x9b, 0xa4, 0x97, 0x42,
-	0x8b, 0x44, 0x8b, 0xbe, 0xab, 0xfb, 0xea, 0xf0, 0x8d, 0x0d, 0xc3, 0x97, 0x16, 0x4e, 0x3f, 0x23,
-	0x78, 0x9e, 0x0f, 0x35, 0xc6, 0xf0, 0x8c, 0x97, 0x8c, 0xfa, 0x28, 0x44, 0x91, 0x43, 0x4c, 0x8d,
-	0x23, 0xf0, 0xf8, 0x86, 0x55, 0x54, 0x16, 0xe2, 0xa1, 0x50, 0x8f, 0xa5, 0x6c, 0x94, 0x7f, 0x1e,
-	0xa2, 0xe8, 0x05, 0x19, 0x1f, 0xf8, 0xdd, 0xc3, 0xca, 0x50, 0x9c, 0xc2, 0x55, 0x2d, 0xb8, 0xda,
-	0x30, 0x2a, 0x0b, 0x45, 0xe5, 0xb6, 0xab, 0xa9, 0xf2, 0x47, 0xe1, 0x28, 0x72, 0x6f, 0xfc, 0xd8,
-	0x0e, 0x8b, 0xe7, 0x36, 0xb1, 0x3a, 0x04, 0x88, 0x57, 0x9f, 0x02, 0x35, 0xfd, 0x8a, 0x60, 0xf2,
-	0x4f, 0x0a, 0xbf, 0x04, 0xb0, 0x1d, 0x8b, 0xae, 0x31, 0x7a, 0xee, 0x0d, 0x3e, 0xf6, 0xb4, 0xa9,
-	0xe5, 0x82, 0x38, 0x36, 0xb5, 0x6c, 0xf0, 0x1c, 0x6c, 0xeb, 0x5e, 0x77, 0x82, 0x17, 0x7a, 0xd7,
-	0x53, 0xe3, 0x3d, 0xfe, 0x4f, 0xc6, 0x04, 0xf2, 0x5d, 0x4f, 0xc9, 0xa4, 0x3e, 0x05, 0xf8, 0x1a,
-	0xae, 0x18, 0x55, 0xaa, 0x6c, 0x69, 0xa1, 0xf5, 0xba, 0xe0, 0x25, 0x17, 0xc3, 0x95, 0x50, 0x34,
-	0x22, 0x13, 0xfb, 0x23, 0xd7, 0xeb, 0x6c, 0xc0, 0xd3, 0x7b, 0x70, 0x8e, 0x22, 0x4f, 0x6e, 0x32,
-	0x04, 0x97, 0xf2, 0x6d, 0x27, 0x05, 0x67, 0x94, 0x6b, 0x23, 0xe3, 0x90, 0xbf, 0xd1, 0x70, 0xea,
-	0x93, 0xe0, 0xd4, 0x4c, 0x70, 0x88, 0xa9, 0xaf, 0x5f, 0xfd, 0xde, 0xc6, 0x1f, 0x2b, 0x17, 0x2e,
-	0xef, 0xb3, 0x77, 0xd9, 0xdd, 0xc7, 0xcc, 0x3b, 0xc3, 0x00, 0x17, 0xab, 0xb7, 0xb7, 0x24, 0x5d,
-	0x78, 0x08, 0x8f, 0x01, 0x48, 0xfa, 0xe1, 0xfd, 0x72, 0x7e, 0x9b, 0xa7, 0x0b, 0xef, 0xfc, 0x8d,
-	0xf7, 0x6d, 0x1f, 0xa0, 0xef, 0xfb, 0x00, 0xfd, 0xd8, 0x07, 0xe8, 0xcb, 0xcf, 0xe0, 0xac, 0xba,
-	0x30, 0x6f, 0x3f, 0xfb, 0x15, 0x00, 0x00, 0xff, 0xff, 0xd4, 0xd7, 0xf0, 0x71, 0x3d, 0x02, 0x00,
-	0x00,
+	// 398 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0xdf, 0x6e, 0xd3, 0x30,
+	0x14, 0x87, 0xe7, 0x15, 0x36, 0xe5, 0x54, 0xb4, 0x99, 0xaf, 0x7a, 0x55, 0x55, 0xbd, 0x8a, 0x76,
+	0xd1, 0x88, 0xf5, 0x1e, 0x69, 0xa4, 0x11, 0x54, 0x40, 0x32, 0x39, 0x89, 0xb8, 0xb4, 0xf2, 0xc7,
+	0xcb, 0x22, 0xcd, 0x76, 0x64, 0xbb, 0x93, 0xc6, 0x33, 0x70, 0xc1, 0xcb, 0xf0, 0x0e, 0x5c, 0xf2,
+	0x08, 0xa8, 0xbc, 0x08, 0x8a, 0x6b, 0x06, 0x83, 0x5d, 0xe5, 0xe8, 0xcb, 0xcf, 0xe7, 0x7c, 0x3e,
+	0x32, 0xbc, 0x6a, 0x3b, 0x73, 0xb3, 0xab, 0x56, 0xb5, 0xe4, 0x21, 0x5f, 0x37, 0x55, 0xc8, 0xd7,
+	0xa1, 0x56, 0x75, 0xc8, 0x75, 0x1b, 0xb6, 0x4c, 0x30, 0x55, 0x1a, 0xd6, 0x84, 0xbd, 0x92, 0x46,
+	0x86, 0x46, 0xf6, 0x5d, 0xdd, 0x57, 0x87, 0xef, 0xca, 0x32, 0x7c, 0xea, 0xe0, 0xf2, 0x33, 0x82,
+	0xe7, 0xf9, 0x50, 0x63, 0x0c, 0xcf, 0x44, 0xc9, 0xd9, 0x0c, 0x2d, 0x50, 0xe0, 0x11, 0x5b, 0xe3,
+	0x00, 0x7c, 0xb1, 0xe3, 0x15, 0x53, 0x54, 0x5e, 0x53, 0x7d, 0x53, 0xaa, 0x46, 0xcf, 0x8e, 0x17,
+	0x28, 0x78, 0x41, 0x26, 0x07, 0x9e, 0x5e, 0x67, 0x96, 0xe2, 0x18, 0xce, 0x6a, 0x29, 0xf4, 0x8e,
+	0x33, 0x45, 0x35, 0x53, 0x77, 0x5d, 0xcd, 0xf4, 0x6c, 0xb4, 0x18, 0x05, 0xe3, 0x8b, 0xd9, 0xca,
+	0x0d, 0x5b, 0x45, 0x2e, 0x91, 0x1d, 0x02, 0xc4, 0xaf, 0x1f, 0x03, 0xbd, 0xfc, 0x8a, 0x60, 0xfa,
+	0x4f, 0x0a, 0xbf, 0x04, 0x70, 0x1d, 0x69, 0xd7, 0x58, 0xbd, 0xf1, 0x05, 0x7e, 0xe8, 0xe9, 0x52,
+	0xdb, 0x0d, 0xf1, 0x5c, 0x6a, 0xdb, 0xe0, 0x08, 0x5c, 0xeb, 0xde, 0x74, 0x52, 0x50, 0x73, 0xdf,
+	0x33, 0xeb, 0x3d, 0xf9, 0x4f, 0xc6, 0x06, 0xf2, 0xfb, 0x9e, 0x91, 0x69, 0xfd, 0x18, 0xe0, 0x73,
+	0x38, 0xe3, 0x4c, 0xeb, 0xb2, 0x65, 0xd4, 0x98, 0x5b, 0x2a, 0x4a, 0x21, 0x87, 0x2b, 0xa1, 0x60,
+	0x44, 0xa6, 0xee, 0x47, 0x6e, 0x6e, 0x93, 0x01, 0x2f, 0x0b, 0xf0, 0x1e, 0x44, 0x9e, 0xdc, 0xe4,
+	0x02, 0xc6, 0x4c, 0xdc, 0x75, 0x4a, 0x0a, 0xce, 0x84, 0xb1, 0x32, 0x1e, 0xf9, 0x1b, 0x0d, 0xa7,
+	0x3e, 0x49, 0xc1, 0xec, 0x04, 0x8f, 0xd8, 0xfa, 0x3c, 0xf9, 0xbd, 0x8d, 0x3f, 0x56, 0x63, 0x38,
+	0x2d, 0x92, 0x77, 0x49, 0xfa, 0x31, 0xf1, 0x8f, 0x30, 0xc0, 0x49, 0xf6, 0xf6, 0x92, 0xc4, 0x1b,
+	0x1f, 0xe1, 0x09, 0x00, 0x89, 0xaf, 0xde, 0x6f, 0xa3, 0xcb, 0x3c, 0xde, 0xf8, 0xc7, 0x18, 0xc3,
+	0x24, 0x4a, 0x93, 0xac, 0xf8, 0x10, 0x13, 0xfa, 0x86, 0xa4, 0xc5, 0x95, 0x3f, 0x7a, 0xed, 0x7f,
+	0xdb, 0xcf, 0xd1, 0xf7, 0xfd, 0x1c, 0xfd, 0xd8, 0xcf, 0xd1, 0x97, 0x9f, 0xf3, 0xa3, 0xea, 0xc4,
+	0xbe, 0x87, 0xf5, 0xaf, 0x01, 0x00, 0x85, 0x67, 0x7b, 0xf2, 0x51, 0x02, 0x00, 0x00,
 }
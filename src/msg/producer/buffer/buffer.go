@@ -32,6 +32,7 @@ import (
 
 	"github.com/uber-go/tally"
 	"go.uber.org/atomic"
+	"go.uber.org/zap"
 )
 
 var (
@@ -55,6 +56,13 @@ type bufferMetrics struct {
 	messageBuffered   tally.Gauge
 	byteBuffered      tally.Gauge
 	bufferScanBatch   tally.Timer
+	messageSpilled    tally.Counter
+	byteSpilled       tally.Counter
+	spillError        tally.Counter
+	messageReplayed   tally.Counter
+	replayError       tally.Counter
+	byteSpillPending  tally.Gauge
+	replayLag         tally.Timer
 }
 
 func newBufferMetrics(
@@ -71,6 +79,13 @@ func newBufferMetrics(
 		messageBuffered:   scope.Gauge("message-buffered"),
 		byteBuffered:      scope.Gauge("byte-buffered"),
 		bufferScanBatch:   instrument.MustCreateSampledTimer(scope.Timer("buffer-scan-batch"), samplingRate),
+		messageSpilled:    scope.Counter("buffer-message-spilled"),
+		byteSpilled:       scope.Counter("buffer-byte-spilled"),
+		spillError:        scope.Counter("spill-error"),
+		messageReplayed:   scope.Counter("spill-message-replayed"),
+		replayError:       scope.Counter("spill-replay-error"),
+		byteSpillPending:  scope.Gauge("byte-spill-pending"),
+		replayLag:         instrument.MustCreateSampledTimer(scope.Timer("spill-replay-lag"), samplingRate),
 	}
 }
 
@@ -86,10 +101,14 @@ type buffer struct {
 	maxMessageSize   int
 	onFinalizeFn     producer.OnFinalizeFn
 	retrier          retry.Retrier
+	spill            *spillLog
+	writeFn          producer.WriteFn
+	logger           *zap.Logger
 	m                bufferMetrics
 
 	size         *atomic.Uint64
 	isClosed     bool
+	replaying    *atomic.Bool
 	dropOldestCh chan struct{}
 	doneCh       chan struct{}
 	forceDrop    bool
@@ -117,11 +136,25 @@ func NewBuffer(opts Options) (producer.Buffer, error) {
 			opts.InstrumentOptions().MetricsScope(),
 			opts.InstrumentOptions().MetricsSamplingRate(),
 		),
+		logger:       opts.InstrumentOptions().Logger(),
 		size:         atomic.NewUint64(0),
 		isClosed:     false,
+		replaying:    atomic.NewBool(false),
 		dropOldestCh: make(chan struct{}, 1),
 		doneCh:       make(chan struct{}),
 	}
+	if opts.OnFullStrategy() == SpillToDisk {
+		spill, err := openSpillLog(
+			opts.SpillDir(),
+			opts.SpillSegmentSize(),
+			opts.SpillSyncBatchSize(),
+			opts.MaxMessageSize(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		b.spill = spill
+	}
 	b.onFinalizeFn = b.subSize
 	return b, nil
 }
@@ -138,6 +171,11 @@ func (b *buffer) Add(m producer.Message) (*producer.RefCountedMessage, error) {
 		return nil, errBufferClosed
 	}
 	messageSize := uint64(s)
+	if b.spill != nil && b.shouldSpill(messageSize) {
+		err := b.spillMessage(m)
+		b.RUnlock()
+		return nil, err
+	}
 	newBufferSize := b.size.Add(messageSize)
 	if newBufferSize > b.maxBufferSize {
 		if err := b.produceOnFull(newBufferSize, messageSize); err != nil {
@@ -145,12 +183,36 @@ func (b *buffer) Add(m producer.Message) (*producer.RefCountedMessage, error) {
 			return nil, err
 		}
 	}
+	rm := b.pushBackWithRLock(m)
+	b.RUnlock()
+	return rm, nil
+}
+
+func (b *buffer) pushBackWithRLock(m producer.Message) *producer.RefCountedMessage {
 	rm := producer.NewRefCountedMessage(m, b.onFinalizeFn)
 	b.listLock.Lock()
 	b.bufferList.PushBack(rm)
 	b.listLock.Unlock()
-	b.RUnlock()
-	return rm, nil
+	return rm
+}
+
+func (b *buffer) shouldSpill(messageSize uint64) bool {
+	// Keep spilling while there are messages pending replay
+	// so the messages are written out in order.
+	return b.spill.PendingBytes() > 0 || b.size.Load()+messageSize > b.maxBufferSize
+}
+
+func (b *buffer) spillMessage(m producer.Message) error {
+	n, err := b.spill.Append(m, time.Now())
+	if err != nil {
+		b.m.spillError.Inc(1)
+		return err
+	}
+	b.m.messageSpilled.Inc(1)
+	b.m.byteSpilled.Inc(int64(n))
+	// NB: The message is finalized by the spill log once its record has been
+	// synced to disk, it will be recreated from the log when it is replayed.
+	return nil
 }
 
 func (b *buffer) produceOnFull(newBufferSize uint64, messageSize uint64) error {
@@ -178,13 +240,22 @@ func (b *buffer) produceOnFull(newBufferSize uint64, messageSize uint64) error {
 	return nil
 }
 
-func (b *buffer) Init() {
+func (b *buffer) Init(fn producer.WriteFn) {
+	b.writeFn = fn
 	b.wg.Add(1)
 	go func() {
 		b.cleanupUntilClose()
 		b.wg.Done()
 	}()
 
+	if b.spill != nil {
+		b.wg.Add(1)
+		go func() {
+			b.replayUntilClose()
+			b.wg.Done()
+		}()
+	}
+
 	if b.opts.OnFullStrategy() != DropOldest {
 		return
 	}
@@ -195,6 +266,10 @@ func (b *buffer) Init() {
 	}()
 }
 
+func (b *buffer) StartReplay() {
+	b.replaying.Store(true)
+}
+
 func (b *buffer) cleanupUntilClose() {
 	ticker := time.NewTicker(
 		b.opts.CleanupRetryOptions().InitialBackoff(),
@@ -355,6 +430,65 @@ func (b *buffer) dropOldestBatchUntilTargetWithListLock(
 	return false
 }
 
+func (b *buffer) replayUntilClose() {
+	ticker := time.NewTicker(b.opts.SpillReplayInterval())
+	defer ticker.Stop()
+
+	syncTicker := time.NewTicker(b.opts.SpillSyncInterval())
+	defer syncTicker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			// NB: spilled messages are only replayed once they can be written
+			// out, otherwise they would be dropped and acked in the spill log.
+			if b.replaying.Load() {
+				b.replay()
+			}
+		case <-syncTicker.C:
+			if err := b.spill.Flush(); err != nil {
+				b.m.spillError.Inc(1)
+				b.logger.Error("could not flush spill log", zap.Error(err))
+			}
+		case <-b.doneCh:
+			return
+		}
+	}
+}
+
+func (b *buffer) replay() {
+	for b.size.Load() < b.maxBufferSize {
+		b.RLock()
+		if b.isClosed {
+			b.RUnlock()
+			break
+		}
+		m, spilledAt, err := b.spill.Next()
+		if err != nil {
+			b.RUnlock()
+			if err != errSpillLogEmpty {
+				b.m.replayError.Inc(1)
+				b.logger.Error("could not replay spilled message", zap.Error(err))
+			}
+			break
+		}
+		b.size.Add(uint64(m.Size()))
+		rm := b.pushBackWithRLock(m)
+		b.RUnlock()
+		b.m.messageReplayed.Inc(1)
+		b.m.replayLag.Record(time.Since(spilledAt))
+		if err := b.writeFn(rm); err != nil {
+			b.m.replayError.Inc(1)
+			b.logger.Error("could not write replayed message", zap.Error(err))
+		}
+	}
+	// Persist the position of the oldest replayed message not yet consumed.
+	if err := b.spill.Sync(); err != nil {
+		b.logger.Error("could not sync spill log", zap.Error(err))
+	}
+	b.m.byteSpillPending.Update(float64(b.spill.PendingBytes()))
+}
+
 func (b *buffer) Close(ct producer.CloseType) {
 	// Stop taking writes right away.
 	b.Lock()
@@ -365,12 +499,25 @@ func (b *buffer) Close(ct producer.CloseType) {
 	b.isClosed = true
 	if ct == producer.DropEverything {
 		b.forceDrop = true
+		if b.spill != nil {
+			// Replayed messages dropped on close are replayed again
+			// once the buffer is opened again.
+			b.spill.KeepDropped()
+		}
 	}
 	b.Unlock()
 	b.waitUntilAllDataConsumed()
 	close(b.doneCh)
 	close(b.dropOldestCh)
 	b.wg.Wait()
+	if b.spill == nil {
+		return
+	}
+	// NB: Messages pending in the spill log, or replayed but not yet
+	// consumed, are kept on disk and replayed once the buffer is opened again.
+	if err := b.spill.Close(); err != nil {
+		b.logger.Error("could not close spill log", zap.Error(err))
+	}
 }
 
 func (b *buffer) waitUntilAllDataConsumed() {
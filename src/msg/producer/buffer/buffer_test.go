@@ -21,6 +21,8 @@
 package buffer
 
 import (
+	"io/ioutil"
+	"os"
 	"sync"
 	"testing"
 	"time"
@@ -48,6 +50,21 @@ func TestOptionsValidation(t *testing.T) {
 
 	opts = opts.SetScanBatchSize(0)
 	require.Equal(t, errInvalidScanBatchSize, opts.Validate())
+
+	opts = NewOptions().SetOnFullStrategy(SpillToDisk)
+	require.Equal(t, errNoSpillDir, opts.Validate())
+
+	opts = opts.SetSpillDir("/tmp/spill").SetSpillSegmentSize(0)
+	require.Equal(t, errInvalidSpillSegment, opts.Validate())
+
+	opts = opts.SetSpillSegmentSize(1024).SetSpillReplayInterval(0)
+	require.Equal(t, errInvalidReplayInterval, opts.Validate())
+
+	opts = opts.SetSpillReplayInterval(time.Second).SetSpillSyncInterval(0)
+	require.Equal(t, errInvalidSyncInterval, opts.Validate())
+
+	opts = opts.SetSpillSyncInterval(time.Second).SetSpillSyncBatchSize(0)
+	require.Equal(t, errInvalidSyncBatchSize, opts.Validate())
 }
 
 func TestBuffer(t *testing.T) {
@@ -273,7 +290,7 @@ func TestBufferCleanupBackground(t *testing.T) {
 	require.Equal(t, rm.Size(), uint64(mm.Size()))
 	require.Equal(t, rm.Size(), b.size.Load())
 
-	b.Init()
+	b.Init(nil)
 	mm.EXPECT().Finalize(producer.Consumed)
 	rm.IncRef()
 	rm.DecRef()
@@ -327,7 +344,7 @@ func TestBufferCloseDropEverything(t *testing.T) {
 	require.Equal(t, rm.Size(), uint64(mm.Size()))
 	require.Equal(t, rm.Size(), b.size.Load())
 
-	b.Init()
+	b.Init(nil)
 	mm.EXPECT().Finalize(producer.Dropped)
 	b.Close(producer.DropEverything)
 	for {
@@ -383,7 +400,7 @@ func TestBufferDropOldestAsyncOnFull(t *testing.T) {
 	mm.EXPECT().Finalize(producer.Dropped).Do(func(interface{}) {
 		wg.Done()
 	}).Times(2)
-	b.Init()
+	b.Init(nil)
 	wg.Wait()
 	require.True(t, rd1.IsDroppedOrConsumed())
 	require.True(t, rd2.IsDroppedOrConsumed())
@@ -471,6 +488,361 @@ func TestBufferReturnErrorOnFull(t *testing.T) {
 	require.Equal(t, 300, int(b.size.Load()))
 }
 
+func TestBufferSpillToDiskOnFull(t *testing.T) {
+	defer leaktest.Check(t)()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir, err := ioutil.TempDir("", "buffer-spill")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	newMessage := func(shard uint32, data string) *producer.MockMessage {
+		mm := producer.NewMockMessage(ctrl)
+		mm.EXPECT().Shard().Return(shard).AnyTimes()
+		mm.EXPECT().Bytes().Return([]byte(data)).AnyTimes()
+		mm.EXPECT().Size().Return(len(data)).AnyTimes()
+		return mm
+	}
+	mm1 := newMessage(1, "foo")
+	mm2 := newMessage(2, "bar")
+	mm3 := newMessage(3, "baz")
+
+	b := mustNewBuffer(t, testOptions().
+		SetMaxMessageSize(3).
+		SetMaxBufferSize(3).
+		SetOnFullStrategy(SpillToDisk).
+		SetSpillDir(dir).
+		SetSpillSegmentSize(1).
+		SetSpillSyncBatchSize(1),
+	)
+
+	rm1, err := b.Add(mm1)
+	require.NoError(t, err)
+	require.NotNil(t, rm1)
+	require.Equal(t, 3, int(b.size.Load()))
+
+	// The buffer is full, new messages are spilled to disk.
+	mm2.EXPECT().Finalize(producer.Consumed)
+	rm, err := b.Add(mm2)
+	require.NoError(t, err)
+	require.Nil(t, rm)
+	mm3.EXPECT().Finalize(producer.Consumed)
+	rm, err = b.Add(mm3)
+	require.NoError(t, err)
+	require.Nil(t, rm)
+	require.Equal(t, 3, int(b.size.Load()))
+	require.Equal(t, int64(2*(spillRecordHeaderLen+3)), b.spill.PendingBytes())
+
+	var written []*producer.RefCountedMessage
+	b.writeFn = func(rm *producer.RefCountedMessage) error {
+		written = append(written, rm)
+		return nil
+	}
+
+	// Nothing is replayed while the buffer is full.
+	b.replay()
+	require.Empty(t, written)
+
+	mm1.EXPECT().Finalize(producer.Consumed)
+	rm1.IncRef()
+	rm1.DecRef()
+	require.Equal(t, 0, int(b.size.Load()))
+
+	b.replay()
+	require.Equal(t, 1, len(written))
+	require.Equal(t, uint32(2), written[0].Shard())
+	require.Equal(t, []byte("bar"), written[0].Bytes())
+	require.Equal(t, 3, int(b.size.Load()))
+
+	// New messages are spilled while there are spilled messages pending.
+	written[0].IncRef()
+	written[0].DecRef()
+	require.Equal(t, 0, int(b.size.Load()))
+	mm4 := newMessage(4, "qux")
+	mm4.EXPECT().Finalize(producer.Consumed)
+	rm, err = b.Add(mm4)
+	require.NoError(t, err)
+	require.Nil(t, rm)
+
+	b.replay()
+	require.Equal(t, 2, len(written))
+	require.Equal(t, uint32(3), written[1].Shard())
+	require.Equal(t, []byte("baz"), written[1].Bytes())
+	written[1].IncRef()
+	written[1].DecRef()
+
+	b.replay()
+	require.Equal(t, 3, len(written))
+	require.Equal(t, uint32(4), written[2].Shard())
+	require.Equal(t, []byte("qux"), written[2].Bytes())
+	require.Equal(t, int64(0), b.spill.PendingBytes())
+	written[2].IncRef()
+	written[2].DecRef()
+
+	b.Init(b.writeFn)
+	b.Close(producer.WaitForConsumption)
+}
+
+func TestBufferSpillToDiskReplaysOnceStarted(t *testing.T) {
+	defer leaktest.Check(t)()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir, err := ioutil.TempDir("", "buffer-spill")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	opts := testOptions().
+		SetMaxMessageSize(3).
+		SetMaxBufferSize(3).
+		SetOnFullStrategy(SpillToDisk).
+		SetSpillDir(dir).
+		SetSpillReplayInterval(10 * time.Millisecond).
+		SetSpillSyncBatchSize(1)
+	b := mustNewBuffer(t, opts)
+
+	newMessage := func(shard uint32, data string) *producer.MockMessage {
+		mm := producer.NewMockMessage(ctrl)
+		mm.EXPECT().Shard().Return(shard).AnyTimes()
+		mm.EXPECT().Bytes().Return([]byte(data)).AnyTimes()
+		mm.EXPECT().Size().Return(len(data)).AnyTimes()
+		return mm
+	}
+	mm1 := newMessage(1, "foo")
+	rm1, err := b.Add(mm1)
+	require.NoError(t, err)
+	mm2 := newMessage(2, "bar")
+	mm2.EXPECT().Finalize(producer.Consumed)
+	rm, err := b.Add(mm2)
+	require.NoError(t, err)
+	require.Nil(t, rm)
+
+	var (
+		lock    sync.Mutex
+		written []*producer.RefCountedMessage
+	)
+	b.Init(func(rm *producer.RefCountedMessage) error {
+		lock.Lock()
+		written = append(written, rm)
+		lock.Unlock()
+		return nil
+	})
+	mm1.EXPECT().Finalize(producer.Consumed)
+	rm1.IncRef()
+	rm1.DecRef()
+
+	// Spilled messages are not replayed until the replay is started.
+	time.Sleep(100 * time.Millisecond)
+	lock.Lock()
+	require.Empty(t, written)
+	lock.Unlock()
+	require.Equal(t, int64(spillRecordHeaderLen+3), b.spill.PendingBytes())
+
+	b.StartReplay()
+	for {
+		lock.Lock()
+		n := len(written)
+		lock.Unlock()
+		if n == 1 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.Equal(t, []byte("bar"), written[0].Bytes())
+	written[0].IncRef()
+	written[0].DecRef()
+	b.Close(producer.WaitForConsumption)
+}
+
+func TestBufferSpillToDiskSurvivesRestart(t *testing.T) {
+	defer leaktest.Check(t)()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir, err := ioutil.TempDir("", "buffer-spill")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	opts := testOptions().
+		SetMaxMessageSize(3).
+		SetMaxBufferSize(3).
+		SetOnFullStrategy(SpillToDisk).
+		SetSpillDir(dir).
+		SetSpillSegmentSize(2 * (spillRecordHeaderLen + 3)).
+		SetSpillSyncBatchSize(1)
+	b := mustNewBuffer(t, opts)
+
+	mm := producer.NewMockMessage(ctrl)
+	mm.EXPECT().Size().Return(3).AnyTimes()
+	rm, err := b.Add(mm)
+	require.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		m := producer.NewMockMessage(ctrl)
+		m.EXPECT().Shard().Return(uint32(i)).AnyTimes()
+		m.EXPECT().Bytes().Return([]byte{byte(i), byte(i), byte(i)}).AnyTimes()
+		m.EXPECT().Size().Return(3).AnyTimes()
+		m.EXPECT().Finalize(producer.Consumed)
+		rm, err := b.Add(m)
+		require.NoError(t, err)
+		require.Nil(t, rm)
+	}
+
+	// Replay the first two spilled messages before restarting, only the
+	// second one is consumed.
+	mm.EXPECT().Finalize(producer.Consumed)
+	rm.IncRef()
+	rm.DecRef()
+	var written []*producer.RefCountedMessage
+	b.writeFn = func(rm *producer.RefCountedMessage) error {
+		written = append(written, rm)
+		return nil
+	}
+	b.replay()
+	require.Equal(t, 1, len(written))
+	written[0].IncRef()
+	b.size.Store(0)
+	b.replay()
+	require.Equal(t, 2, len(written))
+	require.Equal(t, uint32(0), written[0].Shard())
+	require.Equal(t, uint32(1), written[1].Shard())
+	written[1].IncRef()
+	written[1].DecRef()
+	require.NoError(t, b.spill.Close())
+
+	// The log is replayed from the oldest message that was not consumed.
+	b = mustNewBuffer(t, opts)
+	require.Equal(t, int64(5*(spillRecordHeaderLen+3)), b.spill.PendingBytes())
+	written = written[:0]
+	b.writeFn = func(rm *producer.RefCountedMessage) error {
+		written = append(written, rm)
+		rm.IncRef()
+		rm.DecRef()
+		b.size.Store(0)
+		return nil
+	}
+	b.replay()
+	require.Equal(t, 5, len(written))
+	for i, rm := range written {
+		require.Equal(t, uint32(i), rm.Shard())
+		require.Equal(t, []byte{byte(i), byte(i), byte(i)}, rm.Bytes())
+	}
+	require.Equal(t, int64(0), b.spill.PendingBytes())
+	require.NoError(t, b.spill.Close())
+
+	// All the messages have been consumed, only the segment being written
+	// is left behind.
+	b = mustNewBuffer(t, opts)
+	require.Equal(t, int64(0), b.spill.PendingBytes())
+	indexes, err := listSpillSegments(dir)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(indexes))
+	require.NoError(t, b.spill.Close())
+}
+
+func TestBufferSpillToDiskCloseDropEverything(t *testing.T) {
+	defer leaktest.Check(t)()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir, err := ioutil.TempDir("", "buffer-spill")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	opts := testOptions().
+		SetMaxMessageSize(3).
+		SetMaxBufferSize(3).
+		SetOnFullStrategy(SpillToDisk).
+		SetSpillDir(dir).
+		SetSpillSyncBatchSize(1).
+		SetCloseCheckInterval(time.Millisecond)
+	b := mustNewBuffer(t, opts)
+
+	m := producer.NewMockMessage(ctrl)
+	m.EXPECT().Shard().Return(uint32(1)).AnyTimes()
+	m.EXPECT().Bytes().Return([]byte("foo")).AnyTimes()
+	m.EXPECT().Size().Return(3).AnyTimes()
+	m.EXPECT().Finalize(producer.Consumed)
+	b.size.Store(3)
+	rm, err := b.Add(m)
+	require.NoError(t, err)
+	require.Nil(t, rm)
+
+	// The replayed message is dropped on close before it is consumed.
+	b.size.Store(0)
+	var written []*producer.RefCountedMessage
+	b.writeFn = func(rm *producer.RefCountedMessage) error {
+		written = append(written, rm)
+		return nil
+	}
+	b.replay()
+	require.Equal(t, 1, len(written))
+	b.Init(b.writeFn)
+	b.Close(producer.DropEverything)
+	require.True(t, written[0].IsDroppedOrConsumed())
+
+	// The dropped message is replayed again once the buffer is reopened.
+	b = mustNewBuffer(t, opts)
+	require.Equal(t, int64(spillRecordHeaderLen+3), b.spill.PendingBytes())
+	written = written[:0]
+	b.writeFn = func(rm *producer.RefCountedMessage) error {
+		written = append(written, rm)
+		return nil
+	}
+	b.replay()
+	require.Equal(t, 1, len(written))
+	require.Equal(t, []byte("foo"), written[0].Bytes())
+	require.NoError(t, b.spill.Close())
+}
+
+func TestBufferSpillToDiskSyncBatch(t *testing.T) {
+	defer leaktest.Check(t)()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir, err := ioutil.TempDir("", "buffer-spill")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	b := mustNewBuffer(t, testOptions().
+		SetMaxMessageSize(3).
+		SetMaxBufferSize(3).
+		SetOnFullStrategy(SpillToDisk).
+		SetSpillDir(dir).
+		SetSpillSyncBatchSize(2),
+	)
+	b.size.Store(3)
+
+	var finalized []int
+	for i := 0; i < 3; i++ {
+		i := i
+		m := producer.NewMockMessage(ctrl)
+		m.EXPECT().Shard().Return(uint32(i)).AnyTimes()
+		m.EXPECT().Bytes().Return([]byte("foo")).AnyTimes()
+		m.EXPECT().Size().Return(3).AnyTimes()
+		m.EXPECT().Finalize(producer.Consumed).Do(func(producer.FinalizeReason) {
+			finalized = append(finalized, i)
+		})
+		rm, err := b.Add(m)
+		require.NoError(t, err)
+		require.Nil(t, rm)
+	}
+
+	// Messages are finalized once a batch of their records has been synced.
+	require.Equal(t, []int{0, 1}, finalized)
+
+	// Flushing syncs the rest of the records.
+	require.NoError(t, b.spill.Flush())
+	require.Equal(t, []int{0, 1, 2}, finalized)
+	require.NoError(t, b.spill.Close())
+}
+
 func mustNewBuffer(t testing.TB, opts Options) *buffer {
 	b, err := NewBuffer(opts)
 	require.NoError(t, err)
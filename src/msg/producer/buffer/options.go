@@ -37,6 +37,10 @@ const (
 	defaultCleanupInitialBackoff = 10 * time.Second
 	defaultAllowedSpilloverRatio = 0.2
 	defaultCleanupMaxBackoff     = time.Minute
+	defaultSpillSegmentSize      = 64 * 1024 * 1024 // 64MB.
+	defaultSpillReplayInterval   = time.Second
+	defaultSpillSyncInterval     = time.Second
+	defaultSpillSyncBatchSize    = 1024
 )
 
 var (
@@ -44,6 +48,11 @@ var (
 	errInvalidMaxMessageSize  = errors.New("invalid max message size")
 	errNegativeMaxBufferSize  = errors.New("negative max buffer size")
 	errNegativeMaxMessageSize = errors.New("negative max message size")
+	errNoSpillDir             = errors.New("no spill dir")
+	errInvalidSpillSegment    = errors.New("invalid spill segment size")
+	errInvalidReplayInterval  = errors.New("invalid spill replay interval")
+	errInvalidSyncInterval    = errors.New("invalid spill sync interval")
+	errInvalidSyncBatchSize   = errors.New("invalid spill sync batch size")
 )
 
 type bufferOptions struct {
@@ -54,6 +63,11 @@ type bufferOptions struct {
 	dropOldestInterval    time.Duration
 	scanBatchSize         int
 	allowedSpilloverRatio float64
+	spillDir              string
+	spillSegmentSize      int
+	spillReplayInterval   time.Duration
+	spillSyncInterval     time.Duration
+	spillSyncBatchSize    int
 	rOpts                 retry.Options
 	iOpts                 instrument.Options
 }
@@ -68,6 +82,10 @@ func NewOptions() Options {
 		dropOldestInterval:    defaultDropOldestInterval,
 		scanBatchSize:         defaultScanBatchSize,
 		allowedSpilloverRatio: defaultAllowedSpilloverRatio,
+		spillSegmentSize:      defaultSpillSegmentSize,
+		spillReplayInterval:   defaultSpillReplayInterval,
+		spillSyncInterval:     defaultSpillSyncInterval,
+		spillSyncBatchSize:    defaultSpillSyncBatchSize,
 		rOpts: retry.NewOptions().
 			SetInitialBackoff(defaultCleanupInitialBackoff).
 			SetMaxBackoff(defaultCleanupMaxBackoff).
@@ -146,6 +164,56 @@ func (opts *bufferOptions) SetAllowedSpilloverRatio(value float64) Options {
 	return &o
 }
 
+func (opts *bufferOptions) SpillDir() string {
+	return opts.spillDir
+}
+
+func (opts *bufferOptions) SetSpillDir(value string) Options {
+	o := *opts
+	o.spillDir = value
+	return &o
+}
+
+func (opts *bufferOptions) SpillSegmentSize() int {
+	return opts.spillSegmentSize
+}
+
+func (opts *bufferOptions) SetSpillSegmentSize(value int) Options {
+	o := *opts
+	o.spillSegmentSize = value
+	return &o
+}
+
+func (opts *bufferOptions) SpillReplayInterval() time.Duration {
+	return opts.spillReplayInterval
+}
+
+func (opts *bufferOptions) SetSpillReplayInterval(value time.Duration) Options {
+	o := *opts
+	o.spillReplayInterval = value
+	return &o
+}
+
+func (opts *bufferOptions) SpillSyncInterval() time.Duration {
+	return opts.spillSyncInterval
+}
+
+func (opts *bufferOptions) SetSpillSyncInterval(value time.Duration) Options {
+	o := *opts
+	o.spillSyncInterval = value
+	return &o
+}
+
+func (opts *bufferOptions) SpillSyncBatchSize() int {
+	return opts.spillSyncBatchSize
+}
+
+func (opts *bufferOptions) SetSpillSyncBatchSize(value int) Options {
+	o := *opts
+	o.spillSyncBatchSize = value
+	return &o
+}
+
 func (opts *bufferOptions) CleanupRetryOptions() retry.Options {
 	return opts.rOpts
 }
@@ -180,5 +248,23 @@ func (opts *bufferOptions) Validate() error {
 		// Max message size can only be as large as max buffer size.
 		return errInvalidMaxMessageSize
 	}
+	if opts.OnFullStrategy() != SpillToDisk {
+		return nil
+	}
+	if opts.SpillDir() == "" {
+		return errNoSpillDir
+	}
+	if opts.SpillSegmentSize() <= 0 {
+		return errInvalidSpillSegment
+	}
+	if opts.SpillReplayInterval() <= 0 {
+		return errInvalidReplayInterval
+	}
+	if opts.SpillSyncInterval() <= 0 {
+		return errInvalidSyncInterval
+	}
+	if opts.SpillSyncBatchSize() <= 0 {
+		return errInvalidSyncBatchSize
+	}
 	return nil
 }
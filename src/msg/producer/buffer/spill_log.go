@@ -0,0 +1,569 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package buffer
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/msg/producer"
+
+	"go.uber.org/atomic"
+)
+
+const (
+	spillSegmentPrefix     = "segment-"
+	spillSegmentSuffix     = ".log"
+	spillCheckpointFile    = "checkpoint"
+	spillCheckpointLen     = 16
+	spillDirPermission     = 0755
+	spillFilePermission    = 0644
+	spillReadBufferSize    = 64 * 1024
+	spillRecordHeaderLen   = 20
+	spillRecordChecksumLen = 4
+)
+
+var (
+	errSpillLogClosed      = errors.New("spill log closed")
+	errSpillLogEmpty       = errors.New("spill log empty")
+	errSpillRecordCorrupt  = errors.New("spill record corrupt")
+	errSpillCheckpointSize = errors.New("invalid spill checkpoint size")
+)
+
+// spillLog is a segmented append only log of messages that did not fit
+// into the in memory buffer.
+//
+// Each record is laid out as:
+// | checksum (4) | spilled at nanos (8) | shard (4) | length (4) | bytes |
+// where the checksum covers everything after itself. Records are appended
+// to the last segment, which is rotated once it grows beyond the segment
+// size, and read from the first segment. The segment being written is synced
+// to disk once enough records have been appended to it or when the log is
+// flushed, and the messages appended are only finalized once their records
+// have been synced.
+//
+// Messages read from the log are acked once they have been consumed. The
+// position of the oldest message read but not yet acked is persisted in a
+// checkpoint file, and segments are only removed once the checkpoint has
+// moved past them, so the log is replayed from the oldest unacked message
+// after a restart.
+type spillLog struct {
+	sync.Mutex
+
+	dir            string
+	segmentSize    int64
+	syncBatchSize  int
+	maxMessageSize int
+
+	segments   []uint64
+	writeFile  *os.File
+	writeSize  int64
+	unsynced   []producer.Message
+	readIndex  uint64
+	readFile   *os.File
+	reader     *bufio.Reader
+	readOffset int64
+	inflight   []spillInflightRecord
+	nextSeq    uint64
+	checkpoint spillPosition
+	keepDrops  bool
+	pending    *atomic.Int64
+	closed     bool
+}
+
+// spillPosition is the position of a record in the log.
+type spillPosition struct {
+	index  uint64
+	offset int64
+}
+
+// spillInflightRecord is a record that has been read but not yet acked.
+type spillInflightRecord struct {
+	seq   uint64
+	pos   spillPosition
+	acked bool
+}
+
+func openSpillLog(
+	dir string,
+	segmentSize int,
+	syncBatchSize int,
+	maxMessageSize int,
+) (*spillLog, error) {
+	if err := os.MkdirAll(dir, spillDirPermission); err != nil {
+		return nil, err
+	}
+	indexes, err := listSpillSegments(dir)
+	if err != nil {
+		return nil, err
+	}
+	checkpoint, err := readSpillCheckpoint(dir)
+	if err != nil {
+		return nil, err
+	}
+	l := &spillLog{
+		dir:            dir,
+		segmentSize:    int64(segmentSize),
+		syncBatchSize:  syncBatchSize,
+		maxMessageSize: maxMessageSize,
+		checkpoint:     checkpoint,
+		pending:        atomic.NewInt64(0),
+	}
+	nextIndex := checkpoint.index
+	for _, index := range indexes {
+		path := l.segmentPath(index)
+		if index < checkpoint.index {
+			// The segment was fully acked before the restart.
+			if err := os.Remove(path); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		fi, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		size := fi.Size()
+		if index == checkpoint.index {
+			size -= checkpoint.offset
+		}
+		l.segments = append(l.segments, index)
+		l.pending.Add(size)
+		nextIndex = index + 1
+	}
+	if len(l.segments) > 0 {
+		l.readIndex = l.segments[0]
+		if l.readIndex == checkpoint.index {
+			l.readOffset = checkpoint.offset
+		}
+	} else {
+		l.readIndex = nextIndex
+	}
+	// NB: Always append to a new segment, the last segment might
+	// have been left with a partially written record.
+	if _, err := l.rotateWithLock(nextIndex); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// PendingBytes returns the number of bytes that have not been replayed.
+func (l *spillLog) PendingBytes() int64 {
+	return l.pending.Load()
+}
+
+// Append appends the message to the log and returns the size of the record.
+// The message is finalized once its record has been synced to disk.
+func (l *spillLog) Append(m producer.Message, spilledAt time.Time) (int, error) {
+	data := m.Bytes()
+	record := make([]byte, spillRecordHeaderLen+len(data))
+	binary.BigEndian.PutUint64(record[4:], uint64(spilledAt.UnixNano()))
+	binary.BigEndian.PutUint32(record[12:], m.Shard())
+	binary.BigEndian.PutUint32(record[16:], uint32(len(data)))
+	copy(record[spillRecordHeaderLen:], data)
+	binary.BigEndian.PutUint32(record, crc32.ChecksumIEEE(record[spillRecordChecksumLen:]))
+
+	l.Lock()
+	if l.closed {
+		l.Unlock()
+		return 0, errSpillLogClosed
+	}
+	var (
+		synced []producer.Message
+		err    error
+	)
+	if l.writeSize >= l.segmentSize {
+		synced, err = l.rotateWithLock(l.segments[len(l.segments)-1] + 1)
+		if err != nil {
+			l.Unlock()
+			finalizeSpilled(synced)
+			return 0, err
+		}
+	}
+	n, err := l.writeFile.Write(record)
+	l.writeSize += int64(n)
+	l.pending.Add(int64(n))
+	if err != nil {
+		// Move on to a new segment so the partially written record
+		// is always at the tail of a segment the reader can skip.
+		synced, rerr := l.rotateWithLock(l.segments[len(l.segments)-1] + 1)
+		l.Unlock()
+		finalizeSpilled(synced)
+		if rerr != nil {
+			return 0, fmt.Errorf("%v, could not rotate segment: %v", err, rerr)
+		}
+		return 0, err
+	}
+	l.unsynced = append(l.unsynced, m)
+	if len(l.unsynced) >= l.syncBatchSize {
+		synced, err = l.syncSegmentWithLock()
+	}
+	l.Unlock()
+	finalizeSpilled(synced)
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// Flush syncs the segment being written to disk and finalizes the messages
+// appended to it.
+func (l *spillLog) Flush() error {
+	l.Lock()
+	if l.closed {
+		l.Unlock()
+		return errSpillLogClosed
+	}
+	synced, err := l.syncSegmentWithLock()
+	l.Unlock()
+	finalizeSpilled(synced)
+	return err
+}
+
+// Next returns the next message in the log and the time it was spilled,
+// it returns errSpillLogEmpty when all the messages have been read. The
+// message acks its record when it is finalized once consumed.
+func (l *spillLog) Next() (producer.Message, time.Time, error) {
+	l.Lock()
+	defer l.Unlock()
+
+	if l.closed {
+		return nil, time.Time{}, errSpillLogClosed
+	}
+	for {
+		if l.reader == nil {
+			if err := l.openReadWithLock(); err != nil {
+				return nil, time.Time{}, err
+			}
+		}
+		pos := spillPosition{index: l.readIndex, offset: l.readOffset}
+		shard, data, spilledAt, n, err := readSpillRecord(l.reader, l.maxMessageSize)
+		if err == nil {
+			l.readOffset += int64(n)
+			l.pending.Sub(int64(n))
+			m := spilledMessage{
+				log:   l,
+				seq:   l.nextSeq,
+				shard: shard,
+				data:  data,
+			}
+			l.inflight = append(l.inflight, spillInflightRecord{seq: l.nextSeq, pos: pos})
+			l.nextSeq++
+			return m, spilledAt, nil
+		}
+		if err != io.EOF && err != io.ErrUnexpectedEOF && err != errSpillRecordCorrupt {
+			return nil, time.Time{}, err
+		}
+		if l.readIndex == l.segments[len(l.segments)-1] {
+			// Caught up with the segment being written, reopen the reader
+			// from the last read offset next time as part of a record might
+			// have been buffered.
+			l.closeReadWithLock()
+			return nil, time.Time{}, errSpillLogEmpty
+		}
+		// The segment has been fully read, or is left with a partially
+		// written record at its tail, move on to the next segment.
+		if err := l.nextReadSegmentWithLock(); err != nil {
+			return nil, time.Time{}, err
+		}
+	}
+}
+
+// KeepDropped makes records of messages dropped from now on be replayed
+// again once the log is reopened, rather than being acked.
+func (l *spillLog) KeepDropped() {
+	l.Lock()
+	l.keepDrops = true
+	l.Unlock()
+}
+
+func (l *spillLog) ack(seq uint64, r producer.FinalizeReason) {
+	l.Lock()
+	defer l.Unlock()
+
+	if r == producer.Dropped && l.keepDrops {
+		return
+	}
+	if len(l.inflight) == 0 || seq < l.inflight[0].seq {
+		return
+	}
+	idx := int(seq - l.inflight[0].seq)
+	if idx >= len(l.inflight) {
+		return
+	}
+	l.inflight[idx].acked = true
+	var numAcked int
+	for numAcked < len(l.inflight) && l.inflight[numAcked].acked {
+		numAcked++
+	}
+	l.inflight = l.inflight[numAcked:]
+}
+
+// Sync persists the position of the oldest message read but not yet acked,
+// and removes the segments before it.
+func (l *spillLog) Sync() error {
+	l.Lock()
+	defer l.Unlock()
+
+	if l.closed {
+		return errSpillLogClosed
+	}
+	return l.syncCheckpointWithLock()
+}
+
+// Close syncs the log and closes it.
+func (l *spillLog) Close() error {
+	l.Lock()
+	if l.closed {
+		l.Unlock()
+		return errSpillLogClosed
+	}
+	l.closed = true
+	synced, err := l.syncSegmentWithLock()
+	if cerr := l.syncCheckpointWithLock(); err == nil {
+		err = cerr
+	}
+	l.closeReadWithLock()
+	if cerr := l.writeFile.Close(); err == nil {
+		err = cerr
+	}
+	l.Unlock()
+	finalizeSpilled(synced)
+	return err
+}
+
+func (l *spillLog) rotateWithLock(index uint64) ([]producer.Message, error) {
+	var synced []producer.Message
+	if l.writeFile != nil {
+		var err error
+		if synced, err = l.syncSegmentWithLock(); err != nil {
+			return synced, err
+		}
+	}
+	f, err := os.OpenFile(
+		l.segmentPath(index),
+		os.O_CREATE|os.O_WRONLY|os.O_APPEND,
+		spillFilePermission,
+	)
+	if err != nil {
+		return synced, err
+	}
+	if l.writeFile != nil {
+		l.writeFile.Close()
+	}
+	l.writeFile = f
+	l.writeSize = 0
+	l.segments = append(l.segments, index)
+	return synced, nil
+}
+
+// syncSegmentWithLock syncs the segment being written and returns the
+// messages appended to it since the last sync, which the caller finalizes
+// once the lock is released.
+func (l *spillLog) syncSegmentWithLock() ([]producer.Message, error) {
+	if len(l.unsynced) == 0 {
+		return nil, nil
+	}
+	if err := l.writeFile.Sync(); err != nil {
+		return nil, err
+	}
+	synced := l.unsynced
+	l.unsynced = nil
+	return synced, nil
+}
+
+func (l *spillLog) openReadWithLock() error {
+	f, err := os.Open(l.segmentPath(l.readIndex))
+	if err != nil {
+		return err
+	}
+	if _, err := f.Seek(l.readOffset, io.SeekStart); err != nil {
+		f.Close()
+		return err
+	}
+	l.readFile = f
+	l.reader = bufio.NewReaderSize(f, spillReadBufferSize)
+	return nil
+}
+
+func (l *spillLog) closeReadWithLock() {
+	if l.readFile == nil {
+		return
+	}
+	l.readFile.Close()
+	l.readFile = nil
+	l.reader = nil
+}
+
+func (l *spillLog) nextReadSegmentWithLock() error {
+	fi, err := l.readFile.Stat()
+	if err != nil {
+		return err
+	}
+	// Account for any partially written record left unread.
+	l.pending.Sub(fi.Size() - l.readOffset)
+	l.closeReadWithLock()
+	for _, index := range l.segments {
+		if index > l.readIndex {
+			l.readIndex = index
+			break
+		}
+	}
+	l.readOffset = 0
+	return nil
+}
+
+func (l *spillLog) syncCheckpointWithLock() error {
+	checkpoint := spillPosition{index: l.readIndex, offset: l.readOffset}
+	if len(l.inflight) > 0 {
+		checkpoint = l.inflight[0].pos
+	}
+	if checkpoint == l.checkpoint {
+		return nil
+	}
+	var buf [spillCheckpointLen]byte
+	binary.BigEndian.PutUint64(buf[:], checkpoint.index)
+	binary.BigEndian.PutUint64(buf[8:], uint64(checkpoint.offset))
+	tmpPath := filepath.Join(l.dir, spillCheckpointFile+".tmp")
+	if err := ioutil.WriteFile(tmpPath, buf[:], spillFilePermission); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, filepath.Join(l.dir, spillCheckpointFile)); err != nil {
+		return err
+	}
+	l.checkpoint = checkpoint
+	// NB: The segments are only removed once the checkpoint has been
+	// persisted past them so they are never replayed again after a restart.
+	for len(l.segments) > 0 && l.segments[0] < checkpoint.index {
+		if err := os.Remove(l.segmentPath(l.segments[0])); err != nil {
+			return err
+		}
+		l.segments = l.segments[1:]
+	}
+	return nil
+}
+
+func (l *spillLog) segmentPath(index uint64) string {
+	return filepath.Join(l.dir, fmt.Sprintf("%s%020d%s", spillSegmentPrefix, index, spillSegmentSuffix))
+}
+
+func listSpillSegments(dir string) ([]uint64, error) {
+	fis, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var indexes []uint64
+	for _, fi := range fis {
+		name := fi.Name()
+		if fi.IsDir() ||
+			!strings.HasPrefix(name, spillSegmentPrefix) ||
+			!strings.HasSuffix(name, spillSegmentSuffix) {
+			continue
+		}
+		str := strings.TrimSuffix(strings.TrimPrefix(name, spillSegmentPrefix), spillSegmentSuffix)
+		index, err := strconv.ParseUint(str, 10, 64)
+		if err != nil {
+			continue
+		}
+		indexes = append(indexes, index)
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+	return indexes, nil
+}
+
+func readSpillCheckpoint(dir string) (spillPosition, error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, spillCheckpointFile))
+	if os.IsNotExist(err) {
+		return spillPosition{}, nil
+	}
+	if err != nil {
+		return spillPosition{}, err
+	}
+	if len(b) != spillCheckpointLen {
+		return spillPosition{}, errSpillCheckpointSize
+	}
+	return spillPosition{
+		index:  binary.BigEndian.Uint64(b),
+		offset: int64(binary.BigEndian.Uint64(b[8:])),
+	}, nil
+}
+
+func readSpillRecord(
+	r io.Reader,
+	maxMessageSize int,
+) (uint32, []byte, time.Time, int, error) {
+	var header [spillRecordHeaderLen]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return 0, nil, time.Time{}, 0, err
+	}
+	length := binary.BigEndian.Uint32(header[16:])
+	if int64(length) > int64(maxMessageSize) {
+		return 0, nil, time.Time{}, 0, errSpillRecordCorrupt
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return 0, nil, time.Time{}, 0, err
+	}
+	checksum := crc32.ChecksumIEEE(header[spillRecordChecksumLen:])
+	checksum = crc32.Update(checksum, crc32.IEEETable, data)
+	if checksum != binary.BigEndian.Uint32(header[:]) {
+		return 0, nil, time.Time{}, 0, errSpillRecordCorrupt
+	}
+	shard := binary.BigEndian.Uint32(header[12:])
+	spilledAt := time.Unix(0, int64(binary.BigEndian.Uint64(header[4:])))
+	return shard, data, spilledAt, spillRecordHeaderLen + len(data), nil
+}
+
+// finalizeSpilled finalizes messages whose records have been synced to disk,
+// they will be recreated from the spill log when they are replayed.
+func finalizeSpilled(messages []producer.Message) {
+	for _, m := range messages {
+		m.Finalize(producer.Consumed)
+	}
+}
+
+// spilledMessage is a message replayed from the spill log.
+type spilledMessage struct {
+	log   *spillLog
+	seq   uint64
+	shard uint32
+	data  []byte
+}
+
+func (m spilledMessage) Shard() uint32 { return m.shard }
+
+func (m spilledMessage) Bytes() []byte { return m.data }
+
+func (m spilledMessage) Size() int { return len(m.data) }
+
+func (m spilledMessage) Finalize(r producer.FinalizeReason) { m.log.ack(m.seq, r) }
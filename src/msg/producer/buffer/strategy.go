@@ -26,6 +26,7 @@ var (
 	validStrategies = []OnFullStrategy{
 		ReturnError,
 		DropOldest,
+		SpillToDisk,
 	}
 )
 
@@ -39,6 +39,11 @@ const (
 	// will be dropped to make room for new buffer requests
 	// when the buffer is full.
 	DropOldest OnFullStrategy = "dropOldest"
+
+	// SpillToDisk means new messages will be appended to a local
+	// segmented log when the buffer is full, and replayed in order
+	// once the buffered messages have been consumed.
+	SpillToDisk OnFullStrategy = "spillToDisk"
 )
 
 // Options configs the buffer.
@@ -90,6 +95,48 @@ type Options interface {
 	// SetAllowedSpilloverRatio sets the ratio for allowed buffer spill over.
 	SetAllowedSpilloverRatio(value float64) Options
 
+	// SpillDir returns the directory of the spill log, only used
+	// when the on full strategy is SpillToDisk.
+	SpillDir() string
+
+	// SetSpillDir sets the directory of the spill log, only used
+	// when the on full strategy is SpillToDisk.
+	SetSpillDir(value string) Options
+
+	// SpillSegmentSize returns the size in bytes after which the
+	// spill log rotates to a new segment.
+	SpillSegmentSize() int
+
+	// SetSpillSegmentSize sets the size in bytes after which the
+	// spill log rotates to a new segment.
+	SetSpillSegmentSize(value int) Options
+
+	// SpillReplayInterval returns the interval to replay spilled
+	// messages into the buffer.
+	SpillReplayInterval() time.Duration
+
+	// SetSpillReplayInterval sets the interval to replay spilled
+	// messages into the buffer.
+	SetSpillReplayInterval(value time.Duration) Options
+
+	// SpillSyncInterval returns the interval to sync the spill log
+	// segment being written to disk.
+	SpillSyncInterval() time.Duration
+
+	// SetSpillSyncInterval sets the interval to sync the spill log
+	// segment being written to disk.
+	SetSpillSyncInterval(value time.Duration) Options
+
+	// SpillSyncBatchSize returns the number of messages appended to
+	// the spill log after which the segment being written is synced
+	// to disk.
+	SpillSyncBatchSize() int
+
+	// SetSpillSyncBatchSize sets the number of messages appended to
+	// the spill log after which the segment being written is synced
+	// to disk.
+	SetSpillSyncBatchSize(value int) Options
+
 	// CleanupRetryOptions returns the cleanup retry options.
 	CleanupRetryOptions() retry.Options
 
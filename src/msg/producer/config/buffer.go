@@ -37,6 +37,11 @@ type BufferConfiguration struct {
 	DropOldestInterval    *time.Duration         `yaml:"dropOldestInterval"`
 	ScanBatchSize         *int                   `yaml:"scanBatchSize"`
 	AllowedSpilloverRatio *float64               `yaml:"allowedSpilloverRatio"`
+	SpillDir              string                 `yaml:"spillDir"`
+	SpillSegmentSize      *int                   `yaml:"spillSegmentSize"`
+	SpillReplayInterval   *time.Duration         `yaml:"spillReplayInterval"`
+	SpillSyncInterval     *time.Duration         `yaml:"spillSyncInterval"`
+	SpillSyncBatchSize    *int                   `yaml:"spillSyncBatchSize"`
 	CleanupRetry          *retry.Configuration   `yaml:"cleanupRetry"`
 }
 
@@ -64,6 +69,21 @@ func (c *BufferConfiguration) NewOptions(iOpts instrument.Options) buffer.Option
 	if c.AllowedSpilloverRatio != nil {
 		opts = opts.SetAllowedSpilloverRatio(*c.AllowedSpilloverRatio)
 	}
+	if c.SpillDir != "" {
+		opts = opts.SetSpillDir(c.SpillDir)
+	}
+	if c.SpillSegmentSize != nil {
+		opts = opts.SetSpillSegmentSize(*c.SpillSegmentSize)
+	}
+	if c.SpillReplayInterval != nil {
+		opts = opts.SetSpillReplayInterval(*c.SpillReplayInterval)
+	}
+	if c.SpillSyncInterval != nil {
+		opts = opts.SetSpillSyncInterval(*c.SpillSyncInterval)
+	}
+	if c.SpillSyncBatchSize != nil {
+		opts = opts.SetSpillSyncBatchSize(*c.SpillSyncBatchSize)
+	}
 	if c.CleanupRetry != nil {
 		opts = opts.SetCleanupRetryOptions(c.CleanupRetry.NewOptions(iOpts.MetricsScope()))
 	}
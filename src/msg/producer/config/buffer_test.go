@@ -59,6 +59,29 @@ cleanupRetry:
 	require.Equal(t, 2*time.Second, bOpts.CleanupRetryOptions().InitialBackoff())
 }
 
+func TestBufferConfigurationSpillToDisk(t *testing.T) {
+	str := `
+onFullStrategy: spillToDisk
+spillDir: /var/lib/m3msg/spill
+spillSegmentSize: 1024
+spillReplayInterval: 200ms
+spillSyncInterval: 100ms
+spillSyncBatchSize: 64
+`
+
+	var cfg BufferConfiguration
+	require.NoError(t, yaml.Unmarshal([]byte(str), &cfg))
+
+	bOpts := cfg.NewOptions(instrument.NewOptions())
+	require.Equal(t, buffer.SpillToDisk, bOpts.OnFullStrategy())
+	require.Equal(t, "/var/lib/m3msg/spill", bOpts.SpillDir())
+	require.Equal(t, 1024, bOpts.SpillSegmentSize())
+	require.Equal(t, 200*time.Millisecond, bOpts.SpillReplayInterval())
+	require.Equal(t, 100*time.Millisecond, bOpts.SpillSyncInterval())
+	require.Equal(t, 64, bOpts.SpillSyncBatchSize())
+	require.NoError(t, bOpts.Validate())
+}
+
 func TestEmptyBufferConfiguration(t *testing.T) {
 	var cfg BufferConfiguration
 	require.NoError(t, yaml.Unmarshal(nil, &cfg))
@@ -34,8 +34,14 @@ func NewProducer(opts Options) Producer {
 }
 
 func (p *producer) Init() error {
-	p.Buffer.Init()
-	return p.Writer.Init()
+	p.Buffer.Init(p.Writer.Write)
+	if err := p.Writer.Init(); err != nil {
+		return err
+	}
+	// NB: messages spilled to disk are only replayed once the writer has
+	// loaded the topic, otherwise they would be dropped for an invalid shard.
+	p.Buffer.StartReplay()
+	return nil
 }
 
 func (p *producer) Produce(m Message) error {
@@ -43,6 +49,11 @@ func (p *producer) Produce(m Message) error {
 	if err != nil {
 		return err
 	}
+	if rm == nil {
+		// The message has been spilled to disk, it will
+		// be written by the buffer once it is replayed.
+		return nil
+	}
 	return p.Writer.Write(rm)
 }
 
@@ -35,6 +35,7 @@ type RefCountedMessage struct {
 	Message
 
 	size         uint64
+	sequence     uint64
 	onFinalizeFn OnFinalizeFn
 
 	refCount            *atomic.Int32
@@ -94,6 +95,16 @@ func (rm *RefCountedMessage) Drop() bool {
 	return rm.finalize(Dropped)
 }
 
+// Sequence returns the sequence number assigned to the message on its shard.
+func (rm *RefCountedMessage) Sequence() uint64 {
+	return rm.sequence
+}
+
+// SetSequence sets the sequence number assigned to the message on its shard.
+func (rm *RefCountedMessage) SetSequence(value uint64) {
+	rm.sequence = value
+}
+
 // IsDroppedOrConsumed returns true if the message has been dropped or consumed.
 func (rm *RefCountedMessage) IsDroppedOrConsumed() bool {
 	return rm.isDroppedOrConsumed.Load()
@@ -105,11 +105,18 @@ type Options interface {
 
 // Buffer buffers all the messages in the producer.
 type Buffer interface {
-	// Add adds message to the buffer and returns a reference counted message.
+	// Add adds message to the buffer and returns a reference counted message,
+	// or nil if the message has been spilled to disk, in which case it will be
+	// written out once it is replayed.
 	Add(m Message) (*RefCountedMessage, error)
 
-	// Init initializes the buffer.
-	Init()
+	// Init initializes the buffer, messages replayed from disk
+	// are written out with the given function.
+	Init(fn WriteFn)
+
+	// StartReplay starts replaying the messages spilled to disk, it must
+	// only be called once the messages replayed can be written out.
+	StartReplay()
 
 	// Close stops the buffer from accepting new requests immediately.
 	// If the CloseType is WaitForConsumption, then it will block until all the messages have been consumed.
@@ -117,6 +124,9 @@ type Buffer interface {
 	Close(ct CloseType)
 }
 
+// WriteFn writes a reference counted message out.
+type WriteFn func(rm *RefCountedMessage) error
+
 // Writer writes all the messages out to the consumer services.
 type Writer interface {
 	// Write writes a reference counted message out.
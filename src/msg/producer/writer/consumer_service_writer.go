@@ -176,6 +176,8 @@ func initShardWriters(
 			sws[i] = newSharedShardWriter(uint32(i), router, mPool, opts, m)
 		case topic.Replicated:
 			sws[i] = newReplicatedShardWriter(uint32(i), numberOfShards, router, mPool, opts, m)
+		case topic.ConsumerGroup:
+			sws[i] = newConsumerGroupShardWriter(uint32(i), router, mPool, opts, m)
 		}
 	}
 	return sws
@@ -239,8 +241,8 @@ func (w *consumerServiceWriterImpl) process(update interface{}) error {
 		isSharded = p.IsSharded()
 	)
 	// Non sharded placement is only allowed for Shared consumption type.
-	if w.cs.ConsumptionType() == topic.Replicated && !isSharded {
-		return fmt.Errorf("non-sharded placement for replicated consumer %s", w.cs.String())
+	if w.cs.ConsumptionType() != topic.Shared && !isSharded {
+		return fmt.Errorf("non-sharded placement for %s consumer %s", w.cs.ConsumptionType(), w.cs.String())
 	}
 	// NB(cw): Lock can be removed as w.consumerWriters is only accessed in this thread.
 	w.Lock()
@@ -622,6 +622,31 @@ func TestConsumerServiceWriterUpdateNonShardedPlacementWithReplicatedConsumption
 	w.Close()
 }
 
+func TestConsumerServiceWriterUpdateNonShardedPlacementWithConsumerGroupConsumptionType(t *testing.T) {
+	defer leaktest.Check(t)()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sid := services.NewServiceID().SetName("foo")
+	cs := topic.NewConsumerService().SetServiceID(sid).SetConsumptionType(topic.ConsumerGroup)
+	sd := services.NewMockServices(ctrl)
+	pOpts := placement.NewOptions().SetIsSharded(false)
+	ps := service.NewPlacementService(storage.NewPlacementStorage(mem.NewStore(), sid.String(), pOpts), pOpts)
+	sd.EXPECT().PlacementService(sid, gomock.Any()).Return(ps, nil)
+	_, err := ps.BuildInitialPlacement([]placement.Instance{
+		placement.NewInstance().SetID("i1").SetEndpoint("i1").SetWeight(1),
+	}, 0, 1)
+	require.NoError(t, err)
+	opts := testOptions().SetServiceDiscovery(sd)
+	w, err := newConsumerServiceWriter(cs, 2, opts)
+	require.NoError(t, err)
+	err = w.Init(failOnError)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "non-sharded placement for consumerGroup consumer")
+	w.Close()
+}
+
 func TestConsumerServiceCloseShardWritersConcurrently(t *testing.T) {
 	defer leaktest.Check(t)()
 
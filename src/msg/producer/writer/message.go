@@ -33,6 +33,7 @@ type message struct {
 
 	pb           msgpb.Message
 	meta         metadata
+	producerID   uint64
 	initNanos    int64
 	retryAtNanos int64
 	retried      int
@@ -50,9 +51,15 @@ func newMessage() *message {
 }
 
 // Set sets the message.
-func (m *message) Set(meta metadata, rm *producer.RefCountedMessage, initNanos int64) {
+func (m *message) Set(
+	meta metadata,
+	rm *producer.RefCountedMessage,
+	producerID uint64,
+	initNanos int64,
+) {
 	m.initNanos = initNanos
 	m.meta = meta
+	m.producerID = producerID
 	m.RefCountedMessage = rm
 	m.ToProto(&m.pb)
 }
@@ -114,6 +121,8 @@ func (m *message) Marshaler() (proto.Marshaler, bool) {
 func (m *message) ToProto(pb *msgpb.Message) {
 	m.meta.ToProto(&pb.Metadata)
 	pb.Value = m.RefCountedMessage.Bytes()
+	pb.ProducerId = m.producerID
+	pb.Sequence = m.RefCountedMessage.Sequence()
 }
 
 func (m *message) ResetProto(pb *msgpb.Message) {
@@ -41,17 +41,20 @@ func TestMessagePool(t *testing.T) {
 	mm := producer.NewMockMessage(ctrl)
 	mm.EXPECT().Size().Return(3)
 	rm := producer.NewRefCountedMessage(mm, nil)
+	rm.SetSequence(7)
 	rm.IncRef()
 
 	m := p.Get()
 	require.Nil(t, m.pb.Value)
 	mm.EXPECT().Bytes().Return([]byte("foo"))
-	m.Set(metadata{}, rm, 500)
+	m.Set(metadata{}, rm, 42, 500)
 	m.SetRetryAtNanos(100)
 
 	pb, ok := m.Marshaler()
 	require.True(t, ok)
 	require.Equal(t, []byte("foo"), pb.(*msgpb.Message).Value)
+	require.Equal(t, uint64(42), pb.(*msgpb.Message).ProducerId)
+	require.Equal(t, uint64(7), pb.(*msgpb.Message).Sequence)
 
 	mm.EXPECT().Finalize(producer.Consumed)
 	m.Ack()
@@ -68,7 +71,7 @@ func TestMessagePool(t *testing.T) {
 
 	mm.EXPECT().Size().Return(3)
 	mm.EXPECT().Bytes().Return([]byte("foo"))
-	m.Set(metadata{}, producer.NewRefCountedMessage(mm, nil), 600)
+	m.Set(metadata{}, producer.NewRefCountedMessage(mm, nil), 0, 600)
 	require.False(t, m.IsDroppedOrConsumed())
 	require.Equal(t, int64(600), m.InitNanos())
 }
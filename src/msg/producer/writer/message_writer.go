@@ -216,7 +216,7 @@ func (w *messageWriterImpl) Write(rm *producer.RefCountedMessage) {
 		shard: w.replicatedShardID,
 		id:    w.msgID,
 	}
-	msg.Set(meta, rm, nowNanos)
+	msg.Set(meta, rm, w.opts.ProducerID(), nowNanos)
 	w.acks.add(meta, msg)
 	// Make sure all the new writes are ordered in queue.
 	if w.lastNewWrite != nil {
@@ -233,6 +233,14 @@ type Options interface {
 	// SetTopicName sets the topic name.
 	SetTopicName(value string) Options
 
+	// ProducerID returns the id identifying the producer instance to consumers
+	// for deduplication, a random id is used for each writer when not set.
+	ProducerID() uint64
+
+	// SetProducerID sets the id identifying the producer instance to consumers
+	// for deduplication, a random id is used for each writer when not set.
+	SetProducerID(value uint64) Options
+
 	// TopicService returns the topic service.
 	TopicService() topic.Service
 
@@ -342,6 +350,7 @@ type Options interface {
 
 type writerOptions struct {
 	topicName                         string
+	producerID                        uint64
 	topicService                      topic.Service
 	topicWatchInitTimeout             time.Duration
 	services                          services.Services
@@ -395,6 +404,16 @@ func (opts *writerOptions) TopicService() topic.Service {
 	return opts.topicService
 }
 
+func (opts *writerOptions) ProducerID() uint64 {
+	return opts.producerID
+}
+
+func (opts *writerOptions) SetProducerID(value uint64) Options {
+	o := *opts
+	o.producerID = value
+	return &o
+}
+
 func (opts *writerOptions) SetTopicService(value topic.Service) Options {
 	o := *opts
 	o.topicService = value
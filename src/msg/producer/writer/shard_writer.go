@@ -24,6 +24,7 @@ import (
 	"sync"
 
 	"github.com/m3db/m3/src/cluster/placement"
+	"github.com/m3db/m3/src/cluster/shard"
 	"github.com/m3db/m3/src/msg/producer"
 
 	"go.uber.org/atomic"
@@ -119,6 +120,73 @@ func (w *sharedShardWriter) SetMessageTTLNanos(value int64) {
 	w.mw.SetMessageTTLNanos(value)
 }
 
+// consumerGroupShardWriter writes all the messages for the shard to a single
+// member of the consumer group, the messages buffered for the shard are
+// retried on the new member when the shard moves to another member.
+type consumerGroupShardWriter struct {
+	*sharedShardWriter
+
+	shard uint32
+}
+
+func newConsumerGroupShardWriter(
+	shard uint32,
+	router ackRouter,
+	mPool messagePool,
+	opts Options,
+	m messageWriterMetrics,
+) shardWriter {
+	return &consumerGroupShardWriter{
+		sharedShardWriter: newSharedShardWriter(shard, router, mPool, opts, m).(*sharedShardWriter),
+		shard:             shard,
+	}
+}
+
+// This is not thread safe, must be called in one thread.
+func (w *consumerGroupShardWriter) UpdateInstances(
+	instances []placement.Instance,
+	cws map[string]consumerWriter,
+) {
+	var members []placement.Instance
+	if member, ok := consumerGroupMember(w.shard, instances); ok {
+		members = append(members, member)
+	}
+	w.sharedShardWriter.UpdateInstances(members, cws)
+}
+
+// consumerGroupMember picks the member consuming the shard, an instance owning
+// the shard is preferred over one still initializing it, and the instance with
+// the smallest id is picked among equals so all producers pick the same member.
+func consumerGroupMember(
+	shardID uint32,
+	instances []placement.Instance,
+) (placement.Instance, bool) {
+	var (
+		member     placement.Instance
+		memberRank int
+	)
+	for _, instance := range instances {
+		s, ok := instance.Shards().Shard(shardID)
+		if !ok {
+			continue
+		}
+		var rank int
+		switch s.State() {
+		case shard.Available:
+			rank = 2
+		case shard.Initializing:
+			rank = 1
+		}
+		if member == nil ||
+			rank > memberRank ||
+			(rank == memberRank && instance.ID() < member.ID()) {
+			member = instance
+			memberRank = rank
+		}
+	}
+	return member, member != nil
+}
+
 // nolint: maligned
 type replicatedShardWriter struct {
 	sync.RWMutex
@@ -113,6 +113,60 @@ func TestSharedShardWriter(t *testing.T) {
 	}
 }
 
+func TestConsumerGroupShardWriter(t *testing.T) {
+	defer leaktest.Check(t)()
+
+	a := newAckRouter(2)
+	opts := testOptions()
+	sw := newConsumerGroupShardWriter(1, a, testMessagePool(opts), opts, testMessageWriterMetrics())
+	defer sw.Close()
+
+	cws := make(map[string]consumerWriter)
+	for _, addr := range []string{"i1", "i2"} {
+		cw := newConsumerWriter(addr, a, opts, testConsumerWriterMetrics())
+		cw.Init()
+		defer cw.Close()
+		cws[addr] = cw
+	}
+	newInstance := func(id string, state shard.State) placement.Instance {
+		return placement.NewInstance().
+			SetID(id).
+			SetEndpoint(id).
+			SetShards(shard.NewShards([]shard.Shard{shard.NewShard(1).SetState(state)}))
+	}
+	mw := sw.(*consumerGroupShardWriter).mw.(*messageWriterImpl)
+	consumerWriterAddrs := func() []string {
+		mw.RLock()
+		defer mw.RUnlock()
+		var addrs []string
+		for _, cw := range mw.consumerWriters {
+			addrs = append(addrs, cw.Address())
+		}
+		return addrs
+	}
+
+	sw.UpdateInstances([]placement.Instance{
+		newInstance("i2", shard.Available),
+		newInstance("i1", shard.Available),
+	}, cws)
+	require.Equal(t, []string{"i1"}, consumerWriterAddrs())
+
+	// The shard is moving from i1 to i2.
+	sw.UpdateInstances([]placement.Instance{
+		newInstance("i1", shard.Leaving),
+		newInstance("i2", shard.Initializing),
+	}, cws)
+	require.Equal(t, []string{"i2"}, consumerWriterAddrs())
+
+	sw.UpdateInstances([]placement.Instance{
+		newInstance("i2", shard.Available),
+	}, cws)
+	require.Equal(t, []string{"i2"}, consumerWriterAddrs())
+
+	sw.UpdateInstances(nil, cws)
+	require.Empty(t, consumerWriterAddrs())
+}
+
 func TestReplicatedShardWriter(t *testing.T) {
 	defer leaktest.Check(t)()
 
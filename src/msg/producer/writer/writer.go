@@ -23,7 +23,9 @@ package writer
 import (
 	"errors"
 	"fmt"
+	"math/rand"
 	"sync"
+	"time"
 
 	"github.com/m3db/m3/src/cluster/services"
 	"github.com/m3db/m3/src/msg/producer"
@@ -32,6 +34,7 @@ import (
 	"github.com/m3db/m3/src/x/watch"
 
 	"github.com/uber-go/tally"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 )
 
@@ -70,6 +73,7 @@ type writer struct {
 	value                  watch.Value
 	initType               initType
 	numShards              uint32
+	sequences              []atomic.Uint64
 	consumerServiceWriters map[string]consumerServiceWriter
 	filterRegistry         map[string]producer.FilterFunc
 	isClosed               bool
@@ -80,6 +84,9 @@ type writer struct {
 
 // NewWriter creates a new writer.
 func NewWriter(opts Options) producer.Writer {
+	if opts.ProducerID() == 0 {
+		opts = opts.SetProducerID(newProducerID())
+	}
 	w := &writer{
 		topic:                  opts.TopicName(),
 		ts:                     opts.TopicService(),
@@ -109,6 +116,9 @@ func (w *writer) Write(rm *producer.RefCountedMessage) error {
 		w.RUnlock()
 		return fmt.Errorf("could not write message for shard %d which is larger than max shard id %d", shard, w.numShards-1)
 	}
+	// NB: The sequence is assigned before the message is written to any
+	// consumer service so every consumer sees the same sequence for it.
+	rm.SetSequence(w.sequences[shard].Inc())
 	// NB(cw): Need to inc ref here in case a consumer service
 	// writes the message too fast and close the message.
 	rm.IncRef()
@@ -227,6 +237,15 @@ func (w *writer) process(update interface{}) error {
 	}
 	w.consumerServiceWriters = newConsumerServiceWriters
 	w.numShards = t.NumberOfShards()
+	if len(w.sequences) < int(w.numShards) {
+		// Keep the sequences of the existing shards so consumers do not see
+		// them restart and drop the messages as duplicates.
+		sequences := make([]atomic.Uint64, w.numShards)
+		for i := range w.sequences {
+			sequences[i].Store(w.sequences[i].Load())
+		}
+		w.sequences = sequences
+	}
 	w.Unlock()
 
 	// Close removed consumer service.
@@ -282,3 +301,13 @@ func (w *writer) UnregisterFilter(sid services.ServiceID) {
 		csw.UnregisterFilter()
 	}
 }
+
+func newProducerID() uint64 {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for {
+		// Zero is reserved for producers not identifying themselves.
+		if id := r.Uint64(); id != 0 {
+			return id
+		}
+	}
+}
@@ -121,6 +121,67 @@ func TestWriterWriteWithInvalidShard(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestWriterWriteAssignsSequencePerShard(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	w := NewWriter(testOptions()).(*writer)
+	require.NotEqual(t, uint64(0), w.opts.ProducerID())
+	w.numShards = 2
+	w.sequences = make([]atomic.Uint64, 2)
+	csw := NewMockconsumerServiceWriter(ctrl)
+	w.consumerServiceWriters = map[string]consumerServiceWriter{"s1": csw}
+
+	var sequences []uint64
+	csw.EXPECT().Write(gomock.Any()).Do(func(rm *producer.RefCountedMessage) {
+		sequences = append(sequences, rm.Sequence())
+	}).Times(4)
+	for _, shard := range []uint32{0, 1, 0, 0} {
+		mm := producer.NewMockMessage(ctrl)
+		mm.EXPECT().Shard().Return(shard)
+		mm.EXPECT().Size().Return(3)
+		mm.EXPECT().Finalize(producer.Consumed)
+		require.NoError(t, w.Write(producer.NewRefCountedMessage(mm, nil)))
+	}
+	require.Equal(t, []uint64{1, 1, 2, 3}, sequences)
+
+	w = NewWriter(testOptions().SetProducerID(42)).(*writer)
+	require.Equal(t, uint64(42), w.opts.ProducerID())
+}
+
+func TestWriterProcessResizesSequences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := testOptions()
+	w := NewWriter(opts).(*writer)
+	w.sequences = make([]atomic.Uint64, 1)
+	w.sequences[0].Store(5)
+
+	testTopic := topic.NewTopic().
+		SetName(opts.TopicName()).
+		SetNumberOfShards(2)
+	require.NoError(t, w.process(testTopic))
+	require.Equal(t, uint32(2), w.NumShards())
+	require.Equal(t, 2, len(w.sequences))
+
+	csw := NewMockconsumerServiceWriter(ctrl)
+	w.consumerServiceWriters = map[string]consumerServiceWriter{"s1": csw}
+
+	var sequences []uint64
+	csw.EXPECT().Write(gomock.Any()).Do(func(rm *producer.RefCountedMessage) {
+		sequences = append(sequences, rm.Sequence())
+	}).Times(2)
+	for _, shard := range []uint32{0, 1} {
+		mm := producer.NewMockMessage(ctrl)
+		mm.EXPECT().Shard().Return(shard)
+		mm.EXPECT().Size().Return(3)
+		mm.EXPECT().Finalize(producer.Consumed)
+		require.NoError(t, w.Write(producer.NewRefCountedMessage(mm, nil)))
+	}
+	require.Equal(t, []uint64{6, 1}, sequences)
+}
+
 func TestWriterInvalidTopicUpdate(t *testing.T) {
 	defer leaktest.Check(t)()
 
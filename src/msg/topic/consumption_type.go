@@ -31,6 +31,7 @@ var (
 	validTypes = []ConsumptionType{
 		Shared,
 		Replicated,
+		ConsumerGroup,
 	}
 )
 
@@ -60,6 +61,8 @@ func NewConsumptionTypeFromProto(ct topicpb.ConsumptionType) (ConsumptionType, e
 		return Shared, nil
 	case topicpb.ConsumptionType_REPLICATED:
 		return Replicated, nil
+	case topicpb.ConsumptionType_CONSUMER_GROUP:
+		return ConsumerGroup, nil
 	}
 	return Unknown, fmt.Errorf("invalid consumption type in protobuf: %v", ct)
 }
@@ -71,6 +74,8 @@ func ConsumptionTypeToProto(ct ConsumptionType) (topicpb.ConsumptionType, error)
 		return topicpb.ConsumptionType_SHARED, nil
 	case Replicated:
 		return topicpb.ConsumptionType_REPLICATED, nil
+	case ConsumerGroup:
+		return topicpb.ConsumptionType_CONSUMER_GROUP, nil
 	}
 	return topicpb.ConsumptionType_UNKNOWN, fmt.Errorf("invalid consumption type: %v", ct)
 }
@@ -35,6 +35,10 @@ func TestNewConsumptionType(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, Replicated, ct)
 
+	ct, err = NewConsumptionType("consumerGroup")
+	require.NoError(t, err)
+	require.Equal(t, ConsumerGroup, ct)
+
 	ct, err = NewConsumptionType("bad")
 	require.Error(t, err)
 	require.Equal(t, Unknown, ct)
@@ -148,4 +148,11 @@ const (
 	// Replicated means the messages for each shard will be
 	// replicated to all the responsible instances.
 	Replicated ConsumptionType = "replicated"
+
+	// ConsumerGroup means the messages for each shard will be
+	// consumed by exactly one instance of the consumer service,
+	// with the shards balanced across the instances by placement.
+	// Each consumer service acts as a consumer group that tracks
+	// its progress independently of the other consumer services.
+	ConsumerGroup ConsumptionType = "consumerGroup"
 )
@@ -0,0 +1,142 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package alerting
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+const (
+	defaultQueryTimeout        = 30 * time.Second
+	defaultAlertmanagerTimeout = 10 * time.Second
+
+	// alertValidForMultiple is the multiple of the resend delay (or evaluation
+	// interval, if larger) a firing alert is considered valid for by the
+	// Alertmanager without being resent, the same as Prometheus.
+	alertValidForMultiple = 4
+)
+
+var errNoRuleFiles = errors.New("alerting requires at least one rule file")
+
+// Configuration is the configuration for evaluating alerting rules.
+type Configuration struct {
+	// RuleFiles is the list of Prometheus-format rule files to load.
+	RuleFiles []string `yaml:"ruleFiles"`
+
+	// EvaluationInterval is the default interval to evaluate rule groups at.
+	EvaluationInterval *time.Duration `yaml:"evaluationInterval"`
+
+	// QueryTimeout is the timeout for evaluating a single rule.
+	QueryTimeout *time.Duration `yaml:"queryTimeout"`
+
+	// ResendDelay is the minimum delay between resending notifications for
+	// a still firing alert.
+	ResendDelay *time.Duration `yaml:"resendDelay"`
+
+	// StateKey is the cluster KV key alert state is persisted to.
+	StateKey string `yaml:"stateKey"`
+
+	// Alertmanager configures sending notifications to an Alertmanager
+	// compatible endpoint, if not set no notifications are sent.
+	Alertmanager *AlertmanagerConfiguration `yaml:"alertmanager"`
+}
+
+// AlertmanagerConfiguration is the configuration for sending notifications
+// to an Alertmanager compatible endpoint.
+type AlertmanagerConfiguration struct {
+	// URL is the base URL of the Alertmanager.
+	URL string `yaml:"url" validate:"nonzero"`
+
+	// Timeout is the timeout for posting notifications.
+	Timeout *time.Duration `yaml:"timeout"`
+
+	// GeneratorURL is the URL sent as the source of alerts.
+	GeneratorURL string `yaml:"generatorURL"`
+}
+
+// NewManager creates a new alerting rule manager from the configuration,
+// if storeFn is nil alert state is not persisted.
+func (c Configuration) NewManager(
+	engine executor.Engine,
+	tagOpts models.TagOptions,
+	storeFn KVStoreFn,
+	instrumentOpts instrument.Options,
+) (Manager, error) {
+	if len(c.RuleFiles) == 0 {
+		return nil, errNoRuleFiles
+	}
+
+	var groups RuleGroups
+	for _, file := range c.RuleFiles {
+		fileGroups, err := ParseRuleFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		groups.Groups = append(groups.Groups, fileGroups.Groups...)
+	}
+
+	queryTimeout := defaultQueryTimeout
+	if c.QueryTimeout != nil {
+		queryTimeout = *c.QueryTimeout
+	}
+
+	opts := NewOptions().
+		SetInstrumentOptions(instrumentOpts).
+		SetQueryFunc(NewEngineQueryFunc(engine, tagOpts, queryTimeout))
+	if c.EvaluationInterval != nil {
+		opts = opts.SetEvaluationInterval(*c.EvaluationInterval)
+	}
+	if c.ResendDelay != nil {
+		opts = opts.SetResendDelay(*c.ResendDelay)
+	}
+
+	if storeFn != nil {
+		key := DefaultStateKey
+		if c.StateKey != "" {
+			key = c.StateKey
+		}
+		opts = opts.SetStateStore(NewKVStateStore(storeFn, key))
+	}
+
+	if am := c.Alertmanager; am != nil {
+		timeout := defaultAlertmanagerTimeout
+		if am.Timeout != nil {
+			timeout = *am.Timeout
+		}
+
+		validFor := opts.ResendDelay()
+		if interval := opts.EvaluationInterval(); interval > validFor {
+			validFor = interval
+		}
+
+		opts = opts.SetNotifier(NewAlertmanagerNotifier(am.URL, am.GeneratorURL,
+			alertValidForMultiple*validFor, &http.Client{Timeout: timeout}))
+	}
+
+	return NewManager(groups, opts)
+}
@@ -0,0 +1,422 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package alerting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+)
+
+var (
+	errManagerAlreadyStarted = errors.New("alerting manager already started")
+	errManagerNotStarted     = errors.New("alerting manager not started")
+	errManagerClosed         = errors.New("alerting manager closed")
+)
+
+type managerMetrics struct {
+	evaluations        tally.Counter
+	evaluationErrors   tally.Counter
+	evaluationLatency  tally.Timer
+	skippedEvaluations tally.Counter
+	notifications      tally.Counter
+	notificationErrors tally.Counter
+	persistErrors      tally.Counter
+	restoreErrors      tally.Counter
+}
+
+func newManagerMetrics(scope tally.Scope) managerMetrics {
+	return managerMetrics{
+		evaluations:        scope.Counter("evaluations"),
+		evaluationErrors:   scope.Counter("evaluation-errors"),
+		evaluationLatency:  scope.Timer("evaluation-latency"),
+		skippedEvaluations: scope.Counter("skipped-evaluations"),
+		notifications:      scope.Counter("notifications"),
+		notificationErrors: scope.Counter("notification-errors"),
+		persistErrors:      scope.Counter("persist-errors"),
+		restoreErrors:      scope.Counter("restore-errors"),
+	}
+}
+
+type ruleGroup struct {
+	name     string
+	file     string
+	interval time.Duration
+	rules    []*alertingRule
+	// keys are the state store keys of the rules, by rule index.
+	keys []string
+
+	lastEvaluation time.Time
+	evaluationTime time.Duration
+}
+
+type manager struct {
+	sync.RWMutex
+
+	opts       Options
+	queryFn    QueryFunc
+	notifier   Notifier
+	stateStore StateStore
+	logger     *zap.Logger
+	metrics    managerMetrics
+
+	groups []*ruleGroup
+
+	// restoreLock serializes restoring and persisting state.
+	restoreLock sync.Mutex
+	restored    bool
+
+	started bool
+	closed  bool
+	closeCh chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewManager creates a new alerting rule manager that evaluates the
+// alerting rules in the given rule groups.
+func NewManager(groups RuleGroups, opts Options) (Manager, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := groups.Validate(); err != nil {
+		return nil, err
+	}
+
+	m := &manager{
+		opts:       opts,
+		queryFn:    opts.QueryFunc(),
+		notifier:   opts.Notifier(),
+		stateStore: opts.StateStore(),
+		logger:     opts.InstrumentOptions().Logger(),
+		metrics:    newManagerMetrics(opts.InstrumentOptions().MetricsScope()),
+		restored:   opts.StateStore() == nil,
+		closeCh:    make(chan struct{}),
+	}
+
+	for _, g := range groups.Groups {
+		group := &ruleGroup{
+			name:     g.Name,
+			file:     g.File,
+			interval: g.Interval,
+		}
+		if group.interval == 0 {
+			group.interval = opts.EvaluationInterval()
+		}
+
+		occurrences := make(map[string]int, len(g.Rules))
+		for _, r := range g.Rules {
+			if r.Alert == "" {
+				// Recording rules are not evaluated.
+				continue
+			}
+
+			rule, err := newAlertingRule(r)
+			if err != nil {
+				return nil, err
+			}
+
+			// NB: alert names are not required to be unique within a group so
+			// key the persisted state by name and occurrence.
+			key := fmt.Sprintf("%s/%s/%d", g.Name, r.Alert, occurrences[r.Alert])
+			occurrences[r.Alert]++
+
+			group.rules = append(group.rules, rule)
+			group.keys = append(group.keys, key)
+		}
+
+		m.groups = append(m.groups, group)
+	}
+
+	return m, nil
+}
+
+func (m *manager) Start() error {
+	m.Lock()
+	defer m.Unlock()
+	if m.started {
+		return errManagerAlreadyStarted
+	}
+
+	m.started = true
+	for _, group := range m.groups {
+		group := group
+		m.wg.Add(1)
+		go func() {
+			defer m.wg.Done()
+			m.run(group)
+		}()
+	}
+
+	return nil
+}
+
+func (m *manager) run(group *ruleGroup) {
+	ticker := time.NewTicker(group.interval)
+	defer ticker.Stop()
+
+	for {
+		m.evalGroup(group)
+
+		select {
+		case <-ticker.C:
+		case <-m.closeCh:
+			return
+		}
+	}
+}
+
+func (m *manager) evalGroup(group *ruleGroup) {
+	if err := m.restore(); err != nil {
+		// NB: evaluating before previous state has been restored would reset
+		// the hold duration of pending alerts and re-notify firing alerts.
+		m.metrics.skippedEvaluations.Inc(1)
+		m.logger.Warn("skipping rule group evaluation, could not restore alert state",
+			zap.String("group", group.name), zap.Error(err))
+		return
+	}
+
+	start := m.opts.NowFn()()
+	ctx, cancel := context.WithTimeout(context.Background(), group.interval)
+	defer cancel()
+
+	for _, rule := range group.rules {
+		ruleStart := m.opts.NowFn()()
+		result, err := m.queryFn(ctx, rule.rule.Expr, start)
+		evaluationTime := m.opts.NowFn()().Sub(ruleStart)
+
+		m.metrics.evaluations.Inc(1)
+		m.metrics.evaluationLatency.Record(evaluationTime)
+
+		m.Lock()
+		rule.lastEvaluation = start
+		rule.evaluationTime = evaluationTime
+		if err != nil {
+			// NB: keep existing alert state on error, the same as Prometheus.
+			rule.health = HealthBad
+			rule.lastError = err
+		} else {
+			rule.health = HealthGood
+			rule.lastError = nil
+			rule.eval(result, start, m.opts.ResolvedRetention())
+		}
+		m.Unlock()
+
+		if err != nil {
+			m.metrics.evaluationErrors.Inc(1)
+			m.logger.Warn("could not evaluate alerting rule",
+				zap.String("group", group.name),
+				zap.String("alert", rule.rule.Alert),
+				zap.Error(err))
+		}
+	}
+
+	m.Lock()
+	group.lastEvaluation = start
+	group.evaluationTime = m.opts.NowFn()().Sub(start)
+	m.Unlock()
+
+	m.sendAlerts(ctx, group, start)
+	m.persist()
+}
+
+func (m *manager) sendAlerts(ctx context.Context, group *ruleGroup, now time.Time) {
+	if m.notifier == nil {
+		return
+	}
+
+	var (
+		alerts []Alert
+		sent   []*Alert
+	)
+	m.Lock()
+	for _, rule := range group.rules {
+		for _, alert := range rule.active {
+			if !m.needsSending(alert, now) {
+				continue
+			}
+
+			notification := *alert
+			notification.LastSentAt = now
+			alerts = append(alerts, notification)
+			sent = append(sent, alert)
+		}
+	}
+	m.Unlock()
+
+	if len(alerts) == 0 {
+		return
+	}
+
+	if err := m.notifier.Send(ctx, alerts); err != nil {
+		// NB: leave the alerts unsent so they are retried on the next
+		// evaluation rather than after the resend delay, or never for
+		// resolved alerts.
+		m.metrics.notificationErrors.Inc(1)
+		m.logger.Error("could not send alert notifications",
+			zap.String("group", group.name), zap.Error(err))
+		return
+	}
+
+	m.Lock()
+	for _, alert := range sent {
+		alert.LastSentAt = now
+	}
+	m.Unlock()
+
+	m.metrics.notifications.Inc(int64(len(alerts)))
+}
+
+func (m *manager) needsSending(alert *Alert, now time.Time) bool {
+	switch alert.State {
+	case StateFiring:
+		return alert.LastSentAt.IsZero() ||
+			now.Sub(alert.LastSentAt) >= m.opts.ResendDelay()
+	case StateInactive:
+		// Send resolved notifications exactly once.
+		return alert.LastSentAt.Before(alert.ResolvedAt)
+	}
+
+	return false
+}
+
+func (m *manager) restore() error {
+	m.restoreLock.Lock()
+	defer m.restoreLock.Unlock()
+	if m.restored {
+		return nil
+	}
+
+	state, err := m.stateStore.Load()
+	if err != nil {
+		m.metrics.restoreErrors.Inc(1)
+		return err
+	}
+
+	m.Lock()
+	for _, group := range m.groups {
+		for i, rule := range group.rules {
+			if alerts, ok := state[group.keys[i]]; ok {
+				rule.restore(alerts)
+			}
+		}
+	}
+	m.Unlock()
+
+	m.restored = true
+	return nil
+}
+
+func (m *manager) persist() {
+	if m.stateStore == nil {
+		return
+	}
+
+	m.restoreLock.Lock()
+	defer m.restoreLock.Unlock()
+
+	m.RLock()
+	state := make(map[string][]Alert)
+	for _, group := range m.groups {
+		for i, rule := range group.rules {
+			if alerts := rule.alerts(); len(alerts) > 0 {
+				state[group.keys[i]] = alerts
+			}
+		}
+	}
+	m.RUnlock()
+
+	if err := m.stateStore.Save(state); err != nil {
+		m.metrics.persistErrors.Inc(1)
+		m.logger.Error("could not persist alert state", zap.Error(err))
+	}
+}
+
+func (m *manager) RuleGroups() []GroupStatus {
+	m.RLock()
+	defer m.RUnlock()
+
+	groups := make([]GroupStatus, 0, len(m.groups))
+	for _, group := range m.groups {
+		status := GroupStatus{
+			Name:           group.name,
+			File:           group.file,
+			Interval:       group.interval,
+			LastEvaluation: group.lastEvaluation,
+			EvaluationTime: group.evaluationTime,
+			Rules:          make([]RuleStatus, 0, len(group.rules)),
+		}
+
+		for _, rule := range group.rules {
+			status.Rules = append(status.Rules, rule.status())
+		}
+
+		groups = append(groups, status)
+	}
+
+	return groups
+}
+
+func (m *manager) Alerts() []Alert {
+	m.RLock()
+	defer m.RUnlock()
+
+	var alerts []Alert
+	for _, group := range m.groups {
+		for _, rule := range group.rules {
+			for _, alert := range rule.alerts() {
+				if alert.State == StateInactive {
+					continue
+				}
+				alerts = append(alerts, alert)
+			}
+		}
+	}
+
+	sort.SliceStable(alerts, func(i, j int) bool {
+		return alerts[i].ActiveAt.Before(alerts[j].ActiveAt)
+	})
+	return alerts
+}
+
+func (m *manager) Close() error {
+	m.Lock()
+	if !m.started {
+		m.Unlock()
+		return errManagerNotStarted
+	}
+	if m.closed {
+		m.Unlock()
+		return errManagerClosed
+	}
+	m.closed = true
+	close(m.closeCh)
+	m.Unlock()
+
+	m.wg.Wait()
+	return nil
+}
@@ -0,0 +1,260 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package alerting
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/cluster/kv/mem"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testNotifier struct {
+	sync.Mutex
+	sent [][]Alert
+	err  error
+}
+
+func (n *testNotifier) Send(_ context.Context, alerts []Alert) error {
+	n.Lock()
+	defer n.Unlock()
+	n.sent = append(n.sent, alerts)
+	return n.err
+}
+
+func (n *testNotifier) setErr(err error) {
+	n.Lock()
+	defer n.Unlock()
+	n.err = err
+}
+
+type testClock struct {
+	sync.Mutex
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.Lock()
+	defer c.Unlock()
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.Lock()
+	defer c.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func testRuleGroups() RuleGroups {
+	return RuleGroups{
+		Groups: []RuleGroup{
+			{
+				Name: "group",
+				Rules: []Rule{
+					{Alert: "InstanceDown", Expr: "up == 0", For: time.Minute},
+				},
+			},
+		},
+	}
+}
+
+func newTestManager(
+	t *testing.T,
+	clock *testClock,
+	result *Vector,
+	store kv.Store,
+	notifier Notifier,
+) *manager {
+	queryFn := func(context.Context, string, time.Time) (Vector, error) {
+		return *result, nil
+	}
+
+	opts := NewOptions().
+		SetNowFn(clock.Now).
+		SetQueryFunc(queryFn).
+		SetNotifier(notifier).
+		SetStateStore(NewKVStateStore(func() (kv.Store, error) {
+			return store, nil
+		}, DefaultStateKey))
+
+	m, err := NewManager(testRuleGroups(), opts)
+	require.NoError(t, err)
+	return m.(*manager)
+}
+
+func TestManagerEvaluatesAndNotifies(t *testing.T) {
+	var (
+		clock    = &testClock{now: time.Unix(1000, 0)}
+		result   = Vector{{Labels: map[string]string{"instance": "a"}, Value: 0}}
+		notifier = &testNotifier{}
+		m        = newTestManager(t, clock, &result, mem.NewStore(), notifier)
+		group    = m.groups[0]
+	)
+
+	m.evalGroup(group)
+	alerts := m.Alerts()
+	require.Equal(t, 1, len(alerts))
+	assert.Equal(t, StatePending, alerts[0].State)
+	assert.Equal(t, 0, len(notifier.sent))
+
+	clock.Advance(time.Minute)
+	m.evalGroup(group)
+	alerts = m.Alerts()
+	require.Equal(t, 1, len(alerts))
+	assert.Equal(t, StateFiring, alerts[0].State)
+	require.Equal(t, 1, len(notifier.sent))
+	assert.Equal(t, StateFiring, notifier.sent[0][0].State)
+
+	// Not resent before the resend delay.
+	clock.Advance(30 * time.Second)
+	m.evalGroup(group)
+	require.Equal(t, 1, len(notifier.sent))
+
+	// Resolved alerts are sent once and no longer listed as active.
+	result = nil
+	clock.Advance(30 * time.Second)
+	m.evalGroup(group)
+	assert.Equal(t, 0, len(m.Alerts()))
+	require.Equal(t, 2, len(notifier.sent))
+	assert.Equal(t, StateInactive, notifier.sent[1][0].State)
+
+	clock.Advance(time.Minute)
+	m.evalGroup(group)
+	require.Equal(t, 2, len(notifier.sent))
+
+	groups := m.RuleGroups()
+	require.Equal(t, 1, len(groups))
+	require.Equal(t, 1, len(groups[0].Rules))
+	assert.Equal(t, HealthGood, groups[0].Rules[0].Health)
+}
+
+func TestManagerRetriesFailedNotifications(t *testing.T) {
+	var (
+		clock    = &testClock{now: time.Unix(1000, 0)}
+		result   = Vector{{Labels: map[string]string{"instance": "a"}, Value: 0}}
+		notifier = &testNotifier{err: errors.New("unavailable")}
+		m        = newTestManager(t, clock, &result, mem.NewStore(), notifier)
+		group    = m.groups[0]
+	)
+
+	m.evalGroup(group)
+	clock.Advance(time.Minute)
+	m.evalGroup(group)
+	require.Equal(t, 1, len(notifier.sent))
+	assert.Equal(t, StateFiring, notifier.sent[0][0].State)
+
+	// A failed firing notification is retried before the resend delay.
+	notifier.setErr(nil)
+	clock.Advance(30 * time.Second)
+	m.evalGroup(group)
+	require.Equal(t, 2, len(notifier.sent))
+	assert.Equal(t, StateFiring, notifier.sent[1][0].State)
+
+	clock.Advance(30 * time.Second)
+	m.evalGroup(group)
+	require.Equal(t, 2, len(notifier.sent))
+
+	// A failed resolved notification is retried until it is sent.
+	result = nil
+	notifier.setErr(errors.New("unavailable"))
+	clock.Advance(30 * time.Second)
+	m.evalGroup(group)
+	require.Equal(t, 3, len(notifier.sent))
+	assert.Equal(t, StateInactive, notifier.sent[2][0].State)
+
+	notifier.setErr(nil)
+	clock.Advance(30 * time.Second)
+	m.evalGroup(group)
+	require.Equal(t, 4, len(notifier.sent))
+	assert.Equal(t, StateInactive, notifier.sent[3][0].State)
+
+	clock.Advance(30 * time.Second)
+	m.evalGroup(group)
+	require.Equal(t, 4, len(notifier.sent))
+}
+
+func TestManagerRestoresState(t *testing.T) {
+	var (
+		clock  = &testClock{now: time.Unix(1000, 0)}
+		result = Vector{{Labels: map[string]string{"instance": "a"}, Value: 0}}
+		store  = mem.NewStore()
+		m      = newTestManager(t, clock, &result, store, nil)
+	)
+
+	m.evalGroup(m.groups[0])
+	alerts := m.Alerts()
+	require.Equal(t, 1, len(alerts))
+	activeAt := alerts[0].ActiveAt
+
+	// A new manager restores the pending alert and fires once the hold
+	// duration has passed since it first became active.
+	clock.Advance(time.Minute)
+	restarted := newTestManager(t, clock, &result, store, nil)
+	restarted.evalGroup(restarted.groups[0])
+	alerts = restarted.Alerts()
+	require.Equal(t, 1, len(alerts))
+	assert.Equal(t, StateFiring, alerts[0].State)
+	assert.True(t, activeAt.Equal(alerts[0].ActiveAt))
+}
+
+func TestManagerSkipsEvaluationUntilRestored(t *testing.T) {
+	var (
+		clock   = &testClock{now: time.Unix(1000, 0)}
+		result  = Vector{{Labels: map[string]string{"instance": "a"}, Value: 0}}
+		storeFn = func() (kv.Store, error) {
+			return nil, errors.New("not ready")
+		}
+	)
+
+	opts := NewOptions().
+		SetNowFn(clock.Now).
+		SetQueryFunc(func(context.Context, string, time.Time) (Vector, error) {
+			return result, nil
+		}).
+		SetStateStore(NewKVStateStore(storeFn, DefaultStateKey))
+
+	m, err := NewManager(testRuleGroups(), opts)
+	require.NoError(t, err)
+
+	mgr := m.(*manager)
+	mgr.evalGroup(mgr.groups[0])
+	assert.Equal(t, 0, len(mgr.Alerts()))
+}
+
+func TestManagerStartClose(t *testing.T) {
+	var (
+		clock  = &testClock{now: time.Unix(1000, 0)}
+		result Vector
+		m      = newTestManager(t, clock, &result, mem.NewStore(), nil)
+	)
+
+	require.NoError(t, m.Start())
+	require.Error(t, m.Start())
+	require.NoError(t, m.Close())
+	require.Error(t, m.Close())
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const alertmanagerAlertsPath = "/api/v2/alerts"
+
+// alertmanagerAlert is an alert in the Alertmanager API format.
+type alertmanagerAlert struct {
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL,omitempty"`
+}
+
+type alertmanagerNotifier struct {
+	url          string
+	generatorURL string
+	validFor     time.Duration
+	client       *http.Client
+}
+
+// NewAlertmanagerNotifier returns a notifier that posts alerts to the
+// Alertmanager compatible endpoint at the given base URL. Firing alerts are
+// sent with an end time of validFor past the time they were sent, so that
+// the receiver resolves them if the coordinator stops resending them.
+func NewAlertmanagerNotifier(
+	baseURL string,
+	generatorURL string,
+	validFor time.Duration,
+	client *http.Client,
+) Notifier {
+	return &alertmanagerNotifier{
+		url:          strings.TrimSuffix(baseURL, "/") + alertmanagerAlertsPath,
+		generatorURL: generatorURL,
+		validFor:     validFor,
+		client:       client,
+	}
+}
+
+func (n *alertmanagerNotifier) Send(ctx context.Context, alerts []Alert) error {
+	payload := make([]alertmanagerAlert, 0, len(alerts))
+	for _, alert := range alerts {
+		a := alertmanagerAlert{
+			Labels:       alert.Labels,
+			Annotations:  alert.Annotations,
+			StartsAt:     alert.FiredAt,
+			GeneratorURL: n.generatorURL,
+		}
+
+		if alert.State == StateInactive {
+			a.EndsAt = alert.ResolvedAt
+		} else {
+			a.EndsAt = alert.LastSentAt.Add(n.validFor)
+		}
+
+		payload = append(payload, a)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		// Drain the body so the connection can be reused.
+		io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("alertmanager %s returned status %d", n.url,
+			resp.StatusCode)
+	}
+
+	return nil
+}
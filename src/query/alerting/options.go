@@ -0,0 +1,218 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package alerting
+
+import (
+	"errors"
+	"time"
+
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+const (
+	defaultEvaluationInterval = time.Minute
+	defaultResendDelay        = time.Minute
+	defaultResolvedRetention  = 15 * time.Minute
+)
+
+var (
+	errNoQueryFunc               = errors.New("no query function set")
+	errInvalidEvaluationInterval = errors.New("evaluation interval must be positive")
+	errInvalidResendDelay        = errors.New("resend delay must not be negative")
+	errInvalidResolvedRetention  = errors.New("resolved retention must not be negative")
+)
+
+// Options are the options for the alerting rule manager.
+type Options interface {
+	// Validate validates the options.
+	Validate() error
+
+	// SetInstrumentOptions sets the instrument options.
+	SetInstrumentOptions(value instrument.Options) Options
+
+	// InstrumentOptions returns the instrument options.
+	InstrumentOptions() instrument.Options
+
+	// SetNowFn sets the now function.
+	SetNowFn(value clock.NowFn) Options
+
+	// NowFn returns the now function.
+	NowFn() clock.NowFn
+
+	// SetQueryFunc sets the function used to evaluate rule expressions.
+	SetQueryFunc(value QueryFunc) Options
+
+	// QueryFunc returns the function used to evaluate rule expressions.
+	QueryFunc() QueryFunc
+
+	// SetNotifier sets the notifier, if nil no notifications are sent.
+	SetNotifier(value Notifier) Options
+
+	// Notifier returns the notifier.
+	Notifier() Notifier
+
+	// SetStateStore sets the state store, if nil alert state is not
+	// persisted across restarts.
+	SetStateStore(value StateStore) Options
+
+	// StateStore returns the state store.
+	StateStore() StateStore
+
+	// SetEvaluationInterval sets the default evaluation interval for rule
+	// groups that do not specify one.
+	SetEvaluationInterval(value time.Duration) Options
+
+	// EvaluationInterval returns the default evaluation interval.
+	EvaluationInterval() time.Duration
+
+	// SetResendDelay sets the minimum delay between resending notifications
+	// for a still firing alert.
+	SetResendDelay(value time.Duration) Options
+
+	// ResendDelay returns the minimum delay between resending notifications.
+	ResendDelay() time.Duration
+
+	// SetResolvedRetention sets how long resolved alerts are retained so
+	// that resolved notifications can be delivered.
+	SetResolvedRetention(value time.Duration) Options
+
+	// ResolvedRetention returns how long resolved alerts are retained.
+	ResolvedRetention() time.Duration
+}
+
+type options struct {
+	instrumentOpts     instrument.Options
+	nowFn              clock.NowFn
+	queryFn            QueryFunc
+	notifier           Notifier
+	stateStore         StateStore
+	evaluationInterval time.Duration
+	resendDelay        time.Duration
+	resolvedRetention  time.Duration
+}
+
+// NewOptions creates a new set of alerting rule manager options.
+func NewOptions() Options {
+	return &options{
+		instrumentOpts:     instrument.NewOptions(),
+		nowFn:              time.Now,
+		evaluationInterval: defaultEvaluationInterval,
+		resendDelay:        defaultResendDelay,
+		resolvedRetention:  defaultResolvedRetention,
+	}
+}
+
+func (o *options) Validate() error {
+	if o.queryFn == nil {
+		return errNoQueryFunc
+	}
+	if o.evaluationInterval <= 0 {
+		return errInvalidEvaluationInterval
+	}
+	if o.resendDelay < 0 {
+		return errInvalidResendDelay
+	}
+	if o.resolvedRetention < 0 {
+		return errInvalidResolvedRetention
+	}
+	return nil
+}
+
+func (o *options) SetInstrumentOptions(value instrument.Options) Options {
+	opts := *o
+	opts.instrumentOpts = value
+	return &opts
+}
+
+func (o *options) InstrumentOptions() instrument.Options {
+	return o.instrumentOpts
+}
+
+func (o *options) SetNowFn(value clock.NowFn) Options {
+	opts := *o
+	opts.nowFn = value
+	return &opts
+}
+
+func (o *options) NowFn() clock.NowFn {
+	return o.nowFn
+}
+
+func (o *options) SetQueryFunc(value QueryFunc) Options {
+	opts := *o
+	opts.queryFn = value
+	return &opts
+}
+
+func (o *options) QueryFunc() QueryFunc {
+	return o.queryFn
+}
+
+func (o *options) SetNotifier(value Notifier) Options {
+	opts := *o
+	opts.notifier = value
+	return &opts
+}
+
+func (o *options) Notifier() Notifier {
+	return o.notifier
+}
+
+func (o *options) SetStateStore(value StateStore) Options {
+	opts := *o
+	opts.stateStore = value
+	return &opts
+}
+
+func (o *options) StateStore() StateStore {
+	return o.stateStore
+}
+
+func (o *options) SetEvaluationInterval(value time.Duration) Options {
+	opts := *o
+	opts.evaluationInterval = value
+	return &opts
+}
+
+func (o *options) EvaluationInterval() time.Duration {
+	return o.evaluationInterval
+}
+
+func (o *options) SetResendDelay(value time.Duration) Options {
+	opts := *o
+	opts.resendDelay = value
+	return &opts
+}
+
+func (o *options) ResendDelay() time.Duration {
+	return o.resendDelay
+}
+
+func (o *options) SetResolvedRetention(value time.Duration) Options {
+	opts := *o
+	opts.resolvedRetention = value
+	return &opts
+}
+
+func (o *options) ResolvedRetention() time.Duration {
+	return o.resolvedRetention
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package alerting
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser/promql"
+	"github.com/m3db/m3/src/query/storage"
+)
+
+// instantQueryStep is the step used to evaluate rules as instant queries.
+const instantQueryStep = time.Second
+
+// NewEngineQueryFunc returns a query function that evaluates rule
+// expressions as PromQL instant queries against the given engine.
+func NewEngineQueryFunc(
+	engine executor.Engine,
+	tagOpts models.TagOptions,
+	timeout time.Duration,
+) QueryFunc {
+	return func(ctx context.Context, query string, t time.Time) (Vector, error) {
+		engineOpts := engine.Options()
+		parser, err := promql.Parse(query, instantQueryStep, tagOpts,
+			engineOpts.ParseOptions())
+		if err != nil {
+			return nil, err
+		}
+
+		fetchOpts := storage.NewFetchOptions()
+		fetchOpts.Timeout = timeout
+		params := models.RequestParams{
+			Start:            t,
+			End:              t,
+			Now:              t,
+			Timeout:          timeout,
+			Step:             instantQueryStep,
+			Query:            query,
+			IncludeEnd:       true,
+			LookbackDuration: engineOpts.LookbackDuration(),
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		bl, err := engine.ExecuteExpr(ctx, parser, &executor.QueryOptions{},
+			fetchOpts, params)
+		if err != nil {
+			return nil, err
+		}
+
+		defer bl.Close()
+		it, err := bl.StepIter()
+		if err != nil {
+			return nil, err
+		}
+
+		defer it.Close()
+		var values []float64
+		for it.Next() {
+			// NB: only the value at the last step is relevant.
+			values = it.Current().Values()
+		}
+
+		if err := it.Err(); err != nil {
+			return nil, err
+		}
+
+		var (
+			blockTags  = bl.Meta().Tags.Tags
+			seriesMeta = it.SeriesMeta()
+			result     = make(Vector, 0, len(values))
+		)
+
+		for i, v := range values {
+			if math.IsNaN(v) {
+				continue
+			}
+
+			// NB: alerts are identified by their labels excluding the
+			// metric name, the same as Prometheus.
+			tags := seriesMeta[i].Tags.AddTags(blockTags).WithoutName()
+			labels := make(map[string]string, tags.Len())
+			for _, tag := range tags.Tags {
+				labels[string(tag.Name)] = string(tag.Value)
+			}
+
+			result = append(result, Sample{Labels: labels, Value: v})
+		}
+
+		return result, nil
+	}
+}
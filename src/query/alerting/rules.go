@@ -0,0 +1,352 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package alerting
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+const alertNameLabel = "alertname"
+
+var (
+	errNoGroupName = errors.New("rule group has no name")
+	errNoRuleName  = errors.New("alerting rule has no alert name")
+	errNoRuleExpr  = errors.New("alerting rule has no expression")
+)
+
+// RuleGroups is a set of rule groups in the Prometheus rule file format.
+type RuleGroups struct {
+	Groups []RuleGroup `yaml:"groups"`
+}
+
+// RuleGroup is a named group of rules evaluated on a common interval.
+type RuleGroup struct {
+	// File is the rule file the group was loaded from, if any.
+	File     string        `yaml:"-"`
+	Name     string        `yaml:"name"`
+	Interval time.Duration `yaml:"interval"`
+	Rules    []Rule        `yaml:"rules"`
+}
+
+// Rule is a single rule in the Prometheus rule file format.
+type Rule struct {
+	// Record is the name of a recording rule; recording rules are accepted
+	// so that existing rule files can be loaded, but are not evaluated.
+	Record      string            `yaml:"record"`
+	Alert       string            `yaml:"alert"`
+	Expr        string            `yaml:"expr"`
+	For         time.Duration     `yaml:"for"`
+	Labels      map[string]string `yaml:"labels"`
+	Annotations map[string]string `yaml:"annotations"`
+}
+
+// ParseRuleGroups parses rule groups in the Prometheus rule file format.
+func ParseRuleGroups(content []byte) (RuleGroups, error) {
+	var groups RuleGroups
+	if err := yaml.UnmarshalStrict(content, &groups); err != nil {
+		return RuleGroups{}, err
+	}
+
+	if err := groups.Validate(); err != nil {
+		return RuleGroups{}, err
+	}
+
+	return groups, nil
+}
+
+// ParseRuleFile parses the rule groups in the given rule file.
+func ParseRuleFile(path string) (RuleGroups, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return RuleGroups{}, err
+	}
+
+	groups, err := ParseRuleGroups(content)
+	if err != nil {
+		return RuleGroups{}, fmt.Errorf("invalid rule file %s: %v", path, err)
+	}
+
+	for i := range groups.Groups {
+		groups.Groups[i].File = path
+	}
+
+	return groups, nil
+}
+
+// Validate validates the rule groups.
+func (g RuleGroups) Validate() error {
+	names := make(map[string]struct{}, len(g.Groups))
+	for _, group := range g.Groups {
+		if group.Name == "" {
+			return errNoGroupName
+		}
+
+		if _, ok := names[group.Name]; ok {
+			return fmt.Errorf("duplicate rule group: %s", group.Name)
+		}
+
+		names[group.Name] = struct{}{}
+		if group.Interval < 0 {
+			return fmt.Errorf("rule group %s has negative interval", group.Name)
+		}
+
+		for _, rule := range group.Rules {
+			if err := rule.Validate(); err != nil {
+				return fmt.Errorf("rule group %s: %v", group.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Validate validates the rule.
+func (r Rule) Validate() error {
+	if r.Record != "" {
+		if r.Alert != "" {
+			return fmt.Errorf("rule %s has both record and alert set", r.Record)
+		}
+
+		return nil
+	}
+
+	if r.Alert == "" {
+		return errNoRuleName
+	}
+
+	if strings.TrimSpace(r.Expr) == "" {
+		return errNoRuleExpr
+	}
+
+	if r.For < 0 {
+		return fmt.Errorf("alerting rule %s has negative for duration", r.Alert)
+	}
+
+	for name, text := range r.Annotations {
+		if _, err := newAnnotationTemplate(name, text); err != nil {
+			return fmt.Errorf("alerting rule %s annotation %s: %v",
+				r.Alert, name, err)
+		}
+	}
+
+	return nil
+}
+
+// alertingRule tracks the alerts produced by a single alerting rule.
+// It is not safe for concurrent use, the owning group serializes access.
+type alertingRule struct {
+	rule        Rule
+	annotations map[string]*template.Template
+
+	health         RuleHealth
+	lastError      error
+	lastEvaluation time.Time
+	evaluationTime time.Duration
+
+	// active is keyed by the fingerprint of the alert labels.
+	active map[string]*Alert
+}
+
+func newAlertingRule(rule Rule) (*alertingRule, error) {
+	annotations := make(map[string]*template.Template, len(rule.Annotations))
+	for name, text := range rule.Annotations {
+		tmpl, err := newAnnotationTemplate(name, text)
+		if err != nil {
+			return nil, err
+		}
+
+		annotations[name] = tmpl
+	}
+
+	return &alertingRule{
+		rule:        rule,
+		annotations: annotations,
+		health:      HealthUnknown,
+		active:      make(map[string]*Alert),
+	}, nil
+}
+
+// eval updates the alert states from the result of evaluating the rule's
+// expression at the given time.
+func (r *alertingRule) eval(
+	result Vector,
+	now time.Time,
+	resolvedRetention time.Duration,
+) {
+	seen := make(map[string]struct{}, len(result))
+	for _, sample := range result {
+		labels := r.alertLabels(sample)
+		fp := fingerprint(labels)
+		seen[fp] = struct{}{}
+
+		alert, ok := r.active[fp]
+		if !ok || alert.State == StateInactive {
+			alert = &Alert{
+				State:    StatePending,
+				Labels:   labels,
+				ActiveAt: now,
+			}
+			r.active[fp] = alert
+		}
+
+		alert.Value = sample.Value
+		alert.Annotations = r.expandAnnotations(labels, sample.Value)
+	}
+
+	for fp, alert := range r.active {
+		if _, ok := seen[fp]; !ok {
+			switch alert.State {
+			case StatePending:
+				delete(r.active, fp)
+			case StateFiring:
+				alert.State = StateInactive
+				alert.ResolvedAt = now
+			case StateInactive:
+				if now.Sub(alert.ResolvedAt) > resolvedRetention {
+					delete(r.active, fp)
+				}
+			}
+			continue
+		}
+
+		if alert.State == StatePending && now.Sub(alert.ActiveAt) >= r.rule.For {
+			alert.State = StateFiring
+			alert.FiredAt = now
+		}
+	}
+}
+
+// restore restores previously persisted alerts, replacing any active ones.
+func (r *alertingRule) restore(alerts []Alert) {
+	r.active = make(map[string]*Alert, len(alerts))
+	for _, alert := range alerts {
+		alert := alert
+		r.active[fingerprint(alert.Labels)] = &alert
+	}
+}
+
+func (r *alertingRule) alertLabels(sample Sample) map[string]string {
+	labels := make(map[string]string, len(sample.Labels)+len(r.rule.Labels)+1)
+	for name, value := range sample.Labels {
+		labels[name] = value
+	}
+
+	for name, value := range r.rule.Labels {
+		labels[name] = value
+	}
+
+	labels[alertNameLabel] = r.rule.Alert
+	return labels
+}
+
+func (r *alertingRule) expandAnnotations(
+	labels map[string]string,
+	value float64,
+) map[string]string {
+	data := struct {
+		Labels map[string]string
+		Value  float64
+	}{
+		Labels: labels,
+		Value:  value,
+	}
+
+	annotations := make(map[string]string, len(r.annotations))
+	for name, tmpl := range r.annotations {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			// Fall back to the raw annotation so the alert is still useful.
+			annotations[name] = r.rule.Annotations[name]
+			continue
+		}
+
+		annotations[name] = buf.String()
+	}
+
+	return annotations
+}
+
+func (r *alertingRule) alerts() []Alert {
+	alerts := make([]Alert, 0, len(r.active))
+	for _, alert := range r.active {
+		alerts = append(alerts, *alert)
+	}
+
+	sort.Slice(alerts, func(i, j int) bool {
+		return fingerprint(alerts[i].Labels) < fingerprint(alerts[j].Labels)
+	})
+	return alerts
+}
+
+func (r *alertingRule) status() RuleStatus {
+	status := RuleStatus{
+		Name:           r.rule.Alert,
+		Query:          r.rule.Expr,
+		For:            r.rule.For,
+		Labels:         r.rule.Labels,
+		Annotations:    r.rule.Annotations,
+		Health:         r.health,
+		LastEvaluation: r.lastEvaluation,
+		EvaluationTime: r.evaluationTime,
+		Alerts:         r.alerts(),
+	}
+
+	if r.lastError != nil {
+		status.LastError = r.lastError.Error()
+	}
+
+	return status
+}
+
+// newAnnotationTemplate creates a template for an annotation, exposing
+// the alert labels and value as $labels and $value as Prometheus does.
+func newAnnotationTemplate(name, text string) (*template.Template, error) {
+	const defs = "{{$labels := .Labels}}{{$value := .Value}}"
+	return template.New(name).Option("missingkey=zero").Parse(defs + text)
+}
+
+// fingerprint returns a stable identifier for a set of labels.
+func fingerprint(labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	var buf strings.Builder
+	for _, name := range names {
+		buf.WriteString(name)
+		buf.WriteByte(0xfe)
+		buf.WriteString(labels[name])
+		buf.WriteByte(0xff)
+	}
+
+	return buf.String()
+}
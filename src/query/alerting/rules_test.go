@@ -0,0 +1,156 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package alerting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRuleFile = `
+groups:
+  - name: example
+    interval: 30s
+    rules:
+      - record: job:up:sum
+        expr: sum(up) by (job)
+      - alert: InstanceDown
+        expr: up == 0
+        for: 5m
+        labels:
+          severity: page
+        annotations:
+          summary: "Instance {{ $labels.instance }} down, value {{ $value }}"
+`
+
+func TestParseRuleGroups(t *testing.T) {
+	groups, err := ParseRuleGroups([]byte(testRuleFile))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(groups.Groups))
+
+	group := groups.Groups[0]
+	assert.Equal(t, "example", group.Name)
+	assert.Equal(t, 30*time.Second, group.Interval)
+	require.Equal(t, 2, len(group.Rules))
+	assert.Equal(t, "job:up:sum", group.Rules[0].Record)
+	assert.Equal(t, "InstanceDown", group.Rules[1].Alert)
+	assert.Equal(t, 5*time.Minute, group.Rules[1].For)
+	assert.Equal(t, map[string]string{"severity": "page"}, group.Rules[1].Labels)
+}
+
+func TestParseRuleGroupsInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{
+			name:    "unknown field",
+			content: "groups:\n  - name: a\n    unknown: b\n",
+		},
+		{
+			name:    "no group name",
+			content: "groups:\n  - rules:\n      - alert: A\n        expr: up\n",
+		},
+		{
+			name:    "duplicate group",
+			content: "groups:\n  - name: a\n  - name: a\n",
+		},
+		{
+			name:    "no expression",
+			content: "groups:\n  - name: a\n    rules:\n      - alert: A\n",
+		},
+		{
+			name: "invalid annotation",
+			content: "groups:\n  - name: a\n    rules:\n      - alert: A\n" +
+				"        expr: up\n        annotations:\n          a: '{{ .Foo'\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRuleGroups([]byte(tt.content))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestAlertingRuleEval(t *testing.T) {
+	rule, err := newAlertingRule(Rule{
+		Alert:       "InstanceDown",
+		Expr:        "up == 0",
+		For:         time.Minute,
+		Labels:      map[string]string{"severity": "page"},
+		Annotations: map[string]string{"summary": "{{ $labels.instance }} is {{ $value }}"},
+	})
+	require.NoError(t, err)
+
+	var (
+		start  = time.Unix(1000, 0)
+		result = Vector{{Labels: map[string]string{"instance": "a"}, Value: 0}}
+	)
+
+	rule.eval(result, start, time.Minute)
+	alerts := rule.alerts()
+	require.Equal(t, 1, len(alerts))
+	assert.Equal(t, StatePending, alerts[0].State)
+	assert.Equal(t, start, alerts[0].ActiveAt)
+	assert.Equal(t, map[string]string{
+		"alertname": "InstanceDown",
+		"instance":  "a",
+		"severity":  "page",
+	}, alerts[0].Labels)
+	assert.Equal(t, map[string]string{"summary": "a is 0"}, alerts[0].Annotations)
+
+	rule.eval(result, start.Add(time.Minute), time.Minute)
+	alerts = rule.alerts()
+	require.Equal(t, 1, len(alerts))
+	assert.Equal(t, StateFiring, alerts[0].State)
+	assert.Equal(t, start.Add(time.Minute), alerts[0].FiredAt)
+
+	rule.eval(nil, start.Add(2*time.Minute), time.Minute)
+	alerts = rule.alerts()
+	require.Equal(t, 1, len(alerts))
+	assert.Equal(t, StateInactive, alerts[0].State)
+	assert.Equal(t, start.Add(2*time.Minute), alerts[0].ResolvedAt)
+
+	rule.eval(nil, start.Add(4*time.Minute), time.Minute)
+	assert.Equal(t, 0, len(rule.alerts()))
+}
+
+func TestAlertingRuleEvalPendingResets(t *testing.T) {
+	rule, err := newAlertingRule(Rule{
+		Alert: "InstanceDown",
+		Expr:  "up == 0",
+		For:   time.Minute,
+	})
+	require.NoError(t, err)
+
+	start := time.Unix(1000, 0)
+	rule.eval(Vector{{Labels: map[string]string{"instance": "a"}}}, start, time.Minute)
+	require.Equal(t, 1, len(rule.alerts()))
+
+	// A pending alert that stops being active is dropped without resolving.
+	rule.eval(nil, start.Add(30*time.Second), time.Minute)
+	assert.Equal(t, 0, len(rule.alerts()))
+}
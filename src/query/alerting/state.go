@@ -0,0 +1,91 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package alerting
+
+import (
+	"encoding/json"
+
+	"github.com/m3db/m3/src/cluster/generated/proto/commonpb"
+	"github.com/m3db/m3/src/cluster/kv"
+)
+
+// DefaultStateKey is the default KV key alert state is persisted to.
+const DefaultStateKey = "_m3query.alerting.state"
+
+// KVStoreFn returns the KV store to persist to, it may return an error
+// if the store is not yet available.
+type KVStoreFn func() (kv.Store, error)
+
+type kvStateStore struct {
+	storeFn KVStoreFn
+	key     string
+}
+
+// NewKVStateStore returns a state store that persists alert state as a
+// single JSON encoded value at the given KV key.
+func NewKVStateStore(storeFn KVStoreFn, key string) StateStore {
+	return &kvStateStore{
+		storeFn: storeFn,
+		key:     key,
+	}
+}
+
+func (s *kvStateStore) Load() (map[string][]Alert, error) {
+	store, err := s.storeFn()
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := store.Get(s.key)
+	if err == kv.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var proto commonpb.StringProto
+	if err := value.Unmarshal(&proto); err != nil {
+		return nil, err
+	}
+
+	var state map[string][]Alert
+	if err := json.Unmarshal([]byte(proto.Value), &state); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+func (s *kvStateStore) Save(state map[string][]Alert) error {
+	store, err := s.storeFn()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	_, err = store.Set(s.key, &commonpb.StringProto{Value: string(data)})
+	return err
+}
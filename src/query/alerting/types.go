@@ -0,0 +1,144 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package alerting
+
+import (
+	"context"
+	"time"
+)
+
+// AlertState is the state of an alert.
+type AlertState int
+
+const (
+	// StateInactive is the state of an alert that is neither pending nor
+	// firing, i.e. one that has recently resolved.
+	StateInactive AlertState = iota
+	// StatePending is the state of an alert whose expression is active but
+	// has not been active for longer than the rule's hold duration.
+	StatePending
+	// StateFiring is the state of an alert that has been active for longer
+	// than the rule's hold duration.
+	StateFiring
+)
+
+func (s AlertState) String() string {
+	switch s {
+	case StateInactive:
+		return "inactive"
+	case StatePending:
+		return "pending"
+	case StateFiring:
+		return "firing"
+	}
+	return "unknown"
+}
+
+// RuleHealth describes the health of a rule as of its last evaluation.
+type RuleHealth string
+
+const (
+	// HealthUnknown is the health of a rule that has not been evaluated yet.
+	HealthUnknown RuleHealth = "unknown"
+	// HealthGood is the health of a rule that evaluated successfully.
+	HealthGood RuleHealth = "ok"
+	// HealthBad is the health of a rule that failed to evaluate.
+	HealthBad RuleHealth = "err"
+)
+
+// Sample is a single element of an instant vector.
+type Sample struct {
+	Labels map[string]string
+	Value  float64
+}
+
+// Vector is the result of evaluating an instant query.
+type Vector []Sample
+
+// QueryFunc evaluates an instant query at the given time.
+type QueryFunc func(ctx context.Context, query string, t time.Time) (Vector, error)
+
+// Alert is a single alert instance produced by an alerting rule.
+type Alert struct {
+	State       AlertState        `json:"state"`
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	Value       float64           `json:"value"`
+	ActiveAt    time.Time         `json:"activeAt"`
+	FiredAt     time.Time         `json:"firedAt"`
+	ResolvedAt  time.Time         `json:"resolvedAt"`
+	LastSentAt  time.Time         `json:"lastSentAt"`
+}
+
+// RuleStatus is a point in time view of an alerting rule.
+type RuleStatus struct {
+	Name           string
+	Query          string
+	For            time.Duration
+	Labels         map[string]string
+	Annotations    map[string]string
+	Health         RuleHealth
+	LastError      string
+	LastEvaluation time.Time
+	EvaluationTime time.Duration
+	Alerts         []Alert
+}
+
+// GroupStatus is a point in time view of a group of alerting rules.
+type GroupStatus struct {
+	Name           string
+	File           string
+	Interval       time.Duration
+	LastEvaluation time.Time
+	EvaluationTime time.Duration
+	Rules          []RuleStatus
+}
+
+// Manager evaluates alerting rules on an interval and tracks the
+// pending/firing state of the alerts they produce.
+type Manager interface {
+	// Start starts evaluating the rule groups.
+	Start() error
+
+	// RuleGroups returns the current status of all rule groups.
+	RuleGroups() []GroupStatus
+
+	// Alerts returns all currently pending and firing alerts.
+	Alerts() []Alert
+
+	// Close stops evaluating the rule groups.
+	Close() error
+}
+
+// Notifier sends alerts to an external alert receiver.
+type Notifier interface {
+	// Send sends the given alerts.
+	Send(ctx context.Context, alerts []Alert) error
+}
+
+// StateStore persists alert state so that it survives restarts.
+type StateStore interface {
+	// Load returns the persisted alerts keyed by rule.
+	Load() (map[string][]Alert, error)
+
+	// Save persists the given alerts keyed by rule.
+	Save(state map[string][]Alert) error
+}
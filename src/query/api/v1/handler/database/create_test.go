@@ -33,6 +33,7 @@ import (
 	"github.com/m3db/m3/src/cluster/client"
 	"github.com/m3db/m3/src/cluster/generated/proto/placementpb"
 	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/cluster/kv/util/history"
 	"github.com/m3db/m3/src/cluster/placement"
 	"github.com/m3db/m3/src/cluster/services"
 	dbconfig "github.com/m3db/m3/src/cmd/services/m3dbnode/config"
@@ -112,8 +113,11 @@ func testLocalType(t *testing.T, providedType string, placementExists bool) {
 		xjson.MustNewTestReader(t, jsonInput))
 	require.NotNil(t, req)
 
-	mockKV.EXPECT().Get(namespace.M3DBNodeNamespacesKey).Return(nil, kv.ErrNotFound).Times(2)
+	mockKV.EXPECT().Get(namespace.M3DBNodeNamespacesKey).Return(nil, kv.ErrNotFound).Times(3)
 	mockKV.EXPECT().CheckAndSet(namespace.M3DBNodeNamespacesKey, gomock.Any(), gomock.Not(nil)).Return(1, nil)
+	mockKV.EXPECT().Set(namespace.M3DBNodeNamespacesKey+history.KeySuffix+"/0", gomock.Any()).Return(1, nil)
+	mockKV.EXPECT().Get(namespace.M3DBNodeNamespacesKey+history.KeySuffix).Return(nil, kv.ErrNotFound).Times(2)
+	mockKV.EXPECT().SetIfNotExists(namespace.M3DBNodeNamespacesKey+history.KeySuffix, gomock.Any()).Return(1, nil)
 
 	placementProto := &placementpb.Placement{
 		Instances: map[string]*placementpb.Instance{
@@ -276,8 +280,11 @@ func TestLocalTypeWithNumShards(t *testing.T) {
 		xjson.MustNewTestReader(t, jsonInput))
 	require.NotNil(t, req)
 
-	mockKV.EXPECT().Get(namespace.M3DBNodeNamespacesKey).Return(nil, kv.ErrNotFound).Times(2)
+	mockKV.EXPECT().Get(namespace.M3DBNodeNamespacesKey).Return(nil, kv.ErrNotFound).Times(3)
 	mockKV.EXPECT().CheckAndSet(namespace.M3DBNodeNamespacesKey, gomock.Any(), gomock.Not(nil)).Return(1, nil)
+	mockKV.EXPECT().Set(namespace.M3DBNodeNamespacesKey+history.KeySuffix+"/0", gomock.Any()).Return(1, nil)
+	mockKV.EXPECT().Get(namespace.M3DBNodeNamespacesKey+history.KeySuffix).Return(nil, kv.ErrNotFound).Times(2)
+	mockKV.EXPECT().SetIfNotExists(namespace.M3DBNodeNamespacesKey+history.KeySuffix, gomock.Any()).Return(1, nil)
 
 	placementProto := &placementpb.Placement{
 		Instances: map[string]*placementpb.Instance{
@@ -387,8 +394,11 @@ func TestLocalWithBlockSizeNanos(t *testing.T) {
 		xjson.MustNewTestReader(t, jsonInput))
 	require.NotNil(t, req)
 
-	mockKV.EXPECT().Get(namespace.M3DBNodeNamespacesKey).Return(nil, kv.ErrNotFound).Times(2)
+	mockKV.EXPECT().Get(namespace.M3DBNodeNamespacesKey).Return(nil, kv.ErrNotFound).Times(3)
 	mockKV.EXPECT().CheckAndSet(namespace.M3DBNodeNamespacesKey, gomock.Any(), gomock.Not(nil)).Return(1, nil)
+	mockKV.EXPECT().Set(namespace.M3DBNodeNamespacesKey+history.KeySuffix+"/0", gomock.Any()).Return(1, nil)
+	mockKV.EXPECT().Get(namespace.M3DBNodeNamespacesKey+history.KeySuffix).Return(nil, kv.ErrNotFound).Times(2)
+	mockKV.EXPECT().SetIfNotExists(namespace.M3DBNodeNamespacesKey+history.KeySuffix, gomock.Any()).Return(1, nil)
 
 	placementProto := &placementpb.Placement{
 		Instances: map[string]*placementpb.Instance{
@@ -504,8 +514,11 @@ func TestLocalWithBlockSizeExpectedSeriesDatapointsPerHour(t *testing.T) {
 		xjson.MustNewTestReader(t, jsonInput))
 	require.NotNil(t, req)
 
-	mockKV.EXPECT().Get(namespace.M3DBNodeNamespacesKey).Return(nil, kv.ErrNotFound).Times(2)
+	mockKV.EXPECT().Get(namespace.M3DBNodeNamespacesKey).Return(nil, kv.ErrNotFound).Times(3)
 	mockKV.EXPECT().CheckAndSet(namespace.M3DBNodeNamespacesKey, gomock.Any(), gomock.Not(nil)).Return(1, nil)
+	mockKV.EXPECT().Set(namespace.M3DBNodeNamespacesKey+history.KeySuffix+"/0", gomock.Any()).Return(1, nil)
+	mockKV.EXPECT().Get(namespace.M3DBNodeNamespacesKey+history.KeySuffix).Return(nil, kv.ErrNotFound).Times(2)
+	mockKV.EXPECT().SetIfNotExists(namespace.M3DBNodeNamespacesKey+history.KeySuffix, gomock.Any()).Return(1, nil)
 
 	placementProto := &placementpb.Placement{
 		Instances: map[string]*placementpb.Instance{
@@ -737,8 +750,11 @@ func testClusterTypeHosts(t *testing.T, placementExists bool) {
 	req := httptest.NewRequest("POST", "/database/create", reqBody)
 	require.NotNil(t, req)
 
-	mockKV.EXPECT().Get(namespace.M3DBNodeNamespacesKey).Return(nil, kv.ErrNotFound).Times(2)
+	mockKV.EXPECT().Get(namespace.M3DBNodeNamespacesKey).Return(nil, kv.ErrNotFound).Times(3)
 	mockKV.EXPECT().CheckAndSet(namespace.M3DBNodeNamespacesKey, gomock.Any(), gomock.Not(nil)).Return(1, nil)
+	mockKV.EXPECT().Set(namespace.M3DBNodeNamespacesKey+history.KeySuffix+"/0", gomock.Any()).Return(1, nil)
+	mockKV.EXPECT().Get(namespace.M3DBNodeNamespacesKey+history.KeySuffix).Return(nil, kv.ErrNotFound).Times(2)
+	mockKV.EXPECT().SetIfNotExists(namespace.M3DBNodeNamespacesKey+history.KeySuffix, gomock.Any()).Return(1, nil)
 
 	placementProto := &placementpb.Placement{
 		Instances: map[string]*placementpb.Instance{
@@ -879,8 +895,11 @@ func TestClusterTypeHostsWithIsolationGroup(t *testing.T) {
 		xjson.MustNewTestReader(t, jsonInput))
 	require.NotNil(t, req)
 
-	mockKV.EXPECT().Get(namespace.M3DBNodeNamespacesKey).Return(nil, kv.ErrNotFound).Times(2)
+	mockKV.EXPECT().Get(namespace.M3DBNodeNamespacesKey).Return(nil, kv.ErrNotFound).Times(3)
 	mockKV.EXPECT().CheckAndSet(namespace.M3DBNodeNamespacesKey, gomock.Any(), gomock.Not(nil)).Return(1, nil)
+	mockKV.EXPECT().Set(namespace.M3DBNodeNamespacesKey+history.KeySuffix+"/0", gomock.Any()).Return(1, nil)
+	mockKV.EXPECT().Get(namespace.M3DBNodeNamespacesKey+history.KeySuffix).Return(nil, kv.ErrNotFound).Times(2)
+	mockKV.EXPECT().SetIfNotExists(namespace.M3DBNodeNamespacesKey+history.KeySuffix, gomock.Any()).Return(1, nil)
 
 	placementProto := &placementpb.Placement{
 		Instances: map[string]*placementpb.Instance{
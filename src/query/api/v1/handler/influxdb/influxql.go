@@ -0,0 +1,713 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package influxdb
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// This file implements a parser for the subset of InfluxQL the query
+// handler supports, statements of the form:
+//
+//   SELECT <function>(<field>) [AS <alias>][, ...] FROM <measurement>
+//   [WHERE <condition> [AND <condition> ...]]
+//   [GROUP BY [time(<interval>)][, <tag> ...]] [fill(<option>)]
+//
+// where conditions are either tag comparisons (=, !=, <>, =~ and !~) or
+// time bounds relative to now(), absolute or as epoch nanoseconds.
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenQuotedIdent
+	tokenString
+	tokenNumber
+	tokenDuration
+	tokenRegex
+	tokenSymbol
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "EOF"
+	case tokenString:
+		return "'" + t.value + "'"
+	case tokenQuotedIdent:
+		return `"` + t.value + `"`
+	case tokenRegex:
+		return "/" + t.value + "/"
+	default:
+		return t.value
+	}
+}
+
+// isKeyword returns whether the token is the given, case insensitive,
+// keyword.
+func (t token) isKeyword(keyword string) bool {
+	return t.kind == tokenIdent && strings.EqualFold(t.value, keyword)
+}
+
+func (t token) isSymbol(symbol string) bool {
+	return t.kind == tokenSymbol && t.value == symbol
+}
+
+var (
+	errNoStatements       = errors.New("no statements in query")
+	errUnterminatedString = errors.New("unterminated string literal")
+	errUnterminatedRegex  = errors.New("unterminated regular expression")
+	errNoLowerTimeBound   = errors.New("a lower time bound is required, " +
+		"e.g. WHERE time > now() - 1h")
+)
+
+// durationUnits are the InfluxQL duration literal units.
+var durationUnits = map[string]time.Duration{
+	"ns": time.Nanosecond,
+	"u":  time.Microsecond,
+	"µ":  time.Microsecond,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+	"d":  24 * time.Hour,
+	"w":  7 * 24 * time.Hour,
+}
+
+func isIdentStart(r rune) bool {
+	return unicode.IsLetter(r) || r == '_'
+}
+
+func isIdentChar(r rune) bool {
+	return isIdentStart(r) || unicode.IsDigit(r)
+}
+
+// lex splits the query into tokens.
+func lex(query string) ([]token, error) {
+	var (
+		tokens []token
+		i      int
+	)
+	for i < len(query) {
+		r, size := utf8.DecodeRuneInString(query[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case isIdentStart(r):
+			start := i
+			for i < len(query) {
+				r, size := utf8.DecodeRuneInString(query[i:])
+				if !isIdentChar(r) {
+					break
+				}
+				i += size
+			}
+			tokens = append(tokens, token{kind: tokenIdent, value: query[start:i]})
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(query) && (isDigit(query[i]) || query[i] == '.') {
+				i++
+			}
+			number := query[start:i]
+			unitStart := i
+			for i < len(query) {
+				r, size := utf8.DecodeRuneInString(query[i:])
+				if !unicode.IsLetter(r) {
+					break
+				}
+				i += size
+			}
+			if unitStart == i {
+				tokens = append(tokens, token{kind: tokenNumber, value: number})
+				continue
+			}
+			tokens = append(tokens, token{kind: tokenDuration, value: query[start:i]})
+		case r == '\'' || r == '"':
+			value, n, err := scanQuoted(query[i:], byte(r))
+			if err != nil {
+				return nil, err
+			}
+			i += n
+			kind := tokenString
+			if r == '"' {
+				kind = tokenQuotedIdent
+			}
+			tokens = append(tokens, token{kind: kind, value: value})
+		case r == '/':
+			end := i + 1
+			var re strings.Builder
+			for ; end < len(query) && query[end] != '/'; end++ {
+				// NB: only escaped slashes are unescaped, everything else
+				// is left to the regular expression.
+				if query[end] == '\\' && end+1 < len(query) && query[end+1] == '/' {
+					end++
+				}
+				re.WriteByte(query[end])
+			}
+			if end >= len(query) {
+				return nil, errUnterminatedRegex
+			}
+			tokens = append(tokens, token{kind: tokenRegex, value: re.String()})
+			i = end + 1
+		default:
+			symbol := string(r)
+			if i+1 < len(query) {
+				switch two := query[i : i+2]; two {
+				case "!=", "<>", "=~", "!~", "<=", ">=":
+					symbol = two
+				}
+			}
+			switch symbol {
+			case "=", "!=", "<>", "=~", "!~", "<", "<=", ">", ">=",
+				"+", "-", "*", "(", ")", ",", ";", ".":
+			default:
+				return nil, fmt.Errorf("unexpected character: %s", symbol)
+			}
+			tokens = append(tokens, token{kind: tokenSymbol, value: symbol})
+			i += len(symbol)
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF}), nil
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// scanQuoted scans a quoted string or identifier, returning its unescaped
+// value and the number of bytes consumed.
+func scanQuoted(str string, quote byte) (string, int, error) {
+	var b strings.Builder
+	for i := 1; i < len(str); i++ {
+		switch str[i] {
+		case quote:
+			return b.String(), i + 1, nil
+		case '\\':
+			if i+1 < len(str) {
+				i++
+			}
+		}
+		b.WriteByte(str[i])
+	}
+	return "", 0, errUnterminatedString
+}
+
+func parseDurationLiteral(str string) (time.Duration, error) {
+	idx := strings.IndexFunc(str, func(r rune) bool {
+		return !unicode.IsDigit(r)
+	})
+	if idx <= 0 {
+		return 0, fmt.Errorf("invalid duration: %s", str)
+	}
+
+	n, err := strconv.ParseInt(str[:idx], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration: %s", str)
+	}
+
+	unit, ok := durationUnits[str[idx:]]
+	if !ok {
+		return 0, fmt.Errorf("invalid duration: %s", str)
+	}
+	return time.Duration(n) * unit, nil
+}
+
+type fillType int
+
+const (
+	fillNull fillType = iota
+	fillNone
+	fillPrevious
+	fillValue
+)
+
+// fillOption is how buckets without values are filled.
+type fillOption struct {
+	fillType fillType
+	value    float64
+}
+
+// selectField is an aggregation of a field.
+type selectField struct {
+	function string
+	field    string
+	alias    string
+}
+
+// tagCondition is a comparison of a tag with a string or a regular
+// expression, op is one of =, !=, =~ and !~.
+type tagCondition struct {
+	tag   string
+	op    string
+	value string
+}
+
+// selectStatement is a parsed InfluxQL select statement.
+type selectStatement struct {
+	fields      []selectField
+	measurement string
+	conditions  []tagCondition
+	start       time.Time
+	end         time.Time
+	interval    time.Duration
+	groupByTags []string
+	fill        fillOption
+}
+
+type queryParser struct {
+	tokens []token
+	pos    int
+	now    time.Time
+}
+
+// parseQuery parses the semicolon separated statements of the query,
+// resolving now() to the given time.
+func parseQuery(query string, now time.Time) ([]*selectStatement, error) {
+	tokens, err := lex(query)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &queryParser{tokens: tokens, now: now}
+	var stmts []*selectStatement
+	for {
+		for p.peek().isSymbol(";") {
+			p.next()
+		}
+		if p.peek().kind == tokenEOF {
+			break
+		}
+
+		stmt, err := p.parseSelect()
+		if err != nil {
+			return nil, err
+		}
+		stmts = append(stmts, stmt)
+
+		if tok := p.next(); tok.kind != tokenEOF && !tok.isSymbol(";") {
+			return nil, unexpected(tok, "; or EOF")
+		}
+	}
+
+	if len(stmts) == 0 {
+		return nil, errNoStatements
+	}
+	return stmts, nil
+}
+
+func unexpected(tok token, expected string) error {
+	return fmt.Errorf("found %s, expected %s", tok, expected)
+}
+
+func (p *queryParser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *queryParser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *queryParser) expectKeyword(keyword string) error {
+	if tok := p.next(); !tok.isKeyword(keyword) {
+		return unexpected(tok, keyword)
+	}
+	return nil
+}
+
+func (p *queryParser) expectSymbol(symbol string) error {
+	if tok := p.next(); !tok.isSymbol(symbol) {
+		return unexpected(tok, symbol)
+	}
+	return nil
+}
+
+func (p *queryParser) parseIdent() (string, error) {
+	tok := p.next()
+	if tok.kind != tokenIdent && tok.kind != tokenQuotedIdent {
+		return "", unexpected(tok, "identifier")
+	}
+	return tok.value, nil
+}
+
+func (p *queryParser) parseSelect() (*selectStatement, error) {
+	if err := p.expectKeyword("select"); err != nil {
+		return nil, err
+	}
+
+	stmt := &selectStatement{end: p.now}
+	for {
+		field, err := p.parseField()
+		if err != nil {
+			return nil, err
+		}
+		stmt.fields = append(stmt.fields, field)
+
+		if !p.peek().isSymbol(",") {
+			break
+		}
+		p.next()
+	}
+	uniqueAliases(stmt.fields)
+
+	if err := p.expectKeyword("from"); err != nil {
+		return nil, err
+	}
+
+	// NB: the database and retention policy of a fully qualified
+	// measurement are ignored.
+	for {
+		measurement, err := p.parseIdent()
+		if err != nil {
+			return nil, err
+		}
+		stmt.measurement = measurement
+
+		if !p.peek().isSymbol(".") {
+			break
+		}
+		p.next()
+	}
+
+	if p.peek().isKeyword("where") {
+		p.next()
+		if err := p.parseConditions(stmt); err != nil {
+			return nil, err
+		}
+	}
+
+	if stmt.start.IsZero() {
+		return nil, errNoLowerTimeBound
+	}
+	if !stmt.start.Before(stmt.end) {
+		return nil, errors.New("lower time bound must be before upper time bound")
+	}
+
+	if p.peek().isKeyword("group") {
+		p.next()
+		if err := p.expectKeyword("by"); err != nil {
+			return nil, err
+		}
+		if err := p.parseGroupBy(stmt); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.peek().isKeyword("fill") {
+		p.next()
+		fill, err := p.parseFill()
+		if err != nil {
+			return nil, err
+		}
+		stmt.fill = fill
+	}
+
+	return stmt, nil
+}
+
+func (p *queryParser) parseField() (selectField, error) {
+	fn := p.next()
+	if fn.kind != tokenIdent {
+		return selectField{}, unexpected(fn, "function")
+	}
+	if !p.peek().isSymbol("(") {
+		return selectField{}, fmt.Errorf(
+			"field %s must be wrapped in an aggregate function", fn.value)
+	}
+
+	function := strings.ToLower(fn.value)
+	if _, ok := influxFunctions[function]; !ok {
+		return selectField{}, fmt.Errorf("unsupported function: %s", fn.value)
+	}
+
+	p.next()
+	field, err := p.parseIdent()
+	if err != nil {
+		return selectField{}, err
+	}
+	if err := p.expectSymbol(")"); err != nil {
+		return selectField{}, err
+	}
+
+	alias := function
+	if p.peek().isKeyword("as") {
+		p.next()
+		if alias, err = p.parseIdent(); err != nil {
+			return selectField{}, err
+		}
+	}
+
+	return selectField{function: function, field: field, alias: alias}, nil
+}
+
+// uniqueAliases suffixes duplicate column names with an index the same as
+// InfluxDB, e.g. SELECT mean(a), mean(b) has the columns mean and mean_1.
+func uniqueAliases(fields []selectField) {
+	seen := make(map[string]struct{}, len(fields))
+	for i, f := range fields {
+		alias := f.alias
+		for n := 1; ; n++ {
+			if _, ok := seen[alias]; !ok {
+				break
+			}
+			alias = f.alias + "_" + strconv.Itoa(n)
+		}
+		seen[alias] = struct{}{}
+		fields[i].alias = alias
+	}
+}
+
+func (p *queryParser) parseConditions(stmt *selectStatement) error {
+	for {
+		if err := p.parseCondition(stmt); err != nil {
+			return err
+		}
+
+		tok := p.peek()
+		if tok.isKeyword("or") {
+			return errors.New("OR conditions are not supported")
+		}
+		if !tok.isKeyword("and") {
+			return nil
+		}
+		p.next()
+	}
+}
+
+func (p *queryParser) parseCondition(stmt *selectStatement) error {
+	name, err := p.parseIdent()
+	if err != nil {
+		return err
+	}
+
+	op := p.next()
+	if op.kind != tokenSymbol {
+		return unexpected(op, "comparison operator")
+	}
+
+	if strings.EqualFold(name, "time") {
+		return p.parseTimeCondition(stmt, op)
+	}
+
+	switch op.value {
+	case "=", "!=", "<>":
+		value := p.next()
+		if value.kind != tokenString {
+			return unexpected(value, "string")
+		}
+		cond := tagCondition{tag: name, op: op.value, value: value.value}
+		if op.value == "<>" {
+			cond.op = "!="
+		}
+		stmt.conditions = append(stmt.conditions, cond)
+	case "=~", "!~":
+		value := p.next()
+		if value.kind != tokenRegex {
+			return unexpected(value, "regular expression")
+		}
+		stmt.conditions = append(stmt.conditions,
+			tagCondition{tag: name, op: op.value, value: value.value})
+	default:
+		return unexpected(op, "=, !=, <>, =~ or !~")
+	}
+
+	return nil
+}
+
+func (p *queryParser) parseTimeCondition(stmt *selectStatement, op token) error {
+	t, err := p.parseTime()
+	if err != nil {
+		return err
+	}
+
+	// NB: bounds are aligned to the query resolution, so inclusive and
+	// exclusive bounds are treated the same.
+	switch op.value {
+	case ">", ">=":
+		if t.After(stmt.start) {
+			stmt.start = t
+		}
+	case "<", "<=":
+		if t.Before(stmt.end) {
+			stmt.end = t
+		}
+	default:
+		return unexpected(op, "<, <=, > or >=")
+	}
+
+	return nil
+}
+
+// parseTime parses now() with an optional duration offset, an RFC3339
+// or date time string, epoch nanoseconds or a duration since the epoch.
+func (p *queryParser) parseTime() (time.Time, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokenIdent:
+		if !strings.EqualFold(tok.value, "now") {
+			return time.Time{}, unexpected(tok, "now()")
+		}
+		if err := p.expectSymbol("("); err != nil {
+			return time.Time{}, err
+		}
+		if err := p.expectSymbol(")"); err != nil {
+			return time.Time{}, err
+		}
+
+		t := p.now
+		for p.peek().isSymbol("+") || p.peek().isSymbol("-") {
+			sign := p.next()
+			d := p.next()
+			if d.kind != tokenDuration {
+				return time.Time{}, unexpected(d, "duration")
+			}
+			offset, err := parseDurationLiteral(d.value)
+			if err != nil {
+				return time.Time{}, err
+			}
+			if sign.value == "-" {
+				offset = -offset
+			}
+			t = t.Add(offset)
+		}
+		return t, nil
+	case tokenString:
+		for _, layout := range []string{
+			time.RFC3339Nano,
+			"2006-01-02 15:04:05.999999999",
+			"2006-01-02",
+		} {
+			if t, err := time.Parse(layout, tok.value); err == nil {
+				return t, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("invalid time: %s", tok.value)
+	case tokenNumber:
+		ns, err := strconv.ParseInt(tok.value, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid time: %s", tok.value)
+		}
+		return time.Unix(0, ns), nil
+	case tokenDuration:
+		d, err := parseDurationLiteral(tok.value)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Unix(0, int64(d)), nil
+	default:
+		return time.Time{}, unexpected(tok, "time")
+	}
+}
+
+func (p *queryParser) parseGroupBy(stmt *selectStatement) error {
+	for {
+		tok := p.peek()
+		if tok.isKeyword("time") && p.tokens[p.pos+1].isSymbol("(") {
+			p.next()
+			p.next()
+			d := p.next()
+			if d.kind != tokenDuration {
+				return unexpected(d, "duration")
+			}
+			interval, err := parseDurationLiteral(d.value)
+			if err != nil {
+				return err
+			}
+			if interval <= 0 {
+				return fmt.Errorf("invalid group by interval: %s", d.value)
+			}
+			if p.peek().isSymbol(",") {
+				return errors.New("group by time offsets are not supported")
+			}
+			if err := p.expectSymbol(")"); err != nil {
+				return err
+			}
+			stmt.interval = interval
+		} else {
+			if tok.isSymbol("*") {
+				return errors.New("group by * is not supported")
+			}
+			tag, err := p.parseIdent()
+			if err != nil {
+				return err
+			}
+			stmt.groupByTags = append(stmt.groupByTags, tag)
+		}
+
+		if !p.peek().isSymbol(",") {
+			return nil
+		}
+		p.next()
+	}
+}
+
+func (p *queryParser) parseFill() (fillOption, error) {
+	if err := p.expectSymbol("("); err != nil {
+		return fillOption{}, err
+	}
+
+	var (
+		fill fillOption
+		tok  = p.next()
+	)
+	switch {
+	case tok.isKeyword("null"):
+		fill.fillType = fillNull
+	case tok.isKeyword("none"):
+		fill.fillType = fillNone
+	case tok.isKeyword("previous"):
+		fill.fillType = fillPrevious
+	case tok.kind == tokenNumber || tok.isSymbol("-"):
+		sign := 1.0
+		if tok.isSymbol("-") {
+			sign = -1
+			tok = p.next()
+		}
+		v, err := strconv.ParseFloat(tok.value, 64)
+		if tok.kind != tokenNumber || err != nil {
+			return fillOption{}, unexpected(tok, "number")
+		}
+		fill = fillOption{fillType: fillValue, value: sign * v}
+	default:
+		return fillOption{}, unexpected(tok, "null, none, previous or a number")
+	}
+
+	if err := p.expectSymbol(")"); err != nil {
+		return fillOption{}, err
+	}
+	return fill, nil
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package influxdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Unix(1500000000, 0).UTC()
+
+func TestParseQuery(t *testing.T) {
+	stmts, err := parseQuery(`SELECT mean("value") FROM cpu `+
+		`WHERE host='server01' AND region =~ /us-.*/ AND time > now() - 1h `+
+		`GROUP BY time(1m), host fill(none)`, testNow)
+	require.NoError(t, err)
+	require.Len(t, stmts, 1)
+
+	assert.Equal(t, &selectStatement{
+		fields:      []selectField{{function: "mean", field: "value", alias: "mean"}},
+		measurement: "cpu",
+		conditions: []tagCondition{
+			{tag: "host", op: "=", value: "server01"},
+			{tag: "region", op: "=~", value: "us-.*"},
+		},
+		start:       testNow.Add(-time.Hour),
+		end:         testNow,
+		interval:    time.Minute,
+		groupByTags: []string{"host"},
+		fill:        fillOption{fillType: fillNone},
+	}, stmts[0])
+}
+
+func TestParseQueryMultipleStatements(t *testing.T) {
+	stmts, err := parseQuery(`select MAX(a) as peak, max(b), Count(c) `+
+		`from "db"."autogen"."m" where time >= '2017-07-14T02:00:00Z' `+
+		`and time < 1499999000000000000 and "host" <> 'x' fill(-1.5); `+
+		`SELECT sum(a) FROM m WHERE time > 1499990000s GROUP BY a, time(5m)`,
+		testNow)
+	require.NoError(t, err)
+	require.Len(t, stmts, 2)
+
+	assert.Equal(t, &selectStatement{
+		fields: []selectField{
+			{function: "max", field: "a", alias: "peak"},
+			{function: "max", field: "b", alias: "max"},
+			{function: "count", field: "c", alias: "count"},
+		},
+		measurement: "m",
+		conditions:  []tagCondition{{tag: "host", op: "!=", value: "x"}},
+		start:       time.Date(2017, 7, 14, 2, 0, 0, 0, time.UTC),
+		end:         time.Unix(1499999000, 0),
+		fill:        fillOption{fillType: fillValue, value: -1.5},
+	}, stmts[0])
+
+	assert.Equal(t, &selectStatement{
+		fields:      []selectField{{function: "sum", field: "a", alias: "sum"}},
+		measurement: "m",
+		start:       time.Unix(1499990000, 0),
+		end:         testNow,
+		interval:    5 * time.Minute,
+		groupByTags: []string{"a"},
+	}, stmts[1])
+}
+
+func TestParseQueryDuplicateAliases(t *testing.T) {
+	stmts, err := parseQuery(
+		"SELECT mean(a), mean(b), mean(c) FROM m WHERE time > now() - 1h",
+		testNow)
+	require.NoError(t, err)
+	require.Len(t, stmts, 1)
+
+	var aliases []string
+	for _, f := range stmts[0].fields {
+		aliases = append(aliases, f.alias)
+	}
+	assert.Equal(t, []string{"mean", "mean_1", "mean_2"}, aliases)
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	for _, query := range []string{
+		"",
+		";",
+		"SHOW MEASUREMENTS",
+		"SELECT value FROM m WHERE time > now() - 1h",
+		"SELECT mode(value) FROM m WHERE time > now() - 1h",
+		"SELECT mean(value) FROM m",
+		"SELECT mean(value) FROM m WHERE time > now() + 1h",
+		"SELECT mean(value) FROM m WHERE time > now() - 1x",
+		"SELECT mean(value) FROM m WHERE host = 'a' OR host = 'b'",
+		"SELECT mean(value) FROM m WHERE host = /a/ AND time > now() - 1h",
+		"SELECT mean(value) FROM m WHERE host =~ 'a' AND time > now() - 1h",
+		"SELECT mean(value) FROM m WHERE host = 'a AND time > now() - 1h",
+		"SELECT mean(value) FROM m WHERE time > now() - 1h GROUP BY *",
+		"SELECT mean(value) FROM m WHERE time > now() - 1h GROUP BY time(1m, 30s)",
+		"SELECT mean(value) FROM m WHERE time > now() - 1h fill(linear)",
+		"SELECT mean(value) FROM m WHERE time > now() - 1h LIMIT 1",
+	} {
+		_, err := parseQuery(query, testNow)
+		assert.Error(t, err, query)
+	}
+}
@@ -0,0 +1,602 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package influxdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/functions"
+	"github.com/m3db/m3/src/query/functions/aggregation"
+	"github.com/m3db/m3/src/query/functions/temporal"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// InfluxQueryURL is the InfluxDB compatible query handler URL.
+	InfluxQueryURL = handler.RoutePrefixV1 + "/influxdb/query"
+
+	queryParam = "q"
+	epochParam = "epoch"
+)
+
+var (
+	// InfluxQueryHTTPMethods are the HTTP methods used with the query
+	// resource.
+	InfluxQueryHTTPMethods = []string{http.MethodGet, http.MethodPost}
+
+	errNoEngine = errors.New("no query engine set")
+	errNoQuery  = errors.New("missing required parameter: q")
+)
+
+// influxFunction is the temporal function applied to each series and the
+// aggregation applied across the series of each group for an InfluxQL
+// function. NB: the aggregation is over the per series values, so for
+// example the mean of a group is the mean of the means of its series.
+type influxFunction struct {
+	temporal    string
+	aggregation string
+	quantile    float64
+}
+
+var influxFunctions = map[string]influxFunction{
+	"mean":   {temporal: temporal.AvgType, aggregation: aggregation.AverageType},
+	"median": {temporal: temporal.QuantileType, aggregation: aggregation.QuantileType, quantile: 0.5},
+	"sum":    {temporal: temporal.SumType, aggregation: aggregation.SumType},
+	"count":  {temporal: temporal.CountType, aggregation: aggregation.SumType},
+	"min":    {temporal: temporal.MinType, aggregation: aggregation.MinType},
+	"max":    {temporal: temporal.MaxType, aggregation: aggregation.MaxType},
+}
+
+// fieldQuery converts a field of a select statement into a DAG of a fetch,
+// the temporal function calculated over the group by interval and an
+// aggregation by the group by tags.
+type fieldQuery struct {
+	name     []byte
+	matchers models.Matchers
+	function influxFunction
+	step     time.Duration
+	groupBy  [][]byte
+	query    string
+}
+
+func newFieldQuery(
+	stmt *selectStatement,
+	field selectField,
+	step time.Duration,
+	rewriter *promRewriter,
+	tagOpts models.TagOptions,
+) (*fieldQuery, error) {
+	name := rewriter.seriesName(stmt.measurement, field.field)
+	nameMatcher, err := models.NewMatcher(models.MatchEqual,
+		tagOpts.MetricName(), name)
+	if err != nil {
+		return nil, err
+	}
+
+	matchers := models.Matchers{nameMatcher}
+	for _, c := range stmt.conditions {
+		matcher, err := newConditionMatcher(c, rewriter)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, matcher)
+	}
+
+	groupBy := make([][]byte, 0, len(stmt.groupByTags))
+	for _, tag := range stmt.groupByTags {
+		groupBy = append(groupBy, labelName(tag, rewriter))
+	}
+
+	return &fieldQuery{
+		name:     name,
+		matchers: matchers,
+		function: influxFunctions[field.function],
+		step:     step,
+		groupBy:  groupBy,
+		query: fmt.Sprintf("SELECT %s(%s) FROM %s", field.function,
+			field.field, stmt.measurement),
+	}, nil
+}
+
+// labelName returns the label a tag is written as.
+func labelName(tag string, rewriter *promRewriter) []byte {
+	name := []byte(tag)
+	rewriter.rewriteLabel(name)
+	return name
+}
+
+func newConditionMatcher(
+	c tagCondition,
+	rewriter *promRewriter,
+) (models.Matcher, error) {
+	name := labelName(c.tag, rewriter)
+	switch c.op {
+	case "=":
+		return models.NewMatcher(models.MatchEqual, name, []byte(c.value))
+	case "!=":
+		return models.NewMatcher(models.MatchNotEqual, name, []byte(c.value))
+	}
+
+	// NB: InfluxQL regular expressions are unanchored.
+	re := ".*(?:" + c.value + ").*"
+	if _, err := regexp.Compile(re); err != nil {
+		return models.Matcher{}, err
+	}
+	if c.op == "!~" {
+		return models.NewMatcher(models.MatchNotRegexp, name, []byte(re))
+	}
+	return models.NewMatcher(models.MatchRegexp, name, []byte(re))
+}
+
+func (q *fieldQuery) DAG() (parser.Nodes, parser.Edges, error) {
+	var (
+		temporalOp parser.Params
+		err        error
+	)
+	if q.function.temporal == temporal.QuantileType {
+		temporalOp, err = temporal.NewQuantileOp(
+			[]interface{}{q.function.quantile, q.step}, temporal.QuantileType)
+	} else {
+		temporalOp, err = temporal.NewAggOp([]interface{}{q.step},
+			q.function.temporal)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	aggregationOp, err := aggregation.NewAggregationOp(q.function.aggregation,
+		aggregation.NodeParams{
+			MatchingTags: q.groupBy,
+			Parameter:    q.function.quantile,
+		})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fetch := functions.FetchOp{
+		Name:     string(q.name),
+		Range:    q.step,
+		Matchers: q.matchers,
+	}
+
+	var (
+		nodes = parser.Nodes{parser.NewTransformFromOperation(fetch, 0)}
+		edges parser.Edges
+	)
+	for _, op := range []parser.Params{temporalOp, aggregationOp} {
+		node := parser.NewTransformFromOperation(op, len(nodes))
+		edges = append(edges, parser.Edge{
+			ParentID: nodes[len(nodes)-1].ID,
+			ChildID:  node.ID,
+		})
+		nodes = append(nodes, node)
+	}
+
+	return nodes, edges, nil
+}
+
+func (q *fieldQuery) String() string {
+	return q.query
+}
+
+type queryResponse struct {
+	Results []statementResult `json:"results"`
+}
+
+type statementResult struct {
+	StatementID int            `json:"statement_id"`
+	Series      []resultSeries `json:"series,omitempty"`
+	Error       string         `json:"error,omitempty"`
+}
+
+type resultSeries struct {
+	Name    string            `json:"name"`
+	Tags    map[string]string `json:"tags,omitempty"`
+	Columns []string          `json:"columns"`
+	Values  [][]interface{}   `json:"values"`
+}
+
+// QueryHandler is the InfluxDB compatible query handler, it supports a
+// minimal subset of InfluxQL select statements which are translated to
+// queries against the series written by the write handler.
+type QueryHandler struct {
+	engine              executor.Engine
+	fetchOptionsBuilder handleroptions.FetchOptionsBuilder
+	tagOpts             models.TagOptions
+	promRewriter        *promRewriter
+	maxDatapoints       int
+	nowFn               clock.NowFn
+	instrumentOpts      instrument.Options
+}
+
+// NewInfluxQueryHandler returns a new instance of the query handler.
+func NewInfluxQueryHandler(opts options.HandlerOptions) http.Handler {
+	return &QueryHandler{
+		engine:              opts.Engine(),
+		fetchOptionsBuilder: opts.FetchOptionsBuilder(),
+		tagOpts:             opts.TagOptions(),
+		promRewriter:        newPromRewriter(),
+		maxDatapoints:       opts.Config().Limits.MaxComputedDatapoints(),
+		nowFn:               opts.NowFn(),
+		instrumentOpts:      opts.InstrumentOpts(),
+	}
+}
+
+func (h *QueryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.engine == nil {
+		xhttp.Error(w, errNoEngine, http.StatusInternalServerError)
+		return
+	}
+
+	query := r.FormValue(queryParam)
+	if query == "" {
+		xhttp.Error(w, errNoQuery, http.StatusBadRequest)
+		return
+	}
+
+	epoch := r.FormValue(epochParam)
+	if epoch != "" {
+		precision, err := parsePrecision(epoch)
+		if err != nil {
+			xhttp.Error(w, err, http.StatusBadRequest)
+			return
+		}
+		epoch = precision
+	}
+
+	now := h.nowFn()
+	stmts, err := parseQuery(query, now)
+	if err != nil {
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	fetchOpts, rErr := h.fetchOptionsBuilder.NewFetchOptions(r)
+	if rErr != nil {
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	// NB: the same as InfluxDB, statement errors are returned in the
+	// result of the statement rather than failing the request.
+	resp := queryResponse{Results: make([]statementResult, 0, len(stmts))}
+	for i, stmt := range stmts {
+		result := statementResult{StatementID: i}
+		series, err := h.execute(r.Context(), stmt, now, fetchOpts, epoch)
+		if err != nil {
+			logger := logging.WithContext(r.Context(), h.instrumentOpts)
+			logger.Error("unable to execute statement",
+				zap.Int("statementID", i), zap.Error(err))
+			result.Error = err.Error()
+		} else {
+			result.Series = series
+		}
+
+		resp.Results = append(resp.Results, result)
+	}
+
+	xhttp.WriteJSONResponse(w, resp, h.instrumentOpts.Logger())
+}
+
+// group is a series of the result, identified by its group by tags, with
+// a row of the values of each field per step.
+type group struct {
+	tags map[string]string
+	rows [][]float64
+}
+
+func (h *QueryHandler) execute(
+	ctx context.Context,
+	stmt *selectStatement,
+	now time.Time,
+	fetchOpts *storage.FetchOptions,
+	epoch string,
+) ([]resultSeries, error) {
+	// NB: InfluxDB group by time buckets are aligned to the interval and
+	// labelled by their start, whereas each step is calculated over the
+	// range preceding it, so evaluate steps at the end of each bucket.
+	// Without an interval the whole time range is a single bucket.
+	params := models.RequestParams{
+		Now:              now,
+		Timeout:          fetchOpts.Timeout,
+		IncludeEnd:       true,
+		LookbackDuration: h.engine.Options().LookbackDuration(),
+	}
+	if stmt.interval > 0 {
+		params.Step = stmt.interval
+		params.Start = stmt.start.Truncate(stmt.interval).Add(stmt.interval)
+		params.End = stmt.end.Add(-1).Truncate(stmt.interval).Add(stmt.interval)
+
+		numSteps := int(params.End.Sub(params.Start) / params.Step)
+		if h.maxDatapoints > 0 && numSteps > h.maxDatapoints {
+			return nil, fmt.Errorf("grouping from %v to %v by time(%v) would "+
+				"result in too many datapoints (more than %d), increase the "+
+				"interval or decrease the time range", stmt.start, stmt.end,
+				stmt.interval, h.maxDatapoints)
+		}
+	} else {
+		params.Step = stmt.end.Sub(stmt.start)
+		params.Start = stmt.end
+		params.End = stmt.end
+	}
+
+	queryOpts := &executor.QueryOptions{
+		QueryContextOptions: models.QueryContextOptions{
+			LimitMaxTimeseries: fetchOpts.Limit,
+		},
+	}
+
+	var (
+		groups = make(map[string]*group)
+		times  []time.Time
+	)
+	for i, field := range stmt.fields {
+		q, err := newFieldQuery(stmt, field, params.Step, h.promRewriter,
+			h.tagOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		params.Query = q.String()
+		stepTimes, err := h.executeField(ctx, q, stmt, i, queryOpts,
+			fetchOpts, params, groups)
+		if err != nil {
+			return nil, err
+		}
+		times = stepTimes
+	}
+
+	for i, t := range times {
+		if stmt.interval > 0 {
+			times[i] = t.Add(-stmt.interval)
+		} else {
+			times[i] = stmt.start
+		}
+	}
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	columns := make([]string, 0, len(stmt.fields)+1)
+	columns = append(columns, "time")
+	for _, field := range stmt.fields {
+		columns = append(columns, field.alias)
+	}
+
+	series := make([]resultSeries, 0, len(keys))
+	for _, key := range keys {
+		g := groups[key]
+		values := fillRows(g.rows, len(stmt.fields), times, stmt.fill, epoch)
+		if len(values) == 0 {
+			continue
+		}
+
+		s := resultSeries{
+			Name:    stmt.measurement,
+			Columns: columns,
+			Values:  values,
+		}
+		if len(stmt.groupByTags) > 0 {
+			s.Tags = g.tags
+		}
+		series = append(series, s)
+	}
+
+	return series, nil
+}
+
+// executeField executes the query for the field at the given index of the
+// statement, adding its values to the groups, and returns the step times.
+func (h *QueryHandler) executeField(
+	ctx context.Context,
+	q *fieldQuery,
+	stmt *selectStatement,
+	fieldIndex int,
+	queryOpts *executor.QueryOptions,
+	fetchOpts *storage.FetchOptions,
+	params models.RequestParams,
+	groups map[string]*group,
+) ([]time.Time, error) {
+	bl, err := h.engine.ExecuteExpr(ctx, q, queryOpts, fetchOpts, params)
+	if err != nil {
+		return nil, err
+	}
+
+	defer bl.Close()
+	it, err := bl.StepIter()
+	if err != nil {
+		return nil, err
+	}
+
+	defer it.Close()
+	var (
+		blockTags  = bl.Meta().Tags.Tags
+		seriesMeta = it.SeriesMeta()
+		seriesGrps = make([]*group, 0, len(seriesMeta))
+		times      []time.Time
+	)
+	for _, meta := range seriesMeta {
+		tags := meta.Tags.AddTags(blockTags)
+		seriesGrps = append(seriesGrps, h.group(groups, stmt, tags))
+	}
+
+	for step := 0; it.Next(); step++ {
+		current := it.Current()
+		times = append(times, current.Time())
+		for i, v := range current.Values() {
+			g := seriesGrps[i]
+			for len(g.rows) <= step {
+				g.rows = append(g.rows, newRow(len(stmt.fields)))
+			}
+			g.rows[step][fieldIndex] = v
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return times, nil
+}
+
+// group returns the group the series with the given tags belongs to.
+func (h *QueryHandler) group(
+	groups map[string]*group,
+	stmt *selectStatement,
+	tags models.Tags,
+) *group {
+	var (
+		values = make(map[string]string, len(stmt.groupByTags))
+		key    strings.Builder
+	)
+	for _, tag := range stmt.groupByTags {
+		value, _ := tags.Get(labelName(tag, h.promRewriter))
+		values[tag] = string(value)
+		key.WriteString(tag)
+		key.WriteByte('=')
+		key.WriteString(string(value))
+		key.WriteByte(',')
+	}
+
+	g, ok := groups[key.String()]
+	if !ok {
+		g = &group{tags: values}
+		groups[key.String()] = g
+	}
+	return g
+}
+
+func newRow(n int) []float64 {
+	row := make([]float64, n)
+	for i := range row {
+		row[i] = math.NaN()
+	}
+	return row
+}
+
+// fillRows returns the rows with their time, filling missing values with
+// the fill option. Series without any values have no rows.
+func fillRows(
+	rows [][]float64,
+	numFields int,
+	times []time.Time,
+	fill fillOption,
+	epoch string,
+) [][]interface{} {
+	var (
+		values   = make([][]interface{}, 0, len(times))
+		previous []interface{}
+		hasValue bool
+	)
+	for i, t := range times {
+		row := make([]interface{}, 1, numFields+1)
+		row[0] = formatTime(t, epoch)
+
+		fields := newRow(numFields)
+		if i < len(rows) {
+			fields = rows[i]
+		}
+
+		empty := true
+		for j, v := range fields {
+			if !math.IsNaN(v) && !math.IsInf(v, 0) {
+				row = append(row, v)
+				empty = false
+				continue
+			}
+
+			switch fill.fillType {
+			case fillValue:
+				row = append(row, fill.value)
+			case fillPrevious:
+				if previous != nil {
+					row = append(row, previous[j+1])
+				} else {
+					row = append(row, nil)
+				}
+			default:
+				row = append(row, nil)
+			}
+		}
+
+		if empty && fill.fillType == fillNone {
+			continue
+		}
+
+		hasValue = hasValue || !empty
+		previous = row
+		values = append(values, row)
+	}
+
+	if !hasValue {
+		return nil
+	}
+	return values
+}
+
+func formatTime(t time.Time, epoch string) interface{} {
+	if epoch == "" {
+		return t.UTC().Format(time.RFC3339Nano)
+	}
+	return t.UnixNano() / int64(precisionDuration(epoch))
+}
+
+// precisionDuration returns the duration of a precision returned by
+// parsePrecision.
+func precisionDuration(precision string) time.Duration {
+	switch precision {
+	case "u":
+		return time.Microsecond
+	case "ms":
+		return time.Millisecond
+	case "s":
+		return time.Second
+	case "m":
+		return time.Minute
+	case "h":
+		return time.Hour
+	default:
+		return time.Nanosecond
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package influxdb
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage/mock"
+	qtest "github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testQueryStart = time.Unix(1500000000, 0).Truncate(time.Minute)
+
+func newTestQueryHandler(t *testing.T) (http.Handler, mock.Storage) {
+	tagOpts := models.NewTagOptions()
+	seriesTags := func(host string) models.Tags {
+		return models.NewTags(2, tagOpts).
+			SetName([]byte("cpu_value")).
+			AddTag(models.Tag{Name: []byte("host"), Value: []byte(host)})
+	}
+
+	// NB: steps are evaluated at the end of each bucket so the block starts
+	// at the end of the first bucket.
+	bounds := models.Bounds{
+		Start:    testQueryStart.Add(time.Minute),
+		Duration: 3 * time.Minute,
+		StepSize: time.Minute,
+	}
+	seriesMeta := []block.SeriesMeta{
+		{Name: []byte("cpu_value"), Tags: seriesTags("a")},
+		{Name: []byte("cpu_value"), Tags: seriesTags("b")},
+	}
+	b := qtest.NewUnconsolidatedBlockFromDatapointsWithMeta(bounds, seriesMeta,
+		[][]float64{{1, 2, math.NaN()}, {3, 4, 5}}, false)
+
+	store := mock.NewMockStorage()
+	store.SetFetchBlocksResult(block.Result{Blocks: []block.Block{b}}, nil)
+
+	instrumentOpts := instrument.NewOptions()
+	engine := executor.NewEngine(executor.NewEngineOptions().
+		SetStore(store).
+		SetLookbackDuration(time.Minute).
+		SetGlobalEnforcer(nil).
+		SetInstrumentOptions(instrumentOpts))
+
+	opts := options.EmptyHandlerOptions().
+		SetEngine(engine).
+		SetFetchOptionsBuilder(handleroptions.NewFetchOptionsBuilder(
+			handleroptions.FetchOptionsBuilderOptions{})).
+		SetTagOptions(tagOpts).
+		SetNowFn(func() time.Time { return testQueryStart.Add(3 * time.Minute) }).
+		SetInstrumentOpts(instrumentOpts)
+	return NewInfluxQueryHandler(opts), store
+}
+
+func serveQuery(t *testing.T, h http.Handler, req *http.Request) queryResponse {
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	var resp queryResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+	return resp
+}
+
+func queryRequest(values url.Values) *http.Request {
+	return httptest.NewRequest(http.MethodGet,
+		InfluxQueryURL+"?"+values.Encode(), nil)
+}
+
+func TestQueryHandlerGroupByTime(t *testing.T) {
+	h, _ := newTestQueryHandler(t)
+
+	values := url.Values{}
+	values.Set(queryParam, "SELECT sum(value) FROM cpu "+
+		"WHERE time > now() - 3m GROUP BY time(1m)")
+	values.Set(epochParam, "s")
+	resp := serveQuery(t, h, queryRequest(values))
+
+	require.Len(t, resp.Results, 1)
+	require.Len(t, resp.Results[0].Series, 1)
+	series := resp.Results[0].Series[0]
+	assert.Equal(t, "cpu", series.Name)
+	assert.Nil(t, series.Tags)
+	assert.Equal(t, []string{"time", "sum"}, series.Columns)
+	assert.Equal(t, [][]interface{}{
+		{float64(testQueryStart.Unix()), 4.0},
+		{float64(testQueryStart.Add(time.Minute).Unix()), 6.0},
+		{float64(testQueryStart.Add(2 * time.Minute).Unix()), 5.0},
+	}, series.Values)
+}
+
+func TestQueryHandlerGroupByTag(t *testing.T) {
+	h, _ := newTestQueryHandler(t)
+
+	body := url.Values{}
+	body.Set(queryParam, "SELECT max(value) AS peak FROM cpu "+
+		"WHERE host =~ /a|b/ AND time > now() - 3m GROUP BY host, time(1m) "+
+		"fill(previous)")
+	req := httptest.NewRequest(http.MethodPost, InfluxQueryURL,
+		strings.NewReader(body.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp := serveQuery(t, h, req)
+
+	require.Len(t, resp.Results, 1)
+	require.Len(t, resp.Results[0].Series, 2)
+	byHost := make(map[string][][]interface{})
+	for _, s := range resp.Results[0].Series {
+		assert.Equal(t, []string{"time", "peak"}, s.Columns)
+		byHost[s.Tags["host"]] = s.Values
+	}
+
+	rfc3339 := func(step int) string {
+		return testQueryStart.Add(time.Duration(step) * time.Minute).UTC().
+			Format(time.RFC3339Nano)
+	}
+	assert.Equal(t, map[string][][]interface{}{
+		"a": {{rfc3339(0), 1.0}, {rfc3339(1), 2.0}, {rfc3339(2), 2.0}},
+		"b": {{rfc3339(0), 3.0}, {rfc3339(1), 4.0}, {rfc3339(2), 5.0}},
+	}, byHost)
+}
+
+func TestQueryHandlerStatementError(t *testing.T) {
+	h, store := newTestQueryHandler(t)
+	store.SetFetchBlocksResult(block.Result{}, assert.AnError)
+
+	values := url.Values{}
+	values.Set(queryParam, "SELECT mean(value) FROM cpu WHERE time > now() - 1h")
+	resp := serveQuery(t, h, queryRequest(values))
+
+	require.Len(t, resp.Results, 1)
+	assert.Equal(t, assert.AnError.Error(), resp.Results[0].Error)
+}
+
+func TestQueryHandlerInvalid(t *testing.T) {
+	h, _ := newTestQueryHandler(t)
+
+	for _, query := range []string{
+		"",
+		"q=SELECT+mean(value)+FROM+cpu",
+		"q=SELECT+mean(value)+FROM+cpu+WHERE+time+>+now()-1h&epoch=x",
+	} {
+		req := httptest.NewRequest(http.MethodGet, InfluxQueryURL+"?"+query, nil)
+		recorder := httptest.NewRecorder()
+		h.ServeHTTP(recorder, req)
+		assert.Equal(t, http.StatusBadRequest, recorder.Code, query)
+	}
+}
+
+func TestNewFieldQuery(t *testing.T) {
+	stmts, err := parseQuery(`SELECT median("usage idle") FROM "cpu-total" `+
+		`WHERE "host name" = 'a' AND dc != 'b' AND r =~ /x/ AND r !~ /y/ `+
+		`AND time > now() - 1h GROUP BY "host name", time(1m)`, testNow)
+	require.NoError(t, err)
+	require.Len(t, stmts, 1)
+
+	q, err := newFieldQuery(stmts[0], stmts[0].fields[0], time.Minute,
+		newPromRewriter(), models.NewTagOptions())
+	require.NoError(t, err)
+
+	assert.Equal(t, "cpu_total_usage_idle", string(q.name))
+	assert.Equal(t, [][]byte{[]byte("host_name")}, q.groupBy)
+	assert.Equal(t, influxFunctions["median"], q.function)
+
+	var matchers []string
+	for _, m := range q.matchers {
+		matchers = append(matchers, m.String())
+	}
+	assert.Equal(t, []string{
+		`__name__="cpu_total_usage_idle"`,
+		`host_name="a"`,
+		`dc!="b"`,
+		`r=~".*(?:x).*"`,
+		`r!~".*(?:y).*"`,
+	}, matchers)
+
+	nodes, edges, err := q.DAG()
+	require.NoError(t, err)
+	assert.Len(t, nodes, 3)
+	assert.Len(t, edges, 2)
+}
+
+func TestFillRows(t *testing.T) {
+	var (
+		nan   = math.NaN()
+		times = []time.Time{time.Unix(0, 0), time.Unix(60, 0), time.Unix(120, 0)}
+		rows  = [][]float64{{1, nan}, {nan, nan}, {nan, 2}}
+	)
+
+	for _, tt := range []struct {
+		fill     fillOption
+		expected [][]interface{}
+	}{
+		{
+			fill:     fillOption{fillType: fillNull},
+			expected: [][]interface{}{{int64(0), 1.0, nil}, {int64(60), nil, nil}, {int64(120), nil, 2.0}},
+		},
+		{
+			fill:     fillOption{fillType: fillNone},
+			expected: [][]interface{}{{int64(0), 1.0, nil}, {int64(120), nil, 2.0}},
+		},
+		{
+			fill:     fillOption{fillType: fillPrevious},
+			expected: [][]interface{}{{int64(0), 1.0, nil}, {int64(60), 1.0, nil}, {int64(120), 1.0, 2.0}},
+		},
+		{
+			fill:     fillOption{fillType: fillValue, value: 0},
+			expected: [][]interface{}{{int64(0), 1.0, 0.0}, {int64(60), 0.0, 0.0}, {int64(120), 0.0, 2.0}},
+		},
+	} {
+		assert.Equal(t, tt.expected, fillRows(rows, 2, times, tt.fill, "s"))
+	}
+
+	assert.Nil(t, fillRows([][]float64{{nan}}, 1, times[:1], fillOption{}, "s"))
+}
@@ -89,3 +89,16 @@ func (pr *promRewriter) rewriteMetricTail(data []byte) {
 func (pr *promRewriter) rewriteLabel(data []byte) {
 	pr.label.rewrite(data)
 }
+
+// seriesName returns the name of the series the field of the measurement
+// is written as.
+func (pr *promRewriter) seriesName(measurement, field string) []byte {
+	name := make([]byte, 0, len(measurement)+len(field)+1)
+	name = append(name, measurement...)
+	name = append(name, '_')
+	pr.rewriteMetric(name)
+	tail := len(name)
+	name = append(name, field...)
+	pr.rewriteMetricTail(name[tail:])
+	return name
+}
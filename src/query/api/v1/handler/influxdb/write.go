@@ -22,8 +22,11 @@ package influxdb
 
 import (
 	"bytes"
+	"compress/gzip"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"time"
@@ -49,12 +52,15 @@ const (
 
 	// InfluxWriteHTTPMethod is the HTTP method used with this resource
 	InfluxWriteHTTPMethod = http.MethodPost
+
+	precisionParam = "precision"
 )
 
 type ingestWriteHandler struct {
-	handlerOpts  options.HandlerOptions
-	tagOpts      models.TagOptions
-	promRewriter *promRewriter
+	handlerOpts               options.HandlerOptions
+	tagOpts                   models.TagOptions
+	promRewriter              *promRewriter
+	stringFieldsAsAnnotations bool
 }
 
 type ingestField struct {
@@ -68,6 +74,10 @@ type ingestIterator struct {
 	tagOpts      models.TagOptions
 	promRewriter *promRewriter
 
+	// stringFieldsAsAnnotations attaches the string fields of a point
+	// as an annotation to the series of its numeric fields.
+	stringFieldsAsAnnotations bool
+
 	// internal
 	pointIndex int
 	err        xerrors.MultiError
@@ -77,6 +87,7 @@ type ingestIterator struct {
 	fields         []*ingestField
 	nextFieldIndex int
 	tags           models.Tags
+	annotation     []byte
 }
 
 func (ii *ingestIterator) populateFields() bool {
@@ -84,6 +95,8 @@ func (ii *ingestIterator) populateFields() bool {
 	it := point.FieldIterator()
 	n := 0
 	ii.fields = make([]*ingestField, 0, 10)
+	ii.annotation = nil
+	var stringFields map[string]string
 	bname := make([]byte, 0, len(point.Name())+1)
 	bname = append(bname, point.Name()...)
 	bname = append(bname, byte('_'))
@@ -92,6 +105,8 @@ func (ii *ingestIterator) populateFields() bool {
 	for it.Next() {
 		var value float64 = 0
 		n += 1
+		// NB: booleans are written as 1 or 0, and integers and unsigned
+		// integers are converted to floats, losing precision above 2^53.
 		switch it.Type() {
 		case imodels.Boolean:
 			v, err := it.BooleanValue()
@@ -123,10 +138,18 @@ func (ii *ingestIterator) populateFields() bool {
 				continue
 			}
 			value = v
+		case imodels.String:
+			// NB: strings are not added as tags to prevent cardinality
+			// explosion, they are either dropped or, if configured,
+			// attached as an annotation.
+			if ii.stringFieldsAsAnnotations {
+				if stringFields == nil {
+					stringFields = make(map[string]string)
+				}
+				stringFields[string(it.FieldKey())] = it.StringValue()
+			}
+			continue
 		default:
-			// TBD if we should stick strings as
-			// tags or not; to prevent cardinality
-			// explosion, we drop them for now
 			continue
 		}
 		tail := it.FieldKey()
@@ -136,6 +159,14 @@ func (ii *ingestIterator) populateFields() bool {
 		ii.promRewriter.rewriteMetricTail(name[bnamelen:])
 		ii.fields = append(ii.fields, &ingestField{name: name, value: value})
 	}
+	if len(stringFields) > 0 {
+		annotation, err := json.Marshal(stringFields)
+		if err != nil {
+			ii.err = ii.err.Add(err)
+		} else {
+			ii.annotation = annotation
+		}
+	}
 	return n > 0
 }
 
@@ -227,7 +258,7 @@ func (ii *ingestIterator) Current() (models.Tags, ts.Datapoints, xtime.Unit, []b
 		t := point.Time()
 
 		return tags, []ts.Datapoint{ts.Datapoint{Timestamp: t,
-			Value: field.value}}, determineTimeUnit(t), nil
+			Value: field.value}}, determineTimeUnit(t), ii.annotation
 	}
 	return models.EmptyTags(), nil, 0, nil
 }
@@ -243,25 +274,58 @@ func (ii *ingestIterator) Error() error {
 	return ii.err.FinalError()
 }
 
+// parsePrecision returns the precision, as understood by the line
+// protocol parser, for the given precision query parameter.
+func parsePrecision(precision string) (string, error) {
+	switch precision {
+	case "", "n", "ns":
+		return "n", nil
+	case "u", "us", "µ", "µs":
+		return "u", nil
+	case "ms", "s", "m", "h":
+		return precision, nil
+	default:
+		return "", fmt.Errorf("invalid precision: %s", precision)
+	}
+}
+
 func NewInfluxWriterHandler(options options.HandlerOptions) http.Handler {
 	return &ingestWriteHandler{handlerOpts: options,
-		tagOpts:      options.TagOptions(),
-		promRewriter: newPromRewriter()}
+		tagOpts:                   options.TagOptions(),
+		promRewriter:              newPromRewriter(),
+		stringFieldsAsAnnotations: options.Config().InfluxDB.StringFieldsAsAnnotations}
 }
 
 func (iwh *ingestWriteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	bytes, err := ioutil.ReadAll(r.Body)
+	precision, err := parsePrecision(r.URL.Query().Get(precisionParam))
+	if err != nil {
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+	var body io.Reader = r.Body
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gzipReader, err := gzip.NewReader(r.Body)
+		if err != nil {
+			xhttp.Error(w, err, http.StatusBadRequest)
+			return
+		}
+		defer gzipReader.Close()
+		body = gzipReader
+	}
+	bytes, err := ioutil.ReadAll(body)
 	if err != nil {
-		xhttp.Error(w, err, http.StatusInternalServerError)
+		xhttp.Error(w, err, http.StatusBadRequest)
 		return
 	}
-	points, err := imodels.ParsePoints(bytes)
+	points, err := imodels.ParsePointsWithPrecision(bytes,
+		iwh.handlerOpts.NowFn()().UTC(), precision)
 	if err != nil {
-		xhttp.Error(w, err, http.StatusInternalServerError)
+		xhttp.Error(w, err, http.StatusBadRequest)
 		return
 	}
 	opts := ingest.WriteOptions{}
-	iter := &ingestIterator{points: points, tagOpts: iwh.tagOpts, promRewriter: iwh.promRewriter}
+	iter := &ingestIterator{points: points, tagOpts: iwh.tagOpts, promRewriter: iwh.promRewriter,
+		stringFieldsAsAnnotations: iwh.stringFieldsAsAnnotations}
 	batchErr := iwh.handlerOpts.DownsamplerAndWriter().WriteBatch(r.Context(), iter, opts)
 	if batchErr == nil {
 		w.WriteHeader(http.StatusNoContent)
@@ -21,11 +21,22 @@
 package influxdb
 
 import (
+	"bytes"
+	"compress/gzip"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
+	"github.com/m3db/m3/src/cmd/services/m3query/config"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/models"
 	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/golang/mock/gomock"
 	imodels "github.com/influxdata/influxdb/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -127,3 +138,164 @@ func TestDetermineTimeUnit(t *testing.T) {
 	assert.Equal(t, determineTimeUnit(zerot.Add(4*time.Nanosecond)), xtime.Nanosecond)
 
 }
+
+func TestIngestIteratorStringFieldsAsAnnotations(t *testing.T) {
+	s := `measure,lab=foo k1=1,s1="a",s2="b" 1574838670386469800
+measure,lab=foo s1="c" 1574838670386469801
+`
+	points, err := imodels.ParsePoints([]byte(s))
+	require.NoError(t, err)
+
+	iter := &ingestIterator{points: points, promRewriter: newPromRewriter(),
+		stringFieldsAsAnnotations: true}
+	require.True(t, iter.Next())
+	tags, _, _, annotation := iter.Current()
+	assert.Equal(t, "__name__: measure_k1, lab: foo", tags.String())
+	assert.Equal(t, `{"s1":"a","s2":"b"}`, string(annotation))
+
+	// NB: points with only string fields have no series to annotate.
+	require.False(t, iter.Next())
+	require.NoError(t, iter.Error())
+
+	iter = &ingestIterator{points: points, promRewriter: newPromRewriter()}
+	require.True(t, iter.Next())
+	_, _, _, annotation = iter.Current()
+	assert.Nil(t, annotation)
+}
+
+func TestParsePrecision(t *testing.T) {
+	for _, tt := range []struct {
+		precision string
+		expected  string
+	}{
+		{"", "n"},
+		{"n", "n"},
+		{"ns", "n"},
+		{"u", "u"},
+		{"us", "u"},
+		{"µ", "u"},
+		{"ms", "ms"},
+		{"s", "s"},
+		{"m", "m"},
+		{"h", "h"},
+	} {
+		precision, err := parsePrecision(tt.precision)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, precision, tt.precision)
+	}
+
+	_, err := parsePrecision("d")
+	require.Error(t, err)
+}
+
+type writtenSeries struct {
+	tags       string
+	value      float64
+	timestamp  time.Time
+	annotation string
+}
+
+func newTestWriteHandler(
+	ctrl *gomock.Controller,
+	cfg config.Configuration,
+) (http.Handler, *[]writtenSeries) {
+	var written []writtenSeries
+	writer := ingest.NewMockDownsamplerAndWriter(ctrl)
+	writer.EXPECT().
+		WriteBatch(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ interface{},
+			iter ingest.DownsampleAndWriteIter,
+			_ ingest.WriteOptions,
+		) ingest.BatchError {
+			for iter.Next() {
+				tags, dps, _, annotation := iter.Current()
+				written = append(written, writtenSeries{
+					tags:       tags.String(),
+					value:      dps[0].Value,
+					timestamp:  dps[0].Timestamp,
+					annotation: string(annotation),
+				})
+			}
+			return nil
+		}).
+		AnyTimes()
+
+	opts := options.EmptyHandlerOptions().
+		SetDownsamplerAndWriter(writer).
+		SetTagOptions(models.NewTagOptions()).
+		SetConfig(cfg)
+	return NewInfluxWriterHandler(opts), &written
+}
+
+func TestInfluxWriterHandlerPrecision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h, written := newTestWriteHandler(ctrl, config.Configuration{})
+	for _, tt := range []struct {
+		precision string
+		timestamp string
+	}{
+		{"", "1574838670000000000"},
+		{"us", "1574838670000000"},
+		{"ms", "1574838670000"},
+		{"s", "1574838670"},
+	} {
+		*written = nil
+		req := httptest.NewRequest(InfluxWriteHTTPMethod,
+			InfluxWriteURL+"?precision="+tt.precision,
+			strings.NewReader("m,h=a f=1i,b=true "+tt.timestamp))
+		recorder := httptest.NewRecorder()
+		h.ServeHTTP(recorder, req)
+		require.Equal(t, http.StatusNoContent, recorder.Code, tt.precision)
+
+		require.Equal(t, []writtenSeries{
+			{tags: "__name__: m_f, h: a", value: 1, timestamp: time.Unix(1574838670, 0).UTC()},
+			{tags: "__name__: m_b, h: a", value: 1, timestamp: time.Unix(1574838670, 0).UTC()},
+		}, *written, tt.precision)
+	}
+
+	req := httptest.NewRequest(InfluxWriteHTTPMethod,
+		InfluxWriteURL+"?precision=d", strings.NewReader("m f=1 1"))
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestInfluxWriterHandlerGzip(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var cfg config.Configuration
+	cfg.InfluxDB.StringFieldsAsAnnotations = true
+	h, written := newTestWriteHandler(ctrl, cfg)
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write([]byte(`m,h=a f=1.5,s="up" 1574838670000000000`))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	req := httptest.NewRequest(InfluxWriteHTTPMethod, InfluxWriteURL, &buf)
+	req.Header.Set("Content-Encoding", "gzip")
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusNoContent, recorder.Code)
+
+	require.Equal(t, []writtenSeries{
+		{
+			tags:       "__name__: m_f, h: a",
+			value:      1.5,
+			timestamp:  time.Unix(1574838670, 0).UTC(),
+			annotation: `{"s":"up"}`,
+		},
+	}, *written)
+
+	req = httptest.NewRequest(InfluxWriteHTTPMethod, InfluxWriteURL,
+		strings.NewReader("not gzip"))
+	req.Header.Set("Content-Encoding", "gzip")
+	recorder = httptest.NewRecorder()
+	h.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
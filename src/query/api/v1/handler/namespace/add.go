@@ -162,11 +162,16 @@ func (h *AddHandler) Add(
 		return emptyReg, err
 	}
 
+	registryHistory := newRegistryHistory(store, opts, h.instrumentOpts.Logger())
+	registryHistory.appendCurrent()
+
 	protoRegistry := namespace.ToProto(nsMap)
-	_, err = store.CheckAndSet(M3DBNodeNamespacesKey, version, protoRegistry)
+	newVersion, err := store.CheckAndSet(M3DBNodeNamespacesKey, version, protoRegistry)
 	if err != nil {
 		return emptyReg, fmt.Errorf("failed to add namespace: %v", err)
 	}
 
+	registryHistory.append(newVersion, protoRegistry)
+
 	return *protoRegistry, nil
 }
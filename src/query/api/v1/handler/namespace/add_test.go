@@ -28,6 +28,7 @@ import (
 	"testing"
 
 	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/cluster/kv/util/history"
 	nsproto "github.com/m3db/m3/src/dbnode/generated/proto/namespace"
 	"github.com/m3db/m3/src/x/instrument"
 	xjson "github.com/m3db/m3/src/x/json"
@@ -98,8 +99,11 @@ func TestNamespaceAddHandler(t *testing.T) {
 	req = httptest.NewRequest("POST", "/namespace", strings.NewReader(testAddJSON))
 	require.NotNil(t, req)
 
-	mockKV.EXPECT().Get(M3DBNodeNamespacesKey).Return(nil, kv.ErrNotFound)
+	mockKV.EXPECT().Get(M3DBNodeNamespacesKey).Return(nil, kv.ErrNotFound).Times(2)
 	mockKV.EXPECT().CheckAndSet(M3DBNodeNamespacesKey, gomock.Any(), gomock.Not(nil)).Return(1, nil)
+	mockKV.EXPECT().Set(M3DBNodeNamespacesKey+history.KeySuffix+"/0", gomock.Any()).Return(1, nil)
+	mockKV.EXPECT().Get(M3DBNodeNamespacesKey+history.KeySuffix).Return(nil, kv.ErrNotFound).Times(2)
+	mockKV.EXPECT().SetIfNotExists(M3DBNodeNamespacesKey+history.KeySuffix, gomock.Any()).Return(1, nil)
 	addHandler.ServeHTTP(svcDefaults, w, req)
 
 	resp = w.Result()
@@ -27,13 +27,17 @@ import (
 
 	clusterclient "github.com/m3db/m3/src/cluster/client"
 	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/cluster/kv/util/history"
 	nsproto "github.com/m3db/m3/src/dbnode/generated/proto/namespace"
 	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/namespace/kvadmin"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
 	"github.com/m3db/m3/src/query/util/logging"
 	"github.com/m3db/m3/src/x/instrument"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/gorilla/mux"
+	"go.uber.org/zap"
 )
 
 const (
@@ -95,6 +99,92 @@ func Metadata(store kv.Store) ([]namespace.Metadata, int, error) {
 	return nsMap.Metadatas(), value.Version(), nil
 }
 
+// registryHistory records the namespace registries written by the handlers
+// in the registry history. The registries have already been written when
+// they are recorded, so failing to record them is logged rather than returned.
+type registryHistory struct {
+	store   kv.Store
+	history history.Store
+	meta    history.Metadata
+	logger  *zap.Logger
+}
+
+// newRegistryHistory returns the registry history kept with the given
+// service options, nothing is recorded if the history limit is not positive.
+func newRegistryHistory(
+	store kv.Store,
+	opts handleroptions.ServiceOptions,
+	logger *zap.Logger,
+) *registryHistory {
+	h := &registryHistory{store: store, meta: opts.ChangeMetadata, logger: logger}
+	if opts.HistoryLimit > 0 {
+		historyOpts := history.NewOptions().SetLimit(opts.HistoryLimit)
+		h.history = history.NewStore(store, M3DBNodeNamespacesKey, historyOpts)
+	}
+	return h
+}
+
+// appendCurrent keeps the current registry in the registry history if the
+// history is empty, it must be called before the registry is changed.
+func (h *registryHistory) appendCurrent() {
+	if h.history == nil {
+		return
+	}
+	current := func() (proto.Message, int, error) {
+		value, err := h.store.Get(M3DBNodeNamespacesKey)
+		if err != nil {
+			return nil, 0, err
+		}
+		var registry nsproto.Registry
+		if err := value.Unmarshal(&registry); err != nil {
+			return nil, 0, err
+		}
+		return &registry, value.Version(), nil
+	}
+	if err := h.history.AppendCurrent(current); err != nil {
+		h.logger.Error("could not record current namespace registry history",
+			zap.Error(err))
+	}
+}
+
+// append keeps the registry written at the given version in the registry
+// history.
+func (h *registryHistory) append(version int, registry *nsproto.Registry) {
+	if h.history == nil {
+		return
+	}
+	if err := h.history.Append(version, registry, h.meta); err != nil {
+		h.logger.Error("could not record namespace registry history",
+			zap.Int("version", version), zap.Error(err))
+	}
+}
+
+// appendDelete keeps the deletion of the registry at the given version in
+// the registry history.
+func (h *registryHistory) appendDelete(version int) {
+	if h.history == nil {
+		return
+	}
+	if err := h.history.AppendDelete(version, h.meta); err != nil {
+		h.logger.Error("could not record namespace registry deletion history",
+			zap.Int("version", version), zap.Error(err))
+	}
+}
+
+// adminServiceOptions returns the options of the namespace admin service
+// used by the handlers, which records the registries it writes in the
+// registry history.
+func adminServiceOptions(
+	opts handleroptions.ServiceOptions,
+	instrumentOpts instrument.Options,
+) []kvadmin.AdminServiceOption {
+	historyOpts := history.NewOptions().SetLimit(opts.HistoryLimit)
+	return []kvadmin.AdminServiceOption{
+		kvadmin.WithHistory(historyOpts, opts.ChangeMetadata),
+		kvadmin.WithLogger(instrumentOpts.Logger()),
+	}
+}
+
 // RegisterRoutes registers the namespace routes.
 func RegisterRoutes(
 	r *mux.Router,
@@ -131,7 +221,8 @@ func RegisterRoutes(
 	r.HandleFunc(M3DBAddURL, addHandler.ServeHTTP).Methods(AddHTTPMethod)
 
 	// Delete M3DB namespaces.
-	deleteHandler := wrapped(NewDeleteHandler(client, instrumentOpts))
+	deleteHandler := wrapped(
+		applyMiddleware(NewDeleteHandler(client, instrumentOpts).ServeHTTP, defaults))
 	r.HandleFunc(DeprecatedM3DBDeleteURL, deleteHandler.ServeHTTP).Methods(DeleteHTTPMethod)
 	r.HandleFunc(M3DBDeleteURL, deleteHandler.ServeHTTP).Methods(DeleteHTTPMethod)
 
@@ -144,4 +235,14 @@ func RegisterRoutes(
 	schemaResetHandler := wrapped(
 		applyMiddleware(NewSchemaResetHandler(client, instrumentOpts).ServeHTTP, defaults))
 	r.HandleFunc(M3DBSchemaURL, schemaResetHandler.ServeHTTP).Methods(DeleteHTTPMethod)
+
+	// Get M3DB namespace registry history.
+	historyHandler := wrapped(
+		applyMiddleware(NewHistoryHandler(client, instrumentOpts).ServeHTTP, defaults))
+	r.HandleFunc(M3DBHistoryURL, historyHandler.ServeHTTP).Methods(HistoryHTTPMethod)
+
+	// Roll back M3DB namespace registry.
+	rollbackHandler := wrapped(
+		applyMiddleware(NewRollbackHandler(client, instrumentOpts).ServeHTTP, defaults))
+	r.HandleFunc(M3DBRollbackURL, rollbackHandler.ServeHTTP).Methods(RollbackHTTPMethod)
 }
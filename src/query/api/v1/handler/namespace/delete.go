@@ -31,6 +31,7 @@ import (
 	clusterclient "github.com/m3db/m3/src/cluster/client"
 	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
 	"github.com/m3db/m3/src/query/util/logging"
 	"github.com/m3db/m3/src/x/instrument"
 	xhttp "github.com/m3db/m3/src/x/net/http"
@@ -79,7 +80,11 @@ func NewDeleteHandler(
 	}
 }
 
-func (h *DeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+func (h *DeleteHandler) ServeHTTP(
+	svc handleroptions.ServiceNameAndDefaults,
+	w http.ResponseWriter,
+	r *http.Request,
+) {
 	ctx := r.Context()
 	logger := logging.WithContext(ctx, h.instrumentOpts)
 	id := strings.TrimSpace(mux.Vars(r)[namespaceIDVar])
@@ -89,7 +94,8 @@ func (h *DeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := h.Delete(id)
+	opts := handleroptions.NewServiceOptions(svc, r.Header, nil)
+	err := h.Delete(id, opts)
 	if err != nil {
 		logger.Error("unable to delete namespace", zap.Error(err))
 		if err == errNamespaceNotFound {
@@ -107,8 +113,9 @@ func (h *DeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// Delete deletes a namespace.
-func (h *DeleteHandler) Delete(id string) error {
+// Delete deletes a namespace, recording the change with the metadata of the
+// service options in the registry history.
+func (h *DeleteHandler) Delete(id string, opts handleroptions.ServiceOptions) error {
 	store, err := h.client.KV()
 	if err != nil {
 		return err
@@ -131,12 +138,17 @@ func (h *DeleteHandler) Delete(id string) error {
 		return errNamespaceNotFound
 	}
 
+	registryHistory := newRegistryHistory(store, opts, h.instrumentOpts.Logger())
+	registryHistory.appendCurrent()
+
 	// If metadatas are empty, remove the key
 	if len(metadatas) == 1 {
-		if _, err = store.Delete(M3DBNodeNamespacesKey); err != nil {
+		deleted, err := store.Delete(M3DBNodeNamespacesKey)
+		if err != nil {
 			return fmt.Errorf("unable to delete kv key: %v", err)
 		}
 
+		registryHistory.appendDelete(deleted.Version())
 		return nil
 	}
 
@@ -151,10 +163,12 @@ func (h *DeleteHandler) Delete(id string) error {
 	}
 
 	protoRegistry := namespace.ToProto(nsMap)
-	_, err = store.CheckAndSet(M3DBNodeNamespacesKey, version, protoRegistry)
+	newVersion, err := store.CheckAndSet(M3DBNodeNamespacesKey, version, protoRegistry)
 	if err != nil {
 		return fmt.Errorf("failed to delete namespace: %v", err)
 	}
 
+	registryHistory.append(newVersion, protoRegistry)
+
 	return nil
 }
@@ -26,8 +26,13 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/m3db/m3/src/cluster/client"
 	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/cluster/kv/mem"
+	"github.com/m3db/m3/src/cluster/kv/util/history"
 	nsproto "github.com/m3db/m3/src/dbnode/generated/proto/namespace"
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
 	"github.com/m3db/m3/src/x/instrument"
 
 	"github.com/golang/mock/gomock"
@@ -50,7 +55,7 @@ func TestNamespaceDeleteHandlerNotFound(t *testing.T) {
 	require.NotNil(t, req)
 
 	mockKV.EXPECT().Get(M3DBNodeNamespacesKey).Return(nil, kv.ErrNotFound)
-	deleteHandler.ServeHTTP(w, req)
+	deleteHandler.ServeHTTP(svcDefaults, w, req)
 
 	resp := w.Result()
 	body, _ := ioutil.ReadAll(resp.Body)
@@ -62,107 +67,90 @@ func TestNamespaceDeleteHandlerDeleteAll(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockClient, mockKV := setupNamespaceTest(t, ctrl)
+	store := mem.NewStore()
+	mockClient := client.NewMockClient(ctrl)
+	mockClient.EXPECT().KV().Return(store, nil).AnyTimes()
 	deleteHandler := NewDeleteHandler(mockClient, instrument.NewOptions())
 
+	// The registry was written before the history was kept.
+	_, err := store.Set(M3DBNodeNamespacesKey, &nsproto.Registry{
+		Namespaces: map[string]*nsproto.NamespaceOptions{
+			"testNamespace": namespace.OptionsToProto(namespace.NewOptions()),
+		},
+	})
+	require.NoError(t, err)
+
 	w := httptest.NewRecorder()
 
 	req := httptest.NewRequest("DELETE", "/namespace/testNamespace", nil)
+	req.Header.Set(handleroptions.HeaderChangeOperator, "alice")
+	req.Header.Set(handleroptions.HeaderChangeReason, "decommission")
 	req = mux.SetURLVars(req, map[string]string{"id": "testNamespace"})
 	require.NotNil(t, req)
 
-	registry := nsproto.Registry{
-		Namespaces: map[string]*nsproto.NamespaceOptions{
-			"testNamespace": &nsproto.NamespaceOptions{
-				BootstrapEnabled:  true,
-				FlushEnabled:      true,
-				WritesToCommitLog: true,
-				CleanupEnabled:    false,
-				RepairEnabled:     false,
-				RetentionOptions: &nsproto.RetentionOptions{
-					RetentionPeriodNanos:                     172800000000000,
-					BlockSizeNanos:                           7200000000000,
-					BufferFutureNanos:                        600000000000,
-					BufferPastNanos:                          600000000000,
-					BlockDataExpiry:                          true,
-					BlockDataExpiryAfterNotAccessPeriodNanos: 3600000000000,
-				},
-			},
-		},
-	}
-
-	mockValue := kv.NewMockValue(ctrl)
-	mockValue.EXPECT().Unmarshal(gomock.Any()).Return(nil).SetArg(0, registry)
-	mockValue.EXPECT().Version().Return(0)
-
-	mockKV.EXPECT().Get(M3DBNodeNamespacesKey).Return(mockValue, nil)
-	mockKV.EXPECT().Delete(M3DBNodeNamespacesKey).Return(nil, nil)
-	deleteHandler.ServeHTTP(w, req)
+	deleteHandler.ServeHTTP(svcDefaults, w, req)
 
 	resp := w.Result()
 	body, _ := ioutil.ReadAll(resp.Body)
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
 	assert.Equal(t, "{\"deleted\":true}\n", string(body))
+
+	_, err = store.Get(M3DBNodeNamespacesKey)
+	require.Equal(t, kv.ErrNotFound, err)
+
+	// Both the deleted registry and the delete are kept in the history.
+	entries, err := history.NewStore(store, M3DBNodeNamespacesKey, nil).Entries()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(entries))
+	assert.Equal(t, 1, entries[0].Version)
+	assert.False(t, entries[0].Deleted)
+	assert.Equal(t, 1, entries[1].Version)
+	assert.True(t, entries[1].Deleted)
+	assert.Equal(t, history.Metadata{Operator: "alice", Reason: "decommission"}, entries[1].Metadata)
+
+	var registry nsproto.Registry
+	require.NoError(t, entries[0].Unmarshal(&registry))
+	assert.Equal(t, 1, len(registry.Namespaces))
 }
 
 func TestNamespaceDeleteHandler(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockClient, mockKV := setupNamespaceTest(t, ctrl)
+	store := mem.NewStore()
+	mockClient := client.NewMockClient(ctrl)
+	mockClient.EXPECT().KV().Return(store, nil).AnyTimes()
 	deleteHandler := NewDeleteHandler(mockClient, instrument.NewOptions())
 
+	_, err := store.Set(M3DBNodeNamespacesKey, &nsproto.Registry{
+		Namespaces: map[string]*nsproto.NamespaceOptions{
+			"otherNamespace": namespace.OptionsToProto(namespace.NewOptions()),
+			"testNamespace":  namespace.OptionsToProto(namespace.NewOptions()),
+		},
+	})
+	require.NoError(t, err)
+
 	w := httptest.NewRecorder()
 
 	req := httptest.NewRequest("DELETE", "/namespace/testNamespace", nil)
 	req = mux.SetURLVars(req, map[string]string{"id": "testNamespace"})
 	require.NotNil(t, req)
 
-	registry := nsproto.Registry{
-		Namespaces: map[string]*nsproto.NamespaceOptions{
-			"otherNamespace": &nsproto.NamespaceOptions{
-				BootstrapEnabled:  true,
-				FlushEnabled:      true,
-				WritesToCommitLog: true,
-				CleanupEnabled:    false,
-				RepairEnabled:     false,
-				RetentionOptions: &nsproto.RetentionOptions{
-					RetentionPeriodNanos:                     172800000000000,
-					BlockSizeNanos:                           7200000000000,
-					BufferFutureNanos:                        600000000000,
-					BufferPastNanos:                          600000000000,
-					BlockDataExpiry:                          true,
-					BlockDataExpiryAfterNotAccessPeriodNanos: 3600000000000,
-				},
-			},
-			"testNamespace": &nsproto.NamespaceOptions{
-				BootstrapEnabled:  true,
-				FlushEnabled:      true,
-				WritesToCommitLog: true,
-				CleanupEnabled:    false,
-				RepairEnabled:     false,
-				RetentionOptions: &nsproto.RetentionOptions{
-					RetentionPeriodNanos:                     172800000000000,
-					BlockSizeNanos:                           7200000000000,
-					BufferFutureNanos:                        600000000000,
-					BufferPastNanos:                          600000000000,
-					BlockDataExpiry:                          true,
-					BlockDataExpiryAfterNotAccessPeriodNanos: 3600000000000,
-				},
-			},
-		},
-	}
-
-	mockValue := kv.NewMockValue(ctrl)
-	mockValue.EXPECT().Unmarshal(gomock.Any()).Return(nil).SetArg(0, registry)
-	mockValue.EXPECT().Version().Return(0)
-
-	mockKV.EXPECT().Get(M3DBNodeNamespacesKey).Return(mockValue, nil)
-	mockKV.EXPECT().CheckAndSet(M3DBNodeNamespacesKey, gomock.Any(), gomock.Any()).Return(1, nil)
-	deleteHandler.ServeHTTP(w, req)
+	deleteHandler.ServeHTTP(svcDefaults, w, req)
 
 	resp := w.Result()
 	body, _ := ioutil.ReadAll(resp.Body)
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
 	assert.Equal(t, "{\"deleted\":true}\n", string(body))
+
+	metadatas, version, err := Metadata(store)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(metadatas))
+	assert.Equal(t, "otherNamespace", metadatas[0].ID().String())
+
+	entries, err := history.NewStore(store, M3DBNodeNamespacesKey, nil).Entries()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(entries))
+	assert.Equal(t, 1, entries[0].Version)
+	assert.Equal(t, version, entries[1].Version)
 }
@@ -0,0 +1,117 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespace
+
+import (
+	"net/http"
+	"path"
+
+	clusterclient "github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/generated/proto/admin"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+)
+
+var (
+	// M3DBHistoryURL is the url for the namespace registry history handler.
+	M3DBHistoryURL = path.Join(handler.RoutePrefixV1, M3DBServiceNamespacePathName, "history")
+
+	// HistoryHTTPMethod is the HTTP method used with this resource.
+	HistoryHTTPMethod = http.MethodGet
+)
+
+// HistoryHandler is the handler for the namespace registry history.
+type HistoryHandler Handler
+
+// NewHistoryHandler returns a new instance of HistoryHandler.
+func NewHistoryHandler(
+	client clusterclient.Client,
+	instrumentOpts instrument.Options,
+) *HistoryHandler {
+	return &HistoryHandler{
+		client:         client,
+		instrumentOpts: instrumentOpts,
+	}
+}
+
+func (h *HistoryHandler) ServeHTTP(
+	svc handleroptions.ServiceNameAndDefaults,
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	ctx := r.Context()
+	logger := logging.WithContext(ctx, h.instrumentOpts)
+
+	opts := handleroptions.NewServiceOptions(svc, r.Header, nil)
+	resp, err := h.History(opts)
+	if err != nil {
+		logger.Error("unable to get namespace registry history", zap.Error(err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	xhttp.WriteProtoMsgJSONResponse(w, resp, logger)
+}
+
+// History returns the namespace registries kept in the registry history.
+func (h *HistoryHandler) History(
+	opts handleroptions.ServiceOptions,
+) (*admin.NamespaceHistoryResponse, error) {
+	kvOpts := kv.NewOverrideOptions().
+		SetEnvironment(opts.ServiceEnvironment).
+		SetZone(opts.ServiceZone)
+
+	store, err := h.client.Store(kvOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := newAdminService(store, M3DBNodeNamespacesKey, nil).History()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &admin.NamespaceHistoryResponse{
+		Entries: make([]*admin.NamespaceHistoryEntry, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		historyEntry := &admin.NamespaceHistoryEntry{
+			Version:  int32(entry.Version),
+			Deleted:  entry.Deleted,
+			Operator: entry.Metadata.Operator,
+			Reason:   entry.Metadata.Reason,
+		}
+		if !entry.UpdatedAt.IsZero() {
+			historyEntry.UpdatedAtNanos = entry.UpdatedAt.UnixNano()
+		}
+		if !entry.Deleted {
+			historyEntry.Registry = entry.Registry
+		}
+		resp.Entries = append(resp.Entries, historyEntry)
+	}
+	return resp, nil
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespace
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/cluster/kv/mem"
+	"github.com/m3db/m3/src/cluster/kv/util/history"
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/generated/proto/admin"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupNamespaceHistoryTest returns a client backed by an in memory store
+// holding two versions of the namespace registry, adding namespaces "first"
+// and "second" in turn.
+func setupNamespaceHistoryTest(t *testing.T, ctrl *gomock.Controller) *client.MockClient {
+	mockClient := client.NewMockClient(ctrl)
+	mockClient.EXPECT().Store(gomock.Any()).Return(mem.NewStore(), nil).AnyTimes()
+
+	addHandler := NewAddHandler(mockClient, instrument.NewOptions())
+	for _, name := range []string{"first", "second"} {
+		_, err := addHandler.Add(&admin.NamespaceAddRequest{
+			Name:    name,
+			Options: namespace.OptionsToProto(namespace.NewOptions()),
+		}, handleroptions.ServiceOptions{
+			ChangeMetadata: history.Metadata{Operator: "alice", Reason: "add " + name},
+			HistoryLimit:   history.DefaultLimit,
+		})
+		require.NoError(t, err)
+	}
+	return mockClient
+}
+
+func TestNamespaceHistoryHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	historyHandler := NewHistoryHandler(setupNamespaceHistoryTest(t, ctrl), instrument.NewOptions())
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(HistoryHTTPMethod, M3DBHistoryURL, nil)
+	historyHandler.ServeHTTP(svcDefaults, w, req)
+
+	resp := w.Result()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var historyResp admin.NamespaceHistoryResponse
+	require.NoError(t, jsonpb.Unmarshal(resp.Body, &historyResp))
+	require.Equal(t, 2, len(historyResp.Entries))
+
+	first, second := historyResp.Entries[0], historyResp.Entries[1]
+	assert.Equal(t, int32(1), first.Version)
+	assert.Equal(t, "alice", first.Operator)
+	assert.Equal(t, "add first", first.Reason)
+	assert.Equal(t, 1, len(first.Registry.Namespaces))
+	assert.Equal(t, int32(2), second.Version)
+	assert.Equal(t, "add second", second.Reason)
+	assert.Equal(t, 2, len(second.Registry.Namespaces))
+}
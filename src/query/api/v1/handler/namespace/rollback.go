@@ -0,0 +1,126 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespace
+
+import (
+	"errors"
+	"net/http"
+	"path"
+	"strconv"
+
+	clusterclient "github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/cluster/kv/util/history"
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/generated/proto/admin"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+)
+
+const (
+	rollbackVersionVar = "version"
+)
+
+var (
+	// M3DBRollbackURL is the url for the namespace registry rollback handler.
+	M3DBRollbackURL = path.Join(handler.RoutePrefixV1, M3DBServiceNamespacePathName, "rollback")
+
+	// RollbackHTTPMethod is the HTTP method used with this resource.
+	RollbackHTTPMethod = http.MethodPost
+
+	errRollbackVersionRequired = errors.New("version is required")
+)
+
+// RollbackHandler is the handler for namespace registry rollbacks.
+type RollbackHandler Handler
+
+// NewRollbackHandler returns a new instance of RollbackHandler.
+func NewRollbackHandler(
+	client clusterclient.Client,
+	instrumentOpts instrument.Options,
+) *RollbackHandler {
+	return &RollbackHandler{
+		client:         client,
+		instrumentOpts: instrumentOpts,
+	}
+}
+
+func (h *RollbackHandler) ServeHTTP(
+	svc handleroptions.ServiceNameAndDefaults,
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	ctx := r.Context()
+	logger := logging.WithContext(ctx, h.instrumentOpts)
+
+	vs := r.URL.Query().Get(rollbackVersionVar)
+	if vs == "" {
+		xhttp.Error(w, errRollbackVersionRequired, http.StatusBadRequest)
+		return
+	}
+	version, err := strconv.Atoi(vs)
+	if err != nil {
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	opts := handleroptions.NewServiceOptions(svc, r.Header, nil)
+	resp, err := h.Rollback(version, opts)
+	if err == history.ErrVersionNotFound {
+		xhttp.Error(w, err, http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		logger.Error("unable to roll back namespace registry", zap.Error(err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	xhttp.WriteProtoMsgJSONResponse(w, resp, logger)
+}
+
+// Rollback sets the namespace registry back to the given version from the
+// registry history.
+func (h *RollbackHandler) Rollback(
+	version int,
+	opts handleroptions.ServiceOptions,
+) (*admin.NamespaceGetResponse, error) {
+	kvOpts := kv.NewOverrideOptions().
+		SetEnvironment(opts.ServiceEnvironment).
+		SetZone(opts.ServiceZone)
+
+	store, err := h.client.Store(kvOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	nsAdmin := newAdminService(store, M3DBNodeNamespacesKey, nil,
+		adminServiceOptions(opts, h.instrumentOpts)...)
+	registry, err := nsAdmin.Rollback(version)
+	if err != nil {
+		return nil, err
+	}
+	return &admin.NamespaceGetResponse{Registry: registry}, nil
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespace
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/generated/proto/admin"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespaceRollbackHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := setupNamespaceHistoryTest(t, ctrl)
+	rollbackHandler := NewRollbackHandler(mockClient, instrument.NewOptions())
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(RollbackHTTPMethod, M3DBRollbackURL+"?version=1", nil)
+	req.Header.Set(handleroptions.HeaderChangeOperator, "bob")
+	req.Header.Set(handleroptions.HeaderChangeReason, "remove second")
+	rollbackHandler.ServeHTTP(svcDefaults, w, req)
+
+	resp := w.Result()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var getResp admin.NamespaceGetResponse
+	require.NoError(t, jsonpb.Unmarshal(resp.Body, &getResp))
+	require.Equal(t, 1, len(getResp.Registry.Namespaces))
+	_, ok := getResp.Registry.Namespaces["first"]
+	assert.True(t, ok)
+
+	historyResp, err := NewHistoryHandler(mockClient, instrument.NewOptions()).
+		History(handleroptions.ServiceOptions{})
+	require.NoError(t, err)
+	require.Equal(t, 3, len(historyResp.Entries))
+	last := historyResp.Entries[2]
+	assert.Equal(t, int32(3), last.Version)
+	assert.Equal(t, "bob", last.Operator)
+	assert.Equal(t, "remove second", last.Reason)
+	assert.Equal(t, 1, len(last.Registry.Namespaces))
+}
+
+func TestNamespaceRollbackHandler_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rollbackHandler := NewRollbackHandler(setupNamespaceHistoryTest(t, ctrl), instrument.NewOptions())
+	for _, test := range []struct {
+		query  string
+		status int
+	}{
+		{query: "", status: http.StatusBadRequest},
+		{query: "?version=abc", status: http.StatusBadRequest},
+		{query: "?version=7", status: http.StatusNotFound},
+	} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(RollbackHTTPMethod, M3DBRollbackURL+test.query, nil)
+		rollbackHandler.ServeHTTP(svcDefaults, w, req)
+		assert.Equal(t, test.status, w.Result().StatusCode, test.query)
+	}
+}
@@ -128,7 +128,8 @@ func (h *SchemaHandler) Add(
 		return emptyRep, err
 	}
 
-	schemaAdmin := newAdminService(store, M3DBNodeNamespacesKey, nil)
+	schemaAdmin := newAdminService(store, M3DBNodeNamespacesKey, nil,
+		adminServiceOptions(opts, h.instrumentOpts)...)
 	deployID, err := schemaAdmin.DeploySchema(addReq.Name, addReq.ProtoName, addReq.MsgName, addReq.ProtoMap)
 	if err != nil {
 		return emptyRep, err
@@ -216,7 +217,8 @@ func (h *SchemaResetHandler) Reset(
 		return &emptyRep, err
 	}
 
-	schemaAdmin := newAdminService(store, M3DBNodeNamespacesKey, nil)
+	schemaAdmin := newAdminService(store, M3DBNodeNamespacesKey, nil,
+		adminServiceOptions(opts, h.instrumentOpts)...)
 	err = schemaAdmin.ResetSchema(addReq.Name)
 	if err != nil {
 		return &emptyRep, err
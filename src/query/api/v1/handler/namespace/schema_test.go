@@ -169,7 +169,9 @@ func TestSchemaDeploy(t *testing.T) {
 	mockClient.EXPECT().Store(gomock.Any()).Return(mockKV, nil)
 
 	mockAdminSvc := kvadmin.NewMockNamespaceMetadataAdminService(ctrl)
-	newAdminService = func(kv.Store, string, func() string) kvadmin.NamespaceMetadataAdminService { return mockAdminSvc }
+	newAdminService = func(kv.Store, string, func() string, ...kvadmin.AdminServiceOption) kvadmin.NamespaceMetadataAdminService {
+		return mockAdminSvc
+	}
 	defer func() { newAdminService = kvadmin.NewAdminService }()
 
 	mockAdminSvc.EXPECT().DeploySchema("testNamespace", "mainpkg/test.proto",
@@ -261,7 +263,9 @@ func TestSchemaReset(t *testing.T) {
 	mockClient.EXPECT().Store(gomock.Any()).Return(mockKV, nil)
 
 	mockAdminSvc := kvadmin.NewMockNamespaceMetadataAdminService(ctrl)
-	newAdminService = func(kv.Store, string, func() string) kvadmin.NamespaceMetadataAdminService { return mockAdminSvc }
+	newAdminService = func(kv.Store, string, func() string, ...kvadmin.AdminServiceOption) kvadmin.NamespaceMetadataAdminService {
+		return mockAdminSvc
+	}
 	defer func() { newAdminService = kvadmin.NewAdminService }()
 
 	mockAdminSvc.EXPECT().ResetSchema("testNamespace").Return(nil)
@@ -0,0 +1,338 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package opentsdb
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/m3db/m3/src/query/functions"
+	"github.com/m3db/m3/src/query/functions/aggregation"
+	"github.com/m3db/m3/src/query/functions/temporal"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+)
+
+const (
+	noneAggregator   = "none"
+	percentilePrefix = "p"
+)
+
+var (
+	// aggregators maps OpenTSDB aggregators to aggregation operations, NB:
+	// values are aggregated at each step so the zero-interpolating and
+	// max/min-interpolating variants behave the same as the linear ones.
+	aggregators = map[string]string{
+		"sum":    aggregation.SumType,
+		"zimsum": aggregation.SumType,
+		"min":    aggregation.MinType,
+		"mimmin": aggregation.MinType,
+		"max":    aggregation.MaxType,
+		"mimmax": aggregation.MaxType,
+		"avg":    aggregation.AverageType,
+		"dev":    aggregation.StandardDeviationType,
+		"count":  aggregation.CountType,
+	}
+
+	// downsamplers maps OpenTSDB downsample aggregators to temporal
+	// aggregation operations.
+	downsamplers = map[string]string{
+		"sum":    temporal.SumType,
+		"zimsum": temporal.SumType,
+		"min":    temporal.MinType,
+		"mimmin": temporal.MinType,
+		"max":    temporal.MaxType,
+		"mimmax": temporal.MaxType,
+		"avg":    temporal.AvgType,
+		"dev":    temporal.StdDevType,
+		"count":  temporal.CountType,
+	}
+)
+
+// parsePercentile parses a percentile aggregator such as p95 or p999 into
+// the quantile it represents.
+func parsePercentile(aggregator string) (float64, bool) {
+	digits := strings.TrimPrefix(aggregator, percentilePrefix)
+	if digits == aggregator || digits == "" {
+		return 0, false
+	}
+
+	n, err := strconv.ParseUint(digits, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	q := float64(n)
+	for range digits {
+		q /= 10
+	}
+	return q, true
+}
+
+// queryParser converts an OpenTSDB sub query into a DAG of a fetch, an
+// optional downsample or rate temporal function, the rate being of the
+// downsampled values when downsampled, and an optional aggregation.
+type queryParser struct {
+	query      SubQuery
+	filters    []Filter
+	downsample *downsample
+	step       time.Duration
+	tagOpts    models.TagOptions
+}
+
+func newQueryParser(
+	query SubQuery,
+	defaultStep time.Duration,
+	tagOpts models.TagOptions,
+) (*queryParser, error) {
+	if err := validateSubQuery(query); err != nil {
+		return nil, err
+	}
+
+	if query.Aggregator != noneAggregator {
+		if _, ok := aggregators[query.Aggregator]; !ok {
+			if _, ok := parsePercentile(query.Aggregator); !ok {
+				return nil, fmt.Errorf("unsupported aggregator: %s", query.Aggregator)
+			}
+		}
+	}
+
+	p := &queryParser{
+		query:   query,
+		filters: query.filters(),
+		step:    defaultStep,
+		tagOpts: tagOpts,
+	}
+
+	if query.Downsample != "" {
+		ds, err := parseDownsample(query.Downsample)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := downsamplers[ds.aggregator]; !ok {
+			if _, ok := parsePercentile(ds.aggregator); !ok {
+				return nil, fmt.Errorf("unsupported downsample aggregator: %s",
+					ds.aggregator)
+			}
+		}
+
+		p.downsample = &ds
+		p.step = ds.interval
+	}
+
+	return p, nil
+}
+
+// groupByTags returns the tags the sub query groups by.
+func (p *queryParser) groupByTags() []string {
+	var tags []string
+	for _, f := range p.filters {
+		if f.GroupBy {
+			tags = append(tags, f.Tagk)
+		}
+	}
+	return tags
+}
+
+// aggregateTags returns the filtered tags the sub query aggregates away.
+func (p *queryParser) aggregateTags() []string {
+	tags := []string{}
+	if p.query.Aggregator == noneAggregator {
+		return tags
+	}
+
+	for _, f := range p.filters {
+		if !f.GroupBy {
+			tags = append(tags, f.Tagk)
+		}
+	}
+	return tags
+}
+
+func (p *queryParser) matchers() (models.Matchers, error) {
+	metric, err := models.NewMatcher(models.MatchEqual, p.tagOpts.MetricName(),
+		[]byte(p.query.Metric))
+	if err != nil {
+		return nil, err
+	}
+
+	matchers := models.Matchers{metric}
+	for _, f := range p.filters {
+		if f.Tagk == "" {
+			return nil, errEmptyTagName
+		}
+
+		var matcher models.Matcher
+		if f.Type == filterLiteral && !strings.Contains(f.Filter, "|") {
+			matcher, err = models.NewMatcher(models.MatchEqual, []byte(f.Tagk),
+				[]byte(f.Filter))
+		} else {
+			re, negated, reErr := filterToRegexp(f)
+			if reErr != nil {
+				return nil, reErr
+			}
+
+			matchType := models.MatchRegexp
+			if negated {
+				matchType = models.MatchNotRegexp
+			}
+			matcher, err = models.NewMatcher(matchType, []byte(f.Tagk), []byte(re))
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		matchers = append(matchers, matcher)
+	}
+
+	return matchers, nil
+}
+
+// temporalOp returns the rate or downsample operation, if any, and how far
+// it looks back. A rate is calculated between values a step apart, which is
+// the downsample interval if set, with values downsampled first.
+func (p *queryParser) temporalOp() (parser.Params, time.Duration, error) {
+	if p.query.Rate {
+		var downsample downsampleFn
+		if p.downsample != nil {
+			downsample, _ = newDownsampleFn(p.downsample.aggregator)
+		}
+
+		counter := p.query.RateOptions != nil && p.query.RateOptions.Counter
+		return newRateOp(p.step, counter, downsample)
+	}
+
+	if p.downsample == nil {
+		return nil, 0, nil
+	}
+
+	if q, ok := parsePercentile(p.downsample.aggregator); ok {
+		op, err := temporal.NewQuantileOp([]interface{}{q, p.step}, temporal.QuantileType)
+		return op, p.step, err
+	}
+
+	op, err := temporal.NewAggOp([]interface{}{p.step}, downsamplers[p.downsample.aggregator])
+	return op, p.step, err
+}
+
+func (p *queryParser) aggregationOp() (parser.Params, error) {
+	if p.query.Aggregator == noneAggregator {
+		return nil, nil
+	}
+
+	groupBy := p.groupByTags()
+	params := aggregation.NodeParams{
+		MatchingTags: make([][]byte, 0, len(groupBy)),
+	}
+	for _, tag := range groupBy {
+		params.MatchingTags = append(params.MatchingTags, []byte(tag))
+	}
+
+	opType, ok := aggregators[p.query.Aggregator]
+	if !ok {
+		q, _ := parsePercentile(p.query.Aggregator)
+		opType = aggregation.QuantileType
+		params.Parameter = q
+	}
+	return aggregation.NewAggregationOp(opType, params)
+}
+
+func (p *queryParser) DAG() (parser.Nodes, parser.Edges, error) {
+	matchers, err := p.matchers()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	temporalOp, lookback, err := p.temporalOp()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	aggregationOp, err := p.aggregationOp()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fetch := functions.FetchOp{
+		Name:     p.query.Metric,
+		Matchers: matchers,
+	}
+	if temporalOp != nil {
+		fetch.Range = lookback
+	}
+
+	var (
+		nodes = parser.Nodes{parser.NewTransformFromOperation(fetch, 0)}
+		edges parser.Edges
+	)
+	for _, op := range []parser.Params{temporalOp, aggregationOp} {
+		if op == nil {
+			continue
+		}
+
+		node := parser.NewTransformFromOperation(op, len(nodes))
+		edges = append(edges, parser.Edge{
+			ParentID: nodes[len(nodes)-1].ID,
+			ChildID:  node.ID,
+		})
+		nodes = append(nodes, node)
+	}
+
+	return nodes, edges, nil
+}
+
+// String returns the sub query in the query string format.
+func (p *queryParser) String() string {
+	parts := []string{p.query.Aggregator}
+	if p.query.Rate {
+		if p.query.RateOptions != nil && p.query.RateOptions.Counter {
+			parts = append(parts, "rate{counter}")
+		} else {
+			parts = append(parts, "rate")
+		}
+	}
+	if p.query.Downsample != "" {
+		parts = append(parts, p.query.Downsample)
+	}
+
+	var groupBy, filters []string
+	for _, f := range p.filters {
+		str := fmt.Sprintf("%s=%s(%s)", f.Tagk, f.Type, f.Filter)
+		if f.GroupBy {
+			groupBy = append(groupBy, str)
+		} else {
+			filters = append(filters, str)
+		}
+	}
+
+	metric := p.query.Metric
+	if len(groupBy) > 0 || len(filters) > 0 {
+		metric += "{" + strings.Join(groupBy, ",") + "}"
+	}
+	if len(filters) > 0 {
+		metric += "{" + strings.Join(filters, ",") + "}"
+	}
+
+	return strings.Join(append(parts, metric), ":")
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package opentsdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/functions"
+	"github.com/m3db/m3/src/query/functions/aggregation"
+	"github.com/m3db/m3/src/query/functions/temporal"
+	"github.com/m3db/m3/src/query/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func opTypes(t *testing.T, q SubQuery) ([]string, functions.FetchOp) {
+	p, err := newQueryParser(q, time.Minute, models.NewTagOptions())
+	require.NoError(t, err)
+
+	nodes, edges, err := p.DAG()
+	require.NoError(t, err)
+	require.Len(t, edges, len(nodes)-1)
+	for i, edge := range edges {
+		assert.Equal(t, nodes[i].ID, edge.ParentID)
+		assert.Equal(t, nodes[i+1].ID, edge.ChildID)
+	}
+
+	types := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		types = append(types, node.Op.OpType())
+	}
+
+	fetch, ok := nodes[0].Op.(functions.FetchOp)
+	require.True(t, ok)
+	return types, fetch
+}
+
+func matcherStrings(matchers models.Matchers) []string {
+	strs := make([]string, 0, len(matchers))
+	for _, m := range matchers {
+		strs = append(strs, m.String())
+	}
+	return strs
+}
+
+func TestQueryParserDAG(t *testing.T) {
+	types, fetch := opTypes(t, SubQuery{Aggregator: "none", Metric: "sys.cpu"})
+	assert.Equal(t, []string{functions.FetchType}, types)
+	assert.Equal(t, time.Duration(0), fetch.Range)
+	assert.Equal(t, []string{`__name__="sys.cpu"`}, matcherStrings(fetch.Matchers))
+
+	types, fetch = opTypes(t, SubQuery{
+		Aggregator: "zimsum",
+		Metric:     "sys.cpu",
+		Downsample: "5m-max",
+		Tags:       map[string]string{"host": "*"},
+		Filters: []Filter{
+			{Type: filterLiteral, Tagk: "dc", Filter: "lga"},
+			{Type: filterNotLit, Tagk: "env", Filter: "dev|test"},
+		},
+	})
+	assert.Equal(t, []string{functions.FetchType, temporal.MaxType,
+		aggregation.SumType}, types)
+	assert.Equal(t, 5*time.Minute, fetch.Range)
+	assert.Equal(t, []string{`__name__="sys.cpu"`, `host=~".*"`, `dc="lga"`,
+		`env!~"dev|test"`}, matcherStrings(fetch.Matchers))
+
+	types, fetch = opTypes(t, SubQuery{
+		Aggregator:  "p95",
+		Metric:      "sys.cpu",
+		Rate:        true,
+		RateOptions: &RateOptions{Counter: true},
+	})
+	assert.Equal(t, []string{functions.FetchType, RateType,
+		aggregation.QuantileType}, types)
+	assert.Equal(t, 2*time.Minute, fetch.Range)
+
+	types, _ = opTypes(t, SubQuery{Aggregator: "avg", Metric: "sys.cpu", Rate: true})
+	assert.Equal(t, []string{functions.FetchType, RateType,
+		aggregation.AverageType}, types)
+
+	// The rate is of the downsampled values.
+	types, fetch = opTypes(t, SubQuery{Aggregator: "sum", Metric: "sys.cpu",
+		Rate: true, Downsample: "5m-max"})
+	assert.Equal(t, []string{functions.FetchType, RateType,
+		aggregation.SumType}, types)
+	assert.Equal(t, 10*time.Minute, fetch.Range)
+
+	types, _ = opTypes(t, SubQuery{Aggregator: "none", Metric: "sys.cpu",
+		Downsample: "1m-p99"})
+	assert.Equal(t, []string{functions.FetchType, temporal.QuantileType}, types)
+}
+
+func TestQueryParserInvalid(t *testing.T) {
+	for _, q := range []SubQuery{
+		{Aggregator: "median", Metric: "sys.cpu"},
+		{Aggregator: "sum", Metric: "sys.cpu", Downsample: "1m-median"},
+		{Aggregator: "sum", Metric: "sys.cpu", Downsample: "1m"},
+		{Aggregator: "sum"},
+	} {
+		_, err := newQueryParser(q, time.Minute, models.NewTagOptions())
+		assert.Error(t, err)
+	}
+}
+
+func TestQueryParserString(t *testing.T) {
+	p, err := newQueryParser(SubQuery{
+		Aggregator:  "sum",
+		Metric:      "sys.cpu",
+		Rate:        true,
+		RateOptions: &RateOptions{Counter: true},
+		Downsample:  "1m-avg",
+		Tags:        map[string]string{"host": "web*"},
+		Filters:     []Filter{{Type: filterLiteral, Tagk: "dc", Filter: "lga"}},
+	}, time.Minute, models.NewTagOptions())
+	require.NoError(t, err)
+	assert.Equal(t, "sum:rate{counter}:1m-avg:"+
+		"sys.cpu{host=wildcard(web*)}{dc=literal_or(lga)}", p.String())
+	assert.Equal(t, []string{"host"}, p.groupByTags())
+	assert.Equal(t, []string{"dc"}, p.aggregateTags())
+}
+
+func TestParsePercentile(t *testing.T) {
+	for str, expected := range map[string]float64{
+		"p50": 0.5, "p75": 0.75, "p90": 0.9, "p99": 0.99, "p999": 0.999,
+	} {
+		q, ok := parsePercentile(str)
+		require.True(t, ok, str)
+		assert.InDelta(t, expected, q, 1e-9, str)
+	}
+
+	for _, invalid := range []string{"p", "sum", "pfoo"} {
+		_, ok := parsePercentile(invalid)
+		assert.False(t, ok, invalid)
+	}
+}
@@ -0,0 +1,446 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package opentsdb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	nowTime         = "now"
+	relativeSuffix  = "-ago"
+	allInterval     = "all"
+	filterWildcard  = "wildcard"
+	filterIWildcard = "iwildcard"
+	filterLiteral   = "literal_or"
+	filterILiteral  = "iliteral_or"
+	filterNotLit    = "not_literal_or"
+	filterNotILit   = "not_iliteral_or"
+	filterRegexp    = "regexp"
+)
+
+var (
+	absoluteTimeFormats = []string{
+		"2006/01/02-15:04:05",
+		"2006/01/02 15:04:05",
+		"2006/01/02-15:04",
+		"2006/01/02 15:04",
+		"2006/01/02",
+	}
+
+	errEmptyTime        = errors.New("missing time")
+	errNoQueries        = errors.New("missing sub queries")
+	errEmptyDuration    = errors.New("missing duration")
+	errEmptyAggregator  = errors.New("missing aggregator")
+	errInvalidMetricStr = errors.New("invalid metric query, expected " +
+		"aggregator:[rate[{counter}]:][downsample:]metric[{tags}][{filters}]")
+)
+
+// Time is an OpenTSDB time, either an absolute or relative time string or
+// a timestamp in seconds or milliseconds.
+type Time string
+
+// UnmarshalJSON unmarshals a time that is either a string or a number.
+func (t *Time) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		*t = Time(str)
+		return nil
+	}
+
+	var num json.Number
+	if err := json.Unmarshal(data, &num); err != nil {
+		return fmt.Errorf("invalid time: %s", data)
+	}
+
+	*t = Time(num.String())
+	return nil
+}
+
+// Parse parses the time relative to now.
+func (t Time) Parse(now time.Time) (time.Time, error) {
+	str := strings.TrimSpace(string(t))
+	switch {
+	case str == "":
+		return time.Time{}, errEmptyTime
+	case str == nowTime:
+		return now, nil
+	case strings.HasSuffix(str, relativeSuffix):
+		d, err := parseDuration(strings.TrimSuffix(str, relativeSuffix))
+		if err != nil {
+			return time.Time{}, err
+		}
+		return now.Add(-d), nil
+	}
+
+	if ts, err := strconv.ParseInt(str, 10, 64); err == nil {
+		if ts > millisTimestampThreshold {
+			return time.Unix(0, ts*int64(time.Millisecond)), nil
+		}
+		return time.Unix(ts, 0), nil
+	}
+
+	for _, format := range absoluteTimeFormats {
+		if parsed, err := time.Parse(format, str); err == nil {
+			return parsed, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time: %s", str)
+}
+
+// parseDuration parses an OpenTSDB duration such as 1h, 30s or 2w.
+func parseDuration(str string) (time.Duration, error) {
+	idx := strings.IndexFunc(str, func(r rune) bool {
+		return r < '0' || r > '9'
+	})
+	if idx <= 0 {
+		if str == "" {
+			return 0, errEmptyDuration
+		}
+		return 0, fmt.Errorf("invalid duration: %s", str)
+	}
+
+	n, err := strconv.ParseInt(str[:idx], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration: %s", str)
+	}
+
+	var unit time.Duration
+	switch str[idx:] {
+	case "ms":
+		unit = time.Millisecond
+	case "s":
+		unit = time.Second
+	case "m":
+		unit = time.Minute
+	case "h":
+		unit = time.Hour
+	case "d":
+		unit = 24 * time.Hour
+	case "w":
+		unit = 7 * 24 * time.Hour
+	case "n":
+		unit = 30 * 24 * time.Hour
+	case "y":
+		unit = 365 * 24 * time.Hour
+	default:
+		return 0, fmt.Errorf("invalid duration unit: %s", str)
+	}
+
+	d := time.Duration(n) * unit
+	if d <= 0 {
+		return 0, fmt.Errorf("duration must be positive: %s", str)
+	}
+	return d, nil
+}
+
+// QueryRequest is an OpenTSDB query request.
+type QueryRequest struct {
+	Start        Time       `json:"start"`
+	End          Time       `json:"end"`
+	Queries      []SubQuery `json:"queries"`
+	MsResolution bool       `json:"msResolution"`
+}
+
+// SubQuery is a single OpenTSDB metric query.
+type SubQuery struct {
+	Aggregator  string            `json:"aggregator"`
+	Metric      string            `json:"metric"`
+	Rate        bool              `json:"rate"`
+	RateOptions *RateOptions      `json:"rateOptions"`
+	Downsample  string            `json:"downsample"`
+	Tags        map[string]string `json:"tags"`
+	Filters     []Filter          `json:"filters"`
+}
+
+// RateOptions are the options for calculating a rate.
+type RateOptions struct {
+	Counter bool `json:"counter"`
+}
+
+// Filter is an OpenTSDB tag filter.
+type Filter struct {
+	Type    string `json:"type"`
+	Tagk    string `json:"tagk"`
+	Filter  string `json:"filter"`
+	GroupBy bool   `json:"groupBy"`
+}
+
+// Validate validates the query request.
+func (r QueryRequest) Validate() error {
+	if len(r.Queries) == 0 {
+		return errNoQueries
+	}
+	for _, q := range r.Queries {
+		if q.Metric == "" {
+			return errNoMetric
+		}
+		if q.Aggregator == "" {
+			return errEmptyAggregator
+		}
+	}
+	return nil
+}
+
+// parseMetricQuery parses a sub query in the query string format
+// aggregator:[rate[{counter}]:][downsample:]metric[{tags}][{filters}].
+func parseMetricQuery(str string) (SubQuery, error) {
+	// NB: tag filters may contain colons so only split on colons outside
+	// of braces.
+	var (
+		parts []string
+		depth int
+		last  int
+	)
+	for i, r := range str {
+		switch r {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case ':':
+			if depth == 0 {
+				parts = append(parts, str[last:i])
+				last = i + 1
+			}
+		}
+	}
+	if len(parts) == 0 {
+		return SubQuery{}, errInvalidMetricStr
+	}
+	metric := str[last:]
+
+	var q SubQuery
+	q.Aggregator = parts[0]
+	for _, part := range parts[1:] {
+		switch {
+		case part == "rate":
+			q.Rate = true
+		case strings.HasPrefix(part, "rate{") && strings.HasSuffix(part, "}"):
+			q.Rate = true
+			opts := strings.Split(strings.TrimSuffix(part[len("rate{"):], "}"), ",")
+			q.RateOptions = &RateOptions{Counter: opts[0] == "counter"}
+		case part != "" && q.Downsample == "":
+			q.Downsample = part
+		default:
+			return SubQuery{}, errInvalidMetricStr
+		}
+	}
+
+	braceIdx := strings.Index(metric, "{")
+	if braceIdx < 0 {
+		q.Metric = metric
+		return q, validateSubQuery(q)
+	}
+
+	q.Metric = metric[:braceIdx]
+	groupBy, rest, err := splitBraces(metric[braceIdx:])
+	if err != nil {
+		return SubQuery{}, err
+	}
+	filters, rest, err := splitBraces(rest)
+	if err != nil {
+		return SubQuery{}, err
+	}
+	if rest != "" {
+		return SubQuery{}, errInvalidMetricStr
+	}
+
+	for _, group := range []struct {
+		str     string
+		groupBy bool
+	}{
+		{str: groupBy, groupBy: true},
+		{str: filters, groupBy: false},
+	} {
+		if group.str == "" {
+			continue
+		}
+		for _, tag := range strings.Split(group.str, ",") {
+			kv := strings.SplitN(tag, "=", 2)
+			if len(kv) != 2 || kv[0] == "" {
+				return SubQuery{}, fmt.Errorf("invalid tag filter: %s", tag)
+			}
+			q.Filters = append(q.Filters, newFilter(kv[0], kv[1], group.groupBy))
+		}
+	}
+
+	return q, validateSubQuery(q)
+}
+
+func validateSubQuery(q SubQuery) error {
+	if q.Metric == "" {
+		return errNoMetric
+	}
+	if q.Aggregator == "" {
+		return errEmptyAggregator
+	}
+	return nil
+}
+
+// splitBraces returns the contents of the leading braces of str and the
+// remainder after them, or an empty string if str does not start with a brace.
+func splitBraces(str string) (string, string, error) {
+	if !strings.HasPrefix(str, "{") {
+		return "", str, nil
+	}
+
+	idx := strings.Index(str, "}")
+	if idx < 0 {
+		return "", "", errInvalidMetricStr
+	}
+	return str[1:idx], str[idx+1:], nil
+}
+
+// newFilter creates a filter from a tag value, either in the function form
+// type(expr) or as a plain value where * is a wildcard and | separates
+// literal values.
+func newFilter(tagk, value string, groupBy bool) Filter {
+	if open := strings.Index(value, "("); open > 0 && strings.HasSuffix(value, ")") {
+		return Filter{
+			Type:    value[:open],
+			Tagk:    tagk,
+			Filter:  value[open+1 : len(value)-1],
+			GroupBy: groupBy,
+		}
+	}
+
+	filterType := filterLiteral
+	if strings.Contains(value, "*") {
+		filterType = filterWildcard
+	}
+	return Filter{
+		Type:    filterType,
+		Tagk:    tagk,
+		Filter:  value,
+		GroupBy: groupBy,
+	}
+}
+
+// filters returns the sub query filters including those specified by the
+// legacy tags map, which are all grouped by.
+func (q SubQuery) filters() []Filter {
+	tagks := make([]string, 0, len(q.Tags))
+	for tagk := range q.Tags {
+		tagks = append(tagks, tagk)
+	}
+	sort.Strings(tagks)
+
+	filters := make([]Filter, 0, len(q.Tags)+len(q.Filters))
+	for _, tagk := range tagks {
+		filters = append(filters, newFilter(tagk, q.Tags[tagk], true))
+	}
+	return append(filters, q.Filters...)
+}
+
+// fillPolicy is the policy for filling missing downsampled values.
+type fillPolicy int
+
+const (
+	fillNone fillPolicy = iota
+	fillNull
+	fillZero
+)
+
+type downsample struct {
+	interval   time.Duration
+	aggregator string
+	fill       fillPolicy
+}
+
+// parseDownsample parses a downsample specification such as 1m-avg or
+// 5m-sum-zero, an interval of "all" or "0all" is not supported.
+func parseDownsample(str string) (downsample, error) {
+	parts := strings.Split(str, "-")
+	if len(parts) < 2 || len(parts) > 3 {
+		return downsample{}, fmt.Errorf("invalid downsample: %s", str)
+	}
+
+	if strings.HasSuffix(parts[0], allInterval) {
+		return downsample{}, fmt.Errorf("unsupported downsample interval: %s", parts[0])
+	}
+
+	interval, err := parseDuration(parts[0])
+	if err != nil {
+		return downsample{}, err
+	}
+
+	result := downsample{interval: interval, aggregator: parts[1]}
+	if len(parts) == 3 {
+		switch parts[2] {
+		case "none":
+			result.fill = fillNone
+		case "nan", "null":
+			result.fill = fillNull
+		case "zero":
+			result.fill = fillZero
+		default:
+			return downsample{}, fmt.Errorf("invalid downsample fill policy: %s", parts[2])
+		}
+	}
+
+	return result, nil
+}
+
+// filterToRegexp returns the anchored regular expression for a filter and
+// whether it is negated.
+func filterToRegexp(f Filter) (string, bool, error) {
+	switch f.Type {
+	case filterLiteral, filterILiteral, filterNotLit, filterNotILit:
+		values := strings.Split(f.Filter, "|")
+		for i, v := range values {
+			values[i] = regexp.QuoteMeta(v)
+		}
+		re := strings.Join(values, "|")
+		if f.Type == filterILiteral || f.Type == filterNotILit {
+			re = "(?i)" + re
+		}
+		negated := f.Type == filterNotLit || f.Type == filterNotILit
+		return re, negated, nil
+	case filterWildcard, filterIWildcard:
+		parts := strings.Split(f.Filter, "*")
+		for i, p := range parts {
+			parts[i] = regexp.QuoteMeta(p)
+		}
+		re := strings.Join(parts, ".*")
+		if f.Type == filterIWildcard {
+			re = "(?i)" + re
+		}
+		return re, false, nil
+	case filterRegexp:
+		// NB: OpenTSDB regular expressions are unanchored.
+		re := ".*(?:" + f.Filter + ").*"
+		if _, err := regexp.Compile(re); err != nil {
+			return "", false, err
+		}
+		return re, false, nil
+	default:
+		return "", false, fmt.Errorf("unsupported filter type: %s", f.Type)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package opentsdb
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeParse(t *testing.T) {
+	now := time.Unix(1500000000, 0).UTC()
+	tests := []struct {
+		str      Time
+		expected time.Time
+	}{
+		{str: "now", expected: now},
+		{str: "1h-ago", expected: now.Add(-time.Hour)},
+		{str: "2d-ago", expected: now.Add(-48 * time.Hour)},
+		{str: "1356998400", expected: time.Unix(1356998400, 0)},
+		{str: "1356998400500", expected: time.Unix(1356998400, 500*int64(time.Millisecond))},
+		{str: "2013/01/01-00:00:00", expected: time.Unix(1356998400, 0).UTC()},
+		{str: "2013/01/01 00:01", expected: time.Unix(1356998460, 0).UTC()},
+		{str: "2013/01/01", expected: time.Unix(1356998400, 0).UTC()},
+	}
+
+	for _, tt := range tests {
+		parsed, err := tt.str.Parse(now)
+		require.NoError(t, err, string(tt.str))
+		assert.True(t, tt.expected.Equal(parsed), string(tt.str))
+	}
+
+	for _, invalid := range []Time{"", "1x-ago", "-ago", "yesterday"} {
+		_, err := invalid.Parse(now)
+		assert.Error(t, err, string(invalid))
+	}
+}
+
+func TestTimeUnmarshalJSON(t *testing.T) {
+	var req QueryRequest
+	require.NoError(t, json.Unmarshal(
+		[]byte(`{"start":1356998400,"end":"1h-ago"}`), &req))
+	assert.Equal(t, Time("1356998400"), req.Start)
+	assert.Equal(t, Time("1h-ago"), req.End)
+}
+
+func TestParseMetricQuery(t *testing.T) {
+	q, err := parseMetricQuery("sum:sys.cpu.user")
+	require.NoError(t, err)
+	assert.Equal(t, SubQuery{Aggregator: "sum", Metric: "sys.cpu.user"}, q)
+
+	q, err = parseMetricQuery(
+		"avg:rate{counter}:1m-avg-zero:sys.cpu.user{host=web*,dc=lga|sjc}{type=regexp(use.*)}")
+	require.NoError(t, err)
+	assert.Equal(t, SubQuery{
+		Aggregator:  "avg",
+		Metric:      "sys.cpu.user",
+		Rate:        true,
+		RateOptions: &RateOptions{Counter: true},
+		Downsample:  "1m-avg-zero",
+		Filters: []Filter{
+			{Type: filterWildcard, Tagk: "host", Filter: "web*", GroupBy: true},
+			{Type: filterLiteral, Tagk: "dc", Filter: "lga|sjc", GroupBy: true},
+			{Type: filterRegexp, Tagk: "type", Filter: "use.*"},
+		},
+	}, q)
+
+	for _, invalid := range []string{
+		"sys.cpu.user",
+		"sum:",
+		"sum:sys.cpu.user{host=a",
+		"sum:sys.cpu.user{host}",
+		"sum:1m-avg:1m-sum:sys.cpu.user",
+	} {
+		_, err := parseMetricQuery(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestParseDownsample(t *testing.T) {
+	ds, err := parseDownsample("5m-sum")
+	require.NoError(t, err)
+	assert.Equal(t, downsample{interval: 5 * time.Minute, aggregator: "sum"}, ds)
+
+	ds, err = parseDownsample("1h-p99-null")
+	require.NoError(t, err)
+	assert.Equal(t, downsample{interval: time.Hour, aggregator: "p99", fill: fillNull}, ds)
+
+	for _, invalid := range []string{"5m", "0all-sum", "5m-sum-foo", "m-sum"} {
+		_, err := parseDownsample(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestFilterToRegexp(t *testing.T) {
+	tests := []struct {
+		filter   Filter
+		expected string
+		negated  bool
+	}{
+		{filter: Filter{Type: filterLiteral, Filter: "a.b|c"}, expected: `a\.b|c`},
+		{filter: Filter{Type: filterILiteral, Filter: "a"}, expected: `(?i)a`},
+		{filter: Filter{Type: filterNotLit, Filter: "a|b"}, expected: `a|b`, negated: true},
+		{filter: Filter{Type: filterWildcard, Filter: "*"}, expected: `.*`},
+		{filter: Filter{Type: filterWildcard, Filter: "web*.com"}, expected: `web.*\.com`},
+		{filter: Filter{Type: filterIWildcard, Filter: "web*"}, expected: `(?i)web.*`},
+		{filter: Filter{Type: filterRegexp, Filter: "^web[0-9]"}, expected: `.*(?:^web[0-9]).*`},
+	}
+
+	for _, tt := range tests {
+		re, negated, err := filterToRegexp(tt.filter)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, re)
+		assert.Equal(t, tt.negated, negated)
+	}
+
+	_, _, err := filterToRegexp(Filter{Type: "unknown"})
+	assert.Error(t, err)
+	_, _, err = filterToRegexp(Filter{Type: filterRegexp, Filter: "("})
+	assert.Error(t, err)
+}
@@ -0,0 +1,313 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package opentsdb
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/ts"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// PutURL is the url for the OpenTSDB compatible put handler, it is not
+	// prefixed so that OpenTSDB collectors can write to it unmodified.
+	PutURL = "/api/put"
+
+	// PutHTTPMethod is the HTTP method used with this resource.
+	PutHTTPMethod = http.MethodPost
+
+	summaryParam = "summary"
+	detailsParam = "details"
+
+	// millisTimestampThreshold is the threshold above which timestamps
+	// are in milliseconds rather than seconds, the same as OpenTSDB.
+	millisTimestampThreshold = 0xFFFFFFFF
+)
+
+var (
+	errNoMetric     = errors.New("metric name was empty")
+	errNoTags       = errors.New("at least one tag is required")
+	errNoTimestamp  = errors.New("invalid timestamp")
+	errEmptyTagName = errors.New("tag name was empty")
+)
+
+// DataPoint is an OpenTSDB datapoint.
+type DataPoint struct {
+	Metric    string            `json:"metric"`
+	Timestamp int64             `json:"timestamp"`
+	Value     json.RawMessage   `json:"value"`
+	Tags      map[string]string `json:"tags"`
+}
+
+type putError struct {
+	DataPoint *DataPoint `json:"datapoint,omitempty"`
+	Error     string     `json:"error"`
+}
+
+type putResponse struct {
+	Failed  int        `json:"failed"`
+	Success int        `json:"success"`
+	Errors  []putError `json:"errors,omitempty"`
+}
+
+// PutHandler is the OpenTSDB compatible put handler, accepting a single
+// datapoint or an array of datapoints.
+type PutHandler struct {
+	downsamplerAndWriter ingest.DownsamplerAndWriter
+	tagOpts              models.TagOptions
+	instrumentOpts       instrument.Options
+}
+
+// NewPutHandler returns a new instance of the put handler.
+func NewPutHandler(opts options.HandlerOptions) http.Handler {
+	return &PutHandler{
+		downsamplerAndWriter: opts.DownsamplerAndWriter(),
+		tagOpts:              opts.TagOptions(),
+		instrumentOpts:       opts.InstrumentOpts(),
+	}
+}
+
+func (h *PutHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	dataPoints, rErr := parseDataPoints(r)
+	if rErr != nil {
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	var (
+		_, summary = r.URL.Query()[summaryParam]
+		_, details = r.URL.Query()[detailsParam]
+		resp       putResponse
+		iter       = &putIter{idx: -1}
+	)
+	for i := range dataPoints {
+		dp := &dataPoints[i]
+		point, err := newPutPoint(dp, h.tagOpts)
+		if err != nil {
+			resp.Failed++
+			resp.Errors = append(resp.Errors, putError{
+				DataPoint: dp,
+				Error:     err.Error(),
+			})
+			continue
+		}
+
+		iter.points = append(iter.points, point)
+	}
+
+	if len(iter.points) > 0 {
+		batchErr := h.downsamplerAndWriter.WriteBatch(r.Context(), iter,
+			ingest.WriteOptions{})
+		failed := 0
+		if batchErr != nil {
+			errs := batchErr.Errors()
+			failed = len(errs)
+			if failed > len(iter.points) {
+				failed = len(iter.points)
+			}
+			for _, err := range errs {
+				resp.Errors = append(resp.Errors, putError{Error: err.Error()})
+			}
+
+			logger := logging.WithContext(r.Context(), h.instrumentOpts)
+			logger.Error("write error",
+				zap.String("remoteAddr", r.RemoteAddr),
+				zap.Int("numErrors", len(errs)),
+				zap.Error(batchErr.LastError()))
+		}
+
+		resp.Failed += failed
+		resp.Success = len(iter.points) - failed
+	}
+
+	status := http.StatusNoContent
+	if resp.Failed > 0 {
+		status = http.StatusBadRequest
+	}
+
+	if !summary && !details {
+		if resp.Failed > 0 {
+			xhttp.Error(w, fmt.Errorf("one or more data points had errors: "+
+				"count=%d, last=%s", resp.Failed, resp.Errors[len(resp.Errors)-1].Error),
+				status)
+			return
+		}
+
+		w.WriteHeader(status)
+		return
+	}
+
+	if !details {
+		resp.Errors = nil
+	} else if resp.Errors == nil {
+		resp.Errors = []putError{}
+	}
+
+	if status == http.StatusNoContent {
+		status = http.StatusOK
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		logger := logging.WithContext(r.Context(), h.instrumentOpts)
+		logger.Error("unable to write put response", zap.Error(err))
+	}
+}
+
+func parseDataPoints(r *http.Request) ([]DataPoint, *xhttp.ParseError) {
+	if r.Body == nil {
+		err := errors.New("empty request body")
+		return nil, xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+
+	defer r.Body.Close()
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, xhttp.NewParseError(err, http.StatusInternalServerError)
+	}
+
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		err := errors.New("empty request body")
+		return nil, xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+
+	// NB: the body is either a single datapoint or an array of datapoints.
+	var dataPoints []DataPoint
+	if body[0] == '[' {
+		err = json.Unmarshal(body, &dataPoints)
+	} else {
+		dataPoints = make([]DataPoint, 1)
+		err = json.Unmarshal(body, &dataPoints[0])
+	}
+	if err != nil {
+		return nil, xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+
+	return dataPoints, nil
+}
+
+type putPoint struct {
+	tags      models.Tags
+	timestamp time.Time
+	unit      xtime.Unit
+	value     float64
+}
+
+func newPutPoint(dp *DataPoint, tagOpts models.TagOptions) (putPoint, error) {
+	if dp.Metric == "" {
+		return putPoint{}, errNoMetric
+	}
+	if len(dp.Tags) == 0 {
+		return putPoint{}, errNoTags
+	}
+	if dp.Timestamp <= 0 {
+		return putPoint{}, errNoTimestamp
+	}
+
+	value, err := parseValue(dp.Value)
+	if err != nil {
+		return putPoint{}, err
+	}
+
+	tags := models.NewTags(len(dp.Tags)+1, tagOpts).SetName([]byte(dp.Metric))
+	for name, value := range dp.Tags {
+		if name == "" {
+			return putPoint{}, errEmptyTagName
+		}
+		tags = tags.AddTag(models.Tag{Name: []byte(name), Value: []byte(value)})
+	}
+
+	timestamp, unit := time.Unix(dp.Timestamp, 0), xtime.Second
+	if dp.Timestamp > millisTimestampThreshold {
+		timestamp = time.Unix(0, dp.Timestamp*int64(time.Millisecond))
+		unit = xtime.Millisecond
+	}
+
+	return putPoint{
+		tags:      tags,
+		timestamp: timestamp,
+		unit:      unit,
+		value:     value,
+	}, nil
+}
+
+// parseValue parses a datapoint value, which is either a number or a
+// string containing a number.
+func parseValue(data json.RawMessage) (float64, error) {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		str = string(data)
+	}
+
+	value, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value: %s", data)
+	}
+	return value, nil
+}
+
+type putIter struct {
+	points []putPoint
+	idx    int
+}
+
+func (i *putIter) Next() bool {
+	i.idx++
+	return i.idx < len(i.points)
+}
+
+func (i *putIter) Current() (models.Tags, ts.Datapoints, xtime.Unit, []byte) {
+	if i.idx < 0 || i.idx >= len(i.points) {
+		return models.EmptyTags(), nil, 0, nil
+	}
+
+	point := i.points[i.idx]
+	return point.tags, ts.Datapoints{
+		{Timestamp: point.timestamp, Value: point.value},
+	}, point.unit, nil
+}
+
+func (i *putIter) Reset() error {
+	i.idx = -1
+	return nil
+}
+
+func (i *putIter) Error() error {
+	return nil
+}
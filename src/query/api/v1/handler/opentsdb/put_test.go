@@ -0,0 +1,184 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package opentsdb
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/ts"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type writtenPoint struct {
+	tags map[string]string
+	dps  ts.Datapoints
+	unit xtime.Unit
+}
+
+func newTestPutHandler(
+	ctrl *gomock.Controller,
+	writeErr error,
+) (http.Handler, *[]writtenPoint) {
+	var written []writtenPoint
+	writer := ingest.NewMockDownsamplerAndWriter(ctrl)
+	writer.EXPECT().
+		WriteBatch(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ interface{},
+			iter ingest.DownsampleAndWriteIter,
+			_ ingest.WriteOptions,
+		) ingest.BatchError {
+			for iter.Next() {
+				tags, dps, unit, _ := iter.Current()
+				point := writtenPoint{
+					tags: make(map[string]string),
+					dps:  dps,
+					unit: unit,
+				}
+				for _, tag := range tags.Tags {
+					point.tags[string(tag.Name)] = string(tag.Value)
+				}
+				written = append(written, point)
+			}
+
+			if writeErr == nil {
+				return nil
+			}
+			var multiErr xerrors.MultiError
+			return multiErr.Add(writeErr)
+		}).
+		AnyTimes()
+
+	opts := options.EmptyHandlerOptions().
+		SetDownsamplerAndWriter(writer).
+		SetTagOptions(models.NewTagOptions())
+	return NewPutHandler(opts), &written
+}
+
+func servePut(h http.Handler, query, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(PutHTTPMethod, PutURL+query, strings.NewReader(body))
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestPutSingle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h, written := newTestPutHandler(ctrl, nil)
+	resp := servePut(h, "", `{"metric":"sys.cpu.nice","timestamp":1346846400,`+
+		`"value":18,"tags":{"host":"web01","dc":"lga"}}`)
+	require.Equal(t, http.StatusNoContent, resp.Code)
+
+	require.Equal(t, []writtenPoint{
+		{
+			tags: map[string]string{
+				"__name__": "sys.cpu.nice",
+				"host":     "web01",
+				"dc":       "lga",
+			},
+			dps: ts.Datapoints{
+				{Timestamp: time.Unix(1346846400, 0), Value: 18},
+			},
+			unit: xtime.Second,
+		},
+	}, *written)
+}
+
+func TestPutBatchDetails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h, written := newTestPutHandler(ctrl, nil)
+	resp := servePut(h, "?details", `[
+		{"metric":"sys.cpu","timestamp":1346846400500,"value":"1.5","tags":{"host":"a"}},
+		{"metric":"sys.cpu","timestamp":1346846400,"value":2,"tags":{}},
+		{"metric":"","timestamp":1346846400,"value":3,"tags":{"host":"a"}},
+		{"metric":"sys.cpu","timestamp":1346846400,"value":"foo","tags":{"host":"a"}}
+	]`)
+	require.Equal(t, http.StatusBadRequest, resp.Code)
+
+	var body putResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+	assert.Equal(t, 1, body.Success)
+	assert.Equal(t, 3, body.Failed)
+	require.Len(t, body.Errors, 3)
+	assert.Equal(t, errNoTags.Error(), body.Errors[0].Error)
+	assert.Equal(t, errNoMetric.Error(), body.Errors[1].Error)
+	require.NotNil(t, body.Errors[2].DataPoint)
+	assert.Equal(t, json.RawMessage(`"foo"`), body.Errors[2].DataPoint.Value)
+
+	require.Len(t, *written, 1)
+	assert.Equal(t, xtime.Millisecond, (*written)[0].unit)
+	assert.Equal(t, ts.Datapoints{
+		{Timestamp: time.Unix(1346846400, 500*int64(time.Millisecond)), Value: 1.5},
+	}, (*written)[0].dps)
+}
+
+func TestPutSummary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h, _ := newTestPutHandler(ctrl, nil)
+	resp := servePut(h, "?summary", `[
+		{"metric":"sys.cpu","timestamp":1346846400,"value":1,"tags":{"host":"a"}},
+		{"metric":"sys.cpu","timestamp":1346846400,"value":1,"tags":{"host":"b"}}
+	]`)
+	require.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{"failed":0,"success":2}`, resp.Body.String())
+
+	h, _ = newTestPutHandler(ctrl, errors.New("write failed"))
+	resp = servePut(h, "?summary", `[
+		{"metric":"sys.cpu","timestamp":1346846400,"value":1,"tags":{"host":"a"}},
+		{"metric":"sys.cpu","timestamp":1346846400,"value":1,"tags":{"host":"b"}}
+	]`)
+	require.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.JSONEq(t, `{"failed":1,"success":1}`, resp.Body.String())
+}
+
+func TestPutInvalidBody(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h, _ := newTestPutHandler(ctrl, nil)
+	for _, body := range []string{"", "{", `[{"metric":1}]`} {
+		resp := servePut(h, "", body)
+		assert.Equal(t, http.StatusBadRequest, resp.Code, body)
+	}
+
+	resp := servePut(h, "", `{"metric":"sys.cpu","timestamp":1,"value":1}`)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
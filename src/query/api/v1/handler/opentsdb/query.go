@@ -0,0 +1,369 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package opentsdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// QueryURL is the url for the OpenTSDB compatible query handler.
+	QueryURL = "/api/query"
+
+	// AggregatorsURL is the url for the OpenTSDB compatible aggregators
+	// handler, used by dashboards to list the supported aggregators.
+	AggregatorsURL = "/api/aggregators"
+
+	// AggregatorsHTTPMethod is the HTTP method used with the aggregators
+	// resource.
+	AggregatorsHTTPMethod = http.MethodGet
+
+	// defaultStep is the resolution sub queries without a downsample are
+	// evaluated at.
+	defaultStep = time.Minute
+
+	startParam        = "start"
+	endParam          = "end"
+	metricQueryParam  = "m"
+	msResolutionParam = "ms"
+)
+
+var (
+	// QueryHTTPMethods are the HTTP methods used with the query resource.
+	QueryHTTPMethods = []string{http.MethodGet, http.MethodPost}
+
+	errNoEngine = errors.New("no query engine set")
+)
+
+type queryResult struct {
+	Metric        string            `json:"metric"`
+	Tags          map[string]string `json:"tags"`
+	AggregateTags []string          `json:"aggregateTags"`
+	DPS           dataPoints        `json:"dps"`
+}
+
+type dataPoint struct {
+	timestamp int64
+	value     float64
+}
+
+// dataPoints are marshalled as an object keyed by timestamp in
+// timestamp order, with missing values as null.
+type dataPoints []dataPoint
+
+func (d dataPoints) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, dp := range d {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('"')
+		buf.WriteString(strconv.FormatInt(dp.timestamp, 10))
+		buf.WriteString(`":`)
+		if math.IsNaN(dp.value) || math.IsInf(dp.value, 0) {
+			buf.WriteString("null")
+		} else {
+			buf.WriteString(strconv.FormatFloat(dp.value, 'g', -1, 64))
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// QueryHandler is the OpenTSDB compatible query handler, sub queries are
+// translated into a fetch followed by the query functions corresponding to
+// their rate, downsample and aggregator.
+type QueryHandler struct {
+	engine              executor.Engine
+	fetchOptionsBuilder handleroptions.FetchOptionsBuilder
+	tagOpts             models.TagOptions
+	nowFn               clock.NowFn
+	instrumentOpts      instrument.Options
+}
+
+// NewQueryHandler returns a new instance of the query handler.
+func NewQueryHandler(opts options.HandlerOptions) http.Handler {
+	return &QueryHandler{
+		engine:              opts.Engine(),
+		fetchOptionsBuilder: opts.FetchOptionsBuilder(),
+		tagOpts:             opts.TagOptions(),
+		nowFn:               opts.NowFn(),
+		instrumentOpts:      opts.InstrumentOpts(),
+	}
+}
+
+func (h *QueryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.engine == nil {
+		xhttp.Error(w, errNoEngine, http.StatusInternalServerError)
+		return
+	}
+
+	req, rErr := parseQueryRequest(r)
+	if rErr != nil {
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	now := h.nowFn()
+	start, err := req.Start.Parse(now)
+	if err != nil {
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	end := now
+	if req.End != "" {
+		if end, err = req.End.Parse(now); err != nil {
+			xhttp.Error(w, err, http.StatusBadRequest)
+			return
+		}
+	}
+
+	if !start.Before(end) {
+		xhttp.Error(w, errors.New("start must be before end"), http.StatusBadRequest)
+		return
+	}
+
+	parsers := make([]*queryParser, 0, len(req.Queries))
+	for _, q := range req.Queries {
+		p, err := newQueryParser(q, defaultStep, h.tagOpts)
+		if err != nil {
+			xhttp.Error(w, err, http.StatusBadRequest)
+			return
+		}
+		parsers = append(parsers, p)
+	}
+
+	fetchOpts, rErr := h.fetchOptionsBuilder.NewFetchOptions(r)
+	if rErr != nil {
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	results := []queryResult{}
+	for _, p := range parsers {
+		subResults, err := h.query(r.Context(), p, start, end, now, fetchOpts,
+			req.MsResolution)
+		if err != nil {
+			logger := logging.WithContext(r.Context(), h.instrumentOpts)
+			logger.Error("unable to execute query",
+				zap.String("query", p.String()), zap.Error(err))
+			xhttp.Error(w, err, http.StatusInternalServerError)
+			return
+		}
+
+		results = append(results, subResults...)
+	}
+
+	xhttp.WriteJSONResponse(w, results, h.instrumentOpts.Logger())
+}
+
+func (h *QueryHandler) query(
+	ctx context.Context,
+	p *queryParser,
+	start, end, now time.Time,
+	fetchOpts *storage.FetchOptions,
+	msResolution bool,
+) ([]queryResult, error) {
+	// NB: OpenTSDB downsample buckets are aligned to the interval and
+	// labelled by their start, whereas each step is calculated over the
+	// range preceding it, so evaluate steps at the end of each bucket.
+	step := p.step
+	params := models.RequestParams{
+		Start:            start.Truncate(step),
+		End:              end.Truncate(step),
+		Now:              now,
+		Timeout:          fetchOpts.Timeout,
+		Step:             step,
+		Query:            p.String(),
+		IncludeEnd:       true,
+		LookbackDuration: h.engine.Options().LookbackDuration(),
+	}
+	labelOffset := time.Duration(0)
+	if p.downsample != nil {
+		params.Start = params.Start.Add(step)
+		params.End = params.End.Add(step)
+		labelOffset = -step
+	}
+
+	queryOpts := &executor.QueryOptions{
+		QueryContextOptions: models.QueryContextOptions{
+			LimitMaxTimeseries: fetchOpts.Limit,
+		},
+	}
+
+	bl, err := h.engine.ExecuteExpr(ctx, p, queryOpts, fetchOpts, params)
+	if err != nil {
+		return nil, err
+	}
+
+	defer bl.Close()
+	it, err := bl.StepIter()
+	if err != nil {
+		return nil, err
+	}
+
+	defer it.Close()
+	var (
+		seriesMeta = it.SeriesMeta()
+		dps        = make([]dataPoints, len(seriesMeta))
+		fill       = fillNone
+	)
+	if p.downsample != nil {
+		fill = p.downsample.fill
+	}
+
+	for it.Next() {
+		current := it.Current()
+		t := current.Time().Add(labelOffset)
+		timestamp := t.Unix()
+		if msResolution {
+			timestamp = t.UnixNano() / int64(time.Millisecond)
+		}
+
+		for i, v := range current.Values() {
+			if math.IsNaN(v) {
+				switch fill {
+				case fillNone:
+					continue
+				case fillZero:
+					v = 0
+				}
+			}
+			dps[i] = append(dps[i], dataPoint{timestamp: timestamp, value: v})
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	var (
+		blockTags     = bl.Meta().Tags.Tags
+		aggregateTags = p.aggregateTags()
+		results       = make([]queryResult, 0, len(seriesMeta))
+	)
+	for i, meta := range seriesMeta {
+		if !hasValue(dps[i]) {
+			continue
+		}
+
+		tags := meta.Tags.AddTags(blockTags).WithoutName()
+		result := queryResult{
+			Metric:        p.query.Metric,
+			Tags:          make(map[string]string, tags.Len()),
+			AggregateTags: aggregateTags,
+			DPS:           dps[i],
+		}
+		for _, tag := range tags.Tags {
+			result.Tags[string(tag.Name)] = string(tag.Value)
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func hasValue(dps dataPoints) bool {
+	for _, dp := range dps {
+		if !math.IsNaN(dp.value) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseQueryRequest(r *http.Request) (QueryRequest, *xhttp.ParseError) {
+	var req QueryRequest
+	if r.Method == http.MethodPost {
+		if r.Body == nil {
+			err := errors.New("empty request body")
+			return QueryRequest{}, xhttp.NewParseError(err, http.StatusBadRequest)
+		}
+
+		defer r.Body.Close()
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return QueryRequest{}, xhttp.NewParseError(err, http.StatusBadRequest)
+		}
+	} else {
+		values := r.URL.Query()
+		req.Start = Time(values.Get(startParam))
+		req.End = Time(values.Get(endParam))
+		_, req.MsResolution = values[msResolutionParam]
+		for _, m := range values[metricQueryParam] {
+			q, err := parseMetricQuery(m)
+			if err != nil {
+				return QueryRequest{}, xhttp.NewParseError(err, http.StatusBadRequest)
+			}
+			req.Queries = append(req.Queries, q)
+		}
+	}
+
+	if err := req.Validate(); err != nil {
+		return QueryRequest{}, xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+
+	return req, nil
+}
+
+// AggregatorsHandler lists the supported aggregators.
+type AggregatorsHandler struct {
+	instrumentOpts instrument.Options
+}
+
+// NewAggregatorsHandler returns a new instance of the aggregators handler.
+func NewAggregatorsHandler(opts options.HandlerOptions) http.Handler {
+	return &AggregatorsHandler{instrumentOpts: opts.InstrumentOpts()}
+}
+
+func (h *AggregatorsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	names := make([]string, 0, len(aggregators)+1)
+	for name := range aggregators {
+		names = append(names, name)
+	}
+	names = append(names, noneAggregator)
+	names = append(names, "p50", "p75", "p90", "p95", "p99", "p999")
+	sort.Strings(names)
+
+	xhttp.WriteJSONResponse(w, names, h.instrumentOpts.Logger())
+}
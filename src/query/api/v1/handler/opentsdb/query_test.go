@@ -0,0 +1,187 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package opentsdb
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testQueryStart = time.Unix(1500000000, 0).Truncate(time.Minute)
+
+func newTestQueryHandler(t *testing.T) http.Handler {
+	tagOpts := models.NewTagOptions()
+	seriesTags := func(host string) models.Tags {
+		return models.NewTags(2, tagOpts).
+			SetName([]byte("sys.cpu")).
+			AddTag(models.Tag{Name: []byte("host"), Value: []byte(host)})
+	}
+
+	meta := block.Metadata{
+		Bounds: models.Bounds{
+			Start:    testQueryStart,
+			Duration: 3 * time.Minute,
+			StepSize: time.Minute,
+		},
+		Tags:           models.NewTags(0, tagOpts),
+		ResultMetadata: block.NewResultMetadata(),
+	}
+	seriesMeta := []block.SeriesMeta{
+		{Name: []byte("sys.cpu"), Tags: seriesTags("a")},
+		{Name: []byte("sys.cpu"), Tags: seriesTags("b")},
+	}
+	b := test.NewBlockFromValuesWithMetaAndSeriesMeta(meta, seriesMeta,
+		[][]float64{{1, 2, math.NaN()}, {3, 4, 5}})
+
+	store := mock.NewMockStorage()
+	store.SetFetchBlocksResult(block.Result{Blocks: []block.Block{b}}, nil)
+
+	instrumentOpts := instrument.NewOptions()
+	engine := executor.NewEngine(executor.NewEngineOptions().
+		SetStore(store).
+		SetLookbackDuration(time.Minute).
+		SetGlobalEnforcer(nil).
+		SetInstrumentOptions(instrumentOpts))
+
+	opts := options.EmptyHandlerOptions().
+		SetEngine(engine).
+		SetFetchOptionsBuilder(handleroptions.NewFetchOptionsBuilder(
+			handleroptions.FetchOptionsBuilderOptions{})).
+		SetTagOptions(tagOpts).
+		SetNowFn(func() time.Time { return testQueryStart.Add(10 * time.Minute) }).
+		SetInstrumentOpts(instrumentOpts)
+	return NewQueryHandler(opts)
+}
+
+func serveQuery(t *testing.T, h http.Handler, req *http.Request) []map[string]interface{} {
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	var results []map[string]interface{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &results))
+	return results
+}
+
+func unixKey(step int) string {
+	return strconv.FormatInt(
+		testQueryStart.Add(time.Duration(step)*time.Minute).Unix(), 10)
+}
+
+func TestQueryHandlerAggregate(t *testing.T) {
+	h := newTestQueryHandler(t)
+
+	values := url.Values{}
+	values.Set(startParam, unixKey(0))
+	values.Set(endParam, unixKey(2))
+	values.Set(metricQueryParam, "sum:sys.cpu")
+	req := httptest.NewRequest(http.MethodGet, QueryURL+"?"+values.Encode(), nil)
+
+	results := serveQuery(t, h, req)
+	require.Len(t, results, 1)
+	assert.Equal(t, "sys.cpu", results[0]["metric"])
+	assert.Equal(t, map[string]interface{}{}, results[0]["tags"])
+	assert.Equal(t, map[string]interface{}{
+		unixKey(0): 4.0,
+		unixKey(1): 6.0,
+		unixKey(2): 5.0,
+	}, results[0]["dps"])
+}
+
+func TestQueryHandlerNoAggregation(t *testing.T) {
+	h := newTestQueryHandler(t)
+
+	body := `{"start":` + unixKey(0) + `,"end":` + unixKey(2) + `,"queries":[` +
+		`{"aggregator":"none","metric":"sys.cpu","tags":{"host":"*"}}]}`
+	req := httptest.NewRequest(http.MethodPost, QueryURL, strings.NewReader(body))
+
+	results := serveQuery(t, h, req)
+	require.Len(t, results, 2)
+
+	byHost := make(map[interface{}]interface{})
+	for _, result := range results {
+		tags := result["tags"].(map[string]interface{})
+		byHost[tags["host"]] = result["dps"]
+	}
+	assert.Equal(t, map[interface{}]interface{}{
+		"a": map[string]interface{}{unixKey(0): 1.0, unixKey(1): 2.0},
+		"b": map[string]interface{}{unixKey(0): 3.0, unixKey(1): 4.0, unixKey(2): 5.0},
+	}, byHost)
+}
+
+func TestQueryHandlerInvalid(t *testing.T) {
+	h := newTestQueryHandler(t)
+
+	for _, query := range []string{
+		"start=1h-ago",
+		"start=1h-ago&m=sum",
+		"start=foo&m=sum:sys.cpu",
+		"start=1h-ago&end=2h-ago&m=sum:sys.cpu",
+		"start=1h-ago&m=median:sys.cpu",
+	} {
+		req := httptest.NewRequest(http.MethodGet, QueryURL+"?"+query, nil)
+		recorder := httptest.NewRecorder()
+		h.ServeHTTP(recorder, req)
+		assert.Equal(t, http.StatusBadRequest, recorder.Code, query)
+	}
+}
+
+func TestAggregatorsHandler(t *testing.T) {
+	h := NewAggregatorsHandler(options.EmptyHandlerOptions())
+	req := httptest.NewRequest(AggregatorsHTTPMethod, AggregatorsURL, nil)
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var names []string
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &names))
+	assert.Contains(t, names, "sum")
+	assert.Contains(t, names, "none")
+	assert.Contains(t, names, "p99")
+}
+
+func TestDataPointsMarshalJSON(t *testing.T) {
+	data, err := json.Marshal(dataPoints{
+		{timestamp: 2, value: 1.5},
+		{timestamp: 1, value: math.NaN()},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `{"2":1.5,"1":null}`, string(data))
+}
@@ -0,0 +1,209 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package opentsdb
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/m3db/m3/src/query/functions/temporal"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/ts"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+const (
+	// RateType calculates the OpenTSDB rate, the change between consecutive
+	// values per second.
+	RateType = "opentsdb_rate"
+)
+
+// downsampleFn aggregates the values of a downsample interval.
+type downsampleFn func(values []float64) float64
+
+// newDownsampleFn returns the function aggregating values of a downsample
+// interval for the OpenTSDB downsample aggregator.
+func newDownsampleFn(aggregator string) (downsampleFn, bool) {
+	if q, ok := parsePercentile(aggregator); ok {
+		return func(values []float64) float64 {
+			return quantile(q, values)
+		}, true
+	}
+
+	switch downsamplers[aggregator] {
+	case temporal.SumType:
+		return sum, true
+	case temporal.MinType:
+		return func(values []float64) float64 {
+			result := values[0]
+			for _, v := range values[1:] {
+				result = math.Min(result, v)
+			}
+			return result
+		}, true
+	case temporal.MaxType:
+		return func(values []float64) float64 {
+			result := values[0]
+			for _, v := range values[1:] {
+				result = math.Max(result, v)
+			}
+			return result
+		}, true
+	case temporal.AvgType:
+		return avg, true
+	case temporal.StdDevType:
+		return func(values []float64) float64 {
+			mean := avg(values)
+			var squares float64
+			for _, v := range values {
+				squares += (v - mean) * (v - mean)
+			}
+			return math.Sqrt(squares / float64(len(values)))
+		}, true
+	case temporal.CountType:
+		return func(values []float64) float64 {
+			return float64(len(values))
+		}, true
+	}
+	return nil, false
+}
+
+func sum(values []float64) float64 {
+	var result float64
+	for _, v := range values {
+		result += v
+	}
+	return result
+}
+
+func avg(values []float64) float64 {
+	return sum(values) / float64(len(values))
+}
+
+// quantile returns the q quantile of the values, interpolating linearly
+// between the closest ranks.
+func quantile(q float64, values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	rank := q * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	weight := rank - float64(lower)
+	return sorted[lower]*(1-weight) + sorted[upper]*weight
+}
+
+// newRateOp returns a temporal operation calculating the OpenTSDB rate at
+// each step. When downsampled the rate is between the values of the current
+// and the previous downsample interval, each aggregated with downsample,
+// otherwise it is between the last two values of the previous two steps. The
+// operation looks back two steps, which is returned with the operation.
+func newRateOp(
+	step time.Duration,
+	counter bool,
+	downsample downsampleFn,
+) (parser.Params, time.Duration, error) {
+	rateFn := pointRateFn(counter)
+	if downsample != nil {
+		rateFn = downsampledRateFn(step, counter, downsample)
+	}
+
+	lookback := 2 * step
+	op, err := temporal.NewRateOpWithProcessor([]interface{}{lookback}, RateType,
+		temporal.RateProcessor{IsRate: true, IsCounter: counter, RateFn: rateFn})
+	return op, lookback, err
+}
+
+// pointRate returns the change from the previous to the current value per
+// second. A decrease of a counter is a counter reset, the counter having
+// increased from zero to the current value.
+func pointRate(
+	previous, current float64,
+	interval time.Duration,
+	counter bool,
+) float64 {
+	if interval <= 0 {
+		return math.NaN()
+	}
+
+	delta := current - previous
+	if counter && delta < 0 {
+		delta = current
+	}
+	return delta / interval.Seconds()
+}
+
+func pointRateFn(counter bool) temporal.RateFn {
+	return func(
+		datapoints ts.Datapoints,
+		_ bool, _ bool, _ xtime.UnixNano, _ xtime.UnixNano, _ time.Duration,
+	) float64 {
+		var last, previous *ts.Datapoint
+		for i := len(datapoints) - 1; i >= 0 && previous == nil; i-- {
+			if math.IsNaN(datapoints[i].Value) {
+				continue
+			}
+			if last == nil {
+				last = &datapoints[i]
+			} else {
+				previous = &datapoints[i]
+			}
+		}
+
+		if previous == nil {
+			return math.NaN()
+		}
+		return pointRate(previous.Value, last.Value,
+			last.Timestamp.Sub(previous.Timestamp), counter)
+	}
+}
+
+func downsampledRateFn(
+	step time.Duration,
+	counter bool,
+	downsample downsampleFn,
+) temporal.RateFn {
+	return func(
+		datapoints ts.Datapoints,
+		_ bool, _ bool, _ xtime.UnixNano, rangeEnd xtime.UnixNano, _ time.Duration,
+	) float64 {
+		var (
+			currentStart      = rangeEnd - xtime.UnixNano(step)
+			previous, current []float64
+		)
+		for _, dp := range datapoints {
+			if math.IsNaN(dp.Value) {
+				continue
+			}
+			if xtime.ToUnixNano(dp.Timestamp) < currentStart {
+				previous = append(previous, dp.Value)
+			} else {
+				current = append(current, dp.Value)
+			}
+		}
+
+		if len(previous) == 0 || len(current) == 0 {
+			return math.NaN()
+		}
+		return pointRate(downsample(previous), downsample(current), step, counter)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package opentsdb
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/ts"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDatapoints(start time.Time, interval time.Duration, values ...float64) ts.Datapoints {
+	dps := make(ts.Datapoints, 0, len(values))
+	for i, v := range values {
+		dps = append(dps, ts.Datapoint{
+			Timestamp: start.Add(time.Duration(i) * interval),
+			Value:     v,
+		})
+	}
+	return dps
+}
+
+func TestPointRate(t *testing.T) {
+	var (
+		start = time.Unix(0, 0)
+		end   = xtime.ToUnixNano(start.Add(time.Minute))
+		dps   = testDatapoints(start, 10*time.Second, 1, 5, 20, math.NaN())
+	)
+
+	// The change between the last two values, not a regression over all.
+	rate := pointRateFn(false)(dps, true, false, 0, end, time.Minute)
+	assert.Equal(t, 1.5, rate)
+
+	// Gauges may decrease, a decrease of a counter is a reset.
+	dps = testDatapoints(start, 10*time.Second, 20, 10)
+	assert.Equal(t, -1.0, pointRateFn(false)(dps, true, false, 0, end, time.Minute))
+	assert.Equal(t, 1.0, pointRateFn(true)(dps, true, true, 0, end, time.Minute))
+
+	dps = testDatapoints(start, 10*time.Second, 20)
+	assert.True(t, math.IsNaN(pointRateFn(false)(dps, true, false, 0, end, time.Minute)))
+}
+
+func TestDownsampledRate(t *testing.T) {
+	max, ok := newDownsampleFn("max")
+	require.True(t, ok)
+
+	var (
+		start = time.Unix(0, 0)
+		end   = xtime.ToUnixNano(start.Add(2 * time.Minute))
+		// Two one minute downsample intervals with values every 20s.
+		dps    = testDatapoints(start, 20*time.Second, 30, 90, 60, 120, 150, 100)
+		rateFn = downsampledRateFn(time.Minute, false, max)
+	)
+
+	// The max of each interval is 90 then 150.
+	assert.Equal(t, 1.0, rateFn(dps, true, false, 0, end, 2*time.Minute))
+
+	// No value in the previous interval.
+	assert.True(t, math.IsNaN(rateFn(dps[3:], true, false, 0, end, 2*time.Minute)))
+}
+
+func TestNewDownsampleFn(t *testing.T) {
+	values := []float64{4, 1, 3, 2}
+	for aggregator, expected := range map[string]float64{
+		"sum":   10,
+		"min":   1,
+		"max":   4,
+		"avg":   2.5,
+		"dev":   math.Sqrt(1.25),
+		"count": 4,
+		"p50":   2.5,
+		"p75":   3.25,
+	} {
+		fn, ok := newDownsampleFn(aggregator)
+		require.True(t, ok, aggregator)
+		assert.InDelta(t, expected, fn(values), 1e-9, aggregator)
+	}
+
+	_, ok := newDownsampleFn("median")
+	assert.False(t, ok)
+}
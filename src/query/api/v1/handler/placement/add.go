@@ -81,6 +81,18 @@ func (h *AddHandler) ServeHTTP(
 		return
 	}
 
+	var curPlacement placement.Placement
+	req.DryRun = req.DryRun || isDryRun(r)
+	if req.DryRun {
+		p, err := h.currentPlacement(svc, r, h.nowFn())
+		if err != nil {
+			logger.Error("unable to get current placement", zap.Error(err))
+			xhttp.Error(w, err, http.StatusInternalServerError)
+			return
+		}
+		curPlacement = p
+	}
+
 	placement, err := h.Add(svc, r, req)
 	if err != nil {
 		status := http.StatusInternalServerError
@@ -92,6 +104,11 @@ func (h *AddHandler) ServeHTTP(
 		return
 	}
 
+	if req.DryRun {
+		h.writeDryRunResponse(svc, w, r, logger, curPlacement, placement)
+		return
+	}
+
 	placementProto, err := placement.Proto()
 	if err != nil {
 		logger.Error("unable to get placement protobuf", zap.Error(err))
@@ -130,6 +147,9 @@ func (h *AddHandler) Add(
 
 	serviceOpts := handleroptions.NewServiceOptions(svc, httpReq.Header,
 		h.m3AggServiceOptions)
+	if req.DryRun {
+		serviceOpts.DryRun = true
+	}
 	var validateFn placement.ValidateFn
 	if !req.Force {
 		validateFn = validateAllAvailable
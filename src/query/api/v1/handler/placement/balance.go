@@ -0,0 +1,187 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package placement
+
+import (
+	"errors"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/placement"
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/generated/proto/admin"
+	"github.com/m3db/m3/src/query/util/logging"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"go.uber.org/zap"
+)
+
+const (
+	// BalanceHTTPMethod is the HTTP method for the the balance endpoint.
+	BalanceHTTPMethod = http.MethodPost
+
+	balancePathName = "balance"
+)
+
+var (
+	// M3DBBalanceURL is the url for the m3db balance handler (method POST).
+	M3DBBalanceURL = path.Join(handler.RoutePrefixV1,
+		M3DBServicePlacementPathName, balancePathName)
+
+	// M3AggBalanceURL is the url for the m3aggregator balance handler
+	// (method POST).
+	M3AggBalanceURL = path.Join(handler.RoutePrefixV1,
+		M3AggServicePlacementPathName, balancePathName)
+
+	errBalanceStateless        = errors.New("cannot balance shards of a stateless service")
+	errBalanceNegativeMaxMoves = errors.New("max_moves must not be negative")
+)
+
+// BalanceHandler is the handler for placement balancing.
+type BalanceHandler Handler
+
+// NewBalanceHandler returns a new BalanceHandler.
+func NewBalanceHandler(opts HandlerOptions) *BalanceHandler {
+	return &BalanceHandler{HandlerOptions: opts, nowFn: time.Now}
+}
+
+func (h *BalanceHandler) ServeHTTP(
+	svc handleroptions.ServiceNameAndDefaults,
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	ctx := r.Context()
+	logger := logging.WithContext(ctx, h.instrumentOptions)
+
+	if isStateless(svc.ServiceName) {
+		xhttp.Error(w, errBalanceStateless, http.StatusBadRequest)
+		return
+	}
+
+	req, pErr := h.parseRequest(r)
+	if pErr != nil {
+		xhttp.Error(w, pErr.Inner(), pErr.Code())
+		return
+	}
+
+	var curPlacement placement.Placement
+	req.DryRun = req.DryRun || isDryRun(r)
+	if req.DryRun {
+		p, err := h.currentPlacement(svc, r, h.nowFn())
+		if err != nil {
+			logger.Error("unable to get current placement", zap.Error(err))
+			xhttp.Error(w, err, http.StatusInternalServerError)
+			return
+		}
+		curPlacement = p
+	}
+
+	placement, err := h.Balance(svc, r, req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if _, ok := err.(unsafeAddError); ok {
+			status = http.StatusBadRequest
+		}
+		logger.Error("unable to balance placement", zap.Error(err))
+		xhttp.Error(w, err, status)
+		return
+	}
+
+	if req.DryRun {
+		h.writeDryRunResponse(svc, w, r, logger, curPlacement, placement)
+		return
+	}
+
+	placementProto, err := placement.Proto()
+	if err != nil {
+		logger.Error("unable to get placement protobuf", zap.Error(err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	resp := &admin.PlacementGetResponse{
+		Placement: placementProto,
+		Version:   int32(placement.Version()),
+	}
+
+	xhttp.WriteProtoMsgJSONResponse(w, resp, logger)
+}
+
+func (h *BalanceHandler) parseRequest(r *http.Request) (*admin.PlacementBalanceRequest, *xhttp.ParseError) {
+	defer r.Body.Close()
+
+	req := &admin.PlacementBalanceRequest{}
+	if err := jsonpb.Unmarshal(r.Body, req); err != nil {
+		return nil, xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+
+	if req.MaxMoves < 0 {
+		return nil, xhttp.NewParseError(errBalanceNegativeMaxMoves, http.StatusBadRequest)
+	}
+
+	return req, nil
+}
+
+// Balance moves at most req.MaxMoves shards in the placement so the number
+// of shards on each instance is proportional to its weight.
+func (h *BalanceHandler) Balance(
+	svc handleroptions.ServiceNameAndDefaults,
+	httpReq *http.Request,
+	req *admin.PlacementBalanceRequest,
+) (placement.Placement, error) {
+	serviceOpts := handleroptions.NewServiceOptions(svc,
+		httpReq.Header, h.m3AggServiceOptions)
+	if req.DryRun {
+		serviceOpts.DryRun = true
+	}
+	service, algo, err := ServiceWithAlgo(h.clusterClient,
+		serviceOpts, h.nowFn(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Force {
+		return service.Balance(int(req.MaxMoves))
+	}
+
+	curPlacement, err := service.Placement()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateAllAvailable(curPlacement); err != nil {
+		return nil, err
+	}
+
+	// We use the algorithm directly so that we can CheckAndSet on the placement
+	// to make "atomic" forward progress.
+	newPlacement, err := algo.Balance(curPlacement, int(req.MaxMoves))
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure the placement we're updating is still the one on which we validated
+	// all shards are available.
+	return service.CheckAndSet(newPlacement, curPlacement.Version())
+}
@@ -80,6 +80,7 @@ type HandlerOptions struct {
 
 	m3AggServiceOptions *handleroptions.M3AggServiceOptions
 	instrumentOptions   instrument.Options
+	shardSizeEstimator  ShardSizeEstimator
 }
 
 // NewHandlerOptions is the constructor function for HandlerOptions.
@@ -100,6 +101,13 @@ func NewHandlerOptions(
 	}, nil
 }
 
+// SetShardSizeEstimator sets the estimator of the M3DB shard sizes used to
+// estimate the bytes moved by a placement change in a dry run.
+func (o HandlerOptions) SetShardSizeEstimator(value ShardSizeEstimator) HandlerOptions {
+	o.shardSizeEstimator = value
+	return o
+}
+
 // Handler represents a generic handler for placement endpoints.
 type Handler struct {
 	HandlerOptions
@@ -157,7 +165,9 @@ func ServiceWithAlgo(
 	pOpts := placement.NewOptions().
 		SetValidZone(opts.ServiceZone).
 		SetIsSharded(true).
-		SetDryrun(opts.DryRun)
+		SetDryrun(opts.DryRun).
+		SetHistoryLimit(opts.HistoryLimit).
+		SetChangeMetadata(opts.ChangeMetadata)
 
 	switch opts.ServiceName {
 	case handleroptions.M3CoordinatorServiceName:
@@ -302,6 +312,48 @@ func RegisterRoutes(
 	r.HandleFunc(M3AggReplaceURL, replaceFn).Methods(ReplaceHTTPMethod)
 	r.HandleFunc(M3CoordinatorReplaceURL, replaceFn).Methods(ReplaceHTTPMethod)
 
+	// Remove replica
+	var (
+		removeReplicaHandler = NewRemoveReplicaHandler(opts)
+		removeReplicaFn      = applyMiddleware(removeReplicaHandler.ServeHTTP, defaults, opts.instrumentOptions)
+	)
+	r.HandleFunc(M3DBRemoveReplicaURL, removeReplicaFn).Methods(RemoveReplicaHTTPMethod)
+	r.HandleFunc(M3AggRemoveReplicaURL, removeReplicaFn).Methods(RemoveReplicaHTTPMethod)
+	r.HandleFunc(M3CoordinatorRemoveReplicaURL, removeReplicaFn).Methods(RemoveReplicaHTTPMethod)
+
+	// Split shards
+	var (
+		splitShardsHandler = NewSplitShardsHandler(opts)
+		splitShardsFn      = applyMiddleware(splitShardsHandler.ServeHTTP, defaults, opts.instrumentOptions)
+	)
+	r.HandleFunc(M3DBSplitShardsURL, splitShardsFn).Methods(SplitShardsHTTPMethod)
+
+	// Balance
+	var (
+		balanceHandler = NewBalanceHandler(opts)
+		balanceFn      = applyMiddleware(balanceHandler.ServeHTTP, defaults, opts.instrumentOptions)
+	)
+	r.HandleFunc(M3DBBalanceURL, balanceFn).Methods(BalanceHTTPMethod)
+	r.HandleFunc(M3AggBalanceURL, balanceFn).Methods(BalanceHTTPMethod)
+
+	// History
+	var (
+		historyHandler = NewHistoryHandler(opts)
+		historyFn      = applyMiddleware(historyHandler.ServeHTTP, defaults, opts.instrumentOptions)
+	)
+	r.HandleFunc(M3DBHistoryURL, historyFn).Methods(HistoryHTTPMethod)
+	r.HandleFunc(M3AggHistoryURL, historyFn).Methods(HistoryHTTPMethod)
+	r.HandleFunc(M3CoordinatorHistoryURL, historyFn).Methods(HistoryHTTPMethod)
+
+	// Rollback
+	var (
+		rollbackHandler = NewRollbackHandler(opts)
+		rollbackFn      = applyMiddleware(rollbackHandler.ServeHTTP, defaults, opts.instrumentOptions)
+	)
+	r.HandleFunc(M3DBRollbackURL, rollbackFn).Methods(RollbackHTTPMethod)
+	r.HandleFunc(M3AggRollbackURL, rollbackFn).Methods(RollbackHTTPMethod)
+	r.HandleFunc(M3CoordinatorRollbackURL, rollbackFn).Methods(RollbackHTTPMethod)
+
 	// Set
 	var (
 		setHandler = NewSetHandler(opts)
@@ -93,6 +93,9 @@ func (h *DeleteHandler) ServeHTTP(
 		force = r.FormValue(placementForceVar) == "true"
 		opts  = handleroptions.NewServiceOptions(svc, r.Header, h.m3AggServiceOptions)
 	)
+	if isDryRun(r) {
+		opts.DryRun = true
+	}
 
 	service, algo, err := ServiceWithAlgo(h.clusterClient, opts, h.nowFn(), nil)
 	if err != nil {
@@ -159,6 +162,11 @@ func (h *DeleteHandler) ServeHTTP(
 		}
 	}
 
+	if opts.DryRun {
+		h.writeDryRunResponse(svc, w, r, logger, curPlacement, newPlacement)
+		return
+	}
+
 	// Now need to delete aggregator related keys (e.g. for shardsets) if required.
 	if svc.ServiceName == handleroptions.M3AggregatorServiceName {
 		shardSetID := instance.ShardSetID()
@@ -0,0 +1,279 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package placement
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/placement"
+	"github.com/m3db/m3/src/cluster/shard"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/generated/proto/admin"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+)
+
+const (
+	placementDryRunVar          = "dryRun"
+	placementEstimateBytesVar   = "estimateBytes"
+	placementEstimateTimeoutVar = "estimateTimeout"
+
+	defaultShardSizeEstimateTimeout = 10 * time.Second
+)
+
+// ShardSizeEstimator estimates the size in bytes of the data held by shards,
+// used to estimate the bytes moved by a placement change.
+type ShardSizeEstimator interface {
+	// ShardSizes returns the estimated size in bytes of each of the given
+	// shards, shards whose size is unknown are omitted. Estimators stop
+	// estimating and return the context error once the context is done.
+	ShardSizes(ctx context.Context, shards []uint32) (map[uint32]int64, error)
+}
+
+// isDryRun returns whether the request asks for a dry run, either through
+// the dry run header or the dry run query parameter.
+func isDryRun(r *http.Request) bool {
+	return strings.TrimSpace(r.Header.Get(handleroptions.HeaderDryRun)) == "true" ||
+		r.URL.Query().Get(placementDryRunVar) == "true"
+}
+
+// shardSizeEstimateTimeout returns whether the request asks for the bytes moved
+// by a dry run to be estimated, and how long the estimate may take. Estimating
+// shard sizes reads the block metadata of the moved shards from the dbnodes,
+// so it is only done when asked for.
+func shardSizeEstimateTimeout(r *http.Request) (time.Duration, bool, error) {
+	if r.URL.Query().Get(placementEstimateBytesVar) != "true" {
+		return 0, false, nil
+	}
+	str := r.URL.Query().Get(placementEstimateTimeoutVar)
+	if str == "" {
+		return defaultShardSizeEstimateTimeout, true, nil
+	}
+	timeout, err := time.ParseDuration(str)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid %s: %v", placementEstimateTimeoutVar, err)
+	}
+	if timeout <= 0 {
+		return 0, false, fmt.Errorf("invalid %s: must be positive", placementEstimateTimeoutVar)
+	}
+	return timeout, true, nil
+}
+
+// currentPlacement returns the placement a dry run is diffed against.
+func (h HandlerOptions) currentPlacement(
+	svc handleroptions.ServiceNameAndDefaults,
+	r *http.Request,
+	now time.Time,
+) (placement.Placement, error) {
+	opts := handleroptions.NewServiceOptions(svc, r.Header, h.m3AggServiceOptions)
+	service, err := Service(h.clusterClient, opts, now, nil)
+	if err != nil {
+		return nil, err
+	}
+	return service.Placement()
+}
+
+// writeDryRunResponse writes the placement resulting from a dry run along
+// with a summary of the changes to the current placement.
+func (h HandlerOptions) writeDryRunResponse(
+	svc handleroptions.ServiceNameAndDefaults,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zap.Logger,
+	curPlacement placement.Placement,
+	newPlacement placement.Placement,
+) {
+	estimateTimeout, estimate, err := shardSizeEstimateTimeout(r)
+	if err != nil {
+		logger.Error("unable to parse request", zap.Error(err))
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	placementProto, err := newPlacement.Proto()
+	if err != nil {
+		logger.Error("unable to get placement protobuf", zap.Error(err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	diff := newPlacementDiff(curPlacement, newPlacement, nil)
+	if estimate && h.shardSizeEstimator != nil && svc.ServiceName == handleroptions.M3DBServiceName {
+		// Only the shards that move are estimated, and failing to estimate
+		// them in time only leaves the byte estimates out of the summary.
+		ctx, cancel := context.WithTimeout(r.Context(), estimateTimeout)
+		sizes, err := h.shardSizeEstimator.ShardSizes(ctx, movedShards(diff))
+		cancel()
+		if err != nil {
+			logger.Warn("unable to estimate shard sizes", zap.Error(err))
+		} else {
+			diff = newPlacementDiff(curPlacement, newPlacement, sizes)
+		}
+	}
+
+	resp := &admin.PlacementDryRunResponse{
+		Placement: placementProto,
+		Version:   int32(newPlacement.Version()),
+		Diff:      diff,
+	}
+
+	xhttp.WriteProtoMsgJSONResponse(w, resp, logger)
+}
+
+// newPlacementDiff summarizes the shards gained and lost by every instance and
+// isolation group between two placements. A shard is owned by an instance
+// unless it is leaving it, so initializing shards count as gained.
+func newPlacementDiff(
+	before placement.Placement,
+	after placement.Placement,
+	sizes map[uint32]int64,
+) *admin.PlacementDiff {
+	var (
+		diff         = &admin.PlacementDiff{}
+		ownedBefore  = ownedShardsByInstance(before)
+		ownedAfter   = ownedShardsByInstance(after)
+		groups       = make(map[string]*admin.PlacementIsolationGroupBalance)
+		instanceIDs  = make([]string, 0, len(ownedAfter))
+		totalBefore  int
+		totalAfter   int
+		groupBalance = func(instance placement.Instance) *admin.PlacementIsolationGroupBalance {
+			group, ok := groups[instance.IsolationGroup()]
+			if !ok {
+				group = &admin.PlacementIsolationGroupBalance{IsolationGroup: instance.IsolationGroup()}
+				groups[instance.IsolationGroup()] = group
+			}
+			return group
+		}
+	)
+
+	for id := range ownedBefore {
+		if _, ok := ownedAfter[id]; !ok {
+			instanceIDs = append(instanceIDs, id)
+		}
+	}
+	for id := range ownedAfter {
+		instanceIDs = append(instanceIDs, id)
+	}
+	sort.Strings(instanceIDs)
+
+	for _, id := range instanceIDs {
+		instance, ok := after.Instance(id)
+		if !ok {
+			instance, _ = before.Instance(id)
+		}
+
+		var (
+			shardsBefore = ownedBefore[id]
+			shardsAfter  = ownedAfter[id]
+			instanceDiff = &admin.PlacementInstanceDiff{
+				Id:             id,
+				IsolationGroup: instance.IsolationGroup(),
+			}
+		)
+		for shardID := range shardsAfter {
+			if _, ok := shardsBefore[shardID]; !ok {
+				instanceDiff.ShardsAdded = append(instanceDiff.ShardsAdded, shardID)
+				instanceDiff.EstimatedBytesAdded += sizes[shardID]
+			}
+		}
+		for shardID := range shardsBefore {
+			if _, ok := shardsAfter[shardID]; !ok {
+				instanceDiff.ShardsRemoved = append(instanceDiff.ShardsRemoved, shardID)
+				instanceDiff.EstimatedBytesRemoved += sizes[shardID]
+			}
+		}
+
+		group := groupBalance(instance)
+		group.ShardsBefore += int32(len(shardsBefore))
+		group.ShardsAfter += int32(len(shardsAfter))
+		totalBefore += len(shardsBefore)
+		totalAfter += len(shardsAfter)
+
+		if len(instanceDiff.ShardsAdded) == 0 && len(instanceDiff.ShardsRemoved) == 0 {
+			continue
+		}
+		sortShardIDs(instanceDiff.ShardsAdded)
+		sortShardIDs(instanceDiff.ShardsRemoved)
+		diff.ShardsMoved += int32(len(instanceDiff.ShardsAdded))
+		diff.EstimatedBytesMoved += instanceDiff.EstimatedBytesAdded
+		diff.Instances = append(diff.Instances, instanceDiff)
+	}
+
+	for _, group := range groups {
+		if totalBefore > 0 {
+			group.LoadBefore = float64(group.ShardsBefore) / float64(totalBefore)
+		}
+		if totalAfter > 0 {
+			group.LoadAfter = float64(group.ShardsAfter) / float64(totalAfter)
+		}
+		diff.IsolationGroups = append(diff.IsolationGroups, group)
+	}
+	sort.Slice(diff.IsolationGroups, func(i, j int) bool {
+		return diff.IsolationGroups[i].IsolationGroup < diff.IsolationGroups[j].IsolationGroup
+	})
+
+	return diff
+}
+
+func ownedShardsByInstance(p placement.Placement) map[string]map[uint32]struct{} {
+	owned := make(map[string]map[uint32]struct{}, p.NumInstances())
+	for _, instance := range p.Instances() {
+		shards := make(map[uint32]struct{}, instance.Shards().NumShards())
+		for _, s := range instance.Shards().All() {
+			if s.State() == shard.Leaving {
+				continue
+			}
+			shards[s.ID()] = struct{}{}
+		}
+		owned[instance.ID()] = shards
+	}
+	return owned
+}
+
+func movedShards(diff *admin.PlacementDiff) []uint32 {
+	var (
+		seen  = make(map[uint32]struct{})
+		moved []uint32
+	)
+	for _, instance := range diff.Instances {
+		for _, ids := range [][]uint32{instance.ShardsAdded, instance.ShardsRemoved} {
+			for _, id := range ids {
+				if _, ok := seen[id]; ok {
+					continue
+				}
+				seen[id] = struct{}{}
+				moved = append(moved, id)
+			}
+		}
+	}
+	sortShardIDs(moved)
+	return moved
+}
+
+func sortShardIDs(ids []uint32) {
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+}
@@ -0,0 +1,301 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package placement
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/cluster/kv/mem"
+	"github.com/m3db/m3/src/cluster/placement"
+	"github.com/m3db/m3/src/cluster/placement/service"
+	"github.com/m3db/m3/src/cluster/placement/storage"
+	"github.com/m3db/m3/src/cluster/services"
+	"github.com/m3db/m3/src/cluster/shard"
+	"github.com/m3db/m3/src/cmd/services/m3query/config"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/generated/proto/admin"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testShardSizeEstimator map[uint32]int64
+
+func (e testShardSizeEstimator) ShardSizes(
+	ctx context.Context,
+	shards []uint32,
+) (map[uint32]int64, error) {
+	return e, nil
+}
+
+// blockingShardSizeEstimator only returns once the estimate times out.
+type blockingShardSizeEstimator struct{}
+
+func (e blockingShardSizeEstimator) ShardSizes(
+	ctx context.Context,
+	shards []uint32,
+) (map[uint32]int64, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func newDryRunTestInstance(id, isolationGroup string, shards ...shard.Shard) placement.Instance {
+	return placement.NewInstance().
+		SetID(id).
+		SetIsolationGroup(isolationGroup).
+		SetZone(handleroptions.DefaultServiceZone).
+		SetEndpoint(id).
+		SetWeight(1).
+		SetShards(shard.NewShards(shards))
+}
+
+func newDryRunTestPlacement() placement.Placement {
+	return placement.NewPlacement().
+		SetInstances([]placement.Instance{
+			newDryRunTestInstance("A", "g1",
+				shard.NewShard(0).SetState(shard.Available),
+				shard.NewShard(1).SetState(shard.Available)),
+			newDryRunTestInstance("B", "g2",
+				shard.NewShard(0).SetState(shard.Available)),
+			newDryRunTestInstance("C", "g3",
+				shard.NewShard(1).SetState(shard.Available)),
+		}).
+		SetShards([]uint32{0, 1}).
+		SetReplicaFactor(2).
+		SetIsSharded(true)
+}
+
+func TestNewPlacementDiff(t *testing.T) {
+	before := newDryRunTestPlacement()
+	after := before.Clone()
+	instA, ok := after.Instance("A")
+	require.True(t, ok)
+	instA.Shards().Add(shard.NewShard(1).SetState(shard.Leaving))
+	after = after.SetInstances(append(after.Instances(),
+		newDryRunTestInstance("D", "g1",
+			shard.NewShard(1).SetState(shard.Initializing).SetSourceID("A"))))
+
+	diff := newPlacementDiff(before, after, map[uint32]int64{0: 10, 1: 100})
+	assert.Equal(t, int32(1), diff.ShardsMoved)
+	assert.Equal(t, int64(100), diff.EstimatedBytesMoved)
+	assert.Equal(t, []*admin.PlacementInstanceDiff{
+		{
+			Id:                    "A",
+			IsolationGroup:        "g1",
+			ShardsRemoved:         []uint32{1},
+			EstimatedBytesRemoved: 100,
+		},
+		{
+			Id:                  "D",
+			IsolationGroup:      "g1",
+			ShardsAdded:         []uint32{1},
+			EstimatedBytesAdded: 100,
+		},
+	}, diff.Instances)
+	assert.Equal(t, []*admin.PlacementIsolationGroupBalance{
+		{IsolationGroup: "g1", ShardsBefore: 2, ShardsAfter: 2, LoadBefore: 0.5, LoadAfter: 0.5},
+		{IsolationGroup: "g2", ShardsBefore: 1, ShardsAfter: 1, LoadBefore: 0.25, LoadAfter: 0.25},
+		{IsolationGroup: "g3", ShardsBefore: 1, ShardsAfter: 1, LoadBefore: 0.25, LoadAfter: 0.25},
+	}, diff.IsolationGroups)
+
+	diff = newPlacementDiff(before, before, nil)
+	assert.Equal(t, int32(0), diff.ShardsMoved)
+	assert.Empty(t, diff.Instances)
+}
+
+// setupDryRunPlacementTest returns a client whose placement services share a
+// single store holding the given placement.
+func setupDryRunPlacementTest(
+	t *testing.T,
+	ctrl *gomock.Controller,
+	p placement.Placement,
+) (*client.MockClient, placement.Storage) {
+	var (
+		store      = mem.NewStore()
+		mockClient = client.NewMockClient(ctrl)
+		mockSvcs   = services.NewMockServices(ctrl)
+	)
+	ps := storage.NewPlacementStorage(store, "", placement.NewOptions())
+	_, err := ps.Set(p)
+	require.NoError(t, err)
+
+	mockClient.EXPECT().Services(gomock.Any()).Return(mockSvcs, nil).AnyTimes()
+	mockSvcs.EXPECT().PlacementService(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ interface{}, opts placement.Options) (placement.Service, error) {
+			return service.NewPlacementService(
+				storage.NewPlacementStorage(store, "", opts), opts), nil
+		}).AnyTimes()
+	return mockClient, ps
+}
+
+func TestPlacementAddHandler_DryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient, ps := setupDryRunPlacementTest(t, ctrl, newDryRunTestPlacement())
+	handlerOpts, err := NewHandlerOptions(mockClient, config.Configuration{}, nil, instrument.NewOptions())
+	require.NoError(t, err)
+	handler := NewAddHandler(handlerOpts.SetShardSizeEstimator(testShardSizeEstimator{0: 10, 1: 100}))
+	handler.nowFn = func() time.Time { return time.Unix(0, 0) }
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(AddHTTPMethod, M3DBAddURL+"?estimateBytes=true", strings.NewReader(
+		`{"dryRun": true, "instances":[{"id": "D","isolation_group": "g4","zone": "embedded","weight": 1,"endpoint": "D"}]}`))
+	svcDefaults := handleroptions.ServiceNameAndDefaults{
+		ServiceName: handleroptions.M3DBServiceName,
+	}
+	handler.ServeHTTP(svcDefaults, w, req)
+
+	resp := w.Result()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var dryRunResp admin.PlacementDryRunResponse
+	require.NoError(t, jsonpb.Unmarshal(resp.Body, &dryRunResp))
+	_, ok := dryRunResp.Placement.Instances["D"]
+	assert.True(t, ok)
+	assert.True(t, dryRunResp.Diff.ShardsMoved > 0)
+	require.True(t, len(dryRunResp.Diff.Instances) > 1)
+	added := dryRunResp.Diff.Instances[len(dryRunResp.Diff.Instances)-1]
+	assert.Equal(t, "D", added.Id)
+	assert.Equal(t, dryRunResp.Diff.ShardsMoved, int32(len(added.ShardsAdded)))
+	assert.Equal(t, added.EstimatedBytesAdded, dryRunResp.Diff.EstimatedBytesMoved)
+	assert.True(t, added.EstimatedBytesAdded > 0)
+
+	// The dry run is not persisted.
+	p, err := ps.Placement()
+	require.NoError(t, err)
+	assert.Equal(t, 3, p.NumInstances())
+}
+
+func TestPlacementAddHandler_DryRunEstimateBytes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name           string
+		query          string
+		estimator      ShardSizeEstimator
+		expectedStatus int
+		expectEstimate bool
+	}{
+		{
+			name:           "not requested",
+			estimator:      testShardSizeEstimator{0: 10, 1: 100},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "requested",
+			query:          "?estimateBytes=true&estimateTimeout=1m",
+			estimator:      testShardSizeEstimator{0: 10, 1: 100},
+			expectedStatus: http.StatusOK,
+			expectEstimate: true,
+		},
+		{
+			name:           "timed out",
+			query:          "?estimateBytes=true&estimateTimeout=10ms",
+			estimator:      blockingShardSizeEstimator{},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid timeout",
+			query:          "?estimateBytes=true&estimateTimeout=-1s",
+			estimator:      testShardSizeEstimator{0: 10, 1: 100},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockClient, _ := setupDryRunPlacementTest(t, ctrl, newDryRunTestPlacement())
+			handlerOpts, err := NewHandlerOptions(mockClient, config.Configuration{}, nil, instrument.NewOptions())
+			require.NoError(t, err)
+			handler := NewAddHandler(handlerOpts.SetShardSizeEstimator(test.estimator))
+			handler.nowFn = func() time.Time { return time.Unix(0, 0) }
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(AddHTTPMethod, M3DBAddURL+test.query, strings.NewReader(
+				`{"dryRun": true, "instances":[{"id": "D","isolation_group": "g4","zone": "embedded","weight": 1,"endpoint": "D"}]}`))
+			svcDefaults := handleroptions.ServiceNameAndDefaults{
+				ServiceName: handleroptions.M3DBServiceName,
+			}
+			handler.ServeHTTP(svcDefaults, w, req)
+
+			resp := w.Result()
+			require.Equal(t, test.expectedStatus, resp.StatusCode)
+			if test.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var dryRunResp admin.PlacementDryRunResponse
+			require.NoError(t, jsonpb.Unmarshal(resp.Body, &dryRunResp))
+			assert.True(t, dryRunResp.Diff.ShardsMoved > 0)
+			assert.Equal(t, test.expectEstimate, dryRunResp.Diff.EstimatedBytesMoved > 0)
+		})
+	}
+}
+
+func TestPlacementDeleteHandler_DryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient, ps := setupDryRunPlacementTest(t, ctrl, newDryRunTestPlacement())
+	handlerOpts, err := NewHandlerOptions(mockClient, config.Configuration{}, nil, instrument.NewOptions())
+	require.NoError(t, err)
+	handler := NewDeleteHandler(handlerOpts)
+	handler.nowFn = func() time.Time { return time.Unix(0, 0) }
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(DeleteHTTPMethod, "/placement/B?force=true&dryRun=true", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "B"})
+	svcDefaults := handleroptions.ServiceNameAndDefaults{
+		ServiceName: handleroptions.M3DBServiceName,
+	}
+	handler.ServeHTTP(svcDefaults, w, req)
+
+	resp := w.Result()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var dryRunResp admin.PlacementDryRunResponse
+	require.NoError(t, jsonpb.Unmarshal(resp.Body, &dryRunResp))
+	require.Equal(t, 2, len(dryRunResp.Diff.Instances))
+	assert.Equal(t, "B", dryRunResp.Diff.Instances[0].Id)
+	assert.Equal(t, []uint32{0}, dryRunResp.Diff.Instances[0].ShardsRemoved)
+	assert.Equal(t, "C", dryRunResp.Diff.Instances[1].Id)
+	assert.Equal(t, []uint32{0}, dryRunResp.Diff.Instances[1].ShardsAdded)
+	assert.Equal(t, int32(1), dryRunResp.Diff.ShardsMoved)
+
+	// The dry run is not persisted.
+	p, err := ps.Placement()
+	require.NoError(t, err)
+	instance, ok := p.Instance("B")
+	require.True(t, ok)
+	assert.Equal(t, 1, instance.Shards().NumShardsForState(shard.Available))
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package placement
+
+import (
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/placement"
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/generated/proto/admin"
+	"github.com/m3db/m3/src/query/util/logging"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// HistoryHTTPMethod is the HTTP method for the the history endpoint.
+	HistoryHTTPMethod = http.MethodGet
+
+	historyPathName = "history"
+)
+
+var (
+	// M3DBHistoryURL is the url for the m3db placement history handler
+	// (method GET).
+	M3DBHistoryURL = path.Join(handler.RoutePrefixV1,
+		M3DBServicePlacementPathName, historyPathName)
+
+	// M3AggHistoryURL is the url for the m3aggregator placement history handler
+	// (method GET).
+	M3AggHistoryURL = path.Join(handler.RoutePrefixV1,
+		M3AggServicePlacementPathName, historyPathName)
+
+	// M3CoordinatorHistoryURL is the url for the m3coordinator placement
+	// history handler (method GET).
+	M3CoordinatorHistoryURL = path.Join(handler.RoutePrefixV1,
+		M3CoordinatorServicePlacementPathName, historyPathName)
+)
+
+// HistoryHandler is the handler for the placement history.
+type HistoryHandler Handler
+
+// NewHistoryHandler returns a new HistoryHandler.
+func NewHistoryHandler(opts HandlerOptions) *HistoryHandler {
+	return &HistoryHandler{HandlerOptions: opts, nowFn: time.Now}
+}
+
+func (h *HistoryHandler) ServeHTTP(
+	svc handleroptions.ServiceNameAndDefaults,
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	var (
+		ctx    = r.Context()
+		logger = logging.WithContext(ctx, h.instrumentOptions)
+	)
+
+	opts := handleroptions.NewServiceOptions(svc, r.Header, h.m3AggServiceOptions)
+	service, err := Service(h.clusterClient, opts, h.nowFn(), nil)
+	if err != nil {
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	entries, err := service.History()
+	if err != nil {
+		logger.Error("unable to get placement history", zap.Error(err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	resp, err := newPlacementHistoryResponse(entries)
+	if err != nil {
+		logger.Error("unable to get placement protobuf", zap.Error(err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	xhttp.WriteProtoMsgJSONResponse(w, resp, logger)
+}
+
+func newPlacementHistoryResponse(
+	entries []placement.HistoryEntry,
+) (*admin.PlacementHistoryResponse, error) {
+	resp := &admin.PlacementHistoryResponse{
+		Entries: make([]*admin.PlacementHistoryEntry, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		historyEntry := &admin.PlacementHistoryEntry{
+			Version:  int32(entry.Version),
+			Deleted:  entry.Deleted,
+			Operator: entry.Metadata.Operator,
+			Reason:   entry.Metadata.Reason,
+		}
+		if !entry.UpdatedAt.IsZero() {
+			historyEntry.UpdatedAtNanos = entry.UpdatedAt.UnixNano()
+		}
+		if !entry.Deleted {
+			placementProto, err := entry.Placement.Proto()
+			if err != nil {
+				return nil, err
+			}
+			historyEntry.Placement = placementProto
+		}
+		resp.Entries = append(resp.Entries, historyEntry)
+	}
+	return resp, nil
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package placement
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/cluster/kv/mem"
+	"github.com/m3db/m3/src/cluster/kv/util/history"
+	"github.com/m3db/m3/src/cluster/placement"
+	"github.com/m3db/m3/src/cluster/placement/service"
+	"github.com/m3db/m3/src/cluster/placement/storage"
+	"github.com/m3db/m3/src/cluster/services"
+	"github.com/m3db/m3/src/cluster/shard"
+	"github.com/m3db/m3/src/cmd/services/m3query/config"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/generated/proto/admin"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupPlacementHistoryTest returns a client whose placement services all
+// share the given store, so that the history written by one request is seen
+// by the next.
+func setupPlacementHistoryTest(
+	t *testing.T,
+	ctrl *gomock.Controller,
+	store kv.Store,
+) *client.MockClient {
+	mockClient := client.NewMockClient(ctrl)
+	mockServices := services.NewMockServices(ctrl)
+	mockClient.EXPECT().Services(gomock.Any()).Return(mockServices, nil).AnyTimes()
+	mockServices.EXPECT().PlacementService(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ interface{}, opts placement.Options) (placement.Service, error) {
+			return service.NewPlacementService(storage.NewPlacementStorage(store, "", opts), opts), nil
+		},
+	).AnyTimes()
+	return mockClient
+}
+
+// newRebalancedPlacement returns the placement of newUnbalancedPlacement with
+// shard 2 moved from instance A to instance B.
+func newRebalancedPlacement() placement.Placement {
+	p := newUnbalancedPlacement()
+	instA, _ := p.Instance("A")
+	instB, _ := p.Instance("B")
+	instA.Shards().Remove(2)
+	instB.Shards().Add(shard.NewShard(2).SetState(shard.Available))
+	return p
+}
+
+func seedPlacementHistory(t *testing.T, store kv.Store) {
+	opts := placement.NewOptions().
+		SetHistoryLimit(history.DefaultLimit).
+		SetChangeMetadata(history.Metadata{Operator: "alice", Reason: "init"})
+	ps := service.NewPlacementService(storage.NewPlacementStorage(store, "", opts), opts)
+	_, err := ps.Set(newUnbalancedPlacement())
+	require.NoError(t, err)
+
+	opts = opts.SetChangeMetadata(history.Metadata{Operator: "bob", Reason: "balance"})
+	ps = service.NewPlacementService(storage.NewPlacementStorage(store, "", opts), opts)
+	_, err = ps.Set(newRebalancedPlacement())
+	require.NoError(t, err)
+}
+
+func TestPlacementHistoryHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mem.NewStore()
+	seedPlacementHistory(t, store)
+
+	handlerOpts, err := NewHandlerOptions(setupPlacementHistoryTest(t, ctrl, store),
+		config.Configuration{}, nil, instrument.NewOptions())
+	require.NoError(t, err)
+	handler := NewHistoryHandler(handlerOpts)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(HistoryHTTPMethod, M3DBHistoryURL, nil)
+	handler.ServeHTTP(handleroptions.ServiceNameAndDefaults{
+		ServiceName: handleroptions.M3DBServiceName,
+	}, w, req)
+
+	resp := w.Result()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var historyResp admin.PlacementHistoryResponse
+	require.NoError(t, jsonpb.Unmarshal(resp.Body, &historyResp))
+	require.Equal(t, 2, len(historyResp.Entries))
+
+	first, second := historyResp.Entries[0], historyResp.Entries[1]
+	assert.Equal(t, int32(1), first.Version)
+	assert.Equal(t, "alice", first.Operator)
+	assert.Equal(t, "init", first.Reason)
+	assert.Equal(t, 3, len(first.Placement.Instances["A"].Shards))
+	assert.Equal(t, int32(2), second.Version)
+	assert.Equal(t, "bob", second.Operator)
+	assert.Equal(t, "balance", second.Reason)
+	assert.Equal(t, 2, len(second.Placement.Instances["A"].Shards))
+	assert.True(t, second.UpdatedAtNanos >= first.UpdatedAtNanos)
+}
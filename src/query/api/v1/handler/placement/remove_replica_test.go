@@ -0,0 +1,155 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package placement
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/placement"
+	"github.com/m3db/m3/src/cmd/services/m3query/config"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRemoveReplicaRequest(body string) *http.Request {
+	rb := strings.NewReader(body)
+	return httptest.NewRequest(RemoveReplicaHTTPMethod, M3DBRemoveReplicaURL, rb)
+}
+
+func TestPlacementRemoveReplicaHandler_Force(t *testing.T) {
+	runForAllAllowedServices(func(s string) {
+		t.Run(s, func(t *testing.T) {
+			testPlacementRemoveReplicaHandlerForce(t, s)
+		})
+	})
+}
+
+func TestPlacementRemoveReplicaHandler_Safe_Err(t *testing.T) {
+	runForAllAllowedServices(func(s string) {
+		t.Run(s, func(t *testing.T) {
+			testPlacementRemoveReplicaHandlerSafeErr(t, s)
+		})
+	})
+}
+
+func testPlacementRemoveReplicaHandlerForce(t *testing.T, serviceName string) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient, mockPlacementService := SetupPlacementTest(t, ctrl)
+	handlerOpts, err := NewHandlerOptions(mockClient, config.Configuration{}, nil, instrument.NewOptions())
+	require.NoError(t, err)
+	handler := NewRemoveReplicaHandler(handlerOpts)
+	handler.nowFn = func() time.Time { return time.Unix(0, 0) }
+
+	svcDefaults := handleroptions.ServiceNameAndDefaults{
+		ServiceName: serviceName,
+	}
+
+	w := httptest.NewRecorder()
+	req := newRemoveReplicaRequest(`{"force": true}`)
+	mockPlacementService.EXPECT().RemoveReplica().Return(nil, errors.New("test"))
+	handler.ServeHTTP(svcDefaults, w, req)
+
+	resp := w.Result()
+	body, _ := ioutil.ReadAll(resp.Body)
+	assert.Equal(t, `{"error":"test"}`+"\n", string(body))
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+
+	w = httptest.NewRecorder()
+	req = newRemoveReplicaRequest(`{"force": true}`)
+	mockPlacementService.EXPECT().RemoveReplica().Return(placement.NewPlacement(), nil)
+	handler.ServeHTTP(svcDefaults, w, req)
+
+	resp = w.Result()
+	body, _ = ioutil.ReadAll(resp.Body)
+	assert.Equal(t, `{"placement":{"instances":{},"replicaFactor":0,"numShards":0,"isSharded":false,"cutoverTime":"0","isMirrored":false,"maxShardSetId":0},"version":0}`, string(body))
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func testPlacementRemoveReplicaHandlerSafeErr(t *testing.T, serviceName string) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient, mockPlacementService := SetupPlacementTest(t, ctrl)
+	handlerOpts, err := NewHandlerOptions(mockClient, config.Configuration{}, nil, instrument.NewOptions())
+	require.NoError(t, err)
+	handler := NewRemoveReplicaHandler(handlerOpts)
+	handler.nowFn = func() time.Time { return time.Unix(0, 0) }
+
+	svcDefaults := handleroptions.ServiceNameAndDefaults{
+		ServiceName: serviceName,
+	}
+
+	w := httptest.NewRecorder()
+	req := newRemoveReplicaRequest("{}")
+	mockPlacementService.EXPECT().Placement().Return(newInitPlacement(), nil)
+	handler.ServeHTTP(svcDefaults, w, req)
+
+	resp := w.Result()
+	body, _ := ioutil.ReadAll(resp.Body)
+	if isStateless(serviceName) {
+		// The placement has no replica factor to decrement.
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		return
+	}
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, `{"error":"instances [A,B] do not have all shards available"}`+"\n", string(body))
+}
+
+func TestPlacementRemoveReplicaHandler_Safe_Ok(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient, mockPlacementService := SetupPlacementTest(t, ctrl)
+	handlerOpts, err := NewHandlerOptions(mockClient, config.Configuration{}, nil, instrument.NewOptions())
+	require.NoError(t, err)
+	handler := NewRemoveReplicaHandler(handlerOpts)
+	handler.nowFn = func() time.Time { return time.Unix(0, 0) }
+
+	svcDefaults := handleroptions.ServiceNameAndDefaults{
+		ServiceName: handleroptions.M3DBServiceName,
+	}
+
+	w := httptest.NewRecorder()
+	req := newRemoveReplicaRequest("{}")
+	mockPlacementService.EXPECT().Placement().Return(newValidAvailPlacement().SetVersion(1), nil)
+	mockPlacementService.EXPECT().CheckAndSet(gomock.Any(), 1).DoAndReturn(
+		func(p placement.Placement, version int) (placement.Placement, error) {
+			assert.Equal(t, 1, p.ReplicaFactor())
+			assert.Equal(t, 1, p.NumInstances())
+			return p.SetVersion(2), nil
+		})
+	handler.ServeHTTP(svcDefaults, w, req)
+
+	resp := w.Result()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
@@ -81,6 +81,18 @@ func (h *ReplaceHandler) ServeHTTP(
 		return
 	}
 
+	var curPlacement placement.Placement
+	req.DryRun = req.DryRun || isDryRun(r)
+	if req.DryRun {
+		p, err := h.currentPlacement(svc, r, h.nowFn())
+		if err != nil {
+			logger.Error("unable to get current placement", zap.Error(err))
+			xhttp.Error(w, err, http.StatusInternalServerError)
+			return
+		}
+		curPlacement = p
+	}
+
 	placement, err := h.Replace(svc, r, req)
 	if err != nil {
 		status := http.StatusInternalServerError
@@ -92,6 +104,11 @@ func (h *ReplaceHandler) ServeHTTP(
 		return
 	}
 
+	if req.DryRun {
+		h.writeDryRunResponse(svc, w, r, logger, curPlacement, placement)
+		return
+	}
+
 	placementProto, err := placement.Proto()
 	if err != nil {
 		logger.Error("unable to get placement protobuf", zap.Error(err))
@@ -131,6 +148,9 @@ func (h *ReplaceHandler) Replace(
 
 	serviceOpts := handleroptions.NewServiceOptions(svc,
 		httpReq.Header, h.m3AggServiceOptions)
+	if req.DryRun {
+		serviceOpts.DryRun = true
+	}
 	service, algo, err := ServiceWithAlgo(h.clusterClient,
 		serviceOpts, h.nowFn(), nil)
 	if err != nil {
@@ -0,0 +1,154 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package placement
+
+import (
+	"errors"
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/cluster/kv/util/history"
+	"github.com/m3db/m3/src/cluster/placement"
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/generated/proto/admin"
+	"github.com/m3db/m3/src/query/util/logging"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// RollbackHTTPMethod is the HTTP method for the the rollback endpoint.
+	RollbackHTTPMethod = http.MethodPost
+
+	rollbackPathName   = "rollback"
+	rollbackVersionVar = "version"
+)
+
+var (
+	// M3DBRollbackURL is the url for the m3db placement rollback handler
+	// (method POST).
+	M3DBRollbackURL = path.Join(handler.RoutePrefixV1,
+		M3DBServicePlacementPathName, rollbackPathName)
+
+	// M3AggRollbackURL is the url for the m3aggregator placement rollback
+	// handler (method POST).
+	M3AggRollbackURL = path.Join(handler.RoutePrefixV1,
+		M3AggServicePlacementPathName, rollbackPathName)
+
+	// M3CoordinatorRollbackURL is the url for the m3coordinator placement
+	// rollback handler (method POST).
+	M3CoordinatorRollbackURL = path.Join(handler.RoutePrefixV1,
+		M3CoordinatorServicePlacementPathName, rollbackPathName)
+
+	errRollbackVersionRequired = errors.New("version is required")
+)
+
+// RollbackHandler is the handler for placement rollbacks.
+type RollbackHandler Handler
+
+// NewRollbackHandler returns a new RollbackHandler.
+func NewRollbackHandler(opts HandlerOptions) *RollbackHandler {
+	return &RollbackHandler{HandlerOptions: opts, nowFn: time.Now}
+}
+
+func (h *RollbackHandler) ServeHTTP(
+	svc handleroptions.ServiceNameAndDefaults,
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	var (
+		ctx    = r.Context()
+		logger = logging.WithContext(ctx, h.instrumentOptions)
+	)
+
+	version, err := parseRollbackVersion(r)
+	if err != nil {
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	var curPlacement placement.Placement
+	dryRun := isDryRun(r)
+	if dryRun {
+		p, err := h.currentPlacement(svc, r, h.nowFn())
+		if err == kv.ErrNotFound {
+			// Rolling back a deleted placement adds all of its instances.
+			p, err = placement.NewPlacement(), nil
+		}
+		if err != nil {
+			logger.Error("unable to get current placement", zap.Error(err))
+			xhttp.Error(w, err, http.StatusInternalServerError)
+			return
+		}
+		curPlacement = p
+	}
+
+	opts := handleroptions.NewServiceOptions(svc, r.Header, h.m3AggServiceOptions)
+	opts.DryRun = dryRun
+	service, err := Service(h.clusterClient, opts, h.nowFn(), nil)
+	if err != nil {
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	newPlacement, err := service.Rollback(version)
+	if err == history.ErrVersionNotFound {
+		xhttp.Error(w, err, http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		logger.Error("unable to roll back placement", zap.Error(err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	if dryRun {
+		h.writeDryRunResponse(svc, w, r, logger, curPlacement, newPlacement)
+		return
+	}
+
+	placementProto, err := newPlacement.Proto()
+	if err != nil {
+		logger.Error("unable to get placement protobuf", zap.Error(err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	resp := &admin.PlacementGetResponse{
+		Placement: placementProto,
+		Version:   int32(newPlacement.Version()),
+	}
+
+	xhttp.WriteProtoMsgJSONResponse(w, resp, logger)
+}
+
+func parseRollbackVersion(r *http.Request) (int, error) {
+	vs := r.URL.Query().Get(rollbackVersionVar)
+	if vs == "" {
+		return 0, errRollbackVersionRequired
+	}
+	return strconv.Atoi(vs)
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package placement
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/m3db/m3/src/cluster/kv/mem"
+	"github.com/m3db/m3/src/cluster/placement"
+	"github.com/m3db/m3/src/cluster/placement/service"
+	"github.com/m3db/m3/src/cluster/placement/storage"
+	"github.com/m3db/m3/src/cmd/services/m3query/config"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/generated/proto/admin"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlacementRollbackHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mem.NewStore()
+	seedPlacementHistory(t, store)
+
+	handlerOpts, err := NewHandlerOptions(setupPlacementHistoryTest(t, ctrl, store),
+		config.Configuration{}, nil, instrument.NewOptions())
+	require.NoError(t, err)
+	handler := NewRollbackHandler(handlerOpts)
+	svcDefaults := handleroptions.ServiceNameAndDefaults{
+		ServiceName: handleroptions.M3DBServiceName,
+	}
+
+	// A dry run returns the rolled back placement without storing it.
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(RollbackHTTPMethod, M3DBRollbackURL+"?version=1&dryRun=true", nil)
+	handler.ServeHTTP(svcDefaults, w, req)
+	resp := w.Result()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var dryRunResp admin.PlacementDryRunResponse
+	require.NoError(t, jsonpb.Unmarshal(resp.Body, &dryRunResp))
+	assert.Equal(t, int32(1), dryRunResp.Diff.ShardsMoved)
+	assert.Equal(t, 3, len(dryRunResp.Placement.Instances["A"].Shards))
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(RollbackHTTPMethod, M3DBRollbackURL+"?version=1", nil)
+	req.Header.Set(handleroptions.HeaderChangeOperator, "carol")
+	req.Header.Set(handleroptions.HeaderChangeReason, "undo balance")
+	handler.ServeHTTP(svcDefaults, w, req)
+	resp = w.Result()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var getResp admin.PlacementGetResponse
+	require.NoError(t, jsonpb.Unmarshal(resp.Body, &getResp))
+	assert.Equal(t, int32(3), getResp.Version)
+	assert.Equal(t, 3, len(getResp.Placement.Instances["A"].Shards))
+
+	opts := placement.NewOptions()
+	ps := service.NewPlacementService(storage.NewPlacementStorage(store, "", opts), opts)
+	entries, err := ps.History()
+	require.NoError(t, err)
+	require.Equal(t, 3, len(entries))
+	assert.Equal(t, 3, entries[2].Placement.Version())
+	assert.Equal(t, "carol", entries[2].Metadata.Operator)
+	assert.Equal(t, "undo balance", entries[2].Metadata.Reason)
+}
+
+func TestPlacementRollbackHandler_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mem.NewStore()
+	seedPlacementHistory(t, store)
+
+	handlerOpts, err := NewHandlerOptions(setupPlacementHistoryTest(t, ctrl, store),
+		config.Configuration{}, nil, instrument.NewOptions())
+	require.NoError(t, err)
+	handler := NewRollbackHandler(handlerOpts)
+	svcDefaults := handleroptions.ServiceNameAndDefaults{
+		ServiceName: handleroptions.M3DBServiceName,
+	}
+
+	for _, test := range []struct {
+		query  string
+		status int
+	}{
+		{query: "", status: http.StatusBadRequest},
+		{query: "?version=abc", status: http.StatusBadRequest},
+		{query: "?version=7", status: http.StatusNotFound},
+	} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(RollbackHTTPMethod, M3DBRollbackURL+test.query, nil)
+		handler.ServeHTTP(svcDefaults, w, req)
+		assert.Equal(t, test.status, w.Result().StatusCode, test.query)
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package placement
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
+	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3/src/query/storage/m3"
+	"github.com/m3db/m3/src/x/ident"
+)
+
+var errNoAdminSession = errors.New("cluster namespace session is not an admin session")
+
+type m3dbShardSizeEstimator struct {
+	clusters m3.Clusters
+	nowFn    func() time.Time
+}
+
+// NewM3DBShardSizeEstimator returns a ShardSizeEstimator that estimates the
+// size of a shard as the sum of the sizes of its blocks in every cluster
+// namespace, as reported by the largest replica of the shard. Block metadata
+// is streamed from the dbnodes for every series of the shard, which is as
+// expensive as a peer bootstrap, so estimates are only computed when a dry run
+// asks for them and the fetch is aborted once the context is done.
+func NewM3DBShardSizeEstimator(clusters m3.Clusters) ShardSizeEstimator {
+	return &m3dbShardSizeEstimator{
+		clusters: clusters,
+		nowFn:    time.Now,
+	}
+}
+
+func (e *m3dbShardSizeEstimator) ShardSizes(
+	ctx context.Context,
+	shards []uint32,
+) (map[uint32]int64, error) {
+	var (
+		now   = e.nowFn()
+		sizes = make(map[uint32]int64, len(shards))
+	)
+	for _, ns := range e.clusters.ClusterNamespaces() {
+		session, ok := ns.Session().(client.AdminSession)
+		if !ok {
+			return nil, errNoAdminSession
+		}
+
+		start := now.Add(-ns.Options().Attributes().Retention)
+		for _, shard := range shards {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			size, err := shardSize(ctx, session, ns.NamespaceID(), shard, start, now)
+			if err != nil {
+				return nil, err
+			}
+			sizes[shard] += size
+		}
+	}
+	return sizes, nil
+}
+
+func shardSize(
+	ctx context.Context,
+	session client.AdminSession,
+	namespace ident.ID,
+	shard uint32,
+	start, end time.Time,
+) (int64, error) {
+	iter, err := session.FetchBlocksMetadataFromPeers(namespace, shard,
+		start, end, topology.ReadConsistencyLevelOne, result.NewOptions())
+	if err != nil {
+		return 0, err
+	}
+	defer iter.Close()
+
+	sizeByHost := make(map[string]int64)
+	for iter.Next() {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		host, metadata := iter.Current()
+		sizeByHost[host.ID()] += metadata.Size
+	}
+	if err := iter.Err(); err != nil {
+		return 0, err
+	}
+
+	var size int64
+	for _, hostSize := range sizeByHost {
+		if hostSize > size {
+			size = hostSize
+		}
+	}
+	return size, nil
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package placement
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/storage/block"
+	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3/src/x/ident"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardSizeLargestReplica(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		end   = time.Now()
+		start = end.Add(-time.Hour)
+		a     = topology.NewHost("a", "a:9000")
+		b     = topology.NewHost("b", "b:9000")
+	)
+	iter := client.NewMockPeerBlockMetadataIter(ctrl)
+	gomock.InOrder(
+		iter.EXPECT().Next().Return(true),
+		iter.EXPECT().Current().Return(a, block.Metadata{Size: 10}),
+		iter.EXPECT().Next().Return(true),
+		iter.EXPECT().Current().Return(b, block.Metadata{Size: 15}),
+		iter.EXPECT().Next().Return(true),
+		iter.EXPECT().Current().Return(a, block.Metadata{Size: 10}),
+		iter.EXPECT().Next().Return(false),
+		iter.EXPECT().Err().Return(nil),
+		iter.EXPECT().Close(),
+	)
+
+	session := client.NewMockAdminSession(ctrl)
+	session.EXPECT().
+		FetchBlocksMetadataFromPeers(ident.NewIDMatcher("ns"), uint32(1),
+			start, end, topology.ReadConsistencyLevelOne, gomock.Any()).
+		Return(iter, nil)
+
+	size, err := shardSize(context.Background(), session,
+		ident.StringID("ns"), 1, start, end)
+	require.NoError(t, err)
+	require.Equal(t, int64(20), size)
+}
+
+func TestShardSizeAbortsWhenContextDone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		end         = time.Now()
+		start       = end.Add(-time.Hour)
+		host        = topology.NewHost("a", "a:9000")
+		ctx, cancel = context.WithCancel(context.Background())
+	)
+	// The metadata stream is closed as soon as the context is done.
+	iter := client.NewMockPeerBlockMetadataIter(ctrl)
+	gomock.InOrder(
+		iter.EXPECT().Next().Return(true),
+		iter.EXPECT().Current().DoAndReturn(func() (topology.Host, block.Metadata) {
+			cancel()
+			return host, block.Metadata{Size: 10}
+		}),
+		iter.EXPECT().Next().Return(true),
+		iter.EXPECT().Close(),
+	)
+
+	session := client.NewMockAdminSession(ctrl)
+	session.EXPECT().
+		FetchBlocksMetadataFromPeers(gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(iter, nil)
+
+	_, err := shardSize(ctx, session, ident.StringID("ns"), 1, start, end)
+	require.Equal(t, context.Canceled, err)
+}
@@ -86,6 +86,12 @@ const (
 	HeaderDryRun = "Dry-Run"
 	// HeaderForce is the header used to specify whether this should be a forced operation.
 	HeaderForce = "Force"
+	// HeaderChangeOperator is the header used to specify who is making a
+	// change, recorded in the history of placements and namespaces.
+	HeaderChangeOperator = "Change-Operator"
+	// HeaderChangeReason is the header used to specify why a change is made,
+	// recorded in the history of placements and namespaces.
+	HeaderChangeReason = "Change-Reason"
 
 	// LimitHeader is the header added when returned series are limited.
 	LimitHeader = M3HeaderPrefix + "Results-Limited"
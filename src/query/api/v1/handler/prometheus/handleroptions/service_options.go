@@ -26,6 +26,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/m3db/m3/src/cluster/kv/util/history"
 	"github.com/m3db/m3/src/cluster/services"
 )
 
@@ -61,6 +62,9 @@ type ServiceOptions struct {
 
 	DryRun bool
 	Force  bool
+
+	ChangeMetadata history.Metadata
+	HistoryLimit   int
 }
 
 // M3AggServiceOptions contains the service options that are
@@ -89,6 +93,15 @@ func WithDefaultServiceZone(zone string) ServiceOptionsDefault {
 	}
 }
 
+// WithDefaultHistoryLimit returns the default number of previous values
+// kept in the history of the values changed, zero keeps no history.
+func WithDefaultHistoryLimit(limit int) ServiceOptionsDefault {
+	return func(o ServiceOptions) ServiceOptions {
+		o.HistoryLimit = limit
+		return o
+	}
+}
+
 // ServiceNameAndDefaults is the params used when identifying a service
 // and it's service option defaults.
 type ServiceNameAndDefaults struct {
@@ -111,6 +124,9 @@ func NewServiceOptions(
 		DryRun: false,
 		Force:  false,
 
+		ChangeMetadata: NewChangeMetadata(headers),
+		HistoryLimit:   history.DefaultLimit,
+
 		M3Agg: &M3AggServiceOptions{
 			MaxAggregationWindowSize: defaultM3AggMaxAggregationWindowSize,
 			WarmupDuration:           defaultM3AggWarmupDuration,
@@ -146,6 +162,15 @@ func NewServiceOptions(
 	return opts
 }
 
+// NewChangeMetadata returns the operator and reason of a change supplied
+// via the request headers.
+func NewChangeMetadata(headers http.Header) history.Metadata {
+	return history.Metadata{
+		Operator: strings.TrimSpace(headers.Get(HeaderChangeOperator)),
+		Reason:   strings.TrimSpace(headers.Get(HeaderChangeReason)),
+	}
+}
+
 // Validate ensures the service options are valid.
 func (opts *ServiceOptions) Validate() error {
 	if opts.ServiceName == "" {
@@ -25,18 +25,34 @@ import (
 	"testing"
 	"time"
 
+	"github.com/m3db/m3/src/cluster/kv/util/history"
+
 	"github.com/stretchr/testify/assert"
 )
 
 func TestNewServiceOptions(t *testing.T) {
 	tests := []struct {
-		service string
-		headers map[string]string
-		aggOpts *M3AggServiceOptions
-		exp     ServiceOptions
+		service  string
+		defaults []ServiceOptionsDefault
+		headers  map[string]string
+		aggOpts  *M3AggServiceOptions
+		exp      ServiceOptions
 	}{
 		{
 			service: "foo",
+			exp: ServiceOptions{
+				ServiceName:        "foo",
+				ServiceEnvironment: DefaultServiceEnvironment,
+				ServiceZone:        DefaultServiceZone,
+				HistoryLimit:       history.DefaultLimit,
+				M3Agg: &M3AggServiceOptions{
+					MaxAggregationWindowSize: time.Minute,
+				},
+			},
+		},
+		{
+			service:  "foo",
+			defaults: []ServiceOptionsDefault{WithDefaultHistoryLimit(0)},
 			exp: ServiceOptions{
 				ServiceName:        "foo",
 				ServiceEnvironment: DefaultServiceEnvironment,
@@ -52,6 +68,8 @@ func TestNewServiceOptions(t *testing.T) {
 				HeaderClusterEnvironmentName: "bar",
 				HeaderClusterZoneName:        "baz",
 				HeaderDryRun:                 "true",
+				HeaderChangeOperator:         "alice",
+				HeaderChangeReason:           " replace bad host ",
 			},
 			aggOpts: &M3AggServiceOptions{
 				MaxAggregationWindowSize: 2 * time.Minute,
@@ -62,6 +80,11 @@ func TestNewServiceOptions(t *testing.T) {
 				ServiceEnvironment: "bar",
 				ServiceZone:        "baz",
 				DryRun:             true,
+				HistoryLimit:       history.DefaultLimit,
+				ChangeMetadata: history.Metadata{
+					Operator: "alice",
+					Reason:   "replace bad host",
+				},
 				M3Agg: &M3AggServiceOptions{
 					MaxAggregationWindowSize: 2 * time.Minute,
 					WarmupDuration:           time.Minute,
@@ -77,6 +100,7 @@ func TestNewServiceOptions(t *testing.T) {
 		}
 		svcDefaults := ServiceNameAndDefaults{
 			ServiceName: test.service,
+			Defaults:    test.defaults,
 		}
 		opts := NewServiceOptions(svcDefaults, h, test.aggOpts)
 		assert.Equal(t, test.exp, opts)
@@ -0,0 +1,90 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/metadata"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+)
+
+const (
+	// PromMetadataURL is the url for the Prometheus compatible metric
+	// metadata endpoint.
+	PromMetadataURL = handler.RoutePrefixV1 + "/metadata"
+
+	// PromMetadataHTTPMethod is the HTTP method used with this resource.
+	PromMetadataHTTPMethod = http.MethodGet
+
+	metadataMetricParam = "metric"
+	metadataLimitParam  = "limit"
+)
+
+type metadataResponse struct {
+	Status string                         `json:"status"`
+	Data   map[string][]metadata.Metadata `json:"data"`
+}
+
+// PromMetadataHandler serves the Prometheus compatible metric metadata
+// endpoint from the metadata received via remote write.
+type PromMetadataHandler struct {
+	store          metadata.Store
+	instrumentOpts instrument.Options
+}
+
+// NewPromMetadataHandler returns a new instance of the metadata handler.
+func NewPromMetadataHandler(opts options.HandlerOptions) http.Handler {
+	return &PromMetadataHandler{
+		store:          opts.MetadataStore(),
+		instrumentOpts: opts.InstrumentOpts(),
+	}
+}
+
+func (h *PromMetadataHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	limit := -1
+	if str := r.FormValue(metadataLimitParam); str != "" {
+		value, err := strconv.Atoi(str)
+		if err != nil {
+			err = fmt.Errorf("limit must be a number: %v", err)
+			xhttp.Error(w, err, http.StatusBadRequest)
+			return
+		}
+		limit = value
+	}
+
+	resp := metadataResponse{
+		Status: "success",
+		Data:   map[string][]metadata.Metadata{},
+	}
+
+	// NB: a limit of zero returns no metadata, the same as Prometheus.
+	if h.store != nil && limit != 0 {
+		resp.Data = h.store.Query(r.FormValue(metadataMetricParam), limit)
+	}
+
+	xhttp.WriteJSONResponse(w, resp, h.instrumentOpts.Logger())
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/metadata"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMetadataHandler(t *testing.T) http.Handler {
+	store, err := metadata.NewStore(metadata.NewOptions())
+	require.NoError(t, err)
+
+	store.Write("up", metadata.Metadata{Type: "gauge", Help: "Whether the target is up."})
+	store.Write("http_requests_total", metadata.Metadata{Type: "counter", Unit: "requests"})
+
+	opts := options.EmptyHandlerOptions().SetMetadataStore(store)
+	return NewPromMetadataHandler(opts)
+}
+
+func serveMetadata(t *testing.T, h http.Handler, query string) (int, metadataResponse) {
+	req := httptest.NewRequest(PromMetadataHTTPMethod, PromMetadataURL+query, nil)
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, req)
+
+	var resp metadataResponse
+	if recorder.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+	}
+	return recorder.Code, resp
+}
+
+func TestPromMetadataHandler(t *testing.T) {
+	h := newTestMetadataHandler(t)
+
+	code, resp := serveMetadata(t, h, "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, metadataResponse{
+		Status: "success",
+		Data: map[string][]metadata.Metadata{
+			"up":                  {{Type: "gauge", Help: "Whether the target is up."}},
+			"http_requests_total": {{Type: "counter", Unit: "requests"}},
+		},
+	}, resp)
+
+	code, resp = serveMetadata(t, h, "?metric=up")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []string{"up"}, metadataNames(resp))
+
+	code, resp = serveMetadata(t, h, "?limit=1")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []string{"http_requests_total"}, metadataNames(resp))
+
+	code, resp = serveMetadata(t, h, "?limit=0")
+	require.Equal(t, http.StatusOK, code)
+	assert.Empty(t, resp.Data)
+
+	code, _ = serveMetadata(t, h, "?limit=foo")
+	require.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestPromMetadataHandlerNoStore(t *testing.T) {
+	h := NewPromMetadataHandler(options.EmptyHandlerOptions())
+
+	code, resp := serveMetadata(t, h, "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "success", resp.Status)
+	assert.Empty(t, resp.Data)
+}
+
+func metadataNames(resp metadataResponse) []string {
+	var names []string
+	for name := range resp.Data {
+		names = append(names, name)
+	}
+	return names
+}
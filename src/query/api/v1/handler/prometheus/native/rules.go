@@ -0,0 +1,207 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/m3db/m3/src/query/alerting"
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+)
+
+const (
+	// PromRulesURL is the url for the Prometheus compatible rules endpoint.
+	PromRulesURL = handler.RoutePrefixV1 + "/rules"
+
+	// PromRulesHTTPMethod is the HTTP method used with this resource.
+	PromRulesHTTPMethod = http.MethodGet
+
+	// PromAlertsURL is the url for the Prometheus compatible alerts endpoint.
+	PromAlertsURL = handler.RoutePrefixV1 + "/alerts"
+
+	// PromAlertsHTTPMethod is the HTTP method used with this resource.
+	PromAlertsHTTPMethod = http.MethodGet
+
+	ruleTypeParam = "type"
+	ruleTypeAlert = "alert"
+	alertingType  = "alerting"
+)
+
+type rulesResponse struct {
+	Status string    `json:"status"`
+	Data   rulesData `json:"data"`
+}
+
+type rulesData struct {
+	Groups []ruleGroupResponse `json:"groups"`
+}
+
+type ruleGroupResponse struct {
+	Name           string         `json:"name"`
+	File           string         `json:"file"`
+	Interval       float64        `json:"interval"`
+	Rules          []ruleResponse `json:"rules"`
+	EvaluationTime float64        `json:"evaluationTime"`
+	LastEvaluation time.Time      `json:"lastEvaluation"`
+}
+
+type ruleResponse struct {
+	Name           string            `json:"name"`
+	Query          string            `json:"query"`
+	Duration       float64           `json:"duration"`
+	Labels         map[string]string `json:"labels"`
+	Annotations    map[string]string `json:"annotations"`
+	Alerts         []alertResponse   `json:"alerts"`
+	Health         string            `json:"health"`
+	LastError      string            `json:"lastError,omitempty"`
+	Type           string            `json:"type"`
+	EvaluationTime float64           `json:"evaluationTime"`
+	LastEvaluation time.Time         `json:"lastEvaluation"`
+}
+
+type alertsResponse struct {
+	Status string     `json:"status"`
+	Data   alertsData `json:"data"`
+}
+
+type alertsData struct {
+	Alerts []alertResponse `json:"alerts"`
+}
+
+type alertResponse struct {
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	State       string            `json:"state"`
+	ActiveAt    time.Time         `json:"activeAt"`
+	Value       string            `json:"value"`
+}
+
+// PromRulesHandler serves the Prometheus compatible rules endpoint.
+type PromRulesHandler struct {
+	manager        alerting.Manager
+	instrumentOpts instrument.Options
+}
+
+// NewPromRulesHandler returns a new instance of the rules handler.
+func NewPromRulesHandler(opts options.HandlerOptions) http.Handler {
+	return &PromRulesHandler{
+		manager:        opts.AlertingManager(),
+		instrumentOpts: opts.InstrumentOpts(),
+	}
+}
+
+func (h *PromRulesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	resp := rulesResponse{
+		Status: "success",
+		Data:   rulesData{Groups: []ruleGroupResponse{}},
+	}
+
+	// NB: only alerting rules are evaluated, so a request for recording
+	// rules is always empty.
+	ruleType := r.FormValue(ruleTypeParam)
+	if h.manager != nil && (ruleType == "" || ruleType == ruleTypeAlert) {
+		for _, group := range h.manager.RuleGroups() {
+			resp.Data.Groups = append(resp.Data.Groups, newRuleGroupResponse(group))
+		}
+	}
+
+	xhttp.WriteJSONResponse(w, resp, h.instrumentOpts.Logger())
+}
+
+// PromAlertsHandler serves the Prometheus compatible alerts endpoint.
+type PromAlertsHandler struct {
+	manager        alerting.Manager
+	instrumentOpts instrument.Options
+}
+
+// NewPromAlertsHandler returns a new instance of the alerts handler.
+func NewPromAlertsHandler(opts options.HandlerOptions) http.Handler {
+	return &PromAlertsHandler{
+		manager:        opts.AlertingManager(),
+		instrumentOpts: opts.InstrumentOpts(),
+	}
+}
+
+func (h *PromAlertsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	resp := alertsResponse{
+		Status: "success",
+		Data:   alertsData{Alerts: []alertResponse{}},
+	}
+
+	if h.manager != nil {
+		resp.Data.Alerts = newAlertResponses(h.manager.Alerts())
+	}
+
+	xhttp.WriteJSONResponse(w, resp, h.instrumentOpts.Logger())
+}
+
+func newRuleGroupResponse(group alerting.GroupStatus) ruleGroupResponse {
+	resp := ruleGroupResponse{
+		Name:           group.Name,
+		File:           group.File,
+		Interval:       group.Interval.Seconds(),
+		Rules:          make([]ruleResponse, 0, len(group.Rules)),
+		EvaluationTime: group.EvaluationTime.Seconds(),
+		LastEvaluation: group.LastEvaluation,
+	}
+
+	for _, rule := range group.Rules {
+		resp.Rules = append(resp.Rules, ruleResponse{
+			Name:           rule.Name,
+			Query:          rule.Query,
+			Duration:       rule.For.Seconds(),
+			Labels:         rule.Labels,
+			Annotations:    rule.Annotations,
+			Alerts:         newAlertResponses(rule.Alerts),
+			Health:         string(rule.Health),
+			LastError:      rule.LastError,
+			Type:           alertingType,
+			EvaluationTime: rule.EvaluationTime.Seconds(),
+			LastEvaluation: rule.LastEvaluation,
+		})
+	}
+
+	return resp
+}
+
+func newAlertResponses(alerts []alerting.Alert) []alertResponse {
+	resp := make([]alertResponse, 0, len(alerts))
+	for _, alert := range alerts {
+		if alert.State == alerting.StateInactive {
+			continue
+		}
+
+		resp = append(resp, alertResponse{
+			Labels:      alert.Labels,
+			Annotations: alert.Annotations,
+			State:       alert.State.String(),
+			ActiveAt:    alert.ActiveAt,
+			Value:       strconv.FormatFloat(alert.Value, 'e', -1, 64),
+		})
+	}
+
+	return resp
+}
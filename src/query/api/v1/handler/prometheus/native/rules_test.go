@@ -0,0 +1,144 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/alerting"
+	"github.com/m3db/m3/src/query/api/v1/options"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testAlertingManager struct {
+	groups []alerting.GroupStatus
+	alerts []alerting.Alert
+}
+
+func (m *testAlertingManager) Start() error                       { return nil }
+func (m *testAlertingManager) RuleGroups() []alerting.GroupStatus { return m.groups }
+func (m *testAlertingManager) Alerts() []alerting.Alert           { return m.alerts }
+func (m *testAlertingManager) Close() error                       { return nil }
+
+func newTestAlertingManager() *testAlertingManager {
+	firing := alerting.Alert{
+		State:    alerting.StateFiring,
+		Labels:   map[string]string{"alertname": "InstanceDown", "instance": "a"},
+		Value:    1,
+		ActiveAt: time.Unix(1000, 0).UTC(),
+	}
+	resolved := alerting.Alert{
+		State:  alerting.StateInactive,
+		Labels: map[string]string{"alertname": "InstanceDown", "instance": "b"},
+	}
+
+	return &testAlertingManager{
+		groups: []alerting.GroupStatus{
+			{
+				Name:     "group",
+				File:     "rules.yml",
+				Interval: time.Minute,
+				Rules: []alerting.RuleStatus{
+					{
+						Name:   "InstanceDown",
+						Query:  "up == 0",
+						For:    5 * time.Minute,
+						Health: alerting.HealthGood,
+						Alerts: []alerting.Alert{firing, resolved},
+					},
+				},
+			},
+		},
+		alerts: []alerting.Alert{firing},
+	}
+}
+
+func TestPromRulesHandler(t *testing.T) {
+	opts := options.EmptyHandlerOptions().
+		SetAlertingManager(newTestAlertingManager())
+	h := NewPromRulesHandler(opts)
+
+	req := httptest.NewRequest(PromRulesHTTPMethod, PromRulesURL, nil)
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var resp rulesResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+	assert.Equal(t, "success", resp.Status)
+	require.Equal(t, 1, len(resp.Data.Groups))
+
+	group := resp.Data.Groups[0]
+	assert.Equal(t, "group", group.Name)
+	assert.Equal(t, float64(60), group.Interval)
+	require.Equal(t, 1, len(group.Rules))
+
+	rule := group.Rules[0]
+	assert.Equal(t, "InstanceDown", rule.Name)
+	assert.Equal(t, "alerting", rule.Type)
+	assert.Equal(t, "ok", rule.Health)
+	assert.Equal(t, float64(300), rule.Duration)
+	require.Equal(t, 1, len(rule.Alerts))
+	assert.Equal(t, "firing", rule.Alerts[0].State)
+
+	// Recording rules are never returned.
+	req = httptest.NewRequest(PromRulesHTTPMethod, PromRulesURL+"?type=record", nil)
+	recorder = httptest.NewRecorder()
+	h.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+	assert.Equal(t, 0, len(resp.Data.Groups))
+}
+
+func TestPromAlertsHandler(t *testing.T) {
+	opts := options.EmptyHandlerOptions().
+		SetAlertingManager(newTestAlertingManager())
+	h := NewPromAlertsHandler(opts)
+
+	req := httptest.NewRequest(PromAlertsHTTPMethod, PromAlertsURL, nil)
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var resp alertsResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+	require.Equal(t, 1, len(resp.Data.Alerts))
+	assert.Equal(t, "firing", resp.Data.Alerts[0].State)
+	assert.Equal(t, "1e+00", resp.Data.Alerts[0].Value)
+	assert.Equal(t, "a", resp.Data.Alerts[0].Labels["instance"])
+}
+
+func TestPromAlertsHandlerNoManager(t *testing.T) {
+	h := NewPromAlertsHandler(options.EmptyHandlerOptions())
+
+	req := httptest.NewRequest(PromAlertsHTTPMethod, PromAlertsURL, nil)
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `{"status":"success","data":{"alerts":[]}}`,
+		recorder.Body.String())
+}
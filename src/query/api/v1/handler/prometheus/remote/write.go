@@ -39,6 +39,7 @@ import (
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
 	"github.com/m3db/m3/src/query/api/v1/options"
 	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	"github.com/m3db/m3/src/query/metadata"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/ts"
@@ -85,6 +86,7 @@ type PromWriteHandler struct {
 	forwardHTTPClient      *http.Client
 	forwardingBoundWorkers xsync.WorkerPool
 	forwardContext         context.Context
+	metadataStore          metadata.Store
 	nowFn                  clock.NowFn
 	instrumentOpts         instrument.Options
 	metrics                promWriteMetrics
@@ -144,6 +146,7 @@ func NewPromWriteHandler(options options.HandlerOptions) (http.Handler, error) {
 		forwardHTTPClient:      xhttp.NewHTTPClient(forwardHTTPOpts),
 		forwardingBoundWorkers: forwardingBoundWorkers,
 		forwardContext:         context.Background(),
+		metadataStore:          options.MetadataStore(),
 		nowFn:                  nowFn,
 		metrics:                metrics,
 		instrumentOpts:         instrumentOpts,
@@ -264,6 +267,10 @@ func (h *PromWriteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if h.metadataStore != nil {
+		h.writeMetadata(req.Metadata)
+	}
+
 	batchErr := h.write(r.Context(), req, opts)
 
 	// Record ingestion delay latency
@@ -431,6 +438,18 @@ func (h *PromWriteHandler) write(
 	return h.downsamplerAndWriter.WriteBatch(ctx, iter, opts)
 }
 
+// writeMetadata records the metric metadata sent alongside series, the
+// metric types are stored lower cased to match the Prometheus metadata API.
+func (h *PromWriteHandler) writeMetadata(metadataList []prompb.MetricMetadata) {
+	for _, m := range metadataList {
+		h.metadataStore.Write(m.MetricFamilyName, metadata.Metadata{
+			Type: strings.ToLower(m.Type.String()),
+			Help: m.Help,
+			Unit: m.Unit,
+		})
+	}
+}
+
 func (h *PromWriteHandler) forward(
 	ctx context.Context,
 	request prometheus.ParsePromCompressedRequestResult,
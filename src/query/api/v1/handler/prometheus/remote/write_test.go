@@ -37,6 +37,8 @@ import (
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/remote/test"
 	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	"github.com/m3db/m3/src/query/metadata"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
 	xclock "github.com/m3db/m3/src/x/clock"
@@ -102,6 +104,49 @@ func TestPromWrite(t *testing.T) {
 	require.Equal(t, http.StatusOK, resp.StatusCode)
 }
 
+func TestPromWriteMetadata(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDownsamplerAndWriter := ingest.NewMockDownsamplerAndWriter(ctrl)
+	mockDownsamplerAndWriter.
+		EXPECT().
+		WriteBatch(gomock.Any(), gomock.Any(), gomock.Any())
+
+	store, err := metadata.NewStore(metadata.NewOptions())
+	require.NoError(t, err)
+
+	opts := makeOptions(mockDownsamplerAndWriter).SetMetadataStore(store)
+	handler, err := NewPromWriteHandler(opts)
+	require.NoError(t, err)
+
+	promReq := test.GeneratePromWriteRequest()
+	promReq.Metadata = []prompb.MetricMetadata{
+		{
+			Type:             prompb.MetricMetadata_COUNTER,
+			MetricFamilyName: "http_requests_total",
+			Help:             "Total HTTP requests.",
+		},
+		{
+			Type:             prompb.MetricMetadata_GAUGEHISTOGRAM,
+			MetricFamilyName: "request_size_bytes",
+			Unit:             "bytes",
+		},
+	}
+	promReqBody := test.GeneratePromWriteRequestBody(t, promReq)
+	req := httptest.NewRequest(PromWriteHTTPMethod, PromWriteURL, promReqBody)
+
+	writer := httptest.NewRecorder()
+	handler.ServeHTTP(writer, req)
+	resp := writer.Result()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	require.Equal(t, map[string][]metadata.Metadata{
+		"http_requests_total": {{Type: "counter", Help: "Total HTTP requests."}},
+		"request_size_bytes":  {{Type: "gaugehistogram", Unit: "bytes"}},
+	}, store.Query("", 0))
+}
+
 func TestPromWriteError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
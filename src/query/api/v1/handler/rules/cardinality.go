@@ -0,0 +1,409 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/downsample"
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/m3ninx/idx"
+	"github.com/m3db/m3/src/metrics/filters"
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	// CardinalityURL is the url for the rollup rule cardinality estimation
+	// handler.
+	CardinalityURL = handler.RoutePrefixV1 + "/rules/cardinality"
+
+	// CardinalityHTTPMethod is the HTTP method used with this resource.
+	CardinalityHTTPMethod = http.MethodPost
+
+	defaultCardinalityLookback = time.Hour
+	defaultCardinalityLimit    = 100000
+
+	// globPatternChars are the characters that make a filter pattern a glob
+	// pattern rather than an exact value.
+	globPatternChars = "*?[{!"
+)
+
+var (
+	errNoFilter          = errors.New("no filter specified")
+	errNoGroupBy         = errors.New("no group by tags specified")
+	errNegativeLookback  = errors.New("lookback must not be negative")
+	errNegativeLimit     = errors.New("limit must not be negative")
+	errDuplicateGroupTag = errors.New("duplicate group by tag specified")
+)
+
+// CardinalityRequest is a request to estimate the cardinality of a rollup
+// rule. Since JSON is valid YAML, the request may be either.
+type CardinalityRequest struct {
+	// Filter is the filter of the rollup rule.
+	Filter string `yaml:"filter"`
+
+	// GroupBy are the tags the rollup rule groups by.
+	GroupBy []string `yaml:"groupBy"`
+
+	// Lookback is how far back to look for series in the index, defaults
+	// to an hour.
+	Lookback time.Duration `yaml:"lookback"`
+
+	// Limit is the maximum number of series to fetch from the index.
+	Limit int `yaml:"limit"`
+}
+
+// CardinalityResult is the estimated cardinality of a rollup rule.
+type CardinalityResult struct {
+	// InputSeries is the number of series that match the filter and have
+	// all of the group by tags.
+	InputSeries int `json:"inputSeries"`
+
+	// OutputSeries is the number of rollup series the input series
+	// roll up into.
+	OutputSeries int `json:"outputSeries"`
+
+	// Tags are the number of distinct values of each of the group by tags.
+	Tags []TagCardinality `json:"tags"`
+
+	// Exhaustive is false when the limit was reached and the results are
+	// a lower bound.
+	Exhaustive bool `json:"exhaustive"`
+}
+
+// TagCardinality is the number of distinct values of a tag.
+type TagCardinality struct {
+	// Name is the name of the tag.
+	Name string `json:"name"`
+
+	// Values is the number of distinct values of the tag across the input
+	// series.
+	Values int `json:"values"`
+
+	// IndexValues is the number of distinct values of the tag across the
+	// series matched by the index query, which also includes series that
+	// only the rule filter rejects.
+	IndexValues int `json:"indexValues"`
+}
+
+// CardinalityHandler estimates the number of input and output series of a
+// rollup rule from the series recently written to the unaggregated
+// namespace, matching series with the same filters used by the downsampler.
+type CardinalityHandler struct {
+	session        client.Session
+	namespace      ident.ID
+	downsampleOpts downsample.DownsamplerOptions
+	nowFn          clock.NowFn
+	instrumentOpts instrument.Options
+}
+
+// NewCardinalityHandler returns a new instance of a rollup rule cardinality
+// estimation handler.
+func NewCardinalityHandler(
+	session client.Session,
+	namespace ident.ID,
+	downsampleOpts downsample.DownsamplerOptions,
+	instrumentOpts instrument.Options,
+) http.Handler {
+	return &CardinalityHandler{
+		session:        session,
+		namespace:      namespace,
+		downsampleOpts: downsampleOpts,
+		nowFn:          downsampleOpts.ClockOptions.NowFn(),
+		instrumentOpts: instrumentOpts,
+	}
+}
+
+func (h *CardinalityHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		logger = logging.WithContext(ctx, h.instrumentOpts)
+	)
+
+	req, rErr := parseCardinalityRequest(r)
+	if rErr != nil {
+		logger.Error("unable to parse request", zap.Error(rErr))
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	filterValues, err := filters.ParseTagFilterValueMap(req.Filter)
+	if err != nil {
+		logger.Error("unable to parse filter", zap.Error(err))
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	ruleFilter, err := h.downsampleOpts.NewRuleFilter(req.Filter)
+	if err != nil {
+		logger.Error("unable to create filter", zap.Error(err))
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+	defer ruleFilter.Close()
+
+	query, err := newCardinalityQuery(filterValues, req.GroupBy)
+	if err != nil {
+		logger.Error("unable to create index query", zap.Error(err))
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	now := h.nowFn()
+	queryOpts := index.QueryOptions{
+		StartInclusive: now.Add(-req.Lookback),
+		EndExclusive:   now,
+		Limit:          req.Limit,
+	}
+
+	result, err := h.estimate(ruleFilter, query, queryOpts, req.GroupBy)
+	if err != nil {
+		logger.Error("unable to estimate cardinality", zap.Error(err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	xhttp.WriteJSONResponse(w, result, logger)
+}
+
+func (h *CardinalityHandler) estimate(
+	ruleFilter *downsample.RuleFilter,
+	query index.Query,
+	queryOpts index.QueryOptions,
+	groupBy []string,
+) (CardinalityResult, error) {
+	tags, aggExhaustive, err := h.tagCardinality(query, queryOpts, groupBy)
+	if err != nil {
+		return CardinalityResult{}, err
+	}
+
+	iter, meta, err := h.session.FetchTaggedIDs(h.namespace, query, queryOpts)
+	if err != nil {
+		return CardinalityResult{}, err
+	}
+	defer iter.Finalize()
+
+	var (
+		result = CardinalityResult{
+			Tags:       tags,
+			Exhaustive: aggExhaustive && meta.Exhaustive,
+		}
+		groupByIdx  = make(map[string]int, len(groupBy))
+		groupKey    = make([][]byte, len(groupBy))
+		groups      = make(map[string]struct{})
+		groupValues = make([]map[string]struct{}, len(groupBy))
+	)
+	for i, tag := range groupBy {
+		groupByIdx[tag] = i
+		groupValues[i] = make(map[string]struct{})
+	}
+
+	for iter.Next() {
+		_, _, seriesTags := iter.Current()
+		matched, err := ruleFilter.Matches(seriesTags)
+		if err != nil {
+			return CardinalityResult{}, err
+		}
+		if !matched {
+			continue
+		}
+
+		for i := range groupKey {
+			groupKey[i] = nil
+		}
+		seriesTags = seriesTags.Duplicate()
+		for seriesTags.Next() {
+			tag := seriesTags.Current()
+			if i, ok := groupByIdx[tag.Name.String()]; ok {
+				groupKey[i] = tag.Value.Bytes()
+			}
+		}
+		err = seriesTags.Err()
+		seriesTags.Close()
+		if err != nil {
+			return CardinalityResult{}, err
+		}
+
+		result.InputSeries++
+		groups[string(bytes.Join(groupKey, []byte{0}))] = struct{}{}
+		for i, value := range groupKey {
+			groupValues[i][string(value)] = struct{}{}
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return CardinalityResult{}, err
+	}
+
+	result.OutputSeries = len(groups)
+	for i := range result.Tags {
+		result.Tags[i].Values = len(groupValues[i])
+	}
+	return result, nil
+}
+
+// tagCardinality returns the number of distinct values of each group by
+// tag across all of the series matched by the index query.
+func (h *CardinalityHandler) tagCardinality(
+	query index.Query,
+	queryOpts index.QueryOptions,
+	groupBy []string,
+) ([]TagCardinality, bool, error) {
+	fieldFilter := make(index.AggregateFieldFilter, 0, len(groupBy))
+	for _, tag := range groupBy {
+		fieldFilter = append(fieldFilter, []byte(tag))
+	}
+
+	iter, meta, err := h.session.Aggregate(h.namespace, query,
+		index.AggregationOptions{
+			QueryOptions: queryOpts,
+			FieldFilter:  fieldFilter,
+			Type:         index.AggregateTagNamesAndValues,
+		})
+	if err != nil {
+		return nil, false, err
+	}
+	defer iter.Finalize()
+
+	valuesByTag := make(map[string]int, len(groupBy))
+	for iter.Next() {
+		name, values := iter.Current()
+		for values.Next() {
+			valuesByTag[name.String()]++
+		}
+		if err := values.Err(); err != nil {
+			return nil, false, err
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return nil, false, err
+	}
+
+	tags := make([]TagCardinality, 0, len(groupBy))
+	for _, tag := range groupBy {
+		tags = append(tags, TagCardinality{Name: tag, IndexValues: valuesByTag[tag]})
+	}
+	return tags, meta.Exhaustive, nil
+}
+
+// newCardinalityQuery returns an index query that matches a superset of the
+// series matched by the filter that also have all of the group by tags.
+// Exact values and regular expressions are matched by the index, all other
+// patterns only require the tag to be present and are matched by the rule
+// filter afterwards.
+func newCardinalityQuery(
+	filterValues filters.TagFilterValueMap,
+	groupBy []string,
+) (index.Query, error) {
+	names := make([]string, 0, len(filterValues))
+	for name := range filterValues {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	queries := make([]idx.Query, 0, len(names)+len(groupBy))
+	for _, name := range names {
+		pattern := filterValues[name].Pattern
+		switch {
+		case strings.HasPrefix(pattern, filters.RegexpPrefix):
+			q, err := idx.NewRegexpQuery([]byte(name),
+				[]byte(strings.TrimPrefix(pattern, filters.RegexpPrefix)))
+			if err != nil {
+				return index.Query{}, err
+			}
+			queries = append(queries, q)
+		case !strings.ContainsAny(pattern, globPatternChars):
+			queries = append(queries, idx.NewTermQuery([]byte(name), []byte(pattern)))
+		default:
+			queries = append(queries, idx.NewFieldQuery([]byte(name)))
+		}
+	}
+	for _, tag := range groupBy {
+		if _, ok := filterValues[tag]; ok {
+			continue
+		}
+		queries = append(queries, idx.NewFieldQuery([]byte(tag)))
+	}
+
+	if len(queries) == 0 {
+		return index.Query{Query: idx.NewAllQuery()}, nil
+	}
+	return index.Query{Query: idx.NewConjunctionQuery(queries...)}, nil
+}
+
+func parseCardinalityRequest(r *http.Request) (CardinalityRequest, *xhttp.ParseError) {
+	defer r.Body.Close()
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return CardinalityRequest{}, xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+
+	var req CardinalityRequest
+	if err := yaml.UnmarshalStrict(body, &req); err != nil {
+		return CardinalityRequest{}, xhttp.NewParseError(
+			fmt.Errorf("unable to parse request: %v", err), http.StatusBadRequest)
+	}
+	if strings.TrimSpace(req.Filter) == "" {
+		return CardinalityRequest{}, xhttp.NewParseError(errNoFilter, http.StatusBadRequest)
+	}
+	if len(req.GroupBy) == 0 {
+		return CardinalityRequest{}, xhttp.NewParseError(errNoGroupBy, http.StatusBadRequest)
+	}
+	seen := make(map[string]struct{}, len(req.GroupBy))
+	for _, tag := range req.GroupBy {
+		if _, ok := seen[tag]; ok {
+			return CardinalityRequest{}, xhttp.NewParseError(errDuplicateGroupTag,
+				http.StatusBadRequest)
+		}
+		seen[tag] = struct{}{}
+	}
+	if req.Lookback < 0 {
+		return CardinalityRequest{}, xhttp.NewParseError(errNegativeLookback,
+			http.StatusBadRequest)
+	}
+	if req.Limit < 0 {
+		return CardinalityRequest{}, xhttp.NewParseError(errNegativeLimit,
+			http.StatusBadRequest)
+	}
+	if req.Lookback == 0 {
+		req.Lookback = defaultCardinalityLookback
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultCardinalityLimit
+	}
+	return req, nil
+}
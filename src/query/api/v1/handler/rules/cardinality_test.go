@@ -0,0 +1,206 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/m3ninx/idx"
+	"github.com/m3db/m3/src/metrics/filters"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardinalityHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		now       = time.Unix(1600000000, 0)
+		namespace = ident.StringID("default")
+		session   = client.NewMockSession(ctrl)
+		series    = []map[string]string{
+			{"__name__": "http_requests", "app": "nginx", "region": "us-east", "status": "200"},
+			{"__name__": "http_requests", "app": "nginx", "region": "us-west", "status": "200"},
+			{"__name__": "http_requests", "app": "nginx", "region": "us-west", "status": "500"},
+			{"__name__": "http_requests", "app": "envoy", "region": "us-east", "status": "200"},
+			// Matched by the index query but not by the rule filter.
+			{"__name__": "http_requests", "app": "nginx-proxy", "region": "us-east", "status": "200"},
+		}
+		expectedQuery = index.Query{Query: idx.NewConjunctionQuery(
+			idx.NewTermQuery([]byte("__name__"), []byte("http_requests")),
+			idx.NewFieldQuery([]byte("app")),
+			idx.NewFieldQuery([]byte("region")),
+		)}
+		expectedOpts = index.QueryOptions{
+			StartInclusive: now.Add(-30 * time.Minute),
+			EndExclusive:   now,
+			Limit:          defaultCardinalityLimit,
+		}
+	)
+
+	aggIter := client.NewMockAggregatedTagsIterator(ctrl)
+	gomock.InOrder(
+		aggIter.EXPECT().Next().Return(true),
+		aggIter.EXPECT().Current().Return(ident.StringID("app"),
+			ident.NewStringIDsIterator("envoy", "nginx", "nginx-proxy")),
+		aggIter.EXPECT().Next().Return(true),
+		aggIter.EXPECT().Current().Return(ident.StringID("region"),
+			ident.NewStringIDsIterator("us-east", "us-west")),
+		aggIter.EXPECT().Next().Return(false),
+		aggIter.EXPECT().Err().Return(nil),
+		aggIter.EXPECT().Finalize(),
+	)
+	session.EXPECT().
+		Aggregate(namespace, expectedQuery, index.AggregationOptions{
+			QueryOptions: expectedOpts,
+			FieldFilter:  index.AggregateFieldFilter{[]byte("app"), []byte("region")},
+			Type:         index.AggregateTagNamesAndValues,
+		}).
+		Return(aggIter, client.FetchResponseMetadata{Exhaustive: true}, nil)
+
+	session.EXPECT().
+		FetchTaggedIDs(namespace, expectedQuery, expectedOpts).
+		Return(newTestTaggedIDsIterator(ctrl, namespace, series),
+			client.FetchResponseMetadata{Exhaustive: true}, nil)
+
+	clockOpts := clock.NewOptions().SetNowFn(func() time.Time { return now })
+	handler := NewCardinalityHandler(session, namespace,
+		newTestDownsamplerOptions(clockOpts), instrument.NewOptions())
+
+	req := httptest.NewRequest(CardinalityHTTPMethod, CardinalityURL,
+		strings.NewReader(`{
+			"filter": "__name__:http_requests app:{nginx,envoy}",
+			"groupBy": ["app", "region"],
+			"lookback": "30m"
+		}`))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	resp := w.Result()
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+	var result CardinalityResult
+	require.NoError(t, json.Unmarshal(body, &result))
+	require.Equal(t, CardinalityResult{
+		InputSeries:  4,
+		OutputSeries: 3,
+		Tags: []TagCardinality{
+			{Name: "app", Values: 2, IndexValues: 3},
+			{Name: "region", Values: 2, IndexValues: 2},
+		},
+		Exhaustive: true,
+	}, result)
+}
+
+func TestNewCardinalityQuery(t *testing.T) {
+	regexpQuery, err := idx.NewRegexpQuery([]byte("app"), []byte("nginx|envoy"))
+	require.NoError(t, err)
+
+	query, err := newCardinalityQuery(map[string]filters.FilterValue{
+		"app":    {Pattern: "re:nginx|envoy"},
+		"env":    {Pattern: "!staging"},
+		"region": {Pattern: "us-*"},
+		"status": {Pattern: "200"},
+	}, []string{"region", "zone"})
+	require.NoError(t, err)
+	require.True(t, query.Equal(idx.NewConjunctionQuery(
+		regexpQuery,
+		idx.NewFieldQuery([]byte("env")),
+		idx.NewFieldQuery([]byte("region")),
+		idx.NewTermQuery([]byte("status"), []byte("200")),
+		idx.NewFieldQuery([]byte("zone")),
+	)), query.String())
+
+	query, err = newCardinalityQuery(nil, nil)
+	require.NoError(t, err)
+	require.True(t, query.Equal(idx.NewAllQuery()))
+
+	_, err = newCardinalityQuery(map[string]filters.FilterValue{
+		"app": {Pattern: "re:(abc"},
+	}, nil)
+	require.Error(t, err)
+}
+
+func TestCardinalityHandlerInvalidRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := NewCardinalityHandler(client.NewMockSession(ctrl),
+		ident.StringID("default"), newTestDownsamplerOptions(clock.NewOptions()),
+		instrument.NewOptions())
+
+	for _, body := range []string{
+		`{"groupBy": ["app"]}`,
+		`{"filter": "app:*"}`,
+		`{"filter": "app:*", "groupBy": ["app", "app"]}`,
+		`{"filter": "app:*", "groupBy": ["app"], "limit": -1}`,
+		`{"filter": "app:*", "groupBy": ["app"], "lookback": "-1h"}`,
+		`{"filter": "app:*", "groupBy": ["app"], "unknown": true}`,
+		`{"filter": "app", "groupBy": ["app"]}`,
+		`{"filter": "app:re:(abc", "groupBy": ["app"]}`,
+	} {
+		req := httptest.NewRequest(CardinalityHTTPMethod, CardinalityURL,
+			strings.NewReader(body))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		require.Equal(t, http.StatusBadRequest, w.Result().StatusCode, body)
+	}
+}
+
+func newTestTaggedIDsIterator(
+	ctrl *gomock.Controller,
+	namespace ident.ID,
+	series []map[string]string,
+) client.TaggedIDsIterator {
+	iter := client.NewMockTaggedIDsIterator(ctrl)
+	calls := make([]*gomock.Call, 0, 2*len(series)+3)
+	for _, tagsByName := range series {
+		tags := make([]ident.Tag, 0, len(tagsByName))
+		for name, value := range tagsByName {
+			tags = append(tags, ident.StringTag(name, value))
+		}
+		calls = append(calls,
+			iter.EXPECT().Next().Return(true),
+			iter.EXPECT().Current().Return(namespace, ident.StringID("id"),
+				ident.NewTagsIterator(ident.NewTags(tags...))))
+	}
+	calls = append(calls,
+		iter.EXPECT().Next().Return(false),
+		iter.EXPECT().Err().Return(nil),
+		iter.EXPECT().Finalize())
+	gomock.InOrder(calls...)
+	return iter
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"net/http"
+
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/downsample"
+	"github.com/m3db/m3/src/cmd/services/m3query/config"
+	"github.com/m3db/m3/src/query/storage/m3"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/instrument"
+	"github.com/m3db/m3/src/x/pool"
+	"github.com/m3db/m3/src/x/serialize"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	// Requests only encode and decode a handful of series at a time.
+	dryRunPoolSize = 16
+)
+
+// RegisterRoutes registers the downsampling rules routes, the rollup rule
+// cardinality estimation route is only registered when the coordinator is
+// connected to a dbnode cluster.
+func RegisterRoutes(
+	r *mux.Router,
+	cfg config.Configuration,
+	clusters m3.Clusters,
+	instrumentOpts instrument.Options,
+) {
+	wrapped := func(n http.Handler) http.Handler {
+		return logging.WithResponseTimeAndPanicErrorLogging(n, instrumentOpts)
+	}
+
+	poolOpts := pool.NewObjectPoolOptions().
+		SetSize(dryRunPoolSize).
+		SetInstrumentOptions(instrumentOpts)
+	downsampleOpts := downsample.DownsamplerOptions{
+		ClockOptions:          clock.NewOptions(),
+		InstrumentOptions:     instrumentOpts,
+		TagEncoderOptions:     serialize.NewTagEncoderOptions(),
+		TagDecoderOptions:     serialize.NewTagDecoderOptions(serialize.TagDecoderOptionsConfig{}),
+		TagEncoderPoolOptions: poolOpts,
+		TagDecoderPoolOptions: poolOpts,
+	}
+
+	r.HandleFunc(DryRunURL,
+		wrapped(NewDryRunHandler(cfg.Downsample.Rules, downsampleOpts,
+			instrumentOpts)).ServeHTTP).
+		Methods(DryRunHTTPMethod)
+
+	if clusters == nil {
+		return
+	}
+
+	unaggregated := clusters.UnaggregatedClusterNamespace()
+	r.HandleFunc(CardinalityURL,
+		wrapped(NewCardinalityHandler(unaggregated.Session(),
+			unaggregated.NamespaceID(), downsampleOpts, instrumentOpts)).ServeHTTP).
+		Methods(CardinalityHTTPMethod)
+}
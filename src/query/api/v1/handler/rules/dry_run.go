@@ -0,0 +1,132 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/downsample"
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	// DryRunURL is the url for the downsampling rules dry run handler.
+	DryRunURL = handler.RoutePrefixV1 + "/rules/dry-run"
+
+	// DryRunHTTPMethod is the HTTP method used with this resource.
+	DryRunHTTPMethod = http.MethodPost
+)
+
+var (
+	errNoRules  = errors.New("no proposed rules specified")
+	errNoSeries = errors.New("no series specified")
+)
+
+// DryRunRequest is a request to match series against a proposed set of
+// downsampling rules. Since JSON is valid YAML, the request may be either.
+type DryRunRequest struct {
+	// Rules are the proposed downsampling rules, an empty set of rules
+	// proposes removing all of the configured rules.
+	Rules *downsample.RulesConfiguration `yaml:"rules"`
+
+	// Series are the tags of each series to match, including the metric
+	// name tag.
+	Series []map[string]string `yaml:"series"`
+}
+
+// DryRunHandler matches series against the downsampling rules set in the
+// coordinator configuration and a proposed set of rules, returning the
+// storage policies, pipelines, rollup IDs and drop decisions for each
+// series before and after the change.
+type DryRunHandler struct {
+	rules          *downsample.RulesConfiguration
+	downsampleOpts downsample.DownsamplerOptions
+	instrumentOpts instrument.Options
+}
+
+// NewDryRunHandler returns a new instance of a downsampling rules dry run
+// handler, the configured rules are nil when the coordinator does not set
+// any rules in its configuration.
+func NewDryRunHandler(
+	rules *downsample.RulesConfiguration,
+	downsampleOpts downsample.DownsamplerOptions,
+	instrumentOpts instrument.Options,
+) http.Handler {
+	return &DryRunHandler{
+		rules:          rules,
+		downsampleOpts: downsampleOpts,
+		instrumentOpts: instrumentOpts,
+	}
+}
+
+func (h *DryRunHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		logger = logging.WithContext(ctx, h.instrumentOpts)
+	)
+
+	req, rErr := parseDryRunRequest(r)
+	if rErr != nil {
+		logger.Error("unable to parse request", zap.Error(rErr))
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	result, err := h.downsampleOpts.DryRunRules(h.rules, req.Rules, req.Series)
+	if err != nil {
+		logger.Error("unable to dry run rules", zap.Error(err))
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	xhttp.WriteJSONResponse(w, result, logger)
+}
+
+func parseDryRunRequest(r *http.Request) (DryRunRequest, *xhttp.ParseError) {
+	defer r.Body.Close()
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return DryRunRequest{}, xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+
+	var req DryRunRequest
+	if err := yaml.UnmarshalStrict(body, &req); err != nil {
+		return DryRunRequest{}, xhttp.NewParseError(
+			fmt.Errorf("unable to parse request: %v", err), http.StatusBadRequest)
+	}
+	if req.Rules == nil {
+		return DryRunRequest{}, xhttp.NewParseError(errNoRules, http.StatusBadRequest)
+	}
+	if len(req.Series) == 0 {
+		return DryRunRequest{}, xhttp.NewParseError(errNoSeries, http.StatusBadRequest)
+	}
+	return req, nil
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/downsample"
+	"github.com/m3db/m3/src/metrics/aggregation"
+	"github.com/m3db/m3/src/metrics/rules/view"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/instrument"
+	"github.com/m3db/m3/src/x/pool"
+	"github.com/m3db/m3/src/x/serialize"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRunHandler(t *testing.T) {
+	configured := &downsample.RulesConfiguration{
+		MappingRules: []downsample.MappingRuleConfiguration{
+			{
+				Filter:       "app:*",
+				Aggregations: []aggregation.Type{aggregation.Max},
+				StoragePolicies: []downsample.StoragePolicyConfiguration{
+					{Resolution: time.Minute, Retention: 48 * time.Hour},
+				},
+			},
+		},
+	}
+	handler := newTestDryRunHandler(configured)
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{
+			name: "json",
+			body: `{
+				"rules": {"mappingRules": [{"filter": "app:nginx*", "drop": true}]},
+				"series": [
+					{"__name__": "http_requests", "app": "nginx"},
+					{"__name__": "http_requests", "app": "envoy"}
+				]
+			}`,
+		},
+		{
+			name: "yaml",
+			body: `
+rules:
+  mappingRules:
+    - filter: "app:nginx*"
+      drop: true
+series:
+  - __name__: http_requests
+    app: nginx
+  - __name__: http_requests
+    app: envoy
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(DryRunHTTPMethod, DryRunURL,
+				strings.NewReader(test.body))
+			handler.ServeHTTP(w, req)
+
+			resp := w.Result()
+			body, err := ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+			var result view.DryRunMatches
+			require.NoError(t, json.Unmarshal(body, &result))
+			require.Equal(t, 2, len(result.Matches))
+
+			nginx := result.Matches[0]
+			require.Equal(t, `{__name__="http_requests",app="nginx"}`, nginx.ID)
+			require.True(t, nginx.Changed)
+			require.False(t, nginx.Before.Dropped)
+			require.Equal(t, 1, len(nginx.Before.Pipelines))
+			require.True(t, nginx.After.Dropped)
+
+			// Envoy no longer matches any rule.
+			envoy := result.Matches[1]
+			require.True(t, envoy.Changed)
+			require.Equal(t, 1, len(envoy.Before.Pipelines))
+			require.Empty(t, envoy.After.Pipelines)
+		})
+	}
+}
+
+func TestDryRunHandlerBadRequest(t *testing.T) {
+	handler := newTestDryRunHandler(nil)
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{
+			name: "malformed",
+			body: `{"rules":`,
+		},
+		{
+			name: "unknown field",
+			body: `{"rules": {}, "series": [{"app": "nginx"}], "foo": 1}`,
+		},
+		{
+			name: "no rules",
+			body: `{"series": [{"app": "nginx"}]}`,
+		},
+		{
+			name: "no series",
+			body: `{"rules": {}}`,
+		},
+		{
+			name: "invalid rule",
+			body: `{
+				"rules": {"mappingRules": [{"filter": "app:re:(abc", "drop": true}]},
+				"series": [{"app": "nginx"}]
+			}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(DryRunHTTPMethod, DryRunURL,
+				strings.NewReader(test.body))
+			handler.ServeHTTP(w, req)
+			require.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		})
+	}
+}
+
+func newTestDryRunHandler(rules *downsample.RulesConfiguration) http.Handler {
+	return NewDryRunHandler(rules, newTestDownsamplerOptions(clock.NewOptions()),
+		instrument.NewOptions())
+}
+
+func newTestDownsamplerOptions(clockOpts clock.Options) downsample.DownsamplerOptions {
+	poolOpts := pool.NewObjectPoolOptions().SetSize(2)
+	return downsample.DownsamplerOptions{
+		ClockOptions:          clockOpts,
+		TagEncoderOptions:     serialize.NewTagEncoderOptions(),
+		TagDecoderOptions:     serialize.NewTagDecoderOptions(serialize.TagDecoderOptionsConfig{}),
+		TagEncoderPoolOptions: poolOpts,
+		TagDecoderPoolOptions: poolOpts,
+	}
+}
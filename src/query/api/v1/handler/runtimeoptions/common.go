@@ -0,0 +1,181 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package runtimeoptions
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	clusterclient "github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/cluster/kv/util/history"
+	"github.com/m3db/m3/src/cluster/kv/util/runtime"
+	"github.com/m3db/m3/src/dbnode/kvconfig"
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	qcost "github.com/m3db/m3/src/query/cost"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	optionKeyVar = "key"
+)
+
+var (
+	// ListURL is the url for the runtime options list handler.
+	ListURL = handler.RoutePrefixV1 + "/runtime/options"
+
+	// ListHTTPMethod is the HTTP method used with the list resource.
+	ListHTTPMethod = http.MethodGet
+
+	// GetURL is the url for the runtime option get handler.
+	GetURL = fmt.Sprintf("%s/{%s}", ListURL, optionKeyVar)
+
+	// GetHTTPMethod is the HTTP method used with the get resource.
+	GetHTTPMethod = http.MethodGet
+
+	// SetURL is the url for the runtime option set handler.
+	SetURL = GetURL
+
+	// SetHTTPMethod is the HTTP method used with the set resource.
+	SetHTTPMethod = http.MethodPost
+
+	// DeleteURL is the url for the runtime option delete handler.
+	DeleteURL = GetURL
+
+	// DeleteHTTPMethod is the HTTP method used with the delete resource.
+	DeleteHTTPMethod = http.MethodDelete
+
+	// HistoryURL is the url for the runtime option history handler.
+	HistoryURL = GetURL + "/history"
+
+	// HistoryHTTPMethod is the HTTP method used with the history resource.
+	HistoryHTTPMethod = http.MethodGet
+)
+
+// Handler represents a generic handler for runtime option endpoints.
+type Handler struct {
+	client         clusterclient.Client
+	registry       runtime.Registry
+	historyOpts    history.Options
+	instrumentOpts instrument.Options
+}
+
+// NewRegistry returns a registry of the runtime options of the database nodes
+// and the coordinator.
+func NewRegistry() (runtime.Registry, error) {
+	registry := runtime.NewRegistry()
+	if err := registry.Register(kvconfig.RuntimeOptionDefinitions()...); err != nil {
+		return nil, err
+	}
+	if err := registry.Register(qcost.RuntimeOptionDefinitions()...); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
+// RegisterRoutes registers the runtime option routes, the given number of
+// previous values of each option is kept in its audit log.
+func RegisterRoutes(
+	r *mux.Router,
+	client clusterclient.Client,
+	historyLimit int,
+	instrumentOpts instrument.Options,
+) error {
+	registry, err := NewRegistry()
+	if err != nil {
+		return err
+	}
+
+	wrapped := func(n http.Handler) http.Handler {
+		return logging.WithResponseTimeAndPanicErrorLogging(n, instrumentOpts)
+	}
+	historyOpts := history.NewOptions().SetLimit(historyLimit)
+
+	r.HandleFunc(ListURL,
+		wrapped(NewListHandler(client, registry, historyOpts, instrumentOpts)).ServeHTTP).
+		Methods(ListHTTPMethod)
+	r.HandleFunc(HistoryURL,
+		wrapped(NewHistoryHandler(client, registry, historyOpts, instrumentOpts)).ServeHTTP).
+		Methods(HistoryHTTPMethod)
+	r.HandleFunc(GetURL,
+		wrapped(NewGetHandler(client, registry, historyOpts, instrumentOpts)).ServeHTTP).
+		Methods(GetHTTPMethod)
+	r.HandleFunc(SetURL,
+		wrapped(NewSetHandler(client, registry, historyOpts, instrumentOpts)).ServeHTTP).
+		Methods(SetHTTPMethod)
+	r.HandleFunc(DeleteURL,
+		wrapped(NewDeleteHandler(client, registry, historyOpts, instrumentOpts)).ServeHTTP).
+		Methods(DeleteHTTPMethod)
+	return nil
+}
+
+// OptionResponse is the JSON representation of a runtime option, the value is
+// null and the version zero when the option is not set.
+type OptionResponse struct {
+	Key         string      `json:"key"`
+	Type        string      `json:"type"`
+	Description string      `json:"description"`
+	Default     interface{} `json:"default"`
+	Value       interface{} `json:"value"`
+	Version     int         `json:"version"`
+}
+
+// ChangeResponse is the JSON representation of a change of a runtime option,
+// the value is null when the option was deleted.
+type ChangeResponse struct {
+	Version   int         `json:"version"`
+	Value     interface{} `json:"value"`
+	Deleted   bool        `json:"deleted"`
+	UpdatedAt time.Time   `json:"updatedAt"`
+	Operator  string      `json:"operator"`
+	Reason    string      `json:"reason"`
+}
+
+func (h *Handler) optionStore() (runtime.OptionStore, error) {
+	store, err := h.client.KV()
+	if err != nil {
+		return nil, err
+	}
+	return runtime.NewOptionStore(store, h.registry, h.historyOpts), nil
+}
+
+func newOptionResponse(value runtime.OptionValue) OptionResponse {
+	def := value.Definition
+	resp := OptionResponse{
+		Key:         def.Key,
+		Type:        def.Type.String(),
+		Description: def.Description,
+		Default:     def.FormatValue(def.Default),
+		Version:     value.Version,
+	}
+	if value.Value != nil {
+		resp.Value = def.FormatValue(value.Value)
+	}
+	return resp
+}
+
+func optionKey(r *http.Request) string {
+	return strings.TrimSpace(mux.Vars(r)[optionKeyVar])
+}
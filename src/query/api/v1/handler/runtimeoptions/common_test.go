@@ -0,0 +1,101 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package runtimeoptions
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/cluster/kv/mem"
+	"github.com/m3db/m3/src/cluster/kv/util/history"
+	"github.com/m3db/m3/src/dbnode/kvconfig"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	qcost "github.com/m3db/m3/src/query/cost"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+// setupRuntimeOptionsTest returns a router serving the runtime option routes
+// with a client backed by an in memory store.
+func setupRuntimeOptionsTest(t *testing.T, ctrl *gomock.Controller) *mux.Router {
+	mockClient := client.NewMockClient(ctrl)
+	mockClient.EXPECT().KV().Return(mem.NewStore(), nil).AnyTimes()
+
+	router := mux.NewRouter()
+	require.NoError(t, RegisterRoutes(router, mockClient, history.DefaultLimit, instrument.NewOptions()))
+	return router
+}
+
+func serveRequest(
+	router *mux.Router,
+	method, url string,
+	body interface{},
+	headers map[string]string,
+) *http.Response {
+	var buf bytes.Buffer
+	if body != nil {
+		json.NewEncoder(&buf).Encode(body)
+	}
+
+	req := httptest.NewRequest(method, url, &buf)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w.Result()
+}
+
+func setOption(
+	router *mux.Router,
+	key string,
+	value interface{},
+	operator, reason string,
+) *http.Response {
+	return serveRequest(router, SetHTTPMethod, ListURL+"/"+key,
+		SetRequest{Value: value}, map[string]string{
+			handleroptions.HeaderChangeOperator: operator,
+			handleroptions.HeaderChangeReason:   reason,
+		})
+}
+
+func TestNewRegistry(t *testing.T) {
+	registry, err := NewRegistry()
+	require.NoError(t, err)
+
+	for _, key := range []string{
+		kvconfig.ClusterNewSeriesInsertLimitKey,
+		kvconfig.IndexMaxQueryLimitKey,
+		qcost.GlobalMaxFetchedDatapointsKey,
+		qcost.PerQueryMaxFetchedDatapointsKey,
+	} {
+		_, ok := registry.Definition(key)
+		require.True(t, ok, key)
+	}
+}
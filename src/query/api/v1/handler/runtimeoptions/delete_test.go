@@ -0,0 +1,88 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package runtimeoptions
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/m3db/m3/src/dbnode/kvconfig"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	router := setupRuntimeOptionsTest(t, ctrl)
+	key := kvconfig.IndexMaxQueryLimitKey
+	resp := setOption(router, key, 1000, "alice", "limit queries")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = serveRequest(router, DeleteHTTPMethod, ListURL+"/"+key, nil, map[string]string{
+		handleroptions.HeaderChangeOperator: "bob",
+		handleroptions.HeaderChangeReason:   "use configured limit",
+	})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var option OptionResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&option))
+	assert.Equal(t, key, option.Key)
+	assert.Nil(t, option.Value)
+	assert.Equal(t, 0, option.Version)
+
+	resp = serveRequest(router, GetHTTPMethod, ListURL+"/"+key, nil, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&option))
+	assert.Nil(t, option.Value)
+	assert.Equal(t, 0, option.Version)
+
+	resp = serveRequest(router, HistoryHTTPMethod, ListURL+"/"+key+"/history", nil, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var historyResp HistoryResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&historyResp))
+	require.Equal(t, 2, len(historyResp.Changes))
+	deleted := historyResp.Changes[1]
+	assert.True(t, deleted.Deleted)
+	assert.Nil(t, deleted.Value)
+	assert.Equal(t, 1, deleted.Version)
+	assert.Equal(t, "bob", deleted.Operator)
+	assert.Equal(t, "use configured limit", deleted.Reason)
+
+	// Deleting an option that is not set fails.
+	resp = serveRequest(router, DeleteHTTPMethod, ListURL+"/"+key, nil, nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestDeleteHandlerUnknownOption(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	router := setupRuntimeOptionsTest(t, ctrl)
+	resp := serveRequest(router, DeleteHTTPMethod, ListURL+"/unknown", nil, nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package runtimeoptions
+
+import (
+	"net/http"
+
+	clusterclient "github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/cluster/kv/util/history"
+	"github.com/m3db/m3/src/cluster/kv/util/runtime"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+)
+
+// ListResponse is the response of the runtime options list handler.
+type ListResponse struct {
+	Options []OptionResponse `json:"options"`
+}
+
+// ListHandler is the handler listing the runtime options.
+type ListHandler Handler
+
+// NewListHandler returns a new instance of ListHandler.
+func NewListHandler(
+	client clusterclient.Client,
+	registry runtime.Registry,
+	historyOpts history.Options,
+	instrumentOpts instrument.Options,
+) *ListHandler {
+	return &ListHandler{
+		client:         client,
+		registry:       registry,
+		historyOpts:    historyOpts,
+		instrumentOpts: instrumentOpts,
+	}
+}
+
+func (h *ListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.WithContext(ctx, h.instrumentOpts)
+
+	store, err := (*Handler)(h).optionStore()
+	if err != nil {
+		logger.Error("unable to get kv store", zap.Error(err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	defs := h.registry.Definitions()
+	resp := ListResponse{Options: make([]OptionResponse, 0, len(defs))}
+	for _, def := range defs {
+		value, err := store.Get(def.Key)
+		if err != nil {
+			logger.Error("unable to get runtime option",
+				zap.String("key", def.Key), zap.Error(err))
+			xhttp.Error(w, err, http.StatusInternalServerError)
+			return
+		}
+		resp.Options = append(resp.Options, newOptionResponse(value))
+	}
+
+	xhttp.WriteJSONResponse(w, resp, logger)
+}
+
+// GetHandler is the handler returning a runtime option.
+type GetHandler Handler
+
+// NewGetHandler returns a new instance of GetHandler.
+func NewGetHandler(
+	client clusterclient.Client,
+	registry runtime.Registry,
+	historyOpts history.Options,
+	instrumentOpts instrument.Options,
+) *GetHandler {
+	return &GetHandler{
+		client:         client,
+		registry:       registry,
+		historyOpts:    historyOpts,
+		instrumentOpts: instrumentOpts,
+	}
+}
+
+func (h *GetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.WithContext(ctx, h.instrumentOpts)
+
+	key := optionKey(r)
+	if _, ok := h.registry.Definition(key); !ok {
+		xhttp.Error(w, runtime.ErrUnknownOption, http.StatusNotFound)
+		return
+	}
+
+	store, err := (*Handler)(h).optionStore()
+	if err != nil {
+		logger.Error("unable to get kv store", zap.Error(err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	value, err := store.Get(key)
+	if err != nil {
+		logger.Error("unable to get runtime option",
+			zap.String("key", key), zap.Error(err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	xhttp.WriteJSONResponse(w, newOptionResponse(value), logger)
+}
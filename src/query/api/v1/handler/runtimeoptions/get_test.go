@@ -0,0 +1,105 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package runtimeoptions
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/m3db/m3/src/dbnode/kvconfig"
+	m3dbruntime "github.com/m3db/m3/src/dbnode/runtime"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	router := setupRuntimeOptionsTest(t, ctrl)
+	resp := setOption(router, kvconfig.IndexMaxQueryLimitKey, 1000, "alice", "limit queries")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = serveRequest(router, ListHTTPMethod, ListURL, nil, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var listResp ListResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&listResp))
+
+	registry, err := NewRegistry()
+	require.NoError(t, err)
+	require.Equal(t, len(registry.Definitions()), len(listResp.Options))
+
+	options := make(map[string]OptionResponse, len(listResp.Options))
+	for i, option := range listResp.Options {
+		if i > 0 {
+			assert.True(t, listResp.Options[i-1].Key < option.Key)
+		}
+		options[option.Key] = option
+	}
+
+	limit := options[kvconfig.IndexMaxQueryLimitKey]
+	assert.Equal(t, "int64", limit.Type)
+	assert.Equal(t, float64(1000), limit.Value)
+	assert.Equal(t, float64(0), limit.Default)
+	assert.Equal(t, 1, limit.Version)
+
+	tick := options[kvconfig.TickMinimumIntervalKey]
+	assert.Equal(t, "duration", tick.Type)
+	expected := m3dbruntime.NewOptions().TickMinimumInterval().String()
+	assert.Nil(t, tick.Value)
+	assert.Equal(t, expected, tick.Default)
+	assert.Equal(t, 0, tick.Version)
+}
+
+func TestGetHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	router := setupRuntimeOptionsTest(t, ctrl)
+	resp := setOption(router, kvconfig.WriteNewSeriesBackoffDurationKey, "5ms", "alice", "backoff")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = serveRequest(router, GetHTTPMethod,
+		ListURL+"/"+kvconfig.WriteNewSeriesBackoffDurationKey, nil, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var option OptionResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&option))
+	assert.Equal(t, kvconfig.WriteNewSeriesBackoffDurationKey, option.Key)
+	assert.Equal(t, "duration", option.Type)
+	assert.Equal(t, "5ms", option.Value)
+	assert.Equal(t, "0s", option.Default)
+	assert.Equal(t, 1, option.Version)
+	assert.NotEmpty(t, option.Description)
+}
+
+func TestGetHandlerUnknownOption(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	router := setupRuntimeOptionsTest(t, ctrl)
+	resp := serveRequest(router, GetHTTPMethod, ListURL+"/unknown", nil, nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
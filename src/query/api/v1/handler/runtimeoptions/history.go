@@ -0,0 +1,105 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package runtimeoptions
+
+import (
+	"net/http"
+
+	clusterclient "github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/cluster/kv/util/history"
+	"github.com/m3db/m3/src/cluster/kv/util/runtime"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+)
+
+// HistoryResponse is the response of the runtime option history handler.
+type HistoryResponse struct {
+	Key     string           `json:"key"`
+	Changes []ChangeResponse `json:"changes"`
+}
+
+// HistoryHandler is the handler returning the audit log of a runtime option.
+type HistoryHandler Handler
+
+// NewHistoryHandler returns a new instance of HistoryHandler.
+func NewHistoryHandler(
+	client clusterclient.Client,
+	registry runtime.Registry,
+	historyOpts history.Options,
+	instrumentOpts instrument.Options,
+) *HistoryHandler {
+	return &HistoryHandler{
+		client:         client,
+		registry:       registry,
+		historyOpts:    historyOpts,
+		instrumentOpts: instrumentOpts,
+	}
+}
+
+func (h *HistoryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.WithContext(ctx, h.instrumentOpts)
+
+	key := optionKey(r)
+	def, ok := h.registry.Definition(key)
+	if !ok {
+		xhttp.Error(w, runtime.ErrUnknownOption, http.StatusNotFound)
+		return
+	}
+
+	store, err := (*Handler)(h).optionStore()
+	if err != nil {
+		logger.Error("unable to get kv store", zap.Error(err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	changes, err := store.Changes(key)
+	if err != nil {
+		logger.Error("unable to get runtime option history",
+			zap.String("key", key), zap.Error(err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	resp := HistoryResponse{
+		Key:     key,
+		Changes: make([]ChangeResponse, 0, len(changes)),
+	}
+	for _, change := range changes {
+		changeResp := ChangeResponse{
+			Version:   change.Version,
+			Deleted:   change.Deleted,
+			UpdatedAt: change.UpdatedAt,
+			Operator:  change.Metadata.Operator,
+			Reason:    change.Metadata.Reason,
+		}
+		if !change.Deleted {
+			changeResp.Value = def.FormatValue(change.Value)
+		}
+		resp.Changes = append(resp.Changes, changeResp)
+	}
+
+	xhttp.WriteJSONResponse(w, resp, logger)
+}
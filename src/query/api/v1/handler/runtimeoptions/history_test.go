@@ -0,0 +1,73 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package runtimeoptions
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/m3db/m3/src/dbnode/kvconfig"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	router := setupRuntimeOptionsTest(t, ctrl)
+	key := kvconfig.IndexDefaultQueryTimeoutKey
+	resp := setOption(router, key, "30s", "alice", "slow queries")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp = setOption(router, key, "1m", "bob", "slower queries")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = serveRequest(router, HistoryHTTPMethod, ListURL+"/"+key+"/history", nil, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var historyResp HistoryResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&historyResp))
+	assert.Equal(t, key, historyResp.Key)
+	require.Equal(t, 2, len(historyResp.Changes))
+
+	first, second := historyResp.Changes[0], historyResp.Changes[1]
+	assert.Equal(t, 1, first.Version)
+	assert.Equal(t, "30s", first.Value)
+	assert.Equal(t, "alice", first.Operator)
+	assert.Equal(t, "slow queries", first.Reason)
+	assert.False(t, first.UpdatedAt.IsZero())
+	assert.Equal(t, 2, second.Version)
+	assert.Equal(t, "1m0s", second.Value)
+	assert.Equal(t, "bob", second.Operator)
+	assert.Equal(t, "slower queries", second.Reason)
+}
+
+func TestHistoryHandlerUnknownOption(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	router := setupRuntimeOptionsTest(t, ctrl)
+	resp := serveRequest(router, HistoryHTTPMethod, ListURL+"/unknown/history", nil, nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
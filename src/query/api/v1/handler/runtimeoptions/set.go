@@ -0,0 +1,121 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package runtimeoptions
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	clusterclient "github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/cluster/kv/util/history"
+	"github.com/m3db/m3/src/cluster/kv/util/runtime"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+)
+
+var (
+	errNoValue = errors.New("no value")
+)
+
+// SetRequest is the request of the runtime option set handler.
+type SetRequest struct {
+	Value interface{} `json:"value"`
+}
+
+// SetHandler is the handler updating a runtime option.
+type SetHandler Handler
+
+// NewSetHandler returns a new instance of SetHandler.
+func NewSetHandler(
+	client clusterclient.Client,
+	registry runtime.Registry,
+	historyOpts history.Options,
+	instrumentOpts instrument.Options,
+) *SetHandler {
+	return &SetHandler{
+		client:         client,
+		registry:       registry,
+		historyOpts:    historyOpts,
+		instrumentOpts: instrumentOpts,
+	}
+}
+
+func (h *SetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.WithContext(ctx, h.instrumentOpts)
+
+	key := optionKey(r)
+	def, ok := h.registry.Definition(key)
+	if !ok {
+		xhttp.Error(w, runtime.ErrUnknownOption, http.StatusNotFound)
+		return
+	}
+
+	value, rErr := parseRequest(r, def)
+	if rErr != nil {
+		logger.Error("unable to parse request", zap.Error(rErr))
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	store, err := (*Handler)(h).optionStore()
+	if err != nil {
+		logger.Error("unable to get kv store", zap.Error(err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	result, err := store.Set(key, value, handleroptions.NewChangeMetadata(r.Header))
+	if err != nil {
+		logger.Error("unable to set runtime option",
+			zap.String("key", key), zap.Error(err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	xhttp.WriteJSONResponse(w, newOptionResponse(result), logger)
+}
+
+func parseRequest(
+	r *http.Request,
+	def runtime.OptionDefinition,
+) (interface{}, *xhttp.ParseError) {
+	defer r.Body.Close()
+
+	var req SetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+	if req.Value == nil {
+		return nil, xhttp.NewParseError(errNoValue, http.StatusBadRequest)
+	}
+
+	value, err := def.ParseValue(req.Value)
+	if err != nil {
+		return nil, xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+	return value, nil
+}
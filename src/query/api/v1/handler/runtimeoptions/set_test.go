@@ -0,0 +1,79 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package runtimeoptions
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/m3db/m3/src/dbnode/kvconfig"
+	qcost "github.com/m3db/m3/src/query/cost"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	router := setupRuntimeOptionsTest(t, ctrl)
+	for i, value := range []interface{}{10000, "20000"} {
+		resp := setOption(router, qcost.PerQueryMaxFetchedDatapointsKey,
+			value, "alice", "limit expensive queries")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var option OptionResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&option))
+		assert.Equal(t, qcost.PerQueryMaxFetchedDatapointsKey, option.Key)
+		assert.Equal(t, float64(10000*(i+1)), option.Value)
+		assert.Equal(t, i+1, option.Version)
+	}
+}
+
+func TestSetHandlerInvalidValue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	router := setupRuntimeOptionsTest(t, ctrl)
+	for _, value := range []interface{}{nil, "ten", 1.5, -1} {
+		resp := setOption(router, kvconfig.IndexMaxQueryLimitKey, value, "alice", "")
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "value %v", value)
+	}
+
+	resp := setOption(router, kvconfig.TickMinimumIntervalKey, "soon", "alice", "")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = serveRequest(router, SetHTTPMethod, ListURL+"/"+kvconfig.IndexMaxQueryLimitKey,
+		"not an object", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestSetHandlerUnknownOption(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	router := setupRuntimeOptionsTest(t, ctrl)
+	resp := setOption(router, "unknown", 1, "alice", "")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -100,3 +100,66 @@ func TestTopicAddHandler(t *testing.T) {
 
 	require.Equal(t, uint32(3), respProto.Version)
 }
+
+func TestTopicAddHandlerConsumerGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := setupTest(t, ctrl)
+	handler := newAddHandler(nil, config.Configuration{}, instrument.NewOptions())
+	handler.(*AddHandler).serviceFn = testServiceFn(mockService)
+
+	t1 := topic.NewTopic().SetName(DefaultTopicName).SetNumberOfShards(256)
+
+	addProto := admin.TopicAddRequest{
+		ConsumerService: &topicpb.ConsumerService{
+			ConsumptionType: topicpb.ConsumptionType_CONSUMER_GROUP,
+			ServiceId: &topicpb.ServiceID{
+				Environment: "env1",
+				Zone:        "zone1",
+				Name:        "name1",
+			},
+			MessageTtlNanos: int64(5 * time.Minute),
+		},
+	}
+	w := httptest.NewRecorder()
+	b := bytes.NewBuffer(nil)
+	require.NoError(t, jsonMarshaler.Marshal(b, &addProto))
+	cs, err := topic.NewConsumerServiceFromProto(addProto.ConsumerService)
+	require.NoError(t, err)
+	t2, err := t1.AddConsumerService(cs)
+	require.NoError(t, err)
+	mockService.
+		EXPECT().
+		Get(gomock.Any()).
+		Return(t1, nil)
+	mockService.EXPECT().CheckAndSet(gomock.Any(), gomock.Any()).Return(t2.SetVersion(3), nil)
+	req := httptest.NewRequest("POST", "/topic", b)
+	require.NotNil(t, req)
+	handler.ServeHTTP(w, req)
+	resp := w.Result()
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var respProto admin.TopicGetResponse
+	require.NoError(t, jsonUnmarshaler.Unmarshal(bytes.NewBuffer(body), &respProto))
+
+	validateEqualTopicProto(t, topicpb.Topic{
+		Name:           DefaultTopicName,
+		NumberOfShards: 256,
+		ConsumerServices: []*topicpb.ConsumerService{
+			&topicpb.ConsumerService{
+				ConsumptionType: topicpb.ConsumptionType_CONSUMER_GROUP,
+				ServiceId: &topicpb.ServiceID{
+					Environment: "env1",
+					Zone:        "zone1",
+					Name:        "name1",
+				},
+				MessageTtlNanos: int64(5 * time.Minute),
+			},
+		},
+	}, *respProto.Topic)
+
+	require.Equal(t, uint32(3), respProto.Version)
+}
@@ -21,6 +21,7 @@
 package topic
 
 import (
+	"fmt"
 	"net/http"
 
 	clusterclient "github.com/m3db/m3/src/cluster/client"
@@ -107,6 +108,12 @@ func (h *UpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if err := validateConsumptionType(m3Topic, csvc); err != nil {
+			svcLogger.Error("invalid consumer service update", zap.Error(err))
+			xhttp.Error(w, err, http.StatusBadRequest)
+			return
+		}
+
 		csvcs = append(csvcs, csvc)
 	}
 
@@ -134,3 +141,19 @@ func (h *UpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 	xhttp.WriteProtoMsgJSONResponse(w, resp, logger)
 }
+
+// validateConsumptionType makes sure the consumption type of an existing
+// consumer service is not changed in-place, producers keep routing messages
+// to a consumer service based on the consumption type it was added with.
+func validateConsumptionType(t topic.Topic, cs topic.ConsumerService) error {
+	for _, existing := range t.ConsumerServices() {
+		if !existing.ServiceID().Equal(cs.ServiceID()) {
+			continue
+		}
+		if existing.ConsumptionType() != cs.ConsumptionType() {
+			return fmt.Errorf("could not change consumption type for consumer service %s from %s to %s",
+				cs.ServiceID().String(), existing.ConsumptionType(), cs.ConsumptionType())
+		}
+	}
+	return nil
+}
@@ -153,3 +153,54 @@ func TestPlacementUpdateHandler(t *testing.T) {
 
 	require.Equal(t, uint32(4), respProto.Version)
 }
+
+func TestPlacementUpdateHandlerConsumptionTypeChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := setupTest(t, ctrl)
+	handler := newUpdateHandler(nil, config.Configuration{}, instrument.NewOptions())
+	handler.(*UpdateHandler).serviceFn = testServiceFn(mockService)
+
+	consumerSvc := &topicpb.ConsumerService{
+		ServiceId: &topicpb.ServiceID{
+			Name:        "svc",
+			Environment: "env",
+			Zone:        "zone",
+		},
+		ConsumptionType: topicpb.ConsumptionType_SHARED,
+	}
+	svc, err := topic.NewConsumerServiceFromProto(consumerSvc)
+	require.NoError(t, err)
+	returnTopic := topic.NewTopic().
+		SetName(testTopicName).
+		SetNumberOfShards(256).
+		SetVersion(1).
+		SetConsumerServices([]topic.ConsumerService{svc})
+
+	updateProto := admin.TopicUpdateRequest{
+		ConsumerServices: []*topicpb.ConsumerService{
+			&topicpb.ConsumerService{
+				ServiceId:       consumerSvc.ServiceId,
+				ConsumptionType: topicpb.ConsumptionType_CONSUMER_GROUP,
+			},
+		},
+		Version: 1,
+	}
+	w := httptest.NewRecorder()
+	b := bytes.NewBuffer(nil)
+	require.NoError(t, jsonMarshaler.Marshal(b, &updateProto))
+	req := httptest.NewRequest("PUT", "/topic/update", b)
+	req.Header.Add("topic-name", testTopicName)
+
+	mockService.EXPECT().
+		Get(testTopicName).
+		Return(returnTopic, nil)
+
+	handler.ServeHTTP(w, req)
+	resp := w.Result()
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	require.Contains(t, string(body), "could not change consumption type")
+}
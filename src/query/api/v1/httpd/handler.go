@@ -35,10 +35,13 @@ import (
 	m3json "github.com/m3db/m3/src/query/api/v1/handler/json"
 	"github.com/m3db/m3/src/query/api/v1/handler/namespace"
 	"github.com/m3db/m3/src/query/api/v1/handler/openapi"
+	"github.com/m3db/m3/src/query/api/v1/handler/opentsdb"
 	"github.com/m3db/m3/src/query/api/v1/handler/placement"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/native"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/remote"
+	"github.com/m3db/m3/src/query/api/v1/handler/rules"
+	"github.com/m3db/m3/src/query/api/v1/handler/runtimeoptions"
 	"github.com/m3db/m3/src/query/api/v1/handler/topic"
 	"github.com/m3db/m3/src/query/api/v1/options"
 	"github.com/m3db/m3/src/query/util/logging"
@@ -181,9 +184,23 @@ func (h *Handler) RegisterRoutes() error {
 		wrapped(native.NewPromReadInstantHandler(h.options)).ServeHTTP,
 	).Methods(native.PromReadInstantHTTPMethods...)
 
-	// InfluxDB write endpoint.
+	// InfluxDB write and query endpoints.
 	h.router.HandleFunc(influxdb.InfluxWriteURL,
 		wrapped(influxdb.NewInfluxWriterHandler(h.options)).ServeHTTP).Methods(influxdb.InfluxWriteHTTPMethod)
+	h.router.HandleFunc(influxdb.InfluxQueryURL,
+		wrapped(influxdb.NewInfluxQueryHandler(h.options)).ServeHTTP,
+	).Methods(influxdb.InfluxQueryHTTPMethods...)
+
+	// OpenTSDB put and query endpoints.
+	h.router.HandleFunc(opentsdb.PutURL,
+		wrapped(opentsdb.NewPutHandler(h.options)).ServeHTTP,
+	).Methods(opentsdb.PutHTTPMethod)
+	h.router.HandleFunc(opentsdb.QueryURL,
+		wrapped(opentsdb.NewQueryHandler(h.options)).ServeHTTP,
+	).Methods(opentsdb.QueryHTTPMethods...)
+	h.router.HandleFunc(opentsdb.AggregatorsURL,
+		wrapped(opentsdb.NewAggregatorsHandler(h.options)).ServeHTTP,
+	).Methods(opentsdb.AggregatorsHTTPMethod)
 
 	// Native M3 search and write endpoints.
 	h.router.HandleFunc(handler.SearchURL,
@@ -214,6 +231,19 @@ func (h *Handler) RegisterRoutes() error {
 		wrapped(native.NewPromThresholdHandler(h.options)).ServeHTTP,
 	).Methods(native.PromThresholdHTTPMethod)
 
+	// Prometheus rules and alerts endpoints.
+	h.router.HandleFunc(native.PromRulesURL,
+		wrapped(native.NewPromRulesHandler(h.options)).ServeHTTP,
+	).Methods(native.PromRulesHTTPMethod)
+	h.router.HandleFunc(native.PromAlertsURL,
+		wrapped(native.NewPromAlertsHandler(h.options)).ServeHTTP,
+	).Methods(native.PromAlertsHTTPMethod)
+
+	// Prometheus metric metadata endpoint.
+	h.router.HandleFunc(native.PromMetadataURL,
+		wrapped(native.NewPromMetadataHandler(h.options)).ServeHTTP,
+	).Methods(native.PromMetadataHTTPMethod)
+
 	// Series match endpoints.
 	h.router.HandleFunc(remote.PromSeriesMatchURL,
 		wrapped(remote.NewPromSeriesMatchHandler(h.options)).ServeHTTP,
@@ -263,6 +293,9 @@ func (h *Handler) RegisterRoutes() error {
 	h.router.HandleFunc(xdebug.DebugURL,
 		wrapped(debugWriter.HTTPHandler()).ServeHTTP)
 
+	// Register downsampling rules dry run and cardinality handlers.
+	rules.RegisterRoutes(h.router, config, h.options.Clusters(), instrumentOpts)
+
 	if clusterClient != nil {
 		err = database.RegisterRoutes(h.router, clusterClient,
 			h.options.Config(), h.options.EmbeddedDbCfg(),
@@ -275,6 +308,15 @@ func (h *Handler) RegisterRoutes() error {
 			serviceOptionDefaults, placementOpts)
 		namespace.RegisterRoutes(h.router, clusterClient, serviceOptionDefaults, instrumentOpts)
 		topic.RegisterRoutes(h.router, clusterClient, config, instrumentOpts)
+		historyLimit, err := config.HistoryLimitOrDefault()
+		if err != nil {
+			return err
+		}
+		err = runtimeoptions.RegisterRoutes(h.router, clusterClient,
+			historyLimit, instrumentOpts)
+		if err != nil {
+			return err
+		}
 
 		// Experimental endpoints.
 		if config.Experimental.Enabled {
@@ -299,12 +341,20 @@ func (h *Handler) RegisterRoutes() error {
 }
 
 func (h *Handler) placementOpts() (placement.HandlerOptions, error) {
-	return placement.NewHandlerOptions(
+	opts, err := placement.NewHandlerOptions(
 		h.options.ClusterClient(),
 		h.options.Config(),
 		h.m3AggServiceOptions(),
 		h.options.InstrumentOpts(),
 	)
+	if err != nil {
+		return placement.HandlerOptions{}, err
+	}
+
+	if clusters := h.options.Clusters(); clusters != nil {
+		opts = opts.SetShardSizeEstimator(placement.NewM3DBShardSizeEstimator(clusters))
+	}
+	return opts, nil
 }
 
 func (h *Handler) m3AggServiceOptions() *handleroptions.M3AggServiceOptions {
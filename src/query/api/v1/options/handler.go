@@ -29,10 +29,12 @@ import (
 	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
 	dbconfig "github.com/m3db/m3/src/cmd/services/m3dbnode/config"
 	"github.com/m3db/m3/src/cmd/services/m3query/config"
+	"github.com/m3db/m3/src/query/alerting"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
 	"github.com/m3db/m3/src/query/cost"
 	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/metadata"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/storage/m3"
@@ -146,6 +148,16 @@ type HandlerOptions interface {
 	// SetServiceOptionDefaults sets the service option defaults.
 	SetServiceOptionDefaults(s []handleroptions.ServiceOptionsDefault) HandlerOptions
 
+	// AlertingManager returns the alerting rule manager.
+	AlertingManager() alerting.Manager
+	// SetAlertingManager sets the alerting rule manager.
+	SetAlertingManager(m alerting.Manager) HandlerOptions
+
+	// MetadataStore returns the metric metadata store.
+	MetadataStore() metadata.Store
+	// SetMetadataStore sets the metric metadata store.
+	SetMetadataStore(s metadata.Store) HandlerOptions
+
 	// NowFn returns the now function.
 	NowFn() clock.NowFn
 	// SetNowFn sets the now function.
@@ -176,6 +188,8 @@ type handlerOptions struct {
 	cpuProfileDuration    time.Duration
 	placementServiceNames []string
 	serviceOptionDefaults []handleroptions.ServiceOptionsDefault
+	alertingManager       alerting.Manager
+	metadataStore         metadata.Store
 	nowFn                 clock.NowFn
 }
 
@@ -397,6 +411,28 @@ func (o *handlerOptions) SetServiceOptionDefaults(
 	return &opts
 }
 
+func (o *handlerOptions) AlertingManager() alerting.Manager {
+	return o.alertingManager
+}
+
+func (o *handlerOptions) SetAlertingManager(
+	m alerting.Manager) HandlerOptions {
+	opts := *o
+	opts.alertingManager = m
+	return &opts
+}
+
+func (o *handlerOptions) MetadataStore() metadata.Store {
+	return o.metadataStore
+}
+
+func (o *handlerOptions) SetMetadataStore(
+	s metadata.Store) HandlerOptions {
+	opts := *o
+	opts.metadataStore = s
+	return &opts
+}
+
 func (o *handlerOptions) InstrumentOpts() instrument.Options {
 	return o.instrumentOpts
 }
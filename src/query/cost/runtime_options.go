@@ -0,0 +1,228 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cost
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/cluster/kv/util"
+	"github.com/m3db/m3/src/cluster/kv/util/runtime"
+	"github.com/m3db/m3/src/x/cost"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+)
+
+const (
+	// GlobalMaxFetchedDatapointsKey is the KV config key for the runtime
+	// configuration specifying the limit of datapoints fetched by all queries.
+	GlobalMaxFetchedDatapointsKey = "m3query.limits.global.max-fetched-datapoints"
+
+	// PerQueryMaxFetchedDatapointsKey is the KV config key for the runtime
+	// configuration specifying the limit of datapoints fetched by a query.
+	PerQueryMaxFetchedDatapointsKey = "m3query.limits.per-query.max-fetched-datapoints"
+
+	defaultWatchRetryInterval = 5 * time.Second
+)
+
+var (
+	// GlobalMaxFetchedDatapointsOption is the runtime option for the limit of
+	// datapoints fetched by all queries, zero disables the limit.
+	GlobalMaxFetchedDatapointsOption = runtime.OptionDefinition{
+		Key:         GlobalMaxFetchedDatapointsKey,
+		Type:        runtime.Int64OptionType,
+		Description: "Limit of datapoints fetched by all queries at any given time, zero for no limit.",
+		Default:     int64(0),
+		ValidateFn:  nonNegativeLimit,
+	}
+
+	// PerQueryMaxFetchedDatapointsOption is the runtime option for the limit
+	// of datapoints fetched by a query, zero disables the limit.
+	PerQueryMaxFetchedDatapointsOption = runtime.OptionDefinition{
+		Key:         PerQueryMaxFetchedDatapointsKey,
+		Type:        runtime.Int64OptionType,
+		Description: "Limit of datapoints fetched by a single query, zero for no limit.",
+		Default:     int64(0),
+		ValidateFn:  nonNegativeLimit,
+	}
+
+	errLimitIsNegative = errors.New("limit cannot be negative")
+)
+
+// RuntimeOptionDefinitions returns the definitions of the coordinator cost
+// limits kept in KV.
+func RuntimeOptionDefinitions() []runtime.OptionDefinition {
+	return []runtime.OptionDefinition{
+		GlobalMaxFetchedDatapointsOption,
+		PerQueryMaxFetchedDatapointsOption,
+	}
+}
+
+func nonNegativeLimit(value interface{}) error {
+	if value.(int64) < 0 {
+		return errLimitIsNegative
+	}
+	return nil
+}
+
+// KVStoreFn returns the KV store holding the runtime options.
+type KVStoreFn func() (kv.Store, error)
+
+type runtimeLimitManager struct {
+	sync.Mutex
+
+	threshold      *atomic.Int64
+	watch          kv.ValueWatch
+	closed         bool
+	closeCh        chan struct{}
+	reportInterval time.Duration
+	logger         *zap.Logger
+	thresholdGauge tally.Gauge
+	enabledGauge   tally.Gauge
+}
+
+// NewRuntimeLimitManager returns a cost.LimitManager for a limit defined by
+// a runtime option, using the configured limit until the option is set in KV.
+// The option is watched once the KV store returned by storeFn is available.
+func NewRuntimeLimitManager(
+	def runtime.OptionDefinition,
+	configured int64,
+	storeFn KVStoreFn,
+	iOpts instrument.Options,
+) cost.LimitManager {
+	scope := iOpts.MetricsScope()
+	m := &runtimeLimitManager{
+		threshold:      atomic.NewInt64(configured),
+		closeCh:        make(chan struct{}),
+		reportInterval: iOpts.ReportInterval(),
+		logger:         iOpts.Logger(),
+		thresholdGauge: scope.Gauge("threshold"),
+		enabledGauge:   scope.Gauge("enabled"),
+	}
+
+	go m.watchWhenAvailable(def, configured, storeFn)
+	return m
+}
+
+func (m *runtimeLimitManager) watchWhenAvailable(
+	def runtime.OptionDefinition,
+	configured int64,
+	storeFn KVStoreFn,
+) {
+	ticker := time.NewTicker(defaultWatchRetryInterval)
+	defer ticker.Stop()
+
+	watchOpts := util.NewOptions().SetLogger(m.logger)
+	for {
+		err := m.tryWatch(def, configured, storeFn, watchOpts)
+		if err == nil {
+			return
+		}
+		m.logger.Warn("could not watch cost limit, retrying",
+			zap.String("key", def.Key), zap.Error(err))
+
+		select {
+		case <-ticker.C:
+		case <-m.closeCh:
+			return
+		}
+	}
+}
+
+func (m *runtimeLimitManager) tryWatch(
+	def runtime.OptionDefinition,
+	configured int64,
+	storeFn KVStoreFn,
+	watchOpts util.Options,
+) error {
+	store, err := storeFn()
+	if err != nil {
+		return err
+	}
+
+	watch, err := def.Watch(store, configured, func(value interface{}) {
+		m.threshold.Store(value.(int64))
+	}, nil, watchOpts)
+	if err != nil {
+		return err
+	}
+
+	m.setWatch(watch)
+	return nil
+}
+
+func (m *runtimeLimitManager) setWatch(watch kv.ValueWatch) {
+	m.Lock()
+	defer m.Unlock()
+	if m.closed {
+		watch.Close()
+		return
+	}
+	m.watch = watch
+}
+
+func (m *runtimeLimitManager) Limit() cost.Limit {
+	threshold := m.threshold.Load()
+	return cost.Limit{
+		Threshold: cost.Cost(threshold),
+		Enabled:   threshold > 0,
+	}
+}
+
+func (m *runtimeLimitManager) Report() {
+	ticker := time.NewTicker(m.reportInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			limit := m.Limit()
+			m.thresholdGauge.Update(float64(limit.Threshold))
+
+			var v float64
+			if limit.Enabled {
+				v = 1
+			}
+			m.enabledGauge.Update(v)
+
+		case <-m.closeCh:
+			return
+		}
+	}
+}
+
+func (m *runtimeLimitManager) Close() {
+	m.Lock()
+	defer m.Unlock()
+	if m.closed {
+		return
+	}
+	if m.watch != nil {
+		m.watch.Close()
+	}
+	close(m.closeCh)
+	m.closed = true
+}
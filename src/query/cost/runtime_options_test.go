@@ -0,0 +1,91 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cost
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/generated/proto/commonpb"
+	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/cluster/kv/mem"
+	"github.com/m3db/m3/src/cluster/kv/util/runtime"
+	"github.com/m3db/m3/src/x/cost"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuntimeOptionDefinitions(t *testing.T) {
+	r := runtime.NewRegistry()
+	require.NoError(t, r.Register(RuntimeOptionDefinitions()...))
+
+	def, ok := r.Definition(GlobalMaxFetchedDatapointsKey)
+	require.True(t, ok)
+	_, err := def.ParseValue(float64(-1))
+	require.Error(t, err)
+}
+
+func TestRuntimeLimitManager(t *testing.T) {
+	store := mem.NewStore()
+	def := GlobalMaxFetchedDatapointsOption
+
+	m := NewRuntimeLimitManager(def, 100, func() (kv.Store, error) {
+		return store, nil
+	}, instrument.NewOptions())
+	defer m.Close()
+
+	require.Equal(t, cost.Limit{Threshold: 100, Enabled: true}, m.Limit())
+
+	_, err := store.Set(def.Key, &commonpb.Int64Proto{Value: 0})
+	require.NoError(t, err)
+	waitForLimit(t, m, cost.Limit{Threshold: 0, Enabled: false})
+
+	_, err = store.Set(def.Key, &commonpb.Int64Proto{Value: 50})
+	require.NoError(t, err)
+	waitForLimit(t, m, cost.Limit{Threshold: 50, Enabled: true})
+
+	// Falls back to the configured limit once the option is deleted.
+	_, err = store.Delete(def.Key)
+	require.NoError(t, err)
+	waitForLimit(t, m, cost.Limit{Threshold: 100, Enabled: true})
+}
+
+func TestRuntimeLimitManagerStoreUnavailable(t *testing.T) {
+	m := NewRuntimeLimitManager(PerQueryMaxFetchedDatapointsOption, 10, func() (kv.Store, error) {
+		return nil, errors.New("unavailable")
+	}, instrument.NewOptions())
+
+	require.Equal(t, cost.Limit{Threshold: 10, Enabled: true}, m.Limit())
+	m.Close()
+	m.Close()
+}
+
+func waitForLimit(t *testing.T, m cost.LimitManager, expected cost.Limit) {
+	deadline := time.Now().Add(5 * time.Second)
+	for m.Limit() != expected {
+		if time.Now().After(deadline) {
+			require.FailNow(t, "timed out waiting for limit", "expected %v, got %v", expected, m.Limit())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
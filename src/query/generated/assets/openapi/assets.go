@@ -239,46 +239,52 @@ d8dUBmQZxiF/+uI1I7E8TMF6pCyWxDpY7WPA8pmKZsl6ouawOaOS+Sj+BQAA//8by2IcfAIAAA==
 
 	"/spec.yml": {
 		local:   "openapi/spec.yml",
-		size:    23034,
+		size:    26544,
 		modtime: 12345,
 		compressed: `
-H4sIAAAAAAAC/+xc32/jNvJ/918x1X4fvgUuUTbZ6wF+c+I0ayCbNZygwLU4oLQ4ktlKpJakkvUW978f
-KMmyZCn6YTtO1pVfEovDIWfmw/kMKcnv4O7zw/UQZhGH3wPyJwJRCvWJh/zkS4Ry+TswF5YigqSRL8FZ
-EO6hAi1AL5gCl/n4w0A9Ec9DOQTr/PTMGjDuiuEAQDPt4xCsTxfjS2sAQFE5koWaCT4EawSUKS3ZPNJI
-QbMAQaFkqIASTeZEIUSKcQ8+XTzc/wquL4j+6QM4IgglKsUEP4V/iwgcwsFlnIKINARCIpC5+deMCkTD
-bwutw6FtBxd0fuoxvYjmp0zYwYX9n/9/tulHEBIEh99umP4YzRNJNbTtVMoRQdzLDi5+PDW2PaJUiV3v
-T8+MEwAcwTVxtPEEACdB4orLMdwI4fkIN1JEoRW3RtIfgpWNYRrUqReLxUO5QkaB/e6H5K8Z2PTzmYNc
-YWGAUUicBcJt0gTnyVRKI5SssOe+mNsBURqlfTu5ur67v7YGC6G06SaUjvX/6/zsvTUwsZkSvRiCZZOQ
-2Y/vrYEmnhoOTtZ2ji/hjgSoQuJgOfhXgrvMi2QS3/Fl3C+WVdaGlqlPHAyQ6xZawpVsUcvI8yR6RAvZ
-XluuzzNax5cwTpFaVlZoPnliFMGNuGNalTVQzgIDjB0Wx8QahEQvlImkrVA+MgdVEpnML0mUPUzxBJB4
-HNLPSZXPzUdFQUDkcgjWDeqyrxMhEaIkZm4TOgQra79BvZJwBFdRPOVsPBKGPnPibvYfSvCVaCgFjZxW
-ohJVKLjCnCHnZ2frL5tetXItsQ9JXhbg/yS6Q7De2RRdxlnsbfsuZ84sHXCt6MPZhz2Pd4McJXOupRRy
-reCfe7erPE5olmsFPFqAY0QpEL6BjwZ4jCh9WXiERJIANcqccLr85oIu115jvHSp7MZ6cIwoneGXCJV+
-U+A8OxJwPpfW7L+yfyfj/yaKKfqocUsgj+POnbGcdHs1OOecsIFqQwzrSxK/REwiHYKWEWaX9TI0Wkw5
-xb2D4jfxW0ydMohNPjx6jyeFb6ySrPCoJf+TqkKpTP16gRtFUvWSyJqPg/2nOXPKCfYtsHJN3FJWZlxp
-wh1Mtl3YOoLHQNDTnDGvQdD1+Dkegm5DuzVITWm3c5JJ+o18/whSTR0XHpY7HCEkZdzsXruQyNW6WznW
-mxJ1LJNX1NPNG6KbHSPc81HPR2+Fj3aEcoGwtslXPXO9BHOR7Ni1C3E9d8BbIVBHWyPPawp/YIR6zjok
-Z+0U3Oxc08S2I28VY92TV09eeyOvnTBdoK62OWva89ahTuts02O469nPxAxLfPZti1216Xs8ycpY02er
-V8CxxPj/naE8S/RkN2UyHma80/4x1XM8yE4N6sH9isdibbP1jvvNUjrfZs95tHm90nn9OjjoOmif7Xdc
-CgU6qJDss3+P+gMdLbVN/jvt10qpv/Oera/ne9DvEfTtM/1OuC/k+bJgHeD7XN/Dfm/b2L9W+80OTxg2
-P+pQ2sy6UgSdtrOv/Mzh2ivf1yOHfcW+NbD3c890s27vl0C/BF6pkum8AvZx46V8P7Et7mMrpj34e/B3
-r2dWb0XajkSiWx7K519QK2J59bYbqqSMeWJ6AYFQGkRshQKKLol8jbQay6v5XMXT+e4r9XHBnNeo0zdn
-8DdEdoxJey6EVlqSMMwCvj3Ox0tOAuYQ31+CeEQpGU2OYQqjgLNaDhRYUtgr4ILiiY+P6GfNhXvMz6yH
-WPQe9WV+gONZH7F5lbYddpU8P4/jepKk4a2f55E/Qy0ZPiZop7llkMN6cRGs1sc/gLlA+LIN0G8OCvS/
-H8RqX27jQoMrIk5NwMwXtX4g8TuAd67F9K14FzdRmdaaYv4HOql9oTSg1GyNhDjf1ZenaWWzlqp/Jfdz
-mL6un5va57yKVvPKltg1J3MfaWmOcyF8JBnEXT9Si5ayT5JpVA/iSgQB07fCa+rgmC9R26lIDAmTrYU1
-cuOcz628PNsQz/IXJ6FaCN1yVMYpfm034iQnarrPKifcKqaZrVOUTNBxWhg0wG/uC+fPe/YN28pHrovy
-50hHsluXKVG6y5xMwrv+GjK5bHL3hvjI1SjvhB45DirV2hmTUtBaeR3bQaKLm6tev++EA48pnXdbfUaZ
-pfKFoWcFJa1zXfILHiXb8h3Nh1Aaz4L405KaTimw/KxlhwknpzK1oava1ncYYeNtgebTg5WLVr/cszk1
-xjV6mGNDVxi7k5aL88KUO8xzderyQpGbpOpzKdwUUj8TRwvZZCOPgvsFkVQ1CTIVyzWvRSfSpqh8YBXc
-XOvgnz6sx/rETLnQPFhAvsbTukc9oXXDrZzUJWy0IZEyJfx4VcQ/69Qg/E3wplrlCZm30E1OQ05Dwbhu
-UKaqo0qkJPnNocagGWGxiwuKG/1tPtlvSNXPNBRStwld9/rw+47gy7ivcBtnn75s4Z7DGh6jdjcLNwKn
-NNHYQDZJOtK581ElIungpMl/ad68I1yobROn0eG6W6tYz73gtvw8kUdB0ngC1uRu8jAZ3U5+ndzdWKuL
-o19Gk9vR5e11duX2evRLKlHxNtJeiHSrtLaxMrLdmJAOtipbck81vTkrWhN7VZWTKyPMIO1Kidpyqfgw
-TAdv+UgeGfcm2Z2r7m6rXm6EU0aJfotg2jpHvziy8oc5XbZxa/nKgFTeidlmc3TXTBvxxXqRfJpLc5gv
-HOJb+SuOHym9RSH9ouutcFJRWYI/cz7RwGqXK7k8R+8JZh9Fgq3L4lxaBV43m4hfQ3Q00vv4l2YN0uIa
-RE1RfhSR3IYnP4r9lqGEUolK7VjvvGJBu38XV98Z3SYltD2oqXi0oPMBw4aOmrsKHSx5JH6EO7Pe/wIA
-AP//JDPkT/pZAAA=
+H4sIAAAAAAAC/+xdUW/bOBJ+96+YVe/hFtjEadPbA/xm19nUQJoGTrHA7eKApcWRzK1EqiSV1F3cfz+Q
+smTJki3JdpzUK780FofDmeHHGc6IdF/B7cdPVwOYxhz+CMlnBKIU6jMf+dmXGOXiD2AeLEQMSSNfgDsn
+3EcFWoCeMwUeC/CHnnokvo9yAM6b8wunx7gnBj0AzXSAA3A+XI5HTg+AonIlizQTfADOEChTWrJZrJGC
+ZiGCQslQASWazIhCiBXjPny4/HT/G3iBIPrnt+CKMJKoFBP8HP4jYnAJB49xCiLWEAqJQGbmTzMqEA2/
+z7WOBv1+eEln5z7T83h2zkQ/vOz/958bm34EIUFw+P2a6ffxLKFUg35/SeWK0Pbqh5c/njs9gAeUKtHr
+9fmFMQKAK7gmrh70AAA4CRNTjMZwLYQfIFxLEUeObY1lMAAnG8M0qHPfktmhPCHjsP/qh+RfM7DpFzAX
+ucLCAMOIuHOEm6QJ3pxfVI5Q0qI/C8SsHxKlUfZvJu+ubu+vnN5cKG26CaUt/3+/uXjt9Mzc3BE9H4DT
+JxHrP7x2epr4atA7W+k5HsEtCVFFxMXy5L8T3GN+LJP5HY+Ap7TKWeNyFxAXQ+S6AZcopS1yGfq+RJ9o
+IZtzy/XZwHU8gvESqWVmheazR0YRvJi7plU5PeXOMURrMDsnTi8ieq4GPYC+QvnAXFTJzGR2MW0APi7x
+BJBYHJafsyqbm4+Kw5DIxQCca9RlWydEIkJJjGwTOgAna79GnVK4gqvYipyNR6IoYK7t1v9TCZ6SRlLQ
+2G1EKlFFgivMKfLm4mL1Zd2qTq7F2pDkaQH+IdEbgPOqT9FjnFlr929z6kyXA64Yvb14e+DxrpGjZO6V
+lEKuGPzr4uLJx4nMcq2ARwNwDCkFwtfwUQOPIaVPC4+ISBKiRpkjXi6/maCLldUYLz0qm3E7OIaUTvFL
+jEq/KHBenAg4N7m1/l/Zn5Px/xLGFAPUuCOQx7Zzaywn3Z4NzjkjrKHaBIbVI4lfYiaRDkDLGLPHehEZ
+LmY7xf2j4jexmw2dMrQqHx+9p+PC11ZJtvHYGvzPqjZK5dCv57i2SapeElnzaUT/u5w6ZQf7EqLylnlb
+RmXGlSbcxSTtwsYzeAoB+i6nzHME6O34OZ0A3STsbkHqMuy2djJJv2EQnICr2RYLjxs7XCEkZdxkr22C
+yLtVt/Jcr1NsizJ5Rl24eUHhZs8Z7uJRF49eSjzaE8qFgLWLv+oi11NELpKVXdsErk0F3gqCbWFr6Pt1
+0x8aoi5mHTNm7TW5WV3TzG3LuFWc6y54dcHrYMFrL0wXQldTn3XXxa1jVev6psdg39rPxAxLAvZth6za
+9D0dZ2W06bzVM+BYov17byhPEz7ZS5ksDjPeKn9c8jkdZC8V6sD9jGWxpt56z3yz5M53yTlP1q9XGq9b
+B0ddB829/Z5LoRAOKig779+h/kilpabOf698reT6W+ds3X6+A/0BQd/c0++F+4KfLxNuA3zn6zvYHyyN
+/SvNN1ucMKw/6lBKZj0pwlbp7DOfOVxZ5WBHDjPeVC6mMV/ja++UOL1qCExRx5IrICvTjy2TFLLwyPRc
+xBoilIopzbhvrZ1cSamS1yOBKgk8EyJAwksSo9IsJBpHC42qjeBXy47KSjMz3SEUD0hhtgACVC5AxnyF
+jlkg3M8QoibmngsIzz5Neqg5kVQdTJdPLEQR6zbavBePEAjuw5JHauW8XiFZgCaf8Seg6JE40Aq0gNcX
+zQV/hoOqXZ63szs8zJv29Wyvc5yd4+wcZ+c4v5usqbXfPMRL3vLZhabe0mpx17nMzmV2LrNzmcfIuNN7
++31XItENXxvnr1AXPWB6HxtVkmibNQmhUBqE1UKlkEBa7QFTed5Zcb77WtK4oM5zVJLWJfgbIttisj8T
+QistSRRlE747zscLTkLmkiBYgHhAKRlNXhQURgE3XQ4UWFJ6UsAFxbMAHzDImgunoDasB0t6j3qUH+B0
+1odVr1K3466SzXKc0lKpvZe6GflT1JLhQ4J2mlsGOawXF0G6Pn4C5gHhiyZAvz4q0P9+ENt6/ZoLDZ6I
+OQXmgfmiUH9H8M61mL4VvxYx6OX2mmL2J7pL/SJpQKnZCgnW323fni53NoPeVhkzMT4m5E5etI95Fo3k
+ypbYFSezAOmgZu/vBbGaN6R9lEyj+iTeiTBk+kb4dR1c8yVuKorEiDDZmFgjN8b52MjK0zXyzH9xEqm5
+0A1HZZzi12YjTnKkpvu0UuBGc5rpeoeSCTpebgxq4GfzxXv2DZvSx56H8pdYx7JdlzuidBuZjMO7+hox
+uagz9xr50NMob4Ueui4q1dgYk9KkNbI6NoNEGzNX/UBMKxz4TGm5qEFeNsp0SV8Yelpg0tjX2b6qpFu+
+o/kQSq0UJLgrsWnlAsu3AVoInNTytk5dVVrfYoS1+2z11YPUROlvy62LxrhGH3PR0BNG76Tl8k1B5GJ9
+64VLbT6UeV7TIcfM84raZp0b6ZiUwj6YgtOu4qZlMGprejuw+vntpjLZRx4sQKGGxzkm5+UL9UOwNbZV
+ggVMWWrCaYHY/nKixTgwDpqFuIpQSXG2vFSJlCSfm2kMVZtzXgnfdHrMhykR2KVpf/3w4CMWuI9IYIYv
+ICMvUwuEMFoTpYp61RAneBtSirS1AaqxlHCcYigeDsazCOlqaRtCushqk5gNmG2f6TYT2n6+RugJibs6
+iOWcm+3IriwCQegGIXgczlDmCatHWtHdFf16M6NtdBQHienp2sxt7k2K/Qtxtag1Go/De2viOkKmLF39
+Ls2NtSk3mBcbu+KeqQ/MJJL1g4XkqxXrHvVk68pIjfRszuub4HVZ7CMyf67rjIacRoJx3WjxHSZQWBMX
+GNfa23yy37/dLmkkpG4yde0rB9/3DD6N+QqHiQ5pywbmOa7iFrX7abi+XdBEY83uOnFHOvfmTIlYujip
+s9/Sb94SLtSujtPw8LydWaxkH/Q2yIk8DpPGM3Amt5NPk+HN5LfJ7bWTPhz+OpzcDEc3V9mTm6vhr0uK
+il9SOEgg3cmtra2MtLsnpIt1gSc5PVFH9QQnKKqug7w4EzbeVWzasy33MGaQZvuYrVl88RZBC2sFSB4Y
+9yfZMRy1a5KwttYJp4wS3SF524XWFwXr/AuOFoLhir4SDZWnE3YpGN7WB0z7cDtJ3sEvvXcgXBI4+Sdu
+ECu9QwrxpIu9UL2vTD421Oxr4vkopcvvTg4Es/ciwdaoKEujidf1KuLXCF2N9N7+/yAGaXb3Zcr570Us
+d9khvBeH3YATSiUqtedO7xm38oc3cfVpoV1cQtOXFxXH7VqXr9d4bHnT3kKTBxLEuHfI/f8AHYkVo7Bn
+AAA=
 `,
 	},
 
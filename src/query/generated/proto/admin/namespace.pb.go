@@ -21,6 +21,50 @@
 // OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
 // THE SOFTWARE.
 
+/*
+Package admin is a generated protocol buffer package.
+
+It is generated from these files:
+
+	github.com/m3db/m3/src/query/generated/proto/admin/namespace.proto
+	github.com/m3db/m3/src/query/generated/proto/admin/placement.proto
+	github.com/m3db/m3/src/query/generated/proto/admin/database.proto
+	github.com/m3db/m3/src/query/generated/proto/admin/topic.proto
+
+It has these top-level messages:
+
+	NamespaceGetResponse
+	NamespaceAddRequest
+	NamespaceSchemaAddRequest
+	NamespaceSchemaAddResponse
+	NamespaceSchemaResetRequest
+	NamespaceSchemaResetResponse
+	NamespaceHistoryResponse
+	NamespaceHistoryEntry
+	PlacementInitRequest
+	PlacementGetResponse
+	PlacementAddRequest
+	PlacementReplaceRequest
+	PlacementSetRequest
+	PlacementSetResponse
+	PlacementRemoveReplicaRequest
+	PlacementSplitShardsRequest
+	PlacementBalanceRequest
+	PlacementDryRunResponse
+	PlacementDiff
+	PlacementInstanceDiff
+	PlacementIsolationGroupBalance
+	PlacementHistoryResponse
+	PlacementHistoryEntry
+	DatabaseCreateRequest
+	BlockSize
+	Host
+	DatabaseCreateResponse
+	TopicGetResponse
+	TopicInitRequest
+	TopicAddRequest
+	TopicUpdateRequest
+*/
 package admin
 
 import proto "github.com/gogo/protobuf/proto"
@@ -35,6 +79,12 @@ var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
 
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+
 type NamespaceGetResponse struct {
 	Registry *namespace1.Registry `protobuf:"bytes,1,opt,name=registry" json:"registry,omitempty"`
 }
@@ -177,6 +227,81 @@ func (*NamespaceSchemaResetResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptorNamespace, []int{5}
 }
 
+type NamespaceHistoryResponse struct {
+	// entries are ordered from the oldest to the most recent registry.
+	Entries []*NamespaceHistoryEntry `protobuf:"bytes,1,rep,name=entries" json:"entries,omitempty"`
+}
+
+func (m *NamespaceHistoryResponse) Reset()         { *m = NamespaceHistoryResponse{} }
+func (m *NamespaceHistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*NamespaceHistoryResponse) ProtoMessage()    {}
+func (*NamespaceHistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorNamespace, []int{6}
+}
+
+func (m *NamespaceHistoryResponse) GetEntries() []*NamespaceHistoryEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+type NamespaceHistoryEntry struct {
+	Registry       *namespace1.Registry `protobuf:"bytes,1,opt,name=registry" json:"registry,omitempty"`
+	Version        int32                `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	UpdatedAtNanos int64                `protobuf:"varint,3,opt,name=updatedAtNanos,proto3" json:"updatedAtNanos,omitempty"`
+	Operator       string               `protobuf:"bytes,4,opt,name=operator,proto3" json:"operator,omitempty"`
+	Reason         string               `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	Deleted        bool                 `protobuf:"varint,6,opt,name=deleted,proto3" json:"deleted,omitempty"`
+}
+
+func (m *NamespaceHistoryEntry) Reset()                    { *m = NamespaceHistoryEntry{} }
+func (m *NamespaceHistoryEntry) String() string            { return proto.CompactTextString(m) }
+func (*NamespaceHistoryEntry) ProtoMessage()               {}
+func (*NamespaceHistoryEntry) Descriptor() ([]byte, []int) { return fileDescriptorNamespace, []int{7} }
+
+func (m *NamespaceHistoryEntry) GetRegistry() *namespace1.Registry {
+	if m != nil {
+		return m.Registry
+	}
+	return nil
+}
+
+func (m *NamespaceHistoryEntry) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *NamespaceHistoryEntry) GetUpdatedAtNanos() int64 {
+	if m != nil {
+		return m.UpdatedAtNanos
+	}
+	return 0
+}
+
+func (m *NamespaceHistoryEntry) GetOperator() string {
+	if m != nil {
+		return m.Operator
+	}
+	return ""
+}
+
+func (m *NamespaceHistoryEntry) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *NamespaceHistoryEntry) GetDeleted() bool {
+	if m != nil {
+		return m.Deleted
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*NamespaceGetResponse)(nil), "admin.NamespaceGetResponse")
 	proto.RegisterType((*NamespaceAddRequest)(nil), "admin.NamespaceAddRequest")
@@ -184,6 +309,8 @@ func init() {
 	proto.RegisterType((*NamespaceSchemaAddResponse)(nil), "admin.NamespaceSchemaAddResponse")
 	proto.RegisterType((*NamespaceSchemaResetRequest)(nil), "admin.NamespaceSchemaResetRequest")
 	proto.RegisterType((*NamespaceSchemaResetResponse)(nil), "admin.NamespaceSchemaResetResponse")
+	proto.RegisterType((*NamespaceHistoryResponse)(nil), "admin.NamespaceHistoryResponse")
+	proto.RegisterType((*NamespaceHistoryEntry)(nil), "admin.NamespaceHistoryEntry")
 }
 func (m *NamespaceGetResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
@@ -366,6 +493,96 @@ func (m *NamespaceSchemaResetResponse) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *NamespaceHistoryResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NamespaceHistoryResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Entries) > 0 {
+		for _, msg := range m.Entries {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintNamespace(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *NamespaceHistoryEntry) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NamespaceHistoryEntry) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Registry != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(m.Registry.Size()))
+		n3, err := m.Registry.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
+	}
+	if m.Version != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(m.Version))
+	}
+	if m.UpdatedAtNanos != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(m.UpdatedAtNanos))
+	}
+	if len(m.Operator) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(len(m.Operator)))
+		i += copy(dAtA[i:], m.Operator)
+	}
+	if len(m.Reason) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(len(m.Reason)))
+		i += copy(dAtA[i:], m.Reason)
+	}
+	if m.Deleted {
+		dAtA[i] = 0x30
+		i++
+		if m.Deleted {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
 func encodeVarintNamespace(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -451,6 +668,45 @@ func (m *NamespaceSchemaResetResponse) Size() (n int) {
 	return n
 }
 
+func (m *NamespaceHistoryResponse) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Entries) > 0 {
+		for _, e := range m.Entries {
+			l = e.Size()
+			n += 1 + l + sovNamespace(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *NamespaceHistoryEntry) Size() (n int) {
+	var l int
+	_ = l
+	if m.Registry != nil {
+		l = m.Registry.Size()
+		n += 1 + l + sovNamespace(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 1 + sovNamespace(uint64(m.Version))
+	}
+	if m.UpdatedAtNanos != 0 {
+		n += 1 + sovNamespace(uint64(m.UpdatedAtNanos))
+	}
+	l = len(m.Operator)
+	if l > 0 {
+		n += 1 + l + sovNamespace(uint64(l))
+	}
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovNamespace(uint64(l))
+	}
+	if m.Deleted {
+		n += 2
+	}
+	return n
+}
+
 func sovNamespace(x uint64) (n int) {
 	for {
 		n++
@@ -1122,6 +1378,286 @@ func (m *NamespaceSchemaResetResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *NamespaceHistoryResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNamespace
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NamespaceHistoryResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NamespaceHistoryResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Entries", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Entries = append(m.Entries, &NamespaceHistoryEntry{})
+			if err := m.Entries[len(m.Entries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNamespace(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NamespaceHistoryEntry) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNamespace
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NamespaceHistoryEntry: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NamespaceHistoryEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Registry", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Registry == nil {
+				m.Registry = &namespace1.Registry{}
+			}
+			if err := m.Registry.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpdatedAtNanos", wireType)
+			}
+			m.UpdatedAtNanos = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UpdatedAtNanos |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Operator", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Operator = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Deleted", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Deleted = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNamespace(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipNamespace(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -1232,29 +1768,36 @@ func init() {
 }
 
 var fileDescriptorNamespace = []byte{
-	// 381 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x91, 0xdf, 0x8a, 0xda, 0x40,
-	0x14, 0xc6, 0x1b, 0xff, 0x54, 0x3d, 0x52, 0x90, 0xd1, 0x8b, 0x34, 0x4a, 0x90, 0x5c, 0x79, 0x95,
-	0xa1, 0x4a, 0x41, 0xda, 0xab, 0x4a, 0x8b, 0xb4, 0xd0, 0x3f, 0x4c, 0x5f, 0xa0, 0x49, 0xe6, 0x10,
-	0x43, 0x4d, 0x26, 0x66, 0x92, 0x85, 0xbc, 0xc5, 0x3e, 0xd6, 0x5e, 0xee, 0x23, 0x2c, 0xee, 0x0b,
-	0xec, 0x23, 0x2c, 0x19, 0x93, 0xb8, 0xeb, 0x2a, 0x7b, 0x37, 0xe7, 0x7c, 0xe7, 0xfb, 0x7d, 0x33,
-	0x67, 0x60, 0xe5, 0x07, 0xe9, 0x26, 0x73, 0x6d, 0x4f, 0x84, 0x34, 0x5c, 0x70, 0x97, 0x86, 0x0b,
-	0x2a, 0x13, 0x8f, 0xee, 0x32, 0x4c, 0x72, 0xea, 0x63, 0x84, 0x89, 0x93, 0x22, 0xa7, 0x71, 0x22,
-	0x52, 0x41, 0x1d, 0x1e, 0x06, 0x11, 0x8d, 0x9c, 0x10, 0x65, 0xec, 0x78, 0x68, 0xab, 0x2e, 0x69,
-	0xab, 0xb6, 0xb1, 0xbe, 0x80, 0xe2, 0x6e, 0x24, 0x38, 0xbe, 0x60, 0xd5, 0x94, 0x53, 0x9e, 0xb5,
-	0x86, 0xd1, 0xaf, 0xaa, 0xb5, 0xc6, 0x94, 0xa1, 0x8c, 0x45, 0x24, 0x91, 0x50, 0xe8, 0x26, 0xe8,
-	0x07, 0x32, 0x4d, 0x72, 0x5d, 0x9b, 0x6a, 0xb3, 0xfe, 0x7c, 0x68, 0x1f, 0xbd, 0xac, 0x94, 0x58,
-	0x3d, 0x64, 0xfd, 0x83, 0x61, 0x0d, 0xfa, 0xc2, 0x39, 0xc3, 0x5d, 0x86, 0x32, 0x25, 0x04, 0x5a,
-	0x85, 0x4d, 0x31, 0x7a, 0x4c, 0x9d, 0xc9, 0x47, 0xe8, 0x88, 0x38, 0x0d, 0x44, 0x24, 0xf5, 0x86,
-	0x42, 0x8f, 0x9f, 0xa0, 0x6b, 0xc8, 0xef, 0xc3, 0x08, 0xab, 0x66, 0xad, 0x07, 0x0d, 0xde, 0xd7,
-	0xea, 0x5f, 0x6f, 0x83, 0xa1, 0xf3, 0x4a, 0x90, 0x0e, 0x9d, 0x50, 0xfa, 0x85, 0x47, 0x05, 0xf5,
-	0x58, 0x55, 0x92, 0x09, 0xf4, 0xd4, 0xfb, 0x95, 0xd6, 0x54, 0xda, 0xb1, 0x41, 0x7e, 0x40, 0x57,
-	0x15, 0x3f, 0x9d, 0x58, 0x6f, 0x4d, 0x9b, 0xb3, 0xfe, 0xdc, 0xb6, 0xd5, 0xde, 0xed, 0x8b, 0xf9,
-	0xf6, 0x9f, 0xd2, 0xf0, 0x2d, 0x52, 0x7b, 0xa9, 0xfc, 0xc6, 0x67, 0x78, 0xf7, 0x4c, 0x22, 0x03,
-	0x68, 0xfe, 0xc7, 0xbc, 0xbc, 0x67, 0x71, 0x24, 0x23, 0x68, 0x5f, 0x39, 0xdb, 0xac, 0xba, 0xe4,
-	0xa1, 0xf8, 0xd4, 0x58, 0x6a, 0xd6, 0x12, 0x8c, 0x73, 0x89, 0xe5, 0x1f, 0x19, 0xd0, 0xe5, 0x18,
-	0x6f, 0x45, 0xfe, 0xfd, 0x6b, 0x89, 0xab, 0x6b, 0xeb, 0x03, 0x8c, 0x4f, 0x9c, 0x0c, 0x65, 0xf1,
-	0xbf, 0x17, 0xb7, 0x65, 0x99, 0x30, 0x39, 0x6f, 0x39, 0xc4, 0xad, 0x06, 0x37, 0x7b, 0x53, 0xbb,
-	0xdd, 0x9b, 0xda, 0xdd, 0xde, 0xd4, 0xae, 0xef, 0xcd, 0x37, 0xee, 0x5b, 0xf5, 0xca, 0xc5, 0x63,
-	0x00, 0x00, 0x00, 0xff, 0xff, 0xe3, 0xa6, 0x90, 0x2e, 0xd9, 0x02, 0x00, 0x00,
+	// 488 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0xdd, 0x6e, 0xd3, 0x30,
+	0x14, 0xc6, 0xeb, 0xff, 0x99, 0x40, 0x93, 0x37, 0x50, 0xe8, 0xaa, 0xaa, 0xca, 0x05, 0xea, 0x55,
+	0x22, 0x56, 0x81, 0x26, 0xb8, 0xda, 0x04, 0x2a, 0x20, 0x31, 0x90, 0x79, 0x01, 0xdc, 0xfa, 0xa8,
+	0x8b, 0x68, 0xec, 0xcc, 0x76, 0x26, 0xe5, 0x2d, 0x78, 0x2c, 0x2e, 0xb9, 0xe4, 0x12, 0x95, 0x17,
+	0xe0, 0x11, 0x50, 0x9c, 0x38, 0x83, 0xd2, 0x09, 0x71, 0x97, 0xef, 0x7c, 0xe7, 0xfb, 0xce, 0xc9,
+	0x67, 0x1b, 0xce, 0x57, 0x89, 0xbd, 0xcc, 0x17, 0xd1, 0x52, 0xa5, 0x71, 0x3a, 0x13, 0x8b, 0x38,
+	0x9d, 0xc5, 0x46, 0x2f, 0xe3, 0xab, 0x1c, 0x75, 0x11, 0xaf, 0x50, 0xa2, 0xe6, 0x16, 0x45, 0x9c,
+	0x69, 0x65, 0x55, 0xcc, 0x45, 0x9a, 0xc8, 0x58, 0xf2, 0x14, 0x4d, 0xc6, 0x97, 0x18, 0xb9, 0x2a,
+	0xed, 0xb8, 0xf2, 0x70, 0x7e, 0x8b, 0x95, 0x58, 0x48, 0x25, 0xf0, 0x2f, 0xaf, 0xc6, 0x65, 0xdb,
+	0x2f, 0x9c, 0xc3, 0xd1, 0x85, 0x2f, 0xcd, 0xd1, 0x32, 0x34, 0x99, 0x92, 0x06, 0x69, 0x0c, 0x7d,
+	0x8d, 0xab, 0xc4, 0x58, 0x5d, 0x04, 0x64, 0x42, 0xa6, 0xfb, 0x27, 0x87, 0xd1, 0x8d, 0x96, 0xd5,
+	0x14, 0x6b, 0x9a, 0xc2, 0x8f, 0x70, 0xd8, 0x18, 0x9d, 0x09, 0xc1, 0xf0, 0x2a, 0x47, 0x63, 0x29,
+	0x85, 0x76, 0x29, 0x73, 0x1e, 0x03, 0xe6, 0xbe, 0xe9, 0x13, 0xe8, 0xa9, 0xcc, 0x26, 0x4a, 0x9a,
+	0x60, 0xcf, 0x59, 0x1f, 0xff, 0x66, 0xdd, 0x98, 0xbc, 0xab, 0x5a, 0x98, 0xef, 0x0d, 0x7f, 0x12,
+	0x78, 0xd8, 0xb0, 0x1f, 0x96, 0x97, 0x98, 0xf2, 0x7f, 0x0c, 0x0a, 0xa0, 0x97, 0x9a, 0x55, 0xa9,
+	0x71, 0x83, 0x06, 0xcc, 0x43, 0x3a, 0x82, 0x81, 0xfb, 0x7f, 0xc7, 0xb5, 0x1c, 0x77, 0x53, 0xa0,
+	0x6f, 0xa0, 0xef, 0xc0, 0x5b, 0x9e, 0x05, 0xed, 0x49, 0x6b, 0xba, 0x7f, 0x12, 0x45, 0x2e, 0xf7,
+	0xe8, 0xd6, 0xf9, 0xd1, 0xfb, 0x5a, 0xf0, 0x52, 0xba, 0x5c, 0xbc, 0x7e, 0xf8, 0x1c, 0xee, 0xfe,
+	0x41, 0xd1, 0x03, 0x68, 0x7d, 0xc2, 0xa2, 0xde, 0xb3, 0xfc, 0xa4, 0x47, 0xd0, 0xb9, 0xe6, 0xeb,
+	0xdc, 0x2f, 0x59, 0x81, 0x67, 0x7b, 0xa7, 0x24, 0x3c, 0x85, 0xe1, 0xae, 0x89, 0xf5, 0x19, 0x0d,
+	0xa1, 0x2f, 0x30, 0x5b, 0xab, 0xe2, 0xf5, 0x8b, 0xda, 0xae, 0xc1, 0xe1, 0x63, 0x38, 0xde, 0x52,
+	0x32, 0x34, 0x68, 0xeb, 0x6d, 0x77, 0xa5, 0x15, 0x8e, 0x61, 0xb4, 0x5b, 0x52, 0x8d, 0x0b, 0x19,
+	0x04, 0x0d, 0xff, 0x2a, 0x31, 0x56, 0xe9, 0xa2, 0x59, 0xe5, 0x29, 0xf4, 0x50, 0x5a, 0x9d, 0xa0,
+	0x09, 0x88, 0x0b, 0x6c, 0xb4, 0x1d, 0x58, 0xad, 0xa8, 0xe2, 0xf1, 0xcd, 0xe1, 0x37, 0x02, 0xf7,
+	0x77, 0xb6, 0xfc, 0xf7, 0x05, 0x2c, 0x0f, 0xfb, 0x1a, 0xb5, 0x49, 0x94, 0x74, 0x39, 0x76, 0x98,
+	0x87, 0xf4, 0x11, 0xdc, 0xcb, 0x33, 0x51, 0x3e, 0x87, 0x33, 0x7b, 0xc1, 0xa5, 0x32, 0xee, 0xc4,
+	0x5b, 0x6c, 0xab, 0x5a, 0xe6, 0xa9, 0xb2, 0xf2, 0xdd, 0x28, 0x1d, 0xb4, 0xab, 0x3c, 0x3d, 0xa6,
+	0x0f, 0xa0, 0xab, 0x91, 0x1b, 0x25, 0x83, 0x8e, 0x63, 0x6a, 0x54, 0x4e, 0x15, 0xb8, 0x46, 0x8b,
+	0x22, 0xe8, 0x4e, 0xc8, 0xb4, 0xcf, 0x3c, 0x3c, 0x3f, 0xf8, 0xb2, 0x19, 0x93, 0xaf, 0x9b, 0x31,
+	0xf9, 0xbe, 0x19, 0x93, 0xcf, 0x3f, 0xc6, 0x77, 0x16, 0x5d, 0x77, 0x29, 0x66, 0xbf, 0x06, 0x00,
+	0x7f, 0x9a, 0xa8, 0x9f, 0x08, 0x04, 0x00, 0x00,
 }
@@ -28,6 +28,8 @@ import fmt "fmt"
 import math "math"
 import placementpb "github.com/m3db/m3/src/cluster/generated/proto/placementpb"
 
+import binary "encoding/binary"
+
 import io "io"
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -96,6 +98,8 @@ type PlacementAddRequest struct {
 	// By default add requests will only succeed if all instances in the placement
 	// are AVAILABLE for all their shards. force overrides that.
 	Force bool `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	// dryRun computes the resulting placement without persisting it.
+	DryRun bool `protobuf:"varint,3,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
 }
 
 func (m *PlacementAddRequest) Reset()                    { *m = PlacementAddRequest{} }
@@ -117,16 +121,26 @@ func (m *PlacementAddRequest) GetForce() bool {
 	return false
 }
 
+func (m *PlacementAddRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
 type PlacementReplaceRequest struct {
 	LeavingInstanceIDs []string                `protobuf:"bytes,1,rep,name=leavingInstanceIDs" json:"leavingInstanceIDs,omitempty"`
 	Candidates         []*placementpb.Instance `protobuf:"bytes,2,rep,name=candidates" json:"candidates,omitempty"`
 	Force              bool                    `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`
+	DryRun             bool                    `protobuf:"varint,4,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
 }
 
-func (m *PlacementReplaceRequest) Reset()                    { *m = PlacementReplaceRequest{} }
-func (m *PlacementReplaceRequest) String() string            { return proto.CompactTextString(m) }
-func (*PlacementReplaceRequest) ProtoMessage()               {}
-func (*PlacementReplaceRequest) Descriptor() ([]byte, []int) { return fileDescriptorPlacement, []int{3} }
+func (m *PlacementReplaceRequest) Reset()         { *m = PlacementReplaceRequest{} }
+func (m *PlacementReplaceRequest) String() string { return proto.CompactTextString(m) }
+func (*PlacementReplaceRequest) ProtoMessage()    {}
+func (*PlacementReplaceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorPlacement, []int{3}
+}
 
 func (m *PlacementReplaceRequest) GetLeavingInstanceIDs() []string {
 	if m != nil {
@@ -149,6 +163,13 @@ func (m *PlacementReplaceRequest) GetForce() bool {
 	return false
 }
 
+func (m *PlacementReplaceRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
 type PlacementSetRequest struct {
 	Placement *placementpb.Placement `protobuf:"bytes,1,opt,name=placement" json:"placement,omitempty"`
 	Version   int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
@@ -214,6 +235,364 @@ func (m *PlacementSetResponse) GetDryRun() bool {
 	return false
 }
 
+type PlacementRemoveReplicaRequest struct {
+	// By default remove replica requests will only succeed if all instances in
+	// the placement are AVAILABLE for all their shards. force overrides that.
+	Force  bool `protobuf:"varint,1,opt,name=force,proto3" json:"force,omitempty"`
+	DryRun bool `protobuf:"varint,2,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
+}
+
+func (m *PlacementRemoveReplicaRequest) Reset()         { *m = PlacementRemoveReplicaRequest{} }
+func (m *PlacementRemoveReplicaRequest) String() string { return proto.CompactTextString(m) }
+func (*PlacementRemoveReplicaRequest) ProtoMessage()    {}
+func (*PlacementRemoveReplicaRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorPlacement, []int{6}
+}
+
+func (m *PlacementRemoveReplicaRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+func (m *PlacementRemoveReplicaRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+type PlacementSplitShardsRequest struct {
+	// num_shards is the new number of shards, it must be a multiple of the
+	// current number of shards.
+	NumShards int32 `protobuf:"varint,1,opt,name=num_shards,json=numShards,proto3" json:"num_shards,omitempty"`
+	Force     bool  `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	DryRun    bool  `protobuf:"varint,3,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
+}
+
+func (m *PlacementSplitShardsRequest) Reset()         { *m = PlacementSplitShardsRequest{} }
+func (m *PlacementSplitShardsRequest) String() string { return proto.CompactTextString(m) }
+func (*PlacementSplitShardsRequest) ProtoMessage()    {}
+func (*PlacementSplitShardsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorPlacement, []int{7}
+}
+
+func (m *PlacementSplitShardsRequest) GetNumShards() int32 {
+	if m != nil {
+		return m.NumShards
+	}
+	return 0
+}
+
+func (m *PlacementSplitShardsRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+func (m *PlacementSplitShardsRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+type PlacementBalanceRequest struct {
+	// max_moves limits the number of shards moved, zero does not limit the
+	// number of shards moved.
+	MaxMoves int32 `protobuf:"varint,1,opt,name=max_moves,json=maxMoves,proto3" json:"max_moves,omitempty"`
+	Force    bool  `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	DryRun   bool  `protobuf:"varint,3,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
+}
+
+func (m *PlacementBalanceRequest) Reset()         { *m = PlacementBalanceRequest{} }
+func (m *PlacementBalanceRequest) String() string { return proto.CompactTextString(m) }
+func (*PlacementBalanceRequest) ProtoMessage()    {}
+func (*PlacementBalanceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorPlacement, []int{8}
+}
+
+func (m *PlacementBalanceRequest) GetMaxMoves() int32 {
+	if m != nil {
+		return m.MaxMoves
+	}
+	return 0
+}
+
+func (m *PlacementBalanceRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+func (m *PlacementBalanceRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+type PlacementDryRunResponse struct {
+	Placement *placementpb.Placement `protobuf:"bytes,1,opt,name=placement" json:"placement,omitempty"`
+	Version   int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Diff      *PlacementDiff         `protobuf:"bytes,3,opt,name=diff" json:"diff,omitempty"`
+}
+
+func (m *PlacementDryRunResponse) Reset()         { *m = PlacementDryRunResponse{} }
+func (m *PlacementDryRunResponse) String() string { return proto.CompactTextString(m) }
+func (*PlacementDryRunResponse) ProtoMessage()    {}
+func (*PlacementDryRunResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorPlacement, []int{9}
+}
+
+func (m *PlacementDryRunResponse) GetPlacement() *placementpb.Placement {
+	if m != nil {
+		return m.Placement
+	}
+	return nil
+}
+
+func (m *PlacementDryRunResponse) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *PlacementDryRunResponse) GetDiff() *PlacementDiff {
+	if m != nil {
+		return m.Diff
+	}
+	return nil
+}
+
+type PlacementDiff struct {
+	// shardsMoved is the number of shard replicas gaining a new owner.
+	ShardsMoved int32 `protobuf:"varint,1,opt,name=shardsMoved,proto3" json:"shardsMoved,omitempty"`
+	// estimatedBytesMoved is only set when shard sizes could be estimated.
+	EstimatedBytesMoved int64                             `protobuf:"varint,2,opt,name=estimatedBytesMoved,proto3" json:"estimatedBytesMoved,omitempty"`
+	Instances           []*PlacementInstanceDiff          `protobuf:"bytes,3,rep,name=instances" json:"instances,omitempty"`
+	IsolationGroups     []*PlacementIsolationGroupBalance `protobuf:"bytes,4,rep,name=isolationGroups" json:"isolationGroups,omitempty"`
+}
+
+func (m *PlacementDiff) Reset()                    { *m = PlacementDiff{} }
+func (m *PlacementDiff) String() string            { return proto.CompactTextString(m) }
+func (*PlacementDiff) ProtoMessage()               {}
+func (*PlacementDiff) Descriptor() ([]byte, []int) { return fileDescriptorPlacement, []int{10} }
+
+func (m *PlacementDiff) GetShardsMoved() int32 {
+	if m != nil {
+		return m.ShardsMoved
+	}
+	return 0
+}
+
+func (m *PlacementDiff) GetEstimatedBytesMoved() int64 {
+	if m != nil {
+		return m.EstimatedBytesMoved
+	}
+	return 0
+}
+
+func (m *PlacementDiff) GetInstances() []*PlacementInstanceDiff {
+	if m != nil {
+		return m.Instances
+	}
+	return nil
+}
+
+func (m *PlacementDiff) GetIsolationGroups() []*PlacementIsolationGroupBalance {
+	if m != nil {
+		return m.IsolationGroups
+	}
+	return nil
+}
+
+type PlacementInstanceDiff struct {
+	Id                    string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	IsolationGroup        string   `protobuf:"bytes,2,opt,name=isolationGroup,proto3" json:"isolationGroup,omitempty"`
+	ShardsAdded           []uint32 `protobuf:"varint,3,rep,packed,name=shardsAdded" json:"shardsAdded,omitempty"`
+	ShardsRemoved         []uint32 `protobuf:"varint,4,rep,packed,name=shardsRemoved" json:"shardsRemoved,omitempty"`
+	EstimatedBytesAdded   int64    `protobuf:"varint,5,opt,name=estimatedBytesAdded,proto3" json:"estimatedBytesAdded,omitempty"`
+	EstimatedBytesRemoved int64    `protobuf:"varint,6,opt,name=estimatedBytesRemoved,proto3" json:"estimatedBytesRemoved,omitempty"`
+}
+
+func (m *PlacementInstanceDiff) Reset()                    { *m = PlacementInstanceDiff{} }
+func (m *PlacementInstanceDiff) String() string            { return proto.CompactTextString(m) }
+func (*PlacementInstanceDiff) ProtoMessage()               {}
+func (*PlacementInstanceDiff) Descriptor() ([]byte, []int) { return fileDescriptorPlacement, []int{11} }
+
+func (m *PlacementInstanceDiff) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *PlacementInstanceDiff) GetIsolationGroup() string {
+	if m != nil {
+		return m.IsolationGroup
+	}
+	return ""
+}
+
+func (m *PlacementInstanceDiff) GetShardsAdded() []uint32 {
+	if m != nil {
+		return m.ShardsAdded
+	}
+	return nil
+}
+
+func (m *PlacementInstanceDiff) GetShardsRemoved() []uint32 {
+	if m != nil {
+		return m.ShardsRemoved
+	}
+	return nil
+}
+
+func (m *PlacementInstanceDiff) GetEstimatedBytesAdded() int64 {
+	if m != nil {
+		return m.EstimatedBytesAdded
+	}
+	return 0
+}
+
+func (m *PlacementInstanceDiff) GetEstimatedBytesRemoved() int64 {
+	if m != nil {
+		return m.EstimatedBytesRemoved
+	}
+	return 0
+}
+
+type PlacementIsolationGroupBalance struct {
+	IsolationGroup string `protobuf:"bytes,1,opt,name=isolationGroup,proto3" json:"isolationGroup,omitempty"`
+	ShardsBefore   int32  `protobuf:"varint,2,opt,name=shardsBefore,proto3" json:"shardsBefore,omitempty"`
+	ShardsAfter    int32  `protobuf:"varint,3,opt,name=shardsAfter,proto3" json:"shardsAfter,omitempty"`
+	// load is the fraction of all shard replicas owned by the isolation group.
+	LoadBefore float64 `protobuf:"fixed64,4,opt,name=loadBefore,proto3" json:"loadBefore,omitempty"`
+	LoadAfter  float64 `protobuf:"fixed64,5,opt,name=loadAfter,proto3" json:"loadAfter,omitempty"`
+}
+
+func (m *PlacementIsolationGroupBalance) Reset()         { *m = PlacementIsolationGroupBalance{} }
+func (m *PlacementIsolationGroupBalance) String() string { return proto.CompactTextString(m) }
+func (*PlacementIsolationGroupBalance) ProtoMessage()    {}
+func (*PlacementIsolationGroupBalance) Descriptor() ([]byte, []int) {
+	return fileDescriptorPlacement, []int{12}
+}
+
+func (m *PlacementIsolationGroupBalance) GetIsolationGroup() string {
+	if m != nil {
+		return m.IsolationGroup
+	}
+	return ""
+}
+
+func (m *PlacementIsolationGroupBalance) GetShardsBefore() int32 {
+	if m != nil {
+		return m.ShardsBefore
+	}
+	return 0
+}
+
+func (m *PlacementIsolationGroupBalance) GetShardsAfter() int32 {
+	if m != nil {
+		return m.ShardsAfter
+	}
+	return 0
+}
+
+func (m *PlacementIsolationGroupBalance) GetLoadBefore() float64 {
+	if m != nil {
+		return m.LoadBefore
+	}
+	return 0
+}
+
+func (m *PlacementIsolationGroupBalance) GetLoadAfter() float64 {
+	if m != nil {
+		return m.LoadAfter
+	}
+	return 0
+}
+
+type PlacementHistoryResponse struct {
+	// entries are ordered from the oldest to the most recent placement.
+	Entries []*PlacementHistoryEntry `protobuf:"bytes,1,rep,name=entries" json:"entries,omitempty"`
+}
+
+func (m *PlacementHistoryResponse) Reset()         { *m = PlacementHistoryResponse{} }
+func (m *PlacementHistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*PlacementHistoryResponse) ProtoMessage()    {}
+func (*PlacementHistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorPlacement, []int{13}
+}
+
+func (m *PlacementHistoryResponse) GetEntries() []*PlacementHistoryEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+type PlacementHistoryEntry struct {
+	Placement      *placementpb.Placement `protobuf:"bytes,1,opt,name=placement" json:"placement,omitempty"`
+	Version        int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	UpdatedAtNanos int64                  `protobuf:"varint,3,opt,name=updatedAtNanos,proto3" json:"updatedAtNanos,omitempty"`
+	Operator       string                 `protobuf:"bytes,4,opt,name=operator,proto3" json:"operator,omitempty"`
+	Reason         string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	Deleted        bool                   `protobuf:"varint,6,opt,name=deleted,proto3" json:"deleted,omitempty"`
+}
+
+func (m *PlacementHistoryEntry) Reset()                    { *m = PlacementHistoryEntry{} }
+func (m *PlacementHistoryEntry) String() string            { return proto.CompactTextString(m) }
+func (*PlacementHistoryEntry) ProtoMessage()               {}
+func (*PlacementHistoryEntry) Descriptor() ([]byte, []int) { return fileDescriptorPlacement, []int{14} }
+
+func (m *PlacementHistoryEntry) GetPlacement() *placementpb.Placement {
+	if m != nil {
+		return m.Placement
+	}
+	return nil
+}
+
+func (m *PlacementHistoryEntry) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *PlacementHistoryEntry) GetUpdatedAtNanos() int64 {
+	if m != nil {
+		return m.UpdatedAtNanos
+	}
+	return 0
+}
+
+func (m *PlacementHistoryEntry) GetOperator() string {
+	if m != nil {
+		return m.Operator
+	}
+	return ""
+}
+
+func (m *PlacementHistoryEntry) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *PlacementHistoryEntry) GetDeleted() bool {
+	if m != nil {
+		return m.Deleted
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*PlacementInitRequest)(nil), "admin.PlacementInitRequest")
 	proto.RegisterType((*PlacementGetResponse)(nil), "admin.PlacementGetResponse")
@@ -221,6 +600,15 @@ func init() {
 	proto.RegisterType((*PlacementReplaceRequest)(nil), "admin.PlacementReplaceRequest")
 	proto.RegisterType((*PlacementSetRequest)(nil), "admin.PlacementSetRequest")
 	proto.RegisterType((*PlacementSetResponse)(nil), "admin.PlacementSetResponse")
+	proto.RegisterType((*PlacementRemoveReplicaRequest)(nil), "admin.PlacementRemoveReplicaRequest")
+	proto.RegisterType((*PlacementSplitShardsRequest)(nil), "admin.PlacementSplitShardsRequest")
+	proto.RegisterType((*PlacementBalanceRequest)(nil), "admin.PlacementBalanceRequest")
+	proto.RegisterType((*PlacementDryRunResponse)(nil), "admin.PlacementDryRunResponse")
+	proto.RegisterType((*PlacementDiff)(nil), "admin.PlacementDiff")
+	proto.RegisterType((*PlacementInstanceDiff)(nil), "admin.PlacementInstanceDiff")
+	proto.RegisterType((*PlacementIsolationGroupBalance)(nil), "admin.PlacementIsolationGroupBalance")
+	proto.RegisterType((*PlacementHistoryResponse)(nil), "admin.PlacementHistoryResponse")
+	proto.RegisterType((*PlacementHistoryEntry)(nil), "admin.PlacementHistoryEntry")
 }
 func (m *PlacementInitRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
@@ -332,6 +720,16 @@ func (m *PlacementAddRequest) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i++
 	}
+	if m.DryRun {
+		dAtA[i] = 0x18
+		i++
+		if m.DryRun {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -387,6 +785,16 @@ func (m *PlacementReplaceRequest) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i++
 	}
+	if m.DryRun {
+		dAtA[i] = 0x20
+		i++
+		if m.DryRun {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -476,128 +884,1920 @@ func (m *PlacementSetResponse) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func encodeVarintPlacement(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *PlacementRemoveReplicaRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+	return dAtA[:n], nil
 }
-func (m *PlacementInitRequest) Size() (n int) {
-	var l int
-	_ = l
-	if len(m.Instances) > 0 {
-		for _, e := range m.Instances {
-			l = e.Size()
-			n += 1 + l + sovPlacement(uint64(l))
+
+func (m *PlacementRemoveReplicaRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Force {
+		dAtA[i] = 0x8
+		i++
+		if m.Force {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.DryRun {
+		dAtA[i] = 0x10
+		i++
+		if m.DryRun {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
 		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *PlacementSplitShardsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *PlacementSplitShardsRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
 	if m.NumShards != 0 {
-		n += 1 + sovPlacement(uint64(m.NumShards))
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(m.NumShards))
 	}
-	if m.ReplicationFactor != 0 {
-		n += 1 + sovPlacement(uint64(m.ReplicationFactor))
+	if m.Force {
+		dAtA[i] = 0x10
+		i++
+		if m.Force {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
-	return n
+	if m.DryRun {
+		dAtA[i] = 0x18
+		i++
+		if m.DryRun {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
 }
 
-func (m *PlacementGetResponse) Size() (n int) {
+func (m *PlacementBalanceRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PlacementBalanceRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.MaxMoves != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(m.MaxMoves))
+	}
+	if m.Force {
+		dAtA[i] = 0x10
+		i++
+		if m.Force {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.DryRun {
+		dAtA[i] = 0x18
+		i++
+		if m.DryRun {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *PlacementDryRunResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PlacementDryRunResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.Placement != nil {
-		l = m.Placement.Size()
-		n += 1 + l + sovPlacement(uint64(l))
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(m.Placement.Size()))
+		n4, err := m.Placement.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n4
 	}
 	if m.Version != 0 {
-		n += 1 + sovPlacement(uint64(m.Version))
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(m.Version))
 	}
-	return n
+	if m.Diff != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(m.Diff.Size()))
+		n5, err := m.Diff.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n5
+	}
+	return i, nil
 }
 
-func (m *PlacementAddRequest) Size() (n int) {
+func (m *PlacementDiff) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PlacementDiff) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
+	if m.ShardsMoved != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(m.ShardsMoved))
+	}
+	if m.EstimatedBytesMoved != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(m.EstimatedBytesMoved))
+	}
 	if len(m.Instances) > 0 {
-		for _, e := range m.Instances {
-			l = e.Size()
-			n += 1 + l + sovPlacement(uint64(l))
+		for _, msg := range m.Instances {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintPlacement(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
 		}
 	}
-	if m.Force {
-		n += 2
+	if len(m.IsolationGroups) > 0 {
+		for _, msg := range m.IsolationGroups {
+			dAtA[i] = 0x22
+			i++
+			i = encodeVarintPlacement(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
 	}
-	return n
+	return i, nil
 }
 
-func (m *PlacementReplaceRequest) Size() (n int) {
+func (m *PlacementInstanceDiff) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PlacementInstanceDiff) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if len(m.LeavingInstanceIDs) > 0 {
-		for _, s := range m.LeavingInstanceIDs {
-			l = len(s)
-			n += 1 + l + sovPlacement(uint64(l))
+	if len(m.Id) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(len(m.Id)))
+		i += copy(dAtA[i:], m.Id)
+	}
+	if len(m.IsolationGroup) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(len(m.IsolationGroup)))
+		i += copy(dAtA[i:], m.IsolationGroup)
+	}
+	if len(m.ShardsAdded) > 0 {
+		dAtA7 := make([]byte, len(m.ShardsAdded)*10)
+		var j6 int
+		for _, num := range m.ShardsAdded {
+			for num >= 1<<7 {
+				dAtA7[j6] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j6++
+			}
+			dAtA7[j6] = uint8(num)
+			j6++
+		}
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(j6))
+		i += copy(dAtA[i:], dAtA7[:j6])
+	}
+	if len(m.ShardsRemoved) > 0 {
+		dAtA9 := make([]byte, len(m.ShardsRemoved)*10)
+		var j8 int
+		for _, num := range m.ShardsRemoved {
+			for num >= 1<<7 {
+				dAtA9[j8] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j8++
+			}
+			dAtA9[j8] = uint8(num)
+			j8++
 		}
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(j8))
+		i += copy(dAtA[i:], dAtA9[:j8])
 	}
-	if len(m.Candidates) > 0 {
-		for _, e := range m.Candidates {
-			l = e.Size()
-			n += 1 + l + sovPlacement(uint64(l))
+	if m.EstimatedBytesAdded != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(m.EstimatedBytesAdded))
+	}
+	if m.EstimatedBytesRemoved != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(m.EstimatedBytesRemoved))
+	}
+	return i, nil
+}
+
+func (m *PlacementIsolationGroupBalance) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PlacementIsolationGroupBalance) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.IsolationGroup) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(len(m.IsolationGroup)))
+		i += copy(dAtA[i:], m.IsolationGroup)
+	}
+	if m.ShardsBefore != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(m.ShardsBefore))
+	}
+	if m.ShardsAfter != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(m.ShardsAfter))
+	}
+	if m.LoadBefore != 0 {
+		dAtA[i] = 0x21
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.LoadBefore))))
+		i += 8
+	}
+	if m.LoadAfter != 0 {
+		dAtA[i] = 0x29
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.LoadAfter))))
+		i += 8
+	}
+	return i, nil
+}
+
+func (m *PlacementHistoryResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PlacementHistoryResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Entries) > 0 {
+		for _, msg := range m.Entries {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintPlacement(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *PlacementHistoryEntry) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PlacementHistoryEntry) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Placement != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(m.Placement.Size()))
+		n10, err := m.Placement.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n10
+	}
+	if m.Version != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(m.Version))
+	}
+	if m.UpdatedAtNanos != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(m.UpdatedAtNanos))
+	}
+	if len(m.Operator) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(len(m.Operator)))
+		i += copy(dAtA[i:], m.Operator)
+	}
+	if len(m.Reason) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintPlacement(dAtA, i, uint64(len(m.Reason)))
+		i += copy(dAtA[i:], m.Reason)
+	}
+	if m.Deleted {
+		dAtA[i] = 0x30
+		i++
+		if m.Deleted {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func encodeVarintPlacement(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *PlacementInitRequest) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Instances) > 0 {
+		for _, e := range m.Instances {
+			l = e.Size()
+			n += 1 + l + sovPlacement(uint64(l))
+		}
+	}
+	if m.NumShards != 0 {
+		n += 1 + sovPlacement(uint64(m.NumShards))
+	}
+	if m.ReplicationFactor != 0 {
+		n += 1 + sovPlacement(uint64(m.ReplicationFactor))
+	}
+	return n
+}
+
+func (m *PlacementGetResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Placement != nil {
+		l = m.Placement.Size()
+		n += 1 + l + sovPlacement(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 1 + sovPlacement(uint64(m.Version))
+	}
+	return n
+}
+
+func (m *PlacementAddRequest) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Instances) > 0 {
+		for _, e := range m.Instances {
+			l = e.Size()
+			n += 1 + l + sovPlacement(uint64(l))
+		}
+	}
+	if m.Force {
+		n += 2
+	}
+	if m.DryRun {
+		n += 2
+	}
+	return n
+}
+
+func (m *PlacementReplaceRequest) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.LeavingInstanceIDs) > 0 {
+		for _, s := range m.LeavingInstanceIDs {
+			l = len(s)
+			n += 1 + l + sovPlacement(uint64(l))
+		}
+	}
+	if len(m.Candidates) > 0 {
+		for _, e := range m.Candidates {
+			l = e.Size()
+			n += 1 + l + sovPlacement(uint64(l))
+		}
+	}
+	if m.Force {
+		n += 2
+	}
+	if m.DryRun {
+		n += 2
+	}
+	return n
+}
+
+func (m *PlacementSetRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Placement != nil {
+		l = m.Placement.Size()
+		n += 1 + l + sovPlacement(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 1 + sovPlacement(uint64(m.Version))
+	}
+	if m.Confirm {
+		n += 2
+	}
+	return n
+}
+
+func (m *PlacementSetResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Placement != nil {
+		l = m.Placement.Size()
+		n += 1 + l + sovPlacement(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 1 + sovPlacement(uint64(m.Version))
+	}
+	if m.DryRun {
+		n += 2
+	}
+	return n
+}
+
+func (m *PlacementRemoveReplicaRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Force {
+		n += 2
+	}
+	if m.DryRun {
+		n += 2
+	}
+	return n
+}
+
+func (m *PlacementSplitShardsRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.NumShards != 0 {
+		n += 1 + sovPlacement(uint64(m.NumShards))
+	}
+	if m.Force {
+		n += 2
+	}
+	if m.DryRun {
+		n += 2
+	}
+	return n
+}
+
+func (m *PlacementBalanceRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.MaxMoves != 0 {
+		n += 1 + sovPlacement(uint64(m.MaxMoves))
+	}
+	if m.Force {
+		n += 2
+	}
+	if m.DryRun {
+		n += 2
+	}
+	return n
+}
+
+func (m *PlacementDryRunResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Placement != nil {
+		l = m.Placement.Size()
+		n += 1 + l + sovPlacement(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 1 + sovPlacement(uint64(m.Version))
+	}
+	if m.Diff != nil {
+		l = m.Diff.Size()
+		n += 1 + l + sovPlacement(uint64(l))
+	}
+	return n
+}
+
+func (m *PlacementDiff) Size() (n int) {
+	var l int
+	_ = l
+	if m.ShardsMoved != 0 {
+		n += 1 + sovPlacement(uint64(m.ShardsMoved))
+	}
+	if m.EstimatedBytesMoved != 0 {
+		n += 1 + sovPlacement(uint64(m.EstimatedBytesMoved))
+	}
+	if len(m.Instances) > 0 {
+		for _, e := range m.Instances {
+			l = e.Size()
+			n += 1 + l + sovPlacement(uint64(l))
+		}
+	}
+	if len(m.IsolationGroups) > 0 {
+		for _, e := range m.IsolationGroups {
+			l = e.Size()
+			n += 1 + l + sovPlacement(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *PlacementInstanceDiff) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovPlacement(uint64(l))
+	}
+	l = len(m.IsolationGroup)
+	if l > 0 {
+		n += 1 + l + sovPlacement(uint64(l))
+	}
+	if len(m.ShardsAdded) > 0 {
+		l = 0
+		for _, e := range m.ShardsAdded {
+			l += sovPlacement(uint64(e))
+		}
+		n += 1 + sovPlacement(uint64(l)) + l
+	}
+	if len(m.ShardsRemoved) > 0 {
+		l = 0
+		for _, e := range m.ShardsRemoved {
+			l += sovPlacement(uint64(e))
+		}
+		n += 1 + sovPlacement(uint64(l)) + l
+	}
+	if m.EstimatedBytesAdded != 0 {
+		n += 1 + sovPlacement(uint64(m.EstimatedBytesAdded))
+	}
+	if m.EstimatedBytesRemoved != 0 {
+		n += 1 + sovPlacement(uint64(m.EstimatedBytesRemoved))
+	}
+	return n
+}
+
+func (m *PlacementIsolationGroupBalance) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.IsolationGroup)
+	if l > 0 {
+		n += 1 + l + sovPlacement(uint64(l))
+	}
+	if m.ShardsBefore != 0 {
+		n += 1 + sovPlacement(uint64(m.ShardsBefore))
+	}
+	if m.ShardsAfter != 0 {
+		n += 1 + sovPlacement(uint64(m.ShardsAfter))
+	}
+	if m.LoadBefore != 0 {
+		n += 9
+	}
+	if m.LoadAfter != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *PlacementHistoryResponse) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Entries) > 0 {
+		for _, e := range m.Entries {
+			l = e.Size()
+			n += 1 + l + sovPlacement(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *PlacementHistoryEntry) Size() (n int) {
+	var l int
+	_ = l
+	if m.Placement != nil {
+		l = m.Placement.Size()
+		n += 1 + l + sovPlacement(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 1 + sovPlacement(uint64(m.Version))
+	}
+	if m.UpdatedAtNanos != 0 {
+		n += 1 + sovPlacement(uint64(m.UpdatedAtNanos))
+	}
+	l = len(m.Operator)
+	if l > 0 {
+		n += 1 + l + sovPlacement(uint64(l))
+	}
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovPlacement(uint64(l))
+	}
+	if m.Deleted {
+		n += 2
+	}
+	return n
+}
+
+func sovPlacement(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozPlacement(x uint64) (n int) {
+	return sovPlacement(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *PlacementInitRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPlacement
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PlacementInitRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PlacementInitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Instances", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Instances = append(m.Instances, &placementpb.Instance{})
+			if err := m.Instances[len(m.Instances)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumShards", wireType)
+			}
+			m.NumShards = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NumShards |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReplicationFactor", wireType)
+			}
+			m.ReplicationFactor = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReplicationFactor |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPlacement(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PlacementGetResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPlacement
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PlacementGetResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PlacementGetResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Placement", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Placement == nil {
+				m.Placement = &placementpb.Placement{}
+			}
+			if err := m.Placement.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPlacement(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PlacementAddRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPlacement
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PlacementAddRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PlacementAddRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Instances", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Instances = append(m.Instances, &placementpb.Instance{})
+			if err := m.Instances[len(m.Instances)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Force", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Force = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DryRun", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DryRun = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPlacement(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PlacementReplaceRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPlacement
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PlacementReplaceRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PlacementReplaceRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeavingInstanceIDs", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LeavingInstanceIDs = append(m.LeavingInstanceIDs, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Candidates", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Candidates = append(m.Candidates, &placementpb.Instance{})
+			if err := m.Candidates[len(m.Candidates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Force", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Force = bool(v != 0)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DryRun", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DryRun = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPlacement(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PlacementSetRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPlacement
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PlacementSetRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PlacementSetRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Placement", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Placement == nil {
+				m.Placement = &placementpb.Placement{}
+			}
+			if err := m.Placement.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Confirm", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Confirm = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPlacement(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PlacementSetResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPlacement
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PlacementSetResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PlacementSetResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Placement", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Placement == nil {
+				m.Placement = &placementpb.Placement{}
+			}
+			if err := m.Placement.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DryRun", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DryRun = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPlacement(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PlacementRemoveReplicaRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPlacement
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PlacementRemoveReplicaRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PlacementRemoveReplicaRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Force", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Force = bool(v != 0)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DryRun", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DryRun = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPlacement(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PlacementSplitShardsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPlacement
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PlacementSplitShardsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PlacementSplitShardsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumShards", wireType)
+			}
+			m.NumShards = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NumShards |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Force", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Force = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DryRun", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DryRun = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPlacement(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PlacementBalanceRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPlacement
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PlacementBalanceRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PlacementBalanceRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxMoves", wireType)
+			}
+			m.MaxMoves = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxMoves |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Force", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Force = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DryRun", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DryRun = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPlacement(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PlacementDryRunResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPlacement
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PlacementDryRunResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PlacementDryRunResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Placement", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Placement == nil {
+				m.Placement = &placementpb.Placement{}
+			}
+			if err := m.Placement.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Diff", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Diff == nil {
+				m.Diff = &PlacementDiff{}
+			}
+			if err := m.Diff.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPlacement(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
 		}
 	}
-	if m.Force {
-		n += 2
-	}
-	return n
-}
-
-func (m *PlacementSetRequest) Size() (n int) {
-	var l int
-	_ = l
-	if m.Placement != nil {
-		l = m.Placement.Size()
-		n += 1 + l + sovPlacement(uint64(l))
-	}
-	if m.Version != 0 {
-		n += 1 + sovPlacement(uint64(m.Version))
-	}
-	if m.Confirm {
-		n += 2
-	}
-	return n
-}
-
-func (m *PlacementSetResponse) Size() (n int) {
-	var l int
-	_ = l
-	if m.Placement != nil {
-		l = m.Placement.Size()
-		n += 1 + l + sovPlacement(uint64(l))
-	}
-	if m.Version != 0 {
-		n += 1 + sovPlacement(uint64(m.Version))
-	}
-	if m.DryRun {
-		n += 2
-	}
-	return n
-}
 
-func sovPlacement(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
-}
-func sozPlacement(x uint64) (n int) {
-	return sovPlacement(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *PlacementInitRequest) Unmarshal(dAtA []byte) error {
+func (m *PlacementDiff) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -620,17 +2820,17 @@ func (m *PlacementInitRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PlacementInitRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: PlacementDiff: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PlacementInitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PlacementDiff: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Instances", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardsMoved", wireType)
 			}
-			var msglen int
+			m.ShardsMoved = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPlacement
@@ -640,28 +2840,16 @@ func (m *PlacementInitRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.ShardsMoved |= (int32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthPlacement
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Instances = append(m.Instances, &placementpb.Instance{})
-			if err := m.Instances[len(m.Instances)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NumShards", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EstimatedBytesMoved", wireType)
 			}
-			m.NumShards = 0
+			m.EstimatedBytesMoved = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPlacement
@@ -671,16 +2859,16 @@ func (m *PlacementInitRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.NumShards |= (int32(b) & 0x7F) << shift
+				m.EstimatedBytesMoved |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReplicationFactor", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Instances", wireType)
 			}
-			m.ReplicationFactor = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPlacement
@@ -690,11 +2878,54 @@ func (m *PlacementInitRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ReplicationFactor |= (int32(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Instances = append(m.Instances, &PlacementInstanceDiff{})
+			if err := m.Instances[len(m.Instances)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsolationGroups", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IsolationGroups = append(m.IsolationGroups, &PlacementIsolationGroupBalance{})
+			if err := m.IsolationGroups[len(m.IsolationGroups)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPlacement(dAtA[iNdEx:])
@@ -716,7 +2947,7 @@ func (m *PlacementInitRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PlacementGetResponse) Unmarshal(dAtA []byte) error {
+func (m *PlacementInstanceDiff) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -735,21 +2966,203 @@ func (m *PlacementGetResponse) Unmarshal(dAtA []byte) error {
 			if b < 0x80 {
 				break
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: PlacementGetResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PlacementGetResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Placement", wireType)
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PlacementInstanceDiff: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PlacementInstanceDiff: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsolationGroup", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IsolationGroup = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType == 0 {
+				var v uint32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPlacement
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (uint32(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.ShardsAdded = append(m.ShardsAdded, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPlacement
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPlacement
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPlacement
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (uint32(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.ShardsAdded = append(m.ShardsAdded, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardsAdded", wireType)
+			}
+		case 4:
+			if wireType == 0 {
+				var v uint32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPlacement
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (uint32(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.ShardsRemoved = append(m.ShardsRemoved, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPlacement
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPlacement
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPlacement
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (uint32(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.ShardsRemoved = append(m.ShardsRemoved, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardsRemoved", wireType)
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EstimatedBytesAdded", wireType)
 			}
-			var msglen int
+			m.EstimatedBytesAdded = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPlacement
@@ -759,30 +3172,16 @@ func (m *PlacementGetResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.EstimatedBytesAdded |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthPlacement
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Placement == nil {
-				m.Placement = &placementpb.Placement{}
-			}
-			if err := m.Placement.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 2:
+		case 6:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EstimatedBytesRemoved", wireType)
 			}
-			m.Version = 0
+			m.EstimatedBytesRemoved = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPlacement
@@ -792,7 +3191,7 @@ func (m *PlacementGetResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Version |= (int32(b) & 0x7F) << shift
+				m.EstimatedBytesRemoved |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -818,7 +3217,7 @@ func (m *PlacementGetResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PlacementAddRequest) Unmarshal(dAtA []byte) error {
+func (m *PlacementIsolationGroupBalance) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -841,17 +3240,17 @@ func (m *PlacementAddRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PlacementAddRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: PlacementIsolationGroupBalance: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PlacementAddRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PlacementIsolationGroupBalance: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Instances", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field IsolationGroup", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPlacement
@@ -861,28 +3260,26 @@ func (m *PlacementAddRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPlacement
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Instances = append(m.Instances, &placementpb.Instance{})
-			if err := m.Instances[len(m.Instances)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.IsolationGroup = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Force", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardsBefore", wireType)
 			}
-			var v int
+			m.ShardsBefore = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPlacement
@@ -892,12 +3289,52 @@ func (m *PlacementAddRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				m.ShardsBefore |= (int32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Force = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardsAfter", wireType)
+			}
+			m.ShardsAfter = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPlacement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ShardsAfter |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LoadBefore", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.LoadBefore = float64(math.Float64frombits(v))
+		case 5:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LoadAfter", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.LoadAfter = float64(math.Float64frombits(v))
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPlacement(dAtA[iNdEx:])
@@ -919,7 +3356,7 @@ func (m *PlacementAddRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PlacementReplaceRequest) Unmarshal(dAtA []byte) error {
+func (m *PlacementHistoryResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -942,44 +3379,15 @@ func (m *PlacementReplaceRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PlacementReplaceRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: PlacementHistoryResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PlacementReplaceRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PlacementHistoryResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LeavingInstanceIDs", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPlacement
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPlacement
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.LeavingInstanceIDs = append(m.LeavingInstanceIDs, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Candidates", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Entries", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1003,31 +3411,11 @@ func (m *PlacementReplaceRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Candidates = append(m.Candidates, &placementpb.Instance{})
-			if err := m.Candidates[len(m.Candidates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Entries = append(m.Entries, &PlacementHistoryEntry{})
+			if err := m.Entries[len(m.Entries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Force", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPlacement
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.Force = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPlacement(dAtA[iNdEx:])
@@ -1049,7 +3437,7 @@ func (m *PlacementReplaceRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PlacementSetRequest) Unmarshal(dAtA []byte) error {
+func (m *PlacementHistoryEntry) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1072,10 +3460,10 @@ func (m *PlacementSetRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PlacementSetRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: PlacementHistoryEntry: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PlacementSetRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PlacementHistoryEntry: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -1132,9 +3520,9 @@ func (m *PlacementSetRequest) Unmarshal(dAtA []byte) error {
 			}
 		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Confirm", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UpdatedAtNanos", wireType)
 			}
-			var v int
+			m.UpdatedAtNanos = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPlacement
@@ -1144,67 +3532,16 @@ func (m *PlacementSetRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				m.UpdatedAtNanos |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Confirm = bool(v != 0)
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPlacement(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthPlacement
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *PlacementSetResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPlacement
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: PlacementSetResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PlacementSetResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Placement", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Operator", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPlacement
@@ -1214,30 +3551,26 @@ func (m *PlacementSetResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPlacement
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Placement == nil {
-				m.Placement = &placementpb.Placement{}
-			}
-			if err := m.Placement.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Operator = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
 			}
-			m.Version = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPlacement
@@ -1247,14 +3580,24 @@ func (m *PlacementSetResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Version |= (int32(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPlacement
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DryRun", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Deleted", wireType)
 			}
 			var v int
 			for shift := uint(0); ; shift += 7 {
@@ -1271,7 +3614,7 @@ func (m *PlacementSetResponse) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			m.DryRun = bool(v != 0)
+			m.Deleted = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPlacement(dAtA[iNdEx:])
@@ -1403,31 +3746,57 @@ func init() {
 }
 
 var fileDescriptorPlacement = []byte{
-	// 407 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x93, 0x4f, 0x6e, 0xd4, 0x30,
-	0x14, 0xc6, 0x31, 0xa3, 0x29, 0x8c, 0xbb, 0x01, 0x53, 0x4a, 0x84, 0x44, 0x34, 0xca, 0x6a, 0x36,
-	0xc4, 0x52, 0x03, 0x07, 0xa0, 0x42, 0xa0, 0x61, 0x85, 0x3c, 0x07, 0x28, 0x8e, 0xfd, 0x32, 0xb5,
-	0x14, 0xdb, 0xa9, 0xed, 0x54, 0xea, 0x06, 0xae, 0xc0, 0x0a, 0x89, 0x1b, 0xb1, 0xe4, 0x08, 0x68,
-	0xb8, 0x08, 0xaa, 0x27, 0xff, 0xf8, 0xbb, 0x01, 0x96, 0xef, 0x7d, 0xcf, 0xdf, 0xf7, 0xcb, 0x7b,
-	0x0a, 0x3e, 0xdd, 0xaa, 0x70, 0xde, 0x96, 0xb9, 0xb0, 0x9a, 0xea, 0x42, 0x96, 0x54, 0x17, 0xd4,
-	0x3b, 0x41, 0x2f, 0x5a, 0x70, 0x57, 0x74, 0x0b, 0x06, 0x1c, 0x0f, 0x20, 0x69, 0xe3, 0x6c, 0xb0,
-	0x94, 0x4b, 0xad, 0x0c, 0x6d, 0x6a, 0x2e, 0x40, 0x83, 0x09, 0x79, 0xec, 0x92, 0x79, 0x6c, 0x3f,
-	0x7c, 0xf5, 0x1b, 0x2b, 0x51, 0xb7, 0x3e, 0x80, 0xfb, 0xc9, 0x6c, 0xb0, 0x69, 0xca, 0x1f, 0x2d,
-	0xb3, 0x8f, 0x08, 0x1f, 0xbd, 0xee, 0x7b, 0x6b, 0xa3, 0x02, 0x83, 0x8b, 0x16, 0x7c, 0x20, 0x05,
-	0x5e, 0x28, 0xe3, 0x03, 0x37, 0x02, 0x7c, 0x82, 0x96, 0xb3, 0xd5, 0xe1, 0xc9, 0xfd, 0x7c, 0xe2,
-	0x94, 0xaf, 0x3b, 0x95, 0x8d, 0x73, 0xe4, 0x11, 0xc6, 0xa6, 0xd5, 0x67, 0xfe, 0x9c, 0x3b, 0xe9,
-	0x93, 0x9b, 0x4b, 0xb4, 0x9a, 0xb3, 0x85, 0x69, 0xf5, 0x26, 0x36, 0xc8, 0x63, 0x4c, 0x1c, 0x34,
-	0xb5, 0x12, 0x3c, 0x28, 0x6b, 0xce, 0x2a, 0x2e, 0x82, 0x75, 0xc9, 0x2c, 0x8e, 0xdd, 0x9d, 0x28,
-	0x2f, 0xa2, 0x90, 0x55, 0x13, 0xb4, 0x97, 0x10, 0x18, 0xf8, 0xc6, 0x1a, 0x0f, 0xe4, 0x09, 0x5e,
-	0x0c, 0x20, 0x09, 0x5a, 0xa2, 0xd5, 0xe1, 0xc9, 0xf1, 0x77, 0x68, 0xc3, 0x2b, 0x36, 0x0e, 0x92,
-	0x04, 0xdf, 0xba, 0x04, 0xe7, 0x95, 0x35, 0x1d, 0x58, 0x5f, 0x66, 0x6f, 0xf0, 0xbd, 0xe1, 0xc5,
-	0x33, 0x29, 0xff, 0x6a, 0x03, 0x47, 0x78, 0x5e, 0x59, 0x27, 0x20, 0x66, 0xdc, 0x66, 0xfb, 0x22,
-	0xfb, 0x80, 0xf0, 0x83, 0x11, 0x0a, 0xa2, 0x49, 0x1f, 0x93, 0x63, 0x52, 0x03, 0xbf, 0x54, 0x66,
-	0xdb, 0xfb, 0xad, 0x9f, 0xef, 0xf3, 0x16, 0xec, 0x17, 0x0a, 0x79, 0x8a, 0xb1, 0xe0, 0x46, 0x2a,
-	0xc9, 0x03, 0x5c, 0xef, 0xf8, 0x0f, 0x5c, 0x93, 0xc1, 0x11, 0x6c, 0x36, 0x05, 0x7b, 0x37, 0xf9,
-	0xf4, 0x0d, 0x0c, 0xc7, 0xff, 0xc7, 0x1b, 0xbe, 0x56, 0x84, 0x35, 0x95, 0x72, 0xba, 0x8b, 0xef,
-	0xcb, 0xec, 0xed, 0xe4, 0xc6, 0x9b, 0xff, 0x77, 0x63, 0x72, 0x8c, 0x0f, 0xa4, 0xbb, 0x62, 0xad,
-	0xe9, 0x00, 0xba, 0xea, 0xf4, 0xce, 0xa7, 0x5d, 0x8a, 0x3e, 0xef, 0x52, 0xf4, 0x65, 0x97, 0xa2,
-	0xf7, 0x5f, 0xd3, 0x1b, 0xe5, 0x41, 0xfc, 0x31, 0x8a, 0x6f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xb9,
-	0x6f, 0x96, 0x58, 0xb1, 0x03, 0x00, 0x00,
+	// 831 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xdd, 0x6e, 0x1c, 0x35,
+	0x14, 0x66, 0xb2, 0xbb, 0xe9, 0xce, 0x09, 0x09, 0xe0, 0x26, 0x65, 0xd5, 0x9f, 0x55, 0x34, 0x82,
+	0x2a, 0x37, 0xec, 0xa0, 0xa4, 0x70, 0xc1, 0x5d, 0x56, 0x81, 0x12, 0xa4, 0x02, 0x72, 0x1f, 0x20,
+	0x72, 0xc6, 0x67, 0x52, 0xa3, 0x19, 0x7b, 0x6a, 0x7b, 0xa2, 0xec, 0x0d, 0xbc, 0x02, 0x12, 0x57,
+	0xbc, 0x04, 0xcf, 0xc1, 0x05, 0x17, 0xbc, 0x00, 0x12, 0x0a, 0x2f, 0xc0, 0x1b, 0x80, 0xc6, 0xf3,
+	0xbf, 0x99, 0x56, 0xaa, 0xda, 0x5e, 0x9e, 0xcf, 0xc7, 0xdf, 0xf9, 0xce, 0xe7, 0x63, 0xcf, 0xc0,
+	0xf2, 0x42, 0xd8, 0x67, 0xf9, 0xf9, 0x22, 0x52, 0x69, 0x98, 0x1e, 0xf1, 0xf3, 0x30, 0x3d, 0x0a,
+	0x8d, 0x8e, 0xc2, 0xe7, 0x39, 0xea, 0x55, 0x78, 0x81, 0x12, 0x35, 0xb3, 0xc8, 0xc3, 0x4c, 0x2b,
+	0xab, 0x42, 0xc6, 0x53, 0x21, 0xc3, 0x2c, 0x61, 0x11, 0xa6, 0x28, 0xed, 0xc2, 0xa1, 0x64, 0xe2,
+	0xe0, 0xbb, 0xdf, 0xbc, 0x80, 0x2a, 0x4a, 0x72, 0x63, 0x51, 0xdf, 0x20, 0x6b, 0x68, 0xb2, 0xf3,
+	0x75, 0xca, 0xe0, 0x57, 0x0f, 0x76, 0xbf, 0xaf, 0xb1, 0x53, 0x29, 0x2c, 0xc5, 0xe7, 0x39, 0x1a,
+	0x4b, 0x8e, 0xc0, 0x17, 0xd2, 0x58, 0x26, 0x23, 0x34, 0x33, 0x6f, 0x7f, 0x74, 0xb0, 0x75, 0xb8,
+	0xb7, 0xe8, 0x30, 0x2d, 0x4e, 0xab, 0x55, 0xda, 0xe6, 0x91, 0x07, 0x00, 0x32, 0x4f, 0xcf, 0xcc,
+	0x33, 0xa6, 0xb9, 0x99, 0x6d, 0xec, 0x7b, 0x07, 0x13, 0xea, 0xcb, 0x3c, 0x7d, 0xea, 0x00, 0xf2,
+	0x09, 0x10, 0x8d, 0x59, 0x22, 0x22, 0x66, 0x85, 0x92, 0x67, 0x31, 0x8b, 0xac, 0xd2, 0xb3, 0x91,
+	0x4b, 0xfb, 0xa0, 0xb3, 0xf2, 0x95, 0x5b, 0x08, 0xe2, 0x8e, 0xb4, 0xc7, 0x68, 0x29, 0x9a, 0x4c,
+	0x49, 0x83, 0xe4, 0x11, 0xf8, 0x8d, 0x90, 0x99, 0xb7, 0xef, 0x1d, 0x6c, 0x1d, 0xde, 0xe9, 0x49,
+	0x6b, 0x76, 0xd1, 0x36, 0x91, 0xcc, 0xe0, 0xd6, 0x25, 0x6a, 0x23, 0x94, 0xac, 0x84, 0xd5, 0x61,
+	0x70, 0x05, 0xb7, 0x9b, 0x1d, 0xc7, 0x9c, 0xbf, 0x96, 0x03, 0xbb, 0x30, 0x89, 0x95, 0x8e, 0xd0,
+	0xd5, 0x98, 0xd2, 0x32, 0x20, 0x77, 0x60, 0x93, 0xeb, 0x15, 0xcd, 0xa5, 0x6b, 0x76, 0x4a, 0xab,
+	0x28, 0xf8, 0xcd, 0x83, 0x0f, 0x5b, 0xb1, 0xe8, 0xc8, 0xeb, 0xf2, 0x0b, 0x20, 0x09, 0xb2, 0x4b,
+	0x21, 0x2f, 0xea, 0x3a, 0xa7, 0x27, 0xa5, 0x0e, 0x9f, 0x0e, 0xac, 0x90, 0xcf, 0x00, 0x22, 0x26,
+	0xb9, 0xe0, 0xcc, 0x62, 0xe1, 0xfd, 0x4b, 0xf4, 0x76, 0x12, 0x5b, 0xc1, 0xa3, 0x61, 0xc1, 0xe3,
+	0x9e, 0xe0, 0x9f, 0x3a, 0x56, 0x3d, 0xc5, 0x66, 0x58, 0xde, 0xf0, 0x89, 0x14, 0x2b, 0x91, 0x92,
+	0xb1, 0xd0, 0x69, 0x25, 0xab, 0x0e, 0x83, 0x1f, 0x61, 0xb7, 0x2f, 0xe0, 0xed, 0xcc, 0xc4, 0x0b,
+	0x4f, 0xec, 0x09, 0x3c, 0x68, 0x99, 0x30, 0x55, 0x97, 0x48, 0xcb, 0xb9, 0xad, 0xad, 0x68, 0xfc,
+	0xf4, 0x86, 0xfd, 0xdc, 0xe8, 0xd1, 0xfd, 0x00, 0xf7, 0xda, 0x76, 0xb2, 0x44, 0xd8, 0xf2, 0xa6,
+	0xd4, 0x64, 0xfd, 0xfb, 0xe4, 0xad, 0xdf, 0xa7, 0x57, 0x1b, 0x36, 0xde, 0x99, 0xb5, 0x25, 0x4b,
+	0xdc, 0x24, 0x54, 0x75, 0xee, 0x81, 0x9f, 0xb2, 0xab, 0xb3, 0xa2, 0x9d, 0xba, 0xcc, 0x34, 0x65,
+	0x57, 0x4f, 0x8a, 0xf8, 0x15, 0xab, 0xfc, 0xd2, 0x1d, 0xe9, 0x13, 0x87, 0xbd, 0xb5, 0x43, 0x3a,
+	0x80, 0x31, 0x17, 0x71, 0xec, 0x14, 0x6c, 0x1d, 0xee, 0x2e, 0xdc, 0xf3, 0xd8, 0x92, 0x9c, 0x88,
+	0x38, 0xa6, 0x2e, 0x23, 0xf8, 0xd7, 0x83, 0xed, 0x1e, 0x4e, 0xf6, 0x61, 0xab, 0xb4, 0xb5, 0x68,
+	0x92, 0x57, 0x4d, 0x77, 0x21, 0xf2, 0x29, 0xdc, 0x46, 0x63, 0x45, 0xca, 0x2c, 0xf2, 0xe5, 0xca,
+	0x62, 0x95, 0x59, 0x68, 0x18, 0xd1, 0xa1, 0x25, 0xf2, 0x45, 0xf7, 0xc5, 0x18, 0xb9, 0x1b, 0x78,
+	0x7f, 0x5d, 0x54, 0x7d, 0x09, 0x9d, 0xb8, 0x36, 0x9d, 0x7c, 0x07, 0xef, 0x09, 0xa3, 0x12, 0xf7,
+	0xfe, 0x3d, 0xd6, 0x2a, 0xcf, 0xcc, 0x6c, 0xec, 0x18, 0x3e, 0xbe, 0xc1, 0xd0, 0x4b, 0xab, 0x4f,
+	0x72, 0x7d, 0x77, 0xf0, 0x9f, 0x07, 0x7b, 0x83, 0x55, 0xc9, 0x0e, 0x6c, 0x88, 0xb2, 0x63, 0x9f,
+	0x6e, 0x08, 0x4e, 0x1e, 0xc2, 0x4e, 0x7f, 0xb3, 0xeb, 0xd1, 0xa7, 0x6b, 0x68, 0x6b, 0xd9, 0x31,
+	0xe7, 0xc8, 0x5d, 0x83, 0xdb, 0xb4, 0x0b, 0x91, 0x8f, 0x60, 0xdb, 0x54, 0x03, 0x9c, 0x3a, 0xb3,
+	0xc6, 0x2e, 0xa7, 0x0f, 0xde, 0x34, 0xb6, 0xe4, 0x9b, 0x0c, 0x19, 0x5b, 0xf2, 0x3e, 0x82, 0xbd,
+	0x3e, 0x5c, 0xf3, 0x6f, 0xba, 0x3d, 0xc3, 0x8b, 0xc1, 0x1f, 0x1e, 0xcc, 0x5f, 0xee, 0xda, 0x40,
+	0xeb, 0xde, 0x60, 0xeb, 0x01, 0xbc, 0x5b, 0xf6, 0xb0, 0xc4, 0x58, 0x69, 0xac, 0x06, 0xb1, 0x87,
+	0x75, 0xec, 0x89, 0x2d, 0xd6, 0x9f, 0xb5, 0x2e, 0x44, 0xe6, 0x00, 0x89, 0x62, 0xbc, 0xe2, 0x28,
+	0x5e, 0x56, 0x8f, 0x76, 0x10, 0x72, 0x1f, 0xfc, 0x22, 0x2a, 0xf7, 0x4f, 0xdc, 0x72, 0x0b, 0x04,
+	0x14, 0x66, 0x4d, 0x37, 0x5f, 0x0b, 0x63, 0x95, 0x5e, 0x35, 0x37, 0xeb, 0x73, 0xb8, 0x85, 0xd2,
+	0x6a, 0xd1, 0x7c, 0xa9, 0x6e, 0xcc, 0x5d, 0xb5, 0xe3, 0x4b, 0x69, 0xf5, 0x8a, 0xd6, 0xc9, 0xc1,
+	0x5f, 0xdd, 0x21, 0xe9, 0xa6, 0xbc, 0xf1, 0xbb, 0xfa, 0x10, 0x76, 0xf2, 0x8c, 0x17, 0x67, 0x74,
+	0x6c, 0xbf, 0x65, 0x52, 0x19, 0x67, 0xd0, 0x88, 0xae, 0xa1, 0xe4, 0x2e, 0x4c, 0x55, 0x86, 0x9a,
+	0x15, 0x7f, 0x06, 0x63, 0x77, 0x16, 0x4d, 0x5c, 0xbc, 0x39, 0x1a, 0x99, 0x51, 0xd2, 0x99, 0xe3,
+	0xd3, 0x2a, 0x2a, 0xaa, 0x72, 0x4c, 0xd0, 0x56, 0x03, 0x31, 0xa5, 0x75, 0xb8, 0x7c, 0xff, 0xf7,
+	0xeb, 0xb9, 0xf7, 0xe7, 0xf5, 0xdc, 0xfb, 0xfb, 0x7a, 0xee, 0xfd, 0xfc, 0xcf, 0xfc, 0x9d, 0xf3,
+	0x4d, 0xf7, 0xdf, 0x73, 0xf4, 0xff, 0x00, 0x5c, 0x1c, 0x68, 0x28, 0x90, 0x09, 0x00, 0x00,
 }
@@ -43,6 +43,7 @@
 		Label
 		Labels
 		LabelMatcher
+		MetricMetadata
 */
 package prompb
 
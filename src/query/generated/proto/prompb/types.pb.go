@@ -64,6 +64,45 @@ func (x LabelMatcher_Type) String() string {
 }
 func (LabelMatcher_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptorTypes, []int{4, 0} }
 
+type MetricMetadata_MetricType int32
+
+const (
+	MetricMetadata_UNKNOWN        MetricMetadata_MetricType = 0
+	MetricMetadata_COUNTER        MetricMetadata_MetricType = 1
+	MetricMetadata_GAUGE          MetricMetadata_MetricType = 2
+	MetricMetadata_HISTOGRAM      MetricMetadata_MetricType = 3
+	MetricMetadata_GAUGEHISTOGRAM MetricMetadata_MetricType = 4
+	MetricMetadata_SUMMARY        MetricMetadata_MetricType = 5
+	MetricMetadata_INFO           MetricMetadata_MetricType = 6
+	MetricMetadata_STATESET       MetricMetadata_MetricType = 7
+)
+
+var MetricMetadata_MetricType_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "COUNTER",
+	2: "GAUGE",
+	3: "HISTOGRAM",
+	4: "GAUGEHISTOGRAM",
+	5: "SUMMARY",
+	6: "INFO",
+	7: "STATESET",
+}
+var MetricMetadata_MetricType_value = map[string]int32{
+	"UNKNOWN":        0,
+	"COUNTER":        1,
+	"GAUGE":          2,
+	"HISTOGRAM":      3,
+	"GAUGEHISTOGRAM": 4,
+	"SUMMARY":        5,
+	"INFO":           6,
+	"STATESET":       7,
+}
+
+func (x MetricMetadata_MetricType) String() string {
+	return proto.EnumName(MetricMetadata_MetricType_name, int32(x))
+}
+func (MetricMetadata_MetricType) EnumDescriptor() ([]byte, []int) { return fileDescriptorTypes, []int{5, 0} }
+
 type Sample struct {
 	Value     float64 `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
 	Timestamp int64   `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
@@ -185,13 +224,57 @@ func (m *LabelMatcher) GetValue() []byte {
 	return nil
 }
 
+// MetricMetadata is the metadata of a metric family, sent alongside series
+// by Prometheus remote write.
+type MetricMetadata struct {
+	Type             MetricMetadata_MetricType `protobuf:"varint,1,opt,name=type,proto3,enum=m3prometheus.MetricMetadata_MetricType" json:"type,omitempty"`
+	MetricFamilyName string                    `protobuf:"bytes,2,opt,name=metric_family_name,json=metricFamilyName,proto3" json:"metric_family_name,omitempty"`
+	Help             string                    `protobuf:"bytes,4,opt,name=help,proto3" json:"help,omitempty"`
+	Unit             string                    `protobuf:"bytes,5,opt,name=unit,proto3" json:"unit,omitempty"`
+}
+
+func (m *MetricMetadata) Reset()                    { *m = MetricMetadata{} }
+func (m *MetricMetadata) String() string            { return proto.CompactTextString(m) }
+func (*MetricMetadata) ProtoMessage()               {}
+func (*MetricMetadata) Descriptor() ([]byte, []int) { return fileDescriptorTypes, []int{5} }
+
+func (m *MetricMetadata) GetType() MetricMetadata_MetricType {
+	if m != nil {
+		return m.Type
+	}
+	return MetricMetadata_UNKNOWN
+}
+
+func (m *MetricMetadata) GetMetricFamilyName() string {
+	if m != nil {
+		return m.MetricFamilyName
+	}
+	return ""
+}
+
+func (m *MetricMetadata) GetHelp() string {
+	if m != nil {
+		return m.Help
+	}
+	return ""
+}
+
+func (m *MetricMetadata) GetUnit() string {
+	if m != nil {
+		return m.Unit
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Sample)(nil), "m3prometheus.Sample")
 	proto.RegisterType((*TimeSeries)(nil), "m3prometheus.TimeSeries")
 	proto.RegisterType((*Label)(nil), "m3prometheus.Label")
 	proto.RegisterType((*Labels)(nil), "m3prometheus.Labels")
 	proto.RegisterType((*LabelMatcher)(nil), "m3prometheus.LabelMatcher")
+	proto.RegisterType((*MetricMetadata)(nil), "m3prometheus.MetricMetadata")
 	proto.RegisterEnum("m3prometheus.LabelMatcher_Type", LabelMatcher_Type_name, LabelMatcher_Type_value)
+	proto.RegisterEnum("m3prometheus.MetricMetadata_MetricType", MetricMetadata_MetricType_name, MetricMetadata_MetricType_value)
 }
 func (m *Sample) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
@@ -359,6 +442,47 @@ func (m *LabelMatcher) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *MetricMetadata) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MetricMetadata) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Type != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Type))
+	}
+	if len(m.MetricFamilyName) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.MetricFamilyName)))
+		i += copy(dAtA[i:], m.MetricFamilyName)
+	}
+	if len(m.Help) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Help)))
+		i += copy(dAtA[i:], m.Help)
+	}
+	if len(m.Unit) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Unit)))
+		i += copy(dAtA[i:], m.Unit)
+	}
+	return i, nil
+}
+
 func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -441,6 +565,27 @@ func (m *LabelMatcher) Size() (n int) {
 	return n
 }
 
+func (m *MetricMetadata) Size() (n int) {
+	var l int
+	_ = l
+	if m.Type != 0 {
+		n += 1 + sovTypes(uint64(m.Type))
+	}
+	l = len(m.MetricFamilyName)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Help)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Unit)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
 func sovTypes(x uint64) (n int) {
 	for {
 		n++
@@ -970,6 +1115,162 @@ func (m *LabelMatcher) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *MetricMetadata) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MetricMetadata: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MetricMetadata: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= (MetricMetadata_MetricType(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MetricFamilyName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MetricFamilyName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Help", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Help = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Unit", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Unit = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipTypes(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -1080,29 +1381,38 @@ func init() {
 }
 
 var fileDescriptorTypes = []byte{
-	// 369 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x52, 0xc1, 0x6a, 0xdb, 0x40,
-	0x10, 0xf5, 0x4a, 0xb2, 0x4c, 0xa7, 0xa6, 0x88, 0xad, 0x0f, 0xa2, 0x14, 0xd9, 0xe8, 0xa4, 0x4b,
-	0x25, 0x6c, 0xf5, 0xd6, 0x42, 0xc1, 0xa0, 0x9b, 0x1b, 0xb0, 0xec, 0x53, 0x6e, 0x92, 0x3d, 0x91,
-	0x05, 0x5a, 0x4b, 0xd1, 0xae, 0x02, 0xfe, 0x8b, 0xdc, 0xf2, 0x4b, 0x3e, 0xe6, 0x0b, 0x42, 0x70,
-	0x7e, 0x24, 0x68, 0xd7, 0x89, 0x1d, 0xf0, 0x25, 0x97, 0x65, 0xe6, 0xed, 0x7b, 0x6f, 0xde, 0x32,
-	0x0b, 0xff, 0xb2, 0x5c, 0x6c, 0x9a, 0xd4, 0x5f, 0x95, 0x2c, 0x60, 0xe1, 0x3a, 0x0d, 0x58, 0x18,
-	0xf0, 0x7a, 0x15, 0xdc, 0x36, 0x58, 0xef, 0x82, 0x0c, 0xb7, 0x58, 0x27, 0x02, 0xd7, 0x41, 0x55,
-	0x97, 0xa2, 0x6c, 0x4f, 0x56, 0xa5, 0x81, 0xd8, 0x55, 0xc8, 0x7d, 0x09, 0xd1, 0x3e, 0x0b, 0x5b,
-	0x14, 0xc5, 0x06, 0x1b, 0xfe, 0xe3, 0xd7, 0x99, 0x5d, 0x56, 0x66, 0xa5, 0xd2, 0xa5, 0xcd, 0x8d,
-	0xec, 0x94, 0x49, 0x5b, 0x29, 0xb1, 0xfb, 0x17, 0xcc, 0x45, 0xc2, 0xaa, 0x02, 0xe9, 0x00, 0xba,
-	0x77, 0x49, 0xd1, 0xa0, 0x4d, 0x46, 0xc4, 0x23, 0xb1, 0x6a, 0xe8, 0x4f, 0xf8, 0x22, 0x72, 0x86,
-	0x5c, 0x24, 0xac, 0xb2, 0xb5, 0x11, 0xf1, 0xf4, 0xf8, 0x04, 0xb8, 0x0d, 0xc0, 0x32, 0x67, 0xb8,
-	0xc0, 0x3a, 0x47, 0x4e, 0xc7, 0x60, 0x16, 0x49, 0x8a, 0x05, 0xb7, 0xc9, 0x48, 0xf7, 0xbe, 0x4e,
-	0xbe, 0xfb, 0xe7, 0xc9, 0xfc, 0x59, 0x7b, 0x37, 0x35, 0xf6, 0x4f, 0xc3, 0x4e, 0x7c, 0x24, 0xd2,
-	0xdf, 0xd0, 0xe3, 0x72, 0x3c, 0xb7, 0x35, 0xa9, 0x19, 0x7c, 0xd4, 0xa8, 0x6c, 0x47, 0xd1, 0x1b,
-	0xd5, 0x1d, 0x43, 0x57, 0x9a, 0x51, 0x0a, 0xc6, 0x36, 0x61, 0x2a, 0x72, 0x3f, 0x96, 0xf5, 0xe9,
-	0x1d, 0x9a, 0x04, 0x55, 0xe3, 0xfe, 0x01, 0x73, 0xa6, 0x46, 0x7e, 0x3e, 0xa5, 0xfb, 0x40, 0xa0,
-	0x2f, 0xf1, 0xff, 0x89, 0x58, 0x6d, 0xb0, 0xa6, 0x21, 0x18, 0xed, 0x06, 0xe4, 0xdc, 0x6f, 0x93,
-	0xe1, 0x05, 0x87, 0x23, 0xd3, 0x5f, 0xee, 0x2a, 0x8c, 0x25, 0xf9, 0x3d, 0xac, 0x76, 0x29, 0xac,
-	0x7e, 0x1e, 0xd6, 0x03, 0xa3, 0xd5, 0x51, 0x13, 0xb4, 0x68, 0x6e, 0x75, 0x68, 0x0f, 0xf4, 0xab,
-	0x68, 0x6e, 0x91, 0x16, 0x88, 0x23, 0x4b, 0x93, 0x40, 0x1c, 0x59, 0xfa, 0xd4, 0xde, 0x1f, 0x1c,
-	0xf2, 0x78, 0x70, 0xc8, 0xf3, 0xc1, 0x21, 0xf7, 0x2f, 0x4e, 0xe7, 0xda, 0x54, 0x3f, 0x24, 0x35,
-	0xe5, 0x7e, 0xc3, 0xd7, 0x00, 0x00, 0x00, 0xff, 0xff, 0x74, 0x54, 0xd9, 0x83, 0x5f, 0x02, 0x00,
-	0x00,
+	// 523 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x93, 0xcf, 0x6e, 0xd3, 0x40,
+	0x10, 0xc6, 0xe3, 0x3f, 0x71, 0x9a, 0x69, 0xa8, 0xac, 0xa5, 0x07, 0x0b, 0xa1, 0x34, 0xf2, 0x85,
+	0x1c, 0x20, 0x56, 0x1b, 0x6e, 0x45, 0x42, 0x29, 0x72, 0x43, 0x45, 0xed, 0xa8, 0x6b, 0x47, 0x08,
+	0x2e, 0xd5, 0x3a, 0xd9, 0x26, 0x96, 0xbc, 0x89, 0xb1, 0xd7, 0x48, 0x79, 0x0b, 0x6e, 0xdc, 0x78,
+	0x9e, 0x1e, 0x79, 0x02, 0x84, 0xc2, 0x8b, 0xa0, 0xdd, 0x0d, 0x4d, 0x22, 0xf5, 0xc2, 0xc5, 0x9a,
+	0xf9, 0x66, 0xbe, 0x99, 0xdf, 0xca, 0x1a, 0x78, 0x3b, 0x4b, 0xf9, 0xbc, 0x4a, 0x7a, 0x93, 0x25,
+	0xf3, 0x58, 0x7f, 0x9a, 0x78, 0xac, 0xef, 0x95, 0xc5, 0xc4, 0xfb, 0x52, 0xd1, 0x62, 0xe5, 0xcd,
+	0xe8, 0x82, 0x16, 0x84, 0xd3, 0xa9, 0x97, 0x17, 0x4b, 0xbe, 0x14, 0x5f, 0x96, 0x27, 0x1e, 0x5f,
+	0xe5, 0xb4, 0xec, 0x49, 0x09, 0xb5, 0x58, 0x5f, 0xa8, 0x94, 0xcf, 0x69, 0x55, 0x3e, 0x7b, 0xb5,
+	0x33, 0x6e, 0xb6, 0x9c, 0x2d, 0x95, 0x2f, 0xa9, 0xee, 0x64, 0xa6, 0x86, 0x88, 0x48, 0x99, 0xdd,
+	0x37, 0x60, 0x45, 0x84, 0xe5, 0x19, 0x45, 0xc7, 0x50, 0xff, 0x4a, 0xb2, 0x8a, 0x3a, 0x5a, 0x47,
+	0xeb, 0x6a, 0x58, 0x25, 0xe8, 0x39, 0x34, 0x79, 0xca, 0x68, 0xc9, 0x09, 0xcb, 0x1d, 0xbd, 0xa3,
+	0x75, 0x0d, 0xbc, 0x15, 0xdc, 0x0a, 0x20, 0x4e, 0x19, 0x8d, 0x68, 0x91, 0xd2, 0x12, 0x9d, 0x82,
+	0x95, 0x91, 0x84, 0x66, 0xa5, 0xa3, 0x75, 0x8c, 0xee, 0xe1, 0xd9, 0xd3, 0xde, 0x2e, 0x59, 0xef,
+	0x5a, 0xd4, 0x2e, 0xcc, 0xfb, 0x5f, 0x27, 0x35, 0xbc, 0x69, 0x44, 0xaf, 0xa1, 0x51, 0xca, 0xf5,
+	0xa5, 0xa3, 0x4b, 0xcf, 0xf1, 0xbe, 0x47, 0xb1, 0x6d, 0x4c, 0xff, 0x5a, 0xdd, 0x53, 0xa8, 0xcb,
+	0x61, 0x08, 0x81, 0xb9, 0x20, 0x4c, 0x21, 0xb7, 0xb0, 0x8c, 0xb7, 0xef, 0xd0, 0xa5, 0xa8, 0x12,
+	0xf7, 0x1c, 0xac, 0x6b, 0xb5, 0xf2, 0xff, 0x29, 0xdd, 0xef, 0x1a, 0xb4, 0xa4, 0x1e, 0x10, 0x3e,
+	0x99, 0xd3, 0x02, 0xf5, 0xc1, 0x14, 0x7f, 0x40, 0xee, 0x3d, 0x3a, 0x3b, 0x79, 0x64, 0xc2, 0xa6,
+	0xb3, 0x17, 0xaf, 0x72, 0x8a, 0x65, 0xf3, 0x03, 0xac, 0xfe, 0x18, 0xac, 0xb1, 0x0b, 0xdb, 0x05,
+	0x53, 0xf8, 0x90, 0x05, 0xba, 0x7f, 0x63, 0xd7, 0x50, 0x03, 0x8c, 0xd0, 0xbf, 0xb1, 0x35, 0x21,
+	0x60, 0xdf, 0xd6, 0xa5, 0x80, 0x7d, 0xdb, 0x70, 0x7f, 0xe8, 0x70, 0x14, 0x50, 0x5e, 0xa4, 0x93,
+	0x80, 0x72, 0x32, 0x25, 0x9c, 0xa0, 0xf3, 0x3d, 0xb6, 0x17, 0xfb, 0x6c, 0xfb, 0xbd, 0x9b, 0x74,
+	0x87, 0xf1, 0x25, 0x20, 0x26, 0xb5, 0xdb, 0x3b, 0xc2, 0xd2, 0x6c, 0x75, 0xfb, 0x40, 0xdc, 0xc4,
+	0xb6, 0xaa, 0x5c, 0xca, 0x42, 0x28, 0xe8, 0x11, 0x98, 0x73, 0x9a, 0xe5, 0x8e, 0x29, 0xeb, 0x32,
+	0x16, 0x5a, 0xb5, 0x48, 0xb9, 0x53, 0x57, 0x9a, 0x88, 0xdd, 0x15, 0xc0, 0x76, 0x13, 0x3a, 0x84,
+	0xc6, 0x38, 0xfc, 0x10, 0x8e, 0x3e, 0x86, 0x76, 0x4d, 0x24, 0xef, 0x46, 0xe3, 0x30, 0xf6, 0xb1,
+	0xad, 0xa1, 0x26, 0xd4, 0x87, 0x83, 0xf1, 0x50, 0xbc, 0xf0, 0x09, 0x34, 0xdf, 0x5f, 0x45, 0xf1,
+	0x68, 0x88, 0x07, 0x81, 0x6d, 0x20, 0x04, 0x47, 0xb2, 0xb2, 0xd5, 0x4c, 0x61, 0x8d, 0xc6, 0x41,
+	0x30, 0xc0, 0x9f, 0xec, 0x3a, 0x3a, 0x00, 0xf3, 0x2a, 0xbc, 0x1c, 0xd9, 0x16, 0x6a, 0xc1, 0x41,
+	0x14, 0x0f, 0x62, 0x3f, 0xf2, 0x63, 0xbb, 0x71, 0xe1, 0xdc, 0xaf, 0xdb, 0xda, 0xcf, 0x75, 0x5b,
+	0xfb, 0xbd, 0x6e, 0x6b, 0xdf, 0xfe, 0xb4, 0x6b, 0x9f, 0x2d, 0x75, 0x42, 0x89, 0x25, 0x0f, 0xa0,
+	0xff, 0x77, 0x00, 0x74, 0x20, 0x9e, 0xc8, 0x80, 0x03, 0x00, 0x00,
 }
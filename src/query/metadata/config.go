@@ -0,0 +1,69 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package metadata
+
+import (
+	"time"
+
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+// Configuration is the configuration for storing metric metadata received
+// via remote write.
+type Configuration struct {
+	// Key is the cluster KV key prefix metadata is persisted under.
+	Key string `yaml:"key"`
+
+	// NumShards is the number of KV keys metric families are sharded across,
+	// it must be the same for all coordinators sharing the key.
+	NumShards *int `yaml:"numShards"`
+
+	// TTL is how long metadata is retained after it was last written.
+	TTL *time.Duration `yaml:"ttl"`
+
+	// FlushInterval is the interval metadata is persisted at.
+	FlushInterval *time.Duration `yaml:"flushInterval"`
+}
+
+// NewStore creates a new metadata store from the configuration, if storeFn
+// is nil metadata is only held in memory.
+func (c Configuration) NewStore(
+	storeFn KVStoreFn,
+	instrumentOpts instrument.Options,
+) (Store, error) {
+	opts := NewOptions().
+		SetInstrumentOptions(instrumentOpts).
+		SetKVStoreFn(storeFn)
+	if c.Key != "" {
+		opts = opts.SetKey(c.Key)
+	}
+	if c.NumShards != nil {
+		opts = opts.SetNumShards(*c.NumShards)
+	}
+	if c.TTL != nil {
+		opts = opts.SetTTL(*c.TTL)
+	}
+	if c.FlushInterval != nil {
+		opts = opts.SetFlushInterval(*c.FlushInterval)
+	}
+
+	return NewStore(opts)
+}
@@ -0,0 +1,205 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package metadata
+
+import (
+	"errors"
+	"time"
+
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+const (
+	// DefaultKey is the default KV key prefix metadata is persisted under.
+	DefaultKey = "_m3query.metadata"
+
+	defaultNumShards     = 32
+	defaultTTL           = 24 * time.Hour
+	defaultFlushInterval = time.Minute
+)
+
+var (
+	errNoKey                = errors.New("no key set")
+	errInvalidNumShards     = errors.New("number of shards must be positive")
+	errInvalidTTL           = errors.New("ttl must be positive")
+	errInvalidFlushInterval = errors.New("flush interval must be positive")
+)
+
+// Options are the options for the metadata store.
+type Options interface {
+	// Validate validates the options.
+	Validate() error
+
+	// SetInstrumentOptions sets the instrument options.
+	SetInstrumentOptions(value instrument.Options) Options
+
+	// InstrumentOptions returns the instrument options.
+	InstrumentOptions() instrument.Options
+
+	// SetNowFn sets the now function.
+	SetNowFn(value clock.NowFn) Options
+
+	// NowFn returns the now function.
+	NowFn() clock.NowFn
+
+	// SetKVStoreFn sets the function returning the KV store metadata is
+	// persisted to, if nil metadata is only held in memory.
+	SetKVStoreFn(value KVStoreFn) Options
+
+	// KVStoreFn returns the function returning the KV store.
+	KVStoreFn() KVStoreFn
+
+	// SetKey sets the KV key prefix metadata is persisted under, each shard
+	// is persisted to its own key with the prefix.
+	SetKey(value string) Options
+
+	// Key returns the KV key prefix metadata is persisted under.
+	Key() string
+
+	// SetNumShards sets the number of KV keys metric families are sharded
+	// across, it must be the same for all coordinators sharing the key.
+	SetNumShards(value int) Options
+
+	// NumShards returns the number of KV keys metric families are sharded
+	// across.
+	NumShards() int
+
+	// SetTTL sets how long metadata is retained after it was last written.
+	SetTTL(value time.Duration) Options
+
+	// TTL returns how long metadata is retained after it was last written.
+	TTL() time.Duration
+
+	// SetFlushInterval sets the interval metadata is persisted at.
+	SetFlushInterval(value time.Duration) Options
+
+	// FlushInterval returns the interval metadata is persisted at.
+	FlushInterval() time.Duration
+}
+
+type options struct {
+	instrumentOpts instrument.Options
+	nowFn          clock.NowFn
+	storeFn        KVStoreFn
+	key            string
+	numShards      int
+	ttl            time.Duration
+	flushInterval  time.Duration
+}
+
+// NewOptions creates a new set of metadata store options.
+func NewOptions() Options {
+	return &options{
+		instrumentOpts: instrument.NewOptions(),
+		nowFn:          time.Now,
+		key:            DefaultKey,
+		numShards:      defaultNumShards,
+		ttl:            defaultTTL,
+		flushInterval:  defaultFlushInterval,
+	}
+}
+
+func (o *options) Validate() error {
+	if o.key == "" {
+		return errNoKey
+	}
+	if o.numShards <= 0 {
+		return errInvalidNumShards
+	}
+	if o.ttl <= 0 {
+		return errInvalidTTL
+	}
+	if o.flushInterval <= 0 {
+		return errInvalidFlushInterval
+	}
+	return nil
+}
+
+func (o *options) SetInstrumentOptions(value instrument.Options) Options {
+	opts := *o
+	opts.instrumentOpts = value
+	return &opts
+}
+
+func (o *options) InstrumentOptions() instrument.Options {
+	return o.instrumentOpts
+}
+
+func (o *options) SetNowFn(value clock.NowFn) Options {
+	opts := *o
+	opts.nowFn = value
+	return &opts
+}
+
+func (o *options) NowFn() clock.NowFn {
+	return o.nowFn
+}
+
+func (o *options) SetKVStoreFn(value KVStoreFn) Options {
+	opts := *o
+	opts.storeFn = value
+	return &opts
+}
+
+func (o *options) KVStoreFn() KVStoreFn {
+	return o.storeFn
+}
+
+func (o *options) SetKey(value string) Options {
+	opts := *o
+	opts.key = value
+	return &opts
+}
+
+func (o *options) Key() string {
+	return o.key
+}
+
+func (o *options) SetNumShards(value int) Options {
+	opts := *o
+	opts.numShards = value
+	return &opts
+}
+
+func (o *options) NumShards() int {
+	return o.numShards
+}
+
+func (o *options) SetTTL(value time.Duration) Options {
+	opts := *o
+	opts.ttl = value
+	return &opts
+}
+
+func (o *options) TTL() time.Duration {
+	return o.ttl
+}
+
+func (o *options) SetFlushInterval(value time.Duration) Options {
+	opts := *o
+	opts.flushInterval = value
+	return &opts
+}
+
+func (o *options) FlushInterval() time.Duration {
+	return o.flushInterval
+}
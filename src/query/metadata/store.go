@@ -0,0 +1,442 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package metadata
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/query/generated/proto/metadatapb"
+	"github.com/m3db/m3/src/x/clock"
+	xerrors "github.com/m3db/m3/src/x/errors"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+)
+
+var (
+	errStoreAlreadyStarted = errors.New("metadata store already started")
+	errStoreNotStarted     = errors.New("metadata store not started")
+	errStoreClosed         = errors.New("metadata store closed")
+)
+
+type storeMetrics struct {
+	flushes     tally.Counter
+	flushErrors tally.Counter
+	expired     tally.Counter
+	entries     tally.Gauge
+}
+
+func newStoreMetrics(scope tally.Scope) storeMetrics {
+	return storeMetrics{
+		flushes:     scope.Counter("flushes"),
+		flushErrors: scope.Counter("flush-errors"),
+		expired:     scope.Counter("expired"),
+		entries:     scope.Gauge("entries"),
+	}
+}
+
+// entries are metadata entries by metric family name, each entry mapped to
+// the time it was last seen.
+type entries map[string]map[Metadata]time.Time
+
+type store struct {
+	sync.RWMutex
+
+	nowFn         clock.NowFn
+	storeFn       KVStoreFn
+	key           string
+	numShards     int
+	ttl           time.Duration
+	flushInterval time.Duration
+	logger        *zap.Logger
+	metrics       storeMetrics
+
+	entries entries
+	// dirty is whether entries of each shard have been written since the
+	// last flush.
+	dirty []bool
+
+	// flushLock serializes flushes so that merges with the persisted
+	// metadata are not interleaved.
+	flushLock sync.Mutex
+
+	started bool
+	closed  bool
+	closeCh chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewStore creates a new metadata store, metadata is held in memory and
+// merged with the metadata persisted to KV every flush interval so that
+// metadata written to any coordinator sharing the KV key is served. Metric
+// families are sharded by name across a fixed number of KV keys, keeping
+// each value small and only updating the shards written to.
+func NewStore(opts Options) (Store, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &store{
+		nowFn:         opts.NowFn(),
+		storeFn:       opts.KVStoreFn(),
+		key:           opts.Key(),
+		numShards:     opts.NumShards(),
+		ttl:           opts.TTL(),
+		flushInterval: opts.FlushInterval(),
+		logger:        opts.InstrumentOptions().Logger(),
+		metrics:       newStoreMetrics(opts.InstrumentOptions().MetricsScope()),
+		entries:       make(entries),
+		dirty:         make([]bool, opts.NumShards()),
+		closeCh:       make(chan struct{}),
+	}, nil
+}
+
+func (s *store) Start() error {
+	s.Lock()
+	defer s.Unlock()
+	if s.started {
+		return errStoreAlreadyStarted
+	}
+
+	s.started = true
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.run()
+	}()
+
+	return nil
+}
+
+func (s *store) run() {
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		// NB: flush immediately so persisted metadata is loaded on startup.
+		s.flushWithLogging()
+
+		select {
+		case <-ticker.C:
+		case <-s.closeCh:
+			return
+		}
+	}
+}
+
+func (s *store) flushWithLogging() {
+	if err := s.flush(); err != nil {
+		s.metrics.flushErrors.Inc(1)
+		s.logger.Warn("could not flush metric metadata", zap.Error(err))
+	}
+}
+
+func (s *store) Write(metric string, metadata Metadata) {
+	if metric == "" {
+		return
+	}
+
+	now := s.nowFn()
+	s.Lock()
+	byMetadata, ok := s.entries[metric]
+	if !ok {
+		byMetadata = make(map[Metadata]time.Time)
+		s.entries[metric] = byMetadata
+	}
+	byMetadata[metadata] = now
+	s.dirty[s.shardFor(metric)] = true
+	s.Unlock()
+}
+
+func (s *store) Query(metric string, limit int) map[string][]Metadata {
+	s.RLock()
+	defer s.RUnlock()
+
+	var names []string
+	if metric != "" {
+		if _, ok := s.entries[metric]; ok {
+			names = append(names, metric)
+		}
+	} else {
+		names = make([]string, 0, len(s.entries))
+		for name := range s.entries {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+
+	if limit > 0 && len(names) > limit {
+		names = names[:limit]
+	}
+
+	result := make(map[string][]Metadata, len(names))
+	for _, name := range names {
+		byMetadata := s.entries[name]
+		values := make([]Metadata, 0, len(byMetadata))
+		for metadata := range byMetadata {
+			values = append(values, metadata)
+		}
+		sort.Slice(values, func(i, j int) bool {
+			return values[i].less(values[j])
+		})
+		result[name] = values
+	}
+
+	return result
+}
+
+// flush expires metadata that has not been seen within the TTL and, if
+// persisting, merges the in memory metadata with the persisted metadata.
+func (s *store) flush() error {
+	s.flushLock.Lock()
+	defer s.flushLock.Unlock()
+
+	s.metrics.flushes.Inc(1)
+	expireBefore := s.nowFn().Add(-s.ttl)
+
+	s.Lock()
+	s.metrics.expired.Inc(int64(s.entries.expire(expireBefore)))
+	s.metrics.entries.Update(float64(len(s.entries)))
+	dirty := s.dirty
+	s.dirty = make([]bool, s.numShards)
+	if s.storeFn == nil {
+		s.Unlock()
+		return nil
+	}
+	shards := s.entries.split(s.numShards, s.shardFor)
+	s.Unlock()
+
+	kvStore, err := s.storeFn()
+	if err != nil {
+		s.markDirty(dirty)
+		return err
+	}
+
+	var (
+		multiErr = xerrors.NewMultiError()
+		failed   = make([]bool, s.numShards)
+	)
+	for shard, merged := range shards {
+		err := s.mergeShard(kvStore, shard, merged, expireBefore, dirty[shard])
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			failed[shard] = dirty[shard]
+		}
+	}
+
+	// Make sure shards that failed to persist are persisted by the next flush.
+	s.markDirty(failed)
+	return multiErr.FinalError()
+}
+
+func (s *store) markDirty(dirty []bool) {
+	s.Lock()
+	for shard, isDirty := range dirty {
+		if isDirty {
+			s.dirty[shard] = true
+		}
+	}
+	s.Unlock()
+}
+
+func (s *store) mergeShard(
+	kvStore kv.Store,
+	shard int,
+	merged entries,
+	expireBefore time.Time,
+	persist bool,
+) error {
+	key := s.shardKey(shard)
+	version := 0
+	value, err := kvStore.Get(key)
+	switch err {
+	case nil:
+		version = value.Version()
+		persisted, err := unmarshalEntries(value)
+		if err != nil {
+			return err
+		}
+
+		merged.mergeFrom(persisted)
+		merged.expire(expireBefore)
+
+		s.Lock()
+		s.entries.mergeFrom(merged)
+		s.metrics.entries.Update(float64(len(s.entries)))
+		s.Unlock()
+	case kv.ErrNotFound:
+	default:
+		return err
+	}
+
+	if !persist {
+		return nil
+	}
+
+	msg := merged.toProto()
+	if version == 0 {
+		_, err = kvStore.SetIfNotExists(key, msg)
+	} else {
+		_, err = kvStore.CheckAndSet(key, version, msg)
+	}
+	return err
+}
+
+func (s *store) shardFor(metric string) int {
+	h := fnv.New32a()
+	h.Write([]byte(metric))
+	return int(h.Sum32() % uint32(s.numShards))
+}
+
+func (s *store) shardKey(shard int) string {
+	return fmt.Sprintf("%s/%d", s.key, shard)
+}
+
+func (s *store) Close() error {
+	s.Lock()
+	if !s.started {
+		s.Unlock()
+		return errStoreNotStarted
+	}
+	if s.closed {
+		s.Unlock()
+		return errStoreClosed
+	}
+	s.closed = true
+	close(s.closeCh)
+	s.Unlock()
+
+	s.wg.Wait()
+
+	// Persist anything written since the last flush.
+	s.flushWithLogging()
+	return nil
+}
+
+func (e entries) expire(before time.Time) int {
+	expired := 0
+	for name, byMetadata := range e {
+		for metadata, lastSeen := range byMetadata {
+			if lastSeen.Before(before) {
+				delete(byMetadata, metadata)
+				expired++
+			}
+		}
+		if len(byMetadata) == 0 {
+			delete(e, name)
+		}
+	}
+	return expired
+}
+
+// mergeFrom merges other into the entries, keeping the latest last seen
+// time of entries present in both.
+func (e entries) mergeFrom(other entries) {
+	for name, otherByMetadata := range other {
+		byMetadata, ok := e[name]
+		if !ok {
+			byMetadata = make(map[Metadata]time.Time, len(otherByMetadata))
+			e[name] = byMetadata
+		}
+		for metadata, lastSeen := range otherByMetadata {
+			if existing, ok := byMetadata[metadata]; !ok || lastSeen.After(existing) {
+				byMetadata[metadata] = lastSeen
+			}
+		}
+	}
+}
+
+// split returns copies of the entries split into shards.
+func (e entries) split(numShards int, shardFn func(string) int) []entries {
+	shards := make([]entries, numShards)
+	for i := range shards {
+		shards[i] = make(entries)
+	}
+	for name, byMetadata := range e {
+		clonedByMetadata := make(map[Metadata]time.Time, len(byMetadata))
+		for metadata, lastSeen := range byMetadata {
+			clonedByMetadata[metadata] = lastSeen
+		}
+		shards[shardFn(name)][name] = clonedByMetadata
+	}
+	return shards
+}
+
+func (e entries) toProto() *metadatapb.MetricMetadataShard {
+	names := make([]string, 0, len(e))
+	for name := range e {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := &metadatapb.MetricMetadataShard{
+		Families: make([]*metadatapb.MetricFamilyMetadata, 0, len(names)),
+	}
+	for _, name := range names {
+		byMetadata := e[name]
+		values := make([]Metadata, 0, len(byMetadata))
+		for metadata := range byMetadata {
+			values = append(values, metadata)
+		}
+		sort.Slice(values, func(i, j int) bool {
+			return values[i].less(values[j])
+		})
+
+		family := &metadatapb.MetricFamilyMetadata{
+			Name:    name,
+			Entries: make([]*metadatapb.MetricMetadataEntry, 0, len(values)),
+		}
+		for _, metadata := range values {
+			family.Entries = append(family.Entries, &metadatapb.MetricMetadataEntry{
+				Type:          metadata.Type,
+				Help:          metadata.Help,
+				Unit:          metadata.Unit,
+				LastSeenNanos: byMetadata[metadata].UnixNano(),
+			})
+		}
+		result.Families = append(result.Families, family)
+	}
+	return result
+}
+
+func unmarshalEntries(value kv.Value) (entries, error) {
+	var shard metadatapb.MetricMetadataShard
+	if err := value.Unmarshal(&shard); err != nil {
+		return nil, err
+	}
+
+	result := make(entries, len(shard.Families))
+	for _, family := range shard.Families {
+		byMetadata := make(map[Metadata]time.Time, len(family.Entries))
+		for _, entry := range family.Entries {
+			metadata := Metadata{Type: entry.Type, Help: entry.Help, Unit: entry.Unit}
+			byMetadata[metadata] = time.Unix(0, entry.LastSeenNanos)
+		}
+		result[family.Name] = byMetadata
+	}
+	return result, nil
+}
@@ -0,0 +1,230 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package metadata
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/cluster/kv/mem"
+	"github.com/m3db/m3/src/query/generated/proto/metadatapb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testClock struct {
+	sync.Mutex
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.Lock()
+	defer c.Unlock()
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.Lock()
+	defer c.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestStore(t *testing.T, clock *testClock, kvStore kv.Store) *store {
+	opts := NewOptions().
+		SetNowFn(clock.Now).
+		SetTTL(time.Hour)
+	if kvStore != nil {
+		opts = opts.SetKVStoreFn(func() (kv.Store, error) {
+			return kvStore, nil
+		})
+	}
+
+	s, err := NewStore(opts)
+	require.NoError(t, err)
+	return s.(*store)
+}
+
+func TestStoreQuery(t *testing.T) {
+	clock := &testClock{now: time.Unix(1000, 0)}
+	s := newTestStore(t, clock, nil)
+
+	up := Metadata{Type: "gauge", Help: "Whether the target is up."}
+	requests := Metadata{Type: "counter", Help: "Total requests.", Unit: "requests"}
+	s.Write("up", up)
+	s.Write("up", up)
+	s.Write("http_requests_total", requests)
+	s.Write("", up)
+
+	assert.Equal(t, map[string][]Metadata{
+		"up":                  {up},
+		"http_requests_total": {requests},
+	}, s.Query("", 0))
+	assert.Equal(t, map[string][]Metadata{
+		"up": {up},
+	}, s.Query("up", 0))
+	assert.Equal(t, map[string][]Metadata{
+		"http_requests_total": {requests},
+	}, s.Query("", 1))
+	assert.Equal(t, map[string][]Metadata{}, s.Query("missing", 0))
+
+	// Conflicting metadata for the same metric family is all returned.
+	changed := Metadata{Type: "gauge", Help: "Whether the target is reachable."}
+	s.Write("up", changed)
+	assert.Equal(t, map[string][]Metadata{
+		"up": {changed, up},
+	}, s.Query("up", 0))
+}
+
+func TestStoreExpires(t *testing.T) {
+	clock := &testClock{now: time.Unix(1000, 0)}
+	s := newTestStore(t, clock, nil)
+
+	up := Metadata{Type: "gauge"}
+	requests := Metadata{Type: "counter"}
+	s.Write("up", up)
+	clock.Advance(30 * time.Minute)
+	s.Write("http_requests_total", requests)
+	clock.Advance(45 * time.Minute)
+
+	require.NoError(t, s.flush())
+	assert.Equal(t, map[string][]Metadata{
+		"http_requests_total": {requests},
+	}, s.Query("", 0))
+}
+
+func TestStorePersistsAndMerges(t *testing.T) {
+	var (
+		clock   = &testClock{now: time.Unix(1000, 0)}
+		kvStore = mem.NewStore()
+		first   = newTestStore(t, clock, kvStore)
+		second  = newTestStore(t, clock, kvStore)
+		up      = Metadata{Type: "gauge"}
+		reqs    = Metadata{Type: "counter"}
+	)
+
+	first.Write("up", up)
+	require.NoError(t, first.flush())
+
+	second.Write("http_requests_total", reqs)
+	require.NoError(t, second.flush())
+
+	// The second store loaded the metadata persisted by the first.
+	expected := map[string][]Metadata{
+		"up":                  {up},
+		"http_requests_total": {reqs},
+	}
+	assert.Equal(t, expected, second.Query("", 0))
+
+	// The first store loads the merged metadata on its next flush.
+	require.NoError(t, first.flush())
+	assert.Equal(t, expected, first.Query("", 0))
+
+	// A new store loads the persisted metadata.
+	third := newTestStore(t, clock, kvStore)
+	require.NoError(t, third.flush())
+	assert.Equal(t, expected, third.Query("", 0))
+
+	// Persisted metadata expires too.
+	clock.Advance(2 * time.Hour)
+	fourth := newTestStore(t, clock, kvStore)
+	require.NoError(t, fourth.flush())
+	assert.Equal(t, map[string][]Metadata{}, fourth.Query("", 0))
+}
+
+func TestStoreShardsPersistedMetadata(t *testing.T) {
+	var (
+		clock   = &testClock{now: time.Unix(1000, 0)}
+		kvStore = mem.NewStore()
+		s       = newTestStore(t, clock, kvStore)
+	)
+
+	for i := 0; i < 100; i++ {
+		s.Write(fmt.Sprintf("metric_%d", i), Metadata{Type: "gauge"})
+	}
+	require.NoError(t, s.flush())
+
+	_, err := kvStore.Get(DefaultKey)
+	require.Equal(t, kv.ErrNotFound, err)
+
+	families := 0
+	for shard := 0; shard < s.numShards; shard++ {
+		value, err := kvStore.Get(s.shardKey(shard))
+		require.NoError(t, err)
+
+		var persisted metadatapb.MetricMetadataShard
+		require.NoError(t, value.Unmarshal(&persisted))
+		for _, family := range persisted.Families {
+			assert.Equal(t, shard, s.shardFor(family.Name))
+			require.Equal(t, 1, len(family.Entries))
+			assert.Equal(t, "gauge", family.Entries[0].Type)
+			assert.Equal(t, clock.Now().UnixNano(), family.Entries[0].LastSeenNanos)
+		}
+		families += len(persisted.Families)
+	}
+	assert.Equal(t, 100, families)
+
+	// Only the shard written to is updated by the next flush.
+	versions := make([]int, s.numShards)
+	for shard := range versions {
+		value, err := kvStore.Get(s.shardKey(shard))
+		require.NoError(t, err)
+		versions[shard] = value.Version()
+	}
+
+	s.Write("metric_0", Metadata{Type: "counter"})
+	require.NoError(t, s.flush())
+
+	written := s.shardFor("metric_0")
+	for shard, version := range versions {
+		value, err := kvStore.Get(s.shardKey(shard))
+		require.NoError(t, err)
+		if shard == written {
+			assert.Equal(t, version+1, value.Version())
+		} else {
+			assert.Equal(t, version, value.Version())
+		}
+	}
+}
+
+func TestStoreStartClose(t *testing.T) {
+	clock := &testClock{now: time.Unix(1000, 0)}
+	kvStore := mem.NewStore()
+	s := newTestStore(t, clock, kvStore)
+
+	require.Error(t, s.Close())
+	require.NoError(t, s.Start())
+	require.Error(t, s.Start())
+
+	s.Write("up", Metadata{Type: "gauge"})
+	require.NoError(t, s.Close())
+	require.Error(t, s.Close())
+
+	// Closing persists metadata written since the last flush.
+	loaded := newTestStore(t, clock, kvStore)
+	require.NoError(t, loaded.flush())
+	assert.Equal(t, map[string][]Metadata{
+		"up": {{Type: "gauge"}},
+	}, loaded.Query("", 0))
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package metadata provides a store for Prometheus metric metadata, such as
+// the type, help text and unit of metric families, received via remote write.
+package metadata
+
+import (
+	"github.com/m3db/m3/src/cluster/kv"
+)
+
+// Metadata is the metadata of a metric family.
+type Metadata struct {
+	Type string `json:"type"`
+	Help string `json:"help"`
+	Unit string `json:"unit"`
+}
+
+func (m Metadata) less(other Metadata) bool {
+	if m.Type != other.Type {
+		return m.Type < other.Type
+	}
+	if m.Help != other.Help {
+		return m.Help < other.Help
+	}
+	return m.Unit < other.Unit
+}
+
+// Store stores metric metadata, expiring metadata that has not been written
+// for longer than the configured TTL.
+type Store interface {
+	// Start loads any persisted metadata and starts periodically
+	// persisting metadata.
+	Start() error
+
+	// Write records the metadata for a metric family.
+	Write(metric string, metadata Metadata)
+
+	// Query returns the metadata for metric families, if metric is not empty
+	// only the metadata for that metric family is returned, and if limit is
+	// positive at most limit metric families are returned.
+	Query(metric string, limit int) map[string][]Metadata
+
+	// Close stops persisting metadata, persisting any pending metadata.
+	Close() error
+}
+
+// KVStoreFn returns the KV store to persist to, it may return an error
+// if the store is not yet available.
+type KVStoreFn func() (kv.Store, error)
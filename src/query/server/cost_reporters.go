@@ -26,6 +26,7 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/m3db/m3/src/cluster/kv/util/runtime"
 	"github.com/m3db/m3/src/cmd/services/m3query/config"
 	qcost "github.com/m3db/m3/src/query/cost"
 	"github.com/m3db/m3/src/x/close"
@@ -48,7 +49,8 @@ const (
 // newConfiguredChainedEnforcer returns a ChainedEnforcer with 3 configured
 // levels: global, per-query, per-block. Global and per-query both have limits
 // on them (as configured by cfg.Limits); per-block is purely for accounting
-// purposes.
+// purposes. When storeFn is set the global and per-query limits are runtime
+// options updated from KV, falling back to the configured limits.
 // Our enforcers report at least these stats:
 //   cost_reporter_datapoints{limit="global"}: gauge;
 //   > the number of datapoints currently in use by this instance.
@@ -63,6 +65,7 @@ const (
 //   > represents the distribution of the maximum datapoints used at any point in each query.
 func newConfiguredChainedEnforcer(
 	cfg *config.Configuration,
+	storeFn qcost.KVStoreFn,
 	instrumentOptions instrument.Options,
 ) (qcost.ChainedEnforcer, close.SimpleCloser, error) {
 	scope := instrumentOptions.MetricsScope().SubScope(costScopeName)
@@ -78,9 +81,9 @@ func newConfiguredChainedEnforcer(
 	globalLimitManagerScope := globalScope.SubScope(limitManagerScopeName)
 	globalReporterScope := globalScope.SubScope(reporterScopeName)
 
-	globalLimitMgr := cost.NewStaticLimitManager(
-		cfg.Limits.Global.AsLimitManagerOptions().
-			SetInstrumentOptions(instrumentOptions.SetMetricsScope(globalLimitManagerScope)))
+	globalLimitMgr := newLimitManager(qcost.GlobalMaxFetchedDatapointsOption,
+		cfg.Limits.Global.MaxFetchedDatapoints, cfg.Limits.Global.AsLimitManagerOptions(),
+		storeFn, instrumentOptions.SetMetricsScope(globalLimitManagerScope))
 
 	globalTracker := cost.NewTracker()
 
@@ -96,9 +99,9 @@ func newConfiguredChainedEnforcer(
 	queryLimitManagerScope := queryScope.SubScope(limitManagerScopeName)
 	queryReporterScope := queryScope.SubScope(reporterScopeName)
 
-	queryLimitMgr := cost.NewStaticLimitManager(
-		cfg.Limits.PerQuery.AsLimitManagerOptions().
-			SetInstrumentOptions(instrumentOptions.SetMetricsScope(queryLimitManagerScope)))
+	queryLimitMgr := newLimitManager(qcost.PerQueryMaxFetchedDatapointsOption,
+		cfg.Limits.PerQuery.MaxFetchedDatapoints, cfg.Limits.PerQuery.AsLimitManagerOptions(),
+		storeFn, instrumentOptions.SetMetricsScope(queryLimitManagerScope))
 
 	queryTracker := cost.NewTracker()
 
@@ -136,6 +139,21 @@ func newConfiguredChainedEnforcer(
 	return enforcer, closer, nil
 }
 
+// newLimitManager returns a static limit manager for the configured limit, or
+// one updated from the runtime option when storeFn is set.
+func newLimitManager(
+	def runtime.OptionDefinition,
+	configured int,
+	limitOpts cost.LimitManagerOptions,
+	storeFn qcost.KVStoreFn,
+	instrumentOptions instrument.Options,
+) cost.LimitManager {
+	if storeFn == nil {
+		return cost.NewStaticLimitManager(limitOpts.SetInstrumentOptions(instrumentOptions))
+	}
+	return qcost.NewRuntimeLimitManager(def, int64(configured), storeFn, instrumentOptions)
+}
+
 // globalReporter records ChainedEnforcer statistics for the global enforcer.
 type globalReporter struct {
 	datapoints        tally.Gauge
@@ -24,10 +24,15 @@ import (
 	"fmt"
 	"math"
 	"testing"
+	"time"
 
+	"github.com/m3db/m3/src/cluster/generated/proto/commonpb"
+	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/cluster/kv/mem"
 	"github.com/m3db/m3/src/cmd/services/m3query/config"
 	"github.com/m3db/m3/src/query/cost"
 	"github.com/m3db/m3/src/x/close"
+	xcost "github.com/m3db/m3/src/x/cost"
 	"github.com/m3db/m3/src/x/cost/test"
 	"github.com/m3db/m3/src/x/instrument"
 
@@ -60,7 +65,7 @@ func TestNewConfiguredChainedEnforcer(t *testing.T) {
 					MaxFetchedDatapoints: globalLimit,
 				},
 			},
-		}, iopts)
+		}, nil, iopts)
 
 		require.NoError(t, err)
 
@@ -165,6 +170,46 @@ func TestNewConfiguredChainedEnforcer(t *testing.T) {
 	})
 }
 
+func TestNewConfiguredChainedEnforcerRuntimeLimits(t *testing.T) {
+	store := mem.NewStore()
+	_, err := store.Set(cost.PerQueryMaxFetchedDatapointsKey, &commonpb.Int64Proto{Value: 3})
+	require.NoError(t, err)
+
+	globalEnforcer, closer, err := newConfiguredChainedEnforcer(&config.Configuration{
+		Limits: config.LimitsConfiguration{
+			PerQuery: config.PerQueryLimitsConfiguration{
+				MaxFetchedDatapoints: 6,
+			},
+		},
+	}, func() (kv.Store, error) {
+		return store, nil
+	}, instrument.NewOptions())
+	require.NoError(t, err)
+	defer closer.Close()
+
+	exceedsLimit := func(c float64) bool {
+		qe := globalEnforcer.Child(cost.QueryLevel)
+		defer qe.Close()
+		return qe.Add(xcost.Cost(c)).Error != nil
+	}
+
+	// The limit set in KV replaces the configured one.
+	deadline := time.Now().Add(5 * time.Second)
+	for !exceedsLimit(4) {
+		require.True(t, time.Now().Before(deadline), "limit from KV not applied")
+		time.Sleep(time.Millisecond)
+	}
+
+	// And the configured limit applies again once it is deleted.
+	_, err = store.Delete(cost.PerQueryMaxFetchedDatapointsKey)
+	require.NoError(t, err)
+	for exceedsLimit(4) {
+		require.True(t, time.Now().Before(deadline), "configured limit not applied")
+		time.Sleep(time.Millisecond)
+	}
+	require.True(t, exceedsLimit(6))
+}
+
 func setupGlobalReporter() (tally.TestScope, *globalReporter) {
 	s := tally.NewTestScope("", nil)
 	gr := newGlobalReporter(s)
@@ -41,11 +41,14 @@ import (
 	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/metrics/aggregation"
 	"github.com/m3db/m3/src/metrics/policy"
+	"github.com/m3db/m3/src/query/alerting"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
 	"github.com/m3db/m3/src/query/api/v1/httpd"
 	"github.com/m3db/m3/src/query/api/v1/options"
 	m3dbcluster "github.com/m3db/m3/src/query/cluster/m3db"
+	qcost "github.com/m3db/m3/src/query/cost"
 	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/metadata"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser/promql"
 	"github.com/m3db/m3/src/query/policy/filter"
@@ -284,8 +287,8 @@ func Run(runOpts RunOptions) {
 		backendStorage = storage.NewNoopStorage()
 		mgmt := cfg.ClusterManagement
 
-		if mgmt == nil || len(mgmt.Etcd.ETCDClusters) == 0 {
-			logger.Fatal("must specify cluster management config and at least one etcd cluster")
+		if mgmt == nil || (len(mgmt.Etcd.ETCDClusters) == 0 && mgmt.Etcd.FileStore == nil) {
+			logger.Fatal("must specify cluster management config and at least one etcd cluster or a file store")
 		}
 
 		opts := mgmt.Etcd.NewOptions()
@@ -320,8 +323,13 @@ func Run(runOpts RunOptions) {
 		logger.Fatal("unrecognized backend", zap.String("backend", string(cfg.Backend)))
 	}
 
+	var costLimitsStoreFn qcost.KVStoreFn
+	if clusterClient != nil {
+		costLimitsStoreFn = clusterClient.KV
+	}
+
 	chainedEnforcer, chainedEnforceCloser, err := newConfiguredChainedEnforcer(&cfg,
-		instrumentOptions)
+		costLimitsStoreFn, instrumentOptions)
 	if err != nil {
 		logger.Fatal("unable to setup chained enforcer", zap.Error(err))
 	}
@@ -345,7 +353,14 @@ func Run(runOpts RunOptions) {
 		logger.Fatal("unable to create new downsampler and writer", zap.Error(err))
 	}
 
-	var serviceOptionDefaults []handleroptions.ServiceOptionsDefault
+	historyLimit, err := cfg.HistoryLimitOrDefault()
+	if err != nil {
+		logger.Fatal("invalid history limit", zap.Error(err))
+	}
+
+	serviceOptionDefaults := []handleroptions.ServiceOptionsDefault{
+		handleroptions.WithDefaultHistoryLimit(historyLimit),
+	}
 	if dbCfg := runOpts.DBConfig; dbCfg != nil {
 		cluster, err := dbCfg.EnvironmentConfig.Services.SyncCluster()
 		if err != nil {
@@ -369,6 +384,48 @@ func Run(runOpts RunOptions) {
 		logger.Fatal("unable to set up handler options", zap.Error(err))
 	}
 
+	if cfg.Alerting != nil {
+		var storeFn alerting.KVStoreFn
+		if clusterClient != nil {
+			storeFn = clusterClient.KV
+		}
+
+		alertingManager, err := cfg.Alerting.NewManager(engine, tagOptions,
+			storeFn, instrumentOptions.SetMetricsScope(
+				instrumentOptions.MetricsScope().SubScope("alerting")))
+		if err != nil {
+			logger.Fatal("unable to create alerting manager", zap.Error(err))
+		}
+
+		if err := alertingManager.Start(); err != nil {
+			logger.Fatal("unable to start alerting manager", zap.Error(err))
+		}
+
+		defer alertingManager.Close()
+		handlerOptions = handlerOptions.SetAlertingManager(alertingManager)
+	}
+
+	if cfg.Metadata != nil {
+		var storeFn metadata.KVStoreFn
+		if clusterClient != nil {
+			storeFn = clusterClient.KV
+		}
+
+		metadataStore, err := cfg.Metadata.NewStore(storeFn,
+			instrumentOptions.SetMetricsScope(
+				instrumentOptions.MetricsScope().SubScope("metadata")))
+		if err != nil {
+			logger.Fatal("unable to create metadata store", zap.Error(err))
+		}
+
+		if err := metadataStore.Start(); err != nil {
+			logger.Fatal("unable to start metadata store", zap.Error(err))
+		}
+
+		defer metadataStore.Close()
+		handlerOptions = handlerOptions.SetMetadataStore(metadataStore)
+	}
+
 	if fn := runOpts.CustomHandlerOptions.OptionTransformFn; fn != nil {
 		handlerOptions = fn(handlerOptions)
 	}
@@ -838,28 +895,55 @@ func remoteZoneStorage(
 	zone config.Remote,
 	poolWrapper *pools.PoolWrapper,
 	opts tsdb.Options,
+	instrumentOpts instrument.Options,
 ) (storage.Storage, error) {
 	if len(zone.Addresses) == 0 {
 		// No addresses; skip.
 		return nil, nil
 	}
 
-	client, err := tsdbRemote.NewGRPCClient(
-		zone.Addresses,
-		poolWrapper,
-		opts,
-	)
+	// NB: hedged requests are sent to a specific alternate coordinator so
+	// need a client per address, otherwise requests are balanced across
+	// the addresses by a single client.
+	addresses := [][]string{zone.Addresses}
+	if zone.HedgeDelay > 0 && len(zone.Addresses) > 1 {
+		addresses = make([][]string, 0, len(zone.Addresses))
+		for _, address := range zone.Addresses {
+			addresses = append(addresses, []string{address})
+		}
+	}
 
-	if err != nil {
-		return nil, err
+	clients := make([]tsdbRemote.Client, 0, len(addresses))
+	for _, addrs := range addresses {
+		client, err := tsdbRemote.NewGRPCClient(
+			addrs,
+			poolWrapper,
+			opts,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		clients = append(clients, client)
 	}
 
 	remoteOpts := remote.Options{
 		Name:          zone.Name,
 		ErrorBehavior: zone.ErrorBehavior,
+		Timeout:       zone.Timeout,
+		HedgeDelay:    zone.HedgeDelay,
+		InstrumentOptions: instrumentOpts.SetMetricsScope(
+			instrumentOpts.MetricsScope().SubScope("remote-storage")),
+	}
+	if cb := zone.CircuitBreaker; cb != nil {
+		remoteOpts.CircuitBreaker = &remote.CircuitBreakerOptions{
+			FailureThreshold: cb.FailureThreshold,
+			OpenDuration:     cb.OpenDuration,
+		}
 	}
 
-	remoteStorage := remote.NewStorage(client, remoteOpts)
+	remoteStorage := remote.NewStorage(clients, remoteOpts)
 	return remoteStorage, nil
 }
 
@@ -877,9 +961,14 @@ func remoteClient(
 			"creating RPC client with remotes",
 			zap.String("name", zone.Name),
 			zap.Strings("addresses", zone.Addresses),
+			zap.String("errorBehavior", zone.ErrorBehavior.String()),
+			zap.Duration("timeout", zone.Timeout),
+			zap.Duration("hedgeDelay", zone.HedgeDelay),
+			zap.Bool("circuitBreaker", zone.CircuitBreaker != nil),
 		)
 
-		remote, err := remoteZoneStorage(zone, poolWrapper, opts)
+		remote, err := remoteZoneStorage(zone, poolWrapper, opts,
+			instrumentOpts)
 		if err != nil {
 			return nil, false, err
 		}
@@ -187,6 +187,7 @@ func TestWrite(t *testing.T) {
 	_, rulesNamespacesWatch, err := rulesNamespacesWatchable.Watch()
 	require.NoError(t, err)
 	kvClient := kv.NewMockStore(ctrl)
+	expectCostLimitWatches(t, kvClient)
 	kvClient.EXPECT().Watch(gomock.Any()).Return(rulesNamespacesWatch, nil).AnyTimes()
 	clusterClient := clusterclient.NewMockClient(ctrl)
 	clusterClient.EXPECT().KV().Return(kvClient, nil).AnyTimes()
@@ -294,6 +295,7 @@ func TestIngest(t *testing.T) {
 	_, rulesNamespacesWatch, err := rulesNamespacesWatchable.Watch()
 	require.NoError(t, err)
 	kvClient := kv.NewMockStore(ctrl)
+	expectCostLimitWatches(t, kvClient)
 	kvClient.EXPECT().Watch(gomock.Any()).Return(rulesNamespacesWatch, nil).AnyTimes()
 	clusterClient := clusterclient.NewMockClient(ctrl)
 	clusterClient.EXPECT().KV().Return(kvClient, nil).AnyTimes()
@@ -531,7 +533,7 @@ func TestNewPerQueryEnforcer(t *testing.T) {
 			},
 		}
 
-		global, closer, err := newConfiguredChainedEnforcer(cfg, instrumentOpts)
+		global, closer, err := newConfiguredChainedEnforcer(cfg, nil, instrumentOpts)
 		require.NoError(t, err)
 
 		queryLvl := global.Child(cost.QueryLevel)
@@ -684,3 +686,16 @@ func (s *queryServer) CompleteTags(
 	s.tagCompletes++
 	return nil
 }
+
+// expectCostLimitWatches sets up unset runtime cost limits so that the
+// configured limits stay in effect.
+func expectCostLimitWatches(t *testing.T, store *kv.MockStore) {
+	for _, key := range []string{
+		cost.GlobalMaxFetchedDatapointsKey,
+		cost.PerQueryMaxFetchedDatapointsKey,
+	} {
+		_, watch, err := kv.NewValueWatchable().Watch()
+		require.NoError(t, err)
+		store.EXPECT().Watch(key).Return(watch, nil).AnyTimes()
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package remote
+
+import (
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/x/clock"
+)
+
+const (
+	defaultFailureThreshold = 5
+	defaultOpenDuration     = 30 * time.Second
+)
+
+// CircuitBreakerOptions are the options for a circuit breaker that skips
+// requests to a persistently failing remote.
+type CircuitBreakerOptions struct {
+	// FailureThreshold is the number of consecutive failures after which
+	// the circuit opens, defaults to 5.
+	FailureThreshold int
+	// OpenDuration is how long the circuit stays open before a single
+	// request is let through to probe the remote, defaults to 30s.
+	OpenDuration time.Duration
+}
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+type circuitBreaker struct {
+	sync.Mutex
+
+	failureThreshold int
+	openDuration     time.Duration
+	nowFn            clock.NowFn
+
+	state    circuitState
+	failures int
+	openedAt time.Time
+}
+
+func newCircuitBreaker(opts CircuitBreakerOptions, nowFn clock.NowFn) *circuitBreaker {
+	b := &circuitBreaker{
+		failureThreshold: opts.FailureThreshold,
+		openDuration:     opts.OpenDuration,
+		nowFn:            nowFn,
+	}
+	if b.failureThreshold <= 0 {
+		b.failureThreshold = defaultFailureThreshold
+	}
+	if b.openDuration <= 0 {
+		b.openDuration = defaultOpenDuration
+	}
+	return b
+}
+
+// allow returns whether a request may be sent, once the open duration has
+// passed only a single probe request is allowed until its outcome is
+// reported.
+func (b *circuitBreaker) allow() bool {
+	b.Lock()
+	defer b.Unlock()
+
+	switch b.state {
+	case circuitClosed:
+		return true
+	case circuitOpen:
+		if b.nowFn().Sub(b.openedAt) < b.openDuration {
+			return false
+		}
+		b.state = circuitHalfOpen
+		return true
+	default:
+		return false
+	}
+}
+
+// success reports a successful request, closing the circuit.
+func (b *circuitBreaker) success() {
+	b.Lock()
+	b.state = circuitClosed
+	b.failures = 0
+	b.Unlock()
+}
+
+// failure reports a failed request and returns whether it opened the
+// circuit.
+func (b *circuitBreaker) failure() bool {
+	b.Lock()
+	defer b.Unlock()
+
+	b.failures++
+	if b.state == circuitHalfOpen || b.failures >= b.failureThreshold {
+		opened := b.state != circuitOpen
+		b.state = circuitOpen
+		b.openedAt = b.nowFn()
+		return opened
+	}
+	return false
+}
+
+// abort reports a request that neither succeeded nor failed, such as one
+// cancelled by the caller, so that a probe may be sent again.
+func (b *circuitBreaker) abort() {
+	b.Lock()
+	if b.state == circuitHalfOpen {
+		b.state = circuitOpen
+	}
+	b.Unlock()
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package remote
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	b := newCircuitBreaker(CircuitBreakerOptions{
+		FailureThreshold: 3,
+		OpenDuration:     time.Minute,
+	}, func() time.Time { return now })
+
+	// Failures below the threshold, and reset by a success, keep it closed.
+	require.True(t, b.allow())
+	assert.False(t, b.failure())
+	assert.False(t, b.failure())
+	b.success()
+	assert.False(t, b.failure())
+	assert.False(t, b.failure())
+	require.True(t, b.allow())
+
+	assert.True(t, b.failure())
+	assert.False(t, b.allow())
+
+	// Only a single probe is allowed once the open duration passed.
+	now = now.Add(time.Minute)
+	assert.True(t, b.allow())
+	assert.False(t, b.allow())
+
+	// A failed probe opens it again.
+	assert.True(t, b.failure())
+	assert.False(t, b.allow())
+
+	// An aborted probe allows another probe.
+	now = now.Add(time.Minute)
+	assert.True(t, b.allow())
+	b.abort()
+	assert.True(t, b.allow())
+
+	// A successful probe closes it.
+	b.success()
+	assert.True(t, b.allow())
+	assert.True(t, b.allow())
+}
+
+func TestCircuitBreakerDefaults(t *testing.T) {
+	b := newCircuitBreaker(CircuitBreakerOptions{}, time.Now)
+	assert.Equal(t, defaultFailureThreshold, b.failureThreshold)
+	assert.Equal(t, defaultOpenDuration, b.openDuration)
+}
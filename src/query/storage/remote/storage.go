@@ -23,11 +23,18 @@ package remote
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
+	"time"
 
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/errors"
 	"github.com/m3db/m3/src/query/remote"
 	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
 )
 
 // Options contains options for remote clients.
@@ -36,16 +43,203 @@ type Options struct {
 	ErrorBehavior storage.ErrorBehavior
 	// Name is this storage's name.
 	Name string
+	// Timeout is the deadline for each request to the remote, if zero
+	// requests are bounded only by the caller's context.
+	Timeout time.Duration
+	// HedgeDelay, if set and there is more than one client, is how long to
+	// wait for a client to respond before also sending the request to the
+	// next client, using the first successful response.
+	HedgeDelay time.Duration
+	// CircuitBreaker, if set, enables skipping requests to the remote after
+	// consecutive failures.
+	CircuitBreaker *CircuitBreakerOptions
+	// InstrumentOptions are the instrument options.
+	InstrumentOptions instrument.Options
+	// NowFn is the now function, defaults to time.Now.
+	NowFn clock.NowFn
+}
+
+type remoteStorageMetrics struct {
+	timeouts           tally.Counter
+	hedgedRequests     tally.Counter
+	hedgedWins         tally.Counter
+	circuitOpened      tally.Counter
+	circuitOpenRejects tally.Counter
+}
+
+func newRemoteStorageMetrics(scope tally.Scope) remoteStorageMetrics {
+	return remoteStorageMetrics{
+		timeouts:           scope.Counter("timeouts"),
+		hedgedRequests:     scope.Counter("hedged-requests"),
+		hedgedWins:         scope.Counter("hedged-wins"),
+		circuitOpened:      scope.Counter("circuit-opened"),
+		circuitOpenRejects: scope.Counter("circuit-open-rejects"),
+	}
 }
 
 type remoteStorage struct {
-	client remote.Client
-	opts   Options
+	clients []remote.Client
+	next    uint64
+	breaker *circuitBreaker
+	opts    Options
+	logger  *zap.Logger
+	metrics remoteStorageMetrics
+}
+
+// NewStorage creates a new remote Storage instance, requests are sent to
+// each of the clients in turn and, if hedging is enabled, hedged to the
+// next client.
+func NewStorage(clients []remote.Client, opts Options) storage.Storage {
+	if opts.InstrumentOptions == nil {
+		opts.InstrumentOptions = instrument.NewOptions()
+	}
+	if opts.NowFn == nil {
+		opts.NowFn = time.Now
+	}
+
+	scope := opts.InstrumentOptions.MetricsScope().
+		Tagged(map[string]string{"remote": opts.Name})
+	s := &remoteStorage{
+		clients: clients,
+		opts:    opts,
+		logger:  opts.InstrumentOptions.Logger(),
+		metrics: newRemoteStorageMetrics(scope),
+	}
+	if opts.CircuitBreaker != nil {
+		s.breaker = newCircuitBreaker(*opts.CircuitBreaker, opts.NowFn)
+	}
+	return s
+}
+
+type requestFn func(ctx context.Context, c remote.Client) (interface{}, error)
+
+type requestResult struct {
+	value  interface{}
+	err    error
+	hedged bool
+}
+
+// execute sends the request subject to the circuit breaker and the
+// deadline, discard is called with any successful result that is not used.
+func (s *remoteStorage) execute(
+	ctx context.Context,
+	fn requestFn,
+	discard func(interface{}),
+) (interface{}, error) {
+	if s.breaker != nil && !s.breaker.allow() {
+		s.metrics.circuitOpenRejects.Inc(1)
+		return nil, fmt.Errorf("remote %s: circuit breaker open", s.opts.Name)
+	}
+
+	reqCtx := ctx
+	if s.opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		reqCtx, cancel = context.WithTimeout(ctx, s.opts.Timeout)
+		defer cancel()
+	}
+
+	value, err := s.hedge(reqCtx, fn, discard)
+	if err != nil && ctx.Err() == nil && reqCtx.Err() == context.DeadlineExceeded {
+		s.metrics.timeouts.Inc(1)
+		err = fmt.Errorf("remote %s: timed out after %v: %v", s.opts.Name,
+			s.opts.Timeout, err)
+	}
+
+	if s.breaker == nil {
+		return value, err
+	}
+
+	switch {
+	case err == nil:
+		s.breaker.success()
+	case ctx.Err() != nil:
+		// NB: requests cancelled by the caller are not failures of the remote.
+		s.breaker.abort()
+	default:
+		if s.breaker.failure() {
+			s.metrics.circuitOpened.Inc(1)
+			s.logger.Warn("remote circuit breaker opened",
+				zap.String("remote", s.opts.Name), zap.Error(err))
+		}
+	}
+
+	return value, err
 }
 
-// NewStorage creates a new remote Storage instance.
-func NewStorage(c remote.Client, opts Options) storage.Storage {
-	return &remoteStorage{client: c, opts: opts}
+// hedge sends the request to the next client and, if it has not succeeded
+// within the hedge delay or fails, to the client after it.
+func (s *remoteStorage) hedge(
+	ctx context.Context,
+	fn requestFn,
+	discard func(interface{}),
+) (interface{}, error) {
+	var (
+		idx     = int(atomic.AddUint64(&s.next, 1) - 1)
+		primary = s.clients[idx%len(s.clients)]
+	)
+	if s.opts.HedgeDelay <= 0 || len(s.clients) < 2 {
+		return fn(ctx, primary)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan requestResult, 2)
+	send := func(c remote.Client, hedged bool) {
+		value, err := fn(ctx, c)
+		results <- requestResult{value: value, err: err, hedged: hedged}
+	}
+
+	go send(primary, false)
+	timer := time.NewTimer(s.opts.HedgeDelay)
+	defer timer.Stop()
+
+	var (
+		hedgeC      = timer.C
+		outstanding = 1
+		firstErr    error
+	)
+	sendHedge := func() {
+		hedgeC = nil
+		outstanding++
+		s.metrics.hedgedRequests.Inc(1)
+		go send(s.clients[(idx+1)%len(s.clients)], true)
+	}
+
+	for {
+		select {
+		case <-hedgeC:
+			sendHedge()
+		case r := <-results:
+			outstanding--
+			if r.err == nil {
+				if r.hedged {
+					s.metrics.hedgedWins.Inc(1)
+				}
+				if outstanding > 0 {
+					go func() {
+						if r := <-results; r.err == nil && discard != nil {
+							discard(r.value)
+						}
+					}()
+				}
+				return r.value, nil
+			}
+
+			if firstErr == nil {
+				firstErr = r.err
+			}
+			if hedgeC != nil {
+				// NB: hedge immediately if the first request fails.
+				timer.Stop()
+				sendHedge()
+				continue
+			}
+			if outstanding == 0 {
+				return nil, firstErr
+			}
+		}
+	}
 }
 
 func (s *remoteStorage) FetchProm(
@@ -53,7 +247,16 @@ func (s *remoteStorage) FetchProm(
 	query *storage.FetchQuery,
 	options *storage.FetchOptions,
 ) (storage.PromResult, error) {
-	return s.client.FetchProm(ctx, query, options)
+	result, err := s.execute(ctx, func(
+		ctx context.Context,
+		c remote.Client,
+	) (interface{}, error) {
+		return c.FetchProm(ctx, query, options)
+	}, nil)
+	if err != nil {
+		return storage.PromResult{}, err
+	}
+	return result.(storage.PromResult), nil
 }
 
 func (s *remoteStorage) FetchBlocks(
@@ -61,7 +264,20 @@ func (s *remoteStorage) FetchBlocks(
 	query *storage.FetchQuery,
 	options *storage.FetchOptions,
 ) (block.Result, error) {
-	return s.client.FetchBlocks(ctx, query, options)
+	result, err := s.execute(ctx, func(
+		ctx context.Context,
+		c remote.Client,
+	) (interface{}, error) {
+		return c.FetchBlocks(ctx, query, options)
+	}, func(result interface{}) {
+		for _, bl := range result.(block.Result).Blocks {
+			bl.Close()
+		}
+	})
+	if err != nil {
+		return block.Result{Metadata: block.NewResultMetadata()}, err
+	}
+	return result.(block.Result), nil
 }
 
 func (s *remoteStorage) SearchSeries(
@@ -69,7 +285,16 @@ func (s *remoteStorage) SearchSeries(
 	query *storage.FetchQuery,
 	options *storage.FetchOptions,
 ) (*storage.SearchResults, error) {
-	return s.client.SearchSeries(ctx, query, options)
+	result, err := s.execute(ctx, func(
+		ctx context.Context,
+		c remote.Client,
+	) (interface{}, error) {
+		return c.SearchSeries(ctx, query, options)
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return result.(*storage.SearchResults), nil
 }
 
 func (s *remoteStorage) CompleteTags(
@@ -77,7 +302,16 @@ func (s *remoteStorage) CompleteTags(
 	query *storage.CompleteTagsQuery,
 	options *storage.FetchOptions,
 ) (*storage.CompleteTagsResult, error) {
-	return s.client.CompleteTags(ctx, query, options)
+	result, err := s.execute(ctx, func(
+		ctx context.Context,
+		c remote.Client,
+	) (interface{}, error) {
+		return c.CompleteTags(ctx, query, options)
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return result.(*storage.CompleteTagsResult), nil
 }
 
 func (s *remoteStorage) Write(ctx context.Context, query *storage.WriteQuery) error {
@@ -0,0 +1,255 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package remote
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/remote"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+var errTestRemote = errors.New("remote error")
+
+// testClient is a remote client whose search results are a single metric
+// named after the client, after an optional delay.
+type testClient struct {
+	sync.Mutex
+
+	name  string
+	delay time.Duration
+	err   error
+	calls int
+}
+
+var _ remote.Client = (*testClient)(nil)
+
+func (c *testClient) respond(ctx context.Context) error {
+	c.Lock()
+	c.calls++
+	c.Unlock()
+
+	select {
+	case <-time.After(c.delay):
+		return c.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *testClient) numCalls() int {
+	c.Lock()
+	defer c.Unlock()
+	return c.calls
+}
+
+func (c *testClient) FetchProm(
+	ctx context.Context,
+	_ *storage.FetchQuery,
+	_ *storage.FetchOptions,
+) (storage.PromResult, error) {
+	return storage.PromResult{}, c.respond(ctx)
+}
+
+func (c *testClient) FetchBlocks(
+	ctx context.Context,
+	_ *storage.FetchQuery,
+	_ *storage.FetchOptions,
+) (block.Result, error) {
+	return block.Result{Metadata: block.NewResultMetadata()}, c.respond(ctx)
+}
+
+func (c *testClient) SearchSeries(
+	ctx context.Context,
+	_ *storage.FetchQuery,
+	_ *storage.FetchOptions,
+) (*storage.SearchResults, error) {
+	if err := c.respond(ctx); err != nil {
+		return nil, err
+	}
+
+	meta := block.NewResultMetadata()
+	meta.Exhaustive = false
+	meta.AddWarning(c.name, "test")
+	return &storage.SearchResults{Metadata: meta}, nil
+}
+
+func (c *testClient) CompleteTags(
+	ctx context.Context,
+	_ *storage.CompleteTagsQuery,
+	_ *storage.FetchOptions,
+) (*storage.CompleteTagsResult, error) {
+	return &storage.CompleteTagsResult{}, c.respond(ctx)
+}
+
+func (c *testClient) Close() error {
+	return nil
+}
+
+func search(t *testing.T, s storage.Storage) (string, error) {
+	result, err := s.SearchSeries(context.Background(), &storage.FetchQuery{},
+		storage.NewFetchOptions())
+	if err != nil {
+		return "", err
+	}
+
+	require.Len(t, result.Metadata.Warnings, 1)
+	return result.Metadata.Warnings[0].Name, nil
+}
+
+func TestStorageRoundRobin(t *testing.T) {
+	a, b := &testClient{name: "a"}, &testClient{name: "b"}
+	s := NewStorage([]remote.Client{a, b}, Options{Name: "test"})
+
+	for _, expected := range []string{"a", "b", "a"} {
+		name, err := search(t, s)
+		require.NoError(t, err)
+		assert.Equal(t, expected, name)
+	}
+}
+
+func TestStorageTimeout(t *testing.T) {
+	scope := tally.NewTestScope("", nil)
+	client := &testClient{name: "a", delay: time.Minute}
+	s := NewStorage([]remote.Client{client}, Options{
+		Name:              "test",
+		Timeout:           10 * time.Millisecond,
+		InstrumentOptions: instrument.NewOptions().SetMetricsScope(scope),
+	})
+
+	_, err := search(t, s)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "remote test: timed out after 10ms")
+	assert.Equal(t, int64(1),
+		scope.Snapshot().Counters()["timeouts+remote=test"].Value())
+}
+
+func TestStorageHedgeSlowPrimary(t *testing.T) {
+	scope := tally.NewTestScope("", nil)
+	slow := &testClient{name: "slow", delay: time.Minute}
+	fast := &testClient{name: "fast"}
+	s := NewStorage([]remote.Client{slow, fast}, Options{
+		Name:              "test",
+		HedgeDelay:        10 * time.Millisecond,
+		InstrumentOptions: instrument.NewOptions().SetMetricsScope(scope),
+	})
+
+	name, err := search(t, s)
+	require.NoError(t, err)
+	assert.Equal(t, "fast", name)
+
+	counters := scope.Snapshot().Counters()
+	assert.Equal(t, int64(1), counters["hedged-requests+remote=test"].Value())
+	assert.Equal(t, int64(1), counters["hedged-wins+remote=test"].Value())
+}
+
+func TestStorageHedgeFailedPrimary(t *testing.T) {
+	failing := &testClient{name: "failing", err: errTestRemote}
+	ok := &testClient{name: "ok", delay: 10 * time.Millisecond}
+	s := NewStorage([]remote.Client{failing, ok}, Options{
+		Name:       "test",
+		HedgeDelay: time.Minute,
+	})
+
+	// NB: the alternate is used as soon as the primary fails rather than
+	// after the hedge delay.
+	name, err := search(t, s)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", name)
+}
+
+func TestStorageHedgeAllFailed(t *testing.T) {
+	a := &testClient{name: "a", err: errTestRemote}
+	b := &testClient{name: "b", err: errors.New("other error")}
+	s := NewStorage([]remote.Client{a, b}, Options{
+		Name:       "test",
+		HedgeDelay: time.Millisecond,
+	})
+
+	_, err := search(t, s)
+	require.Equal(t, errTestRemote, err)
+	assert.Equal(t, 1, a.numCalls())
+	assert.Equal(t, 1, b.numCalls())
+}
+
+func TestStorageCircuitBreaker(t *testing.T) {
+	var (
+		now    = time.Now()
+		nowFn  = func() time.Time { return now }
+		scope  = tally.NewTestScope("", nil)
+		client = &testClient{name: "a", err: errTestRemote}
+	)
+	s := NewStorage([]remote.Client{client}, Options{
+		Name: "test",
+		CircuitBreaker: &CircuitBreakerOptions{
+			FailureThreshold: 2,
+			OpenDuration:     time.Minute,
+		},
+		InstrumentOptions: instrument.NewOptions().SetMetricsScope(scope),
+		NowFn:             nowFn,
+	})
+
+	for i := 0; i < 3; i++ {
+		_, err := search(t, s)
+		require.Error(t, err)
+	}
+
+	// NB: the third request is skipped without calling the remote.
+	assert.Equal(t, 2, client.numCalls())
+	counters := scope.Snapshot().Counters()
+	assert.Equal(t, int64(1), counters["circuit-opened+remote=test"].Value())
+	assert.Equal(t, int64(1), counters["circuit-open-rejects+remote=test"].Value())
+
+	// After the open duration a probe is sent, and succeeds.
+	now = now.Add(time.Minute)
+	client.err = nil
+	name, err := search(t, s)
+	require.NoError(t, err)
+	assert.Equal(t, "a", name)
+	assert.Equal(t, 3, client.numCalls())
+}
+
+func TestStorageCancelledNotFailure(t *testing.T) {
+	client := &testClient{name: "a", delay: time.Minute}
+	s := NewStorage([]remote.Client{client}, Options{
+		Name:           "test",
+		CircuitBreaker: &CircuitBreakerOptions{FailureThreshold: 1},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := s.SearchSeries(ctx, &storage.FetchQuery{}, storage.NewFetchOptions())
+	require.Error(t, err)
+
+	client.delay = 0
+	_, err = search(t, s)
+	require.NoError(t, err)
+}